@@ -21,11 +21,13 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/remote"
 )
 
 type FactoryOpts struct {
-	ProviderName string  `mapstructure:"default" json:"default" yaml:"Default"`
-	SwOpts       *SwOpts `mapstructure:"SW,omitempty" json:"SW,omitempty" yaml:"SwOpts"`
+	ProviderName string             `mapstructure:"default" json:"default" yaml:"Default"`
+	SwOpts       *SwOpts            `mapstructure:"SW,omitempty" json:"SW,omitempty" yaml:"SwOpts"`
+	RemoteOpts   *remote.RemoteOpts `mapstructure:"REMOTE,omitempty" json:"REMOTE,omitempty" yaml:"Remote"`
 }
 
 // InitFactories must be called before using factory interfaces
@@ -59,6 +61,15 @@ func InitFactories(config *FactoryOpts) error {
 			}
 		}
 
+		// Remote signer based BCCSP
+		if config.RemoteOpts != nil {
+			f := &RemoteFactory{}
+			err := initBCCSP(f, config)
+			if err != nil {
+				factoriesInitError = fmt.Errorf("Failed initializing REMOTE.BCCSP %s\n[%s]", factoriesInitError, err)
+			}
+		}
+
 		var ok bool
 		defaultBCCSP, ok = bccspMap[config.ProviderName]
 		if !ok {
@@ -75,6 +86,8 @@ func GetBCCSPFromOpts(config *FactoryOpts) (bccsp.BCCSP, error) {
 	switch config.ProviderName {
 	case "SW":
 		f = &SWFactory{}
+	case "REMOTE":
+		f = &RemoteFactory{}
 	default:
 		return nil, fmt.Errorf("Could not find BCCSP, no '%s' provider", config.ProviderName)
 	}
@@ -22,12 +22,14 @@ import (
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/pkcs11"
+	"github.com/hyperledger/fabric/bccsp/remote"
 )
 
 type FactoryOpts struct {
 	ProviderName string             `mapstructure:"default" json:"default" yaml:"Default"`
 	SwOpts       *SwOpts            `mapstructure:"SW,omitempty" json:"SW,omitempty" yaml:"SwOpts"`
 	Pkcs11Opts   *pkcs11.PKCS11Opts `mapstructure:"PKCS11,omitempty" json:"PKCS11,omitempty" yaml:"PKCS11"`
+	RemoteOpts   *remote.RemoteOpts `mapstructure:"REMOTE,omitempty" json:"REMOTE,omitempty" yaml:"Remote"`
 }
 
 // InitFactories must be called before using factory interfaces
@@ -77,6 +79,15 @@ func setFactories(config *FactoryOpts) error {
 		}
 	}
 
+	// Remote signer based BCCSP
+	if config.RemoteOpts != nil {
+		f := &RemoteFactory{}
+		err := initBCCSP(f, config)
+		if err != nil {
+			factoriesInitError = fmt.Errorf("Failed initializing REMOTE.BCCSP %s\n[%s]", factoriesInitError, err)
+		}
+	}
+
 	var ok bool
 	defaultBCCSP, ok = bccspMap[config.ProviderName]
 	if !ok {
@@ -94,6 +105,8 @@ func GetBCCSPFromOpts(config *FactoryOpts) (bccsp.BCCSP, error) {
 		f = &SWFactory{}
 	case "PKCS11":
 		f = &PKCS11Factory{}
+	case "REMOTE":
+		f = &RemoteFactory{}
 	default:
 		return nil, fmt.Errorf("Could not find BCCSP, no '%s' provider", config.ProviderName)
 	}
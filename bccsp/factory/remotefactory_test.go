@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp/remote"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteFactoryName(t *testing.T) {
+	f := &RemoteFactory{}
+	assert.Equal(t, f.Name(), RemoteBasedFactoryName)
+}
+
+func TestRemoteFactoryGetInvalidArgs(t *testing.T) {
+	f := &RemoteFactory{}
+
+	_, err := f.Get(nil)
+	assert.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{})
+	assert.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	opts := &FactoryOpts{
+		RemoteOpts: &remote.RemoteOpts{},
+	}
+	_, err = f.Get(opts)
+	assert.EqualError(t, err, "Invalid config. Address must not be empty.")
+}
+
+func TestGetBCCSPFromOptsRemote(t *testing.T) {
+	opts := &FactoryOpts{
+		ProviderName: "REMOTE",
+		RemoteOpts: &remote.RemoteOpts{
+			SecLevel:   256,
+			HashFamily: "SHA2",
+			Address:    "127.0.0.1:1",
+			Timeout:    time.Millisecond,
+		},
+	}
+	_, err := GetBCCSPFromOpts(opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Could not initialize BCCSP REMOTE")
+}
@@ -16,8 +16,10 @@ limitations under the License.
 package factory
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/sw"
@@ -49,7 +51,11 @@ func (f *SWFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
 	if swOpts.Ephemeral == true {
 		ks = sw.NewDummyKeyStore()
 	} else if swOpts.FileKeystore != nil {
-		fks, err := sw.NewFileBasedKeyStore(nil, swOpts.FileKeystore.KeyStorePath, false)
+		pwd, err := swOpts.FileKeystore.passphrase()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to initialize software key store: %s", err)
+		}
+		fks, err := sw.NewFileBasedKeyStore(pwd, swOpts.FileKeystore.KeyStorePath, false)
 		if err != nil {
 			return nil, fmt.Errorf("Failed to initialize software key store: %s", err)
 		}
@@ -77,6 +83,36 @@ type SwOpts struct {
 // Pluggable Keystores, could add JKS, P12, etc..
 type FileKeystoreOpts struct {
 	KeyStorePath string `mapstructure:"keystore" yaml:"KeyStore"`
+
+	// Passphrase protecting the private keys in the keystore. When set,
+	// new private keys are stored encrypted (PKCS#8, PBES2) and encrypted
+	// keys can be loaded. At most one of Passphrase and PassphraseFile
+	// may be set.
+	Passphrase string `mapstructure:"passphrase,omitempty" json:"passphrase,omitempty" yaml:"Passphrase,omitempty"`
+	// PassphraseFile is the path of a file whose content is the passphrase.
+	// Trailing newlines are ignored.
+	PassphraseFile string `mapstructure:"passphrasefile,omitempty" json:"passphrasefile,omitempty" yaml:"PassphraseFile,omitempty"`
+}
+
+// passphrase returns the keystore passphrase, or nil if the keystore
+// is not encrypted
+func (o *FileKeystoreOpts) passphrase() ([]byte, error) {
+	if o.Passphrase != "" && o.PassphraseFile != "" {
+		return nil, errors.New("Invalid config. Only one of Passphrase and PassphraseFile can be set.")
+	}
+	if o.PassphraseFile == "" {
+		return []byte(o.Passphrase), nil
+	}
+
+	raw, err := ioutil.ReadFile(o.PassphraseFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading passphrase file [%s]", err)
+	}
+	pwd := bytes.TrimRight(raw, "\r\n")
+	if len(pwd) == 0 {
+		return nil, fmt.Errorf("Passphrase file [%s] is empty", o.PassphraseFile)
+	}
+	return pwd, nil
 }
 
 type DummyKeystoreOpts struct{}
@@ -16,9 +16,12 @@ limitations under the License.
 package factory
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/hyperledger/fabric/bccsp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,3 +71,53 @@ func TestSWFactoryGet(t *testing.T) {
 	assert.NotNil(t, csp)
 
 }
+
+func TestSWFactoryGetEncryptedKeystore(t *testing.T) {
+	f := &SWFactory{}
+
+	ksPath, err := ioutil.TempDir("", "swfactory")
+	assert.NoError(t, err)
+	defer os.RemoveAll(ksPath)
+	passphraseFile := filepath.Join(ksPath, "passphrase")
+	assert.NoError(t, ioutil.WriteFile(passphraseFile, []byte("passwd\n"), 0600))
+
+	opts := &FactoryOpts{
+		SwOpts: &SwOpts{
+			SecLevel:   256,
+			HashFamily: "SHA2",
+			FileKeystore: &FileKeystoreOpts{
+				KeyStorePath: ksPath,
+				Passphrase:   "passwd",
+			},
+		},
+	}
+	csp, err := f.Get(opts)
+	assert.NoError(t, err)
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+
+	// the same passphrase read from a file unlocks the keystore
+	opts.SwOpts.FileKeystore.Passphrase = ""
+	opts.SwOpts.FileKeystore.PassphraseFile = passphraseFile
+	csp, err = f.Get(opts)
+	assert.NoError(t, err)
+	k2, err := csp.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.True(t, k2.Private())
+
+	opts.SwOpts.FileKeystore.Passphrase = "passwd"
+	_, err = f.Get(opts)
+	assert.EqualError(t, err, "Failed to initialize software key store: Invalid config. Only one of Passphrase and PassphraseFile can be set.")
+
+	opts.SwOpts.FileKeystore.Passphrase = ""
+	opts.SwOpts.FileKeystore.PassphraseFile = filepath.Join(ksPath, "missing")
+	_, err = f.Get(opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed reading passphrase file")
+
+	assert.NoError(t, ioutil.WriteFile(passphraseFile, []byte("\n"), 0600))
+	opts.SwOpts.FileKeystore.PassphraseFile = passphraseFile
+	_, err = f.Get(opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is empty")
+}
@@ -0,0 +1,210 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkcs11
+
+import (
+	"crypto/aes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/miekg/pkcs11"
+	"github.com/op/go-logging"
+)
+
+func (csp *impl) generateAESKey(length int, label string, ephemeral bool) (ski []byte, err error) {
+	p11lib := csp.ctx
+	session := csp.getSession()
+	defer csp.returnSession(session)
+
+	if label == "" {
+		label = fmt.Sprintf("BCSEC%s", nextIDCtr().Text(16))
+	} else if err = checkLabelUnused(p11lib, session, label); err != nil {
+		return nil, err
+	}
+
+	// The secret value might never leave the token,
+	// so the SKI cannot be derived from it
+	ski = make([]byte, sha256.Size)
+	if _, err = rand.Read(ski); err != nil {
+		return nil, fmt.Errorf("Failed generating SKI [%s]", err)
+	}
+
+	keyTemplate := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, length),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, !ephemeral),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_ENCRYPT, true),
+		pkcs11.NewAttribute(pkcs11.CKA_DECRYPT, true),
+
+		pkcs11.NewAttribute(pkcs11.CKA_ID, ski),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, !csp.extractable()),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, csp.extractable()),
+	}
+
+	key, err := p11lib.GenerateKey(session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_KEY_GEN, nil)},
+		keyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("P11: AES key generate failed [%s]\n", err)
+	}
+
+	logger.Infof("Generated new P11 AES key, SKI %x\n", ski)
+	if logger.IsEnabledFor(logging.DEBUG) {
+		listAttrs(p11lib, session, key)
+	}
+
+	return ski, nil
+}
+
+func (csp *impl) importAESKey(raw []byte, ephemeral bool) (ski []byte, err error) {
+	p11lib := csp.ctx
+	session := csp.getSession()
+	defer csp.returnSession(session)
+
+	// Same SKI as the software BCCSP would assign to this key
+	hash := sha256.Sum256(raw)
+	ski = hash[:]
+
+	keyTemplate := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, !ephemeral),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_ENCRYPT, true),
+		pkcs11.NewAttribute(pkcs11.CKA_DECRYPT, true),
+
+		pkcs11.NewAttribute(pkcs11.CKA_ID, ski),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, fmt.Sprintf("BCSEC%s", nextIDCtr().Text(16))),
+
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, !csp.extractable()),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, csp.extractable()),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE, raw),
+	}
+
+	key, err := p11lib.CreateObject(session, keyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("P11: AES key import failed [%s]\n", err)
+	}
+
+	if logger.IsEnabledFor(logging.DEBUG) {
+		listAttrs(p11lib, session, key)
+	}
+
+	return ski, nil
+}
+
+// findAESKey returns true if the token holds an AES key with the given SKI
+func (csp *impl) findAESKey(ski []byte) bool {
+	p11lib := csp.ctx
+	session := csp.getSession()
+	defer csp.returnSession(session)
+
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_AES),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, ski),
+	}
+	obj, err := findObject(p11lib, session, template)
+	return err == nil && obj != nil
+}
+
+// getSecretKeyValue returns the value of the secret key with the given SKI.
+// It fails if the key is sensitive or non-extractable.
+func (csp *impl) getSecretKeyValue(ski []byte) ([]byte, error) {
+	p11lib := csp.ctx
+	session := csp.getSession()
+	defer csp.returnSession(session)
+
+	key, err := findKeyFromSKI(p11lib, session, ski, pkcs11.CKO_SECRET_KEY)
+	if err != nil {
+		return nil, fmt.Errorf("Secret key not found [%s]", err)
+	}
+
+	attrs, err := p11lib.GetAttributeValue(session, *key, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_VALUE, nil)})
+	if err != nil {
+		return nil, fmt.Errorf("P11: get(CKA_VALUE) failed, the key may be sensitive [%s]", err)
+	}
+	if len(attrs) == 0 || len(attrs[0].Value) == 0 {
+		return nil, errors.New("P11: secret key has no value")
+	}
+
+	return attrs[0].Value, nil
+}
+
+// encryptP11AES encrypts in CBC mode with PKCS7 padding. As in the
+// software BCCSP, the random IV is prepended to the ciphertext.
+func (csp *impl) encryptP11AES(ski []byte, plaintext []byte) (ciphertext []byte, err error) {
+	p11lib := csp.ctx
+	session := csp.getSession()
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	key, err := findKeyFromSKI(p11lib, session, ski, pkcs11.CKO_SECRET_KEY)
+	if err != nil {
+		return nil, fmt.Errorf("Secret key not found [%s]\n", err)
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err = rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("Failed generating IV [%s]", err)
+	}
+
+	err = p11lib.EncryptInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_CBC_PAD, iv)}, *key)
+	if err != nil {
+		return nil, fmt.Errorf("Encrypt-initialize failed [%s]\n", err)
+	}
+
+	ct, err := p11lib.Encrypt(session, plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("P11: encrypt failed [%s]\n", err)
+	}
+
+	return append(iv, ct...), nil
+}
+
+func (csp *impl) decryptP11AES(ski []byte, ciphertext []byte) (plaintext []byte, err error) {
+	if len(ciphertext) < 2*aes.BlockSize || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, errors.New("Invalid ciphertext. It must be a multiple of the block size and contain IV and at least one block.")
+	}
+
+	p11lib := csp.ctx
+	session := csp.getSession()
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	key, err := findKeyFromSKI(p11lib, session, ski, pkcs11.CKO_SECRET_KEY)
+	if err != nil {
+		return nil, fmt.Errorf("Secret key not found [%s]\n", err)
+	}
+
+	iv := ciphertext[:aes.BlockSize]
+	err = p11lib.DecryptInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_AES_CBC_PAD, iv)}, *key)
+	if err != nil {
+		return nil, fmt.Errorf("Decrypt-initialize failed [%s]\n", err)
+	}
+
+	plaintext, err = p11lib.Decrypt(session, ciphertext[aes.BlockSize:])
+	if err != nil {
+		return nil, fmt.Errorf("P11: decrypt failed [%s]\n", err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkcs11
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/stretchr/testify/assert"
+)
+
+// newHardwareKeysBCCSP returns a BCCSP generating AES and RSA keys in the token
+func newHardwareKeysBCCSP(t *testing.T, nonExtractable bool) *impl {
+	lib, pin, label := FindPKCS11Lib()
+	opts := PKCS11Opts{
+		HashFamily:     "SHA2",
+		SecLevel:       256,
+		Library:        lib,
+		Label:          label,
+		Pin:            pin,
+		NonExtractable: nonExtractable,
+		HardwareAES:    true,
+		HardwareRSA:    true,
+	}
+	csp, err := New(opts, currentKS)
+	if err != nil {
+		t.Fatalf("Failed initializing BCCSP [%s]", err)
+	}
+	return csp.(*impl)
+}
+
+func TestP11AESKeyGenEncryptDecrypt(t *testing.T) {
+	csp := newHardwareKeysBCCSP(t, false)
+	msg := []byte("Hello World")
+
+	for _, opts := range []bccsp.KeyGenOpts{
+		&bccsp.AESKeyGenOpts{Temporary: true},
+		&bccsp.AES128KeyGenOpts{Temporary: true},
+		&bccsp.AES192KeyGenOpts{Temporary: true},
+		&bccsp.AES256KeyGenOpts{Temporary: false},
+	} {
+		k, err := csp.KeyGen(opts)
+		assert.NoError(t, err)
+		assert.IsType(t, &aesKey{}, k)
+		assert.True(t, k.Private())
+		assert.True(t, k.Symmetric())
+		_, err = k.Bytes()
+		assert.Error(t, err)
+		_, err = k.PublicKey()
+		assert.Error(t, err)
+
+		ct, err := csp.Encrypt(k, msg, &bccsp.AESCBCPKCS7ModeOpts{})
+		assert.NoError(t, err)
+		pt, err := csp.Decrypt(k, ct, bccsp.AESCBCPKCS7ModeOpts{})
+		assert.NoError(t, err)
+		assert.Equal(t, msg, pt)
+
+		// The ciphertext has the same format as the software BCCSP's
+		secret, err := csp.getSecretKeyValue(k.SKI())
+		assert.NoError(t, err)
+		pt, err = sw.AESCBCPKCS7Decrypt(secret, ct)
+		assert.NoError(t, err)
+		assert.Equal(t, msg, pt)
+
+		k2, err := csp.GetKey(k.SKI())
+		assert.NoError(t, err)
+		assert.IsType(t, &aesKey{}, k2)
+		assert.Equal(t, k.SKI(), k2.SKI())
+	}
+}
+
+func TestP11AESKeyImport(t *testing.T) {
+	csp := newHardwareKeysBCCSP(t, false)
+
+	raw, err := sw.GetRandomBytes(32)
+	assert.NoError(t, err)
+	k, err := csp.KeyImport(raw, &bccsp.AES256ImportKeyOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.IsType(t, &aesKey{}, k)
+	expectedSKI := sha256.Sum256(raw)
+	assert.Equal(t, expectedSKI[:], k.SKI())
+
+	msg := []byte("Hello World")
+	ct, err := sw.AESCBCPKCS7Encrypt(raw, msg)
+	assert.NoError(t, err)
+	pt, err := csp.Decrypt(k, ct, &bccsp.AESCBCPKCS7ModeOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, msg, pt)
+
+	_, err = csp.KeyImport(raw[:16], &bccsp.AES256ImportKeyOpts{Temporary: true})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid Key Length")
+	_, err = csp.KeyImport("not bytes", &bccsp.AES256ImportKeyOpts{Temporary: true})
+	assert.Error(t, err)
+
+	csp.noPrivImport = true
+	_, err = csp.KeyImport(raw, &bccsp.AES256ImportKeyOpts{Temporary: true})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Cannot import")
+}
+
+func TestP11AESKeyDeriv(t *testing.T) {
+	csp := newHardwareKeysBCCSP(t, false)
+
+	k, err := csp.KeyGen(&bccsp.AESKeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+
+	dk, err := csp.KeyDeriv(k, &bccsp.HMACTruncated256AESDeriveKeyOpts{Temporary: true, Arg: []byte{1}})
+	assert.NoError(t, err)
+	assert.True(t, dk.Symmetric())
+
+	_, err = csp.KeyDeriv(k, nil)
+	assert.Error(t, err)
+	_, err = csp.KeyDeriv(k, &bccsp.ECDSAReRandKeyOpts{Temporary: true})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Unrecognized KeyDerivOpts provided")
+}
+
+func TestP11AESNonExtractable(t *testing.T) {
+	csp := newHardwareKeysBCCSP(t, true)
+
+	k, err := csp.KeyGen(&bccsp.AESKeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+
+	// The key is usable, but its value never leaves the token
+	msg := []byte("Hello World")
+	ct, err := csp.Encrypt(k, msg, &bccsp.AESCBCPKCS7ModeOpts{})
+	assert.NoError(t, err)
+	pt, err := csp.Decrypt(k, ct, &bccsp.AESCBCPKCS7ModeOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, msg, pt)
+
+	_, err = csp.getSecretKeyValue(k.SKI())
+	assert.Error(t, err)
+	_, err = csp.KeyDeriv(k, &bccsp.HMACDeriveKeyOpts{Temporary: true, Arg: []byte{1}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Could not obtain AES secret value")
+}
+
+func TestP11AESBadPaths(t *testing.T) {
+	csp := newHardwareKeysBCCSP(t, false)
+
+	k, err := csp.KeyGen(&bccsp.AESKeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+
+	_, err = csp.Encrypt(nil, []byte("Hello World"), &bccsp.AESCBCPKCS7ModeOpts{})
+	assert.Error(t, err)
+	_, err = csp.Decrypt(nil, []byte("Hello World"), &bccsp.AESCBCPKCS7ModeOpts{})
+	assert.Error(t, err)
+
+	_, err = csp.Encrypt(k, []byte("Hello World"), nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Mode not recognized")
+	_, err = csp.Decrypt(k, make([]byte, 32), nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Mode not recognized")
+
+	_, err = csp.Decrypt(k, make([]byte, 17), &bccsp.AESCBCPKCS7ModeOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid ciphertext")
+
+	_, err = csp.Encrypt(&aesKey{[]byte{1, 2, 3}}, []byte("Hello World"), &bccsp.AESCBCPKCS7ModeOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Secret key not found")
+}
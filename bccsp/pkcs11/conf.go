@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"hash"
 
+	"github.com/hyperledger/fabric/bccsp"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -97,6 +98,29 @@ type PKCS11Opts struct {
 	Pin        string `mapstructure:"pin" json:"pin"`
 	Sensitive  bool   `mapstructure:"sensitivekeys,omitempty" json:"sensitivekeys,omitempty"`
 	SoftVerify bool   `mapstructure:"softwareverify,omitempty" json:"softwareverify,omitempty"`
+
+	// NonExtractable marks the private and secret keys generated in the
+	// token as sensitive and non-extractable. Unlike Sensitive, it does not
+	// forbid key imports.
+	NonExtractable bool `mapstructure:"nonextractablekeys,omitempty" json:"nonextractablekeys,omitempty"`
+
+	// HardwareAES and HardwareRSA generate AES and RSA keys in the token and
+	// perform encryption and signing with them there, instead of falling
+	// back to the software BCCSP
+	HardwareAES bool `mapstructure:"hardwareaes,omitempty" json:"hardwareaes,omitempty"`
+	HardwareRSA bool `mapstructure:"hardwarersa,omitempty" json:"hardwarersa,omitempty"`
+
+	// SessionCacheSize is the number of idle sessions kept open for reuse.
+	// Defaults to 10.
+	SessionCacheSize int `mapstructure:"sessioncachesize,omitempty" json:"sessioncachesize,omitempty"`
+}
+
+// LabeledKeyGenOpts wraps key generation options to set the CKA_LABEL
+// of the keys generated in the token. The key can then be retrieved
+// with GetKey([]byte(label)).
+type LabeledKeyGenOpts struct {
+	bccsp.KeyGenOpts
+	Label string
 }
 
 // Since currently only ECDSA operations go to PKCS11, need a keystore still
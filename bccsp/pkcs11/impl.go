@@ -16,7 +16,9 @@ limitations under the License.
 package pkcs11
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -67,8 +69,25 @@ func New(opts PKCS11Opts, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
 			lib, label, err)
 	}
 
-	sessions := make(chan pkcs11.SessionHandle, sessionCacheSize)
-	csp := &impl{swCSP, conf, keyStore, ctx, sessions, slot, lib, opts.Sensitive, opts.SoftVerify}
+	cacheSize := opts.SessionCacheSize
+	if cacheSize <= 0 {
+		cacheSize = sessionCacheSize
+	}
+	sessions := make(chan pkcs11.SessionHandle, cacheSize)
+	csp := &impl{
+		BCCSP:          swCSP,
+		conf:           conf,
+		ks:             keyStore,
+		ctx:            ctx,
+		sessions:       sessions,
+		slot:           slot,
+		lib:            lib,
+		noPrivImport:   opts.Sensitive,
+		softVerify:     opts.SoftVerify,
+		nonExtractable: opts.NonExtractable,
+		hardwareAES:    opts.HardwareAES,
+		hardwareRSA:    opts.HardwareRSA,
+	}
 	csp.returnSession(*session)
 	return csp, nil
 }
@@ -83,9 +102,18 @@ type impl struct {
 	sessions chan pkcs11.SessionHandle
 	slot     uint
 
-	lib          string
-	noPrivImport bool
-	softVerify   bool
+	lib            string
+	noPrivImport   bool
+	softVerify     bool
+	nonExtractable bool
+	hardwareAES    bool
+	hardwareRSA    bool
+}
+
+// extractable returns true if the private and secret keys generated
+// in the token can be read back
+func (csp *impl) extractable() bool {
+	return !csp.noPrivImport && !csp.nonExtractable
 }
 
 // KeyGen generates a key using opts.
@@ -95,17 +123,29 @@ func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
 		return nil, errors.New("Invalid Opts parameter. It must not be nil.")
 	}
 
+	label := ""
+	if labeledOpts, ok := opts.(*LabeledKeyGenOpts); ok {
+		if labeledOpts.KeyGenOpts == nil {
+			return nil, errors.New("Invalid Opts parameter. The wrapped KeyGenOpts must not be nil.")
+		}
+		if labeledOpts.Label == "" {
+			return nil, errors.New("Invalid Opts parameter. The label must not be empty.")
+		}
+		label = labeledOpts.Label
+		opts = labeledOpts.KeyGenOpts
+	}
+
 	// Parse algorithm
 	switch opts.(type) {
 	case *bccsp.ECDSAKeyGenOpts:
-		ski, pub, err := csp.generateECKey(csp.conf.ellipticCurve, opts.Ephemeral())
+		ski, pub, err := csp.generateECKey(csp.conf.ellipticCurve, label, opts.Ephemeral())
 		if err != nil {
 			return nil, fmt.Errorf("Failed generating ECDSA key [%s]", err)
 		}
 		k = &ecdsaPrivateKey{ski, ecdsaPublicKey{ski, pub}}
 
 	case *bccsp.ECDSAP256KeyGenOpts:
-		ski, pub, err := csp.generateECKey(oidNamedCurveP256, opts.Ephemeral())
+		ski, pub, err := csp.generateECKey(oidNamedCurveP256, label, opts.Ephemeral())
 		if err != nil {
 			return nil, fmt.Errorf("Failed generating ECDSA P256 key [%s]", err)
 		}
@@ -113,14 +153,76 @@ func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
 		k = &ecdsaPrivateKey{ski, ecdsaPublicKey{ski, pub}}
 
 	case *bccsp.ECDSAP384KeyGenOpts:
-		ski, pub, err := csp.generateECKey(oidNamedCurveP384, opts.Ephemeral())
+		ski, pub, err := csp.generateECKey(oidNamedCurveP384, label, opts.Ephemeral())
 		if err != nil {
 			return nil, fmt.Errorf("Failed generating ECDSA P384 key [%s]", err)
 		}
 
 		k = &ecdsaPrivateKey{ski, ecdsaPublicKey{ski, pub}}
 
+	case *bccsp.AESKeyGenOpts, *bccsp.AES256KeyGenOpts, *bccsp.AES192KeyGenOpts, *bccsp.AES128KeyGenOpts:
+		if !csp.hardwareAES {
+			if label != "" {
+				return nil, errors.New("Labels are supported only for keys generated in the token. Enable 'hardwareaes' in pkcs11 options.")
+			}
+			return csp.BCCSP.KeyGen(opts)
+		}
+
+		var length int
+		switch opts.(type) {
+		case *bccsp.AESKeyGenOpts:
+			length = csp.conf.aesBitLength
+		case *bccsp.AES256KeyGenOpts:
+			length = 32
+		case *bccsp.AES192KeyGenOpts:
+			length = 24
+		case *bccsp.AES128KeyGenOpts:
+			length = 16
+		}
+
+		ski, err := csp.generateAESKey(length, label, opts.Ephemeral())
+		if err != nil {
+			return nil, fmt.Errorf("Failed generating AES %d key [%s]", length*8, err)
+		}
+
+		k = &aesKey{ski}
+
+	case *bccsp.RSAKeyGenOpts, *bccsp.RSA1024KeyGenOpts, *bccsp.RSA2048KeyGenOpts, *bccsp.RSA3072KeyGenOpts, *bccsp.RSA4096KeyGenOpts:
+		if !csp.hardwareRSA {
+			if label != "" {
+				return nil, errors.New("Labels are supported only for keys generated in the token. Enable 'hardwarersa' in pkcs11 options.")
+			}
+			return csp.BCCSP.KeyGen(opts)
+		}
+
+		var bits int
+		switch opts.(type) {
+		case *bccsp.RSAKeyGenOpts:
+			bits = csp.conf.rsaBitLength
+		case *bccsp.RSA1024KeyGenOpts:
+			bits = 1024
+		case *bccsp.RSA2048KeyGenOpts:
+			bits = 2048
+		case *bccsp.RSA3072KeyGenOpts:
+			bits = 3072
+		case *bccsp.RSA4096KeyGenOpts:
+			bits = 4096
+		}
+
+		ski, pub, err := csp.generateRSAKey(bits, label, opts.Ephemeral())
+		if err != nil {
+			return nil, fmt.Errorf("Failed generating RSA %d key [%s]", bits, err)
+		}
+
+		k, err = csp.newRSAPrivateKey(ski, pub)
+		if err != nil {
+			return nil, err
+		}
+
 	default:
+		if label != "" {
+			return nil, fmt.Errorf("Labels are not supported for [%s] keys", opts.Algorithm())
+		}
 		return csp.BCCSP.KeyGen(opts)
 	}
 
@@ -267,6 +369,30 @@ func (csp *impl) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOpts) (dk bccsp.Key, e
 
 		}
 
+	case *aesKey:
+		// HMAC based derivations are performed in software
+		// over the secret value, if the token allows to extract it
+		if opts == nil {
+			return nil, errors.New("Invalid Opts parameter. It must not be nil.")
+		}
+
+		switch opts.(type) {
+		case *bccsp.HMACTruncated256AESDeriveKeyOpts, *bccsp.HMACDeriveKeyOpts:
+			secret, err := csp.getSecretKeyValue(k.SKI())
+			if err != nil {
+				return nil, fmt.Errorf("Could not obtain AES secret value [%s]", err)
+			}
+
+			swKey, err := csp.BCCSP.KeyImport(secret, &bccsp.HMACImportKeyOpts{Temporary: true})
+			if err != nil {
+				return nil, fmt.Errorf("Failed importing AES secret value [%s]", err)
+			}
+			return csp.BCCSP.KeyDeriv(swKey, opts)
+
+		default:
+			return nil, fmt.Errorf("Unrecognized KeyDerivOpts provided [%s]", opts.Algorithm())
+		}
+
 	default:
 		return csp.BCCSP.KeyDeriv(k, opts)
 
@@ -407,6 +533,31 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 		k = &ecdsaPublicKey{ski, lowLevelKey}
 		return k, nil
 
+	case *bccsp.AES256ImportKeyOpts:
+		if !csp.hardwareAES {
+			return csp.BCCSP.KeyImport(raw, opts)
+		}
+
+		if csp.noPrivImport {
+			return nil, errors.New("[AES256ImportKeyOpts] PKCS11 options 'sensitivekeys' is set to true. Cannot import.")
+		}
+
+		aesRaw, ok := raw.([]byte)
+		if !ok {
+			return nil, errors.New("[AES256ImportKeyOpts] Invalid raw material. Expected byte array.")
+		}
+
+		if len(aesRaw) != 32 {
+			return nil, fmt.Errorf("[AES256ImportKeyOpts] Invalid Key Length [%d]. Must be 32 bytes", len(aesRaw))
+		}
+
+		ski, err := csp.importAESKey(aesRaw, opts.Ephemeral())
+		if err != nil {
+			return nil, fmt.Errorf("Failed importing AES key [%s]", err)
+		}
+
+		return &aesKey{ski}, nil
+
 	case *bccsp.X509PublicKeyImportOpts:
 		x509Cert, ok := raw.(*x509.Certificate)
 		if !ok {
@@ -420,8 +571,10 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 			return csp.KeyImport(pk, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: opts.Ephemeral()})
 		case *rsa.PublicKey:
 			return csp.KeyImport(pk, &bccsp.RSAGoPublicKeyImportOpts{Temporary: opts.Ephemeral()})
+		case ed25519.PublicKey:
+			return csp.KeyImport(pk, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: opts.Ephemeral()})
 		default:
-			return nil, errors.New("Certificate's public key type not recognized. Supported keys: [ECDSA, RSA]")
+			return nil, errors.New("Certificate's public key type not recognized. Supported keys: [ECDSA, RSA, ED25519]")
 		}
 
 	default:
@@ -432,16 +585,47 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 
 // GetKey returns the key this CSP associates to
 // the Subject Key Identifier ski.
+// Keys stored in the token can also be retrieved by
+// passing their label (CKA_LABEL) in place of the SKI.
 func (csp *impl) GetKey(ski []byte) (k bccsp.Key, err error) {
+	k, err = csp.getP11Key(ski)
+	if err == nil {
+		return k, nil
+	}
+
+	labeledSKI, err := csp.findSKIFromLabel(string(ski))
+	if err == nil && !bytes.Equal(labeledSKI, ski) {
+		k, err = csp.getP11Key(labeledSKI)
+		if err == nil {
+			return k, nil
+		}
+	}
+
+	return csp.BCCSP.GetKey(ski)
+}
+
+// getP11Key looks for an EC, RSA or AES key in the token, by SKI
+func (csp *impl) getP11Key(ski []byte) (bccsp.Key, error) {
 	pubKey, isPriv, err := csp.getECKey(ski)
 	if err == nil {
 		if isPriv {
 			return &ecdsaPrivateKey{ski, ecdsaPublicKey{ski, pubKey}}, nil
-		} else {
-			return &ecdsaPublicKey{ski, pubKey}, nil
 		}
+		return &ecdsaPublicKey{ski, pubKey}, nil
 	}
-	return csp.BCCSP.GetKey(ski)
+
+	if csp.findAESKey(ski) {
+		return &aesKey{ski}, nil
+	}
+
+	rsaPubKey, isPriv, err := csp.getRSAKey(ski)
+	if err != nil {
+		return nil, err
+	}
+	if isPriv {
+		return csp.newRSAPrivateKey(ski, rsaPubKey)
+	}
+	return csp.BCCSP.KeyImport(rsaPubKey, &bccsp.RSAGoPublicKeyImportOpts{Temporary: true})
 }
 
 // Sign signs digest using key k.
@@ -463,6 +647,8 @@ func (csp *impl) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signat
 	switch k.(type) {
 	case *ecdsaPrivateKey:
 		return csp.signECDSA(*k.(*ecdsaPrivateKey), digest, opts)
+	case *rsaPrivateKey:
+		return csp.signRSA(*k.(*rsaPrivateKey), digest, opts)
 	default:
 		return csp.BCCSP.Sign(k, digest, opts)
 	}
@@ -487,6 +673,9 @@ func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.Signer
 		return csp.verifyECDSA(k.(*ecdsaPrivateKey).pub, signature, digest, opts)
 	case *ecdsaPublicKey:
 		return csp.verifyECDSA(*k.(*ecdsaPublicKey), signature, digest, opts)
+	case *rsaPrivateKey:
+		// RSA verification only needs the public key, do it in software
+		return csp.BCCSP.Verify(k.(*rsaPrivateKey).pub, signature, digest, opts)
 	default:
 		return csp.BCCSP.Verify(k, signature, digest, opts)
 	}
@@ -495,14 +684,43 @@ func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.Signer
 // Encrypt encrypts plaintext using key k.
 // The opts argument should be appropriate for the primitive used.
 func (csp *impl) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) (ciphertext []byte, err error) {
-	// TODO: Add PKCS11 support for encryption, when fabric starts requiring it
-	return csp.BCCSP.Encrypt(k, plaintext, opts)
+	// Validate arguments
+	if k == nil {
+		return nil, errors.New("Invalid Key. It must not be nil.")
+	}
+
+	switch k.(type) {
+	case *aesKey:
+		switch opts.(type) {
+		case *bccsp.AESCBCPKCS7ModeOpts, bccsp.AESCBCPKCS7ModeOpts:
+			return csp.encryptP11AES(k.SKI(), plaintext)
+		default:
+			return nil, fmt.Errorf("Mode not recognized [%s]", opts)
+		}
+	default:
+		return csp.BCCSP.Encrypt(k, plaintext, opts)
+	}
 }
 
 // Decrypt decrypts ciphertext using key k.
 // The opts argument should be appropriate for the primitive used.
 func (csp *impl) Decrypt(k bccsp.Key, ciphertext []byte, opts bccsp.DecrypterOpts) (plaintext []byte, err error) {
-	return csp.BCCSP.Decrypt(k, ciphertext, opts)
+	// Validate arguments
+	if k == nil {
+		return nil, errors.New("Invalid Key. It must not be nil.")
+	}
+
+	switch k.(type) {
+	case *aesKey:
+		switch opts.(type) {
+		case *bccsp.AESCBCPKCS7ModeOpts, bccsp.AESCBCPKCS7ModeOpts:
+			return csp.decryptP11AES(k.SKI(), ciphertext)
+		default:
+			return nil, fmt.Errorf("Mode not recognized [%s]", opts)
+		}
+	default:
+		return csp.BCCSP.Decrypt(k, ciphertext, opts)
+	}
 }
 
 // THIS IS ONLY USED FOR TESTING
@@ -21,6 +21,7 @@ import (
 	"crypto/sha256"
 	"encoding/asn1"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
@@ -126,6 +127,22 @@ func (csp *impl) returnSession(session pkcs11.SessionHandle) {
 	}
 }
 
+// handleSessionReturn returns the session to the cache, unless the
+// operation performed with it failed and the session is no longer
+// usable (e.g. it was closed by the device or the token was removed).
+// Such a session would otherwise fail every subsequent operation
+// picking it from the cache.
+func (csp *impl) handleSessionReturn(err error, session pkcs11.SessionHandle) {
+	if err != nil {
+		if _, infoErr := csp.ctx.GetSessionInfo(session); infoErr != nil {
+			logger.Warningf("Discarding invalid pkcs11 session %+v [%s]\n", session, infoErr)
+			csp.ctx.CloseSession(session)
+			return
+		}
+	}
+	csp.returnSession(session)
+}
+
 // Look for an EC key by SKI, stored in CKA_ID
 // This function can probably be addapted for both EC and RSA keys.
 func (csp *impl) getECKey(ski []byte) (pubKey *ecdsa.PublicKey, isPriv bool, err error) {
@@ -219,14 +236,15 @@ func oidFromNamedCurve(curve elliptic.Curve) (asn1.ObjectIdentifier, bool) {
 	return nil, false
 }
 
-func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski []byte, pubKey *ecdsa.PublicKey, err error) {
+func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, label string, ephemeral bool) (ski []byte, pubKey *ecdsa.PublicKey, err error) {
 	p11lib := csp.ctx
 	session := csp.getSession()
 	defer csp.returnSession(session)
 
-	id := nextIDCtr()
-	publabel := fmt.Sprintf("BCPUB%s", id.Text(16))
-	prvlabel := fmt.Sprintf("BCPRV%s", id.Text(16))
+	publabel, prvlabel, err := keyPairLabels(p11lib, session, label)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	marshaledOID, err := asn1.Marshal(curve)
 	if err != nil {
@@ -255,7 +273,10 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 		pkcs11.NewAttribute(pkcs11.CKA_ID, prvlabel),
 		pkcs11.NewAttribute(pkcs11.CKA_LABEL, prvlabel),
 
-		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, !csp.noPrivImport),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, csp.extractable()),
+	}
+	if csp.nonExtractable {
+		prvkey_t = append(prvkey_t, pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true))
 	}
 
 	pub, prv, err := p11lib.GenerateKeyPair(session,
@@ -308,7 +329,7 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 func (csp *impl) signP11ECDSA(ski []byte, msg []byte) (R, S *big.Int, err error) {
 	p11lib := csp.ctx
 	session := csp.getSession()
-	defer csp.returnSession(session)
+	defer func() { csp.handleSessionReturn(err, session) }()
 
 	privateKey, err := findKeyPairFromSKI(p11lib, session, ski, privateKeyFlag)
 	if err != nil {
@@ -338,7 +359,7 @@ func (csp *impl) signP11ECDSA(ski []byte, msg []byte) (R, S *big.Int, err error)
 func (csp *impl) verifyP11ECDSA(ski []byte, msg []byte, R, S *big.Int, byteSize int) (valid bool, err error) {
 	p11lib := csp.ctx
 	session := csp.getSession()
-	defer csp.returnSession(session)
+	defer func() { csp.handleSessionReturn(err, session) }()
 
 	logger.Debugf("Verify ECDSA\n")
 
@@ -453,10 +474,30 @@ func findKeyPairFromSKI(mod *pkcs11.Ctx, session pkcs11.SessionHandle, ski []byt
 		ktype = pkcs11.CKO_PRIVATE_KEY
 	}
 
+	return findKeyFromSKI(mod, session, ski, ktype)
+}
+
+// findKeyFromSKI looks for a key of the given class (CKA_CLASS) by SKI, stored in CKA_ID
+func findKeyFromSKI(mod *pkcs11.Ctx, session pkcs11.SessionHandle, ski []byte, class uint) (*pkcs11.ObjectHandle, error) {
 	template := []*pkcs11.Attribute{
-		pkcs11.NewAttribute(pkcs11.CKA_CLASS, ktype),
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
 		pkcs11.NewAttribute(pkcs11.CKA_ID, ski),
 	}
+
+	obj, err := findObject(mod, session, template)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("Key not found [%s]", hex.Dump(ski))
+	}
+
+	return obj, nil
+}
+
+// findObject returns the first object matching the template,
+// or nil if there is none
+func findObject(mod *pkcs11.Ctx, session pkcs11.SessionHandle, template []*pkcs11.Attribute) (*pkcs11.ObjectHandle, error) {
 	if err := mod.FindObjectsInit(session, template); err != nil {
 		return nil, err
 	}
@@ -464,6 +505,7 @@ func findKeyPairFromSKI(mod *pkcs11.Ctx, session pkcs11.SessionHandle, ski []byt
 	// single session instance, assume one hit only
 	objs, _, err := mod.FindObjects(session, 1)
 	if err != nil {
+		mod.FindObjectsFinal(session)
 		return nil, err
 	}
 	if err = mod.FindObjectsFinal(session); err != nil {
@@ -471,12 +513,67 @@ func findKeyPairFromSKI(mod *pkcs11.Ctx, session pkcs11.SessionHandle, ski []byt
 	}
 
 	if len(objs) == 0 {
-		return nil, fmt.Errorf("Key not found [%s]", hex.Dump(ski))
+		return nil, nil
 	}
 
 	return &objs[0], nil
 }
 
+// findSKIFromLabel returns the SKI (CKA_ID) of the key with the given label
+func (csp *impl) findSKIFromLabel(label string) ([]byte, error) {
+	if label == "" {
+		return nil, errors.New("Invalid label. It must not be empty.")
+	}
+
+	p11lib := csp.ctx
+	session := csp.getSession()
+	defer csp.returnSession(session)
+
+	obj, err := findObject(p11lib, session, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_LABEL, label)})
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("Key not found with label [%s]", label)
+	}
+
+	attrs, err := p11lib.GetAttributeValue(session, *obj, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_ID, nil)})
+	if err != nil {
+		return nil, fmt.Errorf("P11: get(CKA_ID) failed [%s]", err)
+	}
+	if len(attrs) == 0 || len(attrs[0].Value) == 0 {
+		return nil, fmt.Errorf("Key with label [%s] has no CKA_ID", label)
+	}
+
+	return attrs[0].Value, nil
+}
+
+// keyPairLabels returns the labels for a new public/private key pair.
+// If a label is requested, both keys get it and no other key in the
+// token may already have it, so that lookups by label are unambiguous.
+func keyPairLabels(mod *pkcs11.Ctx, session pkcs11.SessionHandle, label string) (publabel, prvlabel string, err error) {
+	if label == "" {
+		id := nextIDCtr()
+		return fmt.Sprintf("BCPUB%s", id.Text(16)), fmt.Sprintf("BCPRV%s", id.Text(16)), nil
+	}
+
+	if err = checkLabelUnused(mod, session, label); err != nil {
+		return "", "", err
+	}
+	return label, label, nil
+}
+
+func checkLabelUnused(mod *pkcs11.Ctx, session pkcs11.SessionHandle, label string) error {
+	obj, err := findObject(mod, session, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_LABEL, label)})
+	if err != nil {
+		return fmt.Errorf("Failed looking up label [%s] [%s]", label, err)
+	}
+	if obj != nil {
+		return fmt.Errorf("A key with label [%s] already exists", label)
+	}
+	return nil
+}
+
 // Fairly straightforward EC-point query, other than opencryptoki
 // mis-reporting length, including the 04 Tag of the field following
 // the SPKI in EP11-returned MACed publickeys:
@@ -591,6 +688,10 @@ func (csp *impl) getSecretValue(ski []byte) []byte {
 	defer csp.returnSession(session)
 
 	keyHandle, err := findKeyPairFromSKI(p11lib, session, ski, privateKeyFlag)
+	if err != nil {
+		logger.Warningf("P11: private key not found [%s]\n", err)
+		return nil
+	}
 
 	var privKey []byte
 	template := []*pkcs11.Attribute{
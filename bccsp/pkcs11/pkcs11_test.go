@@ -21,6 +21,7 @@ import (
 	"crypto/rand"
 	"encoding/asn1"
 	"encoding/hex"
+	"errors"
 	"testing"
 
 	"github.com/hyperledger/fabric/bccsp"
@@ -163,7 +164,7 @@ func TestPKCS11ECKeySignVerify(t *testing.T) {
 		oid = oidNamedCurveP384
 	}
 
-	key, pubKey, err := currentBCCSP.(*impl).generateECKey(oid, true)
+	key, pubKey, err := currentBCCSP.(*impl).generateECKey(oid, "", true)
 	if err != nil {
 		t.Fatalf("Failed generating Key [%s]", err)
 	}
@@ -289,7 +290,7 @@ func TestPKCS11ECKeyExport(t *testing.T) {
 		oid = oidNamedCurveP384
 	}
 
-	key, pubKey, err := currentBCCSP.(*impl).generateECKey(oid, false)
+	key, pubKey, err := currentBCCSP.(*impl).generateECKey(oid, "", false)
 	if err != nil {
 		t.Fatalf("Failed generating Key [%s]", err)
 	}
@@ -348,3 +349,143 @@ func TestPKCS11ECKeyExport(t *testing.T) {
 		t.Fatal("Signature should not match with software verification!")
 	}
 }
+
+func TestPKCS11GetKeyByLabel(t *testing.T) {
+	csp := newHardwareKeysBCCSP(t, false)
+	prefix := hex.EncodeToString(nextIDCtr().Bytes())
+
+	for name, opts := range map[string]bccsp.KeyGenOpts{
+		"ecdsa": &bccsp.ECDSAP256KeyGenOpts{Temporary: true},
+		"aes":   &bccsp.AESKeyGenOpts{Temporary: true},
+		"rsa":   &bccsp.RSA1024KeyGenOpts{Temporary: true},
+	} {
+		label := "label-" + name + "-" + prefix
+		k, err := csp.KeyGen(&LabeledKeyGenOpts{KeyGenOpts: opts, Label: label})
+		assert.NoError(t, err)
+
+		k2, err := csp.GetKey([]byte(label))
+		assert.NoError(t, err)
+		assert.Equal(t, k.SKI(), k2.SKI())
+		assert.IsType(t, k, k2)
+
+		// Labels are unique
+		_, err = csp.KeyGen(&LabeledKeyGenOpts{KeyGenOpts: opts, Label: label})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+	}
+
+	_, err := csp.KeyGen(&LabeledKeyGenOpts{KeyGenOpts: &bccsp.ECDSAKeyGenOpts{}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "The label must not be empty")
+	_, err = csp.KeyGen(&LabeledKeyGenOpts{Label: "label"})
+	assert.Error(t, err)
+	_, err = csp.KeyGen(&LabeledKeyGenOpts{KeyGenOpts: &bccsp.HMACDeriveKeyOpts{}, Label: "label"})
+	assert.Error(t, err)
+
+	_, err = csp.GetKey([]byte("no-such-label-" + prefix))
+	assert.Error(t, err)
+
+	// Keys falling back to the software BCCSP cannot be labeled
+	csp.hardwareAES = false
+	_, err = csp.KeyGen(&LabeledKeyGenOpts{KeyGenOpts: &bccsp.AESKeyGenOpts{}, Label: "sw-" + prefix})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Labels are supported only for keys generated in the token")
+}
+
+func TestPKCS11NonExtractableECKey(t *testing.T) {
+	csp := newHardwareKeysBCCSP(t, true)
+
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Nil(t, csp.getSecretValue(k.SKI()))
+
+	digest := make([]byte, 32)
+	sig, err := csp.Sign(k, digest, nil)
+	assert.NoError(t, err)
+	valid, err := csp.Verify(k, sig, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestPKCS11ConcurrentSign(t *testing.T) {
+	csp := currentBCCSP.(*impl)
+	// Not a session object, as the session creating it
+	// might be closed when returned to a full cache
+	k, err := csp.KeyGen(&bccsp.ECDSAKeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+
+	digest, err := csp.Hash([]byte("Hello World"), &bccsp.SHAOpts{})
+	assert.NoError(t, err)
+
+	const workers = 20
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for j := 0; j < 10; j++ {
+				sig, err := csp.Sign(k, digest, nil)
+				if err != nil {
+					errs <- err
+					return
+				}
+				valid, err := csp.Verify(pk, sig, digest, nil)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if !valid {
+					errs <- errors.New("Invalid signature")
+					return
+				}
+			}
+			errs <- nil
+		}()
+	}
+	for i := 0; i < workers; i++ {
+		assert.NoError(t, <-errs)
+	}
+
+	// Sessions opened beyond the cache size are closed once returned
+	assert.True(t, len(csp.sessions) <= cap(csp.sessions))
+}
+
+func TestPKCS11SessionCacheSize(t *testing.T) {
+	lib, pin, label := FindPKCS11Lib()
+	csp, err := New(PKCS11Opts{HashFamily: "SHA2", SecLevel: 256, Library: lib, Label: label, Pin: pin, SessionCacheSize: 2}, currentKS)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cap(csp.(*impl).sessions))
+}
+
+func TestPKCS11HandleSessionReturn(t *testing.T) {
+	csp := newHardwareKeysBCCSP(t, false)
+
+	// Drain the cache
+	var cached []pkcs11.SessionHandle
+	for len(csp.sessions) > 0 {
+		cached = append(cached, <-csp.sessions)
+	}
+	defer func() {
+		for len(csp.sessions) > 0 {
+			csp.ctx.CloseSession(<-csp.sessions)
+		}
+		for _, session := range cached {
+			csp.returnSession(session)
+		}
+	}()
+
+	// A failed operation on a valid session returns it to the cache
+	session := csp.getSession()
+	csp.handleSessionReturn(errors.New("operation failed"), session)
+	assert.Equal(t, 1, len(csp.sessions))
+
+	// An invalid session is discarded
+	session = csp.getSession()
+	csp.ctx.CloseSession(session)
+	csp.handleSessionReturn(errors.New("operation failed"), session)
+	assert.Equal(t, 0, len(csp.sessions))
+
+	// Without errors, the session is not checked
+	csp.handleSessionReturn(nil, csp.getSession())
+	assert.Equal(t, 1, len(csp.sessions))
+}
@@ -0,0 +1,312 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/miekg/pkcs11"
+	"github.com/op/go-logging"
+)
+
+// rsaPublicKeyASN is the structure hashed to compute the SKI
+// of an RSA key, as done by the software BCCSP
+type rsaPublicKeyASN struct {
+	N *big.Int
+	E int
+}
+
+func rsaSKI(pub *rsa.PublicKey) ([]byte, error) {
+	raw, err := asn1.Marshal(rsaPublicKeyASN{N: pub.N, E: pub.E})
+	if err != nil {
+		return nil, fmt.Errorf("Failed marshalling RSA public key [%s]", err)
+	}
+	hash := sha256.Sum256(raw)
+	return hash[:], nil
+}
+
+// MGF1 identifiers (CK_RSA_PKCS_MGF_TYPE), not defined by the bindings
+const (
+	ckgMGF1SHA1   = 0x00000001
+	ckgMGF1SHA256 = 0x00000002
+	ckgMGF1SHA384 = 0x00000003
+	ckgMGF1SHA512 = 0x00000004
+	ckgMGF1SHA224 = 0x00000005
+)
+
+var pssHashMechanisms = map[crypto.Hash]struct{ hash, mgf uint }{
+	crypto.SHA1:   {pkcs11.CKM_SHA_1, ckgMGF1SHA1},
+	crypto.SHA224: {pkcs11.CKM_SHA224, ckgMGF1SHA224},
+	crypto.SHA256: {pkcs11.CKM_SHA256, ckgMGF1SHA256},
+	crypto.SHA384: {pkcs11.CKM_SHA384, ckgMGF1SHA384},
+	crypto.SHA512: {pkcs11.CKM_SHA512, ckgMGF1SHA512},
+}
+
+// pkcs1HashPrefixes are the DER encoded DigestInfo prefixes that
+// CKM_RSA_PKCS expects in front of the digest, as in crypto/rsa
+var pkcs1HashPrefixes = map[crypto.Hash][]byte{
+	crypto.SHA1:   {0x30, 0x21, 0x30, 0x09, 0x06, 0x05, 0x2b, 0x0e, 0x03, 0x02, 0x1a, 0x05, 0x00, 0x04, 0x14},
+	crypto.SHA224: {0x30, 0x2d, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x04, 0x05, 0x00, 0x04, 0x1c},
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+}
+
+// newRSAPrivateKey wraps an RSA private key stored in the token.
+// The public key is imported in the software BCCSP, which performs
+// verifications and gives it the same SKI.
+func (csp *impl) newRSAPrivateKey(ski []byte, pub *rsa.PublicKey) (bccsp.Key, error) {
+	pk, err := csp.BCCSP.KeyImport(pub, &bccsp.RSAGoPublicKeyImportOpts{Temporary: true})
+	if err != nil {
+		return nil, fmt.Errorf("Failed importing RSA public key [%s]", err)
+	}
+	return &rsaPrivateKey{ski, pk}, nil
+}
+
+func (csp *impl) generateRSAKey(bits int, label string, ephemeral bool) (ski []byte, pubKey *rsa.PublicKey, err error) {
+	p11lib := csp.ctx
+	session := csp.getSession()
+	defer csp.returnSession(session)
+
+	publabel, prvlabel, err := keyPairLabels(p11lib, session, label)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pubkey_t := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_RSA),
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, !ephemeral),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+		pkcs11.NewAttribute(pkcs11.CKA_MODULUS_BITS, bits),
+		pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, []byte{0x01, 0x00, 0x01}),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+
+		pkcs11.NewAttribute(pkcs11.CKA_ID, publabel),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, publabel),
+	}
+
+	prvkey_t := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_RSA),
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, !ephemeral),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+
+		pkcs11.NewAttribute(pkcs11.CKA_ID, prvlabel),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, prvlabel),
+
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, csp.extractable()),
+	}
+	if csp.nonExtractable {
+		prvkey_t = append(prvkey_t, pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true))
+	}
+
+	pub, prv, err := p11lib.GenerateKeyPair(session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS_KEY_PAIR_GEN, nil)},
+		pubkey_t, prvkey_t)
+	if err != nil {
+		return nil, nil, fmt.Errorf("P11: RSA keypair generate failed [%s]\n", err)
+	}
+
+	pubKey, err = readRSAPublicKey(p11lib, session, pub)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ski, err = rsaSKI(pubKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// set CKA_ID of the both keys to SKI(public key)
+	setski_t := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_ID, ski),
+	}
+
+	logger.Infof("Generated new P11 RSA key, SKI %x\n", ski)
+	err = p11lib.SetAttributeValue(session, pub, setski_t)
+	if err != nil {
+		return nil, nil, fmt.Errorf("P11: set-ID-to-SKI[public] failed [%s]\n", err)
+	}
+
+	err = p11lib.SetAttributeValue(session, prv, setski_t)
+	if err != nil {
+		return nil, nil, fmt.Errorf("P11: set-ID-to-SKI[private] failed [%s]\n", err)
+	}
+
+	if logger.IsEnabledFor(logging.DEBUG) {
+		listAttrs(p11lib, session, prv)
+		listAttrs(p11lib, session, pub)
+	}
+
+	return ski, pubKey, nil
+}
+
+// getRSAKey looks for an RSA key by SKI, stored in CKA_ID
+func (csp *impl) getRSAKey(ski []byte) (pubKey *rsa.PublicKey, isPriv bool, err error) {
+	p11lib := csp.ctx
+	session := csp.getSession()
+	defer csp.returnSession(session)
+
+	find := func(class uint) (*pkcs11.ObjectHandle, error) {
+		return findObject(p11lib, session, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+			pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_RSA),
+			pkcs11.NewAttribute(pkcs11.CKA_ID, ski),
+		})
+	}
+
+	prv, err := find(pkcs11.CKO_PRIVATE_KEY)
+	if err != nil {
+		return nil, false, fmt.Errorf("Failed looking up RSA private key [%s]", err)
+	}
+	pub, err := find(pkcs11.CKO_PUBLIC_KEY)
+	if err != nil {
+		return nil, false, fmt.Errorf("Failed looking up RSA public key [%s]", err)
+	}
+
+	// The private key object carries the public components as well,
+	// in case the public key object was not kept in the token
+	obj := pub
+	if obj == nil {
+		obj = prv
+	}
+	if obj == nil {
+		return nil, false, fmt.Errorf("RSA key not found for SKI [%x]", ski)
+	}
+
+	pubKey, err = readRSAPublicKey(p11lib, session, *obj)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return pubKey, prv != nil, nil
+}
+
+func readRSAPublicKey(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle, key pkcs11.ObjectHandle) (*rsa.PublicKey, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_MODULUS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, nil),
+	}
+
+	attrs, err := p11lib.GetAttributeValue(session, key, template)
+	if err != nil {
+		return nil, fmt.Errorf("PKCS11: get(RSA public key) [%s]\n", err)
+	}
+
+	var n, e *big.Int
+	for _, a := range attrs {
+		switch a.Type {
+		case pkcs11.CKA_MODULUS:
+			n = new(big.Int).SetBytes(a.Value)
+		case pkcs11.CKA_PUBLIC_EXPONENT:
+			e = new(big.Int).SetBytes(a.Value)
+		}
+	}
+	if n == nil || e == nil || n.Sign() == 0 {
+		return nil, errors.New("CKA_MODULUS or CKA_PUBLIC_EXPONENT not found, perhaps not an RSA Key?")
+	}
+	if !e.IsInt64() || e.Int64() > int64(^uint32(0)>>1) {
+		return nil, errors.New("RSA public exponent too large")
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+// signRSA signs with PSS when opts is an *rsa.PSSOptions, with
+// PKCS#1 v1.5 otherwise, as the software BCCSP does
+func (csp *impl) signRSA(k rsaPrivateKey, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	if opts == nil {
+		return nil, errors.New("Invalid options. Must be different from nil.")
+	}
+
+	hash := opts.HashFunc()
+	if hash != 0 && hash.Size() != len(digest) {
+		return nil, fmt.Errorf("Invalid digest length [%d]. Must be [%d].", len(digest), hash.Size())
+	}
+
+	switch opts.(type) {
+	case *rsa.PSSOptions:
+		mechs, ok := pssHashMechanisms[hash]
+		if !ok {
+			return nil, fmt.Errorf("Hash function not supported for RSA PSS [%v]", hash)
+		}
+
+		saltLength := opts.(*rsa.PSSOptions).SaltLength
+		if saltLength == rsa.PSSSaltLengthAuto || saltLength == rsa.PSSSaltLengthEqualsHash {
+			saltLength = hash.Size()
+		}
+		if saltLength < 0 {
+			return nil, fmt.Errorf("Invalid salt length [%d]", saltLength)
+		}
+
+		// CK_RSA_PKCS_PSS_PARAMS is made of three CK_ULONG
+		params := append(ulongBytes(mechs.hash), ulongBytes(mechs.mgf)...)
+		params = append(params, ulongBytes(uint(saltLength))...)
+
+		return csp.signP11RSA(k.ski, pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS_PSS, params), digest)
+
+	default:
+		var prefix []byte
+		if hash != 0 {
+			var ok bool
+			prefix, ok = pkcs1HashPrefixes[hash]
+			if !ok {
+				return nil, fmt.Errorf("Hash function not supported for RSA PKCS#1 v1.5 [%v]", hash)
+			}
+		}
+		msg := append(append([]byte{}, prefix...), digest...)
+
+		return csp.signP11RSA(k.ski, pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS, nil), msg)
+	}
+}
+
+func (csp *impl) signP11RSA(ski []byte, mech *pkcs11.Mechanism, msg []byte) (sig []byte, err error) {
+	p11lib := csp.ctx
+	session := csp.getSession()
+	defer func() { csp.handleSessionReturn(err, session) }()
+
+	privateKey, err := findKeyPairFromSKI(p11lib, session, ski, privateKeyFlag)
+	if err != nil {
+		return nil, fmt.Errorf("Private key not found [%s]\n", err)
+	}
+
+	err = p11lib.SignInit(session, []*pkcs11.Mechanism{mech}, *privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Sign-initialize  failed [%s]\n", err)
+	}
+
+	sig, err = p11lib.Sign(session, msg)
+	if err != nil {
+		return nil, fmt.Errorf("P11: sign failed [%s]\n", err)
+	}
+
+	return sig, nil
+}
+
+// ulongBytes encodes v as a CK_ULONG, in the platform representation
+func ulongBytes(v uint) []byte {
+	return pkcs11.NewAttribute(0, v).Value
+}
@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestP11RSAKeyGenSignVerify(t *testing.T) {
+	csp := newHardwareKeysBCCSP(t, false)
+
+	k, err := csp.KeyGen(&bccsp.RSA2048KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.IsType(t, &rsaPrivateKey{}, k)
+	assert.True(t, k.Private())
+	assert.False(t, k.Symmetric())
+	_, err = k.Bytes()
+	assert.Error(t, err)
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, k.SKI(), pk.SKI())
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	goPK, err := x509.ParsePKIXPublicKey(raw)
+	assert.NoError(t, err)
+	rsaPK := goPK.(*rsa.PublicKey)
+	assert.Equal(t, 2048, rsaPK.N.BitLen())
+
+	digest := sha256.Sum256([]byte("Hello World"))
+
+	// PSS
+	pssOpts := &rsa.PSSOptions{SaltLength: 32, Hash: crypto.SHA256}
+	sig, err := csp.Sign(k, digest[:], pssOpts)
+	assert.NoError(t, err)
+	assert.NoError(t, rsa.VerifyPSS(rsaPK, crypto.SHA256, digest[:], sig, pssOpts))
+	valid, err := csp.Verify(k, sig, digest[:], pssOpts)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = csp.Verify(pk, sig, digest[:], pssOpts)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	sig, err = csp.Sign(k, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto, Hash: crypto.SHA256})
+	assert.NoError(t, err)
+	assert.NoError(t, rsa.VerifyPSS(rsaPK, crypto.SHA256, digest[:], sig, nil))
+
+	// PKCS#1 v1.5
+	sig, err = csp.Sign(k, digest[:], crypto.SHA256)
+	assert.NoError(t, err)
+	assert.NoError(t, rsa.VerifyPKCS1v15(rsaPK, crypto.SHA256, digest[:], sig))
+
+	// The key can be retrieved from the token
+	k2, err := csp.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.IsType(t, &rsaPrivateKey{}, k2)
+	assert.Equal(t, k.SKI(), k2.SKI())
+}
+
+func TestP11RSAKeyGenOpts(t *testing.T) {
+	csp := newHardwareKeysBCCSP(t, false)
+
+	for opts, bits := range map[bccsp.KeyGenOpts]int{
+		&bccsp.RSAKeyGenOpts{Temporary: true}:     2048,
+		&bccsp.RSA1024KeyGenOpts{Temporary: true}: 1024,
+		&bccsp.RSA3072KeyGenOpts{Temporary: true}: 3072,
+	} {
+		k, err := csp.KeyGen(opts)
+		assert.NoError(t, err)
+		pk, err := k.PublicKey()
+		assert.NoError(t, err)
+		raw, err := pk.Bytes()
+		assert.NoError(t, err)
+		goPK, err := x509.ParsePKIXPublicKey(raw)
+		assert.NoError(t, err)
+		assert.Equal(t, bits, goPK.(*rsa.PublicKey).N.BitLen())
+	}
+}
+
+func TestP11RSASignBadPaths(t *testing.T) {
+	csp := newHardwareKeysBCCSP(t, true)
+
+	k, err := csp.KeyGen(&bccsp.RSA1024KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	digest := sha256.Sum256([]byte("Hello World"))
+
+	_, err = csp.Sign(k, digest[:], nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid options")
+
+	_, err = csp.Sign(k, digest[:20], crypto.SHA256)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid digest length")
+
+	_, err = csp.Sign(k, make([]byte, 32), &rsa.PSSOptions{Hash: crypto.SHA3_256})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Hash function not supported")
+
+	_, err = csp.Sign(&rsaPrivateKey{[]byte{1, 2, 3}, nil}, digest[:], crypto.SHA256)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Private key not found")
+}
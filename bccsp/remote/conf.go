@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"time"
+)
+
+// defaultTimeout is used to connect to the remote signer, and for
+// each call to it, when RemoteOpts.Timeout is not set
+const defaultTimeout = 10 * time.Second
+
+// RemoteOpts contains options for the remote signer based BCCSP
+type RemoteOpts struct {
+	// Default algorithms used for local hashing and verification
+	SecLevel   int    `mapstructure:"security" json:"security" yaml:"Security"`
+	HashFamily string `mapstructure:"hash" json:"hash" yaml:"Hash"`
+
+	// Address (host:port) of the remote signer
+	Address string `mapstructure:"address" json:"address" yaml:"Address"`
+	// ServerNameOverride, when set, is the name expected in the
+	// remote signer's TLS certificate instead of the host of Address
+	ServerNameOverride string `mapstructure:"servernameoverride,omitempty" json:"servernameoverride,omitempty" yaml:"ServerNameOverride"`
+
+	// TLS certificate and key used to authenticate to the remote signer
+	ClientCertFile string `mapstructure:"clientcert" json:"clientcert" yaml:"ClientCert"`
+	ClientKeyFile  string `mapstructure:"clientkey" json:"clientkey" yaml:"ClientKey"`
+	// CA certificates used to authenticate the remote signer
+	RootCAFiles []string `mapstructure:"rootcas" json:"rootcas" yaml:"RootCAs"`
+
+	// Timeout used to connect to the remote signer and for each call to it
+	Timeout time.Duration `mapstructure:"timeout,omitempty" json:"timeout,omitempty" yaml:"Timeout"`
+}
+
+func (opts *RemoteOpts) timeout() time.Duration {
+	if opts.Timeout <= 0 {
+		return defaultTimeout
+	}
+	return opts.Timeout
+}
+
+// tlsConfig returns the mutual TLS configuration used to connect
+// to the remote signer
+func (opts *RemoteOpts) tlsConfig() (*tls.Config, error) {
+	if opts.Address == "" {
+		return nil, errors.New("Invalid config. Address must not be empty.")
+	}
+	if opts.ClientCertFile == "" || opts.ClientKeyFile == "" {
+		return nil, errors.New("Invalid config. A client certificate and key are required.")
+	}
+	if len(opts.RootCAFiles) == 0 {
+		return nil, errors.New("Invalid config. At least one root CA is required.")
+	}
+
+	cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading client certificate [%s]", err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	for _, file := range opts.RootCAFiles {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("Failed reading root CA [%s]", err)
+		}
+		if !rootCAs.AppendCertsFromPEM(raw) {
+			return nil, fmt.Errorf("No valid certificate found in root CA file [%s]", file)
+		}
+	}
+
+	serverName := opts.ServerNameOverride
+	if serverName == "" {
+		serverName, _, err = net.SplitHostPort(opts.Address)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid address [%s]: [%s]", opts.Address, err)
+		}
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      rootCAs,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/protos/remotesigner"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var logger = flogging.MustGetLogger("bccsp_remote")
+
+// New returns a new instance of the remote signer based BCCSP.
+// Signing with, and retrieving, private keys is forwarded over
+// mutual TLS to the remote signer at opts.Address. Hashing,
+// verification and all the other operations are performed locally
+// by a software BCCSP without a keystore.
+func New(opts RemoteOpts) (bccsp.BCCSP, error) {
+	tlsConfig, err := opts.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	swCSP, err := sw.New(opts.SecLevel, opts.HashFamily, sw.NewDummyKeyStore())
+	if err != nil {
+		return nil, fmt.Errorf("Failed initializing fallback SW BCCSP [%s]", err)
+	}
+
+	conn, err := grpc.Dial(opts.Address,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithBlock(),
+		grpc.WithTimeout(opts.timeout()))
+	if err != nil {
+		return nil, fmt.Errorf("Failed connecting to remote signer at [%s]: [%s]", opts.Address, err)
+	}
+
+	return newWithClient(swCSP, remotesigner.NewRemoteSignerClient(conn), opts.timeout()), nil
+}
+
+func newWithClient(swCSP bccsp.BCCSP, client remotesigner.RemoteSignerClient, timeout time.Duration) *impl {
+	return &impl{
+		BCCSP:   swCSP,
+		client:  client,
+		timeout: timeout,
+		keys:    make(map[string]*remoteKey),
+	}
+}
+
+type impl struct {
+	bccsp.BCCSP
+
+	client  remotesigner.RemoteSignerClient
+	timeout time.Duration
+
+	// keys caches the handles, and public keys, of the remote keys
+	// already retrieved, indexed by hex encoded SKI
+	keysLock sync.RWMutex
+	keys     map[string]*remoteKey
+}
+
+// GetKey returns the key this CSP associates to
+// the Subject Key Identifier ski.
+// The key is looked up on the remote signer, and its public part is
+// cached so that subsequent lookups do not hit the remote signer.
+func (csp *impl) GetKey(ski []byte) (k bccsp.Key, err error) {
+	if len(ski) == 0 {
+		return nil, errors.New("Invalid SKI. Cannot be of zero length.")
+	}
+
+	id := hex.EncodeToString(ski)
+	csp.keysLock.RLock()
+	cached, ok := csp.keys[id]
+	csp.keysLock.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+	resp, err := csp.client.GetPublicKey(ctx, &remotesigner.GetPublicKeyRequest{Ski: ski})
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting key [%s] from remote signer [%s]", id, err)
+	}
+
+	lowLevelKey, err := utils.DERToPublicKey(resp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing public key [%s] returned by remote signer [%s]", id, err)
+	}
+	pub, err := csp.importPublicKey(lowLevelKey)
+	if err != nil {
+		return nil, fmt.Errorf("Failed importing public key [%s] returned by remote signer [%s]", id, err)
+	}
+	if !bytes.Equal(pub.SKI(), ski) {
+		return nil, fmt.Errorf("Remote signer returned public key [%x] for key [%s]", pub.SKI(), id)
+	}
+
+	key := &remoteKey{ski: ski, pub: pub, lowLevelPub: lowLevelKey}
+	csp.keysLock.Lock()
+	csp.keys[id] = key
+	csp.keysLock.Unlock()
+	logger.Debugf("Retrieved key [%s] from remote signer", id)
+
+	return key, nil
+}
+
+// importPublicKey imports a public key into the local software BCCSP
+func (csp *impl) importPublicKey(lowLevelKey interface{}) (bccsp.Key, error) {
+	switch lowLevelKey.(type) {
+	case *ecdsa.PublicKey:
+		return csp.BCCSP.KeyImport(lowLevelKey, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true})
+	case *rsa.PublicKey:
+		return csp.BCCSP.KeyImport(lowLevelKey, &bccsp.RSAGoPublicKeyImportOpts{Temporary: true})
+	case ed25519.PublicKey:
+		return csp.BCCSP.KeyImport(lowLevelKey, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	default:
+		return nil, fmt.Errorf("Public key type not recognized [%T]. Supported keys: [ECDSA, RSA, ED25519]", lowLevelKey)
+	}
+}
+
+// KeyDeriv derives a key from k using opts.
+// The opts argument should be appropriate for the primitive used.
+// Keys held by the remote signer cannot be derived.
+func (csp *impl) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOpts) (dk bccsp.Key, err error) {
+	if _, isRemote := k.(*remoteKey); isRemote {
+		return nil, errors.New("Key derivation is not supported for keys held by the remote signer")
+	}
+	return csp.BCCSP.KeyDeriv(k, opts)
+}
+
+// Sign signs digest using key k.
+// The opts argument should be appropriate for the primitive used.
+// Keys held by the remote signer sign on the remote signer, which is
+// sent the hash function and PSS options of opts; ECDSA signatures
+// returned by it are normalized to low-S.
+func (csp *impl) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signature []byte, err error) {
+	rk, isRemote := k.(*remoteKey)
+	if !isRemote {
+		return csp.BCCSP.Sign(k, digest, opts)
+	}
+	if len(digest) == 0 {
+		return nil, errors.New("Invalid digest. Cannot be empty.")
+	}
+
+	req := &remotesigner.SignRequest{Ski: rk.ski, Digest: digest}
+	switch o := opts.(type) {
+	case nil:
+	case *rsa.PSSOptions:
+		req.Hash, req.Pss, req.PssSaltLength = uint32(o.Hash), true, int32(o.SaltLength)
+	default:
+		req.Hash = uint32(o.HashFunc())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+	resp, err := csp.client.Sign(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed signing with key [%x] on remote signer [%s]", rk.ski, err)
+	}
+
+	if ecdsaPub, isECDSA := rk.lowLevelPub.(*ecdsa.PublicKey); isECDSA {
+		return sw.SignatureToLowS(ecdsaPub, resp.Signature)
+	}
+
+	return resp.Signature, nil
+}
+
+// Verify verifies signature against key k and digest
+// The opts argument should be appropriate for the algorithm used.
+// Verification always happens locally.
+func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	if rk, isRemote := k.(*remoteKey); isRemote {
+		return csp.BCCSP.Verify(rk.pub, signature, digest, opts)
+	}
+	return csp.BCCSP.Verify(k, signature, digest, opts)
+}
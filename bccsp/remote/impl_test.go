@@ -0,0 +1,474 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/protos/remotesigner"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// testCA issues TLS certificates for the tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "tlsca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	assert.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// issue returns a PEM encoded certificate and key for the given name
+func (ca *testCA) issue(t *testing.T, name string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	keyRaw, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyRaw})
+}
+
+func (ca *testCA) pem() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+// testEnv is a reference remote signer running over mutual TLS,
+// together with the options a client needs to connect to it
+type testEnv struct {
+	dir       string
+	serverCSP bccsp.BCCSP
+	server    *grpc.Server
+	opts      RemoteOpts
+	clientCA  *testCA
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	dir, err := ioutil.TempDir("", "remotesigner")
+	assert.NoError(t, err)
+
+	// the signing service keeps its keys in a file based keystore
+	ks, err := sw.NewFileBasedKeyStore(nil, filepath.Join(dir, "keystore"), false)
+	assert.NoError(t, err)
+	serverCSP, err := sw.New(256, "SHA2", ks)
+	assert.NoError(t, err)
+
+	serverCA := newTestCA(t)
+	clientCA := newTestCA(t)
+	serverCert, serverKey := serverCA.issue(t, "localhost", x509.ExtKeyUsageServerAuth)
+	cert, err := tls.X509KeyPair(serverCert, serverKey)
+	assert.NoError(t, err)
+	clientRootCAs := x509.NewCertPool()
+	clientRootCAs.AddCert(clientCA.cert)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := NewGRPCServer(NewServer(serverCSP), cert, clientRootCAs)
+	go server.Serve(lis)
+
+	clientCert, clientKey := clientCA.issue(t, "peer0", x509.ExtKeyUsageClientAuth)
+	writeFile(t, filepath.Join(dir, "client.crt"), clientCert)
+	writeFile(t, filepath.Join(dir, "client.key"), clientKey)
+	writeFile(t, filepath.Join(dir, "ca.crt"), serverCA.pem())
+
+	return &testEnv{
+		dir:       dir,
+		serverCSP: serverCSP,
+		server:    server,
+		clientCA:  clientCA,
+		opts: RemoteOpts{
+			SecLevel:           256,
+			HashFamily:         "SHA2",
+			Address:            lis.Addr().String(),
+			ServerNameOverride: "localhost",
+			ClientCertFile:     filepath.Join(dir, "client.crt"),
+			ClientKeyFile:      filepath.Join(dir, "client.key"),
+			RootCAFiles:        []string{filepath.Join(dir, "ca.crt")},
+			Timeout:            5 * time.Second,
+		},
+	}
+}
+
+func (env *testEnv) stop() {
+	env.server.Stop()
+	os.RemoveAll(env.dir)
+}
+
+func writeFile(t *testing.T, path string, raw []byte) {
+	assert.NoError(t, ioutil.WriteFile(path, raw, 0600))
+}
+
+func TestRemoteSignVerify(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.stop()
+
+	csp, err := New(env.opts)
+	assert.NoError(t, err)
+
+	for _, opts := range []bccsp.KeyGenOpts{
+		&bccsp.ECDSAP256KeyGenOpts{Temporary: false},
+		&bccsp.ED25519KeyGenOpts{Temporary: false},
+	} {
+		// the key is generated, and stays, on the signing service
+		serverKey, err := env.serverCSP.KeyGen(opts)
+		assert.NoError(t, err)
+
+		k, err := csp.GetKey(serverKey.SKI())
+		assert.NoError(t, err)
+		assert.True(t, k.Private())
+		assert.False(t, k.Symmetric())
+		assert.Equal(t, serverKey.SKI(), k.SKI())
+		_, err = k.Bytes()
+		assert.Error(t, err)
+		pk, err := k.PublicKey()
+		assert.NoError(t, err)
+		assert.Equal(t, serverKey.SKI(), pk.SKI())
+
+		// hashing happens locally, signing remotely
+		digest, err := csp.Hash([]byte("Hello World"), &bccsp.SHAOpts{})
+		assert.NoError(t, err)
+		signature, err := csp.Sign(k, digest, nil)
+		assert.NoError(t, err)
+
+		valid, err := csp.Verify(k, signature, digest, nil)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+		valid, err = csp.Verify(pk, signature, digest, nil)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+		valid, err = env.serverCSP.Verify(serverKey, signature, digest, nil)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		_, err = csp.Sign(k, nil, nil)
+		assert.Error(t, err)
+
+		_, err = csp.KeyDeriv(k, &bccsp.ECDSAReRandKeyOpts{Temporary: true, Expansion: []byte{1}})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Key derivation is not supported for keys held by the remote signer")
+	}
+}
+
+func TestRemoteLowS(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.stop()
+
+	csp, err := New(env.opts)
+	assert.NoError(t, err)
+
+	serverKey, err := env.serverCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	k, err := csp.GetKey(serverKey.SKI())
+	assert.NoError(t, err)
+
+	ecdsaPub := k.(*remoteKey).lowLevelPub.(*ecdsa.PublicKey)
+	for i := 0; i < 10; i++ {
+		digest, err := csp.Hash([]byte{byte(i)}, &bccsp.SHAOpts{})
+		assert.NoError(t, err)
+		signature, err := csp.Sign(k, digest, nil)
+		assert.NoError(t, err)
+		_, s, err := sw.UnmarshalECDSASignature(signature)
+		assert.NoError(t, err)
+		lowS, err := sw.IsLowS(ecdsaPub, s)
+		assert.NoError(t, err)
+		assert.True(t, lowS)
+	}
+}
+
+func TestRemoteRSA(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.stop()
+
+	csp, err := New(env.opts)
+	assert.NoError(t, err)
+
+	serverKey, err := env.serverCSP.KeyGen(&bccsp.RSA2048KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	k, err := csp.GetKey(serverKey.SKI())
+	assert.NoError(t, err)
+
+	// the PSS options are forwarded to the signing service
+	digest, err := csp.Hash([]byte("Hello World"), &bccsp.SHAOpts{})
+	assert.NoError(t, err)
+	opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}
+	signature, err := csp.Sign(k, digest, opts)
+	assert.NoError(t, err)
+	valid, err := csp.Verify(k, signature, digest, opts)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = csp.Sign(k, digest, nil)
+	assert.Error(t, err)
+	_, err = csp.Sign(k, digest, &rsa.PSSOptions{Hash: crypto.Hash(1000)})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Hash function [1000] not available")
+}
+
+func TestRemoteKeyCache(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.stop()
+
+	csp, err := New(env.opts)
+	assert.NoError(t, err)
+
+	serverKey, err := env.serverCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	k, err := csp.GetKey(serverKey.SKI())
+	assert.NoError(t, err)
+
+	// once retrieved, the key no longer requires the remote signer
+	env.server.Stop()
+	k2, err := csp.GetKey(serverKey.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, k, k2)
+
+	_, err = csp.GetKey([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
+
+func TestRemoteGetKeyErrors(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.stop()
+
+	csp, err := New(env.opts)
+	assert.NoError(t, err)
+
+	_, err = csp.GetKey(nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid SKI. Cannot be of zero length.")
+
+	_, err = csp.GetKey([]byte{1, 2, 3})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed getting key [010203] from remote signer")
+
+	// the remote signer only serves private keys
+	serverKey, err := env.serverCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	pk, err := serverKey.PublicKey()
+	assert.NoError(t, err)
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	pubOnly, err := env.serverCSP.KeyImport(raw, &bccsp.ECDSAPKIXPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	_, err = NewServer(env.serverCSP).Sign(context.Background(), &remotesigner.SignRequest{Ski: []byte{1, 2, 3}, Digest: []byte{1}})
+	assert.Error(t, err)
+	_, err = NewServer(&publicOnlyCSP{BCCSP: env.serverCSP, k: pubOnly}).GetPublicKey(context.Background(), &remotesigner.GetPublicKeyRequest{Ski: pubOnly.SKI()})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is not an asymmetric private key")
+}
+
+func TestRemoteLocalKeys(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.stop()
+
+	csp, err := New(env.opts)
+	assert.NoError(t, err)
+
+	// ephemeral keys are handled by the local software BCCSP
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	digest, err := csp.Hash([]byte("Hello World"), &bccsp.SHAOpts{})
+	assert.NoError(t, err)
+	signature, err := csp.Sign(k, digest, nil)
+	assert.NoError(t, err)
+	valid, err := csp.Verify(k, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// persistent keys cannot be generated locally
+	_, err = csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	assert.Error(t, err)
+}
+
+func TestRemoteMutualTLS(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.stop()
+
+	serverKey, err := env.serverCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+
+	// a client certificate not issued by a trusted CA is rejected
+	untrustedCert, untrustedKey := newTestCA(t).issue(t, "peer1", x509.ExtKeyUsageClientAuth)
+	writeFile(t, filepath.Join(env.dir, "untrusted.crt"), untrustedCert)
+	writeFile(t, filepath.Join(env.dir, "untrusted.key"), untrustedKey)
+	opts := env.opts
+	opts.ClientCertFile = filepath.Join(env.dir, "untrusted.crt")
+	opts.ClientKeyFile = filepath.Join(env.dir, "untrusted.key")
+	opts.Timeout = time.Second
+	csp, err := New(opts)
+	if err == nil {
+		_, err = csp.GetKey(serverKey.SKI())
+	}
+	assert.Error(t, err)
+
+	// the remote signer must present a certificate for the expected name
+	opts = env.opts
+	opts.ServerNameOverride = "signer.example.com"
+	opts.Timeout = time.Second
+	_, err = New(opts)
+	assert.Error(t, err)
+}
+
+func TestRemoteOpts(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.stop()
+
+	opts := env.opts
+	opts.Address = ""
+	_, err := New(opts)
+	assert.EqualError(t, err, "Invalid config. Address must not be empty.")
+
+	opts = env.opts
+	opts.ClientKeyFile = ""
+	_, err = New(opts)
+	assert.EqualError(t, err, "Invalid config. A client certificate and key are required.")
+
+	opts = env.opts
+	opts.RootCAFiles = nil
+	_, err = New(opts)
+	assert.EqualError(t, err, "Invalid config. At least one root CA is required.")
+
+	opts = env.opts
+	opts.ClientCertFile = filepath.Join(env.dir, "missing.crt")
+	_, err = New(opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed loading client certificate")
+
+	opts = env.opts
+	opts.RootCAFiles = []string{filepath.Join(env.dir, "client.key")}
+	_, err = New(opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "No valid certificate found in root CA file")
+
+	opts = env.opts
+	opts.ServerNameOverride = ""
+	opts.Address = "localhost"
+	_, err = New(opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid address")
+
+	opts = env.opts
+	opts.HashFamily = "SHA8"
+	_, err = New(opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed initializing fallback SW BCCSP")
+
+	opts = env.opts
+	opts.Timeout = 0
+	assert.Equal(t, defaultTimeout, opts.timeout())
+}
+
+func TestRemoteSignerMismatchedKey(t *testing.T) {
+	swCSP, err := sw.New(256, "SHA2", sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	k, err := swCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+
+	// a remote signer returning a public key that does not match
+	// the requested SKI is not trusted
+	client := &mockClient{publicKey: raw}
+	csp := newWithClient(swCSP, client, time.Second)
+	_, err = csp.GetKey([]byte{1, 2, 3})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Remote signer returned public key")
+
+	client.publicKey = []byte{1, 2, 3}
+	_, err = csp.GetKey(k.SKI())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed parsing public key")
+
+	client.publicKey = raw
+	rk, err := csp.GetKey(k.SKI())
+	assert.NoError(t, err)
+	client.signErr = errors.New("unavailable")
+	_, err = csp.Sign(rk, []byte{1}, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unavailable")
+}
+
+type mockClient struct {
+	publicKey []byte
+	signErr   error
+}
+
+func (c *mockClient) GetPublicKey(ctx context.Context, in *remotesigner.GetPublicKeyRequest, opts ...grpc.CallOption) (*remotesigner.GetPublicKeyResponse, error) {
+	return &remotesigner.GetPublicKeyResponse{PublicKey: c.publicKey}, nil
+}
+
+func (c *mockClient) Sign(ctx context.Context, in *remotesigner.SignRequest, opts ...grpc.CallOption) (*remotesigner.SignResponse, error) {
+	return nil, c.signErr
+}
+
+// publicOnlyCSP returns the public key k on any GetKey
+type publicOnlyCSP struct {
+	bccsp.BCCSP
+	k bccsp.Key
+}
+
+func (csp *publicOnlyCSP) GetKey(ski []byte) (bccsp.Key, error) {
+	return csp.k, nil
+}
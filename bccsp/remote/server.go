@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/protos/remotesigner"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Server is a reference implementation of the remote signer, backed by
+// a local BCCSP (for instance a software BCCSP with a file based
+// keystore). It is meant for tests and development environments.
+type Server struct {
+	csp bccsp.BCCSP
+}
+
+// NewServer returns a remote signer serving the private keys of csp
+func NewServer(csp bccsp.BCCSP) *Server {
+	return &Server{csp: csp}
+}
+
+// NewGRPCServer returns a gRPC server that exposes s over mutual TLS
+// using cert. Only clients presenting a certificate issued by one of
+// clientRootCAs are accepted.
+func NewGRPCServer(s *Server, cert tls.Certificate, clientRootCAs *x509.CertPool) *grpc.Server {
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientRootCAs,
+		MinVersion:   tls.VersionTLS12,
+	}
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	remotesigner.RegisterRemoteSignerServer(server, s)
+	return server
+}
+
+// GetPublicKey returns the public key of the private key identified by req.Ski
+func (s *Server) GetPublicKey(ctx context.Context, req *remotesigner.GetPublicKeyRequest) (*remotesigner.GetPublicKeyResponse, error) {
+	k, err := s.getPrivateKey(req.Ski)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, err := k.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting public key [%s]", err)
+	}
+	raw, err := pk.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("Failed marshalling public key [%s]", err)
+	}
+
+	return &remotesigner.GetPublicKeyResponse{PublicKey: raw}, nil
+}
+
+// Sign signs req.Digest with the private key identified by req.Ski,
+// using the hash function and PSS options of req
+func (s *Server) Sign(ctx context.Context, req *remotesigner.SignRequest) (*remotesigner.SignResponse, error) {
+	k, err := s.getPrivateKey(req.Ski)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Digest) == 0 {
+		return nil, errors.New("Invalid digest. Cannot be empty.")
+	}
+
+	opts, err := signerOpts(req)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.csp.Sign(k, req.Digest, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Failed signing [%s]", err)
+	}
+	logger.Debugf("Signed digest with key [%x]", req.Ski)
+
+	return &remotesigner.SignResponse{Signature: signature}, nil
+}
+
+// signerOpts returns the SignerOpts described by req, nil if req
+// carries no hash function
+func signerOpts(req *remotesigner.SignRequest) (bccsp.SignerOpts, error) {
+	if req.Hash == 0 && !req.Pss {
+		return nil, nil
+	}
+
+	hash := crypto.Hash(req.Hash)
+	if !hash.Available() {
+		return nil, fmt.Errorf("Hash function [%d] not available", req.Hash)
+	}
+	if req.Pss {
+		return &rsa.PSSOptions{SaltLength: int(req.PssSaltLength), Hash: hash}, nil
+	}
+	return hash, nil
+}
+
+func (s *Server) getPrivateKey(ski []byte) (bccsp.Key, error) {
+	if len(ski) == 0 {
+		return nil, errors.New("Invalid SKI. Cannot be of zero length.")
+	}
+
+	k, err := s.csp.GetKey(ski)
+	if err != nil {
+		return nil, fmt.Errorf("Key [%x] not found [%s]", ski, err)
+	}
+	if !k.Private() || k.Symmetric() {
+		return nil, fmt.Errorf("Key [%x] is not an asymmetric private key", ski)
+	}
+
+	return k, nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sw
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/hyperledger/fabric/bccsp"
+)
+
+// signED25519 signs the given message. Ed25519 signatures are
+// deterministic and not malleable, so unlike ECDSA no low-S
+// normalization is needed. Ed25519 hashes the message itself, so
+// callers pass the whole message rather than a digest of it, as
+// MSP identities do.
+func signED25519(k ed25519.PrivateKey, digest []byte, opts bccsp.SignerOpts) (signature []byte, err error) {
+	if len(k) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("Invalid Ed25519 private key length [%d]", len(k))
+	}
+
+	return ed25519.Sign(k, digest), nil
+}
+
+func verifyED25519(k ed25519.PublicKey, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	if len(k) != ed25519.PublicKeySize {
+		return false, fmt.Errorf("Invalid Ed25519 public key length [%d]", len(k))
+	}
+	if len(signature) != ed25519.SignatureSize {
+		return false, fmt.Errorf("Invalid Ed25519 signature length [%d]. Must be %d.", len(signature), ed25519.SignatureSize)
+	}
+
+	return ed25519.Verify(k, digest, signature), nil
+}
+
+type ed25519Signer struct{}
+
+func (s *ed25519Signer) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signature []byte, err error) {
+	return signED25519(k.(*ed25519PrivateKey).privKey, digest, opts)
+}
+
+type ed25519PrivateKeyVerifier struct{}
+
+func (v *ed25519PrivateKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	return verifyED25519(k.(*ed25519PrivateKey).privKey.Public().(ed25519.PublicKey), signature, digest, opts)
+}
+
+type ed25519PublicKeyKeyVerifier struct{}
+
+func (v *ed25519PublicKeyKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	return verifyED25519(k.(*ed25519PublicKey).pubKey, signature, digest, opts)
+}
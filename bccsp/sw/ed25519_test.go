@@ -0,0 +1,234 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sw
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	mocks2 "github.com/hyperledger/fabric/bccsp/mocks"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestED25519KeyGenerator(t *testing.T) {
+	kg := &ed25519KeyGenerator{}
+
+	k, err := kg.KeyGen(nil)
+	assert.NoError(t, err)
+
+	edK, ok := k.(*ed25519PrivateKey)
+	assert.True(t, ok)
+	assert.Len(t, edK.privKey, ed25519.PrivateKeySize)
+}
+
+func TestED25519Keys(t *testing.T) {
+	_, lowLevelKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	k := &ed25519PrivateKey{lowLevelKey}
+	assert.True(t, k.Private())
+	assert.False(t, k.Symmetric())
+	_, err = k.Bytes()
+	assert.Error(t, err)
+	assert.Len(t, k.SKI(), 32)
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.False(t, pk.Private())
+	assert.False(t, pk.Symmetric())
+	assert.Equal(t, k.SKI(), pk.SKI())
+
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	pub, err := x509.ParsePKIXPublicKey(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, lowLevelKey.Public(), pub)
+
+	pk2, err := pk.PublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, pk, pk2)
+
+	// invalid keys
+	k = &ed25519PrivateKey{}
+	assert.Nil(t, k.SKI())
+	_, err = k.PublicKey()
+	assert.Error(t, err)
+	assert.Nil(t, (&ed25519PublicKey{}).SKI())
+}
+
+func TestED25519SignVerify(t *testing.T) {
+	_, lowLevelKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	k := &ed25519PrivateKey{lowLevelKey}
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+
+	msg := []byte("Hello World")
+	sigma, err := (&ed25519Signer{}).Sign(k, msg, nil)
+	assert.NoError(t, err)
+	assert.Len(t, sigma, ed25519.SignatureSize)
+
+	valid, err := (&ed25519PrivateKeyVerifier{}).Verify(k, sigma, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = (&ed25519PublicKeyKeyVerifier{}).Verify(pk, sigma, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = (&ed25519PublicKeyKeyVerifier{}).Verify(pk, sigma, []byte("Bye World"), nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	_, err = (&ed25519PublicKeyKeyVerifier{}).Verify(pk, sigma[1:], msg, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid Ed25519 signature length")
+
+	_, err = (&ed25519Signer{}).Sign(&ed25519PrivateKey{}, msg, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid Ed25519 private key length")
+
+	_, err = (&ed25519PublicKeyKeyVerifier{}).Verify(&ed25519PublicKey{}, sigma, msg, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid Ed25519 public key length")
+}
+
+func TestED25519KeyImporters(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	// PKIX
+	ki := &ed25519PKIXPublicKeyImportOptsKeyImporter{}
+	_, err = ki.KeyImport("Hello World", &mocks2.KeyImportOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid raw material. Expected byte array.")
+	_, err = ki.KeyImport([]byte(nil), &mocks2.KeyImportOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid raw. It must not be nil.")
+	_, err = ki.KeyImport([]byte{0}, &mocks2.KeyImportOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed converting PKIX to Ed25519 public key [")
+
+	ecdsaKey, err := (&ecdsaKeyGenerator{curve: currentBCCSP.(*impl).conf.ellipticCurve}).KeyGen(nil)
+	assert.NoError(t, err)
+	ecdsaPub, err := ecdsaKey.PublicKey()
+	assert.NoError(t, err)
+	ecdsaRaw, err := ecdsaPub.Bytes()
+	assert.NoError(t, err)
+	_, err = ki.KeyImport(ecdsaRaw, &mocks2.KeyImportOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed casting to Ed25519 public key. Invalid raw material.")
+
+	raw, err := utils.PublicKeyToDER(pub)
+	assert.NoError(t, err)
+	k, err := ki.KeyImport(raw, &mocks2.KeyImportOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, pub, k.(*ed25519PublicKey).pubKey)
+
+	// PKCS#8
+	ski := &ed25519PrivateKeyImportOptsKeyImporter{}
+	_, err = ski.KeyImport("Hello World", &mocks2.KeyImportOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "[ED25519PrivateKeyImportOpts] Invalid raw material. Expected byte array.")
+	_, err = ski.KeyImport([]byte(nil), &mocks2.KeyImportOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "[ED25519PrivateKeyImportOpts] Invalid raw. It must not be nil.")
+	_, err = ski.KeyImport([]byte{0}, &mocks2.KeyImportOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed converting PKCS#8 to Ed25519 private key")
+
+	raw, err = x509.MarshalPKCS8PrivateKey(priv)
+	assert.NoError(t, err)
+	k, err = ski.KeyImport(raw, &mocks2.KeyImportOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, priv, k.(*ed25519PrivateKey).privKey)
+
+	// Go
+	gki := &ed25519GoPublicKeyImportOptsKeyImporter{}
+	_, err = gki.KeyImport("Hello World", &mocks2.KeyImportOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid raw material. Expected ed25519.PublicKey.")
+	_, err = gki.KeyImport(ed25519.PublicKey{0}, &mocks2.KeyImportOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid Ed25519 public key length")
+	k, err = gki.KeyImport(pub, &mocks2.KeyImportOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, pub, k.(*ed25519PublicKey).pubKey)
+}
+
+func TestED25519BCCSP(t *testing.T) {
+	k, err := currentBCCSP.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	assert.True(t, k.Private())
+
+	// the key must have been stored in the keystore
+	k2, err := currentBCCSP.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, k, k2)
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.NoError(t, currentKS.StoreKey(pk))
+	pk2, err := currentKS.GetKey(pk.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk2.SKI())
+
+	// Ed25519 signs the whole message, which it hashes itself
+	msg := []byte("Hello World")
+	signature, err := currentBCCSP.Sign(k, msg, nil)
+	assert.NoError(t, err)
+
+	valid, err := currentBCCSP.Verify(k, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = currentBCCSP.Verify(pk, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// import the public key from PKIX and from a certificate
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	pk3, err := currentBCCSP.KeyImport(raw, &bccsp.ED25519PKIXPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk3.SKI())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ed25519"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	priv := k.(*ed25519PrivateKey).privKey
+	certRaw, err := x509.CreateCertificate(rand.Reader, template, template, priv.Public(), priv)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certRaw)
+	assert.NoError(t, err)
+	pk4, err := currentBCCSP.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk4.SKI())
+	valid, err = currentBCCSP.Verify(pk4, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
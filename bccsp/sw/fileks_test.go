@@ -16,10 +16,18 @@ limitations under the License.
 package sw
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestInvalidStoreKey(t *testing.T) {
@@ -64,3 +72,35 @@ func TestInvalidStoreKey(t *testing.T) {
 		t.Fatal("Error should be different from nil in this case")
 	}
 }
+
+func TestEncryptedKeyStore(t *testing.T) {
+	ksPath, err := ioutil.TempDir("", "bccspks")
+	assert.NoError(t, err)
+	defer os.RemoveAll(ksPath)
+
+	ks, err := NewFileBasedKeyStore([]byte("passwd"), ksPath, false)
+	assert.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	k := &ecdsaPrivateKey{key}
+	assert.NoError(t, ks.StoreKey(k))
+
+	// the private key is stored as an encrypted PKCS#8 structure
+	raw, err := ioutil.ReadFile(filepath.Join(ksPath, hex.EncodeToString(k.SKI())+"_sk"))
+	assert.NoError(t, err)
+	block, _ := pem.Decode(raw)
+	assert.Equal(t, "ENCRYPTED PRIVATE KEY", block.Type)
+
+	k2, err := ks.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, k, k2)
+
+	// without the right password the key cannot be loaded
+	for _, pwd := range [][]byte{nil, []byte("wrong")} {
+		ks, err = NewFileBasedKeyStore(pwd, ksPath, true)
+		assert.NoError(t, err)
+		_, err = ks.GetKey(k.SKI())
+		assert.Error(t, err)
+	}
+}
@@ -18,6 +18,7 @@ package utils
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -425,3 +426,58 @@ func TestPrivateKeyToPEM(t *testing.T) {
 	assert.Equal(t, key.PublicKey.E, key3.(*rsa.PublicKey).E)
 	assert.Equal(t, key.PublicKey.N, key3.(*rsa.PublicKey).N)
 }
+
+func TestED25519Keys(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	// private key, clear and encrypted
+	pem, err := PrivateKeyToPEM(priv, nil)
+	assert.NoError(t, err)
+	key, err := PEMtoPrivateKey(pem, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, priv, key)
+
+	pem, err = PrivateKeyToEncryptedPEM(priv, []byte("passwd"))
+	assert.NoError(t, err)
+	key, err = PEMtoPrivateKey(pem, []byte("passwd"))
+	assert.NoError(t, err)
+	assert.Equal(t, priv, key)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	assert.NoError(t, err)
+	key, err = DERToPrivateKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, priv, key)
+
+	// public key, clear and encrypted
+	pem, err = PublicKeyToPEM(pub, nil)
+	assert.NoError(t, err)
+	key, err = PEMtoPublicKey(pem, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, pub, key)
+
+	pem, err = PublicKeyToEncryptedPEM(pub, []byte("passwd"))
+	assert.NoError(t, err)
+	key, err = PEMtoPublicKey(pem, []byte("passwd"))
+	assert.NoError(t, err)
+	assert.Equal(t, pub, key)
+
+	der, err = PublicKeyToDER(pub)
+	assert.NoError(t, err)
+	key, err = DERToPublicKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, pub, key)
+
+	// malformed keys
+	_, err = PrivateKeyToPEM(ed25519.PrivateKey{0}, nil)
+	assert.Error(t, err)
+	_, err = PrivateKeyToEncryptedPEM(ed25519.PrivateKey{0}, []byte("passwd"))
+	assert.Error(t, err)
+	_, err = PublicKeyToPEM(ed25519.PublicKey{0}, nil)
+	assert.Error(t, err)
+	_, err = PublicKeyToDER(ed25519.PublicKey{0})
+	assert.Error(t, err)
+	_, err = PublicKeyToEncryptedPEM(ed25519.PublicKey{0}, []byte("passwd"))
+	assert.Error(t, err)
+}
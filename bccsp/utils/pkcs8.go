@@ -0,0 +1,220 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Encrypted private keys are stored as PKCS#8 EncryptedPrivateKeyInfo
+// structures (RFC 5208) using the PBES2 scheme (RFC 8018) with
+// PBKDF2-HMAC-SHA256 as key derivation function and AES-256-CBC as
+// cipher. This is the format OpenSSL produces with
+// 'openssl pkcs8 -topk8 -v2 aes-256-cbc -v2prf hmacWithSHA256'.
+
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+const (
+	// pbkdf2SaltSize is the size in bytes of the random salt
+	pbkdf2SaltSize = 16
+	// pbkdf2Iterations is the PBKDF2 iteration count used when encrypting
+	pbkdf2Iterations = 100000
+	// pbkdf2MaxIterations bounds the iteration count accepted when
+	// decrypting, to avoid spending unbounded time on crafted keys
+	pbkdf2MaxIterations = 10000000
+)
+
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// EncryptPKCS8 encrypts the given DER encoded PKCS#8 private key with a key
+// derived from pwd, and returns the DER encoded EncryptedPrivateKeyInfo
+func EncryptPKCS8(der []byte, pwd []byte) ([]byte, error) {
+	if len(der) == 0 {
+		return nil, errors.New("Invalid PKCS#8 private key. It must be different from nil.")
+	}
+	if len(pwd) == 0 {
+		return nil, errors.New("Invalid password. It must be different from nil.")
+	}
+
+	salt := make([]byte, pbkdf2SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("Failed generating salt [%s]", err)
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("Failed generating IV [%s]", err)
+	}
+
+	key := pbkdf2.Key(pwd, salt, pbkdf2Iterations, 32, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := pkcs7Padding(der)
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, plaintext)
+
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt:           salt,
+		IterationCount: pbkdf2Iterations,
+		PRF: pkix.AlgorithmIdentifier{
+			Algorithm:  oidHMACWithSHA256,
+			Parameters: asn1.NullRawValue,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ivParams, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, err
+	}
+	schemeParams, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPBKDF2,
+			Parameters: asn1.RawValue{FullBytes: kdfParams},
+		},
+		EncryptionScheme: pkix.AlgorithmIdentifier{
+			Algorithm:  oidAES256CBC,
+			Parameters: asn1.RawValue{FullBytes: ivParams},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		EncryptionAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPBES2,
+			Parameters: asn1.RawValue{FullBytes: schemeParams},
+		},
+		EncryptedData: ciphertext,
+	})
+}
+
+// DecryptPKCS8 decrypts the given DER encoded EncryptedPrivateKeyInfo with
+// a key derived from pwd, and returns the DER encoded PKCS#8 private key
+func DecryptPKCS8(der []byte, pwd []byte) ([]byte, error) {
+	if len(pwd) == 0 {
+		return nil, errors.New("Invalid password. It must be different from nil.")
+	}
+
+	var info encryptedPrivateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil || len(rest) != 0 {
+		return nil, errors.New("Invalid encrypted PKCS#8 private key")
+	}
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("Unsupported encryption scheme [%s]. Only PBES2 is supported", info.EncryptionAlgorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, errors.New("Invalid PBES2 parameters")
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("Unsupported key derivation function [%s]. Only PBKDF2 is supported", params.KeyDerivationFunc.Algorithm)
+	}
+	if !params.EncryptionScheme.Algorithm.Equal(oidAES256CBC) {
+		return nil, fmt.Errorf("Unsupported cipher [%s]. Only AES-256-CBC is supported", params.EncryptionScheme.Algorithm)
+	}
+
+	var kdfParams pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); err != nil {
+		return nil, errors.New("Invalid PBKDF2 parameters")
+	}
+	if !kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA256) {
+		return nil, fmt.Errorf("Unsupported PBKDF2 pseudorandom function [%s]. Only HMAC-SHA256 is supported", kdfParams.PRF.Algorithm)
+	}
+	if kdfParams.IterationCount <= 0 || kdfParams.IterationCount > pbkdf2MaxIterations {
+		return nil, fmt.Errorf("Invalid PBKDF2 iteration count [%d]", kdfParams.IterationCount)
+	}
+	if kdfParams.KeyLength != 0 && kdfParams.KeyLength != 32 {
+		return nil, fmt.Errorf("Invalid PBKDF2 key length [%d]", kdfParams.KeyLength)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil || len(iv) != aes.BlockSize {
+		return nil, errors.New("Invalid AES-256-CBC IV")
+	}
+	if len(info.EncryptedData) == 0 || len(info.EncryptedData)%aes.BlockSize != 0 {
+		return nil, errors.New("Invalid encrypted data length")
+	}
+
+	key := pbkdf2.Key(pwd, kdfParams.Salt, kdfParams.IterationCount, 32, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, info.EncryptedData)
+
+	// a wrong password almost always results in invalid padding
+	plaintext, err = pkcs7UnPadding(plaintext)
+	if err != nil {
+		return nil, errors.New("Failed decrypting PKCS#8 private key. Wrong password?")
+	}
+	return plaintext, nil
+}
+
+func pkcs7Padding(src []byte) []byte {
+	padding := aes.BlockSize - len(src)%aes.BlockSize
+	return append(src[:len(src):len(src)], bytes.Repeat([]byte{byte(padding)}, padding)...)
+}
+
+func pkcs7UnPadding(src []byte) ([]byte, error) {
+	length := len(src)
+	unpadding := int(src[length-1])
+	if unpadding == 0 || unpadding > aes.BlockSize || unpadding > length {
+		return nil, errors.New("Invalid padding")
+	}
+	for _, b := range src[length-unpadding:] {
+		if int(b) != unpadding {
+			return nil, errors.New("Invalid padding")
+		}
+	}
+	return src[:length-unpadding], nil
+}
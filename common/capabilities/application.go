@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capabilities
+
+import (
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+const (
+	applicationTypeName = "Application"
+
+	// ApplicationV1_1 is the capabilities string for standard new non-backwards compatible
+	// fabric v1.1 application capabilities
+	ApplicationV1_1 = "V1_1"
+
+	// ApplicationV1_2 is the capabilities string for the application capabilities
+	// introduced after fabric v1.1, such as the chaincode lifecycle system chaincode
+	// and channel configurable ACLs. It implies the ApplicationV1_1 capabilities
+	ApplicationV1_2 = "V1_2"
+)
+
+// ApplicationProvider provides capabilities information for application level config
+type ApplicationProvider struct {
+	*registry
+	v11 bool
+	v12 bool
+}
+
+// NewApplicationProvider creates an application capabilities provider
+func NewApplicationProvider(capabilities map[string]*cb.Capability) *ApplicationProvider {
+	ap := &ApplicationProvider{}
+	ap.registry = newRegistry(ap, capabilities)
+	_, ap.v11 = capabilities[ApplicationV1_1]
+	_, ap.v12 = capabilities[ApplicationV1_2]
+	return ap
+}
+
+// Type returns a descriptive string for logging purposes
+func (ap *ApplicationProvider) Type() string {
+	return applicationTypeName
+}
+
+// HasCapability returns true if the capability is supported by this binary
+func (ap *ApplicationProvider) HasCapability(capability string) bool {
+	switch capability {
+	// Add new capability names here
+	case ApplicationV1_1:
+		return true
+	case ApplicationV1_2:
+		return true
+	default:
+		return false
+	}
+}
+
+// ForbidDuplicateTXIdInBlock specifies whether two transactions with the same TXId are permitted
+// in the same block or whether we mark the second one as TxValidationCode_DUPLICATE_TXID
+func (ap *ApplicationProvider) ForbidDuplicateTXIdInBlock() bool {
+	return ap.v11 || ap.v12
+}
+
+// ChaincodeLifecycle specifies whether chaincodes may be defined through the _lifecycle
+// system chaincode and whether the init-required flag of their definitions is enforced
+func (ap *ApplicationProvider) ChaincodeLifecycle() bool {
+	return ap.v12
+}
+
+// QueryProposals specifies whether peers simulate proposals marked as queries with the
+// query timeout of the chaincode and refuse to endorse them
+func (ap *ApplicationProvider) QueryProposals() bool {
+	return ap.v12
+}
+
+// ACLs specifies whether the application config may carry ACLs mapping peer resources
+// to channel policies
+func (ap *ApplicationProvider) ACLs() bool {
+	return ap.v12
+}
@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capabilities
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSatisfied(t *testing.T) {
+	var capsMap map[string]*cb.Capability
+	for _, provider := range []*registry{
+		NewChannelProvider(capsMap).registry,
+		NewOrdererProvider(capsMap).registry,
+		NewApplicationProvider(capsMap).registry,
+	} {
+		assert.Nil(t, provider.Supported())
+	}
+}
+
+func TestNotSatisfied(t *testing.T) {
+	capsMap := map[string]*cb.Capability{
+		"FakeCapability": &cb.Capability{},
+	}
+	for _, provider := range []*registry{
+		NewChannelProvider(capsMap).registry,
+		NewOrdererProvider(capsMap).registry,
+		NewApplicationProvider(capsMap).registry,
+	} {
+		assert.Error(t, provider.Supported())
+	}
+}
+
+func TestChannelV10(t *testing.T) {
+	cp := NewChannelProvider(map[string]*cb.Capability{})
+	assert.NoError(t, cp.Supported())
+	assert.Equal(t, msp.MSPv1_0, cp.MSPVersion())
+}
+
+func TestChannelV11(t *testing.T) {
+	cp := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV1_1: &cb.Capability{},
+	})
+	assert.NoError(t, cp.Supported())
+	assert.Equal(t, msp.MSPv1_1, cp.MSPVersion())
+}
+
+func TestOrdererV10(t *testing.T) {
+	op := NewOrdererProvider(map[string]*cb.Capability{})
+	assert.NoError(t, op.Supported())
+	assert.False(t, op.PredictableChannelTemplate())
+}
+
+func TestOrdererV11(t *testing.T) {
+	op := NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_1: &cb.Capability{},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.PredictableChannelTemplate())
+}
+
+func TestApplicationV10(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{})
+	assert.NoError(t, ap.Supported())
+	assert.False(t, ap.ForbidDuplicateTXIdInBlock())
+	assert.False(t, ap.ChaincodeLifecycle())
+	assert.False(t, ap.QueryProposals())
+	assert.False(t, ap.ACLs())
+}
+
+func TestApplicationV11(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_1: &cb.Capability{},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.ForbidDuplicateTXIdInBlock())
+	assert.False(t, ap.ChaincodeLifecycle())
+	assert.False(t, ap.QueryProposals())
+	assert.False(t, ap.ACLs())
+}
+
+func TestApplicationV12(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_2: &cb.Capability{},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.ForbidDuplicateTXIdInBlock())
+	assert.True(t, ap.ChaincodeLifecycle())
+	assert.True(t, ap.QueryProposals())
+	assert.True(t, ap.ACLs())
+}
+
+func TestUnsupportedErrorNamesCapability(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_1: &cb.Capability{},
+		"V9_9":          &cb.Capability{},
+	})
+	err := ap.Supported()
+	assert.EqualError(t, err, "Application capability V9_9 is required but not supported")
+}
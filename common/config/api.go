@@ -21,6 +21,7 @@ package config
 import (
 	"time"
 
+	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -47,6 +48,19 @@ type ApplicationOrg interface {
 type Application interface {
 	// Organizations returns a map of org ID to ApplicationOrg
 	Organizations() map[string]ApplicationOrg
+
+	// APIPolicyMapper returns a PolicyMapper that maps API names to policies
+	APIPolicyMapper() PolicyMapper
+
+	// Capabilities defines the capabilities for the application portion of a channel
+	Capabilities() ApplicationCapabilities
+}
+
+// PolicyMapper is an interface for mapping API names to policies
+type PolicyMapper interface {
+	// PolicyRefForAPI takes the name of an API, and returns the policy name
+	// or the empty string if the API is not found
+	PolicyRefForAPI(apiName string) string
 }
 
 // Channel gives read only access to the channel configuration
@@ -61,6 +75,9 @@ type Channel interface {
 
 	// OrdererAddresses returns the list of valid orderer addresses to connect to to invoke Broadcast/Deliver
 	OrdererAddresses() []string
+
+	// Capabilities defines the capabilities for a channel
+	Capabilities() ChannelCapabilities
 }
 
 // Consortiums represents the set of consortiums serviced by an ordering service
@@ -96,6 +113,50 @@ type Orderer interface {
 
 	// Organizations returns the organizations for the ordering service
 	Organizations() map[string]Org
+
+	// Capabilities defines the capabilities for the orderer portion of a channel
+	Capabilities() OrdererCapabilities
+}
+
+// ChannelCapabilities defines the capabilities for a channel
+type ChannelCapabilities interface {
+	// Supported returns an error if there are unknown capabilities in this channel which are required
+	Supported() error
+
+	// MSPVersion specifies the version of the behavior of the MSPs of the channel
+	MSPVersion() msp.MSPVersion
+}
+
+// OrdererCapabilities defines the capabilities for the orderer portion of a channel
+type OrdererCapabilities interface {
+	// Supported returns an error if there are unknown capabilities in this channel which are required
+	Supported() error
+
+	// PredictableChannelTemplate specifies whether the v1.0 undesirable behavior of setting the /Channel
+	// group's mod_policy to "" and copying versions from the orderer system channel config should be fixed or not
+	PredictableChannelTemplate() bool
+}
+
+// ApplicationCapabilities defines the capabilities for the application portion of a channel
+type ApplicationCapabilities interface {
+	// Supported returns an error if there are unknown capabilities in this channel which are required
+	Supported() error
+
+	// ForbidDuplicateTXIdInBlock specifies whether two transactions with the same TXId are permitted
+	// in the same block or whether we mark the second one as TxValidationCode_DUPLICATE_TXID
+	ForbidDuplicateTXIdInBlock() bool
+
+	// ChaincodeLifecycle specifies whether chaincodes may be defined through the _lifecycle
+	// system chaincode and whether the init-required flag of their definitions is enforced
+	ChaincodeLifecycle() bool
+
+	// QueryProposals specifies whether peers simulate proposals marked as queries with the
+	// query timeout of the chaincode and refuse to endorse them
+	QueryProposals() bool
+
+	// ACLs specifies whether the application config may carry ACLs mapping peer resources
+	// to channel policies
+	ACLs() bool
 }
 
 type ValueProposer interface {
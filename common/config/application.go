@@ -19,12 +19,18 @@ package config
 import (
 	"fmt"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/config/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 const (
 	// ApplicationGroupKey is the group name for the Application config
 	ApplicationGroupKey = "Application"
+
+	// ACLsKey is the name of the ACLs config
+	ACLsKey = "ACLs"
 )
 
 // ApplicationGroup represents the application config group
@@ -34,8 +40,15 @@ type ApplicationGroup struct {
 	mspConfig *msp.MSPConfigHandler
 }
 
+// ApplicationProtos is used as the source of the ApplicationConfig
+type ApplicationProtos struct {
+	ACLs         *pb.ACLs
+	Capabilities *cb.Capabilities
+}
+
 type ApplicationConfig struct {
 	*standardValues
+	protos *ApplicationProtos
 
 	applicationGroup *ApplicationGroup
 	applicationOrgs  map[string]ApplicationOrg
@@ -61,17 +74,18 @@ func (ag *ApplicationGroup) Allocate() Values {
 }
 
 func NewApplicationConfig(ag *ApplicationGroup) *ApplicationConfig {
-	sv, err := NewStandardValues(&(struct{}{}))
+	ac := &ApplicationConfig{
+		applicationGroup: ag,
+		protos:           &ApplicationProtos{},
+	}
+
+	var err error
+	ac.standardValues, err = NewStandardValues(ac.protos)
 	if err != nil {
 		logger.Panicf("Programming error: %s", err)
 	}
 
-	return &ApplicationConfig{
-		applicationGroup: ag,
-
-		// Currently there are no config values
-		standardValues: sv,
-	}
+	return ac
 }
 
 func (ac *ApplicationConfig) Validate(tx interface{}, groups map[string]ValueProposer) error {
@@ -83,6 +97,18 @@ func (ac *ApplicationConfig) Validate(tx interface{}, groups map[string]ValuePro
 			return fmt.Errorf("Application sub-group %s was not an ApplicationOrgGroup, actually %T", key, value)
 		}
 	}
+	return ac.validateACLs()
+}
+
+func (ac *ApplicationConfig) validateACLs() error {
+	if len(ac.protos.ACLs.Acls) > 0 && !ac.Capabilities().ACLs() {
+		return fmt.Errorf("ACLs may not be set unless the %s application capability is required", capabilities.ApplicationV1_2)
+	}
+	for apiName, resource := range ac.protos.ACLs.Acls {
+		if resource == nil || resource.PolicyRef == "" {
+			return fmt.Errorf("ACL for API %s does not reference a policy", apiName)
+		}
+	}
 	return nil
 }
 
@@ -94,3 +120,28 @@ func (ac *ApplicationConfig) Commit() {
 func (ac *ApplicationConfig) Organizations() map[string]ApplicationOrg {
 	return ac.applicationOrgs
 }
+
+// Capabilities returns information about the available capabilities for application
+func (ac *ApplicationConfig) Capabilities() ApplicationCapabilities {
+	return capabilities.NewApplicationProvider(ac.protos.Capabilities.GetCapabilities())
+}
+
+// APIPolicyMapper returns a PolicyMapper that maps API names to the
+// policies defined in the ACLs of the channel
+func (ac *ApplicationConfig) APIPolicyMapper() PolicyMapper {
+	return &aclsPolicyMapper{acls: ac.protos.ACLs.Acls}
+}
+
+type aclsPolicyMapper struct {
+	acls map[string]*pb.APIResource
+}
+
+// PolicyRefForAPI returns the policy reference for the given API name,
+// or the empty string if the ACLs do not define one
+func (apm *aclsPolicyMapper) PolicyRefForAPI(apiName string) string {
+	resource, ok := apm.acls[apiName]
+	if !ok {
+		return ""
+	}
+	return resource.PolicyRef
+}
@@ -19,7 +19,11 @@ package config
 import (
 	"testing"
 
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+
 	logging "github.com/op/go-logging"
+	"github.com/stretchr/testify/assert"
 )
 
 func init() {
@@ -29,3 +33,49 @@ func init() {
 func TestApplicationInterface(t *testing.T) {
 	_ = Application((*ApplicationGroup)(nil))
 }
+
+func TestACLs(t *testing.T) {
+	ac := NewApplicationConfig(NewApplicationGroup(nil))
+	assert.NoError(t, ac.validateACLs(), "No ACLs is valid")
+	assert.Equal(t, "", ac.APIPolicyMapper().PolicyRefForAPI("qscc/GetChainInfo"))
+
+	ac.protos.ACLs.Acls = map[string]*pb.APIResource{
+		"qscc/GetChainInfo": {PolicyRef: "/Channel/Application/Admins"},
+	}
+	assert.Error(t, ac.validateACLs(), "ACLs without the V1_2 capability")
+
+	ac.protos.Capabilities.Capabilities = map[string]*cb.Capability{"V1_2": {}}
+	assert.NoError(t, ac.validateACLs(), "ACLs were valid")
+	assert.Equal(t, "/Channel/Application/Admins", ac.APIPolicyMapper().PolicyRefForAPI("qscc/GetChainInfo"))
+	assert.Equal(t, "", ac.APIPolicyMapper().PolicyRefForAPI("qscc/GetBlockByNumber"))
+
+	ac.protos.ACLs.Acls["qscc/GetBlockByNumber"] = &pb.APIResource{}
+	assert.Error(t, ac.validateACLs(), "ACL without a policy reference")
+}
+
+func TestTemplateACLs(t *testing.T) {
+	ac := NewApplicationConfig(NewApplicationGroup(nil))
+	cg := TemplateACLs(map[string]string{"peer/Propose": "Writers"})
+	_, err := ac.Deserialize(ACLsKey, cg.Groups[ApplicationGroupKey].Values[ACLsKey].Value)
+	assert.NoError(t, err)
+	assert.Equal(t, "Writers", ac.APIPolicyMapper().PolicyRefForAPI("peer/Propose"))
+}
+
+func TestApplicationCapabilities(t *testing.T) {
+	ac := NewApplicationConfig(NewApplicationGroup(nil))
+	assert.NoError(t, ac.Capabilities().Supported())
+	assert.False(t, ac.Capabilities().ForbidDuplicateTXIdInBlock())
+
+	cg := TemplateApplicationCapabilities([]string{"V1_1"})
+	_, err := ac.Deserialize(CapabilitiesKey, cg.Groups[ApplicationGroupKey].Values[CapabilitiesKey].Value)
+	assert.NoError(t, err)
+	assert.NoError(t, ac.Capabilities().Supported())
+	assert.True(t, ac.Capabilities().ForbidDuplicateTXIdInBlock())
+	assert.False(t, ac.Capabilities().ACLs())
+
+	cg = TemplateApplicationCapabilities([]string{"V1_2"})
+	_, err = ac.Deserialize(CapabilitiesKey, cg.Groups[ApplicationGroupKey].Values[CapabilitiesKey].Value)
+	assert.NoError(t, err)
+	assert.True(t, ac.Capabilities().ChaincodeLifecycle())
+	assert.True(t, ac.Capabilities().ACLs())
+}
@@ -32,6 +32,33 @@ func applicationConfigGroup(orgID string, key string, value []byte) *cb.ConfigGr
 	return result
 }
 
+// TemplateACLs creates a headerless config item representing the ACLs of the
+// channel, which map API names to the policies they are checked against
+func TemplateACLs(acls map[string]string) *cb.ConfigGroup {
+	result := cb.NewConfigGroup()
+	result.Groups[ApplicationGroupKey] = cb.NewConfigGroup()
+
+	apiResources := make(map[string]*pb.APIResource)
+	for apiName, policyRef := range acls {
+		apiResources[apiName] = &pb.APIResource{PolicyRef: policyRef}
+	}
+	result.Groups[ApplicationGroupKey].Values[ACLsKey] = &cb.ConfigValue{
+		Value: utils.MarshalOrPanic(&pb.ACLs{Acls: apiResources}),
+	}
+	return result
+}
+
+// TemplateApplicationCapabilities creates a headerless config item representing the capabilities
+// the peers must support to process the channel
+func TemplateApplicationCapabilities(capabilities []string) *cb.ConfigGroup {
+	result := cb.NewConfigGroup()
+	result.Groups[ApplicationGroupKey] = cb.NewConfigGroup()
+	result.Groups[ApplicationGroupKey].Values[CapabilitiesKey] = &cb.ConfigValue{
+		Value: utils.MarshalOrPanic(capabilitiesFromNames(capabilities)),
+	}
+	return result
+}
+
 // TemplateAnchorPeers creates a headerless config item representing the anchor peers
 func TemplateAnchorPeers(orgID string, anchorPeers []*pb.AnchorPeer) *cb.ConfigGroup {
 	return applicationConfigGroup(orgID, AnchorPeersKey, utils.MarshalOrPanic(&pb.AnchorPeers{AnchorPeers: anchorPeers}))
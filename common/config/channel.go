@@ -21,8 +21,10 @@ import (
 	"math"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/config/msp"
 	"github.com/hyperledger/fabric/common/util"
+	mspi "github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
 )
 
@@ -40,6 +42,10 @@ const (
 	// OrdererAddressesKey is the cb.ConfigItem type key name for the OrdererAddresses message
 	OrdererAddressesKey = "OrdererAddresses"
 
+	// CapabilitiesKey is the name of the key which refers to capabilities, it appears at the channel,
+	// application, and orderer levels and this constant is used for all three
+	CapabilitiesKey = "Capabilities"
+
 	// GroupKey is the name of the channel group
 	ChannelGroupKey = "Channel"
 )
@@ -56,6 +62,9 @@ type ChannelValues interface {
 
 	// OrdererAddresses returns the list of valid orderer addresses to connect to to invoke Broadcast/Deliver
 	OrdererAddresses() []string
+
+	// Capabilities returns the capabilities required by the channel
+	Capabilities() ChannelCapabilities
 }
 
 // ChannelProtos is where the proposed configuration is unmarshaled into
@@ -64,6 +73,7 @@ type ChannelProtos struct {
 	BlockDataHashingStructure *cb.BlockDataHashingStructure
 	OrdererAddresses          *cb.OrdererAddresses
 	Consortium                *cb.Consortium
+	Capabilities              *cb.Capabilities
 }
 
 type channelConfigSetter struct {
@@ -91,6 +101,20 @@ func NewChannelGroup(mspConfigHandler *msp.MSPConfigHandler) *ChannelGroup {
 	return cg
 }
 
+// BeginValueProposals starts a config proposal, whose MSPs behave
+// as the version the proposed channel capabilities select
+func (cg *ChannelGroup) BeginValueProposals(tx interface{}, groups []string) (ValueDeserializer, []ValueProposer, error) {
+	values, groupProposers, err := cg.Proposer.BeginValueProposals(tx, groups)
+	if err != nil {
+		return nil, nil, err
+	}
+	pending := values.(*channelConfigSetter)
+	cg.mspConfigHandler.ProposeVersion(tx, func() mspi.MSPVersion {
+		return pending.Capabilities().MSPVersion()
+	})
+	return values, groupProposers, nil
+}
+
 // Allocate creates new config resources for a pending config update
 func (cg *ChannelGroup) Allocate() Values {
 	return &channelConfigSetter{
@@ -169,6 +193,11 @@ func (cc *ChannelConfig) OrdererAddresses() []string {
 	return cc.protos.OrdererAddresses.Addresses
 }
 
+// Capabilities returns information about the available capabilities for this channel
+func (cc *ChannelConfig) Capabilities() ChannelCapabilities {
+	return capabilities.NewChannelProvider(cc.protos.Capabilities.GetCapabilities())
+}
+
 // ConsortiumName returns the name of the consortium this channel was created under
 func (cc *ChannelConfig) ConsortiumName() string {
 	return cc.protos.Consortium.Name
@@ -21,9 +21,14 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/config/msp"
 	"github.com/hyperledger/fabric/common/util"
+	coreconfig "github.com/hyperledger/fabric/core/config"
+	mspi "github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 
 	logging "github.com/op/go-logging"
 	"github.com/stretchr/testify/assert"
@@ -136,6 +141,58 @@ func TestConsortiumName(t *testing.T) {
 	assert.Equal(t, "TestConsortium", cc.ConsortiumName(), "Unexpected consortium name returned")
 }
 
+func TestChannelCapabilities(t *testing.T) {
+	cc := NewChannelConfig()
+	assert.NoError(t, cc.Capabilities().Supported(), "No capabilities are required")
+
+	cg := TemplateChannelCapabilities([]string{"V1_1"})
+	_, err := cc.Deserialize(CapabilitiesKey, cg.Values[CapabilitiesKey].Value)
+	assert.NoError(t, err)
+	assert.NoError(t, cc.Capabilities().Supported(), "V1_1 is supported")
+
+	cg = TemplateChannelCapabilities([]string{"V1_1", "FakeCapability"})
+	_, err = cc.Deserialize(CapabilitiesKey, cg.Values[CapabilitiesKey].Value)
+	assert.NoError(t, err)
+	assert.Error(t, cc.Capabilities().Supported(), "FakeCapability is not supported")
+}
+
+func TestChannelGroupMSPVersion(t *testing.T) {
+	mspDir, err := coreconfig.GetDevMspDir()
+	assert.NoError(t, err)
+	conf, err := mspi.GetLocalMspConfig(mspDir, nil, "DEFAULT")
+	assert.NoError(t, err)
+	fabricConf := &mspprotos.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, fabricConf))
+	fabricConf.FabricNodeOus = &mspprotos.FabricNodeOUs{
+		Enable:             true,
+		ClientOuIdentifier: &mspprotos.FabricOUIdentifier{OrganizationalUnitIdentifier: "COP"},
+	}
+	conf.Config, err = proto.Marshal(fabricConf)
+	assert.NoError(t, err)
+
+	// NodeOUs are accepted only once the V1_1 channel capability is proposed
+	for _, capabilities := range [][]string{nil, {"V1_1"}} {
+		mspHandler := msp.NewMSPConfigHandler()
+		cg := NewChannelGroup(mspHandler)
+		mspHandler.BeginConfig(t)
+		vd, _, err := cg.BeginValueProposals(t, nil)
+		assert.NoError(t, err)
+		if capabilities != nil {
+			cap := TemplateChannelCapabilities(capabilities)
+			_, err = vd.Deserialize(CapabilitiesKey, cap.Values[CapabilitiesKey].Value)
+			assert.NoError(t, err)
+		}
+		_, err = mspHandler.ProposeMSP(t, conf)
+		if capabilities == nil {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+		cg.RollbackProposals(t)
+		mspHandler.RollbackProposals(t)
+	}
+}
+
 func TestChannelUtils(t *testing.T) {
 	// these functions all panic if marshaling fails so just executing them is sufficient
 	_ = TemplateConsortium("test")
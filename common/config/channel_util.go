@@ -72,3 +72,16 @@ func TemplateOrdererAddresses(addresses []string) *cb.ConfigGroup {
 func DefaultOrdererAddresses() *cb.ConfigGroup {
 	return TemplateOrdererAddresses(defaultOrdererAddresses)
 }
+
+func capabilitiesFromNames(capabilities []string) *cb.Capabilities {
+	result := &cb.Capabilities{Capabilities: make(map[string]*cb.Capability)}
+	for _, capability := range capabilities {
+		result.Capabilities[capability] = &cb.Capability{}
+	}
+	return result
+}
+
+// TemplateChannelCapabilities creates a headerless config item representing the capabilities required by the channel
+func TemplateChannelCapabilities(capabilities []string) *cb.ConfigGroup {
+	return configGroup(CapabilitiesKey, utils.MarshalOrPanic(capabilitiesFromNames(capabilities)))
+}
@@ -33,6 +33,7 @@ type pendingMSPConfig struct {
 type mspConfigStore struct {
 	idMap       map[string]*pendingMSPConfig
 	proposedMgr msp.MSPManager
+	version     func() msp.MSPVersion
 }
 
 // MSPConfigHandler
@@ -81,6 +82,19 @@ func (bh *MSPConfigHandler) CommitProposals(tx interface{}) {
 	delete(bh.pendingConfig, tx)
 }
 
+// ProposeVersion sets where the MSP version of a config proposal comes from.
+// It's consulted as the MSPs are proposed, after all the config values of
+// the proposal have been deserialized. Without it, MSPs are of version v1.0
+func (bh *MSPConfigHandler) ProposeVersion(tx interface{}, version func() msp.MSPVersion) {
+	bh.pendingLock.RLock()
+	pendingConfig, ok := bh.pendingConfig[tx]
+	bh.pendingLock.RUnlock()
+	if !ok {
+		panic("Programming error, called ProposeVersion for tx which was not started")
+	}
+	pendingConfig.version = version
+}
+
 // ProposeValue called when config is added to a proposal
 func (bh *MSPConfigHandler) ProposeMSP(tx interface{}, mspConfig *mspprotos.MSPConfig) (msp.MSP, error) {
 	bh.pendingLock.RLock()
@@ -90,13 +104,14 @@ func (bh *MSPConfigHandler) ProposeMSP(tx interface{}, mspConfig *mspprotos.MSPC
 		panic("Programming error, called BeginConfig multiply for the same tx")
 	}
 
-	// check that the type for that MSP is supported
-	if mspConfig.Type != int32(msp.FABRIC) {
-		return nil, fmt.Errorf("Setup error: unsupported msp type %d", mspConfig.Type)
+	version := msp.MSPv1_0
+	if pendingConfig.version != nil {
+		version = pendingConfig.version()
 	}
 
-	// create the msp instance
-	mspInst, err := msp.NewBccspMsp()
+	// create the msp instance, this fails if the type
+	// for that MSP is not supported
+	mspInst, err := msp.New(msp.ProviderType(mspConfig.Type), version)
 	if err != nil {
 		return nil, fmt.Errorf("Creating the MSP manager failed, err %s", err)
 	}
@@ -19,6 +19,7 @@ package msp
 import (
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/msp"
@@ -79,6 +80,42 @@ func TestMSPConfigManager(t *testing.T) {
 	}, "Expected panic calling BeginConfig multiple times for same transaction")
 }
 
+func TestMSPConfigVersion(t *testing.T) {
+	mspDir, err := config.GetDevMspDir()
+	assert.NoError(t, err)
+	conf, err := msp.GetLocalMspConfig(mspDir, nil, "DEFAULT")
+	assert.NoError(t, err)
+	fabricConf := &mspprotos.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, fabricConf))
+	fabricConf.FabricNodeOus = &mspprotos.FabricNodeOUs{
+		Enable:             true,
+		ClientOuIdentifier: &mspprotos.FabricOUIdentifier{OrganizationalUnitIdentifier: "COP"},
+	}
+	conf.Config, err = proto.Marshal(fabricConf)
+	assert.NoError(t, err)
+
+	// Without a version, the MSPs are v1.0, which refuse NodeOUs
+	mspCH := NewMSPConfigHandler()
+	mspCH.BeginConfig(t)
+	_, err = mspCH.ProposeMSP(t, conf)
+	assert.Error(t, err)
+	mspCH.RollbackProposals(t)
+
+	assert.Panics(t, func() {
+		mspCH.ProposeVersion(t, func() msp.MSPVersion { return msp.MSPv1_1 })
+	}, "Expected panic calling ProposeVersion before beginning transaction")
+
+	// The version is read as the MSPs are proposed
+	mspCH.BeginConfig(t)
+	version := msp.MSPv1_0
+	mspCH.ProposeVersion(t, func() msp.MSPVersion { return version })
+	version = msp.MSPv1_1
+	_, err = mspCH.ProposeMSP(t, conf)
+	assert.NoError(t, err)
+	assert.NoError(t, mspCH.PreCommit(t))
+	mspCH.CommitProposals(t)
+}
+
 func TestTemplates(t *testing.T) {
 	mspDir, err := config.GetDevMspDir()
 	assert.NoError(t, err)
@@ -108,3 +145,27 @@ func TestTemplates(t *testing.T) {
 	}, "Expected panic with bad msp config")
 
 }
+
+func TestMSPConfigManagerIdemix(t *testing.T) {
+	conf, err := msp.GetIdemixMspConfig("../../../msp/testdata/idemix/MSP1OU1", "MSP1OU1")
+	assert.NoError(t, err)
+
+	// Channels without the v1.1 MSP version refuse idemix MSPs
+	mspCH := NewMSPConfigHandler()
+	mspCH.BeginConfig(t)
+	_, err = mspCH.ProposeMSP(t, conf)
+	assert.Error(t, err)
+	mspCH.RollbackProposals(t)
+
+	mspCH.BeginConfig(t)
+	mspCH.ProposeVersion(t, func() msp.MSPVersion { return msp.MSPv1_1 })
+	mspInst, err := mspCH.ProposeMSP(t, conf)
+	assert.NoError(t, err)
+	assert.Equal(t, msp.IDEMIX, mspInst.GetType())
+	mspCH.PreCommit(t)
+	mspCH.CommitProposals(t)
+
+	msps, err := mspCH.GetMSPs()
+	assert.NoError(t, err)
+	assert.Contains(t, msps, "MSP1OU1")
+}
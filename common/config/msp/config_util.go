@@ -45,13 +45,10 @@ const (
 // TemplateGroupMSPWithAdminRolePrincipal creates an MSP ConfigValue at the given configPath with Admin policy
 // of role type ADMIN if admin==true or MEMBER otherwise
 func TemplateGroupMSPWithAdminRolePrincipal(configPath []string, mspConfig *mspprotos.MSPConfig, admin bool) *cb.ConfigGroup {
-	// check that the type for that MSP is supported
-	if mspConfig.Type != int32(msp.FABRIC) {
-		logger.Panicf("Setup error: unsupported msp type %d", mspConfig.Type)
-	}
-
-	// create the msp instance
-	mspInst, err := msp.NewBccspMsp()
+	// create the msp instance, this fails if the type
+	// for that MSP is not supported. The MSP is only set up
+	// to learn its ID, so it takes the latest version
+	mspInst, err := msp.New(msp.ProviderType(mspConfig.Type), msp.MSPv1_1)
 	if err != nil {
 		logger.Panicf("Creating the MSP manager failed, err %s", err)
 	}
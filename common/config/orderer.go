@@ -23,7 +23,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/config/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 )
 
@@ -56,6 +58,7 @@ type OrdererProtos struct {
 	BatchTimeout        *ab.BatchTimeout
 	KafkaBrokers        *ab.KafkaBrokers
 	ChannelRestrictions *ab.ChannelRestrictions
+	Capabilities        *cb.Capabilities
 }
 
 // Config is stores the orderer component configuration
@@ -146,6 +149,11 @@ func (oc *OrdererConfig) Organizations() map[string]Org {
 	return oc.orgs
 }
 
+// Capabilities returns the capabilities the ordering network requires for this channel
+func (oc *OrdererConfig) Capabilities() OrdererCapabilities {
+	return capabilities.NewOrdererProvider(oc.protos.Capabilities.GetCapabilities())
+}
+
 func (oc *OrdererConfig) Validate(tx interface{}, groups map[string]ValueProposer) error {
 	for _, validator := range []func() error{
 		oc.validateConsensusType,
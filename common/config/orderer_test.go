@@ -83,3 +83,20 @@ func TestKafkaBrokers(t *testing.T) {
 	oc = &OrdererConfig{protos: &OrdererProtos{KafkaBrokers: &ab.KafkaBrokers{Brokers: []string{"127.0.0.1", "foo.bar", "127.0.0.1:-1", "localhost:65536", "foo.bar.:9092", ".127.0.0.1:9092", "-foo.bar:9092"}}}}
 	assert.Error(t, oc.validateKafkaBrokers(), "Invalid kafka brokers")
 }
+
+func TestOrdererCapabilities(t *testing.T) {
+	oc := NewOrdererConfig(NewOrdererGroup(nil))
+	assert.NoError(t, oc.Capabilities().Supported())
+	assert.False(t, oc.Capabilities().PredictableChannelTemplate())
+
+	cg := TemplateOrdererCapabilities([]string{"V1_1"})
+	_, err := oc.Deserialize(CapabilitiesKey, cg.Groups[OrdererGroupKey].Values[CapabilitiesKey].Value)
+	assert.NoError(t, err)
+	assert.NoError(t, oc.Capabilities().Supported())
+	assert.True(t, oc.Capabilities().PredictableChannelTemplate())
+
+	cg = TemplateOrdererCapabilities([]string{"FakeCapability"})
+	_, err = oc.Deserialize(CapabilitiesKey, cg.Groups[OrdererGroupKey].Values[CapabilitiesKey].Value)
+	assert.NoError(t, err)
+	assert.Error(t, oc.Capabilities().Supported())
+}
@@ -55,3 +55,9 @@ func TemplateChannelRestrictions(maxChannels uint64) *cb.ConfigGroup {
 func TemplateKafkaBrokers(brokers []string) *cb.ConfigGroup {
 	return ordererConfigGroup(KafkaBrokersKey, utils.MarshalOrPanic(&ab.KafkaBrokers{Brokers: brokers}))
 }
+
+// TemplateOrdererCapabilities creates a headerless config item representing the capabilities
+// the orderers must support to process the channel
+func TemplateOrdererCapabilities(capabilities []string) *cb.ConfigGroup {
+	return ordererConfigGroup(CapabilitiesKey, utils.MarshalOrPanic(capabilitiesFromNames(capabilities)))
+}
@@ -82,10 +82,11 @@ type TopLevel struct {
 
 // Profile encodes orderer/application configuration combinations for the configtxgen tool.
 type Profile struct {
-	Consortium  string                 `yaml:"Consortium"`
-	Application *Application           `yaml:"Application"`
-	Orderer     *Orderer               `yaml:"Orderer"`
-	Consortiums map[string]*Consortium `yaml:"Consortiums"`
+	Consortium   string                 `yaml:"Consortium"`
+	Application  *Application           `yaml:"Application"`
+	Orderer      *Orderer               `yaml:"Orderer"`
+	Consortiums  map[string]*Consortium `yaml:"Consortiums"`
+	Capabilities map[string]bool        `yaml:"Capabilities"`
 }
 
 // Consortium represents a group of organizations which may create channels with eachother
@@ -95,7 +96,9 @@ type Consortium struct {
 
 // Application encodes the application-level configuration needed in config transactions.
 type Application struct {
-	Organizations []*Organization `yaml:"Organizations"`
+	Organizations []*Organization   `yaml:"Organizations"`
+	ACLs          map[string]string `yaml:"ACLs"`
+	Capabilities  map[string]bool   `yaml:"Capabilities"`
 }
 
 // Organization encodes the organization-level configuration needed in config transactions.
@@ -133,6 +136,7 @@ type Orderer struct {
 	Kafka         Kafka           `yaml:"Kafka"`
 	Organizations []*Organization `yaml:"Organizations"`
 	MaxChannels   uint64          `yaml:"MaxChannels"`
+	Capabilities  map[string]bool `yaml:"Capabilities"`
 }
 
 // BatchSize contains configuration affecting the size of batches.
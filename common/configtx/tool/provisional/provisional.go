@@ -18,6 +18,7 @@ package provisional
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/config"
@@ -101,6 +102,10 @@ func New(conf *genesisconfig.Profile) Generator {
 		},
 	}
 
+	if capabilities := enabledCapabilities(conf.Capabilities); len(capabilities) > 0 {
+		bs.channelGroups = append(bs.channelGroups, config.TemplateChannelCapabilities(capabilities))
+	}
+
 	if conf.Orderer != nil {
 		// Orderer addresses
 		oa := config.TemplateOrdererAddresses(conf.Orderer.Addresses)
@@ -138,6 +143,10 @@ func New(conf *genesisconfig.Profile) Generator {
 			)
 		}
 
+		if capabilities := enabledCapabilities(conf.Orderer.Capabilities); len(capabilities) > 0 {
+			bs.ordererGroups = append(bs.ordererGroups, config.TemplateOrdererCapabilities(capabilities))
+		}
+
 		switch conf.Orderer.OrdererType {
 		case ConsensusTypeSolo:
 		case ConsensusTypeKafka:
@@ -177,6 +186,14 @@ func New(conf *genesisconfig.Profile) Generator {
 			bs.applicationGroups = append(bs.applicationGroups, config.TemplateAnchorPeers(org.Name, anchorProtos))
 		}
 
+		if len(conf.Application.ACLs) > 0 {
+			bs.applicationGroups = append(bs.applicationGroups, config.TemplateACLs(conf.Application.ACLs))
+		}
+
+		if capabilities := enabledCapabilities(conf.Application.Capabilities); len(capabilities) > 0 {
+			bs.applicationGroups = append(bs.applicationGroups, config.TemplateApplicationCapabilities(capabilities))
+		}
+
 	}
 
 	if conf.Consortiums != nil {
@@ -226,6 +243,18 @@ func New(conf *genesisconfig.Profile) Generator {
 	return bs
 }
 
+// enabledCapabilities returns the sorted names of the capabilities set to true
+func enabledCapabilities(capabilities map[string]bool) []string {
+	var names []string
+	for name, enabled := range capabilities {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // ChannelTemplate TODO
 func (bs *bootstrapper) ChannelTemplate() configtx.Template {
 	return configtx.NewModPolicySettingTemplate(
@@ -71,3 +71,12 @@ func TestGenesisBlockForChannelHeader(t *testing.T) {
 		assert.Nil(t, genesisBlock.Header.PreviousHash, "Case %s: Header previousHash to be nil", tc.Orderer.OrdererType)
 	}
 }
+
+func TestEnabledCapabilities(t *testing.T) {
+	assert.Empty(t, enabledCapabilities(nil))
+	assert.Equal(t, []string{"V1_1", "V1_2"}, enabledCapabilities(map[string]bool{
+		"V1_2": true,
+		"V1_0": false,
+		"V1_1": true,
+	}))
+}
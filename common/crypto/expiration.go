@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/protos/msp"
+)
+
+var logger = flogging.MustGetLogger("crypto")
+
+// ExpiresAt returns when the given serialized identity expires, or the zero
+// time if the identity is not an X.509 identity or cannot be parsed
+func ExpiresAt(identityBytes []byte) time.Time {
+	sId := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(identityBytes, sId); err != nil {
+		return time.Time{}
+	}
+	return CertExpiresAt(sId.IdBytes)
+}
+
+// CertExpiresAt returns when the given PEM encoded certificate expires, or
+// the zero time if it cannot be parsed
+func CertExpiresAt(certPEM []byte) time.Time {
+	bl, _ := pem.Decode(certPEM)
+	if bl == nil {
+		return time.Time{}
+	}
+	cert, err := x509.ParseCertificate(bl.Bytes)
+	if err != nil {
+		return time.Time{}
+	}
+	return cert.NotAfter
+}
+
+// IsExpired returns whether the given serialized identity has an expiration
+// time that is not after now
+func IsExpired(identityBytes []byte, now time.Time) bool {
+	expiresAt := ExpiresAt(identityBytes)
+	return !expiresAt.IsZero() && !now.Before(expiresAt)
+}
+
+// CertificateExpiration names a certificate of a node and when it expires
+type CertificateExpiration struct {
+	// Name identifies the certificate in the logs and in the
+	// label of the gauge, such as "signing" or "tls"
+	Name      string
+	ExpiresAt time.Time
+}
+
+// NewExpirationGauge creates the gauge of the seconds left before the
+// certificates of a node expire, labeled by certificate name
+func NewExpirationGauge(p metrics.Provider, namespace string) metrics.Gauge {
+	return p.NewGauge(metrics.GaugeOpts{
+		Namespace:  namespace,
+		Subsystem:  "certificate",
+		Name:       "seconds_until_expiration",
+		Help:       "Seconds left before the certificate expires, by certificate",
+		LabelNames: []string{"certificate"},
+	})
+}
+
+// ExpirationCheckInterval is how often TrackExpiration
+// updates the gauge and checks whether to log warnings
+var ExpirationCheckInterval = time.Minute
+
+// expirationWarningIntervals maps the time left before a certificate
+// expires to how often a warning is logged, from the longest to the shortest
+var expirationWarningIntervals = []struct {
+	timeLeft time.Duration
+	interval time.Duration
+}{
+	{timeLeft: 30 * 24 * time.Hour, interval: 24 * time.Hour},
+	{timeLeft: 7 * 24 * time.Hour, interval: time.Hour},
+	{timeLeft: 24 * time.Hour, interval: 10 * time.Minute},
+}
+
+// TrackExpiration reports the seconds left before each of the given
+// certificates expires to gauge, and logs warnings that grow more frequent,
+// and then become errors, as the expiration approaches. Certificates without
+// an expiration time are ignored. It returns when stop is closed.
+func TrackExpiration(certs []CertificateExpiration, gauge metrics.Gauge, stop <-chan struct{}) {
+	t := newExpirationTracker(certs, gauge)
+	t.check()
+
+	ticker := time.NewTicker(ExpirationCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			t.check()
+		}
+	}
+}
+
+type expirationTracker struct {
+	certs []CertificateExpiration
+	gauge metrics.Gauge
+	now   func() time.Time
+	// when each certificate was last warned about
+	lastWarning map[string]time.Time
+}
+
+func newExpirationTracker(certs []CertificateExpiration, gauge metrics.Gauge) *expirationTracker {
+	var tracked []CertificateExpiration
+	for _, cert := range certs {
+		if !cert.ExpiresAt.IsZero() {
+			tracked = append(tracked, cert)
+		}
+	}
+	return &expirationTracker{
+		certs:       tracked,
+		gauge:       gauge,
+		now:         time.Now,
+		lastWarning: make(map[string]time.Time),
+	}
+}
+
+func (t *expirationTracker) check() {
+	now := t.now()
+	for _, cert := range t.certs {
+		timeLeft := cert.ExpiresAt.Sub(now)
+		t.gauge.With(cert.Name).Set(timeLeft.Seconds())
+
+		interval, warn := warningInterval(timeLeft)
+		if !warn {
+			continue
+		}
+		if last, warned := t.lastWarning[cert.Name]; warned && now.Sub(last) < interval {
+			continue
+		}
+		t.lastWarning[cert.Name] = now
+
+		switch {
+		case timeLeft <= 0:
+			logger.Errorf("The %s certificate expired on %s", cert.Name, cert.ExpiresAt)
+		case timeLeft <= 24*time.Hour:
+			logger.Errorf("The %s certificate expires in %s, on %s", cert.Name, timeLeft.Truncate(time.Minute), cert.ExpiresAt)
+		default:
+			logger.Warningf("The %s certificate expires in %s, on %s", cert.Name, timeLeft.Truncate(time.Minute), cert.ExpiresAt)
+		}
+	}
+}
+
+// warningInterval returns how often to warn about a certificate
+// that expires in timeLeft, and false if there is no need to
+func warningInterval(timeLeft time.Duration) (time.Duration, bool) {
+	if timeLeft > expirationWarningIntervals[0].timeLeft {
+		return 0, false
+	}
+	interval := expirationWarningIntervals[0].interval
+	for _, w := range expirationWarningIntervals {
+		if timeLeft <= w.timeLeft {
+			interval = w.interval
+		}
+	}
+	return interval, true
+}
@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/metrics"
+	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiresAt(t *testing.T) {
+	notAfter := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	cert, err := mockcrypto.MakeCert(notAfter)
+	assert.NoError(t, err)
+	assert.Equal(t, notAfter, CertExpiresAt(cert))
+
+	identity, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "SampleOrg", IdBytes: cert})
+	assert.NoError(t, err)
+	assert.Equal(t, notAfter, ExpiresAt(identity))
+	assert.False(t, IsExpired(identity, time.Now()))
+	assert.True(t, IsExpired(identity, notAfter))
+	assert.True(t, IsExpired(identity, notAfter.Add(time.Second)))
+
+	// identities which aren't X.509 certificates never expire
+	identity, err = proto.Marshal(&msp.SerializedIdentity{Mspid: "SampleOrg", IdBytes: []byte("not a certificate")})
+	assert.NoError(t, err)
+	assert.True(t, ExpiresAt(identity).IsZero())
+	assert.False(t, IsExpired(identity, time.Now()))
+	assert.True(t, ExpiresAt([]byte("garbage")).IsZero())
+	assert.True(t, CertExpiresAt(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")})).IsZero())
+}
+
+func TestWarningInterval(t *testing.T) {
+	for _, test := range []struct {
+		timeLeft time.Duration
+		interval time.Duration
+		warn     bool
+	}{
+		{timeLeft: 60 * 24 * time.Hour, warn: false},
+		{timeLeft: 30*24*time.Hour + time.Second, warn: false},
+		{timeLeft: 30 * 24 * time.Hour, interval: 24 * time.Hour, warn: true},
+		{timeLeft: 8 * 24 * time.Hour, interval: 24 * time.Hour, warn: true},
+		{timeLeft: 7 * 24 * time.Hour, interval: time.Hour, warn: true},
+		{timeLeft: 2 * 24 * time.Hour, interval: time.Hour, warn: true},
+		{timeLeft: 24 * time.Hour, interval: 10 * time.Minute, warn: true},
+		{timeLeft: time.Minute, interval: 10 * time.Minute, warn: true},
+		{timeLeft: -time.Hour, interval: 10 * time.Minute, warn: true},
+	} {
+		interval, warn := warningInterval(test.timeLeft)
+		assert.Equal(t, test.warn, warn, "time left: %s", test.timeLeft)
+		assert.Equal(t, test.interval, interval, "time left: %s", test.timeLeft)
+	}
+}
+
+type mockGauge struct {
+	labels []string
+	values map[string]float64
+}
+
+func (g *mockGauge) With(labelValues ...string) metrics.Gauge {
+	return &mockGauge{labels: labelValues, values: g.values}
+}
+
+func (g *mockGauge) Add(delta float64) {}
+
+func (g *mockGauge) Set(value float64) {
+	g.values[g.labels[0]] = value
+}
+
+func TestExpirationTracker(t *testing.T) {
+	now := time.Now()
+	gauge := &mockGauge{values: map[string]float64{}}
+	tracker := newExpirationTracker([]CertificateExpiration{
+		{Name: "signing", ExpiresAt: now.Add(60 * 24 * time.Hour)},
+		{Name: "tls", ExpiresAt: now.Add(7 * 24 * time.Hour)},
+		{Name: "idemix"},
+	}, gauge)
+	tracker.now = func() time.Time { return now }
+
+	tracker.check()
+	assert.Equal(t, map[string]float64{
+		"signing": (60 * 24 * time.Hour).Seconds(),
+		"tls":     (7 * 24 * time.Hour).Seconds(),
+	}, gauge.values)
+	// only the TLS certificate is close enough to its expiration
+	assert.Equal(t, map[string]time.Time{"tls": now}, tracker.lastWarning)
+
+	// the next warning is logged an hour later
+	now = now.Add(30 * time.Minute)
+	tracker.check()
+	assert.Equal(t, map[string]time.Time{"tls": now.Add(-30 * time.Minute)}, tracker.lastWarning)
+	now = now.Add(30 * time.Minute)
+	tracker.check()
+	assert.Equal(t, map[string]time.Time{"tls": now}, tracker.lastWarning)
+	assert.Equal(t, (7*24*time.Hour - time.Hour).Seconds(), gauge.values["tls"])
+
+	// past the expiration the gauge becomes negative
+	now = now.Add(7 * 24 * time.Hour)
+	tracker.check()
+	assert.Equal(t, -time.Hour.Seconds(), gauge.values["tls"])
+	assert.Equal(t, now, tracker.lastWarning["tls"])
+}
+
+func TestTrackExpiration(t *testing.T) {
+	gauge := &mockGauge{values: map[string]float64{}}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		TrackExpiration([]CertificateExpiration{{Name: "signing", ExpiresAt: time.Now().Add(time.Hour)}}, gauge, stop)
+		close(done)
+	}()
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("TrackExpiration did not return after stop was closed")
+	}
+	assert.InDelta(t, time.Hour.Seconds(), gauge.values["signing"], 60)
+}
@@ -18,13 +18,19 @@ package localmsp
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	cb "github.com/hyperledger/fabric/protos/common"
 )
 
 type mspSigner struct {
+	lock sync.Mutex
+	// localMSP is the local MSP the signing identity was obtained from
+	localMSP msp.MSP
+	signer   msp.SigningIdentity
 }
 
 // NewSigner returns a new instance of the msp-based LocalSigner.
@@ -34,11 +40,31 @@ func NewSigner() crypto.LocalSigner {
 	return &mspSigner{}
 }
 
+// getSigner returns the default signing identity of the local MSP. The same
+// identity is returned until the local MSP is replaced, as MSPs like idemix
+// return an identity with a different pseudonym on each request
+func (s *mspSigner) getSigner() (msp.SigningIdentity, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	localMSP := mspmgmt.GetLocalMSP()
+	if s.signer != nil && s.localMSP == localMSP {
+		return s.signer, nil
+	}
+	signer, err := localMSP.GetDefaultSigningIdentity()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting MSP-based signer [%s]", err)
+	}
+	s.localMSP = localMSP
+	s.signer = signer
+	return signer, nil
+}
+
 // NewSignatureHeader creates a SignatureHeader with the correct signing identity and a valid nonce
 func (s *mspSigner) NewSignatureHeader() (*cb.SignatureHeader, error) {
-	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	signer, err := s.getSigner()
 	if err != nil {
-		return nil, fmt.Errorf("Failed getting MSP-based signer [%s]", err)
+		return nil, err
 	}
 
 	creatorIdentityRaw, err := signer.Serialize()
@@ -60,9 +86,9 @@ func (s *mspSigner) NewSignatureHeader() (*cb.SignatureHeader, error) {
 
 // Sign a message which should embed a signature header created by NewSignatureHeader
 func (s *mspSigner) Sign(message []byte) ([]byte, error) {
-	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	signer, err := s.getSigner()
 	if err != nil {
-		return nil, fmt.Errorf("Failed getting MSP-based signer [%s]", err)
+		return nil, err
 	}
 
 	signature, err := signer.Sign(message)
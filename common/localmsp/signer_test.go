@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/stretchr/testify/assert"
 )
@@ -71,3 +73,32 @@ func TestMspSigner_Sign(t *testing.T) {
 	err = mspIdentity.Verify(msg, sigma)
 	assert.NoError(t, err, "Failed verifiing signature")
 }
+
+func TestMspSigner_Idemix(t *testing.T) {
+	// restore the development MSP for the other tests
+	defer func() {
+		mspDir, err := config.GetDevMspDir()
+		assert.NoError(t, err)
+		assert.NoError(t, mspmgmt.ReloadLocalMsp(mspDir, "DEFAULT"))
+	}()
+	err := mspmgmt.LoadLocalMspWithType("../../msp/testdata/idemix/MSP1OU1", nil, "MSP1OU1", msp.ProviderTypeToString(msp.IDEMIX))
+	assert.NoError(t, err)
+
+	// the idemix MSP returns a signing identity with a fresh pseudonym on each
+	// request, while the signer signs with the identity of its signature headers
+	signer := NewSigner()
+	sh, err := signer.NewSignatureHeader()
+	assert.NoError(t, err)
+	msg := []byte("Hello World")
+	sigma, err := signer.Sign(msg)
+	assert.NoError(t, err)
+
+	creator, err := mspmgmt.GetLocalMSP().DeserializeIdentity(sh.Creator)
+	assert.NoError(t, err)
+	assert.NoError(t, creator.Verify(msg, sigma))
+
+	otherSigner := NewSigner()
+	otherSh, err := otherSigner.NewSignatureHeader()
+	assert.NoError(t, err)
+	assert.NotEqual(t, sh.Creator, otherSh.Creator)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prometheus provides metrics that are scraped by a Prometheus
+// server, kept and exposed by the Prometheus client library
+package prometheus
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
+)
+
+// ContentType is the content type of the exposed metrics
+const ContentType = string(expfmt.FmtText)
+
+// Provider is a metrics.Provider whose metrics are kept in a registry
+// of their own, and exposed to Prometheus servers over HTTP
+type Provider struct {
+	registry *prom.Registry
+	handler  http.Handler
+
+	lock       sync.Mutex
+	collectors map[string]prom.Collector
+}
+
+// NewProvider creates a new Provider with no metrics
+func NewProvider() *Provider {
+	registry := prom.NewRegistry()
+	return &Provider{
+		registry:   registry,
+		handler:    promhttp.HandlerFor(registry, promhttp.HandlerOpts{}),
+		collectors: make(map[string]prom.Collector),
+	}
+}
+
+// NewCounter creates a new counter
+func (p *Provider) NewCounter(o metrics.CounterOpts) metrics.Counter {
+	name := metrics.FullyQualifiedName("_", o.Namespace, o.Subsystem, o.Name)
+	c := p.register(name, func() prom.Collector {
+		return prom.NewCounterVec(prom.CounterOpts{Name: name, Help: o.Help}, o.LabelNames)
+	})
+	vec, ok := c.(*prom.CounterVec)
+	if !ok {
+		panic(fmt.Sprintf("metric %s is already registered as another type", name))
+	}
+	return &Counter{vec: vec, labelCount: len(o.LabelNames)}
+}
+
+// NewGauge creates a new gauge
+func (p *Provider) NewGauge(o metrics.GaugeOpts) metrics.Gauge {
+	name := metrics.FullyQualifiedName("_", o.Namespace, o.Subsystem, o.Name)
+	c := p.register(name, func() prom.Collector {
+		return prom.NewGaugeVec(prom.GaugeOpts{Name: name, Help: o.Help}, o.LabelNames)
+	})
+	vec, ok := c.(*prom.GaugeVec)
+	if !ok {
+		panic(fmt.Sprintf("metric %s is already registered as another type", name))
+	}
+	return &Gauge{vec: vec, labelCount: len(o.LabelNames)}
+}
+
+// register returns the collector registered under the given name,
+// or registers the one newCollector creates
+func (p *Provider) register(name string, newCollector func() prom.Collector) prom.Collector {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if c, exists := p.collectors[name]; exists {
+		return c
+	}
+	c := newCollector()
+	p.registry.MustRegister(c)
+	p.collectors[name] = c
+	return c
+}
+
+// ServeHTTP writes the current values of all metrics in the
+// Prometheus exposition format the request accepts
+func (p *Provider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.handler.ServeHTTP(w, r)
+}
+
+// WriteTo writes the current values of all metrics to the given
+// writer in the Prometheus text exposition format
+func (p *Provider) WriteTo(w io.Writer) (int64, error) {
+	families, err := p.registry.Gather()
+	if err != nil {
+		return 0, err
+	}
+	cw := &countingWriter{w: w}
+	enc := expfmt.NewEncoder(cw, expfmt.FmtText)
+	for _, f := range families {
+		if err := enc.Encode(f); err != nil {
+			return cw.n, err
+		}
+	}
+	return cw.n, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(b []byte) (int, error) {
+	n, err := cw.w.Write(b)
+	cw.n += int64(n)
+	return n, err
+}
+
+// labelValues pads the bound label values with empty ones up to the
+// number of labels of the metric, as label values that weren't bound
+// are left empty
+func labelValues(bound []string, count int) []string {
+	values := make([]string, count)
+	copy(values, bound)
+	return values
+}
+
+// Counter is a counter exposed to Prometheus
+type Counter struct {
+	vec         *prom.CounterVec
+	labelCount  int
+	labelValues []string
+}
+
+// With returns the counter with the given label values bound to it
+func (c *Counter) With(labelValues ...string) metrics.Counter {
+	return &Counter{
+		vec:         c.vec,
+		labelCount:  c.labelCount,
+		labelValues: append(append([]string{}, c.labelValues...), labelValues...),
+	}
+}
+
+// Add increments the counter, negative deltas are ignored
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		return
+	}
+	c.vec.WithLabelValues(labelValues(c.labelValues, c.labelCount)...).Add(delta)
+}
+
+// Gauge is a gauge exposed to Prometheus
+type Gauge struct {
+	vec         *prom.GaugeVec
+	labelCount  int
+	labelValues []string
+}
+
+// With returns the gauge with the given label values bound to it
+func (g *Gauge) With(labelValues ...string) metrics.Gauge {
+	return &Gauge{
+		vec:         g.vec,
+		labelCount:  g.labelCount,
+		labelValues: append(append([]string{}, g.labelValues...), labelValues...),
+	}
+}
+
+// Add increments the gauge by the given delta
+func (g *Gauge) Add(delta float64) {
+	g.vec.WithLabelValues(labelValues(g.labelValues, g.labelCount)...).Add(delta)
+}
+
+// Set sets the gauge to the given value
+func (g *Gauge) Set(value float64) {
+	g.vec.WithLabelValues(labelValues(g.labelValues, g.labelCount)...).Set(value)
+}
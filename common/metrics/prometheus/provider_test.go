@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheus
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterAndGauge(t *testing.T) {
+	p := NewProvider()
+	c := p.NewCounter(metrics.CounterOpts{
+		Namespace:  "ns",
+		Subsystem:  "sub",
+		Name:       "messages",
+		Help:       "Number of messages",
+		LabelNames: []string{"type", "channel"},
+	})
+	c.With("alive", "A").Add(2)
+	c.With("alive").With("A").Add(1)
+	c.With("data", "B").Add(1)
+	// Counters never decrease
+	c.With("data", "B").Add(-1)
+
+	g := p.NewGauge(metrics.GaugeOpts{
+		Name: "size",
+	})
+	g.Set(10)
+	g.Add(-3)
+
+	out := &bytes.Buffer{}
+	_, err := p.WriteTo(out)
+	assert.NoError(t, err)
+	assert.Equal(t, `# HELP ns_sub_messages Number of messages
+# TYPE ns_sub_messages counter
+ns_sub_messages{channel="A",type="alive"} 3
+ns_sub_messages{channel="B",type="data"} 1
+# HELP size 
+# TYPE size gauge
+size 7
+`, out.String())
+}
+
+func TestRegisterTwice(t *testing.T) {
+	p := NewProvider()
+	opts := metrics.CounterOpts{Name: "messages"}
+	p.NewCounter(opts).Add(1)
+	p.NewCounter(opts).Add(1)
+	out := &bytes.Buffer{}
+	p.WriteTo(out)
+	assert.Contains(t, out.String(), "messages 2\n")
+
+	assert.Panics(t, func() {
+		p.NewGauge(metrics.GaugeOpts{Name: "messages"})
+	})
+}
+
+func TestLabelValueEscaping(t *testing.T) {
+	p := NewProvider()
+	p.NewGauge(metrics.GaugeOpts{Name: "g", LabelNames: []string{"l"}}).With("a\"b\\c\nd").Set(1)
+	out := &bytes.Buffer{}
+	p.WriteTo(out)
+	assert.Contains(t, out.String(), `g{l="a\"b\\c\nd"} 1`)
+}
+
+func TestServeHTTP(t *testing.T) {
+	p := NewProvider()
+	p.NewCounter(metrics.CounterOpts{Name: "messages"}).Add(1)
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "messages 1\n")
+}
@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import "strings"
+
+// Provider creates the counters and gauges components report their metrics to
+type Provider interface {
+	// NewCounter creates a new counter, or returns the existing
+	// counter with the same name
+	NewCounter(CounterOpts) Counter
+	// NewGauge creates a new gauge, or returns the existing
+	// gauge with the same name
+	NewGauge(GaugeOpts) Gauge
+}
+
+// Counter is a metric that only increases, such as a number of messages
+type Counter interface {
+	// With returns a counter with the given label values bound to it.
+	// Label values are matched to the label names of the counter by order.
+	With(labelValues ...string) Counter
+	// Add increments the counter by the given non-negative delta
+	Add(delta float64)
+}
+
+// Gauge is a metric that may go up and down, such as the size of a buffer
+type Gauge interface {
+	// With returns a gauge with the given label values bound to it.
+	// Label values are matched to the label names of the gauge by order.
+	With(labelValues ...string) Gauge
+	// Add increments the gauge by the given, possibly negative, delta
+	Add(delta float64)
+	// Set sets the gauge to the given value
+	Set(value float64)
+}
+
+// CounterOpts defines the name and labels of a counter
+type CounterOpts struct {
+	Namespace  string
+	Subsystem  string
+	Name       string
+	Help       string
+	LabelNames []string
+}
+
+// GaugeOpts defines the name and labels of a gauge
+type GaugeOpts struct {
+	Namespace  string
+	Subsystem  string
+	Name       string
+	Help       string
+	LabelNames []string
+}
+
+// FullyQualifiedName joins the non-empty namespace, subsystem and name
+// of a metric with the given separator
+func FullyQualifiedName(separator, namespace, subsystem, name string) string {
+	var parts []string
+	for _, p := range []string{namespace, subsystem, name} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, separator)
+}
@@ -0,0 +1,228 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statsd provides metrics that are periodically pushed to a
+// statsd server
+package statsd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// maxPacketSize is the maximum size of a write to the statsd server,
+// which keeps UDP datagrams within the common network MTU
+const maxPacketSize = 1432
+
+var logger = flogging.MustGetLogger("metrics/statsd")
+
+// Provider is a metrics.Provider that accumulates the metrics reported
+// to it, and periodically writes them to a statsd server.
+// Metric names are the dot separated prefix, namespace, subsystem and name
+// of the metric, followed by the label values of the reported series.
+type Provider struct {
+	prefix   string
+	lock     sync.Mutex
+	counters map[string]float64
+	gauges   map[string]float64
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// NewProvider creates a new Provider with the given metric name prefix
+func NewProvider(prefix string) *Provider {
+	return &Provider{
+		prefix:   prefix,
+		counters: make(map[string]float64),
+		gauges:   make(map[string]float64),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// NewCounter creates a new counter
+func (p *Provider) NewCounter(o metrics.CounterOpts) metrics.Counter {
+	return &Counter{provider: p, name: p.name(o.Namespace, o.Subsystem, o.Name)}
+}
+
+// NewGauge creates a new gauge
+func (p *Provider) NewGauge(o metrics.GaugeOpts) metrics.Gauge {
+	return &Gauge{provider: p, name: p.name(o.Namespace, o.Subsystem, o.Name)}
+}
+
+func (p *Provider) name(namespace, subsystem, name string) string {
+	return metrics.FullyQualifiedName(".", p.prefix, metrics.FullyQualifiedName(".", namespace, subsystem, name), "")
+}
+
+// Start writes the accumulated metrics to the given writer
+// every interval, until Stop is called
+func (p *Provider) Start(w io.Writer, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.Flush(w); err != nil {
+					logger.Warningf("Failed writing metrics to statsd: %v", err)
+				}
+			case <-p.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops writing metrics
+func (p *Provider) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopChan)
+	})
+}
+
+// Flush writes the counter increments since the previous write,
+// and the current values of the gauges to the given writer.
+// Writes are split so that each is at most maxPacketSize bytes long.
+func (p *Provider) Flush(w io.Writer) error {
+	var lines []string
+	p.lock.Lock()
+	for name, value := range p.counters {
+		if value != 0 {
+			lines = append(lines, fmt.Sprintf("%s:%s|c", name, formatFloat(value)))
+		}
+		delete(p.counters, name)
+	}
+	for name, value := range p.gauges {
+		lines = append(lines, fmt.Sprintf("%s:%s|g", name, formatFloat(value)))
+	}
+	p.lock.Unlock()
+	sort.Strings(lines)
+
+	buff := &bytes.Buffer{}
+	for _, line := range lines {
+		if buff.Len() > 0 && buff.Len()+len(line)+1 > maxPacketSize {
+			if _, err := w.Write(buff.Bytes()); err != nil {
+				return err
+			}
+			buff.Reset()
+		}
+		buff.WriteString(line)
+		buff.WriteString("\n")
+	}
+	if buff.Len() == 0 {
+		return nil
+	}
+	_, err := w.Write(buff.Bytes())
+	return err
+}
+
+func (p *Provider) addToCounter(name string, delta float64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.counters[name] += delta
+}
+
+func (p *Provider) updateGauge(name string, value float64, set bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if set {
+		p.gauges[name] = value
+		return
+	}
+	p.gauges[name] += value
+}
+
+// seriesName appends the given label values to the name of a metric
+func seriesName(name string, labelValues []string) string {
+	if len(labelValues) == 0 {
+		return name
+	}
+	values := make([]string, len(labelValues))
+	for i, v := range labelValues {
+		values[i] = sanitize(v)
+	}
+	return name + "." + strings.Join(values, ".")
+}
+
+// sanitize replaces characters that have a meaning in the statsd protocol
+func sanitize(s string) string {
+	if s == "" {
+		return "_"
+	}
+	return strings.NewReplacer(".", "_", ":", "_", "|", "_", "@", "_", "\n", "_", " ", "_").Replace(s)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Counter is a counter pushed to statsd
+type Counter struct {
+	provider    *Provider
+	name        string
+	labelValues []string
+}
+
+// With returns the counter with the given label values bound to it
+func (c *Counter) With(labelValues ...string) metrics.Counter {
+	return &Counter{
+		provider:    c.provider,
+		name:        c.name,
+		labelValues: append(append([]string{}, c.labelValues...), labelValues...),
+	}
+}
+
+// Add increments the counter, negative deltas are ignored
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		return
+	}
+	c.provider.addToCounter(seriesName(c.name, c.labelValues), delta)
+}
+
+// Gauge is a gauge pushed to statsd
+type Gauge struct {
+	provider    *Provider
+	name        string
+	labelValues []string
+}
+
+// With returns the gauge with the given label values bound to it
+func (g *Gauge) With(labelValues ...string) metrics.Gauge {
+	return &Gauge{
+		provider:    g.provider,
+		name:        g.name,
+		labelValues: append(append([]string{}, g.labelValues...), labelValues...),
+	}
+}
+
+// Add increments the gauge by the given delta
+func (g *Gauge) Add(delta float64) {
+	g.provider.updateGauge(seriesName(g.name, g.labelValues), delta, false)
+}
+
+// Set sets the gauge to the given value
+func (g *Gauge) Set(value float64) {
+	g.provider.updateGauge(seriesName(g.name, g.labelValues), value, true)
+}
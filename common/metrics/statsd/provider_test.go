@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statsd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+type writerMock struct {
+	writes chan string
+}
+
+func (w *writerMock) Write(b []byte) (int, error) {
+	w.writes <- string(b)
+	return len(b), nil
+}
+
+func TestCounterAndGauge(t *testing.T) {
+	p := NewProvider("peer0")
+	c := p.NewCounter(metrics.CounterOpts{
+		Namespace:  "ns",
+		Subsystem:  "sub",
+		Name:       "messages",
+		LabelNames: []string{"type", "channel"},
+	})
+	c.With("alive", "my.channel").Add(2)
+	c.With("alive").With("my.channel").Add(1)
+	c.With("data", "").Add(1)
+	g := p.NewGauge(metrics.GaugeOpts{Name: "size"})
+	g.Set(10)
+	g.Add(-3)
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, p.Flush(out))
+	assert.Equal(t, `peer0.ns.sub.messages.alive.my_channel:3|c
+peer0.ns.sub.messages.data._:1|c
+peer0.size:7|g
+`, out.String())
+
+	// Counters are reset after each write, gauges keep their values
+	out.Reset()
+	assert.NoError(t, p.Flush(out))
+	assert.Equal(t, "peer0.size:7|g\n", out.String())
+}
+
+func TestWriteSplitsPackets(t *testing.T) {
+	p := NewProvider("")
+	c := p.NewCounter(metrics.CounterOpts{Name: "counter", LabelNames: []string{"n"}})
+	for i := 0; i < 200; i++ {
+		c.With(fmt.Sprintf("%03d", i)).Add(1)
+	}
+	w := &writerMock{writes: make(chan string, 100)}
+	assert.NoError(t, p.Flush(w))
+	close(w.writes)
+	lines := 0
+	for packet := range w.writes {
+		assert.True(t, len(packet) <= maxPacketSize)
+		lines += strings.Count(packet, "\n")
+	}
+	assert.Equal(t, 200, lines)
+}
+
+func TestStartStop(t *testing.T) {
+	p := NewProvider("")
+	p.NewCounter(metrics.CounterOpts{Name: "counter"}).Add(1)
+	w := &writerMock{writes: make(chan string, 10)}
+	p.Start(w, time.Millisecond*50)
+	select {
+	case packet := <-w.writes:
+		assert.Equal(t, "counter:1|c\n", packet)
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "Metrics weren't written")
+	}
+	p.Stop()
+	p.Stop()
+}
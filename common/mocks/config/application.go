@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/hyperledger/fabric/common/config"
+)
+
+// Application is a mock implementation of config.Application
+type Application struct {
+	// OrganizationsVal is returned as the result of Organizations()
+	OrganizationsVal map[string]config.ApplicationOrg
+	// APIPolicyMapperVal is returned as the result of APIPolicyMapper()
+	APIPolicyMapperVal config.PolicyMapper
+	// CapabilitiesVal is returned as the result of Capabilities()
+	CapabilitiesVal config.ApplicationCapabilities
+}
+
+// Organizations returns OrganizationsVal
+func (a *Application) Organizations() map[string]config.ApplicationOrg {
+	return a.OrganizationsVal
+}
+
+// APIPolicyMapper returns APIPolicyMapperVal
+func (a *Application) APIPolicyMapper() config.PolicyMapper {
+	return a.APIPolicyMapperVal
+}
+
+// Capabilities returns CapabilitiesVal
+func (a *Application) Capabilities() config.ApplicationCapabilities {
+	return a.CapabilitiesVal
+}
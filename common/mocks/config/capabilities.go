@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/hyperledger/fabric/msp"
+)
+
+// ChannelCapabilities is a mock implementation of config.ChannelCapabilities
+type ChannelCapabilities struct {
+	// SupportedErr is returned as the result of Supported()
+	SupportedErr error
+	// MSPVersionVal is returned as the result of MSPVersion()
+	MSPVersionVal msp.MSPVersion
+}
+
+// Supported returns SupportedErr
+func (cc *ChannelCapabilities) Supported() error {
+	return cc.SupportedErr
+}
+
+// MSPVersion returns MSPVersionVal
+func (cc *ChannelCapabilities) MSPVersion() msp.MSPVersion {
+	return cc.MSPVersionVal
+}
+
+// OrdererCapabilities is a mock implementation of config.OrdererCapabilities
+type OrdererCapabilities struct {
+	// SupportedErr is returned as the result of Supported()
+	SupportedErr error
+	// PredictableChannelTemplateVal is returned as the result of PredictableChannelTemplate()
+	PredictableChannelTemplateVal bool
+}
+
+// Supported returns SupportedErr
+func (oc *OrdererCapabilities) Supported() error {
+	return oc.SupportedErr
+}
+
+// PredictableChannelTemplate returns PredictableChannelTemplateVal
+func (oc *OrdererCapabilities) PredictableChannelTemplate() bool {
+	return oc.PredictableChannelTemplateVal
+}
+
+// ApplicationCapabilities is a mock implementation of config.ApplicationCapabilities
+type ApplicationCapabilities struct {
+	// SupportedErr is returned as the result of Supported()
+	SupportedErr error
+	// ForbidDuplicateTXIdInBlockVal is returned as the result of ForbidDuplicateTXIdInBlock()
+	ForbidDuplicateTXIdInBlockVal bool
+	// ChaincodeLifecycleVal is returned as the result of ChaincodeLifecycle()
+	ChaincodeLifecycleVal bool
+	// QueryProposalsVal is returned as the result of QueryProposals()
+	QueryProposalsVal bool
+	// ACLsVal is returned as the result of ACLs()
+	ACLsVal bool
+}
+
+// Supported returns SupportedErr
+func (ac *ApplicationCapabilities) Supported() error {
+	return ac.SupportedErr
+}
+
+// ForbidDuplicateTXIdInBlock returns ForbidDuplicateTXIdInBlockVal
+func (ac *ApplicationCapabilities) ForbidDuplicateTXIdInBlock() bool {
+	return ac.ForbidDuplicateTXIdInBlockVal
+}
+
+// ChaincodeLifecycle returns ChaincodeLifecycleVal
+func (ac *ApplicationCapabilities) ChaincodeLifecycle() bool {
+	return ac.ChaincodeLifecycleVal
+}
+
+// QueryProposals returns QueryProposalsVal
+func (ac *ApplicationCapabilities) QueryProposals() bool {
+	return ac.QueryProposalsVal
+}
+
+// ACLs returns ACLsVal
+func (ac *ApplicationCapabilities) ACLs() bool {
+	return ac.ACLsVal
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package config
 
-import "github.com/hyperledger/fabric/common/util"
+import (
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/common/util"
+)
 
 func nearIdentityHash(input []byte) []byte {
 	return util.ConcatenateBytes([]byte("FakeHash("), input, []byte(""))
@@ -30,6 +33,8 @@ type Channel struct {
 	BlockDataHashingStructureWidthVal uint32
 	// OrdererAddressesVal is returned as the result of OrdererAddresses()
 	OrdererAddressesVal []string
+	// CapabilitiesVal is returned as the result of Capabilities()
+	CapabilitiesVal config.ChannelCapabilities
 }
 
 // HashingAlgorithm returns the HashingAlgorithmVal if set, otherwise a fake simple hash function
@@ -49,3 +54,8 @@ func (scm *Channel) BlockDataHashingStructureWidth() uint32 {
 func (scm *Channel) OrdererAddresses() []string {
 	return scm.OrdererAddressesVal
 }
+
+// Capabilities returns the CapabilitiesVal
+func (scm *Channel) Capabilities() config.ChannelCapabilities {
+	return scm.CapabilitiesVal
+}
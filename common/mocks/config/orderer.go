@@ -37,6 +37,8 @@ type Orderer struct {
 	MaxChannelsCountVal uint64
 	// OrganizationsVal is returned as the result of Organizations()
 	OrganizationsVal map[string]config.Org
+	// CapabilitiesVal is returned as the result of Capabilities()
+	CapabilitiesVal config.OrdererCapabilities
 }
 
 // ConsensusType returns the ConsensusTypeVal
@@ -68,3 +70,8 @@ func (scm *Orderer) MaxChannelsCount() uint64 {
 func (scm *Orderer) Organizations() map[string]config.Org {
 	return scm.OrganizationsVal
 }
+
+// Capabilities returns CapabilitiesVal
+func (scm *Orderer) Capabilities() config.OrdererCapabilities {
+	return scm.CapabilitiesVal
+}
@@ -17,6 +17,7 @@ package ca_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/x509"
 	"os"
 	"path/filepath"
@@ -38,7 +39,7 @@ var testDir = filepath.Join(os.TempDir(), "ca-test")
 func TestNewCA(t *testing.T) {
 
 	caDir := filepath.Join(testDir, "ca")
-	rootCA, err := ca.NewCA(caDir, testCAName, testCAName)
+	rootCA, err := ca.NewCA(caDir, testCAName, testCAName, "")
 	assert.NoError(t, err, "Error generating CA")
 	assert.NotNil(t, rootCA, "Failed to return CA")
 	assert.NotNil(t, rootCA.Signer,
@@ -59,7 +60,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 	caDir := filepath.Join(testDir, "ca")
 	certDir := filepath.Join(testDir, "certs")
 	// generate private key
-	priv, _, err := csp.GeneratePrivateKey(certDir)
+	priv, _, err := csp.GeneratePrivateKey(certDir, "")
 	assert.NoError(t, err, "Failed to generate signed certificate")
 
 	// get EC public key
@@ -68,7 +69,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 	assert.NotNil(t, ecPubKey, "Failed to generate signed certificate")
 
 	// create our CA
-	rootCA, err := ca.NewCA(caDir, testCA2Name, testCA2Name)
+	rootCA, err := ca.NewCA(caDir, testCA2Name, testCA2Name, "")
 	assert.NoError(t, err, "Error generating CA")
 
 	cert, err := rootCA.SignCertificate(certDir, testName, nil, ecPubKey,
@@ -106,6 +107,42 @@ func TestGenerateSignCertificate(t *testing.T) {
 
 }
 
+func TestEd25519CA(t *testing.T) {
+
+	caDir := filepath.Join(testDir, "ca")
+	certDir := filepath.Join(testDir, "certs")
+
+	rootCA, err := ca.NewCA(caDir, testCAName, testCAName, "ED25519")
+	assert.NoError(t, err, "Error generating Ed25519 CA")
+	assert.Equal(t, x509.PureEd25519, rootCA.SignCert.SignatureAlgorithm)
+	assert.IsType(t, ed25519.PublicKey{}, rootCA.SignCert.PublicKey)
+
+	// an Ed25519 CA can certify both Ed25519 and ECDSA keys
+	priv, _, err := csp.GeneratePrivateKey(certDir, "ED25519")
+	assert.NoError(t, err, "Failed to generate private key")
+	pubKey, err := csp.GetPublicKey(priv)
+	assert.NoError(t, err, "Failed to get public key")
+
+	cert, err := rootCA.SignCertificate(certDir, testName, nil, pubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	assert.NoError(t, err, "Failed to generate signed certificate")
+	assert.NoError(t, cert.CheckSignatureFrom(rootCA.SignCert))
+
+	priv, _, err = csp.GeneratePrivateKey(certDir, "")
+	assert.NoError(t, err, "Failed to generate private key")
+	ecPubKey, err := csp.GetECPublicKey(priv)
+	assert.NoError(t, err, "Failed to get public key")
+
+	cert, err = rootCA.SignCertificate(certDir, testName, nil, ecPubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	assert.NoError(t, err, "Failed to generate signed certificate")
+	assert.NoError(t, cert.CheckSignatureFrom(rootCA.SignCert))
+
+	_, err = ca.NewCA(caDir, testCAName, testCAName, "DSA")
+	assert.Error(t, err, "Unsupported key algorithm should fail")
+	cleanup(testDir)
+}
+
 func cleanup(dir string) {
 	os.RemoveAll(dir)
 }
@@ -19,14 +19,40 @@ import (
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/x509"
+	"fmt"
+	"strings"
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/bccsp/signer"
 )
 
-// GeneratePrivateKey creates a private key and stores it in keystorePath
-func GeneratePrivateKey(keystorePath string) (bccsp.Key,
+// KeyGenOpts returns the BCCSP key generation options for the given
+// key algorithm. Supported algorithms are ECDSA (P-256) and ED25519;
+// an empty algorithm defaults to ECDSA.
+func KeyGenOpts(keyAlg string, temporary bool) (bccsp.KeyGenOpts, error) {
+	switch strings.ToUpper(keyAlg) {
+	case "", bccsp.ECDSA:
+		return &bccsp.ECDSAP256KeyGenOpts{Temporary: temporary}, nil
+	case bccsp.ED25519:
+		return &bccsp.ED25519KeyGenOpts{Temporary: temporary}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm [%s]. Supported algorithms: [%s, %s]",
+			keyAlg, bccsp.ECDSA, bccsp.ED25519)
+	}
+}
+
+// GeneratePrivateKey creates a private key using the given key algorithm
+// (see KeyGenOpts) and stores it in keystorePath
+func GeneratePrivateKey(keystorePath, keyAlg string) (bccsp.Key,
+	crypto.Signer, error) {
+	return GenerateEncryptedPrivateKey(keystorePath, keyAlg, "")
+}
+
+// GenerateEncryptedPrivateKey creates a private key using the given key
+// algorithm (see KeyGenOpts) and stores it in keystorePath, encrypted
+// with the given passphrase. An empty passphrase stores the key unencrypted.
+func GenerateEncryptedPrivateKey(keystorePath, keyAlg, passphrase string) (bccsp.Key,
 	crypto.Signer, error) {
 
 	var err error
@@ -41,13 +67,18 @@ func GeneratePrivateKey(keystorePath string) (bccsp.Key,
 
 			FileKeystore: &factory.FileKeystoreOpts{
 				KeyStorePath: keystorePath,
+				Passphrase:   passphrase,
 			},
 		},
 	}
+	keyGenOpts, err := KeyGenOpts(keyAlg, false)
+	if err != nil {
+		return nil, nil, err
+	}
 	csp, err := factory.GetBCCSPFromOpts(opts)
 	if err == nil {
 		// generate a key
-		priv, err = csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+		priv, err = csp.KeyGen(keyGenOpts)
 		if err == nil {
 			// create a crypto.Signer
 			s, err = signer.New(csp, priv)
@@ -58,6 +89,21 @@ func GeneratePrivateKey(keystorePath string) (bccsp.Key,
 
 func GetECPublicKey(priv bccsp.Key) (*ecdsa.PublicKey, error) {
 
+	pubKey, err := GetPublicKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	ecPubKey, ok := pubKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an ECDSA public key, got [%T]", pubKey)
+	}
+	return ecPubKey, nil
+}
+
+// GetPublicKey returns the public key corresponding to priv
+// as a crypto.PublicKey (*ecdsa.PublicKey or ed25519.PublicKey)
+func GetPublicKey(priv bccsp.Key) (crypto.PublicKey, error) {
+
 	// get the public key
 	pubKey, err := priv.PublicKey()
 	if err != nil {
@@ -69,9 +115,5 @@ func GetECPublicKey(priv bccsp.Key) (*ecdsa.PublicKey, error) {
 		return nil, err
 	}
 	// unmarshal using pkix
-	ecPubKey, err := x509.ParsePKIXPublicKey(pubKeyBytes)
-	if err != nil {
-		return nil, err
-	}
-	return ecPubKey.(*ecdsa.PublicKey), nil
+	return x509.ParsePKIXPublicKey(pubKeyBytes)
 }
@@ -17,6 +17,7 @@ package csp_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"encoding/hex"
 	"errors"
 	"os"
@@ -59,7 +60,7 @@ var testDir = filepath.Join(os.TempDir(), "csp-test")
 
 func TestGeneratePrivateKey(t *testing.T) {
 
-	priv, signer, err := csp.GeneratePrivateKey(testDir)
+	priv, signer, err := csp.GeneratePrivateKey(testDir, "")
 	assert.NoError(t, err, "Failed to generate private key")
 	assert.NotNil(t, priv, "Should have returned a bccsp.Key")
 	assert.Equal(t, true, priv.Private(), "Failed to return private key")
@@ -72,9 +73,33 @@ func TestGeneratePrivateKey(t *testing.T) {
 
 }
 
+func TestGeneratePrivateKeyEd25519(t *testing.T) {
+
+	priv, signer, err := csp.GeneratePrivateKey(testDir, "ed25519")
+	assert.NoError(t, err, "Failed to generate Ed25519 private key")
+	assert.Equal(t, true, priv.Private(), "Failed to return private key")
+	assert.IsType(t, ed25519.PublicKey{}, signer.Public(),
+		"Signer should expose an ed25519.PublicKey")
+	pkFile := filepath.Join(testDir, hex.EncodeToString(priv.SKI())+"_sk")
+	assert.Equal(t, true, checkForFile(pkFile),
+		"Expected to find private key file")
+
+	pubKey, err := csp.GetPublicKey(priv)
+	assert.NoError(t, err, "Failed to get public key from private key")
+	assert.Equal(t, signer.Public(), pubKey)
+
+	_, err = csp.GetECPublicKey(priv)
+	assert.Error(t, err, "Expected an error for a non ECDSA key")
+
+	_, _, err = csp.GeneratePrivateKey(testDir, "DSA")
+	assert.Error(t, err, "Expected an error for an unsupported key algorithm")
+
+	cleanup(testDir)
+}
+
 func TestGetECPublicKey(t *testing.T) {
 
-	priv, _, err := csp.GeneratePrivateKey(testDir)
+	priv, _, err := csp.GeneratePrivateKey(testDir, "")
 	assert.NoError(t, err, "Failed to generate private key")
 
 	ecPubKey, err := csp.GetECPublicKey(priv)
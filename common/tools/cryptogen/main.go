@@ -20,6 +20,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	"gopkg.in/yaml.v2"
@@ -29,7 +30,9 @@ import (
 	"bytes"
 	"io/ioutil"
 
+	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/metadata"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/msp"
 )
@@ -71,12 +74,13 @@ type UsersSpec struct {
 }
 
 type OrgSpec struct {
-	Name     string       `yaml:"Name"`
-	Domain   string       `yaml:"Domain"`
-	CA       NodeSpec     `yaml:"CA"`
-	Template NodeTemplate `yaml:"Template"`
-	Specs    []NodeSpec   `yaml:"Specs"`
-	Users    UsersSpec    `yaml:"Users"`
+	Name         string       `yaml:"Name"`
+	Domain       string       `yaml:"Domain"`
+	KeyAlgorithm string       `yaml:"KeyAlgorithm"`
+	CA           NodeSpec     `yaml:"CA"`
+	Template     NodeTemplate `yaml:"Template"`
+	Specs        []NodeSpec   `yaml:"Specs"`
+	Users        UsersSpec    `yaml:"Users"`
 }
 
 type Config struct {
@@ -111,6 +115,15 @@ PeerOrgs:
   - Name: Org1
     Domain: org1.example.com
 
+    # ---------------------------------------------------------------------------
+    # "KeyAlgorithm"
+    # ---------------------------------------------------------------------------
+    # The algorithm used for the keys of the organization's signing CA and of
+    # the signing identities it issues.  Either ECDSA (P-256, the default) or
+    # ED25519.  TLS keys and certificates are always ECDSA.
+    # ---------------------------------------------------------------------------
+    # KeyAlgorithm: ECDSA
+
     # ---------------------------------------------------------------------------
     # "CA"
     # ---------------------------------------------------------------------------
@@ -190,13 +203,19 @@ PeerOrgs:
       Count: 1
 `
 
+// passphraseEnv is the environment variable that may hold the passphrase
+// used to encrypt the private keys of the MSP keystores
+const passphraseEnv = "CRYPTOGEN_PASSPHRASE"
+
 //command line flags
 var (
 	app = kingpin.New("cryptogen", "Utility for generating Hyperledger Fabric key material")
 
-	gen        = app.Command("generate", "Generate key material")
-	outputDir  = gen.Flag("output", "The output directory in which to place artifacts").Default("crypto-config").String()
-	configFile = gen.Flag("config", "The configuration template to use").File()
+	gen            = app.Command("generate", "Generate key material")
+	outputDir      = gen.Flag("output", "The output directory in which to place artifacts").Default("crypto-config").String()
+	configFile     = gen.Flag("config", "The configuration template to use").File()
+	passphraseFile = gen.Flag("passphraseFile", "File containing the passphrase used to encrypt the private keys of the MSP keystores. "+
+		"The passphrase can also be set through the "+passphraseEnv+" environment variable. TLS and CA private keys are not encrypted").File()
 
 	showtemplate = app.Command("showtemplate", "Show the default configuration template")
 
@@ -254,23 +273,52 @@ func generate() {
 		os.Exit(-1)
 	}
 
+	passphrase, err := getPassphrase()
+	if err != nil {
+		fmt.Printf("Error reading passphrase: %s", err)
+		os.Exit(-1)
+	}
+
 	for _, orgSpec := range config.PeerOrgs {
 		err = renderOrgSpec(&orgSpec, "peer")
 		if err != nil {
 			fmt.Printf("Error processing peer configuration: %s", err)
 			os.Exit(-1)
 		}
-		generatePeerOrg(*outputDir, orgSpec)
+		generatePeerOrg(*outputDir, orgSpec, passphrase)
 	}
 
 	for _, orgSpec := range config.OrdererOrgs {
-		renderOrgSpec(&orgSpec, "orderer")
+		err = renderOrgSpec(&orgSpec, "orderer")
 		if err != nil {
 			fmt.Printf("Error processing orderer configuration: %s", err)
 			os.Exit(-1)
 		}
-		generateOrdererOrg(*outputDir, orgSpec)
+		generateOrdererOrg(*outputDir, orgSpec, passphrase)
+	}
+}
+
+// getPassphrase returns the passphrase used to encrypt the private keys of
+// the MSP keystores, read from the passphraseFile flag or from the
+// environment. An empty passphrase means the keys are not encrypted.
+func getPassphrase() (string, error) {
+	envPassphrase := os.Getenv(passphraseEnv)
+	if *passphraseFile == nil {
+		return envPassphrase, nil
+	}
+	if envPassphrase != "" {
+		return "", fmt.Errorf("only one of --passphraseFile and %s can be set", passphraseEnv)
+	}
+
+	data, err := ioutil.ReadAll(*passphraseFile)
+	if err != nil {
+		return "", err
+	}
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase file %s is empty", (*passphraseFile).Name())
 	}
+	return passphrase, nil
 }
 
 func parseTemplate(input string, data interface{}) (string, error) {
@@ -334,6 +382,11 @@ func renderNodeSpec(domain string, spec *NodeSpec) error {
 }
 
 func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
+	// Validate the key algorithm of the org
+	if _, err := csp.KeyGenOpts(orgSpec.KeyAlgorithm, true); err != nil {
+		return err
+	}
+
 	// First process all of our templated nodes
 	for i := 0; i < orgSpec.Template.Count; i++ {
 		data := HostnameData{
@@ -376,7 +429,7 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 	return nil
 }
 
-func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
+func generatePeerOrg(baseDir string, orgSpec OrgSpec, passphrase string) {
 
 	orgName := orgSpec.Domain
 
@@ -390,13 +443,13 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName)
+	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.KeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName)
+	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, bccsp.ECDSA)
 	if err != nil {
 		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -408,7 +461,7 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 		os.Exit(1)
 	}
 
-	generateNodes(peersDir, orgSpec.Specs, signCA, tlsCA)
+	generateNodes(peersDir, orgSpec.Specs, signCA, tlsCA, passphrase)
 
 	// TODO: add ability to specify usernames
 	users := []NodeSpec{}
@@ -425,7 +478,7 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	}
 
 	users = append(users, adminUser)
-	generateNodes(usersDir, users, signCA, tlsCA)
+	generateNodes(usersDir, users, signCA, tlsCA, passphrase)
 
 	// copy the admin cert to the org's MSP admincerts
 	err = copyAdminCert(usersDir, adminCertsDir, adminUser.CommonName)
@@ -468,11 +521,11 @@ func copyAdminCert(usersDir, adminCertsDir, adminUserName string) error {
 
 }
 
-func generateNodes(baseDir string, nodes []NodeSpec, signCA *ca.CA, tlsCA *ca.CA) {
+func generateNodes(baseDir string, nodes []NodeSpec, signCA *ca.CA, tlsCA *ca.CA, passphrase string) {
 
 	for _, node := range nodes {
 		nodeDir := filepath.Join(baseDir, node.CommonName)
-		err := msp.GenerateLocalMSP(nodeDir, node.CommonName, node.SANS, signCA, tlsCA)
+		err := msp.GenerateLocalMSP(nodeDir, node.CommonName, node.SANS, signCA, tlsCA, passphrase)
 		if err != nil {
 			fmt.Printf("Error generating local MSP for %s:\n%v\n", node, err)
 			os.Exit(1)
@@ -480,7 +533,7 @@ func generateNodes(baseDir string, nodes []NodeSpec, signCA *ca.CA, tlsCA *ca.CA
 	}
 }
 
-func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
+func generateOrdererOrg(baseDir string, orgSpec OrgSpec, passphrase string) {
 
 	orgName := orgSpec.Domain
 
@@ -493,13 +546,13 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName)
+	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.KeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName)
+	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, bccsp.ECDSA)
 	if err != nil {
 		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -511,7 +564,7 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 		os.Exit(1)
 	}
 
-	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA)
+	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, passphrase)
 
 	adminUser := NodeSpec{
 		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
@@ -521,7 +574,7 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 	users := []NodeSpec{}
 	// add an admin user
 	users = append(users, adminUser)
-	generateNodes(usersDir, users, signCA, tlsCA)
+	generateNodes(usersDir, users, signCA, tlsCA, passphrase)
 
 	// copy the admin cert to the org's MSP admincerts
 	err = copyAdminCert(usersDir, adminCertsDir, adminUser.CommonName)
@@ -16,10 +16,15 @@ limitations under the License.
 package msp_test
 
 import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/msp"
 	fabricmsp "github.com/hyperledger/fabric/msp"
@@ -38,7 +43,7 @@ func TestGenerateLocalMSP(t *testing.T) {
 
 	cleanup(testDir)
 
-	err := msp.GenerateLocalMSP(testDir, testName, nil, &ca.CA{}, &ca.CA{})
+	err := msp.GenerateLocalMSP(testDir, testName, nil, &ca.CA{}, &ca.CA{}, "")
 	assert.Error(t, err, "Empty CA should have failed")
 
 	caDir := filepath.Join(testDir, "ca")
@@ -46,13 +51,13 @@ func TestGenerateLocalMSP(t *testing.T) {
 	mspDir := filepath.Join(testDir, "msp")
 
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName)
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, "")
 	assert.NoError(t, err, "Error generating CA")
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName)
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, "")
 	assert.NoError(t, err, "Error generating CA")
 	// generate local MSP
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, "")
 	assert.NoError(t, err, "Failed to generate local MSP")
 
 	// check to see that the right files were generated/saved
@@ -78,26 +83,144 @@ func TestGenerateLocalMSP(t *testing.T) {
 	assert.NoError(t, err, "Error setting up local MSP")
 
 	tlsCA.Name = "test/fail"
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, "")
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	signCA.Name = "test/fail"
-	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA)
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, "")
 	assert.Error(t, err, "Should have failed with CA name 'test/fail'")
 	t.Log(err)
 	cleanup(testDir)
 
 }
 
+func TestGenerateLocalMSPEd25519(t *testing.T) {
+
+	cleanup(testDir)
+
+	caDir := filepath.Join(testDir, "ca")
+	tlsCADir := filepath.Join(testDir, "tlsca")
+	mspDir := filepath.Join(testDir, "msp")
+	verifyingMSPDir := filepath.Join(testDir, "vmsp")
+
+	// generate an Ed25519 signing CA and an ECDSA TLS CA
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, "ED25519")
+	assert.NoError(t, err, "Error generating CA")
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, "")
+	assert.NoError(t, err, "Error generating CA")
+
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, "")
+	assert.NoError(t, err, "Failed to generate local MSP")
+
+	// the local MSP must be usable to sign
+	testMSPConfig, err := fabricmsp.GetLocalMspConfig(mspDir, nil, testName)
+	assert.NoError(t, err, "Error parsing local MSP config")
+	testMSP, err := fabricmsp.NewBccspMsp()
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	err = testMSP.Setup(testMSPConfig)
+	assert.NoError(t, err, "Error setting up local MSP")
+
+	id, err := testMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err, "Error getting the default signing identity")
+	msg := []byte("hello world")
+	sig, err := id.Sign(msg)
+	assert.NoError(t, err, "Error signing")
+
+	// the verifying MSP must accept the signer and its signature
+	err = msp.GenerateVerifyingMSP(verifyingMSPDir, signCA, tlsCA)
+	assert.NoError(t, err, "Failed to generate verifying MSP")
+	verifyingMSPConfig, err := fabricmsp.GetVerifyingMspConfig(verifyingMSPDir, testName)
+	assert.NoError(t, err, "Error parsing verifying MSP config")
+	verifyingMSP, err := fabricmsp.NewBccspMsp()
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	err = verifyingMSP.Setup(verifyingMSPConfig)
+	assert.NoError(t, err, "Error setting up verifying MSP")
+
+	serializedID, err := id.Serialize()
+	assert.NoError(t, err, "Error serializing identity")
+	verifyingID, err := verifyingMSP.DeserializeIdentity(serializedID)
+	assert.NoError(t, err, "Error deserializing identity")
+	assert.NoError(t, verifyingID.Validate())
+	assert.NoError(t, verifyingID.Verify(msg, sig))
+	assert.Error(t, verifyingID.Verify([]byte("bye world"), sig))
+
+	cleanup(testDir)
+}
+
+func TestGenerateLocalMSPEncryptedKeystore(t *testing.T) {
+
+	cleanup(testDir)
+
+	caDir := filepath.Join(testDir, "ca")
+	tlsCADir := filepath.Join(testDir, "tlsca")
+	mspDir := filepath.Join(testDir, "msp")
+	keystoreDir := filepath.Join(mspDir, "keystore")
+
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, "")
+	assert.NoError(t, err, "Error generating CA")
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, "")
+	assert.NoError(t, err, "Error generating CA")
+
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, "passwd")
+	assert.NoError(t, err, "Failed to generate local MSP")
+
+	// the MSP private key is encrypted...
+	files, err := ioutil.ReadDir(keystoreDir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	raw, err := ioutil.ReadFile(filepath.Join(keystoreDir, files[0].Name()))
+	assert.NoError(t, err)
+	block, _ := pem.Decode(raw)
+	assert.Equal(t, "ENCRYPTED PRIVATE KEY", block.Type)
+
+	// ...while the TLS private key is not
+	raw, err = ioutil.ReadFile(filepath.Join(testDir, "tls", "server.key"))
+	assert.NoError(t, err)
+	block, _ = pem.Decode(raw)
+	assert.Equal(t, "PRIVATE KEY", block.Type)
+
+	// the private key matching the signing certificate can only be
+	// loaded with the passphrase
+	raw, err = ioutil.ReadFile(filepath.Join(mspDir, "signcerts", testName+"-cert.pem"))
+	assert.NoError(t, err)
+	block, _ = pem.Decode(raw)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	assert.NoError(t, err)
+
+	for _, passphrase := range []string{"", "wrong", "passwd"} {
+		csp, err := factory.GetBCCSPFromOpts(&factory.FactoryOpts{
+			ProviderName: "SW",
+			SwOpts: &factory.SwOpts{
+				HashFamily:   "SHA2",
+				SecLevel:     256,
+				FileKeystore: &factory.FileKeystoreOpts{KeyStorePath: keystoreDir, Passphrase: passphrase},
+			},
+		})
+		assert.NoError(t, err)
+		pub, err := csp.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+		assert.NoError(t, err)
+		priv, err := csp.GetKey(pub.SKI())
+		if passphrase == "passwd" {
+			assert.NoError(t, err)
+			assert.True(t, priv.Private())
+		} else {
+			// the keystore falls back to the public key
+			assert.False(t, err == nil && priv.Private(), "private key loaded with passphrase [%s]", passphrase)
+		}
+	}
+
+	cleanup(testDir)
+}
+
 func TestGenerateVerifyingMSP(t *testing.T) {
 
 	caDir := filepath.Join(testDir, "ca")
 	tlsCADir := filepath.Join(testDir, "tlsca")
 	mspDir := filepath.Join(testDir, "msp")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName)
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, "")
 	assert.NoError(t, err, "Error generating CA")
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName)
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, "")
 	assert.NoError(t, err, "Error generating CA")
 
 	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA)
@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemixca
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/msp"
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/manudrijvers/amcl/go"
+)
+
+// GenerateIssuerKey invokes Idemix library to generate an issuer (CA) signing key pair.
+// Currently four attributes are supported by the issuer:
+// AttributeNameOU is the organization unit name
+// AttributeNameRole is the role (member or admin) name
+// AttributeNameEnrollmentId is the enrollment id
+// AttributeNameRevocationHandle contains the revocation handle; credentials cannot be revoked
+// yet, as only ALG_NO_REVOCATION is supported
+// Generated keys are serialized to bytes.
+func GenerateIssuerKey() ([]byte, []byte, error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, nil, err
+	}
+	AttributeNames := []string{msp.AttributeNameOU, msp.AttributeNameRole, msp.AttributeNameEnrollmentId, msp.AttributeNameRevocationHandle}
+	key, err := idemix.NewIssuerKey(AttributeNames, rng)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot generate CA key: %s", err)
+	}
+	ipkSerialized, err := proto.Marshal(key.Ipk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key.Isk, ipkSerialized, nil
+}
+
+// GenerateSignerConfig creates a new signer config.
+// It generates a fresh user secret and issues a credential
+// with four attributes (described above) using the CA's key pair.
+func GenerateSignerConfig(role m.MSPRole_MSPRoleType, ouString string, enrollmentId string, revocationHandle int, key *idemix.IssuerKey, revKey *ecdsa.PrivateKey) ([]byte, error) {
+	if ouString == "" {
+		return nil, fmt.Errorf("the OU attribute value is empty")
+	}
+
+	if enrollmentId == "" {
+		return nil, fmt.Errorf("the enrollment id value is empty")
+	}
+
+	attrs := make([]*amcl.BIG, 4)
+	attrs[msp.AttributeIndexOU] = idemix.HashModOrder([]byte(ouString))
+	attrs[msp.AttributeIndexRole] = amcl.NewBIGint(int(role))
+	attrs[msp.AttributeIndexEnrollmentId] = idemix.HashModOrder([]byte(enrollmentId))
+	attrs[msp.AttributeIndexRevocationHandle] = amcl.NewBIGint(revocationHandle)
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, fmt.Errorf("error getting PRNG: %s", err)
+	}
+	sk := idemix.RandModOrder(rng)
+	ni := idemix.BigToBytes(idemix.RandModOrder(rng))
+	msg := idemix.NewCredRequest(sk, ni, key.Ipk, rng)
+	cred, err := idemix.NewCredential(key, msg, attrs, rng)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate a credential: %s", err)
+	}
+
+	credBytes, err := proto.Marshal(cred)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal credential: %s", err)
+	}
+
+	// NOTE currently, idemixca creates CRI's with "ALG_NO_REVOCATION"
+	cri, err := idemix.CreateCRI(revKey, []*amcl.BIG{amcl.NewBIGint(revocationHandle)}, 0, idemix.ALG_NO_REVOCATION, rng)
+	if err != nil {
+		return nil, err
+	}
+	criBytes, err := proto.Marshal(cri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal CRI: %s", err)
+	}
+
+	signer := &m.IdemixMSPSignerConfig{
+		Cred:                            credBytes,
+		Sk:                              idemix.BigToBytes(sk),
+		OrganizationalUnitIdentifier:    ouString,
+		Role:                            int32(role),
+		EnrollmentId:                    enrollmentId,
+		CredentialRevocationInformation: criBytes,
+	}
+	return proto.Marshal(signer)
+}
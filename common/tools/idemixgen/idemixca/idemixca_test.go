@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemixca
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/msp"
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdemixCa(t *testing.T) {
+	isk, ipkBytes, err := GenerateIssuerKey()
+	assert.NoError(t, err)
+
+	ipk := &idemix.IssuerPublicKey{}
+	assert.NoError(t, proto.Unmarshal(ipkBytes, ipk))
+	assert.NoError(t, ipk.Check())
+
+	revocationKey, err := idemix.GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+
+	key := &idemix.IssuerKey{Isk: isk, Ipk: ipk}
+
+	_, err = GenerateSignerConfig(m.MSPRole_MEMBER, "", "enrollmentid", 1, key, revocationKey)
+	assert.Error(t, err, "generating a signer config without an OU should fail")
+	_, err = GenerateSignerConfig(m.MSPRole_MEMBER, "OU1", "", 1, key, revocationKey)
+	assert.Error(t, err, "generating a signer config without an enrollment id should fail")
+
+	signerConfig, err := GenerateSignerConfig(m.MSPRole_ADMIN, "OU1", "enrollmentid", 1, key, revocationKey)
+	assert.NoError(t, err)
+
+	// write the generated material the way the MSP expects it
+	testDir, err := ioutil.TempDir("", "idemixca-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	encodedRevocationPK, err := x509.MarshalPKIXPublicKey(revocationKey.Public())
+	assert.NoError(t, err)
+	pemEncodedRevocationPK := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encodedRevocationPK})
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(testDir, msp.IdemixConfigDirMsp), 0770))
+	assert.NoError(t, os.MkdirAll(filepath.Join(testDir, msp.IdemixConfigDirUser), 0770))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(testDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileIssuerPublicKey), ipkBytes, 0640))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(testDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileRevocationPublicKey), pemEncodedRevocationPK, 0640))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(testDir, msp.IdemixConfigDirUser, msp.IdemixConfigFileSigner), signerConfig, 0640))
+
+	// the resulting MSP sets up a default signer with the admin role
+	conf, err := msp.GetIdemixMspConfig(testDir, "TestMSP")
+	assert.NoError(t, err)
+	idemixMsp, err := msp.New(msp.IDEMIX, msp.MSPv1_1)
+	assert.NoError(t, err)
+	assert.NoError(t, idemixMsp.Setup(conf))
+
+	signer, err := idemixMsp.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	adminPrincipal, err := proto.Marshal(&m.MSPRole{MspIdentifier: "TestMSP", Role: m.MSPRole_ADMIN})
+	assert.NoError(t, err)
+	assert.NoError(t, signer.SatisfiesPrincipal(&m.MSPPrincipal{PrincipalClassification: m.MSPPrincipal_ROLE, Principal: adminPrincipal}))
+}
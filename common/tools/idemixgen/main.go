@@ -0,0 +1,179 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+// idemixgen is a command line tool that generates the CA's keys and
+// generates MSP configs for signing and for verification
+// This tool can be used to setup the peers and CA to support
+// the Identity Mixer MSP
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/tools/idemixgen/idemixca"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/msp"
+	m "github.com/hyperledger/fabric/protos/msp"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const (
+	IdemixDirIssuer                    = "ca"
+	IdemixConfigIssuerSecretKey        = "IssuerSecretKey"
+	IdemixConfigRevocationAuthorityKey = "RevocationKey"
+)
+
+// command line flags
+var (
+	app = kingpin.New("idemixgen", "Utility for generating key material to be used with the Identity Mixer MSP in Hyperledger Fabric")
+
+	outputDir = app.Flag("output", "The output directory in which to place artifacts").Default("idemix-config").String()
+
+	genIssuerKey = app.Command("ca-keygen", "Generate CA key material")
+
+	genSignerConfig         = app.Command("signerconfig", "Generate a default signer for this Idemix MSP")
+	genCAInput              = genSignerConfig.Flag("ca-input", "The folder where CA's secrets are stored").String()
+	genCredOU               = genSignerConfig.Flag("org-unit", "The Organizational Unit of the default signer").Short('u').String()
+	genCredRole             = genSignerConfig.Flag("role", "The role of the default signer (member, admin, client, peer or orderer)").Default("member").Enum("member", "admin", "client", "peer", "orderer")
+	genCredEnrollmentId     = genSignerConfig.Flag("enrollmentId", "The enrollment id of the default signer").Short('e').String()
+	genCredRevocationHandle = genSignerConfig.Flag("revocationHandle", "The revocation handle of the default signer (credentials cannot be revoked yet)").Short('r').Int()
+
+	version = app.Command("version", "Show version information")
+)
+
+func main() {
+	app.HelpFlag.Short('h')
+
+	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+
+	// "ca-keygen" command
+	case genIssuerKey.FullCommand():
+		isk, ipk, err := idemixca.GenerateIssuerKey()
+		handleError(err)
+
+		revocationKey, err := idemix.GenerateLongTermRevocationKey()
+		handleError(err)
+		encodedRevocationSK, err := x509.MarshalECPrivateKey(revocationKey)
+		handleError(err)
+		pemEncodedRevocationSK := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: encodedRevocationSK})
+		encodedRevocationPK, err := x509.MarshalPKIXPublicKey(revocationKey.Public())
+		handleError(err)
+		pemEncodedRevocationPK := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encodedRevocationPK})
+
+		// Prevent overwriting the existing key
+		path := filepath.Join(*outputDir, IdemixDirIssuer)
+		checkDirectoryNotExists(path, fmt.Sprintf("Directory %s already exists", path))
+
+		path = filepath.Join(*outputDir, msp.IdemixConfigDirMsp)
+		checkDirectoryNotExists(path, fmt.Sprintf("Directory %s already exists", path))
+
+		// write private and public keys to the file
+		handleError(os.MkdirAll(filepath.Join(*outputDir, IdemixDirIssuer), 0770))
+		handleError(os.MkdirAll(filepath.Join(*outputDir, msp.IdemixConfigDirMsp), 0770))
+		writeFile(filepath.Join(*outputDir, IdemixDirIssuer, IdemixConfigIssuerSecretKey), isk)
+		writeFile(filepath.Join(*outputDir, IdemixDirIssuer, IdemixConfigRevocationAuthorityKey), pemEncodedRevocationSK)
+		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileIssuerPublicKey), ipk)
+		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileRevocationPublicKey), pemEncodedRevocationPK)
+
+	// "signerconfig" command
+	case genSignerConfig.FullCommand():
+		roleValue := m.MSPRole_MSPRoleType_value[strings.ToUpper(*genCredRole)]
+		config, err := idemixca.GenerateSignerConfig(m.MSPRole_MSPRoleType(roleValue), *genCredOU, *genCredEnrollmentId, *genCredRevocationHandle, readIssuerKey(), readRevocationKey())
+		handleError(err)
+
+		path := filepath.Join(*outputDir, msp.IdemixConfigDirUser)
+		checkDirectoryNotExists(path, fmt.Sprintf("This MSP config already contains a directory \"%s\"", path))
+
+		// Write config to file
+		handleError(os.MkdirAll(filepath.Join(*outputDir, msp.IdemixConfigDirUser), 0770))
+		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirUser, msp.IdemixConfigFileSigner), config)
+
+	// "version" command
+	case version.FullCommand():
+		printVersion()
+	}
+}
+
+func printVersion() {
+	fmt.Println("Development Build")
+}
+
+// writeFile writes bytes to a file and panics in case of an error
+func writeFile(path string, contents []byte) {
+	handleError(ioutil.WriteFile(path, contents, 0640))
+}
+
+// readIssuerKey reads the issuer key from the CA input directory
+func readIssuerKey() *idemix.IssuerKey {
+	path := filepath.Join(*genCAInput, IdemixDirIssuer, IdemixConfigIssuerSecretKey)
+	isk, err := ioutil.ReadFile(path)
+	if err != nil {
+		handleError(fmt.Errorf("failed to open issuer secret key file: %s", path))
+	}
+	path = filepath.Join(*genCAInput, msp.IdemixConfigDirMsp, msp.IdemixConfigFileIssuerPublicKey)
+	ipkBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		handleError(fmt.Errorf("failed to open issuer public key file: %s", path))
+	}
+	ipk := &idemix.IssuerPublicKey{}
+	handleError(proto.Unmarshal(ipkBytes, ipk))
+	key := &idemix.IssuerKey{Isk: isk, Ipk: ipk}
+
+	return key
+}
+
+// readRevocationKey reads the long term revocation key of the CA
+func readRevocationKey() *ecdsa.PrivateKey {
+	path := filepath.Join(*genCAInput, IdemixDirIssuer, IdemixConfigRevocationAuthorityKey)
+	keyBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		handleError(fmt.Errorf("failed to open revocation secret key file: %s", path))
+	}
+
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		handleError(errors.New("failed to decode ECDSA private key"))
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	handleError(err)
+
+	return key
+}
+
+// checkDirectoryNotExists checks whether a directory with the given path already exists and exits if this is the case
+func checkDirectoryNotExists(path string, errorMessage string) {
+	_, err := os.Stat(path)
+	if err == nil {
+		handleError(errors.New(errorMessage))
+	}
+}
+
+func handleError(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
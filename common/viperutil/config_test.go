@@ -24,6 +24,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/hyperledger/fabric/orderer/mocks/util"
@@ -478,3 +479,30 @@ func TestEnhancedExactUnmarshalKey(t *testing.T) {
 	}
 
 }
+
+func TestEnhancedExactUnmarshalKeyDuration(t *testing.T) {
+	type nestedKey struct {
+		Timeout time.Duration
+	}
+
+	yaml := "---\n" +
+		"Top:\n" +
+		"  Nested:\n" +
+		"    Timeout: 10s\n"
+
+	viper.SetConfigType("yaml")
+	defer viper.Reset()
+
+	if err := viper.ReadConfig(bytes.NewReader([]byte(yaml))); err != nil {
+		t.Fatalf("Error reading config: %s", err)
+	}
+
+	var uconf nestedKey
+	if err := EnhancedExactUnmarshalKey("top.Nested", &uconf); err != nil {
+		t.Fatalf("Failed to unmarshall: %s", err)
+	}
+
+	if uconf.Timeout != 10*time.Second {
+		t.Fatalf(`Expected: "%s", Actual: "%s"`, 10*time.Second, uconf.Timeout)
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aclmgmt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/policy"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+var aclLogger = flogging.MustGetLogger("aclmgmt")
+
+// ACLProvider checks the access to the resources of the peer
+type ACLProvider interface {
+	// CheckACL checks that idinfo satisfies the policy mapped to the
+	// resource on the given channel. idinfo is either the
+	// *pb.SignedProposal or the []*common.SignedData of the request.
+	CheckACL(resName string, channelID string, idinfo interface{}) error
+}
+
+// PolicyMapperGetter retrieves the PolicyMapper of a channel
+type PolicyMapperGetter interface {
+	// PolicyMapper returns the PolicyMapper defined by the configuration
+	// of the channel, or false if the channel has no application config
+	PolicyMapper(channelID string) (config.PolicyMapper, bool)
+}
+
+// resourcePolicy is the policy a resource is checked against when
+// the configuration of the channel does not define an ACL for it
+type resourcePolicy struct {
+	name string
+
+	// local policies are checked against the local MSP
+	// rather than against the policies of the channel
+	local bool
+}
+
+var defaultPolicies = map[string]resourcePolicy{
+	PeerPropose:              {name: policies.ChannelApplicationWriters},
+	PeerChaincodeToChaincode: {name: policies.ChannelApplicationWriters},
+
+	EventBlock: {name: mgmt.Members, local: true},
+
+	QsccGetChainInfo:       {name: policies.ChannelApplicationReaders},
+	QsccGetBlockByNumber:   {name: policies.ChannelApplicationReaders},
+	QsccGetBlockByHash:     {name: policies.ChannelApplicationReaders},
+	QsccGetTransactionByID: {name: policies.ChannelApplicationReaders},
+	QsccGetBlockByTxID:     {name: policies.ChannelApplicationReaders},
+
+	CsccJoinChain:      {name: mgmt.Admins, local: true},
+	CsccGetConfigBlock: {name: policies.ChannelApplicationReaders},
+	CsccGetChannels:    {name: mgmt.Members, local: true},
+
+	LsccInstall:                   {name: mgmt.Admins, local: true},
+	LsccGetChaincodeInfo:          {name: policies.ChannelApplicationReaders},
+	LsccGetDeploymentSpec:         {name: policies.ChannelApplicationReaders},
+	LsccGetChaincodeData:          {name: policies.ChannelApplicationReaders},
+	LsccGetInstantiatedChaincodes: {name: mgmt.Admins, local: true},
+	LsccGetInstalledChaincodes:    {name: mgmt.Admins, local: true},
+
+	LifecycleApprove:             {name: policies.ChannelApplicationWriters},
+	LifecycleCommit:              {name: policies.ChannelApplicationWriters},
+	LifecycleQueryApprovalStatus: {name: policies.ChannelApplicationReaders},
+	LifecycleQueryDefinition:     {name: policies.ChannelApplicationReaders},
+	LifecycleGetChaincodeData:    {name: policies.ChannelApplicationReaders},
+}
+
+type aclProvider struct {
+	policyChecker policy.PolicyChecker
+	mapperGetter  PolicyMapperGetter
+}
+
+// NewACLProvider returns an ACLProvider which checks the resources against
+// the policies referenced by the ACLs of their channel, as returned by
+// mapperGetter, and falls back to the default policy of each resource.
+// A nil mapperGetter always checks the default policies.
+func NewACLProvider(policyChecker policy.PolicyChecker, mapperGetter PolicyMapperGetter) ACLProvider {
+	return &aclProvider{
+		policyChecker: policyChecker,
+		mapperGetter:  mapperGetter,
+	}
+}
+
+// CheckACL checks that idinfo satisfies the policy mapped to the
+// resource on the given channel
+func (p *aclProvider) CheckACL(resName string, channelID string, idinfo interface{}) error {
+	if policyRef := p.channelPolicyRef(resName, channelID); policyRef != "" {
+		policyName := qualifiedPolicyName(policyRef)
+		aclLogger.Debugf("Checking resource %s against policy %s of channel %s", resName, policyName, channelID)
+		return p.checkChannelPolicy(channelID, policyName, idinfo)
+	}
+
+	rp, ok := defaultPolicies[resName]
+	if !ok {
+		return fmt.Errorf("No policy defined for resource %s", resName)
+	}
+	if rp.local {
+		aclLogger.Debugf("Checking resource %s against local MSP policy %s", resName, rp.name)
+		return p.checkLocalPolicy(rp.name, idinfo)
+	}
+	aclLogger.Debugf("Checking resource %s against default policy %s of channel %s", resName, rp.name, channelID)
+	return p.checkChannelPolicy(channelID, rp.name, idinfo)
+}
+
+// channelPolicyRef returns the policy the configuration of the channel
+// maps the resource to, or the empty string if it does not
+func (p *aclProvider) channelPolicyRef(resName string, channelID string) string {
+	if channelID == "" || p.mapperGetter == nil {
+		return ""
+	}
+	mapper, ok := p.mapperGetter.PolicyMapper(channelID)
+	if !ok || mapper == nil {
+		return ""
+	}
+	return mapper.PolicyRefForAPI(resName)
+}
+
+func (p *aclProvider) checkChannelPolicy(channelID string, policyName string, idinfo interface{}) error {
+	switch idinfo := idinfo.(type) {
+	case *pb.SignedProposal:
+		return p.policyChecker.CheckPolicy(channelID, policyName, idinfo)
+	case []*common.SignedData:
+		return p.policyChecker.CheckPolicyBySignedData(channelID, policyName, idinfo)
+	default:
+		return fmt.Errorf("Unsupported identity information of type %T", idinfo)
+	}
+}
+
+func (p *aclProvider) checkLocalPolicy(policyName string, idinfo interface{}) error {
+	switch idinfo := idinfo.(type) {
+	case *pb.SignedProposal:
+		return p.policyChecker.CheckPolicyNoChannel(policyName, idinfo)
+	case []*common.SignedData:
+		return p.policyChecker.CheckPolicyNoChannelBySignedData(policyName, idinfo)
+	default:
+		return fmt.Errorf("Unsupported identity information of type %T", idinfo)
+	}
+}
+
+// qualifiedPolicyName resolves policy references which are not absolute
+// against the application policies of the channel
+func qualifiedPolicyName(policyRef string) string {
+	if strings.HasPrefix(policyRef, policies.PathSeparator) {
+		return policyRef
+	}
+	return policies.PathSeparator + policies.ChannelPrefix + policies.PathSeparator +
+		policies.ApplicationPrefix + policies.PathSeparator + policyRef
+}
@@ -0,0 +1,163 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aclmgmt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPolicyChecker records the last check it performed
+type mockPolicyChecker struct {
+	err        error
+	method     string
+	channelID  string
+	policyName string
+}
+
+func (c *mockPolicyChecker) record(method, channelID, policyName string) error {
+	c.method, c.channelID, c.policyName = method, channelID, policyName
+	return c.err
+}
+
+func (c *mockPolicyChecker) CheckPolicy(channelID, policyName string, signedProp *pb.SignedProposal) error {
+	return c.record("CheckPolicy", channelID, policyName)
+}
+
+func (c *mockPolicyChecker) CheckPolicyBySignedData(channelID, policyName string, sd []*common.SignedData) error {
+	return c.record("CheckPolicyBySignedData", channelID, policyName)
+}
+
+func (c *mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *pb.SignedProposal) error {
+	return c.record("CheckPolicyNoChannel", "", policyName)
+}
+
+func (c *mockPolicyChecker) CheckPolicyNoChannelBySignedData(policyName string, sd []*common.SignedData) error {
+	return c.record("CheckPolicyNoChannelBySignedData", "", policyName)
+}
+
+type mockPolicyMapper map[string]string
+
+func (m mockPolicyMapper) PolicyRefForAPI(apiName string) string {
+	return m[apiName]
+}
+
+type mockPolicyMapperGetter map[string]mockPolicyMapper
+
+func (g mockPolicyMapperGetter) PolicyMapper(channelID string) (config.PolicyMapper, bool) {
+	mapper, ok := g[channelID]
+	return mapper, ok
+}
+
+func TestDefaultPolicies(t *testing.T) {
+	checker := &mockPolicyChecker{}
+	provider := NewACLProvider(checker, mockPolicyMapperGetter{})
+	sp := &pb.SignedProposal{}
+
+	assert.NoError(t, provider.CheckACL(PeerPropose, "mychannel", sp))
+	assert.Equal(t, "CheckPolicy", checker.method)
+	assert.Equal(t, "mychannel", checker.channelID)
+	assert.Equal(t, policies.ChannelApplicationWriters, checker.policyName)
+
+	assert.NoError(t, provider.CheckACL(QsccGetBlockByNumber, "mychannel", sp))
+	assert.Equal(t, policies.ChannelApplicationReaders, checker.policyName)
+
+	assert.NoError(t, provider.CheckACL(LsccInstall, "", sp))
+	assert.Equal(t, "CheckPolicyNoChannel", checker.method)
+	assert.Equal(t, mgmt.Admins, checker.policyName)
+
+	// local policies are checked against the local MSP even on a channel
+	assert.NoError(t, provider.CheckACL(LsccGetInstantiatedChaincodes, "mychannel", sp))
+	assert.Equal(t, "CheckPolicyNoChannel", checker.method)
+	assert.Equal(t, mgmt.Admins, checker.policyName)
+
+	err := provider.CheckACL("foo/Bar", "mychannel", sp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "No policy defined for resource foo/Bar")
+}
+
+func TestChannelPolicies(t *testing.T) {
+	checker := &mockPolicyChecker{}
+	provider := NewACLProvider(checker, mockPolicyMapperGetter{
+		"mychannel": {
+			QsccGetBlockByNumber:          "/Channel/Application/Admins",
+			LsccGetInstantiatedChaincodes: "Readers",
+		},
+	})
+	sp := &pb.SignedProposal{}
+
+	assert.NoError(t, provider.CheckACL(QsccGetBlockByNumber, "mychannel", sp))
+	assert.Equal(t, "CheckPolicy", checker.method)
+	assert.Equal(t, "mychannel", checker.channelID)
+	assert.Equal(t, policies.ChannelApplicationAdmins, checker.policyName)
+
+	// relative references are resolved against the application policies
+	assert.NoError(t, provider.CheckACL(LsccGetInstantiatedChaincodes, "mychannel", sp))
+	assert.Equal(t, "CheckPolicy", checker.method)
+	assert.Equal(t, policies.ChannelApplicationReaders, checker.policyName)
+
+	// resources without ACL fall back to their default policy
+	assert.NoError(t, provider.CheckACL(QsccGetChainInfo, "mychannel", sp))
+	assert.Equal(t, policies.ChannelApplicationReaders, checker.policyName)
+
+	// other channels keep the default policies
+	assert.NoError(t, provider.CheckACL(QsccGetBlockByNumber, "otherchannel", sp))
+	assert.Equal(t, "otherchannel", checker.channelID)
+	assert.Equal(t, policies.ChannelApplicationReaders, checker.policyName)
+
+	// a channel ACL may grant access to resources without default policy
+	provider = NewACLProvider(checker, mockPolicyMapperGetter{
+		"mychannel": {"foo/Bar": "Writers"},
+	})
+	assert.NoError(t, provider.CheckACL("foo/Bar", "mychannel", sp))
+	assert.Equal(t, policies.ChannelApplicationWriters, checker.policyName)
+}
+
+func TestSignedData(t *testing.T) {
+	checker := &mockPolicyChecker{}
+	provider := NewACLProvider(checker, nil)
+	sd := []*common.SignedData{{}}
+
+	assert.NoError(t, provider.CheckACL(EventBlock, "", sd))
+	assert.Equal(t, "CheckPolicyNoChannelBySignedData", checker.method)
+	assert.Equal(t, mgmt.Members, checker.policyName)
+
+	assert.NoError(t, provider.CheckACL(PeerPropose, "mychannel", sd))
+	assert.Equal(t, "CheckPolicyBySignedData", checker.method)
+	assert.Equal(t, policies.ChannelApplicationWriters, checker.policyName)
+
+	err := provider.CheckACL(PeerPropose, "mychannel", "foo")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Unsupported identity information of type string")
+	err = provider.CheckACL(EventBlock, "", "foo")
+	assert.Error(t, err)
+}
+
+func TestCheckFailure(t *testing.T) {
+	checker := &mockPolicyChecker{err: errors.New("denied")}
+	provider := NewACLProvider(checker, mockPolicyMapperGetter{"mychannel": {PeerPropose: "Admins"}})
+
+	assert.EqualError(t, provider.CheckACL(PeerPropose, "mychannel", &pb.SignedProposal{}), "denied")
+	assert.EqualError(t, provider.CheckACL(CsccGetChannels, "", &pb.SignedProposal{}), "denied")
+}
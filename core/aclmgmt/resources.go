@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aclmgmt
+
+// Names of the peer resources whose access is controlled through ACLs.
+// The functions of a system chaincode are named after the system chaincode
+// and the function, e.g. qscc/GetBlockByNumber.
+const (
+	// Peer resources
+	PeerPropose              = "peer/Propose"
+	PeerChaincodeToChaincode = "peer/ChaincodeToChaincode"
+
+	// Events. Events are not channel specific, hence event/Block is always
+	// checked against the local MSP and cannot be set in the ACLs of a channel
+	EventBlock = "event/Block"
+
+	// Query system chaincode functions
+	QsccGetChainInfo       = "qscc/GetChainInfo"
+	QsccGetBlockByNumber   = "qscc/GetBlockByNumber"
+	QsccGetBlockByHash     = "qscc/GetBlockByHash"
+	QsccGetTransactionByID = "qscc/GetTransactionByID"
+	QsccGetBlockByTxID     = "qscc/GetBlockByTxID"
+
+	// Configuration system chaincode functions
+	CsccJoinChain      = "cscc/JoinChain"
+	CsccGetConfigBlock = "cscc/GetConfigBlock"
+	CsccGetChannels    = "cscc/GetChannels"
+
+	// Lifecycle system chaincode functions
+	LsccInstall                   = "lscc/install"
+	LsccGetChaincodeInfo          = "lscc/getid"
+	LsccGetDeploymentSpec         = "lscc/getdepspec"
+	LsccGetChaincodeData          = "lscc/getccdata"
+	LsccGetInstantiatedChaincodes = "lscc/getchaincodes"
+	LsccGetInstalledChaincodes    = "lscc/getinstalledchaincodes"
+
+	// Chaincode definition lifecycle functions
+	LifecycleApprove             = "_lifecycle/approve"
+	LifecycleCommit              = "_lifecycle/commit"
+	LifecycleQueryApprovalStatus = "_lifecycle/queryapprovalstatus"
+	LifecycleQueryDefinition     = "_lifecycle/querydefinition"
+	LifecycleGetChaincodeData    = "_lifecycle/getccdata"
+)
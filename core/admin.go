@@ -17,31 +17,73 @@ limitations under the License.
 package core
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/policy"
+	"github.com/hyperledger/fabric/gossip/api"
+	gcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/msp/mgmt"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"golang.org/x/net/context"
 )
 
 var log = flogging.MustGetLogger("server")
 
+// maxAdminRequestAge is how far the timestamp of a signed admin request
+// may be from the time of the peer, so that requests can't be replayed
+// long after they were signed
+const maxAdminRequestAge = 15 * time.Minute
+
 // NewAdminServer creates and returns a Admin service instance.
 func NewAdminServer() *ServerAdmin {
-	s := new(ServerAdmin)
+	s := &ServerAdmin{
+		policyChecker: policy.NewPolicyChecker(nil, mgmt.GetLocalMSP(), mgmt.NewLocalMSPPrincipalGetter()),
+	}
 	return s
 }
 
 // ServerAdmin implementation of the Admin service for the Peer
 type ServerAdmin struct {
+	policyChecker policy.PolicyChecker
 }
 
-// GetStatus reports the status of the server
+// GetStatus reports the status of the server, along with
+// when the signing and TLS certificates of the peer expire
 func (*ServerAdmin) GetStatus(context.Context, *empty.Empty) (*pb.ServerStatus, error) {
 	status := &pb.ServerStatus{Status: pb.ServerStatus_STARTED}
+	if id, err := mgmt.GetLocalMSP().GetDefaultSigningIdentity(); err == nil {
+		if idBytes, err := id.Serialize(); err == nil {
+			status.SigningCertExpiration = expirationTimestamp(crypto.ExpiresAt(idBytes))
+		}
+	}
+	if comm.TLSEnabled() {
+		if cert, err := ioutil.ReadFile(config.GetPath("peer.tls.cert.file")); err == nil {
+			status.TlsCertExpiration = expirationTimestamp(crypto.CertExpiresAt(cert))
+		}
+	}
 	log.Debugf("returning status: %s", status)
 	return status, nil
 }
 
+func expirationTimestamp(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return &timestamp.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
+
 // StartServer starts the server
 func (*ServerAdmin) StartServer(context.Context, *empty.Empty) (*pb.ServerStatus, error) {
 	status := &pb.ServerStatus{Status: pb.ServerStatus_STARTED}
@@ -71,3 +113,73 @@ func (*ServerAdmin) RevertLogLevels(context.Context, *empty.Empty) (*empty.Empty
 
 	return &empty.Empty{}, err
 }
+
+// validateAdminRequest checks that the signed proposal was signed recently by
+// an admin of the local MSP, and unmarshals the request in its payload
+func (s *ServerAdmin) validateAdminRequest(signedProp *pb.SignedProposal, request proto.Message) error {
+	if err := s.policyChecker.CheckPolicyNoChannel(mgmt.Admins, signedProp); err != nil {
+		return fmt.Errorf("access denied: %s", err)
+	}
+	prop, err := utils.GetProposal(signedProp.ProposalBytes)
+	if err != nil {
+		return err
+	}
+	hdr, err := utils.GetHeader(prop.Header)
+	if err != nil {
+		return err
+	}
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return err
+	}
+	if chdr.Timestamp == nil {
+		return errors.New("request has no timestamp")
+	}
+	age := time.Since(time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos)))
+	if age > maxAdminRequestAge || age < -maxAdminRequestAge {
+		return fmt.Errorf("request timestamp is %s away from the time of the peer, more than %s", age, maxAdminRequestAge)
+	}
+	return proto.Unmarshal(prop.Payload, request)
+}
+
+// UpdateGossipEndpoint updates the endpoint the peer publishes to peers of
+// other organizations, which spreads to the rest of the network via gossip
+func (s *ServerAdmin) UpdateGossipEndpoint(ctx context.Context, signedProp *pb.SignedProposal) (*empty.Empty, error) {
+	request := &pb.GossipEndpointRequest{}
+	if err := s.validateAdminRequest(signedProp, request); err != nil {
+		return nil, err
+	}
+	gossipService := service.GetGossipService()
+	if gossipService == nil {
+		return nil, errors.New("gossip service isn't initialized")
+	}
+	if err := gossipService.UpdateExternalEndpoint(request.Endpoint); err != nil {
+		return nil, err
+	}
+	log.Infof("Updated the external gossip endpoint to %s", request.Endpoint)
+	return &empty.Empty{}, nil
+}
+
+// AddAnchorPeers makes the peer connect to the given anchor peers of an
+// organization of a channel, in addition to the anchor peers of the
+// channel configuration, until the peer restarts
+func (s *ServerAdmin) AddAnchorPeers(ctx context.Context, signedProp *pb.SignedProposal) (*empty.Empty, error) {
+	request := &pb.AnchorPeersRequest{}
+	if err := s.validateAdminRequest(signedProp, request); err != nil {
+		return nil, err
+	}
+	gossipService := service.GetGossipService()
+	if gossipService == nil {
+		return nil, errors.New("gossip service isn't initialized")
+	}
+	var anchorPeers []api.AnchorPeer
+	for _, ap := range request.AnchorPeers {
+		anchorPeers = append(anchorPeers, api.AnchorPeer{Host: ap.Host, Port: int(ap.Port)})
+	}
+	err := gossipService.AddAnchorPeers(gcommon.ChainID(request.ChannelId), api.OrgIdentityType(request.MspId), anchorPeers)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Added anchor peers %v of %s in channel %s", anchorPeers, request.MspId, request.ChannelId)
+	return &empty.Empty{}, nil
+}
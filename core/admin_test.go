@@ -18,12 +18,19 @@ package core
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/policy"
 	"github.com/hyperledger/fabric/core/testutil"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -40,6 +47,14 @@ func TestGetStatus(t *testing.T) {
 	assert.Nil(t, err, "Error should have been nil")
 }
 
+func TestExpirationTimestamp(t *testing.T) {
+	assert.Nil(t, expirationTimestamp(time.Time{}))
+	expiresAt := time.Date(2027, 6, 1, 12, 0, 0, 500, time.UTC)
+	ts := expirationTimestamp(expiresAt)
+	assert.Equal(t, expiresAt.Unix(), ts.Seconds)
+	assert.Equal(t, int32(500), ts.Nanos)
+}
+
 func TestStartServer(t *testing.T) {
 	response, err := adminServer.StartServer(context.Background(), &empty.Empty{})
 	assert.NotNil(t, response, "Response should have been set")
@@ -68,3 +83,68 @@ func TestLoggingCalls(t *testing.T) {
 	assert.Equal(t, flogging.DefaultLevel(), logResponse.LogLevel, "log level should have been the default")
 	assert.Nil(t, err, "Error should have been nil")
 }
+
+type mockPolicyChecker struct {
+	policy.PolicyChecker
+	policyName string
+	err        error
+}
+
+func (m *mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *pb.SignedProposal) error {
+	m.policyName = policyName
+	return m.err
+}
+
+func adminRequest(t *testing.T, request proto.Message, ts time.Time) *pb.SignedProposal {
+	chdr := utils.MakeChannelHeader(cb.HeaderType_MESSAGE, 0, "", 0)
+	chdr.Timestamp.Seconds = ts.Unix()
+	shdr := utils.MakeSignatureHeader([]byte("creator"), []byte("nonce"))
+	payload, err := proto.Marshal(request)
+	assert.NoError(t, err)
+	prop := &pb.Proposal{
+		Header:  utils.MarshalOrPanic(utils.MakePayloadHeader(chdr, shdr)),
+		Payload: payload,
+	}
+	return &pb.SignedProposal{ProposalBytes: utils.MarshalOrPanic(prop), Signature: []byte("signature")}
+}
+
+func TestUpdateGossipEndpoint(t *testing.T) {
+	checker := &mockPolicyChecker{err: errors.New("not an admin")}
+	server := &ServerAdmin{policyChecker: checker}
+	request := &pb.GossipEndpointRequest{Endpoint: "peer0:7051"}
+
+	_, err := server.UpdateGossipEndpoint(context.Background(), adminRequest(t, request, time.Now()))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+	assert.Equal(t, mgmt.Admins, checker.policyName)
+
+	checker.err = nil
+	_, err = server.UpdateGossipEndpoint(context.Background(), adminRequest(t, request, time.Now().Add(-time.Hour)))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "request timestamp")
+
+	// The gossip service isn't initialized in this test
+	_, err = server.UpdateGossipEndpoint(context.Background(), adminRequest(t, request, time.Now()))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "gossip service isn't initialized")
+}
+
+func TestAddAnchorPeers(t *testing.T) {
+	checker := &mockPolicyChecker{err: errors.New("not an admin")}
+	server := &ServerAdmin{policyChecker: checker}
+	request := &pb.AnchorPeersRequest{
+		ChannelId:   "testchainid",
+		MspId:       "SampleOrg",
+		AnchorPeers: []*pb.AnchorPeer{{Host: "peer0", Port: 7051}},
+	}
+
+	_, err := server.AddAnchorPeers(context.Background(), adminRequest(t, request, time.Now()))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+
+	// The gossip service isn't initialized in this test
+	checker.err = nil
+	_, err = server.AddAnchorPeers(context.Background(), adminRequest(t, request, time.Now()))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "gossip service isn't initialized")
+}
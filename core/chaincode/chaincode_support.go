@@ -32,8 +32,10 @@ import (
 	"strings"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/limits"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/container"
@@ -50,12 +52,18 @@ const (
 	DevModeUserRunsChaincode       string = "dev"
 	chaincodeStartupTimeoutDefault int    = 5000
 	peerAddressDefault             string = "0.0.0.0:7051"
+	stateChunkSizeDefault          int    = 1024 * 1024
+	maxPendingStateSizeDefault     int    = 128 * 1024 * 1024
 
 	//TXSimulatorKey is used to attach ledger simulation context
 	TXSimulatorKey key = "txsimulatorkey"
 
 	//HistoryQueryExecutorKey is used to attach ledger history query executor context
 	HistoryQueryExecutorKey key = "historyqueryexecutorkey"
+
+	//QueryKey marks executions whose results are not endorsed, to which
+	//the query timeout of the chaincode applies
+	QueryKey key = "querykey"
 )
 
 //this is basically the singleton that supports the
@@ -180,6 +188,33 @@ func NewChaincodeSupport(getCCEndpoint func() (*pb.PeerEndpoint, error), userrun
 
 	theChaincodeSupport.executetimeout = execto
 
+	theChaincodeSupport.maxRecvMsgSize = comm.MaxRecvMsgSize()
+	if size := viper.GetInt("chaincode.stream.maxRecvMsgSize"); size > 0 {
+		theChaincodeSupport.maxRecvMsgSize = size
+	}
+	theChaincodeSupport.maxSendMsgSize = comm.MaxSendMsgSize()
+	if size := viper.GetInt("chaincode.stream.maxSendMsgSize"); size > 0 {
+		theChaincodeSupport.maxSendMsgSize = size
+	}
+
+	//state values larger than the chunk size are transferred in several
+	//messages, each of which has to fit in the stream's message size limits
+	theChaincodeSupport.stateChunkSize = stateChunkSizeDefault
+	if size := viper.GetInt("chaincode.stream.chunkSize"); size > 0 {
+		theChaincodeSupport.stateChunkSize = size
+	}
+	theChaincodeSupport.maxPendingStateSize = maxPendingStateSizeDefault
+	if size := viper.GetInt("chaincode.stream.maxPendingSize"); size > 0 {
+		theChaincodeSupport.maxPendingStateSize = size
+	}
+	limit := theChaincodeSupport.maxRecvMsgSize
+	if theChaincodeSupport.maxSendMsgSize < limit {
+		limit = theChaincodeSupport.maxSendMsgSize
+	}
+	if theChaincodeSupport.stateChunkSize >= limit {
+		chaincodeLogger.Warningf("Chunk size %d does not fit in the chaincode stream message size limit %d", theChaincodeSupport.stateChunkSize, limit)
+	}
+
 	viper.SetEnvPrefix("CORE")
 	viper.AutomaticEnv()
 	replacer := strings.NewReplacer(".", "_")
@@ -229,6 +264,12 @@ type ChaincodeSupport struct {
 	executetimeout    time.Duration
 	userRunsCC        bool
 	peerTLS           bool
+	maxRecvMsgSize    int
+	maxSendMsgSize    int
+	stateChunkSize    int
+	// maxPendingStateSize bounds the bytes of the state values
+	// a transaction has in chunked transfer at any time
+	maxPendingStateSize int
 }
 
 // DuplicateChaincodeHandlerError returned if attempt to register same chaincodeID while a stream already exists.
@@ -276,6 +317,11 @@ func (chaincodeSupport *ChaincodeSupport) registerHandler(chaincodehandler *Hand
 	chaincodehandler.txCtxs = make(map[string]*transactionContext)
 	chaincodehandler.txidMap = make(map[string]bool)
 
+	//bound the number of transactions the chaincode executes at the same time
+	if l := limits.GetLimits(getChaincodeInstance(key).ChaincodeName); l.MaxConcurrency > 0 {
+		chaincodehandler.txSlots = make(chan struct{}, l.MaxConcurrency)
+	}
+
 	chaincodeLogger.Debugf("registered handler complete for chaincode %s", key)
 
 	return nil
@@ -383,6 +429,17 @@ func (chaincodeSupport *ChaincodeSupport) getArgsAndEnv(cccid *ccprovider.CCCont
 	if chaincodeSupport.logFormat != "" {
 		envs = append(envs, "CORE_CHAINCODE_LOGGING_FORMAT="+chaincodeSupport.logFormat)
 	}
+
+	// what the peer sends, the chaincode receives and vice versa
+	if chaincodeSupport.maxSendMsgSize > 0 {
+		envs = append(envs, fmt.Sprintf("CORE_CHAINCODE_STREAM_MAXRECVMSGSIZE=%d", chaincodeSupport.maxSendMsgSize))
+	}
+	if chaincodeSupport.maxRecvMsgSize > 0 {
+		envs = append(envs, fmt.Sprintf("CORE_CHAINCODE_STREAM_MAXSENDMSGSIZE=%d", chaincodeSupport.maxRecvMsgSize))
+	}
+	if chaincodeSupport.stateChunkSize > 0 {
+		envs = append(envs, fmt.Sprintf("CORE_CHAINCODE_STREAM_CHUNKSIZE=%d", chaincodeSupport.stateChunkSize))
+	}
 	switch cLang {
 	case pb.ChaincodeSpec_GOLANG, pb.ChaincodeSpec_CAR:
 		args = []string{"chaincode", fmt.Sprintf("-peer.address=%s", chaincodeSupport.peerAddress)}
@@ -680,6 +737,11 @@ func (chaincodeSupport *ChaincodeSupport) Execute(ctxt context.Context, cccid *c
 	}
 	chaincodeSupport.runningChaincodes.Unlock()
 
+	if !chrte.handler.acquireTxSlot() {
+		return nil, fmt.Errorf("Chaincode %s has reached its limit of %d concurrent transactions", canName, cap(chrte.handler.txSlots))
+	}
+	defer chrte.handler.releaseTxSlot()
+
 	var notfy chan *pb.ChaincodeMessage
 	var err error
 	if notfy, err = chrte.handler.sendExecuteMessage(ctxt, cccid.ChainID, msg, cccid.SignedProposal, cccid.Proposal); err != nil {
@@ -691,7 +753,10 @@ func (chaincodeSupport *ChaincodeSupport) Execute(ctxt context.Context, cccid *c
 		//response is sent to user or calling chaincode. ChaincodeMessage_ERROR
 		//are typically treated as error
 	case <-time.After(timeout):
-		err = fmt.Errorf("Timeout expired while executing transaction")
+		err = fmt.Errorf("Timeout expired while executing transaction (%s)", timeout)
+		if limits.OOMKilled(cccid.Name, cccid.Version) {
+			err = fmt.Errorf("Chaincode %s was killed for exceeding its memory limit while executing transaction", canName)
+		}
 	}
 
 	//our responsibility to delete transaction context if sendExecuteMessage succeeded
@@ -700,6 +765,20 @@ func (chaincodeSupport *ChaincodeSupport) Execute(ctxt context.Context, cccid *c
 	return ccresp, err
 }
 
+// executeTimeout returns how long the given chaincode may take to execute a
+// transaction, which is a query if it cannot update the ledger
+func (chaincodeSupport *ChaincodeSupport) executeTimeout(ccname string, query bool) time.Duration {
+	l := limits.GetLimits(ccname)
+	timeout := l.InvokeTimeout
+	if query {
+		timeout = l.QueryTimeout
+	}
+	if timeout <= 0 {
+		return chaincodeSupport.executetimeout
+	}
+	return timeout
+}
+
 // IsDevMode returns true if the peer was configured with development-mode enabled
 func IsDevMode() bool {
 	mode := viper.GetString("chaincode.mode")
@@ -44,6 +44,7 @@ import (
 	plgr "github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/viper"
 	"golang.org/x/net/context"
 
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
@@ -342,7 +343,7 @@ func initializeCC(t *testing.T, chainID, ccname string, ccSide *mockpeer.MockCCC
 	}
 
 	chaincodeID := &pb.ChaincodeID{Name: ccname, Version: "0"}
-	ci := &pb.ChaincodeInput{[][]byte{[]byte("init"), []byte("A"), []byte("100"), []byte("B"), []byte("200")}}
+	ci := &pb.ChaincodeInput{Args: [][]byte{[]byte("init"), []byte("A"), []byte("100"), []byte("B"), []byte("200")}}
 	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]), ChaincodeId: chaincodeID, Input: ci}}
 
 	ctxt, txsim, sprop, prop := startTx(t, chainID, cis)
@@ -392,7 +393,7 @@ func invokeCC(t *testing.T, chainID, ccname string, ccSide *mockpeer.MockCCComm)
 	}
 
 	chaincodeID := &pb.ChaincodeID{Name: ccname, Version: "0"}
-	ci := &pb.ChaincodeInput{[][]byte{[]byte("invoke"), []byte("A"), []byte("B"), []byte("10")}}
+	ci := &pb.ChaincodeInput{Args: [][]byte{[]byte("invoke"), []byte("A"), []byte("B"), []byte("10")}}
 	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]), ChaincodeId: chaincodeID, Input: ci}}
 
 	ctxt, txsim, sprop, prop := startTx(t, chainID, cis)
@@ -439,7 +440,7 @@ func getQueryStateByRange(t *testing.T, chainID, ccname string, ccSide *mockpeer
 	}
 
 	chaincodeID := &pb.ChaincodeID{Name: ccname, Version: "0"}
-	ci := &pb.ChaincodeInput{[][]byte{[]byte("invoke"), []byte("A"), []byte("B"), []byte("10")}}
+	ci := &pb.ChaincodeInput{Args: [][]byte{[]byte("invoke"), []byte("A"), []byte("B"), []byte("10")}}
 	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]), ChaincodeId: chaincodeID, Input: ci}}
 
 	ctxt, txsim, sprop, prop := startTx(t, chainID, cis)
@@ -486,7 +487,7 @@ func cc2cc(t *testing.T, chainID, chainID2, ccname string, ccSide *mockpeer.Mock
 	}
 
 	chaincodeID := &pb.ChaincodeID{Name: calledCC, Version: "0"}
-	ci := &pb.ChaincodeInput{[][]byte{[]byte("deploycc")}}
+	ci := &pb.ChaincodeInput{Args: [][]byte{[]byte("deploycc")}}
 	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]), ChaincodeId: chaincodeID, Input: ci}}
 
 	//first deploy the new cc to LSCC
@@ -500,7 +501,7 @@ func cc2cc(t *testing.T, chainID, chainID2, ccname string, ccSide *mockpeer.Mock
 
 	//now do the cc2cc
 	chaincodeID = &pb.ChaincodeID{Name: ccname, Version: "0"}
-	ci = &pb.ChaincodeInput{[][]byte{[]byte("invokecc")}}
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("invokecc")}}
 	cis = &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]), ChaincodeId: chaincodeID, Input: ci}}
 
 	ctxt, txsim, sprop, prop = startTx(t, chainID, cis)
@@ -538,7 +539,7 @@ func getQueryResult(t *testing.T, chainID, ccname string, ccSide *mockpeer.MockC
 	}
 
 	chaincodeID := &pb.ChaincodeID{Name: ccname, Version: "0"}
-	ci := &pb.ChaincodeInput{[][]byte{[]byte("invoke"), []byte("A"), []byte("B"), []byte("10")}}
+	ci := &pb.ChaincodeInput{Args: [][]byte{[]byte("invoke"), []byte("A"), []byte("B"), []byte("10")}}
 	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]), ChaincodeId: chaincodeID, Input: ci}}
 
 	ctxt, txsim, sprop, prop := startTx(t, chainID, cis)
@@ -589,7 +590,7 @@ func getHistory(t *testing.T, chainID, ccname string, ccSide *mockpeer.MockCCCom
 	}
 
 	chaincodeID := &pb.ChaincodeID{Name: ccname, Version: "0"}
-	ci := &pb.ChaincodeInput{[][]byte{[]byte("invoke"), []byte("A"), []byte("B"), []byte("10")}}
+	ci := &pb.ChaincodeInput{Args: [][]byte{[]byte("invoke"), []byte("A"), []byte("B"), []byte("10")}}
 	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]), ChaincodeId: chaincodeID, Input: ci}}
 
 	ctxt, txsim, sprop, prop := startTx(t, chainID, cis)
@@ -680,3 +681,114 @@ func TestCCFramework(t *testing.T) {
 
 	ccSide.Quit()
 }
+
+func TestStateChunks(t *testing.T) {
+	handler := &Handler{}
+	txContext := &transactionContext{pendingPuts: make(map[string][]byte), pendingGets: make(map[string][]byte)}
+
+	//value assembled from chunks received in order
+	value, err := handler.appendStateChunk(txContext, &pb.StateChunk{Key: "A", Data: []byte("abc"), Offset: 0, Size: 5})
+	if err != nil || value != nil {
+		t.Fatalf("expected partial value, got %v, %s", value, err)
+	}
+	value, err = handler.appendStateChunk(txContext, &pb.StateChunk{Key: "A", Data: []byte("de"), Offset: 3, Size: 5})
+	if err != nil || string(value) != "abcde" {
+		t.Fatalf("expected complete value, got %v, %s", value, err)
+	}
+	if _, ok := txContext.pendingPuts["A"]; ok {
+		t.Fatalf("expected pending value to be removed")
+	}
+
+	//out of order chunk should fail
+	if _, err = handler.appendStateChunk(txContext, &pb.StateChunk{Key: "B", Data: []byte("de"), Offset: 3, Size: 5}); err == nil {
+		t.Fatalf("expected out of order chunk to fail")
+	}
+
+	//chunk overflowing the declared size should fail
+	if _, err = handler.appendStateChunk(txContext, &pb.StateChunk{Key: "B", Data: []byte("abcdef"), Offset: 0, Size: 5}); err == nil {
+		t.Fatalf("expected oversized chunk to fail")
+	}
+
+	if txContext.pendingBytes != 0 {
+		t.Fatalf("expected no bytes in transfer, got %d", txContext.pendingBytes)
+	}
+
+	//large value sent back in chunks
+	large := make([]byte, stateChunkSizeDefault+10)
+	if err = handler.addPendingGet(txContext, "C", large); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	msg, err := handler.nextStateChunk(txContext, "1", "C", 0)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	chunk := &pb.StateChunk{}
+	if err = proto.Unmarshal(msg.Payload, chunk); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if msg.Type != pb.ChaincodeMessage_GET_STATE_CHUNK || len(chunk.Data) != stateChunkSizeDefault || chunk.Size != uint64(len(large)) {
+		t.Fatalf("unexpected first chunk %v", chunk)
+	}
+	msg, err = handler.nextStateChunk(txContext, "1", "C", chunk.Offset+uint64(len(chunk.Data)))
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if err = proto.Unmarshal(msg.Payload, chunk); err != nil || len(chunk.Data) != 10 {
+		t.Fatalf("unexpected last chunk %v, %s", chunk, err)
+	}
+	if _, err = handler.nextStateChunk(txContext, "1", "C", 0); err == nil {
+		t.Fatalf("expected transfer to be complete")
+	}
+	if txContext.pendingBytes != 0 {
+		t.Fatalf("expected no bytes in transfer, got %d", txContext.pendingBytes)
+	}
+
+	//values in transfer are bounded per transaction
+	handler.chaincodeSupport = &ChaincodeSupport{maxPendingStateSize: 8}
+	if _, err = handler.appendStateChunk(txContext, &pb.StateChunk{Key: "D", Data: []byte("abc"), Offset: 0, Size: 9}); err == nil {
+		t.Fatalf("expected value over the limit to fail")
+	}
+	if _, err = handler.appendStateChunk(txContext, &pb.StateChunk{Key: "D", Data: []byte("abc"), Offset: 0, Size: 5}); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if err = handler.addPendingGet(txContext, "E", []byte("abcd")); err == nil {
+		t.Fatalf("expected values over the limit to fail")
+	}
+	if _, err = handler.appendStateChunk(txContext, &pb.StateChunk{Key: "D", Data: []byte("de"), Offset: 3, Size: 5}); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if err = handler.addPendingGet(txContext, "E", []byte("abcd")); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+}
+
+func TestExecuteLimits(t *testing.T) {
+	viper.Set("chaincode.limits.maxConcurrency", 1)
+	viper.Set("chaincode.limits.overrides.mycc.queryTimeout", "5s")
+	defer viper.Set("chaincode.limits", nil)
+
+	cs := &ChaincodeSupport{runningChaincodes: &runningChaincodes{chaincodeMap: make(map[string]*chaincodeRTEnv)}, userRunsCC: true, executetimeout: 30 * time.Second}
+	handler := &Handler{ChaincodeID: &pb.ChaincodeID{Name: "mycc:0"}}
+	if err := cs.registerHandler(handler); err != nil {
+		t.Fatalf("registration failed: %s", err)
+	}
+
+	//a single transaction may execute at a time
+	if !handler.acquireTxSlot() {
+		t.Fatalf("expected a transaction to be allowed to execute")
+	}
+	if handler.acquireTxSlot() {
+		t.Fatalf("expected concurrent transaction to be rejected")
+	}
+	handler.releaseTxSlot()
+	if !handler.acquireTxSlot() {
+		t.Fatalf("expected a transaction to be allowed to execute after release")
+	}
+
+	if to := cs.executeTimeout("mycc", true); to != 5*time.Second {
+		t.Fatalf("expected query timeout of 5s, got %s", to)
+	}
+	if to := cs.executeTimeout("mycc", false); to != 30*time.Second {
+		t.Fatalf("expected invoke timeout to default to the execute timeout, got %s", to)
+	}
+}
@@ -39,6 +39,11 @@ func Execute(ctxt context.Context, cccid *ccprovider.CCContext, spec interface{}
 			panic("Execute should be called with deployment or invocation spec")
 		}
 		cctyp = pb.ChaincodeMessage_TRANSACTION
+		//an explicit initialization of a chaincode whose
+		//definition requires one calls its Init method too
+		if ci.ChaincodeSpec != nil && ci.ChaincodeSpec.Input != nil && ci.ChaincodeSpec.Input.IsInit {
+			cctyp = pb.ChaincodeMessage_INIT
+		}
 	}
 
 	_, cMsg, err := theChaincodeSupport.Launch(ctxt, cccid, spec)
@@ -52,7 +57,10 @@ func Execute(ctxt context.Context, cccid *ccprovider.CCContext, spec interface{}
 		return nil, nil, fmt.Errorf("Failed to transaction message(%s)", err)
 	}
 
-	resp, err := theChaincodeSupport.Execute(ctxt, cccid, ccMsg, theChaincodeSupport.executetimeout)
+	//the endorser marks the executions it does not endorse as queries
+	query, _ := ctxt.Value(QueryKey).(bool)
+
+	resp, err := theChaincodeSupport.Execute(ctxt, cccid, ccMsg, theChaincodeSupport.executeTimeout(cccid.Name, query))
 	if err != nil {
 		// Rollback transaction
 		return nil, nil, fmt.Errorf("Failed to execute transaction (%s)", err)
@@ -26,8 +26,8 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
-	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/container/ccintf"
@@ -66,6 +66,13 @@ type transactionContext struct {
 	// tracks open iterators used for range queries
 	queryIteratorMap map[string]commonledger.ResultsIterator
 
+	// tracks, by key, large state values being transferred
+	// in chunks from (puts) and to (gets) the chaincode, and
+	// the number of bytes they hold
+	pendingPuts  map[string][]byte
+	pendingGets  map[string][]byte
+	pendingBytes uint64
+
 	txsimulator          ledger.TxSimulator
 	historyQueryExecutor ledger.HistoryQueryExecutor
 }
@@ -102,7 +109,31 @@ type Handler struct {
 	// used to do Send after making sure the state transition is complete
 	nextState chan *nextStateInfo
 
-	policyChecker policy.PolicyChecker
+	aclProvider aclmgmt.ACLProvider
+
+	// bounds the number of transactions executed at the same time,
+	// nil if they are not limited
+	txSlots chan struct{}
+}
+
+// acquireTxSlot reserves a slot for a transaction to execute, returning
+// false if the chaincode already executes as many as it is allowed to
+func (handler *Handler) acquireTxSlot() bool {
+	if handler.txSlots == nil {
+		return true
+	}
+	select {
+	case handler.txSlots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (handler *Handler) releaseTxSlot() {
+	if handler.txSlots != nil {
+		<-handler.txSlots
+	}
 }
 
 func shorttxid(txid string) string {
@@ -192,7 +223,9 @@ func (handler *Handler) createTxContext(ctxt context.Context, chainID string, tx
 	}
 	txctx := &transactionContext{chainID: chainID, signedProp: signedProp,
 		proposal: prop, responseNotifier: make(chan *pb.ChaincodeMessage, 1),
-		queryIteratorMap: make(map[string]commonledger.ResultsIterator)}
+		queryIteratorMap: make(map[string]commonledger.ResultsIterator),
+		pendingPuts:      make(map[string][]byte),
+		pendingGets:      make(map[string][]byte)}
 	handler.txCtxs[txid] = txctx
 	txctx.txsimulator = getTxSimulator(ctxt)
 	txctx.historyQueryExecutor = getHistoryQueryExecutor(ctxt)
@@ -233,6 +266,120 @@ func (handler *Handler) deleteQueryIterator(txContext *transactionContext, txid
 	delete(txContext.queryIteratorMap, txid)
 }
 
+// appendStateChunk adds a part of a large state value sent by the chaincode
+// and returns the whole value once all of its parts have been received
+func (handler *Handler) appendStateChunk(txContext *transactionContext, chunk *pb.StateChunk) ([]byte, error) {
+	handler.Lock()
+	defer handler.Unlock()
+	value, ok := txContext.pendingPuts[chunk.Key]
+	if !ok {
+		// the whole value is accounted for when its first part arrives
+		if chunk.Offset != 0 {
+			return nil, fmt.Errorf("unexpected chunk of key %s at offset %d, expected offset 0", chunk.Key, chunk.Offset)
+		}
+		if err := handler.reserveStateBytes(txContext, chunk.Size); err != nil {
+			return nil, err
+		}
+		value = make([]byte, 0, chunk.Size)
+	}
+	if chunk.Offset != uint64(len(value)) {
+		handler.deletePendingPut(txContext, chunk.Key, value)
+		return nil, fmt.Errorf("unexpected chunk of key %s at offset %d, expected offset %d", chunk.Key, chunk.Offset, len(value))
+	}
+	if len(chunk.Data) == 0 || chunk.Size != uint64(cap(value)) || uint64(len(value)+len(chunk.Data)) > chunk.Size {
+		handler.deletePendingPut(txContext, chunk.Key, value)
+		return nil, fmt.Errorf("invalid chunk of key %s at offset %d", chunk.Key, chunk.Offset)
+	}
+
+	value = append(value, chunk.Data...)
+	if uint64(len(value)) < chunk.Size {
+		txContext.pendingPuts[chunk.Key] = value
+		return nil, nil
+	}
+
+	handler.deletePendingPut(txContext, chunk.Key, value)
+	return value, nil
+}
+
+// addPendingGet starts the transfer of a large state value to the chaincode
+func (handler *Handler) addPendingGet(txContext *transactionContext, key string, value []byte) error {
+	handler.Lock()
+	defer handler.Unlock()
+	if previous, ok := txContext.pendingGets[key]; ok {
+		handler.deletePendingGet(txContext, key, previous)
+	}
+	if err := handler.reserveStateBytes(txContext, uint64(len(value))); err != nil {
+		return err
+	}
+	txContext.pendingGets[key] = value
+	return nil
+}
+
+// nextStateChunk returns the message carrying the part of a large state value,
+// pending transfer to the chaincode, that starts at the given offset
+func (handler *Handler) nextStateChunk(txContext *transactionContext, txid string, key string, offset uint64) (*pb.ChaincodeMessage, error) {
+	handler.Lock()
+	defer handler.Unlock()
+	value := txContext.pendingGets[key]
+	if value == nil {
+		return nil, fmt.Errorf("no transfer of the value of key %s in progress", key)
+	}
+	if offset >= uint64(len(value)) {
+		handler.deletePendingGet(txContext, key, value)
+		return nil, fmt.Errorf("offset %d out of the value of key %s", offset, key)
+	}
+
+	end := offset + uint64(handler.stateChunkSize())
+	if end >= uint64(len(value)) {
+		end = uint64(len(value))
+		handler.deletePendingGet(txContext, key, value)
+	}
+
+	payload, err := proto.Marshal(&pb.StateChunk{Key: key, Data: value[offset:end], Offset: offset, Size: uint64(len(value))})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_CHUNK, Payload: payload, Txid: txid}, nil
+}
+
+// reserveStateBytes accounts for size more bytes of state values in transfer
+// in the transaction, which must not exceed the configured limit. The values
+// are held whole in memory until their transfer completes.
+// It must be called with the handler locked.
+func (handler *Handler) reserveStateBytes(txContext *transactionContext, size uint64) error {
+	limit := uint64(handler.maxPendingStateSize())
+	if size > limit || txContext.pendingBytes > limit-size {
+		return fmt.Errorf("state values in transfer would exceed the limit of %d bytes per transaction", limit)
+	}
+	txContext.pendingBytes += size
+	return nil
+}
+
+func (handler *Handler) deletePendingPut(txContext *transactionContext, key string, value []byte) {
+	delete(txContext.pendingPuts, key)
+	txContext.pendingBytes -= uint64(cap(value))
+}
+
+func (handler *Handler) deletePendingGet(txContext *transactionContext, key string, value []byte) {
+	delete(txContext.pendingGets, key)
+	txContext.pendingBytes -= uint64(len(value))
+}
+
+func (handler *Handler) stateChunkSize() int {
+	if handler.chaincodeSupport == nil || handler.chaincodeSupport.stateChunkSize <= 0 {
+		return stateChunkSizeDefault
+	}
+	return handler.chaincodeSupport.stateChunkSize
+}
+
+func (handler *Handler) maxPendingStateSize() int {
+	if handler.chaincodeSupport == nil || handler.chaincodeSupport.maxPendingStateSize <= 0 {
+		return maxPendingStateSizeDefault
+	}
+	return handler.chaincodeSupport.maxPendingStateSize
+}
+
 // Check if the transactor is allow to call this chaincode on this channel
 func (handler *Handler) checkACL(signedProp *pb.SignedProposal, proposal *pb.Proposal, ccIns *sysccprovider.ChaincodeInstance) error {
 	// ensure that we don't invoke a system chaincode
@@ -258,7 +405,7 @@ func (handler *Handler) checkACL(signedProp *pb.SignedProposal, proposal *pb.Pro
 		return fmt.Errorf("Signed Proposal must not be nil from caller [%s]", ccIns.String())
 	}
 
-	return handler.policyChecker.CheckPolicy(ccIns.ChainID, policies.ChannelApplicationWriters, signedProp)
+	return handler.aclProvider.CheckACL(aclmgmt.PeerChaincodeToChaincode, ccIns.ChainID, signedProp)
 }
 
 func (handler *Handler) deregister() error {
@@ -414,10 +561,12 @@ func newChaincodeSupportHandler(chaincodeSupport *ChaincodeSupport, peerChatStre
 			{Name: pb.ChaincodeMessage_REGISTER.String(), Src: []string{createdstate}, Dst: establishedstate},
 			{Name: pb.ChaincodeMessage_READY.String(), Src: []string{establishedstate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_PUT_STATE.String(), Src: []string{readystate}, Dst: readystate},
+			{Name: pb.ChaincodeMessage_PUT_STATE_CHUNK.String(), Src: []string{readystate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_DEL_STATE.String(), Src: []string{readystate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_INVOKE_CHAINCODE.String(), Src: []string{readystate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_COMPLETED.String(), Src: []string{readystate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_GET_STATE.String(), Src: []string{readystate}, Dst: readystate},
+			{Name: pb.ChaincodeMessage_GET_STATE_CHUNK.String(), Src: []string{readystate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_GET_STATE_BY_RANGE.String(), Src: []string{readystate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_GET_QUERY_RESULT.String(), Src: []string{readystate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY.String(), Src: []string{readystate}, Dst: readystate},
@@ -432,12 +581,14 @@ func newChaincodeSupportHandler(chaincodeSupport *ChaincodeSupport, peerChatStre
 			"before_" + pb.ChaincodeMessage_REGISTER.String():           func(e *fsm.Event) { v.beforeRegisterEvent(e, v.FSM.Current()) },
 			"before_" + pb.ChaincodeMessage_COMPLETED.String():          func(e *fsm.Event) { v.beforeCompletedEvent(e, v.FSM.Current()) },
 			"after_" + pb.ChaincodeMessage_GET_STATE.String():           func(e *fsm.Event) { v.afterGetState(e, v.FSM.Current()) },
+			"after_" + pb.ChaincodeMessage_GET_STATE_CHUNK.String():     func(e *fsm.Event) { v.afterGetStateChunk(e, v.FSM.Current()) },
 			"after_" + pb.ChaincodeMessage_GET_STATE_BY_RANGE.String():  func(e *fsm.Event) { v.afterGetStateByRange(e, v.FSM.Current()) },
 			"after_" + pb.ChaincodeMessage_GET_QUERY_RESULT.String():    func(e *fsm.Event) { v.afterGetQueryResult(e, v.FSM.Current()) },
 			"after_" + pb.ChaincodeMessage_GET_HISTORY_FOR_KEY.String(): func(e *fsm.Event) { v.afterGetHistoryForKey(e, v.FSM.Current()) },
 			"after_" + pb.ChaincodeMessage_QUERY_STATE_NEXT.String():    func(e *fsm.Event) { v.afterQueryStateNext(e, v.FSM.Current()) },
 			"after_" + pb.ChaincodeMessage_QUERY_STATE_CLOSE.String():   func(e *fsm.Event) { v.afterQueryStateClose(e, v.FSM.Current()) },
 			"after_" + pb.ChaincodeMessage_PUT_STATE.String():           func(e *fsm.Event) { v.enterBusyState(e, v.FSM.Current()) },
+			"after_" + pb.ChaincodeMessage_PUT_STATE_CHUNK.String():     func(e *fsm.Event) { v.enterBusyState(e, v.FSM.Current()) },
 			"after_" + pb.ChaincodeMessage_DEL_STATE.String():           func(e *fsm.Event) { v.enterBusyState(e, v.FSM.Current()) },
 			"after_" + pb.ChaincodeMessage_INVOKE_CHAINCODE.String():    func(e *fsm.Event) { v.enterBusyState(e, v.FSM.Current()) },
 			"enter_" + establishedstate:                                 func(e *fsm.Event) { v.enterEstablishedState(e, v.FSM.Current()) },
@@ -446,10 +597,13 @@ func newChaincodeSupportHandler(chaincodeSupport *ChaincodeSupport, peerChatStre
 		},
 	)
 
-	v.policyChecker = policy.NewPolicyChecker(
-		peer.NewChannelPolicyManagerGetter(),
-		mgmt.GetLocalMSP(),
-		mgmt.NewLocalMSPPrincipalGetter(),
+	v.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			peer.NewChannelPolicyManagerGetter(),
+			mgmt.GetLocalMSP(),
+			mgmt.NewLocalMSPPrincipalGetter(),
+		),
+		peer.NewPolicyMapperGetter(),
 	)
 
 	return v
@@ -649,6 +803,19 @@ func (handler *Handler) handleGetState(msg *pb.ChaincodeMessage) {
 			chaincodeLogger.Debugf("[%s]No state associated with key: %s. Sending %s with an empty payload",
 				shorttxid(msg.Txid), key, pb.ChaincodeMessage_RESPONSE)
 			serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid}
+		} else if len(res) > handler.stateChunkSize() {
+			// The value is too large for a single message, send its first part
+			err = handler.addPendingGet(txContext, key, res)
+			if err == nil {
+				serialSendMsg, err = handler.nextStateChunk(txContext, msg.Txid, key, 0)
+			}
+			if err != nil {
+				chaincodeLogger.Errorf("[%s]Failed to send chaincode state(%s). Sending %s",
+					shorttxid(msg.Txid), err, pb.ChaincodeMessage_ERROR)
+				serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(err.Error()), Txid: msg.Txid}
+			} else if chaincodeLogger.IsEnabledFor(logging.DEBUG) {
+				chaincodeLogger.Debugf("[%s]Got state of size %d. Sending %s", shorttxid(msg.Txid), len(res), pb.ChaincodeMessage_GET_STATE_CHUNK)
+			}
 		} else {
 			// Send response msg back to chaincode. GetState will not trigger event
 			if chaincodeLogger.IsEnabledFor(logging.DEBUG) {
@@ -660,6 +827,61 @@ func (handler *Handler) handleGetState(msg *pb.ChaincodeMessage) {
 	}()
 }
 
+// afterGetStateChunk handles a GET_STATE_CHUNK request from the chaincode.
+func (handler *Handler) afterGetStateChunk(e *fsm.Event, state string) {
+	msg, ok := e.Args[0].(*pb.ChaincodeMessage)
+	if !ok {
+		e.Cancel(fmt.Errorf("Received unexpected message type"))
+		return
+	}
+	chaincodeLogger.Debugf("[%s]Received %s, sending next part of state", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_CHUNK)
+
+	handler.handleGetStateChunk(msg)
+}
+
+// Handles a request for the next part of a large state value
+func (handler *Handler) handleGetStateChunk(msg *pb.ChaincodeMessage) {
+	go func() {
+		// Check if this is the unique state request from this chaincode txid
+		uniqueReq := handler.createTXIDEntry(msg.Txid)
+		if !uniqueReq {
+			// Drop this request
+			chaincodeLogger.Error("Another state request pending for this Txid. Cannot process.")
+			return
+		}
+
+		var serialSendMsg *pb.ChaincodeMessage
+		var txContext *transactionContext
+		txContext, serialSendMsg = handler.isValidTxSim(msg.Txid,
+			"[%s]No ledger context for GetStateChunk. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
+
+		defer func() {
+			handler.deleteTXIDEntry(msg.Txid)
+			if chaincodeLogger.IsEnabledFor(logging.DEBUG) {
+				chaincodeLogger.Debugf("[%s]handleGetStateChunk serial send %s",
+					shorttxid(serialSendMsg.Txid), serialSendMsg.Type)
+			}
+			handler.serialSendAsync(serialSendMsg, nil)
+		}()
+
+		if txContext == nil {
+			return
+		}
+
+		chunk := &pb.StateChunk{}
+		err := proto.Unmarshal(msg.Payload, chunk)
+		if err == nil {
+			serialSendMsg, err = handler.nextStateChunk(txContext, msg.Txid, chunk.Key, chunk.Offset)
+		}
+
+		if err != nil {
+			chaincodeLogger.Errorf("[%s]Failed to send chaincode state(%s). Sending %s",
+				shorttxid(msg.Txid), err, pb.ChaincodeMessage_ERROR)
+			serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(err.Error()), Txid: msg.Txid}
+		}
+	}()
+}
+
 // afterGetStateByRange handles a GET_STATE_BY_RANGE request from the chaincode.
 func (handler *Handler) afterGetStateByRange(e *fsm.Event, state string) {
 	msg, ok := e.Args[0].(*pb.ChaincodeMessage)
@@ -1183,6 +1405,19 @@ func (handler *Handler) enterBusyState(e *fsm.Event, state string) {
 			}
 
 			err = txContext.txsimulator.SetState(chaincodeID, putStateInfo.Key, putStateInfo.Value)
+		} else if msg.Type.String() == pb.ChaincodeMessage_PUT_STATE_CHUNK.String() {
+			chunk := &pb.StateChunk{}
+			unmarshalErr := proto.Unmarshal(msg.Payload, chunk)
+			if unmarshalErr != nil {
+				errHandler([]byte(unmarshalErr.Error()), "[%s]Unable to decipher payload. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
+				return
+			}
+
+			// the value is put once all of its parts have been received
+			var value []byte
+			if value, err = handler.appendStateChunk(txContext, chunk); err == nil && value != nil {
+				err = txContext.txsimulator.SetState(chaincodeID, chunk.Key, value)
+			}
 		} else if msg.Type.String() == pb.ChaincodeMessage_DEL_STATE.String() {
 			// Invoke ledger to delete state
 			key := string(msg.Payload)
@@ -1287,8 +1522,8 @@ func (handler *Handler) enterBusyState(e *fsm.Event, state string) {
 				return
 			}
 
-			// TODO: Need to handle timeout correctly
-			timeout := time.Duration(30000) * time.Millisecond
+			//calls to a chaincode of another channel are queries
+			timeout := handler.chaincodeSupport.executeTimeout(calledCcIns.ChaincodeName, calledCcIns.ChainID != txContext.chainID)
 
 			ccMsg, _ := createCCMessage(pb.ChaincodeMessage_TRANSACTION, msg.Txid, chaincodeInput)
 
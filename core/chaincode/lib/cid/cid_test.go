@@ -0,0 +1,176 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cid_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/lib/cid"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+// makeCert creates a self-signed certificate carrying the given
+// content in the attribute extension, if any
+func makeCert(t *testing.T, attrs []byte) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "user1", OrganizationalUnit: []string{"client"}, Organization: []string{"org1"}},
+		Issuer:       pkix.Name{CommonName: "ca.org1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	if attrs != nil {
+		template.ExtraExtensions = []pkix.Extension{{Id: cid.AttributeOID, Value: attrs}}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func newStub(t *testing.T, mspID string, idBytes []byte) *shim.MockStub {
+	stub := shim.NewMockStub("cidtest", nil)
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: idBytes})
+	assert.NoError(t, err)
+	stub.Creator = creator
+	return stub
+}
+
+func TestClient(t *testing.T) {
+	stub := newStub(t, "org1MSP", makeCert(t, []byte(`{"attrs":{"attr1":"val1","hf.Affiliation":"org1.department1"}}`)))
+
+	sinfo, err := cid.New(stub)
+	assert.NoError(t, err)
+
+	id, err := sinfo.GetID()
+	assert.NoError(t, err)
+	decoded, err := base64.StdEncoding.DecodeString(id)
+	assert.NoError(t, err)
+	assert.Equal(t, "x509::CN=user1,OU=client,O=org1::CN=user1,OU=client,O=org1", string(decoded))
+
+	mspID, err := sinfo.GetMSPID()
+	assert.NoError(t, err)
+	assert.Equal(t, "org1MSP", mspID)
+
+	cert, err := sinfo.GetX509Certificate()
+	assert.NoError(t, err)
+	assert.Equal(t, "user1", cert.Subject.CommonName)
+
+	value, found, err := sinfo.GetAttributeValue("attr1")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "val1", value)
+
+	value, found, err = sinfo.GetAttributeValue("unknown")
+	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.Empty(t, value)
+
+	assert.NoError(t, sinfo.AssertAttributeValue("hf.Affiliation", "org1.department1"))
+	err = sinfo.AssertAttributeValue("attr1", "val2")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not 'val2'")
+	err = sinfo.AssertAttributeValue("unknown", "val1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "was not found")
+
+	// the package level functions behave the same way
+	pkgID, err := cid.GetID(stub)
+	assert.NoError(t, err)
+	assert.Equal(t, id, pkgID)
+	mspID, err = cid.GetMSPID(stub)
+	assert.NoError(t, err)
+	assert.Equal(t, "org1MSP", mspID)
+	value, found, err = cid.GetAttributeValue(stub, "attr1")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "val1", value)
+	assert.NoError(t, cid.AssertAttributeValue(stub, "attr1", "val1"))
+	cert, err = cid.GetX509Certificate(stub)
+	assert.NoError(t, err)
+	assert.NotNil(t, cert)
+}
+
+func TestClientWithoutAttributes(t *testing.T) {
+	stub := newStub(t, "org1MSP", makeCert(t, nil))
+
+	sinfo, err := cid.New(stub)
+	assert.NoError(t, err)
+	_, found, err := sinfo.GetAttributeValue("attr1")
+	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.Error(t, sinfo.AssertAttributeValue("attr1", "val1"))
+}
+
+func TestClientWithoutCertificate(t *testing.T) {
+	// The identity isn't PEM encoded, as idemix identities
+	stub := newStub(t, "idemixMSP", []byte("not a certificate"))
+
+	sinfo, err := cid.New(stub)
+	assert.NoError(t, err)
+	mspID, err := sinfo.GetMSPID()
+	assert.NoError(t, err)
+	assert.Equal(t, "idemixMSP", mspID)
+	_, err = sinfo.GetID()
+	assert.Error(t, err)
+	cert, err := sinfo.GetX509Certificate()
+	assert.NoError(t, err)
+	assert.Nil(t, cert)
+	_, found, err := sinfo.GetAttributeValue("attr1")
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestClientErrors(t *testing.T) {
+	// no creator
+	_, err := cid.New(shim.NewMockStub("cidtest", nil))
+	assert.Error(t, err)
+	_, err = cid.GetID(shim.NewMockStub("cidtest", nil))
+	assert.Error(t, err)
+
+	// creator is not a serialized identity
+	stub := shim.NewMockStub("cidtest", nil)
+	stub.Creator = []byte("garbage")
+	_, err = cid.New(stub)
+	assert.Error(t, err)
+
+	// PEM block that is not a certificate
+	_, _, err = cid.GetAttributeValue(newStub(t, "org1MSP", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")})), "attr1")
+	assert.Error(t, err)
+
+	// malformed attribute extension
+	err = cid.AssertAttributeValue(newStub(t, "org1MSP", makeCert(t, []byte("not json"))), "attr1", "val1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to get attributes")
+
+	_, err = cid.GetX509Certificate(newStub(t, "org1MSP", makeCert(t, []byte("not json"))))
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limits
+
+import (
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const limitsKey = "chaincode.limits"
+
+// Limits are the resources a chaincode is allowed to use while running.
+// A zero value means that the corresponding resource is not limited
+// (or, for the timeouts, that chaincode.executetimeout applies)
+type Limits struct {
+	// Memory is the memory limit of the chaincode container in bytes
+	Memory int64
+	// CPUShares is the relative CPU weight of the chaincode container
+	CPUShares int64
+	// CPUQuota is the CPU time in microseconds the chaincode container
+	// may use during each CPUPeriod
+	CPUQuota  int64
+	CPUPeriod int64
+	// MaxConcurrency is the maximum number of transactions the chaincode
+	// may execute at the same time
+	MaxConcurrency int
+	// QueryTimeout bounds the execution of transactions which cannot
+	// update the ledger: proposals marked as queries by their clients
+	// and calls to a chaincode of another channel
+	QueryTimeout time.Duration
+	// InvokeTimeout bounds the execution of all other transactions
+	InvokeTimeout time.Duration
+}
+
+// GetLimits returns the limits of the given chaincode: those configured
+// under chaincode.limits, each of which can be overridden for a single
+// chaincode under chaincode.limits.overrides.<chaincode name>
+func GetLimits(ccname string) *Limits {
+	key := func(name string) string {
+		override := limitsKey + ".overrides." + ccname + "." + name
+		if ccname != "" && viper.IsSet(override) {
+			return override
+		}
+		return limitsKey + "." + name
+	}
+
+	return &Limits{
+		Memory:         int64(viper.GetInt(key("memory"))),
+		CPUShares:      int64(viper.GetInt(key("cpuShares"))),
+		CPUQuota:       int64(viper.GetInt(key("cpuQuota"))),
+		CPUPeriod:      int64(viper.GetInt(key("cpuPeriod"))),
+		MaxConcurrency: viper.GetInt(key("maxConcurrency")),
+		QueryTimeout:   viper.GetDuration(key("queryTimeout")),
+		InvokeTimeout:  viper.GetDuration(key("invokeTimeout")),
+	}
+}
+
+// oomKilled holds the chaincode containers, by chaincode name and version,
+// which were killed for exceeding their memory limit since they started
+var oomKilled = struct {
+	sync.Mutex
+	containers map[string]bool
+}{containers: make(map[string]bool)}
+
+// SetOOMKilled records whether the container of the given version of a
+// chaincode was killed for exceeding its memory limit
+func SetOOMKilled(ccname, version string, killed bool) {
+	oomKilled.Lock()
+	defer oomKilled.Unlock()
+	if killed {
+		oomKilled.containers[ccname+":"+version] = true
+	} else {
+		delete(oomKilled.containers, ccname+":"+version)
+	}
+}
+
+// OOMKilled returns whether the container of the given version of a
+// chaincode was killed for exceeding its memory limit since it started
+func OOMKilled(ccname, version string) bool {
+	oomKilled.Lock()
+	defer oomKilled.Unlock()
+	return oomKilled.containers[ccname+":"+version]
+}
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limits
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLimits(t *testing.T) {
+	defer viper.Set(limitsKey, nil)
+
+	viper.Set(limitsKey, nil)
+	assert.Equal(t, &Limits{}, GetLimits("mycc"), "no limits are configured by default")
+
+	viper.Set("chaincode.limits.memory", 1024)
+	viper.Set("chaincode.limits.maxConcurrency", 10)
+	viper.Set("chaincode.limits.invokeTimeout", "20s")
+	viper.Set("chaincode.limits.overrides.mycc.maxConcurrency", 2)
+	viper.Set("chaincode.limits.overrides.mycc.queryTimeout", "5s")
+
+	l := GetLimits("othercc")
+	assert.Equal(t, int64(1024), l.Memory)
+	assert.Equal(t, 10, l.MaxConcurrency)
+	assert.Equal(t, 20*time.Second, l.InvokeTimeout)
+	assert.Equal(t, time.Duration(0), l.QueryTimeout)
+
+	l = GetLimits("mycc")
+	assert.Equal(t, int64(1024), l.Memory, "settings without override should apply")
+	assert.Equal(t, 2, l.MaxConcurrency)
+	assert.Equal(t, 20*time.Second, l.InvokeTimeout)
+	assert.Equal(t, 5*time.Second, l.QueryTimeout)
+}
+
+func TestOOMKilled(t *testing.T) {
+	assert.False(t, OOMKilled("mycc", "1.0"))
+
+	SetOOMKilled("mycc", "1.0", true)
+	assert.True(t, OOMKilled("mycc", "1.0"))
+	assert.False(t, OOMKilled("mycc", "1.1"), "other versions should not be affected")
+
+	SetOOMKilled("mycc", "1.0", false)
+	assert.False(t, OOMKilled("mycc", "1.0"))
+}
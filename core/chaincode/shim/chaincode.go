@@ -51,6 +51,7 @@ const (
 	maxUnicodeRuneValue   = utf8.MaxRune //U+10FFFF - maximum (and unallocated) code point
 	compositeKeyNamespace = "\x00"
 	emptyKeySubstitute    = "\x01"
+	defaultStateChunkSize = 1024 * 1024
 )
 
 // ChaincodeStub is an object passed to chaincode for shim side handling of
@@ -62,6 +63,7 @@ type ChaincodeStub struct {
 	handler        *Handler
 	signedProposal *pb.SignedProposal
 	proposal       *pb.Proposal
+	isInit         bool
 
 	// Additional fields extracted from the signedProposal
 	creator   []byte
@@ -230,12 +232,28 @@ func getPeerAddress() string {
 
 func newPeerClientConnection() (*grpc.ClientConn, error) {
 	var peerAddress = getPeerAddress()
+	// the peer passes the message size limits of the chaincode stream
+	if size := viper.GetInt("chaincode.stream.maxRecvMsgSize"); size > 0 {
+		comm.SetMaxRecvMsgSize(size)
+	}
+	if size := viper.GetInt("chaincode.stream.maxSendMsgSize"); size > 0 {
+		comm.SetMaxSendMsgSize(size)
+	}
 	if comm.TLSEnabled() {
 		return comm.NewClientConnectionWithAddress(peerAddress, true, true, comm.InitTLSForPeer())
 	}
 	return comm.NewClientConnectionWithAddress(peerAddress, true, false, nil)
 }
 
+// getStateChunkSize returns the size above which state values are sent to
+// the peer in chunks, as set by the peer when it launched the chaincode
+func getStateChunkSize() int {
+	if size := viper.GetInt("chaincode.stream.chunkSize"); size > 0 {
+		return size
+	}
+	return defaultStateChunkSize
+}
+
 func chatWithPeer(chaincodename string, stream PeerChaincodeStream, cc Chaincode) error {
 
 	// Create the shim handler responsible for all control logic
@@ -705,6 +723,11 @@ func (stub *ChaincodeStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
 	return chdr.GetTimestamp(), nil
 }
 
+// IsInit documentation can be found in interfaces.go
+func (stub *ChaincodeStub) IsInit() bool {
+	return stub.isInit
+}
+
 // ------------- ChaincodeEvent API ----------------------
 
 // SetEvent documentation can be found in interfaces.go
@@ -55,6 +55,8 @@ type Handler struct {
 	// responseChannel is the channel on which responses are communicated by the shim to the chaincodeStub.
 	responseChannel map[string]chan pb.ChaincodeMessage
 	nextState       chan *nextStateInfo
+	// state values larger than chunkSize are sent to the peer in chunks
+	chunkSize int
 }
 
 func shorttxid(txid string) string {
@@ -160,6 +162,7 @@ func newChaincodeHandler(peerChatStream PeerChaincodeStream, chaincode Chaincode
 	v := &Handler{
 		ChatStream: peerChatStream,
 		cc:         chaincode,
+		chunkSize:  getStateChunkSize(),
 	}
 	v.responseChannel = make(map[string]chan pb.ChaincodeMessage)
 	v.nextState = make(chan *nextStateInfo)
@@ -175,16 +178,19 @@ func newChaincodeHandler(peerChatStream PeerChaincodeStream, chaincode Chaincode
 			{Name: pb.ChaincodeMessage_INIT.String(), Src: []string{"ready"}, Dst: "ready"},
 			{Name: pb.ChaincodeMessage_TRANSACTION.String(), Src: []string{"ready"}, Dst: "ready"},
 			{Name: pb.ChaincodeMessage_RESPONSE.String(), Src: []string{"ready"}, Dst: "ready"},
+			{Name: pb.ChaincodeMessage_GET_STATE_CHUNK.String(), Src: []string{"init"}, Dst: "init"},
+			{Name: pb.ChaincodeMessage_GET_STATE_CHUNK.String(), Src: []string{"ready"}, Dst: "ready"},
 			{Name: pb.ChaincodeMessage_ERROR.String(), Src: []string{"ready"}, Dst: "ready"},
 			{Name: pb.ChaincodeMessage_COMPLETED.String(), Src: []string{"init"}, Dst: "ready"},
 			{Name: pb.ChaincodeMessage_COMPLETED.String(), Src: []string{"ready"}, Dst: "ready"},
 		},
 		fsm.Callbacks{
-			"before_" + pb.ChaincodeMessage_REGISTERED.String():  func(e *fsm.Event) { v.beforeRegistered(e) },
-			"after_" + pb.ChaincodeMessage_RESPONSE.String():     func(e *fsm.Event) { v.afterResponse(e) },
-			"after_" + pb.ChaincodeMessage_ERROR.String():        func(e *fsm.Event) { v.afterError(e) },
-			"before_" + pb.ChaincodeMessage_INIT.String():        func(e *fsm.Event) { v.beforeInit(e) },
-			"before_" + pb.ChaincodeMessage_TRANSACTION.String(): func(e *fsm.Event) { v.beforeTransaction(e) },
+			"before_" + pb.ChaincodeMessage_REGISTERED.String():     func(e *fsm.Event) { v.beforeRegistered(e) },
+			"after_" + pb.ChaincodeMessage_RESPONSE.String():        func(e *fsm.Event) { v.afterResponse(e) },
+			"after_" + pb.ChaincodeMessage_GET_STATE_CHUNK.String(): func(e *fsm.Event) { v.afterResponse(e) },
+			"after_" + pb.ChaincodeMessage_ERROR.String():           func(e *fsm.Event) { v.afterError(e) },
+			"before_" + pb.ChaincodeMessage_INIT.String():           func(e *fsm.Event) { v.beforeInit(e) },
+			"before_" + pb.ChaincodeMessage_TRANSACTION.String():    func(e *fsm.Event) { v.beforeTransaction(e) },
 		},
 	)
 	return v
@@ -238,6 +244,7 @@ func (handler *Handler) handleInit(msg *pb.ChaincodeMessage) {
 		if nextStateMsg = errFunc(err, nil, stub.chaincodeEvent, "[%s]Init get error response [%s]. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
 			return
 		}
+		stub.isInit = true
 
 		res := handler.cc.Init(stub)
 		chaincodeLogger.Debugf("[%s]Init get response status: %d", shorttxid(msg.Txid), res.Status)
@@ -402,6 +409,11 @@ func (handler *Handler) handleGetState(key string, txid string) ([]byte, error)
 		chaincodeLogger.Debugf("[%s]GetState received payload %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
 		return responseMsg.Payload, nil
 	}
+	if responseMsg.Type.String() == pb.ChaincodeMessage_GET_STATE_CHUNK.String() {
+		// The value is too large for a single message, get the remaining parts
+		chaincodeLogger.Debugf("[%s]GetState received %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_GET_STATE_CHUNK)
+		return handler.getStateChunks(key, responseMsg, respChan)
+	}
 	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
 		// Error response
 		chaincodeLogger.Errorf("[%s]GetState received error %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
@@ -412,11 +424,58 @@ func (handler *Handler) handleGetState(key string, txid string) ([]byte, error)
 	return nil, errors.New(fmt.Sprintf("[%s]Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR))
 }
 
+// getStateChunks assembles a large state value from the first part sent by
+// the peer and the following parts, which it requests one at a time
+func (handler *Handler) getStateChunks(key string, responseMsg pb.ChaincodeMessage, respChan chan pb.ChaincodeMessage) ([]byte, error) {
+	txid := responseMsg.Txid
+	var value []byte
+	var err error
+	for {
+		chunk := &pb.StateChunk{}
+		if err = proto.Unmarshal(responseMsg.Payload, chunk); err != nil {
+			return nil, errors.New(fmt.Sprintf("[%s]error unmarshalling %s: %s", shorttxid(txid), pb.ChaincodeMessage_GET_STATE_CHUNK, err))
+		}
+		if chunk.Key != key || chunk.Offset != uint64(len(value)) || chunk.Size < chunk.Offset+uint64(len(chunk.Data)) || len(chunk.Data) == 0 {
+			return nil, errors.New(fmt.Sprintf("[%s]unexpected chunk of key %s at offset %d", shorttxid(txid), chunk.Key, chunk.Offset))
+		}
+		if value == nil {
+			value = make([]byte, 0, chunk.Size)
+		}
+		value = append(value, chunk.Data...)
+		if uint64(len(value)) == chunk.Size {
+			return value, nil
+		}
+
+		// Send GET_STATE_CHUNK message to validator chaincode support
+		payloadBytes, _ := proto.Marshal(&pb.StateChunk{Key: key, Offset: uint64(len(value))})
+		msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_CHUNK, Payload: payloadBytes, Txid: txid}
+		chaincodeLogger.Debugf("[%s]Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_CHUNK)
+
+		if responseMsg, err = handler.sendReceive(msg, respChan); err != nil {
+			return nil, errors.New(fmt.Sprintf("[%s]error sending %s %s", shorttxid(txid), pb.ChaincodeMessage_GET_STATE_CHUNK, err))
+		}
+
+		if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+			// Error response
+			chaincodeLogger.Errorf("[%s]GetState received error %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+			return nil, errors.New(string(responseMsg.Payload[:]))
+		}
+		if responseMsg.Type.String() != pb.ChaincodeMessage_GET_STATE_CHUNK.String() {
+			// Incorrect chaincode message received
+			return nil, errors.New(fmt.Sprintf("[%s]Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_GET_STATE_CHUNK, pb.ChaincodeMessage_ERROR))
+		}
+	}
+}
+
 // handlePutState communicates with the validator to put state information into the ledger.
 func (handler *Handler) handlePutState(key string, value []byte, txid string) error {
 	// Check if this is a transaction
 	chaincodeLogger.Debugf("[%s]Inside putstate", shorttxid(txid))
 
+	if handler.chunkSize > 0 && len(value) > handler.chunkSize {
+		return handler.handlePutStateChunks(key, value, txid)
+	}
+
 	//we constructed a valid object. No need to check for error
 	payloadBytes, _ := proto.Marshal(&pb.PutStateInfo{Key: key, Value: value})
 
@@ -455,6 +514,53 @@ func (handler *Handler) handlePutState(key string, value []byte, txid string) er
 	return errors.New(fmt.Sprintf("[%s]Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR))
 }
 
+// handlePutStateChunks sends a state value too large for a single message
+// to the validator in parts, each of which is acknowledged before the next
+func (handler *Handler) handlePutStateChunks(key string, value []byte, txid string) error {
+	// Create the channel on which to communicate the response from validating peer
+	var respChan chan pb.ChaincodeMessage
+	var err error
+	if respChan, err = handler.createChannel(txid); err != nil {
+		return err
+	}
+
+	defer handler.deleteChannel(txid)
+
+	for offset := 0; offset < len(value); offset += handler.chunkSize {
+		end := offset + handler.chunkSize
+		if end > len(value) {
+			end = len(value)
+		}
+
+		//we constructed a valid object. No need to check for error
+		payloadBytes, _ := proto.Marshal(&pb.StateChunk{Key: key, Data: value[offset:end], Offset: uint64(offset), Size: uint64(len(value))})
+
+		// Send PUT_STATE_CHUNK message to validator chaincode support
+		msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PUT_STATE_CHUNK, Payload: payloadBytes, Txid: txid}
+		chaincodeLogger.Debugf("[%s]Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_PUT_STATE_CHUNK)
+
+		var responseMsg pb.ChaincodeMessage
+
+		if responseMsg, err = handler.sendReceive(msg, respChan); err != nil {
+			return errors.New(fmt.Sprintf("[%s]error sending PUT_STATE_CHUNK %s", msg.Txid, err))
+		}
+
+		if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+			// Error response
+			chaincodeLogger.Errorf("[%s]Received %s. Payload: %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR, responseMsg.Payload)
+			return errors.New(string(responseMsg.Payload[:]))
+		}
+
+		if responseMsg.Type.String() != pb.ChaincodeMessage_RESPONSE.String() {
+			// Incorrect chaincode message received
+			return errors.New(fmt.Sprintf("[%s]Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR))
+		}
+	}
+
+	chaincodeLogger.Debugf("[%s]Successfully updated state of size %d", shorttxid(txid), len(value))
+	return nil
+}
+
 // handleDelState communicates with the validator to delete a key from the state in the ledger.
 func (handler *Handler) handleDelState(key string, txid string) error {
 	// Create the channel on which to communicate the response from validating peer
@@ -188,6 +188,11 @@ type ChaincodeStubInterface interface {
 	// client's timestamp, and will have the same value across all endorsers.
 	GetTxTimestamp() (*timestamp.Timestamp, error)
 
+	// IsInit returns true if the chaincode was called to run its Init
+	// function, either upon instantiation or because a client explicitly
+	// initialized a chaincode whose definition requires it.
+	IsInit() bool
+
 	// SetEvent allows the chaincode to propose an event on the transaction
 	// proposal. If the transaction is validated and successfully committed,
 	// the event will be delivered to the current event listeners.
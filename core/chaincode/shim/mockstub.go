@@ -64,6 +64,12 @@ type MockStub struct {
 
 	// mocked signedProposal
 	signedProposal *pb.SignedProposal
+
+	// Creator is the serialized identity returned by GetCreator
+	Creator []byte
+
+	// true while the chaincode's Init function runs
+	isInit bool
 }
 
 func (stub *MockStub) GetTxID() string {
@@ -120,7 +126,9 @@ func (stub *MockStub) MockPeerChaincode(invokableChaincodeName string, otherStub
 func (stub *MockStub) MockInit(uuid string, args [][]byte) pb.Response {
 	stub.args = args
 	stub.MockTransactionStart(uuid)
+	stub.isInit = true
 	res := stub.cc.Init(stub)
+	stub.isInit = false
 	stub.MockTransactionEnd(uuid)
 	return res
 }
@@ -277,9 +285,9 @@ func (stub *MockStub) InvokeChaincode(chaincodeName string, args [][]byte, chann
 	return res
 }
 
-// Not implemented
+// GetCreator returns the serialized identity set in the Creator field
 func (stub *MockStub) GetCreator() ([]byte, error) {
-	return nil, nil
+	return stub.Creator, nil
 }
 
 // Not implemented
@@ -301,6 +309,11 @@ func (stub *MockStub) setSignedProposal(sp *pb.SignedProposal) {
 	stub.signedProposal = sp
 }
 
+// IsInit returns true while the chaincode's Init function runs
+func (stub *MockStub) IsInit() bool {
+	return stub.isInit
+}
+
 // Not implemented
 func (stub *MockStub) GetArgsSlice() ([]byte, error) {
 	return nil, nil
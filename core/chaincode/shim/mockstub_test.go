@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/viper"
 )
 
@@ -235,6 +236,36 @@ func TestGetTxTimestamp(t *testing.T) {
 	stub.MockTransactionEnd("init")
 }
 
+type isInitTestCC struct {
+	initialized bool
+}
+
+func (cc *isInitTestCC) Init(stub ChaincodeStubInterface) pb.Response {
+	cc.initialized = stub.IsInit()
+	return Success(nil)
+}
+
+func (cc *isInitTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
+	if stub.IsInit() {
+		return Error("not an initialization")
+	}
+	return Success(nil)
+}
+
+func TestIsInit(t *testing.T) {
+	cc := &isInitTestCC{}
+	stub := NewMockStub("IsInit", cc)
+
+	stub.MockInit("init", nil)
+	if !cc.initialized {
+		t.Fatal("IsInit should be true while Init runs")
+	}
+
+	if res := stub.MockInvoke("invoke", nil); res.Status != OK {
+		t.Fatalf("IsInit should be false while Invoke runs: %s", res.Message)
+	}
+}
+
 //TestMockMock clearly cheating for coverage... but not. Mock should
 //be tucked away under common/mocks package which is not
 //included for coverage. Moving mockstub to another package
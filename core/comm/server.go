@@ -35,6 +35,10 @@ type SecureServerConfig struct {
 	UseTLS bool
 	//Whether or not TLS client must present certificates for authentication
 	RequireClientCert bool
+	//Maximum size in bytes of the messages the server can receive and send;
+	//the package wide limits apply if not set
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
 }
 
 //GRPCServer defines an interface representing a GRPC-based server
@@ -167,8 +171,16 @@ func NewGRPCServerFromListener(listener net.Listener, secureConfig SecureServerC
 		}
 	}
 	// set max send and recv msg sizes
-	serverOpts = append(serverOpts, grpc.MaxSendMsgSize(MaxSendMsgSize()))
-	serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(MaxRecvMsgSize()))
+	maxSendMsgSize := MaxSendMsgSize()
+	if secureConfig.MaxSendMsgSize > 0 {
+		maxSendMsgSize = secureConfig.MaxSendMsgSize
+	}
+	maxRecvMsgSize := MaxRecvMsgSize()
+	if secureConfig.MaxRecvMsgSize > 0 {
+		maxRecvMsgSize = secureConfig.MaxRecvMsgSize
+	}
+	serverOpts = append(serverOpts, grpc.MaxSendMsgSize(maxSendMsgSize))
+	serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(maxRecvMsgSize))
 	// set the keepalive options
 	serverOpts = append(serverOpts, ServerKeepaliveOptions()...)
 
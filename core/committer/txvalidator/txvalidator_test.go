@@ -22,6 +22,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
@@ -162,6 +163,44 @@ func TestNewTxValidator_DuplicateTransactions(t *testing.T) {
 	assert.True(t, txsfltr.IsInvalid(0))
 }
 
+func TestNewTxValidator_DuplicateTransactionsInBlock(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	gb, _ := test.MakeGenesisBlock("TestLedger")
+	ledger, _ := ledgermgmt.CreateLedger(gb)
+	defer ledger.Close()
+
+	simulator, _ := ledger.NewTxSimulator()
+	simulator.SetState("ns1", "key1", []byte("value1"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+
+	env, _, err := testutil.ConstructTransaction(t, simRes, true)
+	assert.NoError(t, err)
+	envBytes, err := proto.Marshal(env)
+	assert.NoError(t, err)
+
+	validate := func(ac *mockconfig.ApplicationCapabilities) util.TxValidationFlags {
+		tValidator := &txValidator{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: ac}, &validator.MockVsccValidator{}}
+		block := common.NewBlock(1, []byte("hash"))
+		block.Data.Data = [][]byte{envBytes, envBytes}
+		assert.NoError(t, tValidator.Validate(block))
+		return util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	// without the capability, the duplicate is not detected within the block
+	txsfltr := validate(&mockconfig.ApplicationCapabilities{})
+	assert.True(t, txsfltr.IsValid(0))
+	assert.True(t, txsfltr.IsValid(1))
+
+	// with the capability, the second transaction is marked as a duplicate
+	txsfltr = validate(&mockconfig.ApplicationCapabilities{ForbidDuplicateTXIdInBlockVal: true})
+	assert.True(t, txsfltr.IsValid(0))
+	assert.True(t, txsfltr.IsSetTo(1, peer.TxValidationCode_DUPLICATE_TXID))
+}
+
 func createCCUpgradeEnvelope(chainID, chaincodeName, chaincodeVersion string, signer msp.SigningIdentity) (*common.Envelope, error) {
 	creator, err := signer.Serialize()
 	if err != nil {
@@ -18,8 +18,11 @@ package txvalidator
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/flogging"
 	coreUtil "github.com/hyperledger/fabric/common/util"
@@ -33,6 +36,7 @@ import (
 
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/op/go-logging"
 
@@ -54,6 +58,9 @@ type Support interface {
 	// GetMSPIDs returns the IDs for the application MSPs
 	// that have been defined in the channel
 	GetMSPIDs(cid string) []string
+
+	// Capabilities defines the capabilities for the application portion of this channel
+	Capabilities() config.ApplicationCapabilities
 }
 
 //Validator interface which defines API to validate block transactions
@@ -88,6 +95,13 @@ type txValidator struct {
 
 var logger *logging.Logger // package-level logger
 
+const (
+	// namespace and key prefix under which the lifecycle scc
+	// stores committed chaincode definitions
+	lifecycleNamespace        = "_lifecycle"
+	lifecycleDefinitionPrefix = "definitions/"
+)
+
 func init() {
 	// Init logger with module name
 	logger = flogging.MustGetLogger("txvalidator")
@@ -117,6 +131,12 @@ func (v *txValidator) Validate(block *common.Block) error {
 	txsChaincodeNames := make(map[int]*sysccprovider.ChaincodeInstance)
 	// upgradedChaincodes records all the chaincodes that are upgrded in a block
 	txsUpgradedChaincodes := make(map[int]*sysccprovider.ChaincodeInstance)
+	// blockTxIDs records the txids seen in the block, when the channel
+	// capabilities forbid duplicate txids within a block
+	var blockTxIDs map[string]struct{}
+	if v.support.Capabilities().ForbidDuplicateTXIdInBlock() {
+		blockTxIDs = make(map[string]struct{})
+	}
 	for tIdx, d := range block.Data.Data {
 		if d != nil {
 			if env, err := utils.GetEnvelopeFromBlock(d); err != nil {
@@ -163,6 +183,14 @@ func (v *txValidator) Validate(block *common.Block) error {
 						txsfltr.SetFlag(tIdx, peer.TxValidationCode_DUPLICATE_TXID)
 						continue
 					}
+					if blockTxIDs != nil {
+						if _, exists := blockTxIDs[txID]; exists {
+							logger.Error("Duplicate transaction found in block, ", txID, ", skipping")
+							txsfltr.SetFlag(tIdx, peer.TxValidationCode_DUPLICATE_TXID)
+							continue
+						}
+						blockTxIDs[txID] = struct{}{}
+					}
 
 					// Validate tx with vscc and policy
 					logger.Debug("Validating transaction vscc tx validate")
@@ -362,9 +390,9 @@ func (v *vsccValidatorImpl) GetInfoForValidate(txid, chID, ccID string) (*sysccp
 	vscc := &sysccprovider.ChaincodeInstance{ChainID: chID}
 	var policy []byte
 	var err error
-	if ccID != "lscc" {
+	if ccID != "lscc" && (ccID != lifecycleNamespace || !v.support.Capabilities().ChaincodeLifecycle()) {
 		// when we are validating any chaincode other than
-		// LSCC, we need to ask LSCC to give us the name
+		// LSCC and the lifecycle scc, we need to ask LSCC to give us the name
 		// of VSCC and of the policy that should be used
 
 		// obtain name of the VSCC and the policy from LSCC
@@ -378,10 +406,10 @@ func (v *vsccValidatorImpl) GetInfoForValidate(txid, chID, ccID string) (*sysccp
 		vscc.ChaincodeName = cd.Vscc
 		policy = cd.Policy
 	} else {
-		// when we are validating LSCC, we use the default
-		// VSCC and a default policy that requires one signature
-		// from any of the members of the channel
-		cc.ChaincodeName = "lscc"
+		// when we are validating LSCC or the lifecycle scc, we use
+		// the default VSCC and a default policy that requires one
+		// signature from any of the members of the channel
+		cc.ChaincodeName = ccID
 		cc.ChaincodeVersion = coreUtil.GetSysCCVersion()
 		vscc.ChaincodeName = "vscc"
 		p := cauthdsl.SignedByAnyMember(v.support.GetMSPIDs(chID))
@@ -414,10 +442,13 @@ func (v *vsccValidatorImpl) VSCCValidateTx(payload *common.Payload, envBytes []b
 	   at first, we establish a few facts about this invocation:
 	   1) which namespaces does it write to?
 	   2) does it write to LSCC's namespace?
-	   3) does it write to any cc that cannot be invoked? */
+	   3) does it write to any cc that cannot be invoked?
+	   4) does it mark any cc as initialized? */
 	wrNamespace := []string{}
 	writesToLSCC := false
+	writesToLifecycle := false
 	writesToNonInvokableSCC := false
+	initializes := []string{}
 	respPayload, err := utils.GetActionFromEnvelope(envBytes)
 	if err != nil {
 		return fmt.Errorf("GetActionFromEnvelope failed, error %s", err), peer.TxValidationCode_BAD_RESPONSE_PAYLOAD
@@ -434,6 +465,10 @@ func (v *vsccValidatorImpl) VSCCValidateTx(payload *common.Payload, envBytes []b
 				writesToLSCC = true
 			}
 
+			if !writesToLifecycle && ns.NameSpace == lifecycleNamespace {
+				writesToLifecycle = true
+			}
+
 			if !writesToNonInvokableSCC && v.sccprovider.IsSysCCAndNotInvokableCC2CC(ns.NameSpace) {
 				writesToNonInvokableSCC = true
 			}
@@ -441,9 +476,20 @@ func (v *vsccValidatorImpl) VSCCValidateTx(payload *common.Payload, envBytes []b
 			if !writesToNonInvokableSCC && v.sccprovider.IsSysCCAndNotInvokableExternal(ns.NameSpace) {
 				writesToNonInvokableSCC = true
 			}
+
+			for _, kvw := range ns.KvRwSet.Writes {
+				if strings.HasPrefix(kvw.Key, ccprovider.InitializedKeyName) {
+					initializes = append(initializes, ns.NameSpace)
+					break
+				}
+			}
 		}
 	}
 
+	// the lifecycle scc and the initialization of chaincodes are
+	// only validated once the channel requires the capability
+	chaincodeLifecycle := v.support.Capabilities().ChaincodeLifecycle()
+
 	// get name and version of the cc we invoked
 	ccID := hdrExt.ChaincodeId.Name
 	ccVer := respPayload.ChaincodeId.Version
@@ -482,7 +528,14 @@ func (v *vsccValidatorImpl) VSCCValidateTx(payload *common.Payload, envBytes []b
 			return fmt.Errorf("Chaincode %s attempted to write to the namespace of LSCC", ccID),
 				peer.TxValidationCode_ILLEGAL_WRITESET
 		}
-		// 2) we don't write to the namespace of a chaincode that we cannot invoke - if
+		// 2) we don't write to the namespace of the lifecycle scc - approvals and
+		//    definitions are only written by invoking it directly, so that VSCC
+		//    checks them against the channel's lifecycle policy
+		if chaincodeLifecycle && writesToLifecycle {
+			return fmt.Errorf("Chaincode %s attempted to write to the namespace of %s", ccID, lifecycleNamespace),
+				peer.TxValidationCode_ILLEGAL_WRITESET
+		}
+		// 3) we don't write to the namespace of a chaincode that we cannot invoke - if
 		//    the chaincode cannot be invoked in the first place, there's no legitimate
 		//    way in which a transaction has a write set that writes to it; additionally
 		//    we don't have any means of verifying whether the transaction had the rights
@@ -493,6 +546,14 @@ func (v *vsccValidatorImpl) VSCCValidateTx(payload *common.Payload, envBytes []b
 			return fmt.Errorf("Chaincode %s attempted to write to the namespace of a system chaincode that cannot be invoked", ccID),
 				peer.TxValidationCode_ILLEGAL_WRITESET
 		}
+		// 4) the chaincode is initialized only through an explicit initialization
+		//    and, if its definition requires one, before it is invoked otherwise
+		if chaincodeLifecycle {
+			if err, code := v.checkInitialization(payload, ccID, initializes, txRWSet); err != nil {
+				logger.Errorf("Initialization check for txId = %s failed, error %s", chdr.TxId, err)
+				return err, code
+			}
+		}
 
 		// validate *EACH* read write set according to its chaincode's endorsement policy
 		for _, ns := range wrNamespace {
@@ -528,6 +589,19 @@ func (v *vsccValidatorImpl) VSCCValidateTx(payload *common.Payload, envBytes []b
 				peer.TxValidationCode_ILLEGAL_WRITESET
 		}
 
+		// the lifecycle scc cannot be invoked before the
+		// channel requires the capability
+		if !chaincodeLifecycle && ccID == lifecycleNamespace {
+			return fmt.Errorf("Committing an invocation of cc %s is illegal without the %s application capability", ccID, capabilities.ApplicationV1_2),
+				peer.TxValidationCode_ILLEGAL_WRITESET
+		}
+
+		// system chaincodes never initialize chaincodes
+		if chaincodeLifecycle && len(initializes) > 0 {
+			return fmt.Errorf("System chaincode %s attempted to mark chaincode %s as initialized", ccID, initializes[0]),
+				peer.TxValidationCode_ILLEGAL_WRITESET
+		}
+
 		// Get latest chaincode version, vscc and validate policy
 		_, vscc, policy, err := v.GetInfoForValidate(chdr.TxId, chdr.ChannelId, ccID)
 		if err != nil {
@@ -549,6 +623,122 @@ func (v *vsccValidatorImpl) VSCCValidateTx(payload *common.Payload, envBytes []b
 	return nil, peer.TxValidationCode_VALID
 }
 
+// checkInitialization verifies that an application chaincode invocation
+// honours the init_required flag of the chaincode's definition: only an
+// explicit initialization may mark the invoked chaincode as initialized, and
+// the endorsers must have read the marker so that MVCC validation rejects
+// a second initialization as well as invocations of a chaincode that has
+// not been initialized yet
+func (v *vsccValidatorImpl) checkInitialization(payload *common.Payload, ccID string, initializes []string, txRWSet *rwsetutil.TxRwSet) (error, peer.TxValidationCode) {
+	isInit, err := isInitInvocation(payload)
+	if err != nil {
+		return err, peer.TxValidationCode_BAD_PAYLOAD
+	}
+
+	for _, ns := range initializes {
+		if !isInit || ns != ccID {
+			return fmt.Errorf("Chaincode %s attempted to mark chaincode %s as initialized", ccID, ns),
+				peer.TxValidationCode_ILLEGAL_WRITESET
+		}
+	}
+
+	cd, err := v.getCDataForCC(ccID)
+	if err != nil {
+		return err, peer.TxValidationCode_INVALID_OTHER_REASON
+	}
+
+	if !cd.InitRequired {
+		if isInit {
+			return fmt.Errorf("Chaincode %s does not require initialization", ccID),
+				peer.TxValidationCode_INVALID_OTHER_REASON
+		}
+		return nil, peer.TxValidationCode_VALID
+	}
+
+	// the marker of the current definition of the chaincode
+	key := ccprovider.InitializedKey(cd)
+
+	for _, ns := range txRWSet.NsRwSets {
+		if ns.NameSpace != ccID {
+			continue
+		}
+		for _, kvw := range ns.KvRwSet.Writes {
+			if strings.HasPrefix(kvw.Key, ccprovider.InitializedKeyName) && kvw.Key != key {
+				return fmt.Errorf("Initialization of chaincode %s marked another definition as initialized", ccID),
+					peer.TxValidationCode_ILLEGAL_WRITESET
+			}
+		}
+	}
+
+	if isInit && len(initializes) == 0 {
+		return fmt.Errorf("Initialization of chaincode %s did not mark it as initialized", ccID),
+			peer.TxValidationCode_INVALID_OTHER_REASON
+	}
+
+	for _, ns := range txRWSet.NsRwSets {
+		if ns.NameSpace != ccID {
+			continue
+		}
+		for _, kvr := range ns.KvRwSet.Reads {
+			if kvr.Key != key {
+				continue
+			}
+			if isInit && kvr.Version != nil {
+				return fmt.Errorf("Chaincode %s is already initialized", ccID),
+					peer.TxValidationCode_INVALID_OTHER_REASON
+			}
+			if !isInit && kvr.Version == nil {
+				return fmt.Errorf("Chaincode %s must be initialized before it can be invoked", ccID),
+					peer.TxValidationCode_INVALID_OTHER_REASON
+			}
+			return nil, peer.TxValidationCode_VALID
+		}
+	}
+
+	return fmt.Errorf("Invocation of chaincode %s did not check whether it is initialized", ccID),
+		peer.TxValidationCode_INVALID_OTHER_REASON
+}
+
+// getInvocationInput returns the input of the chaincode invocation
+// proposed by the transaction
+func getInvocationInput(payload *common.Payload) (*peer.ChaincodeInput, error) {
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		return nil, fmt.Errorf("GetTransaction failed, error %s", err)
+	}
+
+	if len(tx.Actions) == 0 {
+		return nil, fmt.Errorf("transaction has no actions")
+	}
+
+	cap, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	if err != nil {
+		return nil, fmt.Errorf("GetChaincodeActionPayload failed, error %s", err)
+	}
+
+	cpp, err := utils.GetChaincodeProposalPayload(cap.ChaincodeProposalPayload)
+	if err != nil {
+		return nil, fmt.Errorf("GetChaincodeProposalPayload failed, error %s", err)
+	}
+
+	cis := &peer.ChaincodeInvocationSpec{}
+	if err = proto.Unmarshal(cpp.Input, cis); err != nil {
+		return nil, fmt.Errorf("GetChaincodeInvokeSpec failed, error %s", err)
+	}
+
+	return cis.ChaincodeSpec.GetInput(), nil
+}
+
+// isInitInvocation returns whether the transaction is an explicit
+// initialization of the chaincode it invokes
+func isInitInvocation(payload *common.Payload) (bool, error) {
+	input, err := getInvocationInput(payload)
+	if err != nil {
+		return false, err
+	}
+	return input.GetIsInit(), nil
+}
+
 func (v *vsccValidatorImpl) VSCCValidateTxForCC(envBytes []byte, txid, chid, vsccName, vsccVer string, policy []byte) error {
 	ctxt, err := v.ccprovider.GetContext(v.support.Ledger())
 	if err != nil {
@@ -594,19 +784,31 @@ func (v *vsccValidatorImpl) getCDataForCC(ccid string) (*ccprovider.ChaincodeDat
 	}
 	defer qe.Done()
 
-	bytes, err := qe.GetState("lscc", ccid)
-	if err != nil {
-		return nil, fmt.Errorf("Could not retrieve state for chaincode %s, error %s", ccid, err)
+	// definitions committed through the lifecycle scc take
+	// precedence over chaincodes instantiated through lscc
+	var cd *ccprovider.ChaincodeData
+	if v.support.Capabilities().ChaincodeLifecycle() {
+		cd, err = getLifecycleCDataForCC(qe, ccid)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if bytes == nil {
-		return nil, fmt.Errorf("lscc's state for [%s] not found.", ccid)
-	}
+	if cd == nil {
+		bytes, err := qe.GetState("lscc", ccid)
+		if err != nil {
+			return nil, fmt.Errorf("Could not retrieve state for chaincode %s, error %s", ccid, err)
+		}
 
-	cd := &ccprovider.ChaincodeData{}
-	err = proto.Unmarshal(bytes, cd)
-	if err != nil {
-		return nil, fmt.Errorf("Unmarshalling ChaincodeQueryResponse failed, error %s", err)
+		if bytes == nil {
+			return nil, fmt.Errorf("lscc's state for [%s] not found.", ccid)
+		}
+
+		cd = &ccprovider.ChaincodeData{}
+		err = proto.Unmarshal(bytes, cd)
+		if err != nil {
+			return nil, fmt.Errorf("Unmarshalling ChaincodeQueryResponse failed, error %s", err)
+		}
 	}
 
 	if cd.Vscc == "" {
@@ -619,3 +821,33 @@ func (v *vsccValidatorImpl) getCDataForCC(ccid string) (*ccprovider.ChaincodeDat
 
 	return cd, err
 }
+
+// getLifecycleCDataForCC returns the definition of the chaincode committed
+// through the lifecycle scc, or nil if there is none. The namespace and the
+// key layout are spelled out because the scc package cannot be imported here
+func getLifecycleCDataForCC(qe ledger.QueryExecutor, ccid string) (*ccprovider.ChaincodeData, error) {
+	bytes, err := qe.GetState(lifecycleNamespace, lifecycleDefinitionPrefix+ccid)
+	if err != nil {
+		return nil, fmt.Errorf("Could not retrieve definition of chaincode %s, error %s", ccid, err)
+	}
+
+	if bytes == nil {
+		return nil, nil
+	}
+
+	def := &lifecycle.ChaincodeDefinition{}
+	if err = proto.Unmarshal(bytes, def); err != nil {
+		return nil, fmt.Errorf("Unmarshalling ChaincodeDefinition failed, error %s", err)
+	}
+
+	return &ccprovider.ChaincodeData{
+		Name:         def.Name,
+		Version:      def.Version,
+		Escc:         def.Escc,
+		Vscc:         def.Vscc,
+		Policy:       def.EndorsementPolicy,
+		Id:           def.Hash,
+		InitRequired: def.InitRequired,
+		Sequence:     def.Sequence,
+	}, nil
+}
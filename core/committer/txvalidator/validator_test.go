@@ -22,14 +22,17 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/config"
 	ctxt "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/common/mocks/scc"
 	"github.com/hyperledger/fabric/common/util"
 	ccp "github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	lutils "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/core/mocks/ccprovider"
@@ -38,6 +41,7 @@ import (
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -49,13 +53,17 @@ func signedByAnyMember(ids []string) []byte {
 }
 
 func setupLedgerAndValidator(t *testing.T) (ledger.PeerLedger, Validator) {
+	return setupLedgerAndValidatorWithCapabilities(t, &mockconfig.ApplicationCapabilities{})
+}
+
+func setupLedgerAndValidatorWithCapabilities(t *testing.T, ac *mockconfig.ApplicationCapabilities) (ledger.PeerLedger, Validator) {
 	viper.Set("peer.fileSystemPath", "/tmp/fabric/validatortest")
 	ledgermgmt.InitializeTestEnv()
 	gb, err := ctxt.MakeGenesisBlock("TestLedger")
 	assert.NoError(t, err)
 	theLedger, err := ledgermgmt.CreateLedger(gb)
 	assert.NoError(t, err)
-	theValidator := NewTxValidator(&mockSupport{l: theLedger})
+	theValidator := NewTxValidator(&mockSupport{l: theLedger, ac: ac})
 
 	return theLedger, theValidator
 }
@@ -71,11 +79,11 @@ func createRWset(t *testing.T, ccnames ...string) []byte {
 	return rws
 }
 
-func getProposal(ccID string) (*peer.Proposal, error) {
+func getProposal(ccID string, input *peer.ChaincodeInput) (*peer.Proposal, error) {
 	cis := &peer.ChaincodeInvocationSpec{
 		ChaincodeSpec: &peer.ChaincodeSpec{
 			ChaincodeId: &peer.ChaincodeID{Name: ccID, Version: ccVersion},
-			Input:       &peer.ChaincodeInput{Args: [][]byte{[]byte("func")}},
+			Input:       input,
 			Type:        peer.ChaincodeSpec_GOLANG}}
 
 	proposal, _, err := utils.CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), cis, signerSerialized)
@@ -85,8 +93,16 @@ func getProposal(ccID string) (*peer.Proposal, error) {
 const ccVersion = "1.0"
 
 func getEnv(ccID string, res []byte, t *testing.T) *common.Envelope {
+	return getEnvWithInit(ccID, false, res, t)
+}
+
+func getEnvWithInit(ccID string, isInit bool, res []byte, t *testing.T) *common.Envelope {
+	return getEnvWithInput(ccID, &peer.ChaincodeInput{Args: [][]byte{[]byte("func")}, IsInit: isInit}, res, t)
+}
+
+func getEnvWithInput(ccID string, input *peer.ChaincodeInput, res []byte, t *testing.T) *common.Envelope {
 	// get a toy proposal
-	prop, err := getProposal(ccID)
+	prop, err := getProposal(ccID, input)
 	assert.NoError(t, err)
 
 	response := &peer.Response{Status: 200}
@@ -124,12 +140,26 @@ func putCCInfoWithVSCCAndVer(theLedger ledger.PeerLedger, ccname, vscc, ver stri
 	assert.NoError(t, err)
 }
 
+func putLifecycleDefinition(theLedger ledger.PeerLedger, def *lifecycle.ChaincodeDefinition, t *testing.T) {
+	simulator, err := theLedger.NewTxSimulator()
+	assert.NoError(t, err)
+	simulator.SetState(lifecycleNamespace, lifecycleDefinitionPrefix+def.Name, utils.MarshalOrPanic(def))
+	simulator.Done()
+
+	simRes, err := simulator.GetTxSimulationResults()
+	assert.NoError(t, err)
+	block0 := testutil.ConstructBlock(t, 1, []byte("hash"), [][]byte{simRes}, true)
+	err = theLedger.Commit(block0)
+	assert.NoError(t, err)
+}
+
 func putCCInfo(theLedger ledger.PeerLedger, ccname string, policy []byte, t *testing.T) {
 	putCCInfoWithVSCCAndVer(theLedger, ccname, "vscc", ccVersion, policy, t)
 }
 
 type mockSupport struct {
-	l ledger.PeerLedger
+	l  ledger.PeerLedger
+	ac *mockconfig.ApplicationCapabilities
 }
 
 func (m *mockSupport) Ledger() ledger.PeerLedger {
@@ -148,6 +178,13 @@ func (m *mockSupport) GetMSPIDs(cid string) []string {
 	return []string{"DEFAULT"}
 }
 
+func (m *mockSupport) Capabilities() config.ApplicationCapabilities {
+	if m.ac == nil {
+		return &mockconfig.ApplicationCapabilities{}
+	}
+	return m.ac
+}
+
 func assertInvalid(block *common.Block, t *testing.T, code peer.TxValidationCode) {
 	txsFilter := lutils.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
 	assert.True(t, txsFilter.IsInvalid(0))
@@ -242,6 +279,219 @@ func TestInvokeNOKWritesToLSCC(t *testing.T) {
 	assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
 }
 
+func TestInvokeNOKWritesToLifecycle(t *testing.T) {
+	l, v := setupLedgerAndValidatorWithCapabilities(t, &mockconfig.ApplicationCapabilities{ChaincodeLifecycleVal: true})
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+
+	putCCInfo(l, ccID, signedByAnyMember([]string{"DEFAULT"}), t)
+
+	tx := getEnv(ccID, createRWset(t, ccID, lifecycleNamespace), t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+}
+
+func TestInvokeOKLifecycleDefinition(t *testing.T) {
+	l, v := setupLedgerAndValidatorWithCapabilities(t, &mockconfig.ApplicationCapabilities{ChaincodeLifecycleVal: true})
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+
+	// the chaincode is only defined through the lifecycle scc
+	def := &lifecycle.ChaincodeDefinition{
+		Name:              ccID,
+		Version:           ccVersion,
+		Sequence:          1,
+		EndorsementPolicy: signedByAnyMember([]string{"DEFAULT"}),
+		Vscc:              "vscc",
+	}
+
+	putLifecycleDefinition(l, def, t)
+
+	tx := getEnv(ccID, createRWset(t, ccID), t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+	assertValid(b, t)
+}
+
+func TestInvokeLifecycleWithoutCapability(t *testing.T) {
+	l, v := setupLedgerAndValidator(t)
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+
+	putLifecycleDefinition(l, &lifecycle.ChaincodeDefinition{
+		Name:              ccID,
+		Version:           ccVersion,
+		Sequence:          1,
+		EndorsementPolicy: signedByAnyMember([]string{"DEFAULT"}),
+		Vscc:              "vscc",
+	}, t)
+
+	// definitions of the lifecycle scc are ignored
+	tx := getEnv(ccID, createRWset(t, ccID), t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+
+	// and the lifecycle scc cannot be invoked
+	tx = getEnv(lifecycleNamespace, createRWset(t, lifecycleNamespace), t)
+	b = &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+
+	err = v.Validate(b)
+	assert.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+}
+
+func TestInvokeOKLifecycleSCC(t *testing.T) {
+	l, v := setupLedgerAndValidatorWithCapabilities(t, &mockconfig.ApplicationCapabilities{ChaincodeLifecycleVal: true})
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	tx := getEnv(lifecycleNamespace, createRWset(t, lifecycleNamespace), t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+	assertValid(b, t)
+}
+
+func TestInvokeInitRequired(t *testing.T) {
+	l, v := setupLedgerAndValidatorWithCapabilities(t, &mockconfig.ApplicationCapabilities{ChaincodeLifecycleVal: true})
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+
+	def := &lifecycle.ChaincodeDefinition{
+		Name:              ccID,
+		Version:           ccVersion,
+		Sequence:          1,
+		EndorsementPolicy: signedByAnyMember([]string{"DEFAULT"}),
+		Vscc:              "vscc",
+		InitRequired:      true,
+	}
+
+	putLifecycleDefinition(l, def, t)
+
+	validate := func(isInit bool, build func(*rwsetutil.RWSetBuilder)) *common.Block {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		rwsetBuilder.AddToWriteSet(ccID, "key", []byte("value"))
+		build(rwsetBuilder)
+		rws, err := rwsetBuilder.GetTxReadWriteSet().ToProtoBytes()
+		assert.NoError(t, err)
+
+		tx := getEnvWithInit(ccID, isInit, rws, t)
+		b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+		err = v.Validate(b)
+		assert.NoError(t, err)
+		return b
+	}
+
+	key := ccp.InitializedKey(&ccp.ChaincodeData{Version: ccVersion, Sequence: 1})
+
+	// an initialization reads the missing marker and writes it
+	b := validate(true, func(rwsb *rwsetutil.RWSetBuilder) {
+		rwsb.AddToReadSet(ccID, key, nil)
+		rwsb.AddToWriteSet(ccID, key, []byte(ccVersion))
+	})
+	assertValid(b, t)
+
+	// an initialization must write the marker
+	b = validate(true, func(rwsb *rwsetutil.RWSetBuilder) {
+		rwsb.AddToReadSet(ccID, key, nil)
+	})
+	assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+
+	// a chaincode cannot be initialized twice
+	b = validate(true, func(rwsb *rwsetutil.RWSetBuilder) {
+		rwsb.AddToReadSet(ccID, key, version.NewHeight(1, 0))
+		rwsb.AddToWriteSet(ccID, key, []byte(ccVersion))
+	})
+	assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+
+	// an invocation reads the marker
+	b = validate(false, func(rwsb *rwsetutil.RWSetBuilder) {
+		rwsb.AddToReadSet(ccID, key, version.NewHeight(1, 0))
+	})
+	assertValid(b, t)
+
+	// an invocation of a chaincode that is not initialized
+	b = validate(false, func(rwsb *rwsetutil.RWSetBuilder) {
+		rwsb.AddToReadSet(ccID, key, nil)
+	})
+	assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+
+	// an invocation that did not check the marker
+	b = validate(false, func(rwsb *rwsetutil.RWSetBuilder) {})
+	assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+
+	// only an initialization may write the marker
+	b = validate(false, func(rwsb *rwsetutil.RWSetBuilder) {
+		rwsb.AddToReadSet(ccID, key, version.NewHeight(1, 0))
+		rwsb.AddToWriteSet(ccID, key, []byte(ccVersion))
+	})
+	assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+
+	// the marker of a previous definition does not count as initialization
+	previous := ccp.InitializedKey(&ccp.ChaincodeData{Version: "0.9", Sequence: 0})
+	b = validate(true, func(rwsb *rwsetutil.RWSetBuilder) {
+		rwsb.AddToReadSet(ccID, key, nil)
+		rwsb.AddToWriteSet(ccID, previous, []byte(ccVersion))
+	})
+	assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+	b = validate(false, func(rwsb *rwsetutil.RWSetBuilder) {
+		rwsb.AddToReadSet(ccID, previous, version.NewHeight(1, 0))
+	})
+	assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+}
+
+func TestInvokeNOKInitNotRequired(t *testing.T) {
+	l, v := setupLedgerAndValidatorWithCapabilities(t, &mockconfig.ApplicationCapabilities{ChaincodeLifecycleVal: true})
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+
+	putCCInfo(l, ccID, signedByAnyMember([]string{"DEFAULT"}), t)
+
+	tx := getEnvWithInit(ccID, true, createRWset(t, ccID), t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+}
+
+func TestInvokeInitWithoutCapability(t *testing.T) {
+	l, v := setupLedgerAndValidator(t)
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+
+	putCCInfo(l, ccID, signedByAnyMember([]string{"DEFAULT"}), t)
+
+	// the initialization flag of the invocation is not checked
+	tx := getEnvWithInit(ccID, true, createRWset(t, ccID), t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+	assertValid(b, t)
+}
+
 func TestInvokeNOKWritesToESCC(t *testing.T) {
 	l, v := setupLedgerAndValidator(t)
 	defer ledgermgmt.CleanupTestEnv()
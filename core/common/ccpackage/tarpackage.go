@@ -0,0 +1,233 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ccpackage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// This file provides functions to create and read chaincode packages in
+// the tar format. Unlike the ChaincodeDeploymentSpec based packages, they
+// may carry artifacts besides the code of the chaincode. A tar package is
+// a gzipped tar archive made of
+//     metadata.json    - the PackageMetadata describing the chaincode
+//     code.tar.gz      - the code archive, as in the CodePackage of a
+//                        ChaincodeDeploymentSpec
+//     collections.json - optional, the collection configuration
+//     META-INF/...     - optional, e.g. the index definitions of the
+//                        state database under META-INF/statedb
+// The artifacts are part of the package, and hence of its ID, but the peer
+// does not interpret them when reading a package.
+
+const (
+	// MetadataFile is the name of the package file holding the metadata
+	MetadataFile = "metadata.json"
+
+	// CodeFile is the name of the package file holding the code archive
+	CodeFile = "code.tar.gz"
+
+	// CollectionsFile is the name of the package file holding the
+	// collection configuration
+	CollectionsFile = "collections.json"
+
+	// MetaInfDir is the directory of the package holding other artifacts
+	MetaInfDir = "META-INF/"
+)
+
+var labelRegExp = regexp.MustCompile("^[[:alnum:]][[:alnum:]_.+-]*$")
+
+// PackageMetadata describes the chaincode of a tar package
+type PackageMetadata struct {
+	// Label is a name given to the package for humans to identify it
+	Label string `json:"label"`
+	// Type is the language of the chaincode, e.g. "golang"
+	Type string `json:"type"`
+	// Path is the path of the chaincode as given upon packaging
+	Path string `json:"path"`
+	// Name and Version are those of the chaincode once installed
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+func (md *PackageMetadata) validate() error {
+	if !labelRegExp.MatchString(md.Label) {
+		return fmt.Errorf("invalid package label '%s', it must match %s", md.Label, labelRegExp)
+	}
+	if _, ok := peer.ChaincodeSpec_Type_value[strings.ToUpper(md.Type)]; !ok {
+		return fmt.Errorf("invalid chaincode type '%s'", md.Type)
+	}
+	if md.Name == "" || md.Version == "" {
+		return fmt.Errorf("chaincode name and version must be specified")
+	}
+	return nil
+}
+
+// isArtifact returns whether a package may carry a file with the given name
+// in addition to the metadata and the code
+func isArtifact(name string) bool {
+	if name == CollectionsFile {
+		return true
+	}
+	return strings.HasPrefix(name, MetaInfDir) && path.Clean(name) == name && !strings.Contains(name, "..")
+}
+
+// CreateTarPackage returns the bytes of a tar package. The same arguments
+// always give the same bytes, so that the hash of a package identifies it
+func CreateTarPackage(md *PackageMetadata, code []byte, artifacts map[string][]byte) ([]byte, error) {
+	if err := md.validate(); err != nil {
+		return nil, err
+	}
+
+	mdbytes, err := json.Marshal(md)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling package metadata: %s", err)
+	}
+
+	names := make([]string, 0, len(artifacts))
+	for name := range artifacts {
+		if !isArtifact(name) {
+			return nil, fmt.Errorf("invalid package artifact %s", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+
+	write := func(name string, content []byte) error {
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(content)),
+			ModTime: time.Unix(0, 0),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("error writing %s to package: %s", name, err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			return fmt.Errorf("error writing %s to package: %s", name, err)
+		}
+		return nil
+	}
+
+	if err = write(MetadataFile, mdbytes); err != nil {
+		return nil, err
+	}
+	if err = write(CodeFile, code); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if err = write(name, artifacts[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return nil, err
+	}
+	if err = gw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// IsTarPackage returns whether the bytes are those of a tar package, as
+// opposed to a ChaincodeDeploymentSpec based one
+func IsTarPackage(buf []byte) bool {
+	return len(buf) >= 2 && buf[0] == 0x1f && buf[1] == 0x8b
+}
+
+// ParseTarPackage returns the metadata and the code archive of a tar
+// package. The other artifacts are checked to be allowed but not read
+func ParseTarPackage(buf []byte) (*PackageMetadata, []byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(buf))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open tar package: %s", err)
+	}
+	tr := tar.NewReader(gr)
+
+	var md *PackageMetadata
+	var code []byte
+	names := make(map[string]struct{})
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read tar package: %s", err)
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			return nil, nil, fmt.Errorf("invalid entry %s in tar package, only regular files are allowed", hdr.Name)
+		}
+		// tools reading the package could pick either of two entries
+		// with the same name
+		if _, ok := names[hdr.Name]; ok {
+			return nil, nil, fmt.Errorf("duplicate entry %s in tar package", hdr.Name)
+		}
+		names[hdr.Name] = struct{}{}
+
+		if isArtifact(hdr.Name) {
+			continue
+		}
+
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s from tar package: %s", hdr.Name, err)
+		}
+
+		switch hdr.Name {
+		case MetadataFile:
+			md = &PackageMetadata{}
+			if err = json.Unmarshal(content, md); err != nil {
+				return nil, nil, fmt.Errorf("failed to unmarshal package metadata: %s", err)
+			}
+		case CodeFile:
+			code = content
+		default:
+			return nil, nil, fmt.Errorf("unexpected file %s in tar package", hdr.Name)
+		}
+	}
+
+	if md == nil {
+		return nil, nil, fmt.Errorf("no %s in tar package", MetadataFile)
+	}
+	if code == nil {
+		return nil, nil, fmt.Errorf("no %s in tar package", CodeFile)
+	}
+	if err = md.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return md, code, nil
+}
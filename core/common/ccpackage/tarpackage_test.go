@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ccpackage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTarPackage(t *testing.T) {
+	md := &PackageMetadata{Label: "mycc_1.0", Type: "golang", Path: "github.com/mycc", Name: "mycc", Version: "1.0"}
+	artifacts := map[string][]byte{
+		CollectionsFile: []byte("collections"),
+		MetaInfDir + "statedb/couchdb/indexes/index.json": []byte("{}"),
+	}
+
+	b, err := CreateTarPackage(md, []byte("code"), artifacts)
+	assert.NoError(t, err)
+
+	b2, err := CreateTarPackage(md, []byte("code"), artifacts)
+	assert.NoError(t, err)
+	assert.Equal(t, b, b2, "packages of the same content should be identical")
+
+	assert.True(t, IsTarPackage(b))
+	md2, code, err := ParseTarPackage(b)
+	assert.NoError(t, err)
+	assert.Equal(t, md, md2)
+	assert.Equal(t, []byte("code"), code)
+
+	b3, err := CreateTarPackage(md, []byte("code"), nil)
+	assert.NoError(t, err)
+	assert.NotEqual(t, b, b3, "artifacts should be part of the package")
+}
+
+func TestTarPackageErrors(t *testing.T) {
+	md := &PackageMetadata{Label: "mycc_1.0", Type: "golang", Path: "github.com/mycc", Name: "mycc", Version: "1.0"}
+
+	_, err := CreateTarPackage(md, []byte("code"), map[string][]byte{"other.json": nil})
+	assert.Error(t, err, "only collections and META-INF artifacts are allowed")
+
+	_, err = CreateTarPackage(md, []byte("code"), map[string][]byte{MetaInfDir + "../escape": nil})
+	assert.Error(t, err, "artifacts should not escape META-INF")
+
+	_, err = CreateTarPackage(&PackageMetadata{Label: "bad label", Type: "golang", Name: "mycc", Version: "1.0"}, []byte("code"), nil)
+	assert.Error(t, err, "invalid label should be rejected")
+
+	_, err = CreateTarPackage(&PackageMetadata{Label: "mycc", Type: "cobol", Name: "mycc", Version: "1.0"}, []byte("code"), nil)
+	assert.Error(t, err, "unknown chaincode type should be rejected")
+
+	assert.False(t, IsTarPackage([]byte("not a package")))
+	_, _, err = ParseTarPackage([]byte("not a package"))
+	assert.Error(t, err)
+
+	// a package with two entries of the same name
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for _, content := range []string{"code", "other code"} {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: CodeFile, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err = tw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+	_, _, err = ParseTarPackage(buf.Bytes())
+	assert.Error(t, err, "duplicate entries should be rejected")
+}
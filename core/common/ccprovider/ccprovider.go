@@ -23,12 +23,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/golang/protobuf/proto"
 
 	"bytes"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/common/ccpackage"
 	"github.com/hyperledger/fabric/core/ledger"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
@@ -37,9 +39,23 @@ var ccproviderLogger = flogging.MustGetLogger("ccprovider")
 
 var chaincodeInstallPath string
 
+// InitializedKeyName is the prefix of the keys, within a chaincode's namespace,
+// under which the peer records that a definition of a chaincode requiring
+// explicit initialization has been initialized. The prefix, which composite
+// keys cannot contain, keeps them apart from the keys chaincodes normally use.
+const InitializedKeyName = "\x00" + string(utf8.MaxRune) + "initialized"
+
+// InitializedKey returns the key under which the initialization of the
+// supplied definition of a chaincode is recorded. Each definition, as
+// identified by its sequence and version, has to be initialized anew
+func InitializedKey(cd *ChaincodeData) string {
+	return fmt.Sprintf("%s/%d/%s", InitializedKeyName, cd.Sequence, cd.Version)
+}
+
 //CCPackage encapsulates a chaincode package which can be
 //    raw ChaincodeDeploymentSpec
 //    SignedChaincodeDeploymentSpec
+//    tar package with metadata and artifacts
 // Attempt to keep the interface at a level with minimal
 // interface for possible generalization.
 type CCPackage interface {
@@ -123,19 +139,12 @@ type CCInfoFSImpl struct{}
 
 // GetChaincodeFromFS this is a wrapper for hiding package implementation.
 func (*CCInfoFSImpl) GetChaincode(ccname string, ccversion string) (CCPackage, error) {
-	//try raw CDS
-	cccdspack := &CDSPackage{}
-	_, _, err := cccdspack.InitFromFS(ccname, ccversion)
+	buf, err := GetChaincodePackage(ccname, ccversion)
 	if err != nil {
-		//try signed CDS
-		ccscdspack := &SignedCDSPackage{}
-		_, _, err = ccscdspack.InitFromFS(ccname, ccversion)
-		if err != nil {
-			return nil, err
-		}
-		return ccscdspack, nil
+		return nil, err
 	}
-	return cccdspack, nil
+
+	return GetCCPackage(buf)
 }
 
 // PutChaincodeIntoFS is a wrapper for putting raw ChaincodeDeploymentSpec
@@ -243,8 +252,17 @@ func CheckInsantiationPolicy(name, version string, cdLedger *ChaincodeData) erro
 }
 
 // GetCCPackage tries each known package implementation one by one
-// till the right package is found
+// till the right package is found. Tar packages are told apart by their
+// format, so that the error returned is the one of the right parser
 func GetCCPackage(buf []byte) (CCPackage, error) {
+	if ccpackage.IsTarPackage(buf) {
+		cctarpack := &TarPackage{}
+		if _, err := cctarpack.InitFromBuffer(buf); err != nil {
+			return nil, err
+		}
+		return cctarpack, nil
+	}
+
 	//try raw CDS
 	cccdspack := &CDSPackage{}
 	if _, err := cccdspack.InitFromBuffer(buf); err != nil {
@@ -405,6 +423,14 @@ type ChaincodeData struct {
 
 	//InstantiationPolicy for the chaincode
 	InstantiationPolicy []byte `protobuf:"bytes,8,opt,name=instantiation_policy,proto3"`
+
+	//InitRequired is set if the chaincode must be explicitly initialized
+	//before it can be invoked
+	InitRequired bool `protobuf:"varint,9,opt,name=init_required"`
+
+	//Sequence of the definition committed through the lifecycle
+	//system chaincode, 0 for chaincodes instantiated through lscc
+	Sequence int64 `protobuf:"varint,10,opt,name=sequence"`
 }
 
 //implement functions needed from proto.Message for proto's mar/unmarshal functions
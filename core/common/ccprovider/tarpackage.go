@@ -0,0 +1,274 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ccprovider
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/core/common/ccpackage"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+//--------- TarPackage ------------
+
+//TarPackage encapsulates a chaincode package in the tar format, which
+//carries artifacts besides the code (see ccpackage.CreateTarPackage).
+//The data stored in the LSCC for it is a CDSData, while its id is the
+//hash of the whole package
+type TarPackage struct {
+	buf      []byte
+	metadata *ccpackage.PackageMetadata
+	depSpec  *pb.ChaincodeDeploymentSpec
+	depSpecb []byte
+	data     *CDSData
+	datab    []byte
+	id       []byte
+}
+
+// resets data
+func (ccpack *TarPackage) reset() {
+	*ccpack = TarPackage{}
+}
+
+// GetId gets the fingerprint of the chaincode, the hash of the package
+func (ccpack *TarPackage) GetId() []byte {
+	//this has to be after creating a package and initializing it
+	//If those steps fail, GetId() should never be called
+	if ccpack.id == nil {
+		panic("GetId called on uninitialized package")
+	}
+	return ccpack.id
+}
+
+// GetPackageID returns the identifier of the package given to humans,
+// made of its label and its hash
+func (ccpack *TarPackage) GetPackageID() string {
+	return ccpack.GetMetadata().Label + ":" + hex.EncodeToString(ccpack.GetId())
+}
+
+// GetMetadata gets the metadata describing the chaincode of the package
+func (ccpack *TarPackage) GetMetadata() *ccpackage.PackageMetadata {
+	if ccpack.metadata == nil {
+		panic("GetMetadata called on uninitialized package")
+	}
+	return ccpack.metadata
+}
+
+// GetDepSpec gets the ChaincodeDeploymentSpec built from the package
+func (ccpack *TarPackage) GetDepSpec() *pb.ChaincodeDeploymentSpec {
+	//this has to be after creating a package and initializing it
+	//If those steps fail, GetDepSpec() should never be called
+	if ccpack.depSpec == nil {
+		panic("GetDepSpec called on uninitialized package")
+	}
+	return ccpack.depSpec
+}
+
+// GetDepSpecBytes gets the serialized ChaincodeDeploymentSpec built from the package
+func (ccpack *TarPackage) GetDepSpecBytes() []byte {
+	//this has to be after creating a package and initializing it
+	//If those steps fail, GetDepSpecBytes() should never be called
+	if ccpack.depSpecb == nil {
+		panic("GetDepSpecBytes called on uninitialized package")
+	}
+	return ccpack.depSpecb
+}
+
+// GetPackageObject gets the ChaincodeDeploymentSpec as proto.Message, the
+// package itself not being a proto.Message
+func (ccpack *TarPackage) GetPackageObject() proto.Message {
+	return ccpack.depSpec
+}
+
+// GetPackageBytes gets the bytes of the package
+func (ccpack *TarPackage) GetPackageBytes() []byte {
+	return ccpack.buf
+}
+
+// GetChaincodeData gets the ChaincodeData
+func (ccpack *TarPackage) GetChaincodeData() *ChaincodeData {
+	//this has to be after creating a package and initializing it
+	//If those steps fail, GetChaincodeData() should never be called
+	if ccpack.depSpec == nil || ccpack.datab == nil || ccpack.id == nil {
+		panic("GetChaincodeData called on uninitialized package")
+	}
+	return &ChaincodeData{Name: ccpack.depSpec.ChaincodeSpec.ChaincodeId.Name, Version: ccpack.depSpec.ChaincodeSpec.ChaincodeId.Version, Data: ccpack.datab, Id: ccpack.id}
+}
+
+func (ccpack *TarPackage) getTarData(buf []byte, cds *pb.ChaincodeDeploymentSpec) ([]byte, []byte, *CDSData, error) {
+	if err := factory.InitFactories(nil); err != nil {
+		return nil, nil, nil, fmt.Errorf("Internal error, BCCSP could not be initialized : %s", err)
+	}
+
+	//compute hashes now
+	hash, err := factory.GetDefault().GetHash(&bccsp.SHAOpts{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	data := &CDSData{}
+
+	//code hash
+	hash.Write(cds.CodePackage)
+	data.CodeHash = hash.Sum(nil)
+
+	hash.Reset()
+
+	//metadata hash
+	hash.Write([]byte(cds.ChaincodeSpec.ChaincodeId.Name))
+	hash.Write([]byte(cds.ChaincodeSpec.ChaincodeId.Version))
+
+	data.MetaDataHash = hash.Sum(nil)
+
+	b, err := proto.Marshal(data)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	hash.Reset()
+
+	//the id covers the artifacts too
+	hash.Write(buf)
+
+	id := hash.Sum(nil)
+
+	return b, id, data, nil
+}
+
+// ValidateCC returns error if the chaincode data does not match the package
+func (ccpack *TarPackage) ValidateCC(ccdata *ChaincodeData) error {
+	if ccpack.depSpec == nil {
+		return fmt.Errorf("uninitialized package")
+	}
+
+	if ccpack.data == nil {
+		return fmt.Errorf("nil data")
+	}
+
+	if ccdata.Name != ccpack.depSpec.ChaincodeSpec.ChaincodeId.Name || ccdata.Version != ccpack.depSpec.ChaincodeSpec.ChaincodeId.Version {
+		return fmt.Errorf("invalid chaincode data %v (%v)", ccdata, ccpack.depSpec.ChaincodeSpec.ChaincodeId)
+	}
+
+	otherdata := &CDSData{}
+	err := proto.Unmarshal(ccdata.Data, otherdata)
+	if err != nil {
+		return err
+	}
+
+	if !ccpack.data.Equals(otherdata) || !bytes.Equal(ccpack.id, ccdata.Id) {
+		return fmt.Errorf("data mismatch")
+	}
+
+	return nil
+}
+
+//InitFromBuffer sets the buffer if valid and returns ChaincodeData
+func (ccpack *TarPackage) InitFromBuffer(buf []byte) (*ChaincodeData, error) {
+	//incase ccpack is reused
+	ccpack.reset()
+
+	md, code, err := ccpackage.ParseTarPackage(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	depSpec := &pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value[strings.ToUpper(md.Type)]),
+			ChaincodeId: &pb.ChaincodeID{Name: md.Name, Version: md.Version, Path: md.Path},
+		},
+		CodePackage: code,
+	}
+	depSpecb, err := proto.Marshal(depSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	databytes, id, data, err := ccpack.getTarData(buf, depSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	ccpack.buf = buf
+	ccpack.metadata = md
+	ccpack.depSpec = depSpec
+	ccpack.depSpecb = depSpecb
+	ccpack.data = data
+	ccpack.datab = databytes
+	ccpack.id = id
+
+	return ccpack.GetChaincodeData(), nil
+}
+
+//InitFromFS returns the chaincode and its package from the file system
+func (ccpack *TarPackage) InitFromFS(ccname string, ccversion string) ([]byte, *pb.ChaincodeDeploymentSpec, error) {
+	//incase ccpack is reused
+	ccpack.reset()
+
+	buf, err := GetChaincodePackage(ccname, ccversion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err = ccpack.InitFromBuffer(buf); err != nil {
+		return nil, nil, err
+	}
+
+	return ccpack.buf, ccpack.depSpec, nil
+}
+
+//PutChaincodeToFS - writes the package as is to the file system
+func (ccpack *TarPackage) PutChaincodeToFS() error {
+	if ccpack.buf == nil {
+		return fmt.Errorf("uninitialized package")
+	}
+
+	if ccpack.id == nil {
+		return fmt.Errorf("id cannot be nil if buf is not nil")
+	}
+
+	if ccpack.depSpec == nil {
+		return fmt.Errorf("depspec cannot be nil if buf is not nil")
+	}
+
+	if ccpack.data == nil {
+		return fmt.Errorf("nil data")
+	}
+
+	ccname := ccpack.depSpec.ChaincodeSpec.ChaincodeId.Name
+	ccversion := ccpack.depSpec.ChaincodeSpec.ChaincodeId.Version
+
+	//return error if chaincode exists
+	path := fmt.Sprintf("%s/%s.%s", chaincodeInstallPath, ccname, ccversion)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("chaincode %s exists", path)
+	}
+
+	if err := ioutil.WriteFile(path, ccpack.buf, 0644); err != nil {
+		return err
+	}
+
+	return nil
+}
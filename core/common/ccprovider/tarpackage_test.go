@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ccprovider
+
+import (
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/common/ccpackage"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTarPackageBytes(t *testing.T) []byte {
+	md := &ccpackage.PackageMetadata{Label: "testcc_0", Type: "golang", Path: "github.com/testcc", Name: "testcc", Version: "0"}
+	b, err := ccpackage.CreateTarPackage(md, []byte("code"), map[string][]byte{
+		ccpackage.CollectionsFile:                   []byte("collections"),
+		ccpackage.MetaInfDir + "statedb/index.json": []byte("{}"),
+	})
+	assert.NoError(t, err)
+	return b
+}
+
+func TestPutTarPackage(t *testing.T) {
+	ccdir := setupccdir()
+	defer os.RemoveAll(ccdir)
+
+	b := getTarPackageBytes(t)
+
+	ccpack, err := GetCCPackage(b)
+	assert.NoError(t, err)
+	tarpack, ok := ccpack.(*TarPackage)
+	assert.True(t, ok, "expected a tar package")
+
+	cd := tarpack.GetChaincodeData()
+	assert.Equal(t, "testcc", cd.Name)
+	assert.Equal(t, "0", cd.Version)
+	assert.Equal(t, "testcc_0:"+hex.EncodeToString(tarpack.GetId()), tarpack.GetPackageID())
+	assert.Equal(t, []byte("code"), tarpack.GetDepSpec().CodePackage)
+	assert.Equal(t, "github.com/testcc", tarpack.GetDepSpec().ChaincodeSpec.ChaincodeId.Path)
+
+	assert.NoError(t, tarpack.PutChaincodeToFS())
+	assert.Error(t, tarpack.PutChaincodeToFS(), "package should not be installed twice")
+
+	ccpack, err = GetChaincodeFromFS("testcc", "0")
+	assert.NoError(t, err)
+	assert.Equal(t, tarpack.GetId(), ccpack.GetId())
+	assert.NoError(t, ccpack.ValidateCC(cd))
+
+	cd.Id = []byte("otherid")
+	assert.Error(t, ccpack.ValidateCC(cd), "package with another id should not validate")
+}
+
+func TestGetCCPackageErrors(t *testing.T) {
+	b := getTarPackageBytes(t)
+
+	_, err := GetCCPackage(b[:len(b)/2])
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "tar package", "a truncated tar package should report the tar error")
+
+	_, err = GetCCPackage([]byte("not a package"))
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "tar package", "other packages should not report the tar error")
+}
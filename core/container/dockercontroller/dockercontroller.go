@@ -29,6 +29,7 @@ import (
 
 	"github.com/fsouza/go-dockerclient"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/limits"
 	container "github.com/hyperledger/fabric/core/container/api"
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	cutil "github.com/hyperledger/fabric/core/container/util"
@@ -74,6 +75,12 @@ type dockerClient interface {
 	KillContainer(opts docker.KillContainerOptions) error
 	// RemoveContainer removes a docker container, returns an error in case of failure
 	RemoveContainer(opts docker.RemoveContainerOptions) error
+	// WaitContainer blocks until a docker container stops, returns its exit
+	// code or an error in case of failure
+	WaitContainer(id string) (int, error)
+	// InspectContainer returns information about a docker container, returns
+	// an error in case of failure
+	InspectContainer(id string) (*docker.Container, error)
 }
 
 // NewDockerVM returns a new DockerVM instance
@@ -147,11 +154,36 @@ func getDockerHostConfig() *docker.HostConfig {
 	return hostConfig
 }
 
+// getChaincodeHostConfig returns the HostConfig of the container of the
+// given chaincode, i.e. the peer-wide one with the resource limits
+// configured for the chaincode applied on top of it
+func getChaincodeHostConfig(ccname string) *docker.HostConfig {
+	hc := *getDockerHostConfig()
+
+	l := limits.GetLimits(ccname)
+	if l.Memory > 0 {
+		hc.Memory = l.Memory
+	}
+	if l.CPUShares > 0 {
+		hc.CPUShares = l.CPUShares
+	}
+	if l.CPUQuota > 0 {
+		hc.CPUQuota = l.CPUQuota
+	}
+	if l.CPUPeriod > 0 {
+		hc.CPUPeriod = l.CPUPeriod
+	}
+	dockerLogger.Debugf("container for chaincode %s limited to memory %d, cpu shares %d, cpu quota %d/%d",
+		ccname, hc.Memory, hc.CPUShares, hc.CPUQuota, hc.CPUPeriod)
+
+	return &hc
+}
+
 func (vm *DockerVM) createContainer(ctxt context.Context, client dockerClient,
 	imageID string, containerID string, args []string,
-	env []string, attachStdout bool) error {
+	env []string, attachStdout bool, hostConfig *docker.HostConfig) error {
 	config := docker.Config{Cmd: args, Image: imageID, Env: env, AttachStdout: attachStdout, AttachStderr: attachStdout}
-	copts := docker.CreateContainerOptions{Name: containerID, Config: &config, HostConfig: getDockerHostConfig()}
+	copts := docker.CreateContainerOptions{Name: containerID, Config: &config, HostConfig: hostConfig}
 	dockerLogger.Debugf("Create container: %s", containerID)
 	_, err := client.CreateContainer(copts)
 	if err != nil {
@@ -221,13 +253,14 @@ func (vm *DockerVM) Start(ctxt context.Context, ccid ccintf.CCID,
 
 	containerID := strings.Replace(imageID, ":", "_", -1)
 	attachStdout := viper.GetBool("vm.docker.attachStdout")
+	hostConfig := getChaincodeHostConfig(ccid.ChaincodeSpec.ChaincodeId.Name)
 
 	//stop,force remove if necessary
 	dockerLogger.Debugf("Cleanup container %s", containerID)
 	vm.stopInternal(ctxt, client, containerID, 0, false, false)
 
 	dockerLogger.Debugf("Start container %s", containerID)
-	err = vm.createContainer(ctxt, client, imageID, containerID, args, env, attachStdout)
+	err = vm.createContainer(ctxt, client, imageID, containerID, args, env, attachStdout, hostConfig)
 	if err != nil {
 		//if image not found try to create image and retry
 		if err == docker.ErrNoSuchImage {
@@ -246,7 +279,7 @@ func (vm *DockerVM) Start(ctxt context.Context, ccid ccintf.CCID,
 				}
 
 				dockerLogger.Debug("start-recreated image successfully")
-				if err1 = vm.createContainer(ctxt, client, imageID, containerID, args, env, attachStdout); err1 != nil {
+				if err1 = vm.createContainer(ctxt, client, imageID, containerID, args, env, attachStdout, hostConfig); err1 != nil {
 					dockerLogger.Errorf("start-could not recreate container post recreate image: %s", err1)
 					return err1
 				}
@@ -336,6 +369,9 @@ func (vm *DockerVM) Start(ctxt context.Context, ccid ccintf.CCID,
 		}
 	}
 
+	ccname := ccid.ChaincodeSpec.ChaincodeId.Name
+	limits.SetOOMKilled(ccname, ccid.Version, false)
+
 	// start container with HostConfig was deprecated since v1.10 and removed in v1.2
 	err = client.StartContainer(containerID, nil)
 	if err != nil {
@@ -343,10 +379,34 @@ func (vm *DockerVM) Start(ctxt context.Context, ccid ccintf.CCID,
 		return err
 	}
 
+	go watchContainer(client, containerID, ccname, ccid.Version)
+
 	dockerLogger.Debugf("Started container %s", containerID)
 	return nil
 }
 
+// watchContainer waits for the container of a chaincode to stop and
+// records whether it was killed for exceeding its memory limit, so that
+// the transactions it was executing fail with a meaningful error
+func watchContainer(client dockerClient, containerID, ccname, version string) {
+	if _, err := client.WaitContainer(containerID); err != nil {
+		dockerLogger.Debugf("Could not wait for container %s (%s)", containerID, err)
+		return
+	}
+
+	c, err := client.InspectContainer(containerID)
+	if err != nil {
+		// the container was removed after being stopped
+		dockerLogger.Debugf("Could not inspect container %s (%s)", containerID, err)
+		return
+	}
+
+	if c.State.OOMKilled {
+		dockerLogger.Errorf("Container %s was killed for exceeding its memory limit", containerID)
+		limits.SetOOMKilled(ccname, version, true)
+	}
+}
+
 //Stop stops a running chaincode
 func (vm *DockerVM) Stop(ctxt context.Context, ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
 	id, err := vm.GetVMName(ccid)
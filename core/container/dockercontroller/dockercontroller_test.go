@@ -34,6 +34,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/limits"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	coreutil "github.com/hyperledger/fabric/core/testutil"
@@ -67,6 +68,21 @@ func TestGetDockerHostConfig(t *testing.T) {
 	testutil.AssertEquals(t, hostConfig.CPUShares, int64(1024*1024*1024*2))
 }
 
+func TestGetChaincodeHostConfig(t *testing.T) {
+	coreutil.SetupTestConfig()
+	viper.Set("chaincode.limits.cpuQuota", 50000)
+	viper.Set("chaincode.limits.overrides.mycc.memory", 256*1024*1024)
+	defer viper.Set("chaincode.limits", nil)
+
+	hostConfig := getChaincodeHostConfig("mycc")
+	testutil.AssertEquals(t, hostConfig.Memory, int64(256*1024*1024))
+	testutil.AssertEquals(t, hostConfig.CPUQuota, int64(50000))
+
+	hostConfig = getChaincodeHostConfig("othercc")
+	testutil.AssertEquals(t, hostConfig.Memory, getDockerHostConfig().Memory)
+	testutil.AssertEquals(t, hostConfig.CPUQuota, int64(50000))
+}
+
 func Test_Deploy(t *testing.T) {
 	dvm := DockerVM{}
 	ccid := ccintf.CCID{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "simple"}}}
@@ -185,6 +201,28 @@ func Test_Start(t *testing.T) {
 	testerr(t, err, false)
 }
 
+func TestWatchContainer(t *testing.T) {
+	client := &mockClient{}
+
+	watchContainer(client, "container", "mycc", "1.0")
+	assert.False(t, limits.OOMKilled("mycc", "1.0"))
+
+	oomKilled = true
+	defer func() { oomKilled = false }()
+	watchContainer(client, "container", "mycc", "1.0")
+	assert.True(t, limits.OOMKilled("mycc", "1.0"))
+	assert.False(t, limits.OOMKilled("mycc", "2.0"))
+
+	// a restarted container starts afresh
+	noSuchImgErr, createErr, startErr = false, false, false
+	dvm := DockerVM{getClientFnc: getMockClient}
+	ccid := ccintf.CCID{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc"}}, Version: "1.0"}
+	oomKilled = false
+	err := dvm.Start(context.Background(), ccid, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.False(t, limits.OOMKilled("mycc", "1.0"))
+}
+
 func Test_Stop(t *testing.T) {
 	dvm := DockerVM{}
 	ccid := ccintf.CCID{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "simple"}}}
@@ -262,7 +300,7 @@ type mockClient struct {
 }
 
 var getClientErr, createErr, noSuchImgErr, buildErr, removeImgErr,
-	startErr, stopErr, killErr, removeErr bool
+	startErr, stopErr, killErr, removeErr, oomKilled bool
 
 func (c *mockClient) CreateContainer(options docker.CreateContainerOptions) (*docker.Container, error) {
 	if createErr {
@@ -322,3 +360,11 @@ func (c *mockClient) RemoveContainer(opts docker.RemoveContainerOptions) error {
 	}
 	return nil
 }
+
+func (c *mockClient) WaitContainer(id string) (int, error) {
+	return 0, nil
+}
+
+func (c *mockClient) InspectContainer(id string) (*docker.Container, error) {
+	return &docker.Container{State: docker.State{OOMKilled: oomKilled}}, nil
+}
@@ -100,9 +100,23 @@ type blocksProviderImpl struct {
 	done int32
 
 	wrongStatusThreshold int
+
+	// failedStatusThreshold is the number of consecutive statuses of any
+	// kind the ordering service may reply with before the provider gives up
+	failedStatusThreshold int
+
+	// ledgerInfo and stallTimeout, if set, detect a stream that delivers
+	// no blocks for stallTimeout while peers of the channel have blocks
+	// this peer doesn't, after which the provider gives up
+	ledgerInfo   LedgerInfo
+	stallTimeout time.Duration
+	lastProgress int64
 }
 
-const wrongStatusThreshold = 10
+const (
+	wrongStatusThreshold  = 10
+	failedStatusThreshold = 20
+)
 
 var MaxRetryDelay = time.Second * 10
 
@@ -112,14 +126,19 @@ func init() {
 	logger = flogging.MustGetLogger("blocksProvider")
 }
 
-// NewBlocksProvider constructor function to create blocks deliverer instance
-func NewBlocksProvider(chainID string, client streamClient, gossip GossipServiceAdapter, mcs api.MessageCryptoService) BlocksProvider {
+// NewBlocksProvider constructor function to create blocks deliverer instance.
+// A stallTimeout of zero disables the detection of stalled streams
+func NewBlocksProvider(chainID string, client streamClient, gossip GossipServiceAdapter, mcs api.MessageCryptoService,
+	ledgerInfo LedgerInfo, stallTimeout time.Duration) BlocksProvider {
 	return &blocksProviderImpl{
-		chainID:              chainID,
-		client:               client,
-		gossip:               gossip,
-		mcs:                  mcs,
-		wrongStatusThreshold: wrongStatusThreshold,
+		chainID:               chainID,
+		client:                client,
+		gossip:                gossip,
+		mcs:                   mcs,
+		wrongStatusThreshold:  wrongStatusThreshold,
+		failedStatusThreshold: failedStatusThreshold,
+		ledgerInfo:            ledgerInfo,
+		stallTimeout:          stallTimeout,
 	}
 }
 
@@ -127,8 +146,15 @@ func NewBlocksProvider(chainID string, client streamClient, gossip GossipService
 // distributed them across peers
 func (b *blocksProviderImpl) DeliverBlocks() {
 	errorStatusCounter := 0
+	failedStatusCounter := 0
 	statusCounter := 0
 	defer b.client.Close()
+	if b.stallTimeout > 0 && b.ledgerInfo != nil {
+		b.progress()
+		stop := make(chan struct{})
+		defer close(stop)
+		go b.detectStall(stop)
+	}
 	for !b.isDone() {
 		msg, err := b.client.Recv()
 		if err != nil {
@@ -152,6 +178,12 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 				errorStatusCounter = 0
 				logger.Warningf("[%s] Got error %v", b.chainID, t)
 			}
+			failedStatusCounter++
+			if b.failedStatusThreshold > 0 && failedStatusCounter > b.failedStatusThreshold {
+				logger.Criticalf("[%s] The ordering service failed to deliver blocks %d times in a row, stopping block provider",
+					b.chainID, failedStatusCounter)
+				return
+			}
 			maxDelay := float64(MaxRetryDelay)
 			currDelay := float64(time.Duration(math.Pow(2, float64(statusCounter))) * 100 * time.Millisecond)
 			time.Sleep(time.Duration(math.Min(maxDelay, currDelay)))
@@ -162,7 +194,9 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 			continue
 		case *orderer.DeliverResponse_Block:
 			errorStatusCounter = 0
+			failedStatusCounter = 0
 			statusCounter = 0
+			b.progress()
 			seqNum := t.Block.Header.Number
 
 			marshaledBlock, err := proto.Marshal(t.Block)
@@ -195,6 +229,42 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 	}
 }
 
+// progress records that the ordering service made progress
+func (b *blocksProviderImpl) progress() {
+	atomic.StoreInt64(&b.lastProgress, time.Now().UnixNano())
+}
+
+// detectStall closes the stream once it delivers no blocks for the
+// stall timeout while peers of the channel are ahead of this peer,
+// which makes DeliverBlocks give up, until stop is closed
+func (b *blocksProviderImpl) detectStall(stop <-chan struct{}) {
+	ticker := time.NewTicker(b.stallTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		if time.Since(time.Unix(0, atomic.LoadInt64(&b.lastProgress))) < b.stallTimeout {
+			continue
+		}
+		height, err := b.ledgerInfo.LedgerHeight()
+		if err != nil {
+			logger.Warningf("[%s] Failed getting the ledger height: %s", b.chainID, err)
+			continue
+		}
+		for _, peer := range b.gossip.PeersOfChannel(gossipcommon.ChainID(b.chainID)) {
+			if peer.Properties != nil && peer.Properties.LedgerHeight > height {
+				logger.Criticalf("[%s] No blocks received from the ordering service for %s while peer %s has a ledger height of %d, ours is %d; stopping block provider",
+					b.chainID, b.stallTimeout, peer.Endpoint, peer.Properties.LedgerHeight, height)
+				b.client.Close()
+				return
+			}
+		}
+	}
+}
+
 // Stop stops blocks delivery provider
 func (b *blocksProviderImpl) Stop() {
 	atomic.StoreInt32(&b.done, 1)
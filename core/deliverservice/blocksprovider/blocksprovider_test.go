@@ -25,7 +25,9 @@ import (
 	"github.com/hyperledger/fabric/core/deliverservice/mocks"
 	"github.com/hyperledger/fabric/gossip/api"
 	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/protos/common"
+	gossip_proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -67,6 +69,11 @@ func (*mockMCS) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
 	return nil
 }
 
+// Expiration returns the expiration time of the identity
+func (*mockMCS) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Now().Add(time.Hour), nil
+}
+
 type rcvFunc func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error)
 
 // Used to generate a simple test case to initialize delivery
@@ -76,7 +83,7 @@ func makeTestCase(ledgerHeight uint64, mcs api.MessageCryptoService, shouldSucce
 		gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64)}
 		deliverer := &mocks.MockBlocksDeliverer{Pos: ledgerHeight}
 		deliverer.MockRecv = rcv
-		provider := NewBlocksProvider("***TEST_CHAINID***", deliverer, gossipServiceAdapter, mcs)
+		provider := NewBlocksProvider("***TEST_CHAINID***", deliverer, gossipServiceAdapter, mcs, &mocks.MockLedgerInfo{Height: ledgerHeight}, 0)
 		defer provider.Stop()
 		ready := make(chan struct{})
 		go func() {
@@ -348,6 +355,63 @@ func TestBlocksProvider_DeliveryWrongStatusClose(t *testing.T) {
 	})
 }
 
+func TestBlocksProvider_DeliveryFailedStatusesClose(t *testing.T) {
+	// Scenario: the ordering service keeps replying with statuses
+	// that aren't BAD_REQUEST or FORBIDDEN.
+	// Expected outcome: the provider gives up once the failed
+	// statuses threshold is passed
+	bd := mocks.MockBlocksDeliverer{DisconnectCalled: make(chan struct{}, 10), CloseCalled: make(chan struct{}, 1)}
+	provider := &blocksProviderImpl{
+		chainID:               "***TEST_CHAINID***",
+		gossip:                &mocks.MockGossipServiceAdapter{},
+		client:                &bd,
+		mcs:                   &mockMCS{},
+		wrongStatusThreshold:  wrongStatusThreshold,
+		failedStatusThreshold: 3,
+	}
+	bd.MockRecv = func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+		return &orderer.DeliverResponse{
+			Type: &orderer.DeliverResponse_Status{
+				Status: common.Status_SERVICE_UNAVAILABLE,
+			},
+		}, nil
+	}
+
+	go provider.DeliverBlocks()
+	waitUntilOrFail(t, func() bool {
+		return len(bd.CloseCalled) == 1
+	})
+	assert.Len(t, bd.DisconnectCalled, 3)
+}
+
+func TestBlocksProvider_StalledStream(t *testing.T) {
+	// Scenario: the ordering service delivers no blocks, first while the peers
+	// of the channel are at the same height as we are, then while they are ahead.
+	// Expected outcome: the provider gives up only once the peers are ahead
+	bd := mocks.MockBlocksDeliverer{CloseCalled: make(chan struct{}, 2)}
+	stop := make(chan struct{})
+	defer close(stop)
+	bd.MockRecv = func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+		<-stop
+		return nil, errors.New("Stopped")
+	}
+	ledgerInfo := &mocks.MockLedgerInfo{Height: 6}
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{
+		Peers: []discovery.NetworkMember{{Endpoint: "p1", Properties: &gossip_proto.Properties{LedgerHeight: 6}}},
+	}
+	provider := NewBlocksProvider("***TEST_CHAINID***", &bd, gossipServiceAdapter, &mockMCS{}, ledgerInfo, time.Millisecond*100)
+	defer provider.Stop()
+
+	go provider.DeliverBlocks()
+	time.Sleep(time.Millisecond * 300)
+	assert.Len(t, bd.CloseCalled, 0)
+
+	atomic.StoreUint64(&ledgerInfo.Height, 5)
+	waitUntilOrFail(t, func() bool {
+		return len(bd.CloseCalled) == 1
+	})
+}
+
 func TestBlockFetchFailure(t *testing.T) {
 	rcvr := func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
 		return nil, errors.New("Failed fetching block")
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/op/go-logging"
+	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 )
 
@@ -28,10 +29,14 @@ func init() {
 	logger = flogging.MustGetLogger("deliveryClient")
 }
 
+const (
+	defaultReConnectTotalTimeThreshold = time.Second * 60 * 5
+	defaultStallTimeout                = time.Second * 60
+)
+
 var (
-	reConnectTotalTimeThreshold = time.Second * 60 * 5
-	connTimeout                 = time.Second * 3
-	reConnectBackoffThreshold   = float64(time.Hour)
+	connTimeout               = time.Second * 3
+	reConnectBackoffThreshold = float64(time.Hour)
 )
 
 // DeliverService used to communicate with orderers to obtain
@@ -39,7 +44,9 @@ var (
 type DeliverService interface {
 	// StartDeliverForChannel dynamically starts delivery of new blocks from ordering service
 	// to channel peers.
-	StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo) error
+	// When the delivery ends, not due to a StopDeliverForChannel invocation,
+	// the finalizer func is called
+	StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, finalizer func()) error
 
 	// StopDeliverForChannel dynamically stops delivery of new blocks from ordering service
 	// to channel peers.
@@ -116,8 +123,11 @@ func (d *deliverServiceImpl) validateConfiguration() error {
 // StartDeliverForChannel starts blocks delivery for channel
 // initializes the grpc stream for given chainID, creates blocks provider instance
 // that spawns in go routine to read new blocks starting from the position provided by ledger
-// info instance.
-func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo) error {
+// info instance. Once the blocks provider gives up on the ordering service,
+// because it can't reconnect, keeps replying with failure statuses or
+// delivers no blocks while peers of the channel are ahead, the finalizer
+// is invoked.
+func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, finalizer func()) error {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 	if d.stopping {
@@ -132,12 +142,26 @@ func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo b
 	} else {
 		client := d.newClient(chainID, ledgerInfo)
 		logger.Debug("This peer will pass blocks from orderer service to other peers for channel", chainID)
-		d.blockProviders[chainID] = blocksprovider.NewBlocksProvider(chainID, client, d.conf.Gossip, d.conf.CryptoSvc)
-		go d.blockProviders[chainID].DeliverBlocks()
+		provider := blocksprovider.NewBlocksProvider(chainID, client, d.conf.Gossip, d.conf.CryptoSvc, ledgerInfo, getStallTimeout())
+		d.blockProviders[chainID] = provider
+		go func() {
+			provider.DeliverBlocks()
+			if d.isDeliveringFor(chainID, provider) {
+				finalizer()
+			}
+		}()
 	}
 	return nil
 }
 
+// isDeliveringFor returns whether the given blocks provider is still
+// the active one for the channel, i.e. it wasn't stopped
+func (d *deliverServiceImpl) isDeliveringFor(chainID string, provider blocksprovider.BlocksProvider) bool {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return !d.stopping && d.blockProviders[chainID] == provider
+}
+
 // StopDeliverForChannel stops blocks delivery for channel by stopping channel block provider
 func (d *deliverServiceImpl) StopDeliverForChannel(chainID string) error {
 	d.lock.Lock()
@@ -178,6 +202,7 @@ func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocks
 	broadcastSetup := func(bd blocksprovider.BlocksDeliverer) error {
 		return requester.RequestBlocks(ledgerInfoProvider)
 	}
+	reConnectTotalTimeThreshold := getReConnectTotalTimeThreshold()
 	backoffPolicy := func(attemptNum int, elapsedTime time.Duration) (time.Duration, bool) {
 		if elapsedTime.Nanoseconds() > reConnectTotalTimeThreshold.Nanoseconds() {
 			return 0, false
@@ -218,3 +243,28 @@ func DefaultConnectionFactory(channelID string) func(endpoint string) (*grpc.Cli
 func DefaultABCFactory(conn *grpc.ClientConn) orderer.AtomicBroadcastClient {
 	return orderer.NewAtomicBroadcastClient(conn)
 }
+
+// getReConnectTotalTimeThreshold returns the total time the delivery client
+// attempts to reconnect to the ordering service before giving up
+func getReConnectTotalTimeThreshold() time.Duration {
+	key := "peer.deliveryclient.reconnectTotalTimeThreshold"
+	if viper.IsSet(key) {
+		if threshold := viper.GetDuration(key); threshold > 0 {
+			return threshold
+		}
+	}
+	return defaultReConnectTotalTimeThreshold
+}
+
+// getStallTimeout returns the time the delivery client waits for blocks
+// from the ordering service while peers of the channel are ahead of it,
+// before giving up
+func getStallTimeout() time.Duration {
+	key := "peer.deliveryclient.stallTimeout"
+	if viper.IsSet(key) {
+		if timeout := viper.GetDuration(key); timeout > 0 {
+			return timeout
+		}
+	}
+	return defaultStallTimeout
+}
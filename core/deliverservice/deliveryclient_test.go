@@ -32,6 +32,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 )
@@ -83,6 +84,11 @@ func (*mockMCS) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
 	return nil
 }
 
+// Expiration returns the expiration time of the identity
+func (*mockMCS) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Now().Add(time.Hour), nil
+}
+
 func TestNewDeliverService(t *testing.T) {
 	defer ensureNoGoroutineLeak(t)()
 	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64, 1)}
@@ -113,10 +119,10 @@ func TestNewDeliverService(t *testing.T) {
 		ConnFactory: connFactory,
 	})
 	assert.NoError(t, err)
-	assert.NoError(t, service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{0}))
+	assert.NoError(t, service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{0}, func() {}))
 
 	// Lets start deliver twice
-	assert.Error(t, service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{0}), "can't start delivery")
+	assert.Error(t, service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{0}, func() {}), "can't start delivery")
 	// Lets stop deliver that not started
 	assert.Error(t, service.StopDeliverForChannel("TEST_CHAINID2"), "can't stop delivery")
 
@@ -130,7 +136,7 @@ func TestNewDeliverService(t *testing.T) {
 	assert.Equal(t, 0, connNumber)
 	assertBlockDissemination(0, gossipServiceAdapter.GossipBlockDisseminations, t)
 	assert.Equal(t, atomic.LoadInt32(&blocksDeliverer.RecvCnt), atomic.LoadInt32(&gossipServiceAdapter.AddPayloadsCnt))
-	assert.Error(t, service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{0}), "Delivery service is stopping")
+	assert.Error(t, service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{0}, func() {}), "Delivery service is stopping")
 	assert.Error(t, service.StopDeliverForChannel("TEST_CHAINID"), "Delivery service is stopping")
 }
 
@@ -157,7 +163,7 @@ func TestDeliverServiceRestart(t *testing.T) {
 	li := &mocks.MockLedgerInfo{Height: uint64(100)}
 	os.SetNextExpectedSeek(uint64(100))
 
-	err = service.StartDeliverForChannel("TEST_CHAINID", li)
+	err = service.StartDeliverForChannel("TEST_CHAINID", li, func() {})
 	assert.NoError(t, err, "can't start delivery")
 	// Check that delivery client requests blocks in order
 	go os.SendBlock(uint64(100))
@@ -203,7 +209,7 @@ func TestDeliverServiceFailover(t *testing.T) {
 	os1.SetNextExpectedSeek(uint64(100))
 	os2.SetNextExpectedSeek(uint64(100))
 
-	err = service.StartDeliverForChannel("TEST_CHAINID", li)
+	err = service.StartDeliverForChannel("TEST_CHAINID", li, func() {})
 	assert.NoError(t, err, "can't start delivery")
 	// We need to discover to which instance the client connected to
 	go os1.SendBlock(uint64(100))
@@ -278,7 +284,7 @@ func TestDeliverServiceServiceUnavailable(t *testing.T) {
 	os1.SetNextExpectedSeek(li.Height)
 	os2.SetNextExpectedSeek(li.Height)
 
-	err = service.StartDeliverForChannel("TEST_CHAINID", li)
+	err = service.StartDeliverForChannel("TEST_CHAINID", li, func() {})
 	assert.NoError(t, err, "can't start delivery")
 
 	waitForConnectionToSomeOSN := func() (*mocks.Orderer, *mocks.Orderer) {
@@ -367,7 +373,7 @@ func TestDeliverServiceShutdown(t *testing.T) {
 
 	li := &mocks.MockLedgerInfo{Height: uint64(100)}
 	os.SetNextExpectedSeek(uint64(100))
-	err = service.StartDeliverForChannel("TEST_CHAINID", li)
+	err = service.StartDeliverForChannel("TEST_CHAINID", li, func() {})
 	assert.NoError(t, err, "can't start delivery")
 
 	// Check that delivery service requests blocks in order
@@ -389,6 +395,49 @@ func TestDeliverServiceShutdown(t *testing.T) {
 	time.Sleep(time.Second)
 }
 
+func TestDeliverServiceFinalizer(t *testing.T) {
+	// Scenario: the ordering service is unreachable, and the delivery client
+	// gives up reconnecting after the configured threshold.
+	// The finalizer is expected to be invoked, but only for deliveries that
+	// were not stopped explicitly
+	viper.Set("peer.deliveryclient.reconnectTotalTimeThreshold", time.Second)
+	defer viper.Set("peer.deliveryclient.reconnectTotalTimeThreshold", nil)
+
+	service, err := NewDeliverService(&Config{
+		Endpoints:   []string{"localhost:5617"},
+		Gossip:      &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64)},
+		CryptoSvc:   &mockMCS{},
+		ABCFactory:  DefaultABCFactory,
+		ConnFactory: DefaultConnectionFactory,
+	})
+	assert.NoError(t, err)
+	defer service.Stop()
+
+	finalized := make(chan struct{}, 1)
+	err = service.StartDeliverForChannel("TEST_CHAINID", &mocks.MockLedgerInfo{Height: 100}, func() {
+		finalized <- struct{}{}
+	})
+	assert.NoError(t, err)
+	select {
+	case <-finalized:
+	case <-time.After(time.Second * 20):
+		assert.Fail(t, "Finalizer wasn't invoked")
+	}
+	assert.NoError(t, service.StopDeliverForChannel("TEST_CHAINID"))
+
+	// Stop the delivery explicitly, the finalizer shouldn't be invoked
+	err = service.StartDeliverForChannel("TEST_CHAINID2", &mocks.MockLedgerInfo{Height: 100}, func() {
+		finalized <- struct{}{}
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, service.StopDeliverForChannel("TEST_CHAINID2"))
+	select {
+	case <-finalized:
+		assert.Fail(t, "Finalizer was invoked although delivery was stopped")
+	case <-time.After(time.Second * 5):
+	}
+}
+
 func TestDeliverServiceBadConfig(t *testing.T) {
 	// Empty endpoints
 	service, err := NewDeliverService(&Config{
@@ -37,6 +37,9 @@ type MockGossipServiceAdapter struct {
 	AddPayloadsCnt int32
 
 	GossipBlockDisseminations chan uint64
+
+	// Peers are the members of the channel
+	Peers []discovery.NetworkMember
 }
 
 type MockAtomicBroadcastClient struct {
@@ -51,8 +54,8 @@ func (mabc *MockAtomicBroadcastClient) Deliver(ctx context.Context, opts ...grpc
 }
 
 // PeersOfChannel returns the slice with peers participating in given channel
-func (*MockGossipServiceAdapter) PeersOfChannel(gossip_common.ChainID) []discovery.NetworkMember {
-	return []discovery.NetworkMember{}
+func (mock *MockGossipServiceAdapter) PeersOfChannel(gossip_common.ChainID) []discovery.NetworkMember {
+	return mock.Peers
 }
 
 // AddPayload adds gossip payload to the local state transfer buffer
@@ -14,9 +14,12 @@ import (
 	"golang.org/x/net/context"
 
 	"errors"
+	"time"
 
-	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
@@ -52,25 +55,52 @@ var endorserLogger = flogging.MustGetLogger("endorser")
 
 // Endorser provides the Endorser service ProcessProposal
 type Endorser struct {
-	policyChecker policy.PolicyChecker
+	aclProvider aclmgmt.ACLProvider
+	// ocspChecker, if set, rejects proposals whose creator's
+	// certificate has been revoked according to its OCSP responder
+	ocspChecker *msp.OCSPChecker
 }
 
 // NewEndorserServer creates and returns a new Endorser server instance.
 func NewEndorserServer() pb.EndorserServer {
 	e := new(Endorser)
-	e.policyChecker = policy.NewPolicyChecker(
-		peer.NewChannelPolicyManagerGetter(),
-		mgmt.GetLocalMSP(),
-		mgmt.NewLocalMSPPrincipalGetter(),
+	e.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			peer.NewChannelPolicyManagerGetter(),
+			mgmt.GetLocalMSP(),
+			mgmt.NewLocalMSPPrincipalGetter(),
+		),
+		peer.NewPolicyMapperGetter(),
 	)
+	if enabled, opts := peer.GetOCSPOptions(); enabled {
+		e.ocspChecker = msp.NewOCSPChecker(opts)
+	}
 
 	return e
 }
 
+// checkCreatorRevocation checks through OCSP that the certificate of the
+// creator of a proposal has not been revoked. The creator has already been
+// validated against the MSPs of the channel by ValidateProposalMessage.
+func (e *Endorser) checkCreatorRevocation(chainID string, creator []byte) error {
+	if e.ocspChecker == nil {
+		return nil
+	}
+
+	id, err := mgmt.GetIdentityDeserializer(chainID).DeserializeIdentity(creator)
+	if err != nil {
+		return fmt.Errorf("Failed to deserialize creator identity, err %s", err)
+	}
+	if err = e.ocspChecker.CheckIdentity(id); err != nil {
+		return fmt.Errorf("Creator identity is not valid, err %s", err)
+	}
+	return nil
+}
+
 // checkACL checks that the supplied proposal complies
-// with the writers policy of the chain
+// with the policy the chain maps proposals to
 func (e *Endorser) checkACL(signedProp *pb.SignedProposal, chdr *common.ChannelHeader, shdr *common.SignatureHeader, hdrext *pb.ChaincodeHeaderExtension) error {
-	return e.policyChecker.CheckPolicy(chdr.ChannelId, policies.ChannelApplicationWriters, signedProp)
+	return e.aclProvider.CheckACL(aclmgmt.PeerPropose, chdr.ChannelId, signedProp)
 }
 
 //TODO - check for escc and vscc
@@ -111,7 +141,13 @@ func (e *Endorser) callChaincode(ctxt context.Context, chainID string, version s
 
 	cccid := ccprovider.NewCCContext(chainID, cid.Name, version, txid, scc, signedProp, prop)
 
-	res, ccevent, err = chaincode.ExecuteChaincode(ctxt, cccid, cis.ChaincodeSpec.Input.Args)
+	if cis.ChaincodeSpec.Input.IsInit {
+		//the invocation spec is passed as is so that the chaincode's
+		//Init method gets called
+		res, ccevent, err = chaincode.Execute(ctxt, cccid, cis)
+	} else {
+		res, ccevent, err = chaincode.ExecuteChaincode(ctxt, cccid, cis.ChaincodeSpec.Input.Args)
+	}
 
 	if err != nil {
 		return nil, nil, err
@@ -236,6 +272,19 @@ func (e *Endorser) simulateProposal(ctx context.Context, chainID string, txid st
 		if err != nil {
 			return nil, nil, nil, nil, err
 		}
+
+		// explicit initialization is only available once
+		// the channel requires the capability
+		if ac := peer.GetApplicationCapabilities(chainID); ac != nil && ac.ChaincodeLifecycle() {
+			err = e.checkInitialization(cid.Name, cdLedger, cis, txsim)
+			if err != nil {
+				return nil, nil, nil, nil, err
+			}
+		} else if cis.ChaincodeSpec.GetInput().GetIsInit() {
+			return nil, nil, nil, nil, fmt.Errorf("chaincode %s cannot be explicitly initialized without the %s application capability", cid.Name, capabilities.ApplicationV1_2)
+		}
+	} else if cis.ChaincodeSpec.GetInput().GetIsInit() {
+		return nil, nil, nil, nil, fmt.Errorf("system chaincode %s cannot be explicitly initialized", cid.Name)
 	} else {
 		version = util.GetSysCCVersion()
 	}
@@ -259,6 +308,44 @@ func (e *Endorser) simulateProposal(ctx context.Context, chainID string, txid st
 	return cdLedger, res, simResult, ccevent, nil
 }
 
+// checkInitialization makes sure that each definition of a chaincode which
+// requires explicit initialization is initialized exactly once, before any
+// other invocation. An initialization records its outcome in the simulation results
+// so that the validators can apply the same check
+func (e *Endorser) checkInitialization(ccname string, cd *ccprovider.ChaincodeData, cis *pb.ChaincodeInvocationSpec, txsim ledger.TxSimulator) error {
+	isInit := cis.ChaincodeSpec.GetInput().GetIsInit()
+	if !cd.InitRequired {
+		if isInit {
+			return fmt.Errorf("chaincode %s does not require initialization", ccname)
+		}
+		return nil
+	}
+
+	if txsim == nil {
+		return fmt.Errorf("chaincode %s requires initialization, which needs a channel", ccname)
+	}
+
+	// each definition of the chaincode has to be initialized
+	key := ccprovider.InitializedKey(cd)
+	initialized, err := txsim.GetState(ccname, key)
+	if err != nil {
+		return fmt.Errorf("could not determine whether chaincode %s was initialized, error %s", ccname, err)
+	}
+
+	if !isInit {
+		if initialized == nil {
+			return fmt.Errorf("chaincode %s must be initialized before it can be invoked", ccname)
+		}
+		return nil
+	}
+
+	if initialized != nil {
+		return fmt.Errorf("chaincode %s is already initialized", ccname)
+	}
+
+	return txsim.SetState(ccname, key, []byte(cd.Version))
+}
+
 func (e *Endorser) getCDSFromLSCC(ctx context.Context, chainID string, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, chaincodeID string, txsim ledger.TxSimulator) (*ccprovider.ChaincodeData, error) {
 	ctxt := ctx
 	if txsim != nil {
@@ -380,6 +467,19 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
 	}
 
+	// reject creators whose certificate has expired
+	if crypto.IsExpired(shdr.Creator, time.Now()) {
+		err = errors.New("Creator identity has expired")
+		endorserLogger.Warningf("Rejecting proposal: %s", err)
+		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+	}
+
+	// reject creators whose certificate has been revoked
+	if err = e.checkCreatorRevocation(chdr.ChannelId, shdr.Creator); err != nil {
+		endorserLogger.Warningf("Rejecting proposal: %s", err)
+		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+	}
+
 	// block invocations to security-sensitive system chaincodes
 	if syscc.IsSysCCAndNotInvokableExternal(hdrExt.ChaincodeId.Name) {
 		endorserLogger.Errorf("Error: an attempt was made by %#v to invoke system chaincode %s",
@@ -450,6 +550,17 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	//       we're trying to emulate a submitting peer. On the other hand, we need
 	//       to validate the supplied action before endorsing it
 
+	// proposals marked as queries are simulated with the query timeout
+	// of the chaincode, and are not endorsed so that clients cannot
+	// submit them for ordering, once the channel requires the capability
+	query := false
+	if ac := peer.GetApplicationCapabilities(chainID); ac != nil && ac.QueryProposals() {
+		if cis, err := putils.GetChaincodeInvocationSpec(prop); err == nil && cis.ChaincodeSpec.GetInput().GetIsQuery() {
+			query = true
+			ctx = context.WithValue(ctx, chaincode.QueryKey, true)
+		}
+	}
+
 	//1 -- simulate
 	cd, res, simulationResult, ccevent, err := e.simulateProposal(ctx, chainID, txid, signedProp, prop, hdrExt.ChaincodeId, txsim)
 	if err != nil {
@@ -479,7 +590,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 
 	//TODO till we implement global ESCC, CSCC for system chaincodes
 	//chainless proposals (such as CSCC) don't have to be endorsed
-	if chainID == "" {
+	if chainID == "" || query {
 		pResp = &pb.ProposalResponse{Response: res}
 	} else {
 		pResp, err = e.endorseProposal(ctx, chainID, txid, signedProp, prop, res, simulationResult, ccevent, hdrExt.PayloadVisibility, hdrExt.ChaincodeId, txsim, cd)
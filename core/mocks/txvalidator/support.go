@@ -17,6 +17,8 @@ limitations under the License.
 package support
 
 import (
+	"github.com/hyperledger/fabric/common/config"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -28,6 +30,8 @@ type Support struct {
 	LedgerVal     ledger.PeerLedger
 	MSPManagerVal msp.MSPManager
 	ApplyVal      error
+	// ACVal is returned as the result of Capabilities() if set
+	ACVal config.ApplicationCapabilities
 }
 
 // Ledger returns LedgerVal
@@ -52,3 +56,11 @@ func (ms *Support) PolicyManager() policies.Manager {
 func (cs *Support) GetMSPIDs(cid string) []string {
 	return []string{"DEFAULT"}
 }
+
+// Capabilities returns ACVal if set, otherwise capabilities which enable no features
+func (cs *Support) Capabilities() config.ApplicationCapabilities {
+	if cs.ACVal == nil {
+		return &mockconfig.ApplicationCapabilities{}
+	}
+	return cs.ACVal
+}
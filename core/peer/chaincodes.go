@@ -0,0 +1,210 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/committer"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/protos/common"
+	gossipproto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+const (
+	// the namespaces whose updates change the chaincodes instantiated on a channel
+	lsccNamespace      = "lscc"
+	lifecycleNamespace = "_lifecycle"
+
+	// the range of keys of the lifecycle namespace holding committed definitions
+	definitionsStartKey = "definitions/"
+	definitionsEndKey   = "definitions0"
+)
+
+// installed caches the chaincodes installed on the peer, as listing
+// them requires reading all the packages from the file system
+var installed = struct {
+	sync.RWMutex
+	chaincodes []*gossipproto.Chaincode
+}{}
+
+// UpdateInstalledChaincodes re-reads the chaincodes installed on the peer
+// and publishes them, through gossip, to the other peers of each channel
+func UpdateInstalledChaincodes() {
+	res, err := ccprovider.GetInstalledChaincodes()
+	if err != nil {
+		peerLogger.Debugf("Failed listing installed chaincodes: %s", err)
+		return
+	}
+	var chaincodes []*gossipproto.Chaincode
+	for _, cc := range res.Chaincodes {
+		chaincodes = append(chaincodes, &gossipproto.Chaincode{Name: cc.Name, Version: cc.Version})
+	}
+
+	installed.Lock()
+	installed.chaincodes = chaincodes
+	installed.Unlock()
+
+	chains.RLock()
+	defer chains.RUnlock()
+	for cid, c := range chains.list {
+		// chains without a committer are not disseminated through gossip
+		if c.committer == nil {
+			continue
+		}
+		publishChaincodes(cid, c.cs.ledger)
+	}
+}
+
+// InstalledChaincodes returns the chaincodes installed on the peer, as
+// last read by UpdateInstalledChaincodes
+func InstalledChaincodes() []*gossipproto.Chaincode {
+	installed.RLock()
+	defer installed.RUnlock()
+	return installed.chaincodes
+}
+
+// publishChaincodes publishes, through gossip, the chaincodes installed
+// on the peer and the ones instantiated on the given channel
+func publishChaincodes(cid string, l ledger.PeerLedger) {
+	instantiated, err := instantiatedChaincodes(l)
+	if err != nil {
+		peerLogger.Warningf("Failed listing the chaincodes instantiated on channel %s: %s", cid, err)
+		return
+	}
+
+	installed.RLock()
+	chaincodes := installed.chaincodes
+	installed.RUnlock()
+
+	service.GetGossipService().UpdateChaincodes(chaincodes, instantiated, gossipcommon.ChainID(cid))
+}
+
+// instantiatedChaincodes returns the chaincodes instantiated through lscc
+// or committed through the lifecycle system chaincode on the ledger
+func instantiatedChaincodes(l ledger.PeerLedger) ([]*gossipproto.Chaincode, error) {
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+
+	var res []*gossipproto.Chaincode
+	seen := make(map[string]bool)
+
+	itr, err := qe.GetStateRangeScanIterator(lifecycleNamespace, definitionsStartKey, definitionsEndKey)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+	for {
+		qr, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if qr == nil {
+			break
+		}
+		def := &lb.ChaincodeDefinition{}
+		if err := proto.Unmarshal(qr.(*queryresult.KV).Value, def); err != nil {
+			continue
+		}
+		seen[def.Name] = true
+		res = append(res, &gossipproto.Chaincode{Name: def.Name, Version: def.Version})
+	}
+
+	lsccItr, err := qe.GetStateRangeScanIterator(lsccNamespace, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer lsccItr.Close()
+	for {
+		qr, err := lsccItr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if qr == nil {
+			break
+		}
+		cd := &ccprovider.ChaincodeData{}
+		if err := proto.Unmarshal(qr.(*queryresult.KV).Value, cd); err != nil || cd.Name == "" {
+			continue
+		}
+		// committed definitions take precedence
+		if seen[cd.Name] {
+			continue
+		}
+		res = append(res, &gossipproto.Chaincode{Name: cd.Name, Version: cd.Version})
+	}
+
+	return res, nil
+}
+
+// chaincodeTrackingCommitter republishes the chaincodes of a channel
+// after committing blocks which may have changed them
+type chaincodeTrackingCommitter struct {
+	committer.Committer
+	cid    string
+	ledger ledger.PeerLedger
+}
+
+// Commit commits the block, and republishes the chaincodes
+// of the channel if the block invokes lscc or the lifecycle
+func (c *chaincodeTrackingCommitter) Commit(block *common.Block) error {
+	if err := c.Committer.Commit(block); err != nil {
+		return err
+	}
+	if invokesLifecycle(block) {
+		publishChaincodes(c.cid, c.ledger)
+	}
+	return nil
+}
+
+func invokesLifecycle(block *common.Block) bool {
+	if block.Data == nil {
+		return false
+	}
+	for _, data := range block.Data.Data {
+		env, err := utils.GetEnvelopeFromBlock(data)
+		if err != nil {
+			continue
+		}
+		payload, err := utils.GetPayload(env)
+		if err != nil || payload.Header == nil {
+			continue
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil || common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		hdrExt, err := utils.GetChaincodeHeaderExtension(payload.Header)
+		if err != nil || hdrExt.ChaincodeId == nil {
+			continue
+		}
+		if hdrExt.ChaincodeId.Name == lsccNamespace || hdrExt.ChaincodeId.Name == lifecycleNamespace {
+			return true
+		}
+	}
+	return false
+}
@@ -38,6 +38,7 @@ import (
 
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -148,3 +149,13 @@ func GetSecureConfig() (comm.SecureServerConfig, error) {
 	}
 	return secureConfig, nil
 }
+
+// GetOCSPOptions returns whether the revocation status of the creators of
+// proposals is checked through OCSP and, if so, the options of the checks
+func GetOCSPOptions() (bool, msp.OCSPOptions) {
+	return viper.GetBool("peer.ocsp.enabled"), msp.OCSPOptions{
+		CacheTTL: viper.GetDuration("peer.ocsp.cacheTTL"),
+		Timeout:  viper.GetDuration("peer.ocsp.timeout"),
+		HardFail: viper.GetBool("peer.ocsp.hardFail"),
+	}
+}
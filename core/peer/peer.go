@@ -12,6 +12,7 @@ import (
 	"net"
 	"sync"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/configtx"
 	configtxapi "github.com/hyperledger/fabric/common/configtx/api"
@@ -20,6 +21,7 @@ import (
 	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/committer"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
@@ -57,6 +59,16 @@ func (cs *chainSupport) GetMSPIDs(cid string) []string {
 	return GetMSPIDs(cid)
 }
 
+// Capabilities returns the capabilities of the application portion of the channel,
+// none of which are enabled if the channel has no application configuration
+func (cs *chainSupport) Capabilities() config.ApplicationCapabilities {
+	ac, ok := cs.ApplicationConfig()
+	if !ok || ac == nil {
+		return capabilities.NewApplicationProvider(nil)
+	}
+	return ac.Capabilities()
+}
+
 // chain is a local struct to manage objects in a chain
 type chain struct {
 	cs        *chainSupport
@@ -92,6 +104,8 @@ func MockSetMSPIDGetter(mspIDGetter func(string) []string) {
 func Initialize(init func(string)) {
 	chainInitializer = init
 
+	UpdateInstalledChaincodes()
+
 	var cb *common.Block
 	var ledger ledger.PeerLedger
 	ledgermgmt.Initialize()
@@ -195,15 +209,29 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block) error {
 		updateTrustedRoots(cm)
 	}
 
-	configtxManager, err := configtx.NewManagerImpl(
+	// The capabilities of the config the chain is created from are checked
+	// below, so that the chain is refused rather than the peer halted
+	var configtxManager configtxapi.Manager
+	capabilitiesCallbackWrapper := func(cm configtxapi.Manager) {
+		if configtxManager == nil {
+			return
+		}
+		capabilitiesSupportedOrPanic(cm)
+	}
+
+	configtxManager, err = configtx.NewManagerImpl(
 		envelopeConfig,
 		configtxInitializer,
-		[]func(cm configtxapi.Manager){gossipCallbackWrapper, trustedRootsCallbackWrapper},
+		[]func(cm configtxapi.Manager){capabilitiesCallbackWrapper, gossipCallbackWrapper, trustedRootsCallbackWrapper},
 	)
 	if err != nil {
 		return err
 	}
 
+	if err = capabilitiesSupported(configtxManager); err != nil {
+		return err
+	}
+
 	// TODO remove once all references to mspmgmt are gone from peer code
 	mspmgmt.XXXSetMSPManager(cid, configtxManager.MSPManager())
 
@@ -217,19 +245,21 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block) error {
 		ledger:      ledger,
 	}
 
-	c := committer.NewLedgerCommitterReactive(ledger, txvalidator.NewTxValidator(cs), func(block *common.Block) error {
+	lc := committer.NewLedgerCommitterReactive(ledger, txvalidator.NewTxValidator(cs), func(block *common.Block) error {
 		chainID, err := utils.GetChainIDFromBlock(block)
 		if err != nil {
 			return err
 		}
 		return SetCurrConfigBlock(block, chainID)
 	})
+	c := &chaincodeTrackingCommitter{Committer: lc, cid: cid, ledger: ledger}
 
 	ordererAddresses := configtxManager.ChannelConfig().OrdererAddresses()
 	if len(ordererAddresses) == 0 {
 		return errors.New("No ordering service endpoint provided in configuration block")
 	}
 	service.GetGossipService().InitializeChannel(cs.ChainID(), c, ordererAddresses)
+	publishChaincodes(cid, ledger)
 
 	chains.Lock()
 	defer chains.Unlock()
@@ -241,6 +271,32 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block) error {
 	return nil
 }
 
+// capabilitiesSupported returns an error if the channel requires
+// capabilities which are not supported by this peer
+func capabilitiesSupported(res configtxapi.Resources) error {
+	if err := res.ChannelConfig().Capabilities().Supported(); err != nil {
+		return fmt.Errorf("incompatible channel: %s", err)
+	}
+
+	ac, ok := res.ApplicationConfig()
+	if !ok || ac == nil {
+		return nil
+	}
+	if err := ac.Capabilities().Supported(); err != nil {
+		return fmt.Errorf("incompatible channel: %s", err)
+	}
+	return nil
+}
+
+// capabilitiesSupportedOrPanic halts the peer when a config update makes the
+// channel require capabilities which are not supported by this peer, as it
+// cannot safely process the blocks of the channel any further
+func capabilitiesSupportedOrPanic(cm configtxapi.Manager) {
+	if err := capabilitiesSupported(cm); err != nil {
+		peerLogger.Panicf("[channel %s] %s, this peer must be upgraded to process the channel", cm.ChainID(), err)
+	}
+}
+
 // CreateChainFromBlock creates a new chain from config block
 func CreateChainFromBlock(cb *common.Block) error {
 	cid, err := utils.GetChainIDFromBlock(cb)
@@ -322,6 +378,28 @@ func GetPolicyManager(cid string) policies.Manager {
 	return nil
 }
 
+// GetChannelConfig returns the config manager of the chain with chain ID. Note that this
+// call returns nil if chain cid has not been created.
+func GetChannelConfig(cid string) configtxapi.Manager {
+	chains.RLock()
+	defer chains.RUnlock()
+	if c, ok := chains.list[cid]; ok {
+		return c.cs.Manager
+	}
+	return nil
+}
+
+// GetApplicationCapabilities returns the application capabilities of the chain
+// with chain ID. Note that this call returns nil if chain cid has not been created.
+func GetApplicationCapabilities(cid string) config.ApplicationCapabilities {
+	chains.RLock()
+	defer chains.RUnlock()
+	if c, ok := chains.list[cid]; ok {
+		return c.cs.Capabilities()
+	}
+	return nil
+}
+
 // GetCurrConfigBlock returns the cached config block of the specified chain.
 // Note that this call returns nil if chain cid has not been created.
 func GetCurrConfigBlock(cid string) *common.Block {
@@ -530,6 +608,26 @@ func (c *channelPolicyManagerGetter) Manager(channelID string) (policies.Manager
 	return policyManager, policyManager != nil
 }
 
+// NewPolicyMapperGetter returns a new instance of aclmgmt.PolicyMapperGetter
+// which returns the ACLs of the channels of the peer
+func NewPolicyMapperGetter() aclmgmt.PolicyMapperGetter {
+	return &policyMapperGetter{}
+}
+
+type policyMapperGetter struct{}
+
+func (p *policyMapperGetter) PolicyMapper(channelID string) (config.PolicyMapper, bool) {
+	cm := GetChannelConfig(channelID)
+	if cm == nil {
+		return nil, false
+	}
+	ac, ok := cm.ApplicationConfig()
+	if !ok || ac == nil {
+		return nil, false
+	}
+	return ac.APIPolicyMapper(), true
+}
+
 // CreatePeerServer creates an instance of comm.GRPCServer
 // This server is used for peer communications
 func CreatePeerServer(listenAddress string,
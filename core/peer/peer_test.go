@@ -25,7 +25,11 @@ import (
 
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
 	mscc "github.com/hyperledger/fabric/common/mocks/scc"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/comm"
 	ccp "github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
@@ -48,7 +52,7 @@ type mockDeliveryClient struct {
 
 // StartDeliverForChannel dynamically starts delivery of new blocks from ordering service
 // to channel peers.
-func (ds *mockDeliveryClient) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo) error {
+func (ds *mockDeliveryClient) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, finalizer func()) error {
 	return nil
 }
 
@@ -158,7 +162,7 @@ func TestCreateChainFromBlock(t *testing.T) {
 		return dialOpts
 	}
 	err = service.InitGossipServiceCustomDeliveryFactory(
-		identity, "localhost:13611", grpcServer,
+		identity, &disabled.Provider{}, "localhost:13611", grpcServer,
 		&mockDeliveryClientFactory{},
 		messageCryptoService, secAdv, defaultSecureDialOpts)
 
@@ -219,6 +223,15 @@ func TestCreateChainFromBlock(t *testing.T) {
 	assert.NotNil(t, pmgr, "PolicyManager should not be nil")
 	assert.Equal(t, true, ok, "expected Manage() to return true")
 
+	// PolicyMapperGetter
+	pmapg := NewPolicyMapperGetter()
+	mapper, ok := pmapg.PolicyMapper(testChainID)
+	assert.True(t, ok, "expected PolicyMapper() to return true")
+	assert.Equal(t, "", mapper.PolicyRefForAPI(aclmgmt.PeerPropose), "no ACLs are defined")
+
+	_, ok = pmapg.PolicyMapper("BogusChain")
+	assert.False(t, ok, "expected PolicyMapper() to return false for a bogus chain")
+
 	// Chaos monkey test
 	Initialize(nil)
 
@@ -230,6 +243,43 @@ func TestCreateChainFromBlock(t *testing.T) {
 	}
 }
 
+func TestCapabilitiesSupported(t *testing.T) {
+	res := &mockconfigtx.Resources{
+		ChannelConfigVal: &mockconfig.Channel{CapabilitiesVal: &mockconfig.ChannelCapabilities{}},
+	}
+	assert.NoError(t, capabilitiesSupported(res), "channel without application config")
+
+	res.ApplicationConfigVal = &mockconfig.Application{CapabilitiesVal: &mockconfig.ApplicationCapabilities{}}
+	assert.NoError(t, capabilitiesSupported(res), "all capabilities are supported")
+
+	res.ApplicationConfigVal = &mockconfig.Application{CapabilitiesVal: &mockconfig.ApplicationCapabilities{
+		SupportedErr: fmt.Errorf("Application capability V9_9 is required but not supported"),
+	}}
+	assert.Error(t, capabilitiesSupported(res), "unsupported application capability")
+
+	res.ApplicationConfigVal = nil
+	res.ChannelConfigVal = &mockconfig.Channel{CapabilitiesVal: &mockconfig.ChannelCapabilities{
+		SupportedErr: fmt.Errorf("Channel capability V9_9 is required but not supported"),
+	}}
+	assert.Error(t, capabilitiesSupported(res), "unsupported channel capability")
+}
+
+func TestChainSupportCapabilities(t *testing.T) {
+	cs := &chainSupport{Manager: &mockconfigtx.Manager{}}
+	ac := cs.Capabilities()
+	assert.NotNil(t, ac, "channel without application config")
+	assert.False(t, ac.ForbidDuplicateTXIdInBlock())
+	assert.False(t, ac.ChaincodeLifecycle())
+	assert.False(t, ac.ACLs())
+
+	cs.Manager = &mockconfigtx.Manager{Initializer: mockconfigtx.Initializer{Resources: mockconfigtx.Resources{
+		ApplicationConfigVal: &mockconfig.Application{CapabilitiesVal: &mockconfig.ApplicationCapabilities{
+			ChaincodeLifecycleVal: true,
+		}},
+	}}}
+	assert.True(t, cs.Capabilities().ChaincodeLifecycle())
+}
+
 func TestNewPeerClientConnection(t *testing.T) {
 	if _, err := NewPeerClientConnection(); err != nil {
 		t.Log(err)
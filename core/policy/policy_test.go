@@ -176,6 +176,39 @@ func TestPolicyChecker(t *testing.T) {
 	assert.Contains(t, err.Error(), "Failed deserializing proposal creator during channelless check policy with policy [Members]: [Invalid Identity]")
 }
 
+func TestPolicyCheckerNoChannelBySignedData(t *testing.T) {
+	identityDeserializer := &mocks.MockIdentityDeserializer{[]byte("Alice"), []byte("msg1")}
+	pc := NewPolicyChecker(
+		&mocks.MockChannelPolicyManagerGetter{},
+		identityDeserializer,
+		&mocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	)
+
+	err := pc.CheckPolicyNoChannelBySignedData("", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid policy name during channelless check policy on signed data")
+
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid signed data during channelless check policy with policy [Members]")
+
+	// Alice is a member of the local MSP, policy check must succeed
+	sd := []*common.SignedData{{Data: []byte("msg1"), Identity: []byte("Alice"), Signature: []byte("msg1")}}
+	assert.NoError(t, pc.CheckPolicyNoChannelBySignedData(mgmt.Members, sd))
+
+	// Bob is not a member of the local MSP, policy check must fail
+	sd = append(sd, &common.SignedData{Data: []byte("msg1"), Identity: []byte("Bob"), Signature: []byte("msg1")})
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, sd)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed deserializing signer during channelless check policy with policy [Members]: [Invalid Identity]")
+
+	// Alice signature over different data must fail
+	sd = []*common.SignedData{{Data: []byte("msg2"), Identity: []byte("Alice"), Signature: []byte("msg2")}}
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, sd)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed verifying the signature during channelless check policy with policy [Members]")
+}
+
 type MockPolicyCheckerFactory struct {
 	mock.Mock
 }
@@ -20,6 +20,7 @@ import (
 	//import system chain codes here
 	"github.com/hyperledger/fabric/core/scc/cscc"
 	"github.com/hyperledger/fabric/core/scc/escc"
+	"github.com/hyperledger/fabric/core/scc/lifecycle"
 	"github.com/hyperledger/fabric/core/scc/lscc"
 	"github.com/hyperledger/fabric/core/scc/qscc"
 	"github.com/hyperledger/fabric/core/scc/vscc"
@@ -44,6 +45,15 @@ var systemChaincodes = []*SystemChaincode{
 		InvokableExternal: true, // lscc is invoked to deploy new chaincodes
 		InvokableCC2CC:    true, // lscc can be invoked by other chaincodes
 	},
+	{
+		Enabled:           true,
+		Name:              lifecycle.Name,
+		Path:              "github.com/hyperledger/fabric/core/scc/lifecycle",
+		InitArgs:          [][]byte{[]byte("")},
+		Chaincode:         &lifecycle.Lifecycle{},
+		InvokableExternal: true, // lifecycle is invoked to approve and commit chaincode definitions
+		InvokableCC2CC:    true, // lifecycle is invoked by lscc to look up chaincode definitions
+	},
 	{
 		Enabled:   true,
 		Name:      "escc",
@@ -0,0 +1,672 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/core/policy"
+	"github.com/hyperledger/fabric/core/policyprovider"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// The lifecycle system chaincode manages chaincode definitions agreed
+// upon by the organizations of a channel. Each organization approves a
+// definition, which can be committed once enough organizations, as
+// specified by the channel's lifecycle policy, approved it.
+//     "Args":["approve",<chainname>,<ChaincodeDefinition>]
+//     "Args":["commit",<chainname>,<ChaincodeDefinition>]
+//     "Args":["queryapprovalstatus",<chainname>,<ChaincodeDefinition>]
+//     "Args":["querydefinition",<chainname>,<chaincodename>]
+//     "Args":["getccdata",<chainname>,<chaincodename>]
+
+var logger = flogging.MustGetLogger("lifecycle")
+
+const (
+	// Name is the name the lifecycle system chaincode is registered with;
+	// it is also the namespace holding approvals and committed definitions
+	Name = "_lifecycle"
+
+	//APPROVE approve a chaincode definition for the caller's organization
+	APPROVE = "approve"
+
+	//COMMIT commit a chaincode definition approved by enough organizations
+	COMMIT = "commit"
+
+	//QUERYAPPROVALSTATUS get the organizations that approved a chaincode definition
+	QUERYAPPROVALSTATUS = "queryapprovalstatus"
+
+	//QUERYDEFINITION get the committed ChaincodeDefinition
+	QUERYDEFINITION = "querydefinition"
+
+	//GETCCDATA get the committed definition as ChaincodeData, as lscc would return it
+	GETCCDATA = "getccdata"
+
+	definitionPrefix = "definitions/"
+	approvalPrefix   = "approvals/"
+
+	allowedCharsChaincodeName = "[A-Za-z0-9_-]+"
+	allowedCharsVersion       = "[A-Za-z0-9_.-]+"
+)
+
+//---------- the lifecycle SCC -----------------
+
+// Lifecycle implements the approval based chaincode lifecycle
+type Lifecycle struct {
+	// sccprovider is the interface with which we call
+	// methods of the system chaincode package without
+	// import cycles
+	sccprovider sysccprovider.SystemChaincodeProvider
+
+	// policyChecker is the interface used to perform
+	// access control and to evaluate the lifecycle policy
+	policyChecker policy.PolicyChecker
+
+	// policyManagerGetter is used to look up which policy
+	// the approvals of a channel are evaluated against
+	policyManagerGetter policies.ChannelPolicyManagerGetter
+
+	// aclProvider checks the callers of the functions against
+	// the ACLs of the channel
+	aclProvider aclmgmt.ACLProvider
+
+	// deserializerGetter returns the identity deserializer
+	// of a channel, used to identify approving organizations
+	deserializerGetter func(chainID string) msp.IdentityDeserializer
+
+	// capabilitiesGetter returns the application capabilities
+	// of a channel, which must enable the chaincode lifecycle
+	capabilitiesGetter func(chainID string) config.ApplicationCapabilities
+}
+
+//----------------errors---------------
+
+//InvalidFunctionErr invalid function error
+type InvalidFunctionErr string
+
+func (f InvalidFunctionErr) Error() string {
+	return fmt.Sprintf("invalid function to lifecycle %s", string(f))
+}
+
+//InvalidArgsLenErr invalid arguments length error
+type InvalidArgsLenErr int
+
+func (i InvalidArgsLenErr) Error() string {
+	return fmt.Sprintf("invalid number of argument to lifecycle %d", int(i))
+}
+
+//InvalidChaincodeNameErr invalid chaincode name error
+type InvalidChaincodeNameErr string
+
+func (f InvalidChaincodeNameErr) Error() string {
+	return fmt.Sprintf("invalid chaincode name '%s'. Names can only consist of alphanumerics, '_', and '-'", string(f))
+}
+
+//InvalidVersionErr invalid version error
+type InvalidVersionErr string
+
+func (f InvalidVersionErr) Error() string {
+	return fmt.Sprintf("invalid chaincode version '%s'. Versions can only consist of alphanumerics, '_',  '-', and '.'", string(f))
+}
+
+//InvalidSequenceErr the sequence of a definition does not follow the committed one
+type InvalidSequenceErr string
+
+func (f InvalidSequenceErr) Error() string {
+	return fmt.Sprintf("invalid sequence for chaincode definition %s", string(f))
+}
+
+//NotFoundErr chaincode definition not committed error
+type NotFoundErr string
+
+func (f NotFoundErr) Error() string {
+	return fmt.Sprintf("could not find chaincode definition with name '%s'", string(f))
+}
+
+//NotApprovedErr chaincode definition not approved by enough organizations error
+type NotApprovedErr string
+
+func (f NotApprovedErr) Error() string {
+	return fmt.Sprintf("chaincode definition not approved by enough organizations (%s)", string(f))
+}
+
+//MissingHashErr chaincode definition without package hash error
+type MissingHashErr string
+
+func (f MissingHashErr) Error() string {
+	return fmt.Sprintf("no package hash supplied for chaincode definition of %s", string(f))
+}
+
+//-------------- ledger layout ------------------
+
+// DefinitionKey returns the key under which the committed definition
+// of the supplied chaincode is stored
+func DefinitionKey(ccname string) string {
+	return definitionPrefix + ccname
+}
+
+// ApprovalKey returns the key under which the approval of an
+// organization for a given sequence of a chaincode is stored
+func ApprovalKey(ccname string, sequence int64, mspID string) string {
+	return approvalPrefix + ccname + "/" + strconv.FormatInt(sequence, 10) + "/" + mspID
+}
+
+//-------------- helper functions ------------------
+
+// PolicyName returns the name of the policy chaincode definitions have
+// to satisfy on a channel: the application LifecycleEndorsement policy
+// if the channel defines one, the application Admins policy otherwise
+func PolicyName(pm policies.Manager) string {
+	if _, ok := pm.GetPolicy(policies.ChannelApplicationLifecycleEndorsement); ok {
+		return policies.ChannelApplicationLifecycleEndorsement
+	}
+	return policies.ChannelApplicationAdmins
+}
+
+// CheckApprover checks that the supplied creator is an admin of its
+// organization and returns the identifier of the organization's MSP
+func CheckApprover(deserializer msp.IdentityDeserializer, creator []byte) (string, error) {
+	if deserializer == nil {
+		return "", fmt.Errorf("no identity deserializer available")
+	}
+	id, err := deserializer.DeserializeIdentity(creator)
+	if err != nil {
+		return "", fmt.Errorf("failed deserializing approver: %s", err)
+	}
+	mspID := id.GetMSPIdentifier()
+
+	role, err := proto.Marshal(&mspprotos.MSPRole{Role: mspprotos.MSPRole_ADMIN, MspIdentifier: mspID})
+	if err != nil {
+		return "", err
+	}
+	principal := &mspprotos.MSPPrincipal{PrincipalClassification: mspprotos.MSPPrincipal_ROLE, Principal: role}
+	if err = id.SatisfiesPrincipal(principal); err != nil {
+		return "", fmt.Errorf("approver is not an admin of %s: %s", mspID, err)
+	}
+
+	return mspID, nil
+}
+
+// GetSignedData returns the data, creator and signature of a signed
+// proposal in a form that can be evaluated against a policy
+func GetSignedData(sp *pb.SignedProposal) (*common.SignedData, error) {
+	if sp == nil {
+		return nil, fmt.Errorf("nil signed proposal")
+	}
+	proposal, err := utils.GetProposal(sp.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	header, err := utils.GetHeader(proposal.Header)
+	if err != nil {
+		return nil, err
+	}
+	shdr, err := utils.GetSignatureHeader(header.SignatureHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.SignedData{
+		Data:      sp.ProposalBytes,
+		Identity:  shdr.Creator,
+		Signature: sp.Signature,
+	}, nil
+}
+
+// SignedApprovals returns the signed data of the approvals that were
+// given for exactly the supplied chaincode definition
+func SignedApprovals(def *lb.ChaincodeDefinition, approvals []*lb.Approval) ([]*common.SignedData, error) {
+	var sd []*common.SignedData
+	for _, approval := range approvals {
+		if approval == nil || !proto.Equal(approval.Definition, def) {
+			continue
+		}
+		d, err := GetSignedData(approval.SignedProposal)
+		if err != nil {
+			return nil, err
+		}
+		sd = append(sd, d)
+	}
+	return sd, nil
+}
+
+func isValidCCNameOrVersion(ccNameOrVersion string, regExp string) bool {
+	re, _ := regexp.Compile(regExp)
+
+	matched := re.FindString(ccNameOrVersion)
+	return len(matched) == len(ccNameOrVersion)
+}
+
+func getDefinitionArg(arg []byte) (*lb.ChaincodeDefinition, error) {
+	def := &lb.ChaincodeDefinition{}
+	if err := proto.Unmarshal(arg, def); err != nil {
+		return nil, fmt.Errorf("invalid chaincode definition: %s", err)
+	}
+
+	if def.Name == "" || !isValidCCNameOrVersion(def.Name, allowedCharsChaincodeName) {
+		return nil, InvalidChaincodeNameErr(def.Name)
+	}
+	if def.Version == "" || !isValidCCNameOrVersion(def.Version, allowedCharsVersion) {
+		return nil, InvalidVersionErr(def.Version)
+	}
+
+	return def, nil
+}
+
+// getDefinition returns the committed definition of a chaincode,
+// or nil if no definition was committed yet
+func (lc *Lifecycle) getDefinition(stub shim.ChaincodeStubInterface, ccname string) (*lb.ChaincodeDefinition, error) {
+	defbytes, err := stub.GetState(DefinitionKey(ccname))
+	if err != nil {
+		return nil, err
+	}
+	if defbytes == nil {
+		return nil, nil
+	}
+
+	def := &lb.ChaincodeDefinition{}
+	if err = proto.Unmarshal(defbytes, def); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling definition of %s: %s", ccname, err)
+	}
+	return def, nil
+}
+
+// nextSequence returns the sequence the next definition of the
+// chaincode has to carry
+func (lc *Lifecycle) nextSequence(stub shim.ChaincodeStubInterface, ccname string) (int64, error) {
+	current, err := lc.getDefinition(stub, ccname)
+	if err != nil {
+		return 0, err
+	}
+	if current == nil {
+		return 1, nil
+	}
+	return current.Sequence + 1, nil
+}
+
+// setDefaults fills the fields of the definition that were left
+// empty with the same defaults lscc applies upon instantiation
+func (lc *Lifecycle) setDefaults(chainname string, def *lb.ChaincodeDefinition) error {
+	if len(def.EndorsementPolicy) == 0 {
+		p := cauthdsl.SignedByAnyMember(peer.GetMSPIDs(chainname))
+		policy, err := utils.Marshal(p)
+		if err != nil {
+			return err
+		}
+		def.EndorsementPolicy = policy
+	}
+
+	if def.Escc == "" {
+		def.Escc = "escc"
+	}
+	if def.Vscc == "" {
+		def.Vscc = "vscc"
+	}
+
+	return nil
+}
+
+// setInstalledHash fills the package hash of the definition, when it
+// was left empty, with the hash of the package installed on this peer.
+// It is only used upon approval, which records the hash in the ledger:
+// commits and queries must not depend on what each peer has installed
+func (lc *Lifecycle) setInstalledHash(def *lb.ChaincodeDefinition) error {
+	if len(def.Hash) != 0 {
+		return nil
+	}
+
+	// the organization agrees on the package installed on this peer
+	ccpack, err := ccprovider.GetChaincodeFromFS(def.Name, def.Version)
+	if err != nil {
+		return fmt.Errorf("no package hash supplied and chaincode %s:%s is not installed (%s)", def.Name, def.Version, err)
+	}
+	def.Hash = ccpack.GetId()
+	return nil
+}
+
+// getApprovals returns the approvals recorded by the organizations of the
+// channel for the supplied sequence of a chaincode
+func (lc *Lifecycle) getApprovals(stub shim.ChaincodeStubInterface, chainname, ccname string, sequence int64) (map[string]*lb.Approval, error) {
+	approvals := make(map[string]*lb.Approval)
+	for _, mspID := range peer.GetMSPIDs(chainname) {
+		abytes, err := stub.GetState(ApprovalKey(ccname, sequence, mspID))
+		if err != nil {
+			return nil, err
+		}
+		if abytes == nil {
+			continue
+		}
+		approval := &lb.Approval{}
+		if err = proto.Unmarshal(abytes, approval); err != nil {
+			return nil, fmt.Errorf("failed unmarshalling approval of %s: %s", mspID, err)
+		}
+		approvals[mspID] = approval
+	}
+	return approvals, nil
+}
+
+// executeApprove implements the "approve" Invoke transaction
+func (lc *Lifecycle) executeApprove(stub shim.ChaincodeStubInterface, chainname string, def *lb.ChaincodeDefinition, sp *pb.SignedProposal) error {
+	sd, err := GetSignedData(sp)
+	if err != nil {
+		return err
+	}
+
+	mspID, err := CheckApprover(lc.deserializerGetter(chainname), sd.Identity)
+	if err != nil {
+		return err
+	}
+
+	isMember := false
+	for _, id := range peer.GetMSPIDs(chainname) {
+		if id == mspID {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		return fmt.Errorf("organization %s is not a member of channel %s", mspID, chainname)
+	}
+
+	sequence, err := lc.nextSequence(stub, def.Name)
+	if err != nil {
+		return err
+	}
+	if def.Sequence != sequence {
+		return InvalidSequenceErr(fmt.Sprintf("%s: expected %d, got %d", def.Name, sequence, def.Sequence))
+	}
+
+	if err = lc.setDefaults(chainname, def); err != nil {
+		return err
+	}
+	if err = lc.setInstalledHash(def); err != nil {
+		return err
+	}
+
+	abytes, err := proto.Marshal(&lb.Approval{Definition: def, SignedProposal: sp})
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf("Organization %s approves definition %d of chaincode %s on channel %s", mspID, def.Sequence, def.Name, chainname)
+
+	return stub.PutState(ApprovalKey(def.Name, def.Sequence, mspID), abytes)
+}
+
+// executeCommit implements the "commit" Invoke transaction
+func (lc *Lifecycle) executeCommit(stub shim.ChaincodeStubInterface, chainname string, def *lb.ChaincodeDefinition) error {
+	sequence, err := lc.nextSequence(stub, def.Name)
+	if err != nil {
+		return err
+	}
+	if def.Sequence != sequence {
+		return InvalidSequenceErr(fmt.Sprintf("%s: expected %d, got %d", def.Name, sequence, def.Sequence))
+	}
+	if len(def.Hash) == 0 {
+		return MissingHashErr(def.Name)
+	}
+
+	if err = lc.setDefaults(chainname, def); err != nil {
+		return err
+	}
+
+	// check that escc and vscc are real system chaincodes
+	if !lc.sccprovider.IsSysCC(def.Escc) {
+		return fmt.Errorf("%s is not a valid endorsement system chaincode", def.Escc)
+	}
+	if !lc.sccprovider.IsSysCC(def.Vscc) {
+		return fmt.Errorf("%s is not a valid validation system chaincode", def.Vscc)
+	}
+
+	approvals, err := lc.getApprovals(stub, chainname, def.Name, def.Sequence)
+	if err != nil {
+		return err
+	}
+	var list []*lb.Approval
+	for _, approval := range approvals {
+		list = append(list, approval)
+	}
+	sd, err := SignedApprovals(def, list)
+	if err != nil {
+		return err
+	}
+	if len(sd) == 0 {
+		return NotApprovedErr(fmt.Sprintf("no organization approved definition %d of %s", def.Sequence, def.Name))
+	}
+
+	pm, ok := lc.policyManagerGetter.Manager(chainname)
+	if pm == nil || !ok {
+		return fmt.Errorf("failed to get policy manager for channel %s", chainname)
+	}
+	if err = lc.policyChecker.CheckPolicyBySignedData(chainname, PolicyName(pm), sd); err != nil {
+		return NotApprovedErr(err.Error())
+	}
+
+	defbytes, err := proto.Marshal(def)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Committing definition %d of chaincode %s on channel %s", def.Sequence, def.Name, chainname)
+
+	return stub.PutState(DefinitionKey(def.Name), defbytes)
+}
+
+// getApprovalStatus returns, for each organization of the channel, whether
+// it approved the supplied definition
+func (lc *Lifecycle) getApprovalStatus(stub shim.ChaincodeStubInterface, chainname string, def *lb.ChaincodeDefinition) (*lb.ApprovalStatus, error) {
+	if def.Sequence == 0 {
+		sequence, err := lc.nextSequence(stub, def.Name)
+		if err != nil {
+			return nil, err
+		}
+		def.Sequence = sequence
+	}
+	if len(def.Hash) == 0 {
+		return nil, MissingHashErr(def.Name)
+	}
+
+	if err := lc.setDefaults(chainname, def); err != nil {
+		return nil, err
+	}
+
+	approvals, err := lc.getApprovals(stub, chainname, def.Name, def.Sequence)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &lb.ApprovalStatus{Approvals: make(map[string]bool)}
+	for _, mspID := range peer.GetMSPIDs(chainname) {
+		approval, ok := approvals[mspID]
+		status.Approvals[mspID] = ok && proto.Equal(approval.Definition, def)
+	}
+	return status, nil
+}
+
+// getChaincodeData returns the committed definition in the form used by
+// lscc; package specific fields are filled from the package installed
+// on this peer, if it is the one the organizations agreed upon
+func (lc *Lifecycle) getChaincodeData(def *lb.ChaincodeDefinition) *ccprovider.ChaincodeData {
+	cd := &ccprovider.ChaincodeData{
+		Name:         def.Name,
+		Version:      def.Version,
+		Escc:         def.Escc,
+		Vscc:         def.Vscc,
+		Policy:       def.EndorsementPolicy,
+		Id:           def.Hash,
+		InitRequired: def.InitRequired,
+		Sequence:     def.Sequence,
+	}
+
+	if local, err := ccprovider.GetChaincodeData(def.Name, def.Version); err == nil && bytes.Equal(local.Id, def.Hash) {
+		cd.Data = local.Data
+		cd.InstantiationPolicy = local.InstantiationPolicy
+	}
+
+	return cd
+}
+
+//-------------- the chaincode stub interface implementation ----------
+
+//Init only initializes the system chaincode provider
+func (lc *Lifecycle) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	lc.sccprovider = sysccprovider.GetSystemChaincodeProvider()
+
+	// Init policy checker for access control
+	lc.policyChecker = policyprovider.GetPolicyChecker()
+	lc.policyManagerGetter = peer.NewChannelPolicyManagerGetter()
+	lc.aclProvider = aclmgmt.NewACLProvider(lc.policyChecker, peer.NewPolicyMapperGetter())
+	lc.deserializerGetter = mspmgmt.GetIdentityDeserializer
+	lc.capabilitiesGetter = peer.GetApplicationCapabilities
+
+	return shim.Success(nil)
+}
+
+// Invoke implements the lifecycle functions "approve" and "commit" and the
+// query functions "queryapprovalstatus", "querydefinition" and "getccdata".
+// All functions take the channel name as their first argument
+func (lc *Lifecycle) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	args := stub.GetArgs()
+	if len(args) != 3 {
+		return shim.Error(InvalidArgsLenErr(len(args)).Error())
+	}
+
+	function := string(args[0])
+	chainname := string(args[1])
+	if chainname == "" {
+		return shim.Error(fmt.Sprintf("invalid chain name %s", chainname))
+	}
+
+	sp, err := stub.GetSignedProposal()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed retrieving signed proposal on executing %s with error %s", function, err))
+	}
+
+	switch function {
+	case APPROVE, COMMIT:
+		// approving is restricted to organization admins, whereas anybody
+		// allowed to submit transactions, by default, can commit an approved definition
+		if err = lc.aclProvider.CheckACL(aclResources[function], chainname, sp); err != nil {
+			return shim.Error(fmt.Sprintf("Authorization for %s on channel %s has been denied with error %s", function, chainname, err))
+		}
+
+		if ac := lc.capabilitiesGetter(chainname); ac == nil || !ac.ChaincodeLifecycle() {
+			return shim.Error(fmt.Sprintf("%s on channel %s requires the %s application capability", function, chainname, capabilities.ApplicationV1_2))
+		}
+
+		def, err := getDefinitionArg(args[2])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		if function == APPROVE {
+			err = lc.executeApprove(stub, chainname, def, sp)
+		} else {
+			err = lc.executeCommit(stub, chainname, def)
+		}
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		defbytes, err := proto.Marshal(def)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(defbytes)
+	case QUERYAPPROVALSTATUS, QUERYDEFINITION, GETCCDATA:
+		// this information is already available on the ledger
+		// therefore by default we enforce that the caller is reader of the channel.
+		if err = lc.aclProvider.CheckACL(aclResources[function], chainname, sp); err != nil {
+			return shim.Error(fmt.Sprintf("Authorization for %s on channel %s has been denied with error %s", function, chainname, err))
+		}
+
+		if function == QUERYAPPROVALSTATUS {
+			def, err := getDefinitionArg(args[2])
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			status, err := lc.getApprovalStatus(stub, chainname, def)
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			statusbytes, err := proto.Marshal(status)
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			return shim.Success(statusbytes)
+		}
+
+		ccname := string(args[2])
+		def, err := lc.getDefinition(stub, ccname)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		if function == GETCCDATA {
+			// an empty payload tells lscc that the chaincode is
+			// not managed by the lifecycle system chaincode
+			if def == nil {
+				return shim.Success(nil)
+			}
+			cdbytes, err := proto.Marshal(lc.getChaincodeData(def))
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			return shim.Success(cdbytes)
+		}
+
+		if def == nil {
+			return shim.Error(NotFoundErr(ccname).Error())
+		}
+		defbytes, err := proto.Marshal(def)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(defbytes)
+	}
+
+	return shim.Error(InvalidFunctionErr(function).Error())
+}
+
+// aclResources maps the functions to the resources their ACLs are defined for
+var aclResources = map[string]string{
+	APPROVE:             aclmgmt.LifecycleApprove,
+	COMMIT:              aclmgmt.LifecycleCommit,
+	QUERYAPPROVALSTATUS: aclmgmt.LifecycleQueryApprovalStatus,
+	QUERYDEFINITION:     aclmgmt.LifecycleQueryDefinition,
+	GETCCDATA:           aclmgmt.LifecycleGetChaincodeData,
+}
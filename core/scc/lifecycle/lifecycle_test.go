@@ -0,0 +1,374 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/config"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/common/mocks/scc"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/peer"
+	policymocks "github.com/hyperledger/fabric/core/policy/mocks"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testChain = "test"
+
+	// v10Chain is a channel which does not enable the chaincode lifecycle
+	v10Chain = "v10"
+)
+
+type mockIdentity struct {
+	mspID string
+	admin bool
+}
+
+func (id *mockIdentity) SatisfiesPrincipal(p *mspprotos.MSPPrincipal) error {
+	role := &mspprotos.MSPRole{}
+	if err := proto.Unmarshal(p.Principal, role); err != nil {
+		return err
+	}
+	if role.MspIdentifier != id.mspID || (role.Role == mspprotos.MSPRole_ADMIN && !id.admin) {
+		return errors.New("principal not satisfied")
+	}
+	return nil
+}
+
+func (id *mockIdentity) GetIdentifier() *msp.IdentityIdentifier {
+	return &msp.IdentityIdentifier{Mspid: id.mspID, Id: "mock"}
+}
+
+func (id *mockIdentity) GetMSPIdentifier() string {
+	return id.mspID
+}
+
+func (id *mockIdentity) Validate() error {
+	return nil
+}
+
+func (id *mockIdentity) GetOrganizationalUnits() []*msp.OUIdentifier {
+	return nil
+}
+
+func (id *mockIdentity) Verify(msg []byte, sig []byte) error {
+	return nil
+}
+
+func (id *mockIdentity) Serialize() ([]byte, error) {
+	return []byte(id.mspID), nil
+}
+
+type mockDeserializer struct {
+	identities map[string]*mockIdentity
+}
+
+func (d *mockDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	if id, ok := d.identities[string(serializedIdentity)]; ok {
+		return id, nil
+	}
+	return nil, errors.New("unknown identity")
+}
+
+// mockPolicyChecker accepts every proposal and records the
+// signed data the lifecycle policy is evaluated against
+type mockPolicyChecker struct {
+	err        error
+	policyName string
+	sd         []*common.SignedData
+}
+
+func (c *mockPolicyChecker) CheckPolicy(channelID, policyName string, signedProp *pb.SignedProposal) error {
+	return nil
+}
+
+func (c *mockPolicyChecker) CheckPolicyBySignedData(channelID, policyName string, sd []*common.SignedData) error {
+	c.policyName = policyName
+	c.sd = sd
+	return c.err
+}
+
+func (c *mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *pb.SignedProposal) error {
+	return nil
+}
+
+func (c *mockPolicyChecker) CheckPolicyNoChannelBySignedData(policyName string, sd []*common.SignedData) error {
+	return nil
+}
+
+func newLifecycleStub(t *testing.T) (*shim.MockStub, *mockPolicyChecker) {
+	checker := &mockPolicyChecker{}
+	lc := &Lifecycle{}
+	stub := shim.NewMockStub(Name, lc)
+	res := stub.MockInit("1", nil)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	lc.policyChecker = checker
+	lc.aclProvider = aclmgmt.NewACLProvider(checker, nil)
+	lc.policyManagerGetter = &policymocks.MockChannelPolicyManagerGetter{
+		Managers: map[string]policies.Manager{
+			testChain: &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{}},
+		},
+	}
+	lc.deserializerGetter = func(chainID string) msp.IdentityDeserializer {
+		return &mockDeserializer{identities: map[string]*mockIdentity{
+			"org1admin":  {mspID: "Org1", admin: true},
+			"org1member": {mspID: "Org1"},
+			"org2admin":  {mspID: "Org2", admin: true},
+			"org3admin":  {mspID: "Org3", admin: true},
+		}}
+	}
+	lc.capabilitiesGetter = func(chainID string) config.ApplicationCapabilities {
+		return &mockconfig.ApplicationCapabilities{ChaincodeLifecycleVal: chainID == testChain}
+	}
+
+	return stub, checker
+}
+
+func signedProposal(creator string) *pb.SignedProposal {
+	sp, _ := utils.MockSignedEndorserProposalOrPanic(testChain, &pb.ChaincodeSpec{}, []byte(creator), []byte("signature"))
+	return sp
+}
+
+func invoke(stub *shim.MockStub, creator string, function string, arg []byte) pb.Response {
+	return stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte(function), []byte(testChain), arg}, signedProposal(creator))
+}
+
+func definition(sequence int64) *lb.ChaincodeDefinition {
+	return &lb.ChaincodeDefinition{
+		Name:              "mycc",
+		Version:           "1.0",
+		Sequence:          sequence,
+		EndorsementPolicy: []byte("policy"),
+		Escc:              "escc",
+		Vscc:              "vscc",
+		Hash:              []byte("hash"),
+	}
+}
+
+func TestKeys(t *testing.T) {
+	assert.Equal(t, "definitions/mycc", DefinitionKey("mycc"))
+	assert.Equal(t, "approvals/mycc/3/Org1", ApprovalKey("mycc", 3, "Org1"))
+}
+
+func TestInvalidInvocations(t *testing.T) {
+	stub, _ := newLifecycleStub(t)
+
+	res := stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte(APPROVE), []byte(testChain)}, signedProposal("org1admin"))
+	assert.Equal(t, InvalidArgsLenErr(2).Error(), res.Message)
+
+	res = invoke(stub, "org1admin", "foo", nil)
+	assert.Equal(t, InvalidFunctionErr("foo").Error(), res.Message)
+
+	res = invoke(stub, "org1admin", APPROVE, []byte("barf"))
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+
+	def := definition(1)
+	def.Name = "my.cc"
+	res = invoke(stub, "org1admin", APPROVE, utils.MarshalOrPanic(def))
+	assert.Equal(t, InvalidChaincodeNameErr("my.cc").Error(), res.Message)
+
+	def = definition(1)
+	def.Version = "1{}0"
+	res = invoke(stub, "org1admin", APPROVE, utils.MarshalOrPanic(def))
+	assert.Equal(t, InvalidVersionErr("1{}0").Error(), res.Message)
+
+	res = invoke(stub, "org1admin", QUERYDEFINITION, []byte("mycc"))
+	assert.Equal(t, NotFoundErr("mycc").Error(), res.Message)
+
+	res = invoke(stub, "org1admin", GETCCDATA, []byte("mycc"))
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Nil(t, res.Payload)
+}
+
+func TestApprove(t *testing.T) {
+	stub, _ := newLifecycleStub(t)
+	defbytes := utils.MarshalOrPanic(definition(1))
+
+	// only admins can approve for their organization
+	res := invoke(stub, "org1member", APPROVE, defbytes)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "approver is not an admin of Org1")
+
+	// and only for organizations of the channel
+	res = invoke(stub, "org3admin", APPROVE, defbytes)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "Org3 is not a member of channel")
+
+	// the first definition has sequence 1
+	res = invoke(stub, "org1admin", APPROVE, utils.MarshalOrPanic(definition(2)))
+	assert.Contains(t, res.Message, InvalidSequenceErr("mycc: expected 1, got 2").Error())
+
+	res = invoke(stub, "org1admin", APPROVE, defbytes)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	abytes := stub.State[ApprovalKey("mycc", 1, "Org1")]
+	assert.NotNil(t, abytes)
+	approval := &lb.Approval{}
+	assert.NoError(t, proto.Unmarshal(abytes, approval))
+	assert.True(t, proto.Equal(definition(1), approval.Definition))
+
+	sd, err := GetSignedData(approval.SignedProposal)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("org1admin"), sd.Identity)
+
+	res = invoke(stub, "org1admin", QUERYAPPROVALSTATUS, defbytes)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	status := &lb.ApprovalStatus{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, status))
+	assert.Equal(t, map[string]bool{"Org1": true, "Org2": false}, status.Approvals)
+
+	// the package hash is never taken from the peer's installed packages
+	nohash := definition(1)
+	nohash.Hash = nil
+	res = invoke(stub, "org1admin", QUERYAPPROVALSTATUS, utils.MarshalOrPanic(nohash))
+	assert.Equal(t, MissingHashErr("mycc").Error(), res.Message)
+
+	// a different definition is not approved by Org1
+	other := definition(1)
+	other.Version = "2.0"
+	res = invoke(stub, "org1admin", QUERYAPPROVALSTATUS, utils.MarshalOrPanic(other))
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.NoError(t, proto.Unmarshal(res.Payload, status))
+	assert.Equal(t, map[string]bool{"Org1": false, "Org2": false}, status.Approvals)
+}
+
+func TestLifecycleCapability(t *testing.T) {
+	stub, _ := newLifecycleStub(t)
+	defbytes := utils.MarshalOrPanic(definition(1))
+
+	for _, function := range []string{APPROVE, COMMIT} {
+		res := stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte(function), []byte(v10Chain), defbytes}, signedProposal("org1admin"))
+		assert.Equal(t, int32(shim.ERROR), res.Status)
+		assert.Contains(t, res.Message, "requires the V1_2 application capability")
+	}
+}
+
+func TestCommit(t *testing.T) {
+	stub, checker := newLifecycleStub(t)
+	defbytes := utils.MarshalOrPanic(definition(1))
+
+	// the package hash has to be supplied
+	nohash := definition(1)
+	nohash.Hash = nil
+	res := invoke(stub, "org1member", COMMIT, utils.MarshalOrPanic(nohash))
+	assert.Equal(t, MissingHashErr("mycc").Error(), res.Message)
+
+	// nobody approved yet
+	res = invoke(stub, "org1member", COMMIT, defbytes)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "no organization approved")
+
+	res = invoke(stub, "org1admin", APPROVE, defbytes)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	other := definition(1)
+	other.Version = "2.0"
+	res = invoke(stub, "org2admin", APPROVE, utils.MarshalOrPanic(other))
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	// the policy is not satisfied
+	checker.err = errors.New("not enough approvals")
+	res = invoke(stub, "org1member", COMMIT, defbytes)
+	assert.Equal(t, NotApprovedErr("not enough approvals").Error(), res.Message)
+	assert.Nil(t, stub.State[DefinitionKey("mycc")])
+
+	// only the approval of the committed definition is evaluated
+	checker.err = nil
+	res = invoke(stub, "org1member", COMMIT, defbytes)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, policies.ChannelApplicationLifecycleEndorsement, checker.policyName)
+	assert.Len(t, checker.sd, 1)
+	assert.Equal(t, []byte("org1admin"), checker.sd[0].Identity)
+
+	res = invoke(stub, "org1member", QUERYDEFINITION, []byte("mycc"))
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	def := &lb.ChaincodeDefinition{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, def))
+	assert.True(t, proto.Equal(definition(1), def))
+
+	res = invoke(stub, "org1member", GETCCDATA, []byte("mycc"))
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	cd := &ccprovider.ChaincodeData{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, cd))
+	assert.Equal(t, "mycc", cd.Name)
+	assert.Equal(t, "1.0", cd.Version)
+	assert.Equal(t, "vscc", cd.Vscc)
+	assert.Equal(t, []byte("policy"), cd.Policy)
+	assert.Equal(t, []byte("hash"), cd.Id)
+
+	// the same sequence cannot be committed twice
+	res = invoke(stub, "org1member", COMMIT, defbytes)
+	assert.Contains(t, res.Message, InvalidSequenceErr("mycc: expected 2, got 1").Error())
+
+	// escc and vscc have to be system chaincodes
+	def = definition(2)
+	def.Vscc = "mycc"
+	res = invoke(stub, "org1member", COMMIT, utils.MarshalOrPanic(def))
+	assert.Equal(t, "mycc is not a valid validation system chaincode", res.Message)
+
+	// chaincodes requiring initialization are reported as such
+	def = definition(2)
+	def.InitRequired = true
+	defbytes = utils.MarshalOrPanic(def)
+	res = invoke(stub, "org1admin", APPROVE, defbytes)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	res = invoke(stub, "org1member", COMMIT, defbytes)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	res = invoke(stub, "org1member", GETCCDATA, []byte("mycc"))
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	cd = &ccprovider.ChaincodeData{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, cd))
+	assert.True(t, cd.InitRequired)
+}
+
+func TestPolicyName(t *testing.T) {
+	assert.Equal(t, policies.ChannelApplicationLifecycleEndorsement, PolicyName(&policymocks.MockChannelPolicyManager{}))
+	assert.Equal(t, policies.ChannelApplicationAdmins, PolicyName(&noPolicyManager{}))
+}
+
+type noPolicyManager struct {
+	policymocks.MockChannelPolicyManager
+}
+
+func (m *noPolicyManager) GetPolicy(id string) (policies.Policy, bool) {
+	return nil, false
+}
+
+func TestMain(m *testing.M) {
+	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{})
+	peer.MockSetMSPIDGetter(func(cid string) []string {
+		return []string{"Org1", "Org2"}
+	})
+
+	os.Exit(m.Run())
+}
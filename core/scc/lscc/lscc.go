@@ -22,15 +22,15 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/flogging"
-	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/peer"
-	"github.com/hyperledger/fabric/core/policy"
 	"github.com/hyperledger/fabric/core/policyprovider"
-	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/core/scc/lifecycle"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -89,9 +89,13 @@ type LifeCycleSysCC struct {
 	// import cycles
 	sccprovider sysccprovider.SystemChaincodeProvider
 
-	// policyChecker is the interface used to perform
+	// aclProvider is the interface used to perform
 	// access control
-	policyChecker policy.PolicyChecker
+	aclProvider aclmgmt.ACLProvider
+
+	// capabilitiesGetter returns the application capabilities of
+	// a channel, which tell whether the lifecycle scc is consulted
+	capabilitiesGetter func(chainID string) config.ApplicationCapabilities
 }
 
 //----------------errors---------------
@@ -236,6 +240,13 @@ func (f InstantiationPolicyMissing) Error() string {
 	return "instantiation policy missing"
 }
 
+//ManagedByLifecycleErr the chaincode is defined through the lifecycle system chaincode
+type ManagedByLifecycleErr string
+
+func (f ManagedByLifecycleErr) Error() string {
+	return fmt.Sprintf("chaincode %s is managed by %s and cannot be upgraded through lscc", string(f), lifecycle.Name)
+}
+
 //-------------- helper functions ------------------
 //create the chaincode on the given chain
 func (lscc *LifeCycleSysCC) createChaincode(stub shim.ChaincodeStubInterface, cd *ccprovider.ChaincodeData) error {
@@ -284,6 +295,24 @@ func (lscc *LifeCycleSysCC) getCCInstance(stub shim.ChaincodeStubInterface, ccna
 	return cdbytes, nil
 }
 
+//returns the ChaincodeData of chaincodes defined through the lifecycle
+//system chaincode, or nil if the chaincode is not defined there or the
+//channel does not enable the lifecycle system chaincode
+func (lscc *LifeCycleSysCC) getLifecycleCCData(stub shim.ChaincodeStubInterface, chainname string, ccname string) ([]byte, error) {
+	if ac := lscc.capabilitiesGetter(chainname); ac == nil || !ac.ChaincodeLifecycle() {
+		return nil, nil
+	}
+
+	res := stub.InvokeChaincode(lifecycle.Name, [][]byte{[]byte(lifecycle.GETCCDATA), []byte(chainname), []byte(ccname)}, "")
+	if res.Status != shim.OK {
+		return nil, fmt.Errorf("failed querying %s for chaincode %s: %s", lifecycle.Name, ccname, res.Message)
+	}
+	if len(res.Payload) == 0 {
+		return nil, nil
+	}
+	return res.Payload, nil
+}
+
 //gets the cd out of the bytes
 func (lscc *LifeCycleSysCC) getChaincodeData(ccname string, cdbytes []byte) (*ccprovider.ChaincodeData, error) {
 	cd := &ccprovider.ChaincodeData{}
@@ -475,6 +504,9 @@ func (lscc *LifeCycleSysCC) executeInstall(stub shim.ChaincodeStubInterface, ccb
 		return fmt.Errorf("Error installing chaincode code %s:%s(%s)", cds.ChaincodeSpec.ChaincodeId.Name, cds.ChaincodeSpec.ChaincodeId.Version, err)
 	}
 
+	// let the other peers of the channels know about the new chaincode
+	peer.UpdateInstalledChaincodes()
+
 	return err
 }
 
@@ -573,6 +605,15 @@ func (lscc *LifeCycleSysCC) executeDeploy(stub shim.ChaincodeStubInterface, chai
 		return nil, ExistsErr(cds.ChaincodeSpec.ChaincodeId.Name)
 	}
 
+	//chaincodes defined by the organizations cannot be instantiated
+	cdbytes, err := lscc.getLifecycleCCData(stub, chainname, cds.ChaincodeSpec.ChaincodeId.Name)
+	if err != nil {
+		return nil, err
+	}
+	if cdbytes != nil {
+		return nil, ExistsErr(cds.ChaincodeSpec.ChaincodeId.Name)
+	}
+
 	//get the chaincode from the FS
 	ccpack, err := ccprovider.GetChaincodeFromFS(cds.ChaincodeSpec.ChaincodeId.Name, cds.ChaincodeSpec.ChaincodeId.Version)
 	if err != nil {
@@ -622,6 +663,15 @@ func (lscc *LifeCycleSysCC) executeUpgrade(stub shim.ChaincodeStubInterface, cha
 		return nil, err
 	}
 
+	// chaincodes defined by the organizations are upgraded by committing a new definition
+	lcbytes, err := lscc.getLifecycleCCData(stub, chainName, chaincodeName)
+	if err != nil {
+		return nil, err
+	}
+	if lcbytes != nil {
+		return nil, ManagedByLifecycleErr(chaincodeName)
+	}
+
 	// check for existence of chaincode instance only (it has to exist on the channel)
 	// we dont care about the old chaincode on the FS. In particular, user may even
 	// have deleted it
@@ -687,8 +737,10 @@ func (lscc *LifeCycleSysCC) executeUpgrade(stub shim.ChaincodeStubInterface, cha
 func (lscc *LifeCycleSysCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
 	lscc.sccprovider = sysccprovider.GetSystemChaincodeProvider()
 
-	// Init policy checker for access control
-	lscc.policyChecker = policyprovider.GetPolicyChecker()
+	// Init ACL provider for access control
+	lscc.aclProvider = aclmgmt.NewACLProvider(policyprovider.GetPolicyChecker(), peer.NewPolicyMapperGetter())
+
+	lscc.capabilitiesGetter = peer.GetApplicationCapabilities
 
 	return shim.Success(nil)
 }
@@ -719,8 +771,8 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 			return shim.Error(InvalidArgsLenErr(len(args)).Error())
 		}
 
-		// 2. check local MSP Admins policy
-		if err = lscc.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+		// 2. check the ACL of installing, by default the local MSP Admins policy
+		if err = lscc.aclProvider.CheckACL(aclmgmt.LsccInstall, "", sp); err != nil {
 			return shim.Error(fmt.Sprintf("Authorization for INSTALL has been denied (error-%s)", err))
 		}
 
@@ -848,18 +900,27 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 		chain := string(args[1])
 		ccname := string(args[2])
 
-		// 2. check local Channel Readers policy
+		// 2. check the ACL of the function on the channel
 		// Notice that this information are already available on the ledger
-		// therefore we enforce here that the caller is reader of the channel.
-		if err = lscc.policyChecker.CheckPolicy(chain, policies.ChannelApplicationReaders, sp); err != nil {
+		// therefore by default we enforce that the caller is reader of the channel.
+		if err = lscc.aclProvider.CheckACL(aclResources[function], chain, sp); err != nil {
 			return shim.Error(fmt.Sprintf("Authorization for %s on channel %s has been denied with error %s", function, args[1], err))
 		}
 
-		cdbytes, err := lscc.getCCInstance(stub, ccname)
+		// definitions committed through the lifecycle system chaincode
+		// take precedence over chaincodes instantiated through lscc
+		cdbytes, err := lscc.getLifecycleCCData(stub, chain, ccname)
 		if err != nil {
 			logger.Errorf("error getting chaincode %s on channel: %s(err:%s)", ccname, chain, err)
 			return shim.Error(err.Error())
 		}
+		if cdbytes == nil {
+			cdbytes, err = lscc.getCCInstance(stub, ccname)
+			if err != nil {
+				logger.Errorf("error getting chaincode %s on channel: %s(err:%s)", ccname, chain, err)
+				return shim.Error(err.Error())
+			}
+		}
 
 		switch function {
 		case GETCCINFO:
@@ -882,9 +943,13 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 			return shim.Error(InvalidArgsLenErr(len(args)).Error())
 		}
 
-		// 2. check local MSP Admins policy
-		if err = lscc.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
-			return shim.Error(fmt.Sprintf("Authorization for GETCHAINCODES on channel %s has been denied with error %s", args[0], err))
+		// 2. check the ACL of the channel, by default the local MSP Admins policy
+		chain, err := getChannelID(sp)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if err = lscc.aclProvider.CheckACL(aclmgmt.LsccGetInstantiatedChaincodes, chain, sp); err != nil {
+			return shim.Error(fmt.Sprintf("Authorization for GETCHAINCODES on channel %s has been denied with error %s", chain, err))
 		}
 
 		return lscc.getChaincodes(stub)
@@ -893,8 +958,8 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 			return shim.Error(InvalidArgsLenErr(len(args)).Error())
 		}
 
-		// 2. check local MSP Admins policy
-		if err = lscc.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+		// 2. check the ACL of listing installed chaincodes, by default the local MSP Admins policy
+		if err = lscc.aclProvider.CheckACL(aclmgmt.LsccGetInstalledChaincodes, "", sp); err != nil {
 			return shim.Error(fmt.Sprintf("Authorization for GETINSTALLEDCHAINCODES on channel %s has been denied with error %s", args[0], err))
 		}
 
@@ -903,3 +968,27 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 
 	return shim.Error(InvalidFunctionErr(function).Error())
 }
+
+// aclResources maps the query functions to the resources their ACLs are defined for
+var aclResources = map[string]string{
+	GETCCINFO:  aclmgmt.LsccGetChaincodeInfo,
+	GETDEPSPEC: aclmgmt.LsccGetDeploymentSpec,
+	GETCCDATA:  aclmgmt.LsccGetChaincodeData,
+}
+
+// getChannelID returns the channel the signed proposal was sent on
+func getChannelID(sp *pb.SignedProposal) (string, error) {
+	prop, err := utils.GetProposal(sp.ProposalBytes)
+	if err != nil {
+		return "", fmt.Errorf("Failed extracting the proposal, %s", err)
+	}
+	hdr, err := utils.GetHeader(prop.Header)
+	if err != nil {
+		return "", fmt.Errorf("Failed extracting the proposal header, %s", err)
+	}
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return "", fmt.Errorf("Failed extracting the proposal channel header, %s", err)
+	}
+	return chdr.ChannelId, nil
+}
@@ -27,9 +27,12 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/config"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/common/mocks/scc"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccpackage"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
@@ -38,6 +41,7 @@ import (
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/policy"
 	policymocks "github.com/hyperledger/fabric/core/policy/mocks"
+	"github.com/hyperledger/fabric/core/scc/lifecycle"
 	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
@@ -77,6 +81,32 @@ func constructDeploymentSpec(name string, path string, version string, initArgs
 	return chaincodeDeploymentSpec, nil
 }
 
+// mockLifecycle stands in for the lifecycle system chaincode and
+// returns the ChaincodeData of the chaincodes it was set up with
+type mockLifecycle struct {
+	ccdata map[string][]byte
+}
+
+func (m *mockLifecycle) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+func (m *mockLifecycle) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	args := stub.GetArgs()
+	if len(args) != 3 || string(args[0]) != lifecycle.GETCCDATA {
+		return shim.Error("unexpected invocation of the lifecycle mock")
+	}
+	return shim.Success(m.ccdata[string(args[2])])
+}
+
+// newMockStub returns a mock stub for lscc able to query a mock
+// lifecycle system chaincode holding the supplied definitions
+func newMockStub(lscc *LifeCycleSysCC, ccdata map[string][]byte) *shim.MockStub {
+	stub := shim.NewMockStub("lscc", lscc)
+	stub.MockPeerChaincode(lifecycle.Name, shim.NewMockStub(lifecycle.Name, &mockLifecycle{ccdata: ccdata}))
+	return stub
+}
+
 //TestInstall tests the install function with various inputs
 func TestInstall(t *testing.T) {
 	path := "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02"
@@ -91,7 +121,7 @@ func TestInstall(t *testing.T) {
 
 func testInstall(t *testing.T, ccname string, version string, path string, expectedErrorMsg string, caller string) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		fmt.Println("Init failed", string(res.Message))
@@ -105,10 +135,13 @@ func testInstall(t *testing.T, ccname string, version string, path string, expec
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 
 	cds, err := constructDeploymentSpec(ccname, path, version, [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, false)
@@ -177,7 +210,7 @@ func testInstall(t *testing.T, ccname string, version string, path string, expec
 //TestReinstall tests the install function
 func TestReinstall(t *testing.T) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		fmt.Println("Init failed", string(res.Message))
@@ -205,7 +238,7 @@ func TestReinstall(t *testing.T) {
 //TestInvalidCodeDeploy tests the deploy function with invalid code package
 func TestInvalidCodeDeploy(t *testing.T) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		fmt.Println("Init failed", string(res.Message))
@@ -245,7 +278,7 @@ func TestDeploy(t *testing.T) {
 
 func testDeploy(t *testing.T, ccname string, version string, path string, forceBlankCCName bool, forceBlankVersion bool, expectedErrorMsg string) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		t.Logf("Init failed: %s", string(res.Message))
@@ -259,10 +292,13 @@ func testDeploy(t *testing.T, ccname string, version string, path string, forceB
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -339,7 +375,7 @@ func testDeploy(t *testing.T, ccname string, version string, path string, forceB
 //TestRedeploy tests the redeploying will fail function(and fail with "exists" error)
 func TestRedeploy(t *testing.T) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		fmt.Println("Init failed", string(res.Message))
@@ -374,7 +410,7 @@ func TestRedeploy(t *testing.T) {
 //TestMultipleDeploy tests deploying multiple chaincodeschaincodes
 func TestMultipleDeploy(t *testing.T) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		fmt.Println("Init failed", string(res.Message))
@@ -388,10 +424,13 @@ func TestMultipleDeploy(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -461,7 +500,7 @@ func TestMultipleDeploy(t *testing.T) {
 //TestRetryFailedDeploy tests re-deploying after a failure
 func TestRetryFailedDeploy(t *testing.T) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		fmt.Println("Init failed", string(res.Message))
@@ -474,10 +513,13 @@ func TestRetryFailedDeploy(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -524,7 +566,7 @@ func TestRetryFailedDeploy(t *testing.T) {
 //TestTamperChaincode modifies the chaincode on the FS after deploy
 func TestTamperChaincode(t *testing.T) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		fmt.Println("Init failed", string(res.Message))
@@ -538,10 +580,13 @@ func TestTamperChaincode(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -622,7 +667,7 @@ func TestTamperChaincode(t *testing.T) {
 //TestIPolDeployFail tests chaincode deploy with an instantiation default policy if the cc package comes without a policy
 func TestIPolDeployDefaultFail(t *testing.T) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		t.Fatalf("Init failed: %s", string(res.Message))
@@ -635,10 +680,13 @@ func TestIPolDeployDefaultFail(t *testing.T) {
 			chainid: &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -668,7 +716,7 @@ func TestIPolDeploy(t *testing.T) {
 
 func testIPolDeploy(t *testing.T, iPol string, successExpected bool) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		t.Fatalf("Init failed [%s]", string(res.Message))
@@ -681,10 +729,13 @@ func testIPolDeploy(t *testing.T, iPol string, successExpected bool) {
 			chainid: &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -747,7 +798,7 @@ func TestUpgrade(t *testing.T) {
 
 func testUpgrade(t *testing.T, ccname string, version string, newccname string, newversion string, path string, expectedErrorMsg string) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		fmt.Println("Init failed", string(res.Message))
@@ -813,6 +864,75 @@ func testUpgrade(t *testing.T, ccname string, version string, newccname string,
 	}
 }
 
+//TestLifecycleManaged tests that definitions of the lifecycle system
+//chaincode take precedence over lscc
+func TestLifecycleManaged(t *testing.T) {
+	path := "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02"
+
+	lcd := &ccprovider.ChaincodeData{Name: "lcc", Version: "1", Escc: "escc", Vscc: "vscc", Id: []byte("hash")}
+	lcdbytes, err := proto.Marshal(lcd)
+	assert.NoError(t, err)
+
+	scc := new(LifeCycleSysCC)
+	stub := newMockStub(scc, map[string][]byte{"lcc": lcdbytes})
+	res := stub.MockInit("1", nil)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	identityDeserializer := &policymocks.MockIdentityDeserializer{[]byte("Alice"), []byte("msg1")}
+	policyManagerGetter := &policymocks.MockChannelPolicyManagerGetter{
+		Managers: map[string]policies.Manager{
+			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
+		},
+	}
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
+	)
+	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
+	identityDeserializer.Msg = sProp.ProposalBytes
+	sProp.Signature = sProp.ProposalBytes
+
+	// the lifecycle is not consulted unless the channel enables it
+	ac := &mockconfig.ApplicationCapabilities{}
+	scc.capabilitiesGetter = func(chainID string) config.ApplicationCapabilities {
+		return ac
+	}
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte(GETCCDATA), []byte("test"), []byte("lcc")}, sProp)
+	assert.Equal(t, NotFoundErr("lcc").Error(), res.Message)
+
+	// queries return the lifecycle definition
+	ac.ChaincodeLifecycleVal = true
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte(GETCCDATA), []byte("test"), []byte("lcc")}, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, lcdbytes, res.Payload)
+
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte(GETCCINFO), []byte("test"), []byte("lcc")}, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, "lcc", string(res.Payload))
+
+	// chaincodes unknown to both are still not found
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte(GETCCDATA), []byte("test"), []byte("unknown")}, sProp)
+	assert.Equal(t, NotFoundErr("unknown").Error(), res.Message)
+
+	// instantiating a chaincode defined through the lifecycle fails
+	cds, err := constructDeploymentSpec("lcc", path, "0", [][]byte{[]byte("init")}, true)
+	assert.NoError(t, err)
+	defer os.Remove(lscctestpath + "/lcc.0")
+	b, err := proto.Marshal(cds)
+	assert.NoError(t, err)
+
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte(DEPLOY), []byte("test"), b}, sProp)
+	assert.Equal(t, ExistsErr("lcc").Error(), res.Message)
+
+	// and so does upgrading it
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte(UPGRADE), []byte("test"), b}, sProp)
+	assert.Equal(t, ManagedByLifecycleErr("lcc").Error(), res.Message)
+}
+
 //TestIPolUpgrade tests chaincode deploy with an instantiation policy
 func TestIPolUpgrade(t *testing.T) {
 	// default policy, this should succeed
@@ -824,7 +944,7 @@ func TestIPolUpgrade(t *testing.T) {
 func testIPolUpgrade(t *testing.T, iPol string, successExpected bool) {
 	// deploy version 0 with a default instantiation policy, this should succeed in any case
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		t.Fatalf("Init failed %s", string(res.Message))
 	}
@@ -835,10 +955,13 @@ func testIPolUpgrade(t *testing.T, iPol string, successExpected bool) {
 			chainid: &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -912,7 +1035,7 @@ func testIPolUpgrade(t *testing.T, iPol string, successExpected bool) {
 //ledger but not on FS
 func TestGetAPIsWithoutInstall(t *testing.T) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		fmt.Println("Init failed", string(res.Message))
@@ -925,10 +1048,13 @@ func TestGetAPIsWithoutInstall(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
 	identityDeserializer.Msg = sProp.ProposalBytes
@@ -1012,7 +1138,7 @@ func TestGetAPIsWithoutInstall(t *testing.T) {
 // the GETINSTALLEDCHAINCODES function
 func TestGetInstalledChaincodesAccessRights(t *testing.T) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		fmt.Println("Init failed", string(res.Message))
@@ -1026,10 +1152,13 @@ func TestGetInstalledChaincodesAccessRights(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 
 	// Should pass
@@ -1056,7 +1185,7 @@ func TestGetInstalledChaincodesAccessRights(t *testing.T) {
 // the GETCHAINCODES function
 func TestGetChaincodesAccessRights(t *testing.T) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		fmt.Println("Init failed", string(res.Message))
@@ -1070,10 +1199,13 @@ func TestGetChaincodesAccessRights(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 
 	// Should pass
@@ -1100,7 +1232,7 @@ func TestGetChaincodesAccessRights(t *testing.T) {
 // the GETCCINFO function
 func TestGetCCAccessRights(t *testing.T) {
 	scc := new(LifeCycleSysCC)
-	stub := shim.NewMockStub("lscc", scc)
+	stub := newMockStub(scc, nil)
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
 		fmt.Println("Init failed", string(res.Message))
@@ -1114,10 +1246,13 @@ func TestGetCCAccessRights(t *testing.T) {
 			"test": &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{Deserializer: identityDeserializer}},
 		},
 	}
-	scc.policyChecker = policy.NewPolicyChecker(
-		policyManagerGetter,
-		identityDeserializer,
-		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	scc.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			policyManagerGetter,
+			identityDeserializer,
+			&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+		),
+		nil,
 	)
 
 	cds, err := constructDeploymentSpec("example02", "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02", "0", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, true)
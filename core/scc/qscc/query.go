@@ -22,7 +22,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 
-	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
@@ -38,7 +38,7 @@ import (
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
 type LedgerQuerier struct {
-	policyChecker policy.PolicyChecker
+	aclProvider aclmgmt.ACLProvider
 }
 
 var qscclogger = flogging.MustGetLogger("qscc")
@@ -52,17 +52,29 @@ const (
 	GetBlockByTxID     string = "GetBlockByTxID"
 )
 
+// aclResources maps the functions to the resources their ACLs are defined for
+var aclResources = map[string]string{
+	GetChainInfo:       aclmgmt.QsccGetChainInfo,
+	GetBlockByNumber:   aclmgmt.QsccGetBlockByNumber,
+	GetBlockByHash:     aclmgmt.QsccGetBlockByHash,
+	GetTransactionByID: aclmgmt.QsccGetTransactionByID,
+	GetBlockByTxID:     aclmgmt.QsccGetBlockByTxID,
+}
+
 // Init is called once per chain when the chain is created.
 // This allows the chaincode to initialize any variables on the ledger prior
 // to any transaction execution on the chain.
 func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 	qscclogger.Info("Init QSCC")
 
-	// Init policy checker for access control
-	e.policyChecker = policy.NewPolicyChecker(
-		peer.NewChannelPolicyManagerGetter(),
-		mgmt.GetLocalMSP(),
-		mgmt.NewLocalMSPPrincipalGetter(),
+	// Init ACL provider for access control
+	e.aclProvider = aclmgmt.NewACLProvider(
+		policy.NewPolicyChecker(
+			peer.NewChannelPolicyManagerGetter(),
+			mgmt.GetLocalMSP(),
+			mgmt.NewLocalMSPPrincipalGetter(),
+		),
+		peer.NewPolicyMapperGetter(),
 	)
 
 	return shim.Success(nil)
@@ -102,8 +114,12 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return shim.Error(fmt.Sprintf("Failed getting signed proposal from stub, %s: %s", cid, err))
 	}
 
-	// 2. check the channel reader policy
-	if err = e.policyChecker.CheckPolicy(cid, policies.ChannelApplicationReaders, sp); err != nil {
+	// 2. check the ACL of the function on the channel
+	resName, ok := aclResources[fname]
+	if !ok {
+		return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
+	}
+	if err = e.aclProvider.CheckACL(resName, cid, sp); err != nil {
 		return shim.Error(fmt.Sprintf("Authorization request failed %s: %s", cid, err))
 	}
 
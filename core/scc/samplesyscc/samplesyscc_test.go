@@ -232,3 +232,7 @@ func (*mockStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
 func (*mockStub) SetEvent(name string, payload []byte) error {
 	panic("implement me")
 }
+
+func (*mockStub) IsInit() bool {
+	panic("implement me")
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package vscc
 
 import (
+	"bytes"
 	"fmt"
 
 	"errors"
@@ -25,16 +26,22 @@ import (
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/core/policy"
+	"github.com/hyperledger/fabric/core/policyprovider"
+	"github.com/hyperledger/fabric/core/scc/lifecycle"
 	"github.com/hyperledger/fabric/core/scc/lscc"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/hyperledger/fabric/protos/utils"
 )
 
@@ -52,11 +59,18 @@ type ValidatorOneValidSignature struct {
 	// methods of the system chaincode package without
 	// import cycles
 	sccprovider sysccprovider.SystemChaincodeProvider
+
+	// policyChecker and policyManagerGetter are used to
+	// evaluate the approvals of committed chaincode definitions
+	policyChecker       policy.PolicyChecker
+	policyManagerGetter policies.ChannelPolicyManagerGetter
 }
 
 // Init is called once when the chaincode started the first time
 func (vscc *ValidatorOneValidSignature) Init(stub shim.ChaincodeStubInterface) pb.Response {
 	vscc.sccprovider = sysccprovider.GetSystemChaincodeProvider()
+	vscc.policyChecker = policyprovider.GetPolicyChecker()
+	vscc.policyManagerGetter = peer.NewChannelPolicyManagerGetter()
 
 	return shim.Success(nil)
 }
@@ -171,6 +185,17 @@ func (vscc *ValidatorOneValidSignature) Invoke(stub shim.ChaincodeStubInterface)
 				return shim.Error(err.Error())
 			}
 		}
+
+		// do some extra validation that is specific to the lifecycle scc
+		if hdrExt.ChaincodeId.Name == lifecycle.Name {
+			logger.Debugf("VSCC info: doing special validation for %s", lifecycle.Name)
+
+			err = vscc.ValidateLifecycleInvocation(chdr.ChannelId, cap, payl)
+			if err != nil {
+				logger.Errorf("VSCC error: ValidateLifecycleInvocation failed, err %s", err)
+				return shim.Error(err.Error())
+			}
+		}
 	}
 
 	logger.Debugf("VSCC exists successfully")
@@ -394,6 +419,197 @@ func (vscc *ValidatorOneValidSignature) ValidateLSCCInvocation(stub shim.Chainco
 	}
 }
 
+// ValidateLifecycleInvocation checks that approvals are recorded for the
+// organization of the submitter and that committed definitions were
+// approved by enough organizations of the channel
+func (vscc *ValidatorOneValidSignature) ValidateLifecycleInvocation(chid string, cap *pb.ChaincodeActionPayload, payl *common.Payload) error {
+	cpp, err := utils.GetChaincodeProposalPayload(cap.ChaincodeProposalPayload)
+	if err != nil {
+		logger.Errorf("VSCC error: GetChaincodeProposalPayload failed, err %s", err)
+		return err
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{}
+	err = proto.Unmarshal(cpp.Input, cis)
+	if err != nil {
+		logger.Errorf("VSCC error: Unmarshal ChaincodeInvocationSpec failed, err %s", err)
+		return err
+	}
+
+	if cis.ChaincodeSpec == nil ||
+		cis.ChaincodeSpec.Input == nil ||
+		len(cis.ChaincodeSpec.Input.Args) == 0 ||
+		cap.Action == nil || cap.Action.ProposalResponsePayload == nil {
+		logger.Errorf("VSCC error: committing invalid %s invocation", lifecycle.Name)
+		return fmt.Errorf("VSCC error: committing invalid %s invocation", lifecycle.Name)
+	}
+
+	lcFunc := string(cis.ChaincodeSpec.Input.Args[0])
+	if lcFunc != lifecycle.APPROVE && lcFunc != lifecycle.COMMIT {
+		return fmt.Errorf("VSCC error: committing an invocation of function %s of %s is invalid", lcFunc, lifecycle.Name)
+	}
+
+	// get the rwset
+	pRespPayload, err := utils.GetProposalResponsePayload(cap.Action.ProposalResponsePayload)
+	if err != nil {
+		return fmt.Errorf("GetProposalResponsePayload error %s", err)
+	}
+	if pRespPayload.Extension == nil {
+		return fmt.Errorf("nil pRespPayload.Extension")
+	}
+	respPayload, err := utils.GetChaincodeAction(pRespPayload.Extension)
+	if err != nil {
+		return fmt.Errorf("GetChaincodeAction error %s", err)
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return fmt.Errorf("txRWSet.FromProtoBytes error %s", err)
+	}
+
+	// there has to be a single write to the lifecycle namespace and
+	// no write to any other namespace
+	var lcrwset *kvrwset.KVRWSet
+	for _, ns := range txRWSet.NsRwSets {
+		if ns.NameSpace == lifecycle.Name {
+			lcrwset = ns.KvRwSet
+		} else if len(ns.KvRwSet.Writes) > 0 {
+			return fmt.Errorf("%s invocation is attempting to write to namespace %s", lifecycle.Name, ns.NameSpace)
+		}
+	}
+	if lcrwset == nil || len(lcrwset.Writes) != 1 {
+		return fmt.Errorf("%s can only issue a single putState upon %s", lifecycle.Name, lcFunc)
+	}
+	write := lcrwset.Writes[0]
+
+	switch lcFunc {
+	case lifecycle.APPROVE:
+		approval := &lb.Approval{}
+		if err = proto.Unmarshal(write.Value, approval); err != nil {
+			return fmt.Errorf("Unmarshalling of Approval failed, error %s", err)
+		}
+		if approval.Definition == nil {
+			return fmt.Errorf("Approval does not contain a chaincode definition")
+		}
+
+		// the approval has to be the proposal of the submitter
+		shdr, err := utils.GetSignatureHeader(payl.Header.SignatureHeader)
+		if err != nil {
+			return err
+		}
+		sd, err := lifecycle.GetSignedData(approval.SignedProposal)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(sd.Identity, shdr.Creator) {
+			return fmt.Errorf("Approval was not signed by the submitter of the transaction")
+		}
+
+		// and the submitter an admin of the organization it approves for
+		mspID, err := lifecycle.CheckApprover(mspmgmt.GetIdentityDeserializer(chid), shdr.Creator)
+		if err != nil {
+			return err
+		}
+
+		if err = vscc.checkLifecycleSequence(chid, approval.Definition); err != nil {
+			return err
+		}
+
+		key := lifecycle.ApprovalKey(approval.Definition.Name, approval.Definition.Sequence, mspID)
+		if write.Key != key {
+			return fmt.Errorf("Expected key %s, found %s", key, write.Key)
+		}
+	case lifecycle.COMMIT:
+		def := &lb.ChaincodeDefinition{}
+		if err = proto.Unmarshal(write.Value, def); err != nil {
+			return fmt.Errorf("Unmarshalling of ChaincodeDefinition failed, error %s", err)
+		}
+
+		key := lifecycle.DefinitionKey(def.Name)
+		if write.Key != key {
+			return fmt.Errorf("Expected key %s, found %s", key, write.Key)
+		}
+
+		if err = vscc.checkLifecycleSequence(chid, def); err != nil {
+			return err
+		}
+
+		if err = vscc.checkLifecycleApprovals(chid, def); err != nil {
+			return err
+		}
+	}
+
+	// all is good!
+	return nil
+}
+
+// checkLifecycleSequence checks that the definition follows the
+// one committed on the ledger
+func (vscc *ValidatorOneValidSignature) checkLifecycleSequence(chid string, def *lb.ChaincodeDefinition) error {
+	qe, err := vscc.sccprovider.GetQueryExecutorForLedger(chid)
+	if err != nil {
+		return fmt.Errorf("Could not retrieve QueryExecutor for channel %s, error %s", chid, err)
+	}
+	defer qe.Done()
+
+	defbytes, err := qe.GetState(lifecycle.Name, lifecycle.DefinitionKey(def.Name))
+	if err != nil {
+		return fmt.Errorf("Could not retrieve definition of chaincode %s on channel %s, error %s", def.Name, chid, err)
+	}
+
+	current := &lb.ChaincodeDefinition{}
+	if err = proto.Unmarshal(defbytes, current); err != nil {
+		return fmt.Errorf("Unmarshalling of ChaincodeDefinition failed, error %s", err)
+	}
+
+	if def.Sequence != current.Sequence+1 {
+		return fmt.Errorf("Expected sequence %d for chaincode %s, found %d", current.Sequence+1, def.Name, def.Sequence)
+	}
+	return nil
+}
+
+// checkLifecycleApprovals evaluates the approvals recorded on the
+// ledger for the definition against the channel's lifecycle policy
+func (vscc *ValidatorOneValidSignature) checkLifecycleApprovals(chid string, def *lb.ChaincodeDefinition) error {
+	qe, err := vscc.sccprovider.GetQueryExecutorForLedger(chid)
+	if err != nil {
+		return fmt.Errorf("Could not retrieve QueryExecutor for channel %s, error %s", chid, err)
+	}
+	defer qe.Done()
+
+	var approvals []*lb.Approval
+	for _, mspID := range peer.GetMSPIDs(chid) {
+		abytes, err := qe.GetState(lifecycle.Name, lifecycle.ApprovalKey(def.Name, def.Sequence, mspID))
+		if err != nil {
+			return fmt.Errorf("Could not retrieve approval of %s on channel %s, error %s", mspID, chid, err)
+		}
+		if abytes == nil {
+			continue
+		}
+		approval := &lb.Approval{}
+		if err = proto.Unmarshal(abytes, approval); err != nil {
+			return fmt.Errorf("Unmarshalling of Approval failed, error %s", err)
+		}
+		approvals = append(approvals, approval)
+	}
+
+	sd, err := lifecycle.SignedApprovals(def, approvals)
+	if err != nil {
+		return err
+	}
+	if len(sd) == 0 {
+		return fmt.Errorf("Chaincode definition %d of %s was not approved", def.Sequence, def.Name)
+	}
+
+	pm, ok := vscc.policyManagerGetter.Manager(chid)
+	if pm == nil || !ok {
+		return fmt.Errorf("Could not retrieve policy manager for channel %s", chid)
+	}
+	if err = vscc.policyChecker.CheckPolicyBySignedData(chid, lifecycle.PolicyName(pm), sd); err != nil {
+		return fmt.Errorf("Chaincode definition %d of %s was not approved by enough organizations, error %s", def.Sequence, def.Name, err)
+	}
+	return nil
+}
+
 func (vscc *ValidatorOneValidSignature) getInstantiatedCC(chid, ccid string) (cd *ccprovider.ChaincodeData, exists bool, err error) {
 	qe, err := vscc.sccprovider.GetQueryExecutorForLedger(chid)
 	if err != nil {
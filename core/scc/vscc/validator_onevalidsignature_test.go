@@ -28,6 +28,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/policies"
 	lm "github.com/hyperledger/fabric/common/mocks/ledger"
 	"github.com/hyperledger/fabric/common/mocks/scc"
 	"github.com/hyperledger/fabric/common/util"
@@ -39,6 +40,8 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	per "github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/policy"
+	policymocks "github.com/hyperledger/fabric/core/policy/mocks"
+	"github.com/hyperledger/fabric/core/scc/lifecycle"
 	"github.com/hyperledger/fabric/core/scc/lscc"
 	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
@@ -46,6 +49,7 @@ import (
 	"github.com/hyperledger/fabric/protos/common"
 	mspproto "github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -371,12 +375,31 @@ func TestInvoke(t *testing.T) {
 	}
 }
 
+// mockLifecycle stands in for the lifecycle system chaincode queried by
+// lscc; it does not hold any chaincode definition
+type mockLifecycle struct {
+}
+
+func (m *mockLifecycle) Init(stub shim.ChaincodeStubInterface) peer.Response {
+	return shim.Success(nil)
+}
+
+func (m *mockLifecycle) Invoke(stub shim.ChaincodeStubInterface) peer.Response {
+	return shim.Success(nil)
+}
+
+func newLSCCStub(lccc *lscc.LifeCycleSysCC) *shim.MockStub {
+	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc.MockPeerChaincode(lifecycle.Name, shim.NewMockStub(lifecycle.Name, &mockLifecycle{}))
+	return stublccc
+}
+
 func TestInvalidFunction(t *testing.T) {
 	v := new(ValidatorOneValidSignature)
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
 
 	lccc := new(lscc.LifeCycleSysCC)
-	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc := newLSCCStub(lccc)
 
 	State := make(map[string]map[string][]byte)
 	State["lscc"] = stublccc.State
@@ -428,7 +451,7 @@ func TestRWSetTooBig(t *testing.T) {
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
 
 	lccc := new(lscc.LifeCycleSysCC)
-	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc := newLSCCStub(lccc)
 
 	State := make(map[string]map[string][]byte)
 	State["lscc"] = stublccc.State
@@ -492,7 +515,7 @@ func TestValidateDeployFail(t *testing.T) {
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
 
 	lccc := new(lscc.LifeCycleSysCC)
-	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc := newLSCCStub(lccc)
 
 	State := make(map[string]map[string][]byte)
 	State["lscc"] = stublccc.State
@@ -777,7 +800,7 @@ func TestAlreadyDeployed(t *testing.T) {
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
 
 	lccc := new(lscc.LifeCycleSysCC)
-	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc := newLSCCStub(lccc)
 
 	State := make(map[string]map[string][]byte)
 	State["lscc"] = stublccc.State
@@ -852,7 +875,7 @@ func TestValidateDeployNoLedger(t *testing.T) {
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
 
 	lccc := new(lscc.LifeCycleSysCC)
-	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc := newLSCCStub(lccc)
 
 	State := make(map[string]map[string][]byte)
 	State["lscc"] = stublccc.State
@@ -906,7 +929,7 @@ func TestValidateDeployOK(t *testing.T) {
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
 
 	lccc := new(lscc.LifeCycleSysCC)
-	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc := newLSCCStub(lccc)
 
 	State := make(map[string]map[string][]byte)
 	State["lscc"] = stublccc.State
@@ -960,7 +983,7 @@ func TestValidateDeployWithPolicies(t *testing.T) {
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
 
 	lccc := new(lscc.LifeCycleSysCC)
-	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc := newLSCCStub(lccc)
 
 	State := make(map[string]map[string][]byte)
 	State["lscc"] = stublccc.State
@@ -1044,7 +1067,7 @@ func TestInvalidUpgrade(t *testing.T) {
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
 
 	lccc := new(lscc.LifeCycleSysCC)
-	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc := newLSCCStub(lccc)
 
 	State := make(map[string]map[string][]byte)
 	State["lscc"] = stublccc.State
@@ -1096,7 +1119,7 @@ func TestValidateUpgradeOK(t *testing.T) {
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
 
 	lccc := new(lscc.LifeCycleSysCC)
-	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc := newLSCCStub(lccc)
 
 	State := make(map[string]map[string][]byte)
 	State["lscc"] = stublccc.State
@@ -1173,7 +1196,7 @@ func TestInvalidateUpgradeBadVersion(t *testing.T) {
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
 
 	lccc := new(lscc.LifeCycleSysCC)
-	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc := newLSCCStub(lccc)
 
 	State := make(map[string]map[string][]byte)
 	State["lscc"] = stublccc.State
@@ -1248,7 +1271,7 @@ func TestValidateUpgradeWithPoliciesOK(t *testing.T) {
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
 
 	lccc := new(lscc.LifeCycleSysCC)
-	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc := newLSCCStub(lccc)
 
 	State := make(map[string]map[string][]byte)
 	State["lscc"] = stublccc.State
@@ -1327,7 +1350,7 @@ func TestValidateUpgradeWithPoliciesFail(t *testing.T) {
 	stub := shim.NewMockStub("validatoronevalidsignature", v)
 
 	lccc := new(lscc.LifeCycleSysCC)
-	stublccc := shim.NewMockStub("lscc", lccc)
+	stublccc := newLSCCStub(lccc)
 
 	State := make(map[string]map[string][]byte)
 	State["lscc"] = stublccc.State
@@ -1401,6 +1424,111 @@ func TestValidateUpgradeWithPoliciesFail(t *testing.T) {
 	}
 }
 
+func createLifecycleTx(f string, def *lb.ChaincodeDefinition, res []byte) (*common.Envelope, error) {
+	cis := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: lifecycle.Name},
+			Input: &peer.ChaincodeInput{
+				Args: [][]byte{[]byte(f), []byte(chainId), utils.MarshalOrPanic(def)},
+			},
+			Type: peer.ChaincodeSpec_GOLANG,
+		},
+	}
+
+	prop, _, err := utils.CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chainId, cis, sid)
+	if err != nil {
+		return nil, err
+	}
+
+	ccid := &peer.ChaincodeID{Name: lifecycle.Name, Version: util.GetSysCCVersion()}
+
+	presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, res, nil, ccid, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.CreateSignedTx(prop, id, presp)
+}
+
+func TestValidateLifecycleInvocation(t *testing.T) {
+	v := new(ValidatorOneValidSignature)
+	stub := shim.NewMockStub("validatoronevalidsignature", v)
+
+	State := make(map[string]map[string][]byte)
+	State[lifecycle.Name] = make(map[string][]byte)
+	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{Qe: lm.NewMockQueryExecutor(State)})
+
+	r1 := stub.MockInit("1", [][]byte{})
+	assert.Equal(t, int32(shim.OK), r1.Status, r1.Message)
+
+	checker := &mockPolicyChecker{}
+	v.policyChecker = checker
+	v.policyManagerGetter = &policymocks.MockChannelPolicyManagerGetter{
+		Managers: map[string]policies.Manager{
+			chainId: &policymocks.MockChannelPolicyManager{MockPolicy: &policymocks.MockPolicy{}},
+		},
+	}
+
+	policy, err := getSignedByMSPMemberPolicy(mspid)
+	assert.NoError(t, err)
+
+	validate := func(f string, def *lb.ChaincodeDefinition, rwsetBuilder *rwsetutil.RWSetBuilder) peer.Response {
+		res, err := rwsetBuilder.GetTxReadWriteSet().ToProtoBytes()
+		assert.NoError(t, err)
+		tx, err := createLifecycleTx(f, def, res)
+		assert.NoError(t, err)
+		envBytes, err := utils.GetBytesEnvelope(tx)
+		assert.NoError(t, err)
+		return stub.MockInvoke("1", [][]byte{[]byte("dv"), envBytes, policy})
+	}
+
+	def := &lb.ChaincodeDefinition{Name: "mycc", Version: "1", Sequence: 1, Escc: "escc", Vscc: "vscc", Hash: []byte("hash")}
+	sp, _ := utils.MockSignedEndorserProposalOrPanic(chainId, &peer.ChaincodeSpec{}, sid, []byte("signature"))
+	approval := utils.MarshalOrPanic(&lb.Approval{Definition: def, SignedProposal: sp})
+
+	// good path: the approval of the submitter's organization
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToWriteSet(lifecycle.Name, lifecycle.ApprovalKey("mycc", 1, mspid), approval)
+	res := validate(lifecycle.APPROVE, def, rwsetBuilder)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	// approval recorded for another organization
+	rwsetBuilder = rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToWriteSet(lifecycle.Name, lifecycle.ApprovalKey("mycc", 1, "OtherMSP"), approval)
+	res = validate(lifecycle.APPROVE, def, rwsetBuilder)
+	assert.Contains(t, res.Message, "Expected key")
+
+	// approval signed by somebody else
+	spOther, _ := utils.MockSignedEndorserProposalOrPanic(chainId, &peer.ChaincodeSpec{}, []byte("other"), []byte("signature"))
+	rwsetBuilder = rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToWriteSet(lifecycle.Name, lifecycle.ApprovalKey("mycc", 1, mspid), utils.MarshalOrPanic(&lb.Approval{Definition: def, SignedProposal: spOther}))
+	res = validate(lifecycle.APPROVE, def, rwsetBuilder)
+	assert.Contains(t, res.Message, "not signed by the submitter")
+
+	// writes to other namespaces
+	rwsetBuilder = rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToWriteSet(lifecycle.Name, lifecycle.ApprovalKey("mycc", 1, mspid), approval)
+	rwsetBuilder.AddToWriteSet("mycc", "key", []byte("value"))
+	res = validate(lifecycle.APPROVE, def, rwsetBuilder)
+	assert.Contains(t, res.Message, "attempting to write to namespace mycc")
+
+	// commit without approvals on the ledger
+	rwsetBuilder = rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToWriteSet(lifecycle.Name, lifecycle.DefinitionKey("mycc"), utils.MarshalOrPanic(def))
+	res = validate(lifecycle.COMMIT, def, rwsetBuilder)
+	assert.Contains(t, res.Message, "was not approved")
+
+	// commit with approvals on the ledger
+	State[lifecycle.Name][lifecycle.ApprovalKey("mycc", 1, "DEFAULT")] = approval
+	res = validate(lifecycle.COMMIT, def, rwsetBuilder)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	// a definition with the wrong sequence
+	State[lifecycle.Name][lifecycle.DefinitionKey("mycc")] = utils.MarshalOrPanic(def)
+	res = validate(lifecycle.COMMIT, def, rwsetBuilder)
+	assert.Contains(t, res.Message, "Expected sequence 2")
+}
+
 var id msp.SigningIdentity
 var sid []byte
 var mspid string
@@ -1428,6 +1556,10 @@ func (c *mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *
 	return nil
 }
 
+func (c *mockPolicyChecker) CheckPolicyNoChannelBySignedData(policyName string, sd []*common.SignedData) error {
+	return nil
+}
+
 var lccctestpath = "/tmp/lscc-validation-test"
 
 func TestMain(m *testing.M) {
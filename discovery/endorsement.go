@@ -0,0 +1,231 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+)
+
+// PrincipalEvaluator returns whether the peer with the given
+// identity satisfies the given principal
+type PrincipalEvaluator func(identity []byte, principal *msp.MSPPrincipal) bool
+
+// maxLayouts bounds the number of combinations of signatures enumerated
+// for a policy, as an N out of M rule alone has M choose N of them
+const maxLayouts = 10000
+
+// layout maps groups, i.e. distinct principals of a policy, to the
+// number of signatures needed from each of them
+type layout map[int]uint32
+
+// key returns a canonical representation of the layout
+func (l layout) key() string {
+	groups := make([]int, 0, len(l))
+	for g := range l {
+		groups = append(groups, g)
+	}
+	sort.Ints(groups)
+	parts := make([]string, len(groups))
+	for i, g := range groups {
+		parts[i] = fmt.Sprintf("%d:%d", g, l[g])
+	}
+	return strings.Join(parts, ",")
+}
+
+func (l layout) merge(other layout) layout {
+	res := make(layout, len(l)+len(other))
+	for g, n := range l {
+		res[g] += n
+	}
+	for g, n := range other {
+		res[g] += n
+	}
+	return res
+}
+
+// layouts returns the minimal combinations of signatures, by group, that
+// satisfy the given rule; principalGroups maps the principal indices of
+// the policy to their group
+func layouts(rule *common.SignaturePolicy, principalGroups []int) ([]layout, error) {
+	switch t := rule.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || int(t.SignedBy) >= len(principalGroups) {
+			return nil, fmt.Errorf("identity index out of range, requested %d, but identities length is %d", t.SignedBy, len(principalGroups))
+		}
+		return []layout{{principalGroups[t.SignedBy]: 1}}, nil
+	case *common.SignaturePolicy_NOutOf_:
+		sub := make([][]layout, len(t.NOutOf.Rules))
+		for i, r := range t.NOutOf.Rules {
+			var err error
+			if sub[i], err = layouts(r, principalGroups); err != nil {
+				return nil, err
+			}
+		}
+
+		if binomial(len(sub), int(t.NOutOf.N)) > maxLayouts {
+			return nil, fmt.Errorf("too many combinations of signatures, more than %d", maxLayouts)
+		}
+
+		res := []layout{}
+		seen := make(map[string]bool)
+		for _, combination := range choose(len(sub), int(t.NOutOf.N)) {
+			// each of the chosen rules must be satisfied by one of its layouts
+			partial := []layout{{}}
+			for _, i := range combination {
+				var next []layout
+				for _, p := range partial {
+					for _, l := range sub[i] {
+						next = append(next, p.merge(l))
+					}
+				}
+				if len(next) > maxLayouts {
+					return nil, fmt.Errorf("too many combinations of signatures, more than %d", maxLayouts)
+				}
+				partial = next
+			}
+			for _, l := range partial {
+				if !seen[l.key()] {
+					seen[l.key()] = true
+					res = append(res, l)
+				}
+			}
+			if len(res) > maxLayouts {
+				return nil, fmt.Errorf("too many combinations of signatures, more than %d", maxLayouts)
+			}
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("unknown signature policy type %T", t)
+	}
+}
+
+// binomial returns n choose k, or some value above maxLayouts
+// once it is known to exceed it
+func binomial(n, k int) int {
+	if k <= 0 {
+		return 1
+	}
+	if k > n {
+		return 0
+	}
+	if k > n-k {
+		k = n - k
+	}
+	res := 1
+	for i := 1; i <= k && res <= maxLayouts; i++ {
+		res = res * (n - k + i) / i
+	}
+	return res
+}
+
+// choose returns all the subsets of size k of {0, ..., n-1}
+func choose(n, k int) [][]int {
+	if k <= 0 {
+		return [][]int{{}}
+	}
+	if k > n {
+		return nil
+	}
+	var res [][]int
+	for _, rest := range choose(n-1, k-1) {
+		res = append(res, append(rest, n-1))
+	}
+	return append(res, choose(n-1, k)...)
+}
+
+// computeEndorsementDescriptor returns the groups of peers which can endorse
+// for the given chaincode and the layouts, in terms of these groups,
+// which satisfy its endorsement policy
+func computeEndorsementDescriptor(chaincode string, policy *common.SignaturePolicyEnvelope, peers []*discprotos.Peer, satisfies PrincipalEvaluator) (*discprotos.EndorsementDescriptor, error) {
+	if policy == nil || policy.Rule == nil {
+		return nil, fmt.Errorf("chaincode %s has no endorsement policy", chaincode)
+	}
+
+	// identical principals form a single group so that a peer
+	// does not appear to count twice towards the same principal
+	var principals []*msp.MSPPrincipal
+	principalGroups := make([]int, len(policy.Identities))
+	for i, principal := range policy.Identities {
+		principalGroups[i] = -1
+		for g, p := range principals {
+			if proto.Equal(p, principal) {
+				principalGroups[i] = g
+				break
+			}
+		}
+		if principalGroups[i] == -1 {
+			principalGroups[i] = len(principals)
+			principals = append(principals, principal)
+		}
+	}
+
+	ls, err := layouts(policy.Rule, principalGroups)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endorsement policy of chaincode %s: %s", chaincode, err)
+	}
+
+	endorsers := make([][]*discprotos.Peer, len(principals))
+	for g, principal := range principals {
+		for _, p := range peers {
+			if satisfies(p.Identity, principal) {
+				endorsers[g] = append(endorsers[g], p)
+			}
+		}
+	}
+
+	desc := &discprotos.EndorsementDescriptor{
+		Chaincode:         chaincode,
+		EndorsersByGroups: make(map[string]*discprotos.Peers),
+	}
+	for _, l := range ls {
+		feasible := true
+		for g, n := range l {
+			if uint32(len(endorsers[g])) < n {
+				feasible = false
+				break
+			}
+		}
+		if !feasible {
+			continue
+		}
+
+		quantities := make(map[string]uint32, len(l))
+		for g, n := range l {
+			name := groupName(g)
+			quantities[name] = n
+			desc.EndorsersByGroups[name] = &discprotos.Peers{Peers: endorsers[g]}
+		}
+		desc.Layouts = append(desc.Layouts, &discprotos.Layout{QuantitiesByGroup: quantities})
+	}
+
+	if len(desc.Layouts) == 0 {
+		return nil, fmt.Errorf("no combination of the peers of the channel satisfies the endorsement policy of chaincode %s", chaincode)
+	}
+
+	return desc, nil
+}
+
+func groupName(g int) string {
+	return fmt.Sprintf("G%d", g)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+func principal(org string) *msp.MSPPrincipal {
+	return &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_IDENTITY,
+		Principal:               []byte(org),
+	}
+}
+
+func testPeer(endpoint, org string) *discprotos.Peer {
+	return &discprotos.Peer{Endpoint: endpoint, Identity: []byte(org), MspId: org}
+}
+
+// identities satisfy the principal carrying their own bytes
+func sameBytes(identity []byte, principal *msp.MSPPrincipal) bool {
+	return bytes.Equal(identity, principal.Principal)
+}
+
+func TestChoose(t *testing.T) {
+	assert.Equal(t, [][]int{{}}, choose(3, 0))
+	assert.Nil(t, choose(2, 3))
+	assert.Len(t, choose(4, 2), 6)
+	assert.Len(t, choose(3, 3), 1)
+}
+
+func TestBinomial(t *testing.T) {
+	assert.Equal(t, 1, binomial(3, 0))
+	assert.Equal(t, 0, binomial(2, 3))
+	assert.Equal(t, 6, binomial(4, 2))
+	assert.Equal(t, 1, binomial(3, 3))
+	assert.True(t, binomial(100, 50) > maxLayouts)
+}
+
+func TestLayoutsTooMany(t *testing.T) {
+	// 20 out of 40 distinct principals
+	var rules []*common.SignaturePolicy
+	groups := make([]int, 40)
+	for i := range groups {
+		rules = append(rules, cauthdsl.SignedBy(int32(i)))
+		groups[i] = i
+	}
+	_, err := layouts(cauthdsl.NOutOf(20, rules), groups)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "too many combinations")
+
+	// 2 out of 2 rules, each of them 2 out of 20
+	half := cauthdsl.NOutOf(2, rules[:20])
+	_, err = layouts(cauthdsl.NOutOf(2, []*common.SignaturePolicy{half, half}), groups)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "too many combinations")
+}
+
+func TestLayouts(t *testing.T) {
+	// 2 out of A, B, C
+	rule := cauthdsl.NOutOf(2, []*common.SignaturePolicy{cauthdsl.SignedBy(0), cauthdsl.SignedBy(1), cauthdsl.SignedBy(2)})
+	ls, err := layouts(rule, []int{0, 1, 2})
+	assert.NoError(t, err)
+	assert.Len(t, ls, 3)
+
+	// A and (B or A): the principals 0 and 2 are identical
+	rule = cauthdsl.And(cauthdsl.SignedBy(0), cauthdsl.Or(cauthdsl.SignedBy(1), cauthdsl.SignedBy(2)))
+	ls, err = layouts(rule, []int{0, 1, 0})
+	assert.NoError(t, err)
+	assert.Len(t, ls, 2)
+	assert.Contains(t, ls, layout{0: 1, 1: 1})
+	assert.Contains(t, ls, layout{0: 2})
+
+	_, err = layouts(cauthdsl.SignedBy(5), []int{0})
+	assert.Error(t, err)
+}
+
+func TestComputeEndorsementDescriptor(t *testing.T) {
+	policy := &common.SignaturePolicyEnvelope{
+		Rule:       cauthdsl.Or(cauthdsl.And(cauthdsl.SignedBy(0), cauthdsl.SignedBy(1)), cauthdsl.SignedBy(2)),
+		Identities: []*msp.MSPPrincipal{principal("Org1"), principal("Org2"), principal("Org3")},
+	}
+	peers := []*discprotos.Peer{
+		testPeer("p0", "Org1"),
+		testPeer("p1", "Org1"),
+		testPeer("p2", "Org2"),
+	}
+
+	desc, err := computeEndorsementDescriptor("mycc", policy, peers, sameBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, "mycc", desc.Chaincode)
+	// Org3 has no live peers, so only Org1 and Org2 together can endorse
+	assert.Len(t, desc.Layouts, 1)
+	assert.Equal(t, map[string]uint32{"G0": 1, "G1": 1}, desc.Layouts[0].QuantitiesByGroup)
+	assert.Len(t, desc.EndorsersByGroups, 2)
+	assert.Len(t, desc.EndorsersByGroups["G0"].Peers, 2)
+	assert.Len(t, desc.EndorsersByGroups["G1"].Peers, 1)
+
+	// without Org2 nobody can endorse
+	_, err = computeEndorsementDescriptor("mycc", policy, peers[:2], sameBytes)
+	assert.Error(t, err)
+
+	_, err = computeEndorsementDescriptor("mycc", &common.SignaturePolicyEnvelope{}, peers, sameBytes)
+	assert.Error(t, err)
+}
@@ -0,0 +1,195 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"golang.org/x/net/context"
+)
+
+var logger = flogging.MustGetLogger("discovery")
+
+// Support provides the discovery service with the information
+// it serves about the channels of the peer
+type Support interface {
+	// ChannelExists returns whether the peer is a member of the given channel
+	ChannelExists(channel string) bool
+
+	// EligibleForService returns nil if the given signed data is authorized
+	// to get information about the given channel, an error otherwise
+	EligibleForService(channel string, data *common.SignedData) error
+
+	// Config returns the MSP configurations and the orderer endpoints of the channel
+	Config(channel string) (*discprotos.ConfigResult, error)
+
+	// PeersOfChannel returns the live peers of the channel, this peer included
+	PeersOfChannel(channel string) []*discprotos.Peer
+
+	// Endorsers returns the live peers of the channel, this peer included,
+	// which have installed the given chaincode in its version defined on
+	// the channel
+	Endorsers(channel string, chaincode string) ([]*discprotos.Peer, error)
+
+	// EndorsementPolicy returns the endorsement policy of the given chaincode
+	EndorsementPolicy(channel string, chaincode string) (*common.SignaturePolicyEnvelope, error)
+
+	// SatisfiesPrincipal returns whether the given identity satisfies the
+	// principal, as evaluated by the MSPs of the channel
+	SatisfiesPrincipal(channel string, identity []byte, principal *msp.MSPPrincipal) bool
+}
+
+type service struct {
+	support Support
+}
+
+// NewService creates a discovery service which answers the
+// queries of clients with the information of the given Support
+func NewService(support Support) discprotos.DiscoveryServer {
+	return &service{support: support}
+}
+
+// Discover answers the queries of a signed request. Each query is
+// answered in the context of its channel, once the request is found to
+// satisfy the Readers policy of the channel
+func (s *service) Discover(ctx context.Context, request *discprotos.SignedRequest) (*discprotos.Response, error) {
+	req := &discprotos.Request{}
+	if err := proto.Unmarshal(request.Payload, req); err != nil {
+		return nil, fmt.Errorf("failed parsing request: %s", err)
+	}
+	if req.Authentication == nil || len(req.Authentication.ClientIdentity) == 0 {
+		return nil, fmt.Errorf("access denied, no authentication info in request")
+	}
+
+	sd := &common.SignedData{
+		Data:      request.Payload,
+		Identity:  req.Authentication.ClientIdentity,
+		Signature: request.Signature,
+	}
+
+	// authorization is checked once per channel
+	authorized := make(map[string]error)
+	res := &discprotos.Response{}
+	for _, q := range req.Queries {
+		err, checked := authorized[q.Channel]
+		if !checked {
+			err = s.authorize(q.Channel, sd)
+			authorized[q.Channel] = err
+		}
+		if err != nil {
+			res.Results = append(res.Results, wrapError(err))
+			continue
+		}
+
+		res.Results = append(res.Results, s.processQuery(q))
+	}
+
+	return res, nil
+}
+
+func (s *service) authorize(channel string, sd *common.SignedData) error {
+	if channel == "" {
+		return fmt.Errorf("no channel specified in query")
+	}
+	if !s.support.ChannelExists(channel) {
+		logger.Debugf("Query for channel %s the peer is not a member of", channel)
+		return fmt.Errorf("access denied")
+	}
+	if err := s.support.EligibleForService(channel, sd); err != nil {
+		logger.Warningf("Request for channel %s is not authorized: %s", channel, err)
+		return fmt.Errorf("access denied")
+	}
+	return nil
+}
+
+func (s *service) processQuery(q *discprotos.Query) *discprotos.QueryResult {
+	switch query := q.Query.(type) {
+	case *discprotos.Query_ConfigQuery:
+		config, err := s.support.Config(q.Channel)
+		if err != nil {
+			logger.Errorf("Failed getting config of channel %s: %s", q.Channel, err)
+			return wrapError(fmt.Errorf("failed getting config of channel %s", q.Channel))
+		}
+		return &discprotos.QueryResult{Result: &discprotos.QueryResult_ConfigResult{ConfigResult: config}}
+	case *discprotos.Query_PeerQuery:
+		return &discprotos.QueryResult{Result: &discprotos.QueryResult_Members{Members: s.membership(q.Channel)}}
+	case *discprotos.Query_CcQuery:
+		descriptors, err := s.endorsementDescriptors(q.Channel, query.CcQuery.Chaincodes)
+		if err != nil {
+			return wrapError(err)
+		}
+		return &discprotos.QueryResult{Result: &discprotos.QueryResult_CcQueryRes{CcQueryRes: descriptors}}
+	default:
+		return wrapError(fmt.Errorf("unknown query type %T", q.Query))
+	}
+}
+
+func (s *service) membership(channel string) *discprotos.PeerMembershipResult {
+	res := &discprotos.PeerMembershipResult{PeersByOrg: make(map[string]*discprotos.Peers)}
+	for _, p := range s.support.PeersOfChannel(channel) {
+		peers, ok := res.PeersByOrg[p.MspId]
+		if !ok {
+			peers = &discprotos.Peers{}
+			res.PeersByOrg[p.MspId] = peers
+		}
+		peers.Peers = append(peers.Peers, p)
+	}
+	return res
+}
+
+func (s *service) endorsementDescriptors(channel string, chaincodes []string) (*discprotos.ChaincodeQueryResult, error) {
+	if len(chaincodes) == 0 {
+		return nil, fmt.Errorf("no chaincodes specified in query")
+	}
+
+	satisfies := func(identity []byte, principal *msp.MSPPrincipal) bool {
+		return s.support.SatisfiesPrincipal(channel, identity, principal)
+	}
+
+	res := &discprotos.ChaincodeQueryResult{}
+	for _, cc := range chaincodes {
+		policy, err := s.support.EndorsementPolicy(channel, cc)
+		if err != nil {
+			logger.Warningf("Failed getting endorsement policy of chaincode %s on channel %s: %s", cc, channel, err)
+			return nil, fmt.Errorf("failed getting endorsement policy of chaincode %s", cc)
+		}
+		peers, err := s.support.Endorsers(channel, cc)
+		if err != nil {
+			logger.Warningf("Failed getting endorsers of chaincode %s on channel %s: %s", cc, channel, err)
+			return nil, fmt.Errorf("failed getting endorsers of chaincode %s", cc)
+		}
+		desc, err := computeEndorsementDescriptor(cc, policy, peers, satisfies)
+		if err != nil {
+			return nil, err
+		}
+		res.Content = append(res.Content, desc)
+	}
+	return res, nil
+}
+
+func wrapError(err error) *discprotos.QueryResult {
+	return &discprotos.QueryResult{
+		Result: &discprotos.QueryResult_Error{
+			Error: &discprotos.Error{Content: err.Error()},
+		},
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+type mockSupport struct {
+	channels   map[string]bool
+	authorized map[string]bool
+	peers      []*discprotos.Peer
+	policies   map[string]*common.SignaturePolicyEnvelope
+	// installed maps chaincodes to the endpoints of the peers having them
+	installed map[string][]string
+}
+
+func (ms *mockSupport) ChannelExists(channel string) bool {
+	return ms.channels[channel]
+}
+
+func (ms *mockSupport) EligibleForService(channel string, data *common.SignedData) error {
+	if !ms.authorized[string(data.Identity)] {
+		return errors.New("not a reader")
+	}
+	return nil
+}
+
+func (ms *mockSupport) Config(channel string) (*discprotos.ConfigResult, error) {
+	return &discprotos.ConfigResult{Orderers: []string{"orderer:7050"}}, nil
+}
+
+func (ms *mockSupport) PeersOfChannel(channel string) []*discprotos.Peer {
+	return ms.peers
+}
+
+func (ms *mockSupport) Endorsers(channel string, chaincode string) ([]*discprotos.Peer, error) {
+	var res []*discprotos.Peer
+	for _, p := range ms.peers {
+		for _, endpoint := range ms.installed[chaincode] {
+			if p.Endpoint == endpoint {
+				res = append(res, p)
+			}
+		}
+	}
+	return res, nil
+}
+
+func (ms *mockSupport) EndorsementPolicy(channel string, chaincode string) (*common.SignaturePolicyEnvelope, error) {
+	if policy, ok := ms.policies[chaincode]; ok {
+		return policy, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (ms *mockSupport) SatisfiesPrincipal(channel string, identity []byte, principal *msp.MSPPrincipal) bool {
+	return sameBytes(identity, principal)
+}
+
+func signedRequest(t *testing.T, client string, queries ...*discprotos.Query) *discprotos.SignedRequest {
+	req := &discprotos.Request{Queries: queries}
+	if client != "" {
+		req.Authentication = &discprotos.AuthInfo{ClientIdentity: []byte(client)}
+	}
+	payload, err := proto.Marshal(req)
+	assert.NoError(t, err)
+	return &discprotos.SignedRequest{Payload: payload, Signature: []byte("signature")}
+}
+
+func TestDiscover(t *testing.T) {
+	support := &mockSupport{
+		channels:   map[string]bool{"mychannel": true},
+		authorized: map[string]bool{"client": true},
+		peers:      []*discprotos.Peer{testPeer("p0", "Org1"), testPeer("p1", "Org1"), testPeer("p2", "Org2")},
+		policies: map[string]*common.SignaturePolicyEnvelope{
+			"mycc": {
+				Rule:       cauthdsl.And(cauthdsl.SignedBy(0), cauthdsl.SignedBy(1)),
+				Identities: []*msp.MSPPrincipal{principal("Org1"), principal("Org2")},
+			},
+			"org1cc": {
+				Rule:       cauthdsl.And(cauthdsl.SignedBy(0), cauthdsl.SignedBy(1)),
+				Identities: []*msp.MSPPrincipal{principal("Org1"), principal("Org2")},
+			},
+		},
+		installed: map[string][]string{
+			"mycc":   {"p0", "p1", "p2"},
+			"org1cc": {"p0", "p1"},
+		},
+	}
+	svc := NewService(support)
+
+	configQuery := &discprotos.Query{Channel: "mychannel", Query: &discprotos.Query_ConfigQuery{ConfigQuery: &discprotos.ConfigQuery{}}}
+	peerQuery := &discprotos.Query{Channel: "mychannel", Query: &discprotos.Query_PeerQuery{PeerQuery: &discprotos.PeerMembershipQuery{}}}
+	ccQuery := &discprotos.Query{Channel: "mychannel", Query: &discprotos.Query_CcQuery{CcQuery: &discprotos.ChaincodeQuery{Chaincodes: []string{"mycc"}}}}
+
+	// malformed and unauthenticated requests are rejected altogether
+	_, err := svc.Discover(context.Background(), &discprotos.SignedRequest{Payload: []byte{1, 2, 3}})
+	assert.Error(t, err)
+	_, err = svc.Discover(context.Background(), signedRequest(t, "", configQuery))
+	assert.Error(t, err)
+
+	res, err := svc.Discover(context.Background(), signedRequest(t, "client", configQuery, peerQuery, ccQuery))
+	assert.NoError(t, err)
+	assert.Len(t, res.Results, 3)
+	assert.Equal(t, []string{"orderer:7050"}, res.Results[0].GetConfigResult().Orderers)
+	members := res.Results[1].GetMembers()
+	assert.Len(t, members.PeersByOrg["Org1"].Peers, 2)
+	assert.Len(t, members.PeersByOrg["Org2"].Peers, 1)
+	descs := res.Results[2].GetCcQueryRes().Content
+	assert.Len(t, descs, 1)
+	assert.Len(t, descs[0].Layouts, 1)
+
+	// unauthorized clients and unknown channels are denied access per query
+	res, err = svc.Discover(context.Background(), signedRequest(t, "intruder", configQuery))
+	assert.NoError(t, err)
+	assert.Contains(t, res.Results[0].GetError().Content, "access denied")
+	res, err = svc.Discover(context.Background(), signedRequest(t, "client", &discprotos.Query{Channel: "other", Query: peerQuery.Query}))
+	assert.NoError(t, err)
+	assert.Contains(t, res.Results[0].GetError().Content, "access denied")
+
+	// only the peers having installed a chaincode are its endorsers
+	res, err = svc.Discover(context.Background(), signedRequest(t, "client", &discprotos.Query{Channel: "mychannel", Query: &discprotos.Query_CcQuery{CcQuery: &discprotos.ChaincodeQuery{Chaincodes: []string{"org1cc"}}}}))
+	assert.NoError(t, err)
+	assert.NotNil(t, res.Results[0].GetError())
+
+	// unknown chaincodes result in an error
+	res, err = svc.Discover(context.Background(), signedRequest(t, "client", &discprotos.Query{Channel: "mychannel", Query: &discprotos.Query_CcQuery{CcQuery: &discprotos.ChaincodeQuery{Chaincodes: []string{"nocc"}}}}))
+	assert.NoError(t, err)
+	assert.NotNil(t, res.Results[0].GetError())
+}
@@ -0,0 +1,300 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package support
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/config"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/core/policy"
+	"github.com/hyperledger/fabric/core/policyprovider"
+	"github.com/hyperledger/fabric/core/scc/lifecycle"
+	"github.com/hyperledger/fabric/discovery"
+	"github.com/hyperledger/fabric/gossip/common"
+	gossipdisc "github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/gossip/state"
+	fabricmsp "github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	cb "github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	gossipproto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/msp"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+)
+
+var logger = flogging.MustGetLogger("discovery/support")
+
+type peerSupport struct {
+	policyChecker policy.PolicyChecker
+}
+
+// NewPeerSupport returns a discovery.Support which answers
+// queries from the channels, ledgers and gossip view of this peer
+func NewPeerSupport() discovery.Support {
+	return &peerSupport{policyChecker: policyprovider.GetPolicyChecker()}
+}
+
+// ChannelExists returns whether the peer has joined the given channel
+func (s *peerSupport) ChannelExists(channel string) bool {
+	return peer.GetLedger(channel) != nil
+}
+
+// EligibleForService checks the signed data against the Readers policy of the channel
+func (s *peerSupport) EligibleForService(channel string, data *cb.SignedData) error {
+	return s.policyChecker.CheckPolicyBySignedData(channel, policies.ChannelApplicationReaders, []*cb.SignedData{data})
+}
+
+// Config returns the MSP configurations of the organizations of the
+// channel, and the orderer endpoints of the channel
+func (s *peerSupport) Config(channel string) (*discprotos.ConfigResult, error) {
+	cm := peer.GetChannelConfig(channel)
+	if cm == nil {
+		return nil, fmt.Errorf("channel %s not found", channel)
+	}
+	env := cm.ConfigEnvelope()
+	if env == nil || env.Config == nil || env.Config.ChannelGroup == nil {
+		return nil, fmt.Errorf("channel %s has no config", channel)
+	}
+
+	msps, err := mspConfigs(env.Config.ChannelGroup)
+	if err != nil {
+		return nil, err
+	}
+	return &discprotos.ConfigResult{
+		Msps:     msps,
+		Orderers: cm.ChannelConfig().OrdererAddresses(),
+	}, nil
+}
+
+// mspConfigs returns the configurations of the MSPs of the application and
+// orderer organizations found in the channel group. Peers and orderers
+// have X.509 identities, so idemix MSPs, which only serve clients, are
+// left out
+func mspConfigs(channelGroup *cb.ConfigGroup) (map[string]*msp.FabricMSPConfig, error) {
+	res := make(map[string]*msp.FabricMSPConfig)
+	for _, groupKey := range []string{config.ApplicationGroupKey, config.OrdererGroupKey} {
+		group, ok := channelGroup.Groups[groupKey]
+		if !ok {
+			continue
+		}
+		for org, orgGroup := range group.Groups {
+			value, ok := orgGroup.Values[config.MSPKey]
+			if !ok {
+				continue
+			}
+			mspConfig := &msp.MSPConfig{}
+			if err := proto.Unmarshal(value.Value, mspConfig); err != nil {
+				return nil, fmt.Errorf("failed unmarshalling MSP config of %s: %s", org, err)
+			}
+			switch fabricmsp.ProviderType(mspConfig.Type) {
+			case fabricmsp.FABRIC:
+				fabricConfig := &msp.FabricMSPConfig{}
+				if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+					return nil, fmt.Errorf("failed unmarshalling MSP config of %s: %s", org, err)
+				}
+				res[fabricConfig.Name] = fabricConfig
+			case fabricmsp.IDEMIX:
+				logger.Debugf("Leaving out the idemix MSP of %s", org)
+			default:
+				return nil, fmt.Errorf("unknown type %d of the MSP of %s", mspConfig.Type, org)
+			}
+		}
+	}
+	return res, nil
+}
+
+// member is a peer of a channel along with the chaincodes it has installed
+type member struct {
+	peer      *discprotos.Peer
+	installed []*gossipproto.Chaincode
+}
+
+// PeersOfChannel returns the peers of the channel gossip considers alive,
+// along with this peer
+func (s *peerSupport) PeersOfChannel(channel string) []*discprotos.Peer {
+	var res []*discprotos.Peer
+	for _, m := range s.members(channel) {
+		res = append(res, m.peer)
+	}
+	return res
+}
+
+// Endorsers returns the peers of the channel, this peer included, which
+// publish that they have installed the version of the chaincode defined
+// on the channel
+func (s *peerSupport) Endorsers(channel string, chaincode string) ([]*discprotos.Peer, error) {
+	version, _, err := definition(channel, chaincode)
+	if err != nil {
+		return nil, err
+	}
+	return endorsers(s.members(channel), chaincode, version), nil
+}
+
+func endorsers(members []member, chaincode string, version string) []*discprotos.Peer {
+	var res []*discprotos.Peer
+	for _, m := range members {
+		for _, cc := range m.installed {
+			if cc.Name == chaincode && cc.Version == version {
+				res = append(res, m.peer)
+				break
+			}
+		}
+	}
+	return res
+}
+
+func (s *peerSupport) members(channel string) []member {
+	var res []member
+
+	gossip := service.GetGossipService()
+	for _, nm := range gossip.PeersOfChannel(common.ChainID(channel)) {
+		identity, err := gossip.PeerIdentity(nm.PKIid)
+		if err != nil {
+			logger.Debugf("No identity of %s found: %s", nm.Endpoint, err)
+			continue
+		}
+		if m := newMember(nm, identity); m != nil {
+			res = append(res, *m)
+		}
+	}
+
+	if self := s.self(channel, gossip.SelfIdentity()); self != nil {
+		res = append(res, *self)
+	}
+	return res
+}
+
+// newMember returns the member of the channel the gossip view of a peer
+// describes, or nil if its identity is malformed
+func newMember(nm gossipdisc.NetworkMember, identity []byte) *member {
+	p := newPeer(nm.Endpoint, identity)
+	if p == nil {
+		return nil
+	}
+	m := &member{peer: p}
+	if nm.Properties != nil {
+		p.LedgerHeight = nm.Properties.LedgerHeight
+		m.installed = nm.Properties.InstalledChaincodes
+	} else if meta, err := state.FromBytes(nm.Metadata); err == nil {
+		// the metadata holds the sequence of the last committed block
+		p.LedgerHeight = meta.LedgerHeight + 1
+	}
+	return m
+}
+
+func (s *peerSupport) self(channel string, identity []byte) *member {
+	endpoint, err := peer.GetPeerEndpoint()
+	if err != nil {
+		logger.Warningf("Failed getting the endpoint of the peer: %s", err)
+		return nil
+	}
+	p := newPeer(endpoint.Address, identity)
+	if p == nil {
+		return nil
+	}
+	if l := peer.GetLedger(channel); l != nil {
+		if info, err := l.GetBlockchainInfo(); err == nil {
+			p.LedgerHeight = info.Height
+		}
+	}
+	return &member{peer: p, installed: peer.InstalledChaincodes()}
+}
+
+func newPeer(endpoint string, identity []byte) *discprotos.Peer {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(identity, sID); err != nil {
+		logger.Warningf("Failed unmarshalling identity of %s: %s", endpoint, err)
+		return nil
+	}
+	return &discprotos.Peer{
+		Endpoint: endpoint,
+		Identity: identity,
+		MspId:    sID.Mspid,
+	}
+}
+
+// EndorsementPolicy returns the endorsement policy of the chaincode, taken
+// from its committed definition, or from its lscc entry if it has none
+func (s *peerSupport) EndorsementPolicy(channel string, chaincode string) (*cb.SignaturePolicyEnvelope, error) {
+	_, policyBytes, err := definition(channel, chaincode)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &cb.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(policyBytes, policy); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling endorsement policy of %s: %s", chaincode, err)
+	}
+	return policy, nil
+}
+
+// definition returns the version and the endorsement policy of the
+// chaincode, taken from its committed definition, or from its lscc entry
+// if it has none
+func definition(channel string, chaincode string) (string, []byte, error) {
+	l := peer.GetLedger(channel)
+	if l == nil {
+		return "", nil, fmt.Errorf("channel %s not found", channel)
+	}
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		return "", nil, err
+	}
+	defer qe.Done()
+
+	defBytes, err := qe.GetState(lifecycle.Name, lifecycle.DefinitionKey(chaincode))
+	if err != nil {
+		return "", nil, err
+	}
+	if defBytes != nil {
+		def := &lb.ChaincodeDefinition{}
+		if err := proto.Unmarshal(defBytes, def); err != nil {
+			return "", nil, fmt.Errorf("failed unmarshalling definition of %s: %s", chaincode, err)
+		}
+		return def.Version, def.EndorsementPolicy, nil
+	}
+
+	cdBytes, err := qe.GetState("lscc", chaincode)
+	if err != nil {
+		return "", nil, err
+	}
+	if cdBytes == nil {
+		return "", nil, fmt.Errorf("chaincode %s not found", chaincode)
+	}
+	cd := &ccprovider.ChaincodeData{}
+	if err := proto.Unmarshal(cdBytes, cd); err != nil {
+		return "", nil, fmt.Errorf("failed unmarshalling chaincode data of %s: %s", chaincode, err)
+	}
+	return cd.Version, cd.Policy, nil
+}
+
+// SatisfiesPrincipal deserializes the identity with the MSPs of the
+// channel, and checks it against the principal
+func (s *peerSupport) SatisfiesPrincipal(channel string, identity []byte, principal *msp.MSPPrincipal) bool {
+	id, err := mspmgmt.GetIdentityDeserializer(channel).DeserializeIdentity(identity)
+	if err != nil {
+		logger.Debugf("Failed deserializing identity: %s", err)
+		return false
+	}
+	return id.SatisfiesPrincipal(principal) == nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package support
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/config"
+	gossipdisc "github.com/hyperledger/fabric/gossip/discovery"
+	fabricmsp "github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	gossipproto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+func orgGroup(t *testing.T, mspType fabricmsp.ProviderType, conf proto.Message) *cb.ConfigGroup {
+	confBytes, err := proto.Marshal(conf)
+	assert.NoError(t, err)
+	mspConfig, err := proto.Marshal(&msp.MSPConfig{Type: int32(mspType), Config: confBytes})
+	assert.NoError(t, err)
+	return &cb.ConfigGroup{Values: map[string]*cb.ConfigValue{config.MSPKey: {Value: mspConfig}}}
+}
+
+func TestMSPConfigs(t *testing.T) {
+	channelGroup := &cb.ConfigGroup{Groups: map[string]*cb.ConfigGroup{
+		config.ApplicationGroupKey: {Groups: map[string]*cb.ConfigGroup{
+			"Org1":   orgGroup(t, fabricmsp.FABRIC, &msp.FabricMSPConfig{Name: "Org1MSP"}),
+			"Idemix": orgGroup(t, fabricmsp.IDEMIX, &msp.IdemixMSPConfig{Name: "IdemixMSP"}),
+		}},
+		config.OrdererGroupKey: {Groups: map[string]*cb.ConfigGroup{
+			"OrdererOrg": orgGroup(t, fabricmsp.FABRIC, &msp.FabricMSPConfig{Name: "OrdererMSP"}),
+		}},
+	}}
+
+	msps, err := mspConfigs(channelGroup)
+	assert.NoError(t, err)
+	assert.Len(t, msps, 2, "the idemix MSP should be left out")
+	assert.Equal(t, "Org1MSP", msps["Org1MSP"].Name)
+	assert.Equal(t, "OrdererMSP", msps["OrdererMSP"].Name)
+
+	channelGroup.Groups[config.ApplicationGroupKey].Groups["Other"] = orgGroup(t, fabricmsp.ProviderType(42), &msp.FabricMSPConfig{Name: "OtherMSP"})
+	_, err = mspConfigs(channelGroup)
+	assert.Error(t, err, "MSPs of unknown types should be rejected")
+}
+
+func TestEndorsers(t *testing.T) {
+	identity, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("cert")})
+	assert.NoError(t, err)
+
+	withCC := newMember(gossipdisc.NetworkMember{
+		Endpoint: "p0",
+		Properties: &gossipproto.Properties{
+			LedgerHeight:        10,
+			InstalledChaincodes: []*gossipproto.Chaincode{{Name: "mycc", Version: "1.0"}},
+		},
+	}, identity)
+	assert.NotNil(t, withCC)
+	assert.Equal(t, uint64(10), withCC.peer.LedgerHeight)
+	assert.Equal(t, "Org1MSP", withCC.peer.MspId)
+
+	withOtherVersion := newMember(gossipdisc.NetworkMember{
+		Endpoint: "p1",
+		Properties: &gossipproto.Properties{
+			InstalledChaincodes: []*gossipproto.Chaincode{{Name: "mycc", Version: "0.9"}},
+		},
+	}, identity)
+	withoutProperties := newMember(gossipdisc.NetworkMember{Endpoint: "p2"}, identity)
+	assert.Nil(t, newMember(gossipdisc.NetworkMember{Endpoint: "p3"}, []byte{1, 2, 3}), "malformed identities should be left out")
+
+	members := []member{*withCC, *withOtherVersion, *withoutProperties}
+	assert.Equal(t, []*discprotos.Peer{withCC.peer}, endorsers(members, "mycc", "1.0"))
+	assert.Equal(t, []*discprotos.Peer{withOtherVersion.peer}, endorsers(members, "mycc", "0.9"))
+	assert.Empty(t, endorsers(members, "othercc", "1.0"))
+}
@@ -22,18 +22,30 @@ import (
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/policy"
 	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 type handler struct {
 	ChatStream       pb.Events_ChatServer
 	interestedEvents map[string]*pb.Interest
+
+	// aclProvider checks the creators of event messages
+	aclProvider aclmgmt.ACLProvider
 }
 
 func newEventHandler(stream pb.Events_ChatServer) (*handler, error) {
 	d := &handler{
 		ChatStream: stream,
+		// Events are not channel specific, hence neither channel
+		// policies nor channel ACLs are involved
+		aclProvider: aclmgmt.NewACLProvider(
+			policy.NewPolicyChecker(nil, mgmt.GetLocalMSP(), mgmt.NewLocalMSPPrincipalGetter()),
+			nil,
+		),
 	}
 	d.interestedEvents = make(map[string]*pb.Interest)
 	return d, nil
@@ -99,7 +111,7 @@ func (d *handler) deregisterAll() {
 
 // HandleMessage handles the Openchain messages for the Peer.
 func (d *handler) HandleMessage(msg *pb.SignedEvent) error {
-	evt, err := validateEventMessage(msg)
+	evt, err := d.validateEventMessage(msg)
 	if err != nil {
 		return fmt.Errorf("event message must be properly signed by an identity from the same organization as the peer: [%s]", err)
 	}
@@ -147,7 +159,7 @@ func (d *handler) SendMessage(msg *pb.Event) error {
 // However, this is not being done for v1.0 due to complexity concerns and the need to complex a stable,
 // minimally viable release. Eventually events will be made channel-specific, at which point this method
 // should be revisited
-func validateEventMessage(signedEvt *pb.SignedEvent) (*pb.Event, error) {
+func (d *handler) validateEventMessage(signedEvt *pb.SignedEvent) (*pb.Event, error) {
 	logger.Debugf("ValidateEventMessage starts for signed event %p", signedEvt)
 
 	// messages from the client for registering and unregistering must be signed
@@ -158,30 +170,16 @@ func validateEventMessage(signedEvt *pb.SignedEvent) (*pb.Event, error) {
 		return nil, fmt.Errorf("error unmarshaling the event bytes in the SignedEvent: %s", err)
 	}
 
-	localMSP := mgmt.GetLocalMSP()
-	principalGetter := mgmt.NewLocalMSPPrincipalGetter()
-
-	// Load MSPPrincipal for policy
-	principal, err := principalGetter.Get(mgmt.Members)
-	if err != nil {
-		return nil, fmt.Errorf("failed getting local MSP principal [member]: [%s]", err)
-	}
-
-	id, err := localMSP.DeserializeIdentity(evt.Creator)
-	if err != nil {
-		return nil, fmt.Errorf("failed deserializing event creator: [%s]", err)
-	}
-
-	// Verify that event's creator satisfies the principal
-	err = id.SatisfiesPrincipal(principal)
-	if err != nil {
-		return nil, fmt.Errorf("failed verifying the creator satisfies local MSP's [member] principal: [%s]", err)
-	}
-
-	// Verify the signature
-	err = id.Verify(signedEvt.EventBytes, signedEvt.Signature)
+	// Check the event's creator against the ACL of block events, which is
+	// the local MSP's [member] principal
+	sd := []*common.SignedData{{
+		Data:      signedEvt.EventBytes,
+		Identity:  evt.Creator,
+		Signature: signedEvt.Signature,
+	}}
+	err = d.aclProvider.CheckACL(aclmgmt.EventBlock, "", sd)
 	if err != nil {
-		return nil, fmt.Errorf("failed verifying the event signature: %s", err)
+		return nil, fmt.Errorf("failed verifying the event creator against the ACL of %s: [%s]", aclmgmt.EventBlock, err)
 	}
 
 	return evt, nil
@@ -145,8 +145,14 @@ func TestSignedEvent(t *testing.T) {
 		return
 	}
 
+	h, err := newEventHandler(nil)
+	if err != nil {
+		t.Fatalf("newEventHandler failed, err %s", err)
+		return
+	}
+
 	// validate it. Expected to succeed
-	_, err = validateEventMessage(sEvt)
+	_, err = h.validateEventMessage(sEvt)
 	if err != nil {
 		t.Fatalf("validateEventMessage failed, err %s", err)
 		return
@@ -156,7 +162,7 @@ func TestSignedEvent(t *testing.T) {
 	corrupt(sEvt.Signature)
 
 	// validate it, it should fail
-	_, err = validateEventMessage(sEvt)
+	_, err = h.validateEventMessage(sEvt)
 	if err == nil {
 		t.Fatalf("validateEventMessage should have failed")
 		return
@@ -177,7 +183,7 @@ func TestSignedEvent(t *testing.T) {
 	}
 
 	// validate it, it should fail
-	_, err = validateEventMessage(sEvt)
+	_, err = h.validateEventMessage(sEvt)
 	if err == nil {
 		t.Fatalf("validateEventMessage should have failed")
 		return
@@ -17,6 +17,8 @@ limitations under the License.
 package api
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/gossip/common"
 	"google.golang.org/grpc"
 )
@@ -57,6 +59,15 @@ type MessageCryptoService interface {
 	// If the identity is invalid, revoked, expired it returns an error.
 	// Else, returns nil
 	ValidateIdentity(peerIdentity PeerIdentityType) error
+
+	// Expiration returns:
+	// - The time when the identity expires, nil
+	//   In case it can expire
+	// - A zero value time.Time, nil
+	//   in case it cannot expire
+	// - A zero value, error in case it cannot be
+	//   determined if the identity can expire or not
+	Expiration(peerIdentity PeerIdentityType) (time.Time, error)
 }
 
 // PeerIdentityType is the peer's certificate
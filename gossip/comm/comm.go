@@ -52,6 +52,10 @@ type Comm interface {
 	// CloseConn closes a connection to a certain endpoint
 	CloseConn(peer *RemotePeer)
 
+	// UpdateIdentity replaces the identity this instance authenticates with
+	// to remote peers, and closes all existing connections
+	UpdateIdentity(identity api.PeerIdentityType)
+
 	// Stop stops the module
 	Stop()
 }
@@ -21,8 +21,6 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
-	"net"
-	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -30,14 +28,12 @@ import (
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/op/go-logging"
 	"github.com/spf13/viper"
-	"golang.org/x/net/context"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/peer"
 )
 
 const (
@@ -57,165 +53,127 @@ func (c *commImpl) SetDialOpts(opts ...grpc.DialOption) {
 		c.logger.Warning("Given an empty set of grpc.DialOption, aborting")
 		return
 	}
-	c.opts = opts
+	if t, isGRPCTransport := c.transport.(*grpcTransport); isGRPCTransport {
+		t.SetDialOpts(opts...)
+	}
 }
 
 // NewCommInstanceWithServer creates a comm instance that creates an underlying gRPC server
 func NewCommInstanceWithServer(port int, idMapper identity.Mapper, peerIdentity api.PeerIdentityType,
-	secureDialOpts api.PeerSecureDialOpts, dialOpts ...grpc.DialOption) (Comm, error) {
-
-	var ll net.Listener
-	var s *grpc.Server
-	var certHash []byte
-
-	if len(dialOpts) == 0 {
-		dialOpts = []grpc.DialOption{grpc.WithTimeout(util.GetDurationOrDefault("peer.gossip.dialTimeout", defDialTimeout))}
-	}
+	secureDialOpts api.PeerSecureDialOpts, commMetrics *metrics.CommMetrics, dialOpts ...grpc.DialOption) (Comm, error) {
 
+	var transport Transport
 	if port > 0 {
-		s, ll, secureDialOpts, certHash = createGRPCLayer(port)
+		transport = NewGRPCTransportWithServer(port, dialOpts...)
+	} else {
+		transport = newGRPCTransport(nil, nil, nil, secureDialOpts, dialOpts...)
 	}
-
-	commInst := &commImpl{
-		selfCertHash:   certHash,
-		PKIID:          idMapper.GetPKIidOfCert(peerIdentity),
-		idMapper:       idMapper,
-		logger:         util.GetLogger(util.LoggingCommModule, fmt.Sprintf("%d", port)),
-		peerIdentity:   peerIdentity,
-		opts:           dialOpts,
-		secureDialOpts: secureDialOpts,
-		port:           port,
-		lsnr:           ll,
-		gSrv:           s,
-		msgPublisher:   NewChannelDemultiplexer(),
-		lock:           &sync.RWMutex{},
-		deadEndpoints:  make(chan common.PKIidType, 100),
-		stopping:       int32(0),
-		exitChan:       make(chan struct{}, 1),
-		subscriptions:  make([]chan proto.ReceivedMessage, 0),
-	}
-	commInst.connStore = newConnStore(commInst, commInst.logger)
-
-	if port > 0 {
-		commInst.stopWG.Add(1)
-		go func() {
-			defer commInst.stopWG.Done()
-			s.Serve(ll)
-		}()
-		proto.RegisterGossipServer(s, commInst)
-	}
-
-	return commInst, nil
+	return createCommInstance(transport, fmt.Sprintf("%d", port), idMapper, peerIdentity, commMetrics), nil
 }
 
 // NewCommInstance creates a new comm instance that binds itself to the given gRPC server
 func NewCommInstance(s *grpc.Server, cert *tls.Certificate, idStore identity.Mapper,
 	peerIdentity api.PeerIdentityType, secureDialOpts api.PeerSecureDialOpts,
-	dialOpts ...grpc.DialOption) (Comm, error) {
+	commMetrics *metrics.CommMetrics, dialOpts ...grpc.DialOption) (Comm, error) {
 
 	dialOpts = append(dialOpts, grpc.WithTimeout(util.GetDurationOrDefault("peer.gossip.dialTimeout", defDialTimeout)))
-	commInst, err := NewCommInstanceWithServer(-1, idStore, peerIdentity, secureDialOpts, dialOpts...)
-	if err != nil {
-		return nil, err
-	}
-
-	if cert != nil {
-		inst := commInst.(*commImpl)
-		if len(cert.Certificate) == 0 {
-			inst.logger.Panic("Certificate supplied but certificate chain is empty")
-		} else {
-			inst.selfCertHash = certHashFromRawCert(cert.Certificate[0])
-		}
-	}
+	transport := NewGRPCTransport(s, cert, secureDialOpts, dialOpts...)
+	return createCommInstance(transport, "-1", idStore, peerIdentity, commMetrics), nil
+}
 
-	proto.RegisterGossipServer(s, commInst.(*commImpl))
+// NewCommInstanceWithTransport creates a comm instance that communicates
+// with remote peers over the given transport
+func NewCommInstanceWithTransport(transport Transport, idMapper identity.Mapper, peerIdentity api.PeerIdentityType,
+	commMetrics *metrics.CommMetrics) (Comm, error) {
+	return createCommInstance(transport, string(peerIdentity), idMapper, peerIdentity, commMetrics), nil
+}
 
-	return commInst, nil
+func createCommInstance(transport Transport, loggerID string, idMapper identity.Mapper, peerIdentity api.PeerIdentityType,
+	commMetrics *metrics.CommMetrics) *commImpl {
+	if commMetrics == nil {
+		commMetrics = metrics.NewDisabledGossipMetrics().CommMetrics
+	}
+	commInst := &commImpl{
+		selfCertHash:  transport.CertHash(),
+		PKIID:         idMapper.GetPKIidOfCert(peerIdentity),
+		idMapper:      idMapper,
+		logger:        util.GetLogger(util.LoggingCommModule, loggerID),
+		peerIdentity:  peerIdentity,
+		transport:     transport,
+		msgPublisher:  NewChannelDemultiplexer(),
+		lock:          &sync.RWMutex{},
+		deadEndpoints: make(chan common.PKIidType, 100),
+		stopping:      int32(0),
+		exitChan:      make(chan struct{}, 1),
+		subscriptions: make([]chan proto.ReceivedMessage, 0),
+		metrics:       commMetrics,
+	}
+	commInst.connStore = newConnStore(commInst, commInst.logger, commMetrics)
+	transport.Serve(commInst.serviceStream)
+	return commInst
 }
 
 type commImpl struct {
-	selfCertHash   []byte
-	peerIdentity   api.PeerIdentityType
-	idMapper       identity.Mapper
-	logger         *logging.Logger
-	opts           []grpc.DialOption
-	secureDialOpts func() []grpc.DialOption
-	connStore      *connectionStore
-	PKIID          []byte
-	deadEndpoints  chan common.PKIidType
-	msgPublisher   *ChannelDeMultiplexer
-	lock           *sync.RWMutex
-	lsnr           net.Listener
-	gSrv           *grpc.Server
-	exitChan       chan struct{}
-	stopWG         sync.WaitGroup
-	subscriptions  []chan proto.ReceivedMessage
-	port           int
-	stopping       int32
+	selfCertHash  []byte
+	peerIdentity  api.PeerIdentityType
+	idMapper      identity.Mapper
+	logger        *logging.Logger
+	transport     Transport
+	connStore     *connectionStore
+	PKIID         []byte
+	identityLock  sync.RWMutex
+	deadEndpoints chan common.PKIidType
+	msgPublisher  *ChannelDeMultiplexer
+	lock          *sync.RWMutex
+	exitChan      chan struct{}
+	stopWG        sync.WaitGroup
+	subscriptions []chan proto.ReceivedMessage
+	stopping      int32
+	metrics       *metrics.CommMetrics
 }
 
 func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidType) (*connection, error) {
-	var err error
-	var cc *grpc.ClientConn
-	var stream proto.Gossip_GossipStreamClient
-	var pkiID common.PKIidType
-	var connInfo *proto.ConnectionInfo
-	var dialOpts []grpc.DialOption
-
 	c.logger.Debug("Entering", endpoint, expectedPKIID)
 	defer c.logger.Debug("Exiting")
 
 	if c.isStopping() {
 		return nil, errors.New("Stopping")
 	}
-	dialOpts = append(dialOpts, c.secureDialOpts()...)
-	dialOpts = append(dialOpts, grpc.WithBlock())
-	dialOpts = append(dialOpts, c.opts...)
-	cc, err = grpc.Dial(endpoint, dialOpts...)
+	stream, err := c.transport.Dial(endpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	cl := proto.NewGossipClient(cc)
-
-	if _, err = cl.Ping(context.Background(), &proto.Empty{}); err != nil {
-		cc.Close()
+	connInfo, err := c.authenticateRemotePeer(stream)
+	if err != nil {
+		c.logger.Warning("Authentication failed:", err)
+		stream.Close()
 		return nil, err
 	}
+	pkiID := connInfo.ID
+	if expectedPKIID != nil && !bytes.Equal(pkiID, expectedPKIID) {
+		// PKIID is nil when we don't know the remote PKI id's
+		c.logger.Warning("Remote endpoint claims to be a different peer, expected", expectedPKIID, "but got", pkiID)
+		stream.Close()
+		return nil, errors.New("Authentication failure")
+	}
+	conn := newConnection(stream)
+	conn.pkiID = pkiID
+	conn.info = connInfo
+	conn.logger = c.logger
+	conn.metrics = c.metrics
 
-	ctx, cf := context.WithCancel(context.Background())
-	if stream, err = cl.GossipStream(ctx); err == nil {
-		connInfo, err = c.authenticateRemotePeer(stream)
-		if err == nil {
-			pkiID = connInfo.ID
-			if expectedPKIID != nil && !bytes.Equal(pkiID, expectedPKIID) {
-				// PKIID is nil when we don't know the remote PKI id's
-				c.logger.Warning("Remote endpoint claims to be a different peer, expected", expectedPKIID, "but got", pkiID)
-				cc.Close()
-				return nil, errors.New("Authentication failure")
-			}
-			conn := newConnection(cl, cc, stream, nil)
-			conn.pkiID = pkiID
-			conn.info = connInfo
-			conn.logger = c.logger
-			conn.cancel = cf
-
-			h := func(m *proto.SignedGossipMessage) {
-				c.logger.Debug("Got message:", m)
-				c.msgPublisher.DeMultiplex(&ReceivedMessageImpl{
-					conn:                conn,
-					lock:                conn,
-					SignedGossipMessage: m,
-					connInfo:            connInfo,
-				})
-			}
-			conn.handler = h
-			return conn, nil
-		}
-		c.logger.Warning("Authentication failed:", err)
+	h := func(m *proto.SignedGossipMessage) {
+		c.logger.Debug("Got message:", m)
+		c.msgPublisher.DeMultiplex(&ReceivedMessageImpl{
+			conn:                conn,
+			lock:                conn,
+			SignedGossipMessage: m,
+			connInfo:            connInfo,
+		})
 	}
-	cc.Close()
-	return nil, err
+	conn.handler = h
+	return conn, nil
 }
 
 func (c *commImpl) Send(msg *proto.SignedGossipMessage, peers ...*RemotePeer) {
@@ -258,50 +216,22 @@ func (c *commImpl) isStopping() bool {
 }
 
 func (c *commImpl) Probe(remotePeer *RemotePeer) error {
-	var dialOpts []grpc.DialOption
-	endpoint := remotePeer.Endpoint
-	pkiID := remotePeer.PKIID
 	if c.isStopping() {
 		return errors.New("Stopping")
 	}
-	c.logger.Debug("Entering, endpoint:", endpoint, "PKIID:", pkiID)
-	dialOpts = append(dialOpts, c.secureDialOpts()...)
-	dialOpts = append(dialOpts, grpc.WithBlock())
-	dialOpts = append(dialOpts, c.opts...)
-
-	cc, err := grpc.Dial(remotePeer.Endpoint, dialOpts...)
-	if err != nil {
-		c.logger.Debug("Returning", err)
-		return err
-	}
-	defer cc.Close()
-	cl := proto.NewGossipClient(cc)
-	_, err = cl.Ping(context.Background(), &proto.Empty{})
+	c.logger.Debug("Entering, endpoint:", remotePeer.Endpoint, "PKIID:", remotePeer.PKIID)
+	err := c.transport.Ping(remotePeer.Endpoint)
 	c.logger.Debug("Returning", err)
 	return err
 }
 
 func (c *commImpl) Handshake(remotePeer *RemotePeer) (api.PeerIdentityType, error) {
-	var dialOpts []grpc.DialOption
-	dialOpts = append(dialOpts, c.secureDialOpts()...)
-	dialOpts = append(dialOpts, grpc.WithBlock())
-	dialOpts = append(dialOpts, c.opts...)
-
-	cc, err := grpc.Dial(remotePeer.Endpoint, dialOpts...)
+	stream, err := c.transport.Dial(remotePeer.Endpoint)
 	if err != nil {
 		return nil, err
 	}
-	defer cc.Close()
-
-	cl := proto.NewGossipClient(cc)
-	if _, err = cl.Ping(context.Background(), &proto.Empty{}); err != nil {
-		return nil, err
-	}
+	defer stream.Close()
 
-	stream, err := cl.GossipStream(context.Background())
-	if err != nil {
-		return nil, err
-	}
 	connInfo, err := c.authenticateRemotePeer(stream)
 	if err != nil {
 		c.logger.Warning("Authentication failed:", err)
@@ -372,12 +302,7 @@ func (c *commImpl) Stop() {
 	atomic.StoreInt32(&c.stopping, int32(1))
 	c.logger.Info("Stopping")
 	defer c.logger.Info("Stopped")
-	if c.gSrv != nil {
-		c.gSrv.Stop()
-	}
-	if c.lsnr != nil {
-		c.lsnr.Close()
-	}
+	c.transport.Stop()
 	c.connStore.shutdown()
 	c.logger.Debug("Shut down connection store, connection count:", c.connStore.connNum())
 	c.exitChan <- struct{}{}
@@ -389,24 +314,26 @@ func (c *commImpl) Stop() {
 }
 
 func (c *commImpl) GetPKIid() common.PKIidType {
+	c.identityLock.RLock()
+	defer c.identityLock.RUnlock()
 	return c.PKIID
 }
 
-func extractRemoteAddress(stream stream) string {
-	var remoteAddress string
-	p, ok := peer.FromContext(stream.Context())
-	if ok {
-		if address := p.Addr; address != nil {
-			remoteAddress = address.String()
-		}
-	}
-	return remoteAddress
+// UpdateIdentity replaces the identity this instance authenticates with,
+// and closes all existing connections so that remote peers
+// re-authenticate this instance with its new identity
+func (c *commImpl) UpdateIdentity(identity api.PeerIdentityType) {
+	c.identityLock.Lock()
+	c.peerIdentity = identity
+	c.PKIID = c.idMapper.GetPKIidOfCert(identity)
+	c.identityLock.Unlock()
+	c.logger.Info("Updated identity, closing all connections")
+	c.connStore.closeAll()
 }
 
-func (c *commImpl) authenticateRemotePeer(stream stream) (*proto.ConnectionInfo, error) {
-	ctx := stream.Context()
-	remoteAddress := extractRemoteAddress(stream)
-	remoteCertHash := extractCertificateHashFromContext(ctx)
+func (c *commImpl) authenticateRemotePeer(stream Stream) (*proto.ConnectionInfo, error) {
+	remoteAddress := stream.RemoteAddress()
+	remoteCertHash := stream.RemoteCertHash()
 	var err error
 	var cMsg *proto.SignedGossipMessage
 	var signer proto.Signer
@@ -431,7 +358,10 @@ func (c *commImpl) authenticateRemotePeer(stream stream) (*proto.ConnectionInfo,
 		return nil, errors.New("No TLS certificate")
 	}
 
-	cMsg, err = c.createConnectionMsg(c.PKIID, c.selfCertHash, c.peerIdentity, signer)
+	c.identityLock.RLock()
+	selfPKIID, selfIdentity := c.PKIID, c.peerIdentity
+	c.identityLock.RUnlock()
+	cMsg, err = c.createConnectionMsg(selfPKIID, c.selfCertHash, selfIdentity, signer)
 	if err != nil {
 		return nil, err
 	}
@@ -494,7 +424,8 @@ func (c *commImpl) authenticateRemotePeer(stream stream) (*proto.ConnectionInfo,
 	return connInfo, nil
 }
 
-func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
+// serviceStream services a stream opened by a remote peer
+func (c *commImpl) serviceStream(stream Stream) error {
 	if c.isStopping() {
 		return errors.New("Shutting down")
 	}
@@ -503,7 +434,7 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 		c.logger.Error("Authentication failed:", err)
 		return err
 	}
-	c.logger.Debug("Servicing", extractRemoteAddress(stream))
+	c.logger.Debug("Servicing", stream.RemoteAddress())
 
 	conn := c.connStore.onConnected(stream, connInfo)
 
@@ -525,7 +456,7 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	conn.handler = h
 
 	defer func() {
-		c.logger.Debug("Client", extractRemoteAddress(stream), " disconnected")
+		c.logger.Debug("Client", stream.RemoteAddress(), " disconnected")
 		c.connStore.closeByPKIid(connInfo.ID)
 		conn.close()
 	}()
@@ -533,10 +464,6 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	return conn.serviceConnection()
 }
 
-func (c *commImpl) Ping(context.Context, *proto.Empty) (*proto.Empty, error) {
-	return &proto.Empty{}, nil
-}
-
 func (c *commImpl) disconnect(pkiID common.PKIidType) {
 	if c.isStopping() {
 		return
@@ -545,30 +472,17 @@ func (c *commImpl) disconnect(pkiID common.PKIidType) {
 	c.connStore.closeByPKIid(pkiID)
 }
 
-func readWithTimeout(stream interface{}, timeout time.Duration, address string) (*proto.SignedGossipMessage, error) {
+func readWithTimeout(stream Stream, timeout time.Duration, address string) (*proto.SignedGossipMessage, error) {
 	incChan := make(chan *proto.SignedGossipMessage, 1)
 	errChan := make(chan error, 1)
 	go func() {
-		if srvStr, isServerStr := stream.(proto.Gossip_GossipStreamServer); isServerStr {
-			if m, err := srvStr.Recv(); err == nil {
-				msg, err := m.ToGossipMessage()
-				if err != nil {
-					errChan <- err
-					return
-				}
-				incChan <- msg
-			}
-		} else if clStr, isClientStr := stream.(proto.Gossip_GossipStreamClient); isClientStr {
-			if m, err := clStr.Recv(); err == nil {
-				msg, err := m.ToGossipMessage()
-				if err != nil {
-					errChan <- err
-					return
-				}
-				incChan <- msg
+		if m, err := stream.Recv(); err == nil {
+			msg, err := m.ToGossipMessage()
+			if err != nil {
+				errChan <- err
+				return
 			}
-		} else {
-			panic(fmt.Errorf("Stream isn't a GossipStreamServer or a GossipStreamClient, but %v. Aborting", reflect.TypeOf(stream)))
+			incChan <- msg
 		}
 	}()
 	select {
@@ -599,44 +513,3 @@ func (c *commImpl) createConnectionMsg(pkiID common.PKIidType, certHash []byte,
 	_, err := sMsg.Sign(signer)
 	return sMsg, err
 }
-
-type stream interface {
-	Send(envelope *proto.Envelope) error
-	Recv() (*proto.Envelope, error)
-	grpc.Stream
-}
-
-func createGRPCLayer(port int) (*grpc.Server, net.Listener, api.PeerSecureDialOpts, []byte) {
-	var returnedCertHash []byte
-	var s *grpc.Server
-	var ll net.Listener
-	var err error
-	var serverOpts []grpc.ServerOption
-	var dialOpts []grpc.DialOption
-
-	cert := GenerateCertificatesOrPanic()
-	returnedCertHash = certHashFromRawCert(cert.Certificate[0])
-
-	tlsConf := &tls.Config{
-		Certificates:       []tls.Certificate{cert},
-		ClientAuth:         tls.RequestClientCert,
-		InsecureSkipVerify: true,
-	}
-	serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConf)))
-	ta := credentials.NewTLS(&tls.Config{
-		Certificates:       []tls.Certificate{cert},
-		InsecureSkipVerify: true,
-	})
-	dialOpts = append(dialOpts, grpc.WithTransportCredentials(ta))
-
-	listenAddress := fmt.Sprintf("%s:%d", "", port)
-	ll, err = net.Listen("tcp", listenAddress)
-	if err != nil {
-		panic(err)
-	}
-	secureDialOpts := func() []grpc.DialOption {
-		return dialOpts
-	}
-	s = grpc.NewServer(serverOpts...)
-	return s, ll, secureDialOpts, returnedCertHash
-}
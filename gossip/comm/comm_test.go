@@ -31,10 +31,12 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/metrics/prometheus"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/spf13/viper"
@@ -66,6 +68,11 @@ func (*naiveSecProvider) ValidateIdentity(peerIdentity api.PeerIdentityType) err
 	return nil
 }
 
+// Expiration returns the expiration time of the identity
+func (*naiveSecProvider) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Now().Add(time.Hour), nil
+}
+
 // GetPKIidOfCert returns the PKI-ID of a peer's identity
 func (*naiveSecProvider) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
 	return common.PKIidType(peerIdentity)
@@ -104,10 +111,12 @@ func (*naiveSecProvider) VerifyByChannel(_ common.ChainID, _ api.PeerIdentityTyp
 	return nil
 }
 
+var disabledMetrics = metrics.NewDisabledGossipMetrics().CommMetrics
+
 func newCommInstance(port int, sec api.MessageCryptoService) (Comm, error) {
 	endpoint := fmt.Sprintf("localhost:%d", port)
 	id := []byte(endpoint)
-	inst, err := NewCommInstanceWithServer(port, identity.NewIdentityMapper(sec, id), id, nil)
+	inst, err := NewCommInstanceWithServer(port, identity.NewIdentityMapper(sec, id), id, nil, disabledMetrics)
 	return inst, err
 }
 
@@ -228,7 +237,7 @@ func TestHandshake(t *testing.T) {
 	idMapper := identity.NewIdentityMapper(naiveSec, id)
 	inst, err := NewCommInstance(s, nil, idMapper, api.PeerIdentityType("localhost:9611"), func() []grpc.DialOption {
 		return []grpc.DialOption{grpc.WithInsecure()}
-	})
+	}, disabledMetrics)
 	assert.NoError(t, err)
 	var msg proto.ReceivedMessage
 
@@ -350,6 +359,93 @@ func TestBasic(t *testing.T) {
 	waitForMessages(t, out, 2, "Didn't receive 2 messages")
 }
 
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+	// Scenario: send a message from one comm instance to another,
+	// and ensure it is counted by both, under the channel only
+	// by the instance whose peer joined it
+	provider1 := prometheus.NewProvider()
+	provider2 := prometheus.NewProvider()
+	commMetrics1 := metrics.NewCommMetrics(provider1)
+	commMetrics1.AddChannel("A")
+	comm1, _ := NewCommInstanceWithServer(2100, identity.NewIdentityMapper(naiveSec, []byte("localhost:2100")),
+		[]byte("localhost:2100"), nil, commMetrics1)
+	comm2, _ := NewCommInstanceWithServer(3100, identity.NewIdentityMapper(naiveSec, []byte("localhost:3100")),
+		[]byte("localhost:3100"), nil, metrics.NewCommMetrics(provider2))
+	defer comm1.Stop()
+	defer comm2.Stop()
+	m2 := comm2.Accept(acceptAll)
+
+	msg, _ := (&proto.GossipMessage{
+		Tag:     proto.GossipMessage_CHAN_ONLY,
+		Channel: []byte("A"),
+		Content: &proto.GossipMessage_DataMsg{
+			DataMsg: &proto.DataMessage{},
+		},
+	}).NoopSign()
+	comm1.Send(msg, remotePeer(3100))
+	select {
+	case <-m2:
+	case <-time.After(time.Second * 10):
+		assert.Fail(t, "Didn't receive a message")
+		return
+	}
+
+	size := envelopeSize(msg.Envelope)
+	out1 := &bytes.Buffer{}
+	provider1.WriteTo(out1)
+	assert.Contains(t, out1.String(), `gossip_comm_messages_sent{type="DataMsg"} 1`)
+	assert.Contains(t, out1.String(), fmt.Sprintf(`gossip_comm_bytes_sent{channel="A"} %d`, size))
+	out2 := &bytes.Buffer{}
+	provider2.WriteTo(out2)
+	assert.Contains(t, out2.String(), `gossip_comm_messages_received{type="DataMsg"} 1`)
+	assert.Contains(t, out2.String(), fmt.Sprintf(`gossip_comm_bytes_received{channel="unknown"} %d`, size))
+}
+
+func TestUpdateIdentity(t *testing.T) {
+	t.Parallel()
+	// Scenario: comm1 sends a message to comm2, then updates its identity
+	// and sends another message. comm2 should receive the second message
+	// from the new PKI-ID of comm1, and be able to reply to it
+	comm1, _ := newCommInstance(2200, naiveSec)
+	comm2, _ := newCommInstance(3200, naiveSec)
+	defer comm1.Stop()
+	defer comm2.Stop()
+	m1 := comm1.Accept(acceptAll)
+	m2 := comm2.Accept(acceptAll)
+
+	comm1.Send(createGossipMsg(), remotePeer(3200))
+	select {
+	case m := <-m2:
+		assert.Equal(t, common.PKIidType("localhost:2200"), m.GetConnectionInfo().ID)
+	case <-time.After(time.Second * 10):
+		assert.Fail(t, "Didn't receive a message in time")
+		return
+	}
+
+	newIdentity := api.PeerIdentityType("localhost:2200-rotated")
+	comm1.UpdateIdentity(newIdentity)
+	assert.Equal(t, common.PKIidType(newIdentity), comm1.GetPKIid())
+
+	comm1.Send(createGossipMsg(), remotePeer(3200))
+	select {
+	case m := <-m2:
+		assert.Equal(t, common.PKIidType(newIdentity), m.GetConnectionInfo().ID)
+		assert.Equal(t, newIdentity, api.PeerIdentityType(m.GetConnectionInfo().Identity))
+	case <-time.After(time.Second * 10):
+		assert.Fail(t, "Didn't receive a message in time")
+		return
+	}
+
+	comm2.Send(createGossipMsg(), &RemotePeer{Endpoint: "localhost:2200", PKIID: common.PKIidType(newIdentity)})
+	select {
+	case m := <-m1:
+		assert.Equal(t, common.PKIidType("localhost:3200"), m.GetConnectionInfo().ID)
+	case <-time.After(time.Second * 10):
+		assert.Fail(t, "Didn't receive a message in time")
+	}
+}
+
 func TestProdConstructor(t *testing.T) {
 	t.Parallel()
 	peerIdentity := GenerateCertificatesOrPanic()
@@ -357,7 +453,8 @@ func TestProdConstructor(t *testing.T) {
 	defer srv.Stop()
 	defer lsnr.Close()
 	id := []byte("localhost:20000")
-	comm1, _ := NewCommInstance(srv, &peerIdentity, identity.NewIdentityMapper(naiveSec, id), id, dialOpts)
+	// nil metrics are disabled
+	comm1, _ := NewCommInstance(srv, &peerIdentity, identity.NewIdentityMapper(naiveSec, id), id, dialOpts, nil)
 	comm1.(*commImpl).selfCertHash = certHash
 	go srv.Serve(lsnr)
 
@@ -366,7 +463,7 @@ func TestProdConstructor(t *testing.T) {
 	defer srv.Stop()
 	defer lsnr.Close()
 	id = []byte("localhost:30000")
-	comm2, _ := NewCommInstance(srv, &peerIdentity, identity.NewIdentityMapper(naiveSec, id), id, dialOpts)
+	comm2, _ := NewCommInstance(srv, &peerIdentity, identity.NewIdentityMapper(naiveSec, id), id, dialOpts, nil)
 	comm2.(*commImpl).selfCertHash = certHash
 	go srv.Serve(lsnr)
 	defer comm1.Stop()
@@ -22,11 +22,10 @@ import (
 	"sync/atomic"
 
 	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/op/go-logging"
-	"golang.org/x/net/context"
-	"google.golang.org/grpc"
 )
 
 type handler func(message *proto.SignedGossipMessage)
@@ -37,6 +36,7 @@ type connFactory interface {
 
 type connectionStore struct {
 	logger           *logging.Logger          // logger
+	metrics          *metrics.CommMetrics     // metrics of the connections
 	isClosing        bool                     // whether this connection store is shutting down
 	connFactory      connFactory              // creates a connection to remote peer
 	sync.RWMutex                              // synchronize access to shared variables
@@ -45,8 +45,9 @@ type connectionStore struct {
 	// used to prevent concurrent connection establishment to the same remote endpoint
 }
 
-func newConnStore(connFactory connFactory, logger *logging.Logger) *connectionStore {
+func newConnStore(connFactory connFactory, logger *logging.Logger, commMetrics *metrics.CommMetrics) *connectionStore {
 	return &connectionStore{
+		metrics:          commMetrics,
 		connFactory:      connFactory,
 		isClosing:        false,
 		pki2Conn:         make(map[string]*connection),
@@ -144,13 +145,18 @@ func (cs *connectionStore) closeConn(peer *RemotePeer) {
 func (cs *connectionStore) shutdown() {
 	cs.Lock()
 	cs.isClosing = true
-	pkiIds2conn := cs.pki2Conn
+	cs.Unlock()
+	cs.closeAll()
+}
 
+// closeAll closes all connections of the store
+func (cs *connectionStore) closeAll() {
+	cs.RLock()
 	var connections2Close []*connection
-	for _, conn := range pkiIds2conn {
+	for _, conn := range cs.pki2Conn {
 		connections2Close = append(connections2Close, conn)
 	}
-	cs.Unlock()
+	cs.RUnlock()
 
 	wg := sync.WaitGroup{}
 	for _, conn := range connections2Close {
@@ -163,7 +169,7 @@ func (cs *connectionStore) shutdown() {
 	wg.Wait()
 }
 
-func (cs *connectionStore) onConnected(serverStream proto.Gossip_GossipStreamServer, connInfo *proto.ConnectionInfo) *connection {
+func (cs *connectionStore) onConnected(serverStream Stream, connInfo *proto.ConnectionInfo) *connection {
 	cs.Lock()
 	defer cs.Unlock()
 
@@ -174,11 +180,12 @@ func (cs *connectionStore) onConnected(serverStream proto.Gossip_GossipStreamSer
 	return cs.registerConn(connInfo, serverStream)
 }
 
-func (cs *connectionStore) registerConn(connInfo *proto.ConnectionInfo, serverStream proto.Gossip_GossipStreamServer) *connection {
-	conn := newConnection(nil, nil, nil, serverStream)
+func (cs *connectionStore) registerConn(connInfo *proto.ConnectionInfo, serverStream Stream) *connection {
+	conn := newConnection(serverStream)
 	conn.pkiID = connInfo.ID
 	conn.info = connInfo
 	conn.logger = cs.logger
+	conn.metrics = cs.metrics
 	cs.pki2Conn[string(connInfo.ID)] = conn
 	return conn
 }
@@ -192,34 +199,28 @@ func (cs *connectionStore) closeByPKIid(pkiID common.PKIidType) {
 	}
 }
 
-func newConnection(cl proto.GossipClient, c *grpc.ClientConn, cs proto.Gossip_GossipStreamClient, ss proto.Gossip_GossipStreamServer) *connection {
+func newConnection(stream Stream) *connection {
 	connection := &connection{
-		outBuff:      make(chan *msgSending, util.GetIntOrDefault("peer.gossip.sendBuffSize", defSendBuffSize)),
-		cl:           cl,
-		conn:         c,
-		clientStream: cs,
-		serverStream: ss,
-		stopFlag:     int32(0),
-		stopChan:     make(chan struct{}, 1),
+		outBuff:  make(chan *msgSending, util.GetIntOrDefault("peer.gossip.sendBuffSize", defSendBuffSize)),
+		stream:   stream,
+		stopFlag: int32(0),
+		stopChan: make(chan struct{}, 1),
 	}
 
 	return connection
 }
 
 type connection struct {
-	cancel       context.CancelFunc
 	info         *proto.ConnectionInfo
 	outBuff      chan *msgSending
-	logger       *logging.Logger                 // logger
-	pkiID        common.PKIidType                // pkiID of the remote endpoint
-	handler      handler                         // function to invoke upon a message reception
-	conn         *grpc.ClientConn                // gRPC connection to remote endpoint
-	cl           proto.GossipClient              // gRPC stub of remote endpoint
-	clientStream proto.Gossip_GossipStreamClient // client-side stream to remote endpoint
-	serverStream proto.Gossip_GossipStreamServer // server-side stream to remote endpoint
-	stopFlag     int32                           // indicates whether this connection is in process of stopping
-	stopChan     chan struct{}                   // a method to stop the server-side gRPC call from a different go-routine
-	sync.RWMutex                                 // synchronizes access to shared variables
+	logger       *logging.Logger      // logger
+	metrics      *metrics.CommMetrics // metrics of sent and received messages
+	pkiID        common.PKIidType     // pkiID of the remote endpoint
+	handler      handler              // function to invoke upon a message reception
+	stream       Stream               // stream to remote endpoint
+	stopFlag     int32                // indicates whether this connection is in process of stopping
+	stopChan     chan struct{}        // a method to stop the server-side stream handler from a different go-routine
+	sync.RWMutex                      // synchronizes access to shared variables
 }
 
 func (conn *connection) close() {
@@ -235,18 +236,9 @@ func (conn *connection) close() {
 	conn.stopChan <- struct{}{}
 
 	conn.Lock()
-
-	if conn.clientStream != nil {
-		conn.clientStream.CloseSend()
+	if conn.stream != nil {
+		conn.stream.Close()
 	}
-	if conn.conn != nil {
-		conn.conn.Close()
-	}
-
-	if conn.cancel != nil {
-		conn.cancel()
-	}
-
 	conn.Unlock()
 
 }
@@ -259,9 +251,13 @@ func (conn *connection) send(msg *proto.SignedGossipMessage, onErr func(error))
 	conn.Lock()
 	defer conn.Unlock()
 
+	msgType := metrics.MessageType(msg.GossipMessage)
 	if len(conn.outBuff) == util.GetIntOrDefault("peer.gossip.sendBuffSize", defSendBuffSize) {
+		conn.metrics.DroppedMessages.With(msgType).Add(1)
 		return
 	}
+	conn.metrics.SentMessages.With(msgType).Add(1)
+	conn.metrics.SentBytes.With(conn.metrics.ChannelLabel(msg.Channel)).Add(float64(envelopeSize(msg.Envelope)))
 
 	m := &msgSending{
 		envelope: msg.Envelope,
@@ -347,32 +343,30 @@ func (conn *connection) readFromStream(errChan chan error, msgChan chan *proto.S
 		if err != nil {
 			errChan <- err
 			conn.logger.Warning(conn.pkiID, "Got error, aborting:", err)
+		} else {
+			conn.metrics.ReceivedMessages.With(metrics.MessageType(msg.GossipMessage)).Add(1)
+			conn.metrics.ReceivedBytes.With(conn.metrics.ChannelLabel(msg.Channel)).Add(float64(envelopeSize(envelope)))
 		}
 		msgChan <- msg
 	}
 }
 
-func (conn *connection) getStream() stream {
+func (conn *connection) getStream() Stream {
 	conn.Lock()
 	defer conn.Unlock()
-
-	if conn.clientStream != nil && conn.serverStream != nil {
-		e := "Both client and server stream are not nil, something went wrong"
-		conn.logger.Error(e)
-	}
-
-	if conn.clientStream != nil {
-		return conn.clientStream
-	}
-
-	if conn.serverStream != nil {
-		return conn.serverStream
-	}
-
-	return nil
+	return conn.stream
 }
 
 type msgSending struct {
 	envelope *proto.Envelope
 	onErr    func(error)
 }
+
+// envelopeSize returns the number of bytes the given envelope carries
+func envelopeSize(e *proto.Envelope) int {
+	size := len(e.Payload) + len(e.Signature)
+	if e.SecretEnvelope != nil {
+		size += len(e.SecretEnvelope.Payload) + len(e.SecretEnvelope.Signature)
+	}
+	return size
+}
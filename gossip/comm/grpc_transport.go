@@ -0,0 +1,348 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package comm
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// NewGRPCTransport creates a Transport that binds itself to the given gRPC server.
+// The hash of the given TLS certificate is sent to remote peers in order to bind
+// the TLS session to the peer's identity
+func NewGRPCTransport(s *grpc.Server, cert *tls.Certificate, secureDialOpts api.PeerSecureDialOpts,
+	dialOpts ...grpc.DialOption) Transport {
+	var certHash []byte
+	if cert != nil {
+		if len(cert.Certificate) == 0 {
+			panic(errors.New("Certificate supplied but certificate chain is empty"))
+		}
+		certHash = certHashFromRawCert(cert.Certificate[0])
+	}
+	t := newGRPCTransport(nil, nil, certHash, secureDialOpts, dialOpts...)
+	proto.RegisterGossipServer(s, &gossipServer{transport: t})
+	return t
+}
+
+// NewGRPCTransportWithServer creates a Transport that creates an underlying gRPC server
+// listening on the given port, with a self-signed TLS certificate
+func NewGRPCTransportWithServer(port int, dialOpts ...grpc.DialOption) Transport {
+	s, ll, secureDialOpts, certHash := createGRPCLayer(port)
+	t := newGRPCTransport(s, ll, certHash, secureDialOpts, dialOpts...)
+	proto.RegisterGossipServer(s, &gossipServer{transport: t})
+	return t
+}
+
+func newGRPCTransport(s *grpc.Server, ll net.Listener, certHash []byte, secureDialOpts api.PeerSecureDialOpts,
+	dialOpts ...grpc.DialOption) *grpcTransport {
+	if len(dialOpts) == 0 {
+		dialOpts = []grpc.DialOption{grpc.WithTimeout(util.GetDurationOrDefault("peer.gossip.dialTimeout", defDialTimeout))}
+	}
+	if secureDialOpts == nil {
+		secureDialOpts = func() []grpc.DialOption {
+			return nil
+		}
+	}
+	return &grpcTransport{
+		certHash:       certHash,
+		opts:           dialOpts,
+		secureDialOpts: secureDialOpts,
+		gSrv:           s,
+		lsnr:           ll,
+		conns:          make(map[string]*sharedConn),
+	}
+}
+
+// grpcTransport is a Transport that multiplexes the streams and pings
+// to a remote endpoint over a single gRPC connection
+type grpcTransport struct {
+	certHash       []byte
+	opts           []grpc.DialOption
+	secureDialOpts api.PeerSecureDialOpts
+	gSrv           *grpc.Server
+	lsnr           net.Listener
+	handler        atomic.Value
+	stopping       int32
+	stopWG         sync.WaitGroup
+	sync.Mutex
+	conns map[string]*sharedConn
+}
+
+// sharedConn is a gRPC connection shared among
+// all streams and pings to the same remote endpoint
+type sharedConn struct {
+	*grpc.ClientConn
+	refCount int
+}
+
+// SetDialOpts sets the options the transport dials remote endpoints with
+func (t *grpcTransport) SetDialOpts(opts ...grpc.DialOption) {
+	t.Lock()
+	defer t.Unlock()
+	t.opts = opts
+}
+
+func (t *grpcTransport) Serve(handler StreamHandler) {
+	t.handler.Store(handler)
+	if t.gSrv == nil {
+		return
+	}
+	t.stopWG.Add(1)
+	go func() {
+		defer t.stopWG.Done()
+		t.gSrv.Serve(t.lsnr)
+	}()
+}
+
+func (t *grpcTransport) CertHash() []byte {
+	return t.certHash
+}
+
+func (t *grpcTransport) Ping(endpoint string) error {
+	cc, release, err := t.acquireConn(endpoint)
+	if err != nil {
+		return err
+	}
+	defer release()
+	_, err = proto.NewGossipClient(cc).Ping(context.Background(), &proto.Empty{})
+	return err
+}
+
+func (t *grpcTransport) Dial(endpoint string) (Stream, error) {
+	cc, release, err := t.acquireConn(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	cl := proto.NewGossipClient(cc)
+	if _, err = cl.Ping(context.Background(), &proto.Empty{}); err != nil {
+		release()
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := cl.GossipStream(ctx)
+	if err != nil {
+		cancel()
+		release()
+		return nil, err
+	}
+	return &grpcClientStream{
+		Gossip_GossipStreamClient: stream,
+		cancel:                    cancel,
+		release:                   release,
+	}, nil
+}
+
+// acquireConn returns a gRPC connection to the given endpoint, and a function
+// that releases it. The connection is closed once all its holders release it
+func (t *grpcTransport) acquireConn(endpoint string) (*grpc.ClientConn, func(), error) {
+	if t.isStopping() {
+		return nil, nil, errors.New("Stopping")
+	}
+
+	t.Lock()
+	conn, exists := t.conns[endpoint]
+	if exists {
+		conn.refCount++
+		t.Unlock()
+		return conn.ClientConn, t.releaseFunc(endpoint, conn), nil
+	}
+	var dialOpts []grpc.DialOption
+	dialOpts = append(dialOpts, t.secureDialOpts()...)
+	dialOpts = append(dialOpts, grpc.WithBlock())
+	dialOpts = append(dialOpts, t.opts...)
+	t.Unlock()
+
+	cc, err := grpc.Dial(endpoint, dialOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	if t.isStopping() {
+		cc.Close()
+		return nil, nil, errors.New("Stopping")
+	}
+	// Someone else might have connected to the endpoint while we were dialing
+	if conn, exists = t.conns[endpoint]; exists {
+		cc.Close()
+	} else {
+		conn = &sharedConn{ClientConn: cc}
+		t.conns[endpoint] = conn
+	}
+	conn.refCount++
+	return conn.ClientConn, t.releaseFunc(endpoint, conn), nil
+}
+
+func (t *grpcTransport) releaseFunc(endpoint string, conn *sharedConn) func() {
+	once := sync.Once{}
+	return func() {
+		once.Do(func() {
+			t.Lock()
+			defer t.Unlock()
+			conn.refCount--
+			if conn.refCount > 0 {
+				return
+			}
+			conn.Close()
+			if t.conns[endpoint] == conn {
+				delete(t.conns, endpoint)
+			}
+		})
+	}
+}
+
+func (t *grpcTransport) isStopping() bool {
+	return atomic.LoadInt32(&t.stopping) == int32(1)
+}
+
+func (t *grpcTransport) Stop() {
+	if !atomic.CompareAndSwapInt32(&t.stopping, int32(0), int32(1)) {
+		return
+	}
+	if t.gSrv != nil {
+		t.gSrv.Stop()
+	}
+	if t.lsnr != nil {
+		t.lsnr.Close()
+	}
+	t.Lock()
+	for endpoint, conn := range t.conns {
+		conn.Close()
+		delete(t.conns, endpoint)
+	}
+	t.Unlock()
+	t.stopWG.Wait()
+}
+
+// gossipServer services the gRPC calls of remote peers
+type gossipServer struct {
+	transport *grpcTransport
+}
+
+// GossipStream is the gRPC handler of streams that remote peers open
+func (s *gossipServer) GossipStream(stream proto.Gossip_GossipStreamServer) error {
+	if s.transport.isStopping() {
+		return errors.New("Shutting down")
+	}
+	handler, isServing := s.transport.handler.Load().(StreamHandler)
+	if !isServing {
+		return errors.New("Not serving streams yet")
+	}
+	return handler(&grpcServerStream{Gossip_GossipStreamServer: stream})
+}
+
+// Ping is the gRPC handler of pings of remote peers
+func (s *gossipServer) Ping(context.Context, *proto.Empty) (*proto.Empty, error) {
+	return &proto.Empty{}, nil
+}
+
+// grpcClientStream is a Stream this peer opened to a remote peer
+type grpcClientStream struct {
+	proto.Gossip_GossipStreamClient
+	cancel  context.CancelFunc
+	release func()
+}
+
+func (s *grpcClientStream) RemoteAddress() string {
+	return extractRemoteAddress(s.Context())
+}
+
+func (s *grpcClientStream) RemoteCertHash() []byte {
+	return extractCertificateHashFromContext(s.Context())
+}
+
+func (s *grpcClientStream) Close() {
+	s.CloseSend()
+	s.cancel()
+	s.release()
+}
+
+// grpcServerStream is a Stream a remote peer opened to this peer
+type grpcServerStream struct {
+	proto.Gossip_GossipStreamServer
+}
+
+func (s *grpcServerStream) RemoteAddress() string {
+	return extractRemoteAddress(s.Context())
+}
+
+func (s *grpcServerStream) RemoteCertHash() []byte {
+	return extractCertificateHashFromContext(s.Context())
+}
+
+// Close is a no-op, since the stream is closed when the gRPC handler returns
+func (s *grpcServerStream) Close() {
+}
+
+func extractRemoteAddress(ctx context.Context) string {
+	var remoteAddress string
+	p, ok := peer.FromContext(ctx)
+	if ok {
+		if address := p.Addr; address != nil {
+			remoteAddress = address.String()
+		}
+	}
+	return remoteAddress
+}
+
+func createGRPCLayer(port int) (*grpc.Server, net.Listener, api.PeerSecureDialOpts, []byte) {
+	var returnedCertHash []byte
+	var s *grpc.Server
+	var ll net.Listener
+	var err error
+	var serverOpts []grpc.ServerOption
+	var dialOpts []grpc.DialOption
+
+	cert := GenerateCertificatesOrPanic()
+	returnedCertHash = certHashFromRawCert(cert.Certificate[0])
+
+	tlsConf := &tls.Config{
+		Certificates:       []tls.Certificate{cert},
+		ClientAuth:         tls.RequestClientCert,
+		InsecureSkipVerify: true,
+	}
+	serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConf)))
+	ta := credentials.NewTLS(&tls.Config{
+		Certificates:       []tls.Certificate{cert},
+		InsecureSkipVerify: true,
+	})
+	dialOpts = append(dialOpts, grpc.WithTransportCredentials(ta))
+
+	listenAddress := fmt.Sprintf("%s:%d", "", port)
+	ll, err = net.Listen("tcp", listenAddress)
+	if err != nil {
+		panic(err)
+	}
+	secureDialOpts := func() []grpc.DialOption {
+		return dialOpts
+	}
+	s = grpc.NewServer(serverOpts...)
+	return s, ll, secureDialOpts, returnedCertHash
+}
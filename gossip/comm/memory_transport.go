@@ -0,0 +1,356 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package comm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	pb "github.com/golang/protobuf/proto"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+const memoryStreamBuffSize = 1000
+
+// MemoryNetwork connects in-memory transports of peers running in the same process.
+// It allows simulating latency, network partitions and message loss between the peers
+type MemoryNetwork struct {
+	sync.RWMutex
+	random     *rand.Rand
+	transports map[string]*memoryTransport
+	partitions map[string]int
+	latency    time.Duration
+	lossRate   float64
+	conns      map[*memoryConn]struct{}
+}
+
+// NewMemoryNetwork creates a MemoryNetwork.
+// The given seed determines which messages are lost
+func NewMemoryNetwork(seed int64) *MemoryNetwork {
+	return &MemoryNetwork{
+		random:     rand.New(rand.NewSource(seed)),
+		transports: make(map[string]*memoryTransport),
+		partitions: make(map[string]int),
+		conns:      make(map[*memoryConn]struct{}),
+	}
+}
+
+// NewTransport creates a Transport bound to the given endpoint of the network
+func (n *MemoryNetwork) NewTransport(endpoint string) (Transport, error) {
+	n.Lock()
+	defer n.Unlock()
+	if _, exists := n.transports[endpoint]; exists {
+		return nil, fmt.Errorf("endpoint %s is already in use", endpoint)
+	}
+	t := &memoryTransport{
+		network:  n,
+		endpoint: endpoint,
+	}
+	n.transports[endpoint] = t
+	return t, nil
+}
+
+// SetLatency sets the time it takes for messages to be delivered
+func (n *MemoryNetwork) SetLatency(latency time.Duration) {
+	n.Lock()
+	defer n.Unlock()
+	n.latency = latency
+}
+
+// SetLossRate sets the probability of a message to be lost,
+// which is expected to be between 0 and 1
+func (n *MemoryNetwork) SetLossRate(lossRate float64) {
+	n.Lock()
+	defer n.Unlock()
+	n.lossRate = lossRate
+}
+
+// Partition splits the network into the given groups of endpoints, and the group of
+// endpoints that aren't in any of the given groups. Endpoints can only communicate
+// with endpoints of their own group, and streams between groups are closed
+func (n *MemoryNetwork) Partition(groups ...[]string) {
+	n.Lock()
+	n.partitions = make(map[string]int)
+	for i, group := range groups {
+		for _, endpoint := range group {
+			n.partitions[endpoint] = i + 1
+		}
+	}
+	var conns2Close []*memoryConn
+	for conn := range n.conns {
+		if !n.canCommunicate(conn.initiator, conn.acceptor) {
+			conns2Close = append(conns2Close, conn)
+		}
+	}
+	n.Unlock()
+
+	for _, conn := range conns2Close {
+		conn.close()
+	}
+}
+
+// Heal removes all partitions of the network
+func (n *MemoryNetwork) Heal() {
+	n.Partition()
+}
+
+// canCommunicate returns whether the given endpoints are in the same partition.
+// Must be called while holding the lock
+func (n *MemoryNetwork) canCommunicate(endpoint1, endpoint2 string) bool {
+	return n.partitions[endpoint1] == n.partitions[endpoint2]
+}
+
+// reachable returns the transport of the given endpoint,
+// if it is serving and reachable from the given source endpoint
+func (n *MemoryNetwork) reachable(source, endpoint string) (*memoryTransport, error) {
+	n.RLock()
+	defer n.RUnlock()
+	t, exists := n.transports[endpoint]
+	if !exists {
+		return nil, fmt.Errorf("%s is unreachable: no such endpoint", endpoint)
+	}
+	if !n.canCommunicate(source, endpoint) {
+		return nil, fmt.Errorf("%s is unreachable from %s: network is partitioned", endpoint, source)
+	}
+	if t.getHandler() == nil {
+		return nil, fmt.Errorf("%s is unreachable: not serving", endpoint)
+	}
+	return t, nil
+}
+
+// transmit returns whether a message sent from the given source
+// to the given destination should be delivered, and after how long
+func (n *MemoryNetwork) transmit(source, destination string) (bool, time.Duration, error) {
+	n.Lock()
+	defer n.Unlock()
+	if !n.canCommunicate(source, destination) {
+		return false, 0, fmt.Errorf("%s is unreachable from %s: network is partitioned", destination, source)
+	}
+	if n.lossRate > 0 && n.random.Float64() < n.lossRate {
+		return false, 0, nil
+	}
+	return true, n.latency, nil
+}
+
+func (n *MemoryNetwork) connect(source string, target *memoryTransport) *memoryConn {
+	n.Lock()
+	defer n.Unlock()
+	conn := &memoryConn{
+		network:   n,
+		initiator: source,
+		acceptor:  target.endpoint,
+		closed:    make(chan struct{}),
+	}
+	conn.initiatorStream = newMemoryStream(conn, source, target.endpoint)
+	conn.acceptorStream = newMemoryStream(conn, target.endpoint, source)
+	conn.initiatorStream.peer = conn.acceptorStream
+	conn.acceptorStream.peer = conn.initiatorStream
+	n.conns[conn] = struct{}{}
+	return conn
+}
+
+func (n *MemoryNetwork) remove(t *memoryTransport) {
+	n.Lock()
+	if n.transports[t.endpoint] == t {
+		delete(n.transports, t.endpoint)
+	}
+	var conns2Close []*memoryConn
+	for conn := range n.conns {
+		if conn.initiator == t.endpoint || conn.acceptor == t.endpoint {
+			conns2Close = append(conns2Close, conn)
+		}
+	}
+	n.Unlock()
+
+	for _, conn := range conns2Close {
+		conn.close()
+	}
+}
+
+// memoryTransport is a Transport over a MemoryNetwork
+type memoryTransport struct {
+	sync.RWMutex
+	network  *MemoryNetwork
+	endpoint string
+	handler  StreamHandler
+}
+
+func (t *memoryTransport) getHandler() StreamHandler {
+	t.RLock()
+	defer t.RUnlock()
+	return t.handler
+}
+
+func (t *memoryTransport) Serve(handler StreamHandler) {
+	t.Lock()
+	defer t.Unlock()
+	t.handler = handler
+}
+
+func (t *memoryTransport) Ping(endpoint string) error {
+	_, err := t.network.reachable(t.endpoint, endpoint)
+	return err
+}
+
+func (t *memoryTransport) Dial(endpoint string) (Stream, error) {
+	target, err := t.network.reachable(t.endpoint, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	conn := t.network.connect(t.endpoint, target)
+	handler := target.getHandler()
+	go func() {
+		defer conn.close()
+		if handler != nil {
+			handler(conn.acceptorStream)
+		}
+	}()
+	return conn.initiatorStream, nil
+}
+
+// CertHash returns nil, since the in-memory transport doesn't use TLS
+func (t *memoryTransport) CertHash() []byte {
+	return nil
+}
+
+func (t *memoryTransport) Stop() {
+	t.network.remove(t)
+}
+
+// memoryConn connects two memory streams
+type memoryConn struct {
+	network         *MemoryNetwork
+	initiator       string
+	acceptor        string
+	initiatorStream *memoryStream
+	acceptorStream  *memoryStream
+	closeOnce       sync.Once
+	closed          chan struct{}
+}
+
+func (conn *memoryConn) close() {
+	conn.closeOnce.Do(func() {
+		close(conn.closed)
+		conn.network.Lock()
+		delete(conn.network.conns, conn)
+		conn.network.Unlock()
+	})
+}
+
+type delayedEnvelope struct {
+	envelope  *proto.Envelope
+	deliverAt time.Time
+}
+
+// memoryStream is one side of a memoryConn
+type memoryStream struct {
+	conn    *memoryConn
+	local   string
+	remote  string
+	peer    *memoryStream
+	pending chan *delayedEnvelope
+	inbox   chan *proto.Envelope
+}
+
+func newMemoryStream(conn *memoryConn, local, remote string) *memoryStream {
+	s := &memoryStream{
+		conn:    conn,
+		local:   local,
+		remote:  remote,
+		pending: make(chan *delayedEnvelope, memoryStreamBuffSize),
+		inbox:   make(chan *proto.Envelope),
+	}
+	go s.deliver()
+	return s
+}
+
+// deliver moves envelopes sent to this stream into its inbox once their latency passes
+func (s *memoryStream) deliver() {
+	for {
+		select {
+		case m := <-s.pending:
+			if delay := m.deliverAt.Sub(time.Now()); delay > 0 {
+				select {
+				case <-time.After(delay):
+				case <-s.conn.closed:
+					return
+				}
+			}
+			select {
+			case s.inbox <- m.envelope:
+			case <-s.conn.closed:
+				return
+			}
+		case <-s.conn.closed:
+			return
+		}
+	}
+}
+
+func (s *memoryStream) Send(envelope *proto.Envelope) error {
+	select {
+	case <-s.conn.closed:
+		return errors.New("stream closed")
+	default:
+	}
+	deliver, latency, err := s.conn.network.transmit(s.local, s.remote)
+	if err != nil {
+		s.conn.close()
+		return err
+	}
+	if !deliver {
+		return nil
+	}
+	// Clone the envelope, as the sender and the receiver would have
+	// distinct copies of it if it were sent over the network
+	m := &delayedEnvelope{
+		envelope:  pb.Clone(envelope).(*proto.Envelope),
+		deliverAt: time.Now().Add(latency),
+	}
+	select {
+	case s.peer.pending <- m:
+		return nil
+	case <-s.conn.closed:
+		return errors.New("stream closed")
+	}
+}
+
+func (s *memoryStream) Recv() (*proto.Envelope, error) {
+	select {
+	case envelope := <-s.inbox:
+		return envelope, nil
+	case <-s.conn.closed:
+		return nil, io.EOF
+	}
+}
+
+func (s *memoryStream) RemoteAddress() string {
+	return s.remote
+}
+
+// RemoteCertHash returns nil, since the in-memory transport doesn't use TLS
+func (s *memoryStream) RemoteCertHash() []byte {
+	return nil
+}
+
+func (s *memoryStream) Close() {
+	s.conn.close()
+}
@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package comm
+
+import (
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+// Stream is a bidirectional stream of envelopes between this peer and a remote peer
+type Stream interface {
+	// Send sends an envelope to the remote peer
+	Send(envelope *proto.Envelope) error
+
+	// Recv blocks until an envelope is received from the remote peer,
+	// and returns an error if the stream has been closed
+	Recv() (*proto.Envelope, error)
+
+	// RemoteAddress returns the address of the remote peer
+	RemoteAddress() string
+
+	// RemoteCertHash returns the hash of the TLS certificate the remote peer
+	// used to open the stream, or nil if the stream isn't bound to a TLS session
+	RemoteCertHash() []byte
+
+	// Close closes the stream
+	Close()
+}
+
+// StreamHandler services a stream opened by a remote peer.
+// The stream is closed once the handler returns
+type StreamHandler func(stream Stream) error
+
+// Transport opens streams to remote peers, and dispatches
+// streams that remote peers open to a StreamHandler
+type Transport interface {
+	// Serve dispatches streams that remote peers open to the given handler
+	Serve(handler StreamHandler)
+
+	// Ping returns nil if the remote peer at the given endpoint is responsive,
+	// and an error if it's not
+	Ping(endpoint string) error
+
+	// Dial opens a stream to the remote peer at the given endpoint
+	Dial(endpoint string) (Stream, error)
+
+	// CertHash returns the hash of the TLS certificate this transport
+	// authenticates with, or nil if it doesn't use TLS
+	CertHash() []byte
+
+	// Stop stops the transport
+	Stop()
+}
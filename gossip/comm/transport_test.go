@@ -0,0 +1,208 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package comm
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/identity"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMemoryCommInstance(t *testing.T, network *MemoryNetwork, endpoint string) Comm {
+	transport, err := network.NewTransport(endpoint)
+	assert.NoError(t, err)
+	id := api.PeerIdentityType(endpoint)
+	inst, err := NewCommInstanceWithTransport(transport, identity.NewIdentityMapper(naiveSec, id), id, disabledMetrics)
+	assert.NoError(t, err)
+	return inst
+}
+
+func memoryPeer(endpoint string) *RemotePeer {
+	return &RemotePeer{Endpoint: endpoint, PKIID: common.PKIidType(endpoint)}
+}
+
+func receivedWithin(msgChan <-chan proto.ReceivedMessage, timeout time.Duration) bool {
+	select {
+	case <-msgChan:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func TestMemoryTransport(t *testing.T) {
+	t.Parallel()
+	network := NewMemoryNetwork(0)
+	comm1 := newMemoryCommInstance(t, network, "p1")
+	comm2 := newMemoryCommInstance(t, network, "p2")
+	defer comm1.Stop()
+	defer comm2.Stop()
+
+	_, err := network.NewTransport("p1")
+	assert.Error(t, err)
+
+	assert.NoError(t, comm1.Probe(memoryPeer("p2")))
+	assert.Error(t, comm1.Probe(memoryPeer("p3")))
+	id, err := comm1.Handshake(memoryPeer("p2"))
+	assert.NoError(t, err)
+	assert.Equal(t, api.PeerIdentityType("p2"), id)
+	_, err = comm1.Handshake(&RemotePeer{Endpoint: "p2", PKIID: common.PKIidType("p3")})
+	assert.Error(t, err)
+
+	m1 := comm1.Accept(acceptAll)
+	m2 := comm2.Accept(acceptAll)
+	comm1.Send(createGossipMsg(), memoryPeer("p2"))
+	select {
+	case m := <-m2:
+		assert.Equal(t, common.PKIidType("p1"), m.GetConnectionInfo().ID)
+		assert.Equal(t, "p1", m.GetConnectionInfo().Endpoint)
+		m.Respond(createGossipMsg().GossipMessage)
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "Didn't receive a message in time")
+		return
+	}
+	assert.True(t, receivedWithin(m1, time.Second*5), "Didn't receive a response in time")
+
+	// Once a peer stops, it is no longer reachable, and its endpoint can be reused
+	comm2.Stop()
+	assert.Error(t, comm1.Probe(memoryPeer("p2")))
+	comm2 = newMemoryCommInstance(t, network, "p2")
+	defer comm2.Stop()
+	assert.NoError(t, comm1.Probe(memoryPeer("p2")))
+}
+
+func TestMemoryTransportPartition(t *testing.T) {
+	t.Parallel()
+	network := NewMemoryNetwork(0)
+	comm1 := newMemoryCommInstance(t, network, "p1")
+	comm2 := newMemoryCommInstance(t, network, "p2")
+	comm3 := newMemoryCommInstance(t, network, "p3")
+	defer comm1.Stop()
+	defer comm2.Stop()
+	defer comm3.Stop()
+	m2 := comm2.Accept(acceptAll)
+	m3 := comm3.Accept(acceptAll)
+
+	comm1.Send(createGossipMsg(), memoryPeer("p2"))
+	assert.True(t, receivedWithin(m2, time.Second*5))
+
+	// Isolate p2 from p1 and p3
+	network.Partition([]string{"p2"})
+	assert.Error(t, comm1.Probe(memoryPeer("p2")))
+	assert.NoError(t, comm1.Probe(memoryPeer("p3")))
+	comm1.Send(createGossipMsg(), memoryPeer("p3"))
+	assert.True(t, receivedWithin(m3, time.Second*5))
+	comm1.Send(createGossipMsg(), memoryPeer("p2"))
+	assert.False(t, receivedWithin(m2, time.Second))
+	select {
+	case pkiID := <-comm1.PresumedDead():
+		assert.Equal(t, common.PKIidType("p2"), pkiID)
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "p2 wasn't presumed dead")
+	}
+
+	network.Heal()
+	assert.NoError(t, comm1.Probe(memoryPeer("p2")))
+	comm1.Send(createGossipMsg(), memoryPeer("p2"))
+	assert.True(t, receivedWithin(m2, time.Second*5))
+}
+
+func TestMemoryTransportLossAndLatency(t *testing.T) {
+	t.Parallel()
+	network := NewMemoryNetwork(0)
+	comm1 := newMemoryCommInstance(t, network, "p1")
+	comm2 := newMemoryCommInstance(t, network, "p2")
+	defer comm1.Stop()
+	defer comm2.Stop()
+	m2 := comm2.Accept(acceptAll)
+
+	comm1.Send(createGossipMsg(), memoryPeer("p2"))
+	assert.True(t, receivedWithin(m2, time.Second*5))
+
+	network.SetLossRate(1)
+	for i := 0; i < 10; i++ {
+		comm1.Send(createGossipMsg(), memoryPeer("p2"))
+	}
+	assert.False(t, receivedWithin(m2, time.Second))
+
+	network.SetLossRate(0)
+	network.SetLatency(time.Millisecond * 500)
+	start := time.Now()
+	comm1.Send(createGossipMsg(), memoryPeer("p2"))
+	assert.True(t, receivedWithin(m2, time.Second*5))
+	assert.True(t, time.Since(start) >= time.Millisecond*500)
+}
+
+func TestMemoryTransportLossIsDeterministic(t *testing.T) {
+	t.Parallel()
+	// Scenario: two networks with the same seed and loss rate
+	// should lose the same messages
+	deliveries := func() []bool {
+		network := NewMemoryNetwork(100)
+		network.SetLossRate(0.5)
+		var delivered []bool
+		for i := 0; i < 100; i++ {
+			deliver, _, err := network.transmit("p1", "p2")
+			assert.NoError(t, err)
+			delivered = append(delivered, deliver)
+		}
+		return delivered
+	}
+	d1 := deliveries()
+	assert.Equal(t, d1, deliveries())
+	assert.Contains(t, d1, true)
+	assert.Contains(t, d1, false)
+}
+
+func TestGRPCTransportMultiplexing(t *testing.T) {
+	t.Parallel()
+	// Scenario: comm1 connects to comm2 and probes it while the connection is open.
+	// The stream and the probes should share a single gRPC connection,
+	// which is closed once the connection to comm2 is closed
+	comm1, _ := newCommInstance(2300, naiveSec)
+	comm2, _ := newCommInstance(3300, naiveSec)
+	defer comm1.Stop()
+	defer comm2.Stop()
+	m2 := comm2.Accept(acceptAll)
+	transport := comm1.(*commImpl).transport.(*grpcTransport)
+	sharedConns := func() int {
+		transport.Lock()
+		defer transport.Unlock()
+		return len(transport.conns)
+	}
+
+	comm1.Send(createGossipMsg(), remotePeer(3300))
+	assert.True(t, receivedWithin(m2, time.Second*5))
+	assert.Equal(t, 1, sharedConns())
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, comm1.Probe(remotePeer(3300)))
+		assert.Equal(t, 1, sharedConns())
+	}
+
+	comm1.CloseConn(remotePeer(3300))
+	assert.Equal(t, 0, sharedConns())
+	assert.NoError(t, comm1.Probe(remotePeer(3300)))
+	assert.Equal(t, 0, sharedConns())
+	assert.Error(t, comm1.Probe(&RemotePeer{Endpoint: fmt.Sprintf("localhost:%d", 3301)}))
+}
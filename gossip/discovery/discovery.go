@@ -80,6 +80,10 @@ type NetworkMember struct {
 	Metadata         []byte
 	PKIid            common.PKIidType
 	InternalEndpoint string
+	Properties       *proto.Properties
+	// NetworkEndpoints are the endpoints the member can be
+	// reached through from other networks, by network name
+	NetworkEndpoints map[string]string
 }
 
 // String returns a string representation of the NetworkMember
@@ -122,6 +126,10 @@ type Discovery interface {
 	// UpdateEndpoint updates this instance's endpoint
 	UpdateEndpoint(string)
 
+	// UpdatePKIid updates this instance's PKI-ID,
+	// after the identity of the peer has changed
+	UpdatePKIid(common.PKIidType)
+
 	// Stops this instance
 	Stop()
 
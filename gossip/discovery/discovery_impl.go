@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -81,6 +82,7 @@ type gossipDiscoveryImpl struct {
 	incTime         uint64
 	seqNum          uint64
 	self            NetworkMember
+	prevPKIids      map[string]struct{}       // PKI-IDs this instance had before its identity was updated
 	deadLastTS      map[string]*timestamp     // H
 	aliveLastTS     map[string]*timestamp     // V
 	id2Member       map[string]*NetworkMember // all known members
@@ -107,6 +109,7 @@ func NewDiscoveryService(self NetworkMember, comm CommService, crypt CryptoServi
 		self:             self,
 		incTime:          uint64(time.Now().UnixNano()),
 		seqNum:           uint64(0),
+		prevPKIids:       make(map[string]struct{}),
 		deadLastTS:       make(map[string]*timestamp),
 		aliveLastTS:      make(map[string]*timestamp),
 		id2Member:        make(map[string]*NetworkMember),
@@ -265,13 +268,7 @@ func (d *gossipDiscoveryImpl) InitiateSync(peerNum int) {
 		if aliveMembersAsSlice[i].Envelope.SecretEnvelope != nil {
 			internalEndpoint = aliveMembersAsSlice[i].Envelope.SecretEnvelope.InternalEndpoint()
 		}
-		netMember := &NetworkMember{
-			Endpoint:         pulledPeer.Endpoint,
-			Metadata:         pulledPeer.Metadata,
-			PKIid:            pulledPeer.PkiId,
-			InternalEndpoint: internalEndpoint,
-		}
-		peers2SendTo = append(peers2SendTo, netMember)
+		peers2SendTo = append(peers2SendTo, toNetworkMember(pulledPeer, internalEndpoint))
 	}
 
 	d.lock.RUnlock()
@@ -413,12 +410,7 @@ func (d *gossipDiscoveryImpl) handleMsgFromComm(m *proto.SignedGossipMessage) {
 func (d *gossipDiscoveryImpl) sendMemResponse(targetMember *proto.Member, internalEndpoint string, nonce uint64) {
 	d.logger.Debug("Entering", targetMember)
 
-	targetPeer := &NetworkMember{
-		Endpoint:         targetMember.Endpoint,
-		Metadata:         targetMember.Metadata,
-		PKIid:            targetMember.PkiId,
-		InternalEndpoint: internalEndpoint,
-	}
+	targetPeer := toNetworkMember(targetMember, internalEndpoint)
 
 	aliveMsg, err := d.createAliveMessage(true)
 	if err != nil {
@@ -493,6 +485,14 @@ func (d *gossipDiscoveryImpl) handleAliveMessage(m *proto.SignedGossipMessage) {
 	}
 
 	pkiID := m.GetAliveMsg().Membership.PkiId
+	d.lock.RLock()
+	wasSelf := d.wasSelf(pkiID)
+	d.lock.RUnlock()
+	if wasSelf {
+		d.logger.Debug("Got alive message about our previous identity,", m)
+		return
+	}
+
 	if equalPKIid(pkiID, d.self.PKIid) {
 		d.logger.Debug("Got alive message about ourselves,", m)
 		diffExternalEndpoint := d.self.Endpoint != m.GetAliveMsg().Membership.Endpoint
@@ -581,12 +581,7 @@ func (d *gossipDiscoveryImpl) resurrectMember(am *proto.SignedGossipMessage, t p
 		internalEndpoint = am.Envelope.SecretEnvelope.InternalEndpoint()
 	}
 
-	d.id2Member[string(pkiID)] = &NetworkMember{
-		Endpoint:         member.Endpoint,
-		Metadata:         member.Metadata,
-		PKIid:            member.PkiId,
-		InternalEndpoint: internalEndpoint,
-	}
+	d.id2Member[string(pkiID)] = toNetworkMember(member, internalEndpoint)
 
 	delete(d.deadLastTS, string(pkiID))
 	d.deadMembership.Remove(common.PKIidType(pkiID))
@@ -750,6 +745,7 @@ func (d *gossipDiscoveryImpl) createAliveMessage(includeInternalEndpoint bool) (
 	meta := d.self.Metadata
 	pkiID := d.self.PKIid
 	internalEndpoint := d.self.InternalEndpoint
+	netEndpoints := networkEndpoints(d.self.NetworkEndpoints)
 
 	d.lock.Unlock()
 
@@ -758,9 +754,10 @@ func (d *gossipDiscoveryImpl) createAliveMessage(includeInternalEndpoint bool) (
 		Content: &proto.GossipMessage_AliveMsg{
 			AliveMsg: &proto.AliveMessage{
 				Membership: &proto.Member{
-					Endpoint: endpoint,
-					Metadata: meta,
-					PkiId:    pkiID,
+					Endpoint:         endpoint,
+					Metadata:         meta,
+					PkiId:            pkiID,
+					NetworkEndpoints: netEndpoints,
 				},
 				Timestamp: &proto.PeerTime{
 					IncNum: uint64(d.incTime),
@@ -811,9 +808,11 @@ func (d *gossipDiscoveryImpl) learnExistingMembers(aliveArr []*proto.SignedGossi
 
 		// update member's data
 		member := d.id2Member[string(am.Membership.PkiId)]
-		member.Endpoint = am.Membership.Endpoint
-		member.Metadata = am.Membership.Metadata
-		member.InternalEndpoint = internalEndpoint
+		updated := toNetworkMember(am.Membership, internalEndpoint)
+		member.Endpoint = updated.Endpoint
+		member.Metadata = updated.Metadata
+		member.InternalEndpoint = updated.InternalEndpoint
+		member.NetworkEndpoints = updated.NetworkEndpoints
 
 		if _, isKnownAsDead := d.deadLastTS[string(am.Membership.PkiId)]; isKnownAsDead {
 			d.logger.Warning(am.Membership, "has already expired")
@@ -852,7 +851,7 @@ func (d *gossipDiscoveryImpl) learnNewMembers(aliveMembers []*proto.SignedGossip
 	defer d.lock.Unlock()
 
 	for _, am := range aliveMembers {
-		if equalPKIid(am.GetAliveMsg().Membership.PkiId, d.self.PKIid) {
+		if equalPKIid(am.GetAliveMsg().Membership.PkiId, d.self.PKIid) || d.wasSelf(am.GetAliveMsg().Membership.PkiId) {
 			continue
 		}
 		d.aliveLastTS[string(am.GetAliveMsg().Membership.PkiId)] = &timestamp{
@@ -866,7 +865,7 @@ func (d *gossipDiscoveryImpl) learnNewMembers(aliveMembers []*proto.SignedGossip
 	}
 
 	for _, dm := range deadMembers {
-		if equalPKIid(dm.GetAliveMsg().Membership.PkiId, d.self.PKIid) {
+		if equalPKIid(dm.GetAliveMsg().Membership.PkiId, d.self.PKIid) || d.wasSelf(dm.GetAliveMsg().Membership.PkiId) {
 			continue
 		}
 		d.deadLastTS[string(dm.GetAliveMsg().Membership.PkiId)] = &timestamp{
@@ -897,12 +896,7 @@ func (d *gossipDiscoveryImpl) learnNewMembers(aliveMembers []*proto.SignedGossip
 				internalEndpoint = prevNetMem.InternalEndpoint
 			}
 
-			d.id2Member[string(member.Membership.PkiId)] = &NetworkMember{
-				Endpoint:         member.Membership.Endpoint,
-				Metadata:         member.Membership.Metadata,
-				PKIid:            member.Membership.PkiId,
-				InternalEndpoint: internalEndpoint,
-			}
+			d.id2Member[string(member.Membership.PkiId)] = toNetworkMember(member.Membership, internalEndpoint)
 		}
 	}
 }
@@ -917,17 +911,46 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 	response := []NetworkMember{}
 	for _, m := range d.aliveMembership.ToSlice() {
 		member := m.GetAliveMsg()
-		response = append(response, NetworkMember{
-			PKIid:            member.Membership.PkiId,
-			Endpoint:         member.Membership.Endpoint,
-			Metadata:         member.Membership.Metadata,
-			InternalEndpoint: d.id2Member[string(m.GetAliveMsg().Membership.PkiId)].InternalEndpoint,
-		})
+		internalEndpoint := d.id2Member[string(m.GetAliveMsg().Membership.PkiId)].InternalEndpoint
+		response = append(response, *toNetworkMember(member.Membership, internalEndpoint))
 	}
 	return response
 
 }
 
+// toNetworkMember creates a NetworkMember out of the given membership information
+func toNetworkMember(member *proto.Member, internalEndpoint string) *NetworkMember {
+	netMember := &NetworkMember{
+		Endpoint:         member.Endpoint,
+		Metadata:         member.Metadata,
+		PKIid:            member.PkiId,
+		InternalEndpoint: internalEndpoint,
+	}
+	if len(member.NetworkEndpoints) == 0 {
+		return netMember
+	}
+	netMember.NetworkEndpoints = make(map[string]string, len(member.NetworkEndpoints))
+	for _, ne := range member.NetworkEndpoints {
+		netMember.NetworkEndpoints[ne.Network] = ne.Endpoint
+	}
+	return netMember
+}
+
+// networkEndpoints returns the given network endpoints
+// as NetworkEndpoint messages, ordered by network name
+func networkEndpoints(endpoints map[string]string) []*proto.NetworkEndpoint {
+	var networks []string
+	for network := range endpoints {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	var res []*proto.NetworkEndpoint
+	for _, network := range networks {
+		res = append(res, &proto.NetworkEndpoint{Network: network, Endpoint: endpoints[network]})
+	}
+	return res
+}
+
 func tsToTime(ts uint64) time.Time {
 	return time.Unix(int64(0), int64(ts))
 }
@@ -945,12 +968,37 @@ func (d *gossipDiscoveryImpl) UpdateEndpoint(endpoint string) {
 	d.self.Endpoint = endpoint
 }
 
+// UpdatePKIid updates this instance's PKI-ID. Alive messages
+// about the previous PKI-ID of this instance are ignored from now on
+func (d *gossipDiscoveryImpl) UpdatePKIid(pkiID common.PKIidType) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if equalPKIid(pkiID, d.self.PKIid) {
+		return
+	}
+	d.prevPKIids[string(d.self.PKIid)] = struct{}{}
+	delete(d.prevPKIids, string(pkiID))
+	d.self.PKIid = pkiID
+}
+
+// wasSelf returns whether the given PKI-ID is a previous PKI-ID
+// of this instance. Should be called while the lock is held
+func (d *gossipDiscoveryImpl) wasSelf(pkiID common.PKIidType) bool {
+	_, exists := d.prevPKIids[string(pkiID)]
+	return exists
+}
+
 func (d *gossipDiscoveryImpl) Self() NetworkMember {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
 	return NetworkMember{
 		Endpoint:         d.self.Endpoint,
 		Metadata:         d.self.Metadata,
 		PKIid:            d.self.PKIid,
 		InternalEndpoint: d.self.InternalEndpoint,
+		NetworkEndpoints: d.self.NetworkEndpoints,
 	}
 }
 
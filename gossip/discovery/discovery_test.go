@@ -472,6 +472,41 @@ func TestUpdate(t *testing.T) {
 	stopInstances(t, instances)
 }
 
+func TestNetworkEndpoints(t *testing.T) {
+	t.Parallel()
+	// Scenario: the first instance publishes an endpoint for the "dmz" network in
+	// addition to its endpoint. The second instance should learn the "dmz" endpoint
+	// along with the endpoint of the first one, which is left as is.
+	bootPeers := []string{bootPeer(15611)}
+	inst1 := createDiscoveryInstance(15611, "d1", bootPeers)
+	d1 := inst1.Discovery.(*gossipDiscoveryImpl)
+	d1.lock.Lock()
+	d1.self.NetworkEndpoints = map[string]string{"dmz": "127.0.0.1:15611"}
+	d1.lock.Unlock()
+	assert.Equal(t, "127.0.0.1:15611", inst1.Self().NetworkEndpoints["dmz"])
+
+	inst2 := createDiscoveryInstance(15612, "d2", bootPeers)
+	instances := []*gossipInstance{inst1, inst2}
+	defer stopInstances(t, instances)
+
+	learnedDMZEndpoint := func() bool {
+		members := inst2.GetMembership()
+		if len(members) != 1 || members[0].NetworkEndpoints["dmz"] != "127.0.0.1:15611" {
+			return false
+		}
+		member := inst2.Lookup(members[0].PKIid)
+		return member != nil && member.NetworkEndpoints["dmz"] == "127.0.0.1:15611"
+	}
+	waitUntilOrFail(t, learnedDMZEndpoint)
+	assert.Equal(t, bootPeer(15611), inst2.GetMembership()[0].Endpoint)
+	assert.Equal(t, bootPeer(15611), inst2.Lookup(inst2.GetMembership()[0].PKIid).Endpoint)
+
+	members := inst1.GetMembership()
+	assert.Len(t, members, 1)
+	assert.Equal(t, bootPeer(15612), members[0].Endpoint)
+	assert.Empty(t, members[0].NetworkEndpoints)
+}
+
 func TestInitiateSync(t *testing.T) {
 	t.Parallel()
 	nodeNum := 10
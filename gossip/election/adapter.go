@@ -44,6 +44,10 @@ func (mi *msgImpl) IsDeclaration() bool {
 	return mi.msg.GetLeadershipMsg().IsDeclaration
 }
 
+func (mi *msgImpl) IsPinned() bool {
+	return mi.msg.GetLeadershipMsg().IsPinned
+}
+
 type peerImpl struct {
 	member discovery.NetworkMember
 }
@@ -137,6 +141,7 @@ func (ai *adapterImpl) CreateMessage(isDeclaration bool) Msg {
 	leadershipMsg := &proto.LeadershipMessage{
 		PkiId:         ai.selfPKIid,
 		IsDeclaration: isDeclaration,
+		IsPinned:      getLeaderPreference() == pinnedLeader,
 		Timestamp: &proto.PeerTime{
 			IncNum: ai.incTime,
 			SeqNum: seqNum,
@@ -77,6 +77,17 @@ import (
 // 	If a proposal message from a peer with an ID lower
 // 	than yourself was received, return.
 //	Else, declare yourself a leader
+//
+// Peers may be configured to be pinned or banned as leaders:
+// - A pinned peer is a better candidate than any peer that is not
+//   pinned, regardless of their IDs, and takes over the leadership
+//   from such peers
+// - A banned peer never proposes itself or declares itself a leader
+//
+// A leader may yield, i.e. stop being a leader and abstain from
+// leader elections until another peer declares itself a leader or
+// a timeout expires. This lets other peers of the organization
+// take over when the leader can't pull blocks from the ordering service.
 
 // LeaderElectionAdapter is used by the leader election module
 // to send and receive messages and to get membership information
@@ -101,6 +112,10 @@ type LeaderElectionService interface {
 	// IsLeader returns whether this peer is a leader or not
 	IsLeader() bool
 
+	// Yield relinquishes the leadership until a new leader is elected,
+	// or a timeout expires
+	Yield()
+
 	// Stop stops the LeaderElectionService
 	Stop()
 }
@@ -121,13 +136,26 @@ type Msg interface {
 	IsProposal() bool
 	// IsDeclaration returns whether this message is a leadership declaration
 	IsDeclaration() bool
+	// IsPinned returns whether the sender is pinned as a leader
+	IsPinned() bool
 }
 
+const (
+	// pinnedLeader is the leader preference of peers preferred as leaders
+	pinnedLeader = "pinned"
+	// bannedLeader is the leader preference of peers that never become leaders
+	bannedLeader = "banned"
+)
+
 func noopCallback(_ bool) {
 }
 
 // NewLeaderElectionService returns a new LeaderElectionService
 func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback leadershipCallback) LeaderElectionService {
+	return newLeaderElectionService(adapter, id, callback, getLeaderPreference())
+}
+
+func newLeaderElectionService(adapter LeaderElectionAdapter, id string, callback leadershipCallback, preference string) LeaderElectionService {
 	if len(id) == 0 {
 		panic("Empty id")
 	}
@@ -141,6 +169,16 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 		callback:      noopCallback,
 	}
 
+	switch preference {
+	case pinnedLeader:
+		le.pinned = true
+	case bannedLeader:
+		le.banned = true
+	case "":
+	default:
+		le.logger.Warning("Unknown leader preference", preference, ", ignoring it")
+	}
+
 	if callback != nil {
 		le.callback = callback
 	}
@@ -161,6 +199,9 @@ type leaderElectionSvcImpl struct {
 	toDie         int32
 	leaderExists  int32
 	sleeping      bool
+	yield         int32
+	pinned        bool
+	banned        bool
 	adapter       LeaderElectionAdapter
 	logger        *logging.Logger
 	callback      leadershipCallback
@@ -203,13 +244,19 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 	defer le.Unlock()
 
 	if msg.IsProposal() {
-		le.proposals.Add(string(msg.SenderID()))
+		le.proposals.Add(candidate{id: string(msg.SenderID()), pinned: msg.IsPinned()})
 	} else if msg.IsDeclaration() {
-		atomic.StoreInt32(&le.leaderExists, int32(1))
+		// Another peer took over, no need to yield anymore
+		atomic.StoreInt32(&le.yield, int32(0))
+		// A pinned peer doesn't accept the leadership of peers that aren't
+		// pinned, and runs a leader election to take over from them
+		if !le.pinned || msg.IsPinned() {
+			atomic.StoreInt32(&le.leaderExists, int32(1))
+		}
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if le.precedes(msg.SenderID(), msg.IsPinned()) && le.IsLeader() {
 			le.stopBeingLeader()
 		}
 	} else {
@@ -263,6 +310,11 @@ func (le *leaderElectionSvcImpl) run() {
 func (le *leaderElectionSvcImpl) leaderElection() {
 	le.logger.Debug(le.id, ": Entering")
 	defer le.logger.Debug(le.id, ": Exiting")
+	// Banned and yielding peers don't take part in leader elections
+	if le.banned || le.isYielding() {
+		le.logger.Debug(le.id, ": Not a candidate for leadership")
+		return
+	}
 	le.propose()
 	le.waitForInterrupt(getLeaderElectionDuration())
 	// If someone declared itself as a leader, give up
@@ -274,8 +326,8 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	// Leader doesn't exist, let's see if there is a better candidate than us
 	// for being a leader
 	for _, o := range le.proposals.ToArray() {
-		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
+		c := o.(candidate)
+		if le.precedes(peerID(c.id), c.pinned) {
 			return
 		}
 	}
@@ -285,6 +337,21 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	atomic.StoreInt32(&le.leaderExists, int32(1))
 }
 
+// candidate is a peer that proposed itself as a leader
+type candidate struct {
+	id     string
+	pinned bool
+}
+
+// precedes returns whether the peer with the given ID
+// is a better candidate for leadership than this peer
+func (le *leaderElectionSvcImpl) precedes(id peerID, pinned bool) bool {
+	if pinned != le.pinned {
+		return pinned
+	}
+	return bytes.Compare(id, le.id) < 0
+}
+
 // propose sends a leadership proposal message to remote peers
 func (le *leaderElectionSvcImpl) propose() {
 	le.logger.Debug(le.id, ": Entering")
@@ -347,6 +414,10 @@ func (le *leaderElectionSvcImpl) isAlive(id peerID) bool {
 	return false
 }
 
+func (le *leaderElectionSvcImpl) isYielding() bool {
+	return atomic.LoadInt32(&le.yield) == int32(1)
+}
+
 func (le *leaderElectionSvcImpl) isLeaderExists() bool {
 	return atomic.LoadInt32(&le.leaderExists) == int32(1)
 }
@@ -370,6 +441,41 @@ func (le *leaderElectionSvcImpl) stopBeingLeader() {
 	le.callback(false)
 }
 
+// Yield relinquishes the leadership until a new leader is elected,
+// or a timeout expires
+func (le *leaderElectionSvcImpl) Yield() {
+	le.Lock()
+	defer le.Unlock()
+	if !le.IsLeader() || le.isYielding() || le.shouldStop() {
+		return
+	}
+	le.logger.Info(le.id, ": Yielding leadership")
+	atomic.StoreInt32(&le.yield, int32(1))
+	le.stopBeingLeader()
+	// We were the leader, so there is no leader now
+	atomic.StoreInt32(&le.leaderExists, int32(0))
+	if le.sleeping && len(le.interruptChan) == 0 {
+		le.interruptChan <- struct{}{}
+	}
+	// Take part in leader elections again once the timeout expires,
+	// in case no other peer could take over
+	le.stopWG.Add(1)
+	go le.stopYielding(getYieldTimeout())
+}
+
+func (le *leaderElectionSvcImpl) stopYielding(timeout time.Duration) {
+	defer le.stopWG.Done()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		atomic.StoreInt32(&le.yield, int32(0))
+	case <-le.stopChan:
+		le.stopChan <- struct{}{}
+	}
+}
+
 func (le *leaderElectionSvcImpl) shouldStop() bool {
 	return atomic.LoadInt32(&le.toDie) == int32(1)
 }
@@ -378,7 +484,10 @@ func (le *leaderElectionSvcImpl) shouldStop() bool {
 func (le *leaderElectionSvcImpl) Stop() {
 	le.logger.Debug(le.id, ": Entering")
 	defer le.logger.Debug(le.id, ": Exiting")
+	// Yield checks the flag under the lock before adding to the wait group
+	le.Lock()
 	atomic.StoreInt32(&le.toDie, int32(1))
+	le.Unlock()
 	le.stopChan <- struct{}{}
 	le.stopWG.Wait()
 }
@@ -426,6 +535,16 @@ func getLeaderElectionDuration() time.Duration {
 	return util.GetDurationOrDefault("peer.gossip.election.leaderElectionDuration", time.Second*5)
 }
 
+// getYieldTimeout returns the time a peer that yielded
+// abstains from leader elections
+func getYieldTimeout() time.Duration {
+	return util.GetDurationOrDefault("peer.gossip.election.yieldTimeout", getLeaderAliveThreshold()*6)
+}
+
+func getLeaderPreference() string {
+	return viper.GetString("peer.gossip.election.leaderPreference")
+}
+
 // GetMsgExpirationTimeout return leadership message expiration timeout
 func GetMsgExpirationTimeout() time.Duration {
 	return getLeaderAliveThreshold() * 10
@@ -47,6 +47,7 @@ func init() {
 type msg struct {
 	sender   string
 	proposal bool
+	pinned   bool
 }
 
 func (m *msg) SenderID() peerID {
@@ -61,6 +62,10 @@ func (m *msg) IsDeclaration() bool {
 	return !m.proposal
 }
 
+func (m *msg) IsPinned() bool {
+	return m.pinned
+}
+
 type peer struct {
 	mockedMethods map[string]struct{}
 	mock.Mock
@@ -70,6 +75,7 @@ type peer struct {
 	msgChan            chan Msg
 	leaderFromCallback bool
 	callbackInvoked    bool
+	pinned             bool
 	lock               sync.RWMutex
 	LeaderElectionService
 }
@@ -114,7 +120,7 @@ func (p *peer) Accept() <-chan Msg {
 }
 
 func (p *peer) CreateMessage(isDeclaration bool) Msg {
-	return &msg{proposal: !isDeclaration, sender: p.id}
+	return &msg{proposal: !isDeclaration, sender: p.id, pinned: p.pinned}
 }
 
 func (p *peer) Peers() []Peer {
@@ -167,10 +173,14 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 }
 
 func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex) *peer {
+	return createPeerWithPreference(id, peerMap, l, "")
+}
+
+func createPeerWithPreference(id int, peerMap map[string]*peer, l *sync.RWMutex, preference string) *peer {
 	idStr := fmt.Sprintf("p%d", id)
 	c := make(chan Msg, 100)
-	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
-	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback)
+	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false, pinned: preference == pinnedLeader}
+	p.LeaderElectionService = newLeaderElectionService(p, idStr, p.leaderCallback, preference)
 	l.Lock()
 	peerMap[idStr] = p
 	l.Unlock()
@@ -363,6 +373,107 @@ func TestPartition(t *testing.T) {
 
 }
 
+func TestYield(t *testing.T) {
+	t.Parallel()
+	// Scenario: peers spawn together, and then the leader yields.
+	// Expected outcome: the peer with the next lowest ID takes over,
+	// and the peer that yielded doesn't become a leader again
+	peers := createPeers(0, 2, 1, 0)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	assert.Equal(t, "p0", leaders[0])
+
+	// Yielding isn't possible for a peer that isn't a leader
+	peers[0].Yield()
+	assert.False(t, peers[0].IsLeader())
+
+	peers[2].Yield()
+	assert.False(t, peers[2].IsLeader())
+	waitForBoolFunc(t, peers[2].isLeaderFromCallback, false, "Leadership callback result is wrong for %s", peers[2].id)
+	time.Sleep(getLeadershipDeclarationInterval() + getLeaderAliveThreshold()*2)
+	leaders = waitForLeaderElection(t, peers)
+	assert.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	assert.Equal(t, "p1", leaders[0])
+
+	time.Sleep(getYieldTimeout())
+	leaders = waitForLeaderElection(t, peers)
+	assert.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	assert.Equal(t, "p1", leaders[0])
+}
+
+func TestStopWhileYielding(t *testing.T) {
+	t.Parallel()
+	// Scenario: the only peer is the leader, yields, and is stopped.
+	// Expected outcome: stopping doesn't wait for the yield timeout
+	peers := createPeers(0, 3)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Len(t, leaders, 1, "Only 1 leader should have been elected")
+
+	peers[0].Yield()
+	assert.False(t, peers[0].IsLeader())
+
+	stopped := make(chan struct{})
+	go func() {
+		peers[0].Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(getYieldTimeout() / 2):
+		t.Fatal("Stopping waited for the yield timeout")
+	}
+}
+
+func TestPinnedLeader(t *testing.T) {
+	t.Parallel()
+	// Scenario: peers spawn together, and the peer with the highest ID is pinned.
+	// Expected outcome: the pinned peer is the leader
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	peers := []*peer{
+		createPeerWithPreference(0, peerMap, l, ""),
+		createPeerWithPreference(1, peerMap, l, ""),
+		createPeerWithPreference(2, peerMap, l, pinnedLeader),
+	}
+	time.Sleep(getStartupGracePeriod() + getLeaderElectionDuration())
+	leaders := waitForLeaderElection(t, peers)
+	assert.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	assert.Equal(t, "p2", leaders[0])
+}
+
+func TestPinnedLeaderTakeover(t *testing.T) {
+	t.Parallel()
+	// Scenario: a peer is elected as a leader, and then a pinned peer spawns.
+	// Expected outcome: the pinned peer takes over the leadership
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	p0 := createPeerWithPreference(0, peerMap, l, "")
+	leaders := waitForLeaderElection(t, []*peer{p0})
+	assert.Equal(t, "p0", leaders[0])
+
+	p1 := createPeerWithPreference(1, peerMap, l, pinnedLeader)
+	time.Sleep(getStartupGracePeriod() + getLeaderAliveThreshold()*2)
+	waitForBoolFunc(t, p1.IsLeader, true, "Pinned peer %s didn't become a leader", p1.id)
+	waitForBoolFunc(t, p0.IsLeader, false, "Peer %s didn't relinquish its leadership", p0.id)
+}
+
+func TestBannedLeader(t *testing.T) {
+	t.Parallel()
+	// Scenario: peers spawn together, and the peer with the lowest ID is banned.
+	// Expected outcome: the peer with the next lowest ID is the leader
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	peers := []*peer{
+		createPeerWithPreference(0, peerMap, l, bannedLeader),
+		createPeerWithPreference(1, peerMap, l, ""),
+		createPeerWithPreference(2, peerMap, l, ""),
+	}
+	time.Sleep(getStartupGracePeriod() + getLeaderElectionDuration())
+	leaders := waitForLeaderElection(t, peers)
+	assert.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	assert.Equal(t, "p1", leaders[0])
+}
+
 func TestConfigFromFile(t *testing.T) {
 	preStartupGracePeriod := getStartupGracePeriod()
 	preMembershipSampleInterval := getMembershipSampleInterval()
@@ -375,6 +486,7 @@ func TestConfigFromFile(t *testing.T) {
 		SetMembershipSampleInterval(preMembershipSampleInterval)
 		SetLeaderAliveThreshold(preLeaderAliveThreshold)
 		SetLeaderElectionDuration(preLeaderElectionDuration)
+		viper.Set("peer.gossip.election.yieldTimeout", nil)
 	}()
 
 	// Verify if using default values when config is missing
@@ -384,6 +496,7 @@ func TestConfigFromFile(t *testing.T) {
 	assert.Equal(t, time.Second*10, getLeaderAliveThreshold())
 	assert.Equal(t, time.Second*5, getLeaderElectionDuration())
 	assert.Equal(t, getLeaderAliveThreshold()/2, getLeadershipDeclarationInterval())
+	assert.Equal(t, getLeaderAliveThreshold()*6, getYieldTimeout())
 
 	//Verify reading the values from config file
 	viper.Reset()
@@ -399,6 +512,10 @@ func TestConfigFromFile(t *testing.T) {
 	assert.Equal(t, time.Second*10, getLeaderAliveThreshold())
 	assert.Equal(t, time.Second*5, getLeaderElectionDuration())
 	assert.Equal(t, getLeaderAliveThreshold()/2, getLeadershipDeclarationInterval())
+	assert.Equal(t, getLeaderAliveThreshold()*6, getYieldTimeout())
+
+	viper.Set("peer.gossip.election.yieldTimeout", time.Minute*5)
+	assert.Equal(t, time.Minute*5, getYieldTimeout())
 }
 
 func waitForBoolFunc(t *testing.T, f func() bool, expectedValue bool, msgAndArgs ...interface{}) {
@@ -21,6 +21,8 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/hyperledger/fabric/gossip/metrics"
 )
 
 type emitBatchCallback func([]interface{})
@@ -44,7 +46,8 @@ type batchingEmitter interface {
 // burstSize: a threshold that triggers a forwarding because of message count
 // latency: the maximum delay that each message can be stored without being forwarded
 // cb: a callback that is called in order for the forwarding to take place
-func newBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emitBatchCallback) batchingEmitter {
+// emitterMetrics: the metrics the buffer overflows and size are reported to
+func newBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emitBatchCallback, emitterMetrics *metrics.EmitterMetrics) batchingEmitter {
 	if iterations < 0 {
 		panic(fmt.Errorf("Got a negative iterations number"))
 	}
@@ -57,6 +60,7 @@ func newBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emi
 		lock:       &sync.Mutex{},
 		buff:       make([]*batchedMessage, 0),
 		stopFlag:   int32(0),
+		metrics:    emitterMetrics,
 	}
 
 	if iterations != 0 {
@@ -89,6 +93,7 @@ func (p *batchingEmitterImpl) emit() {
 
 	p.cb(msgs2beEmitted)
 	p.decrementCounters()
+	p.metrics.BufferSize.Set(float64(len(p.buff)))
 }
 
 func (p *batchingEmitterImpl) decrementCounters() {
@@ -116,6 +121,7 @@ type batchingEmitterImpl struct {
 	lock       *sync.Mutex
 	buff       []*batchedMessage
 	stopFlag   int32
+	metrics    *metrics.EmitterMetrics
 }
 
 type batchedMessage struct {
@@ -141,8 +147,10 @@ func (p *batchingEmitterImpl) Add(message interface{}) {
 	defer p.lock.Unlock()
 
 	p.buff = append(p.buff, &batchedMessage{data: message, iterationsLeft: p.iterations})
+	p.metrics.BufferSize.Set(float64(len(p.buff)))
 
 	if len(p.buff) >= p.burstSize {
+		p.metrics.BufferOverflows.Add(1)
 		p.emit()
 	}
 }
@@ -22,16 +22,19 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/stretchr/testify/assert"
 )
 
+var disabledEmitterMetrics = metrics.NewDisabledGossipMetrics().EmitterMetrics
+
 func init() {
 	util.SetupTestLogging()
 }
 
 func TestBatchingEmitterAddAndSize(t *testing.T) {
-	emitter := newBatchingEmitter(1, 10, time.Second, func(a []interface{}) {})
+	emitter := newBatchingEmitter(1, 10, time.Second, func(a []interface{}) {}, disabledEmitterMetrics)
 	defer emitter.Stop()
 	emitter.Add(1)
 	emitter.Add(2)
@@ -46,7 +49,7 @@ func TestBatchingEmitterStop(t *testing.T) {
 		atomic.AddInt32(&disseminationAttempts, int32(1))
 	}
 
-	emitter := newBatchingEmitter(10, 1, time.Duration(100)*time.Millisecond, cb)
+	emitter := newBatchingEmitter(10, 1, time.Duration(100)*time.Millisecond, cb, disabledEmitterMetrics)
 	emitter.Add(1)
 	time.Sleep(time.Duration(100) * time.Millisecond)
 	emitter.Stop()
@@ -62,7 +65,7 @@ func TestBatchingEmitterExpiration(t *testing.T) {
 		atomic.AddInt32(&disseminationAttempts, int32(1))
 	}
 
-	emitter := newBatchingEmitter(10, 1, time.Duration(10)*time.Millisecond, cb)
+	emitter := newBatchingEmitter(10, 1, time.Duration(10)*time.Millisecond, cb, disabledEmitterMetrics)
 	defer emitter.Stop()
 
 	emitter.Add(1)
@@ -88,7 +91,7 @@ func TestBatchingEmitterCounter(t *testing.T) {
 		}
 	}
 
-	emitter := newBatchingEmitter(5, 100, time.Duration(500)*time.Millisecond, cb)
+	emitter := newBatchingEmitter(5, 100, time.Duration(500)*time.Millisecond, cb, disabledEmitterMetrics)
 	defer emitter.Stop()
 
 	for i := 1; i <= 5; i++ {
@@ -114,7 +117,7 @@ func TestBatchingEmitterBurstSizeCap(t *testing.T) {
 	cb := func(a []interface{}) {
 		atomic.AddInt32(&disseminationAttempts, int32(1))
 	}
-	emitter := newBatchingEmitter(1, 10, time.Duration(800)*time.Millisecond, cb)
+	emitter := newBatchingEmitter(1, 10, time.Duration(800)*time.Millisecond, cb, disabledEmitterMetrics)
 	defer emitter.Stop()
 
 	for i := 0; i < 50; i++ {
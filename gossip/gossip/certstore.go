@@ -119,6 +119,26 @@ func (cs *certStore) validateIdentityMsg(msg *proto.SignedGossipMessage) error {
 	return cs.mcs.ValidateIdentity(api.PeerIdentityType(idMsg.Cert))
 }
 
+// updateSelfIdentity replaces the identity of the peer that is
+// disseminated to other peers with the given identity
+func (cs *certStore) updateSelfIdentity(selfIdentity api.PeerIdentityType) error {
+	cs.Lock()
+	defer cs.Unlock()
+	prevPKIID := cs.idMapper.GetPKIidOfCert(cs.selfIdentity)
+	prevIdentity := cs.selfIdentity
+	cs.selfIdentity = selfIdentity
+	selfIdMsg, err := cs.createIdentityMessage()
+	if err != nil {
+		cs.selfIdentity = prevIdentity
+		return fmt.Errorf("Failed creating self identity message: %v", err)
+	}
+	cs.pull.Remove(string(prevPKIID))
+	cs.pull.Add(selfIdMsg)
+	return nil
+}
+
+// createIdentityMessage creates the identity message of the peer,
+// the caller should hold the lock if the certStore is already in use
 func (cs *certStore) createIdentityMessage() (*proto.SignedGossipMessage, error) {
 	identity := &proto.PeerIdentity{
 		Cert:     cs.selfIdentity,
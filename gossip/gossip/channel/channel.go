@@ -32,6 +32,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/filter"
 	"github.com/hyperledger/fabric/gossip/gossip/msgstore"
 	"github.com/hyperledger/fabric/gossip/gossip/pull"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/op/go-logging"
@@ -48,6 +49,7 @@ type Config struct {
 	RequestStateInfoInterval    time.Duration
 	BlockExpirationInterval     time.Duration
 	StateInfoCacheSweepInterval time.Duration
+	PullMetrics                 *metrics.PullMetrics
 }
 
 // GossipChannel defines an object that deals with all channel-related messages
@@ -80,6 +82,10 @@ type GossipChannel interface {
 	// that are eligible to be in the channel
 	ConfigureChannel(joinMsg api.JoinChannelMessage)
 
+	// UpdatePKIid updates the PKI-ID of the peer in the channel,
+	// after the identity of the peer has changed
+	UpdatePKIid(pkiID common.PKIidType)
+
 	// Stop stops the channel's activity
 	Stop()
 }
@@ -243,6 +249,7 @@ func (gc *gossipChannel) GetPeers() []discovery.NetworkMember {
 			continue
 		}
 		member.Metadata = stateInf.GetStateInfo().Metadata
+		member.Properties = stateInf.GetStateInfo().Properties
 		members = append(members, member)
 	}
 	return members
@@ -302,6 +309,7 @@ func (gc *gossipChannel) createBlockPuller() pull.Mediator {
 		MsgCons: func(msg *proto.SignedGossipMessage) {
 			gc.DeMultiplex(msg)
 		},
+		PullMetrics: gc.GetConf().PullMetrics,
 	}
 	return pull.NewPullMediator(conf, adapter)
 }
@@ -648,13 +656,24 @@ func (gc *gossipChannel) verifyMsg(msg proto.ReceivedMessage) bool {
 	return true
 }
 
+// UpdatePKIid updates the PKI-ID of the peer in the channel,
+// after the identity of the peer has changed
+func (gc *gossipChannel) UpdatePKIid(pkiID common.PKIidType) {
+	gc.Lock()
+	defer gc.Unlock()
+	gc.pkiID = pkiID
+}
+
 func (gc *gossipChannel) createStateInfoRequest() (*proto.SignedGossipMessage, error) {
+	gc.RLock()
+	pkiID := gc.pkiID
+	gc.RUnlock()
 	return (&proto.GossipMessage{
 		Tag:   proto.GossipMessage_CHAN_OR_ORG,
 		Nonce: 0,
 		Content: &proto.GossipMessage_StateInfoPullReq{
 			StateInfoPullReq: &proto.StateInfoPullRequest{
-				Channel_MAC: GenerateMAC(gc.pkiID, gc.chainID),
+				Channel_MAC: GenerateMAC(pkiID, gc.chainID),
 			},
 		},
 	}).NoopSign()
@@ -145,6 +145,11 @@ func (cs *cryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType) err
 	panic("Should not be called in this test")
 }
 
+// Expiration returns the expiration time of the identity
+func (*cryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Now().Add(time.Hour), nil
+}
+
 type receivedMsg struct {
 	PKIID common.PKIidType
 	msg   *proto.SignedGossipMessage
@@ -41,6 +41,10 @@ type Gossip interface {
 	// and also subscribed to the channel given
 	PeersOfChannel(common.ChainID) []discovery.NetworkMember
 
+	// IsInMyOrg returns whether the given network member
+	// is in the same organization as the peer
+	IsInMyOrg(member discovery.NetworkMember) bool
+
 	// UpdateMetadata updates the self metadata of the discovery layer
 	// the peer publishes to other peers
 	UpdateMetadata(metadata []byte)
@@ -49,6 +53,19 @@ type Gossip interface {
 	// publishes to other peers about its channel-related state
 	UpdateChannelMetadata(metadata []byte, chainID common.ChainID)
 
+	// UpdateLedgerHeight updates the ledger height the peer publishes to
+	// other peers in the channel, along with the channel metadata, which
+	// encodes it for peers that do not read it from the properties
+	UpdateLedgerHeight(height uint64, metadata []byte, chainID common.ChainID)
+
+	// UpdateChaincodes updates the chaincodes the peer publishes to other
+	// peers as installed on it, and as instantiated in the channel
+	UpdateChaincodes(installed []*proto.Chaincode, instantiated []*proto.Chaincode, chainID common.ChainID)
+
+	// UpdateLeadership updates whether the peer publishes to other
+	// peers that it is the leader of its organization in the channel
+	UpdateLeadership(isLeader bool, chainID common.ChainID)
+
 	// Gossip sends a message to other peers to the network
 	Gossip(msg *proto.GossipMessage)
 
@@ -65,6 +82,21 @@ type Gossip interface {
 	// any connections to peers with identities that are found invalid
 	SuspectPeers(s api.PeerSuspector)
 
+	// UpdateIdentity replaces the identity of the peer with the given identity,
+	// and announces it to the rest of the network. The signing key of the peer
+	// should be replaced with the key of the new identity beforehand
+	UpdateIdentity(identity api.PeerIdentityType) error
+
+	// UpdateExternalEndpoint replaces the endpoint the peer publishes to peers
+	// of other organizations, and announces it to the rest of the network.
+	// An empty endpoint makes the peer inaccessible outside of its organization
+	UpdateExternalEndpoint(endpoint string) error
+
+	// AddAnchorPeers makes the peer connect to the given anchor peers of an
+	// organization of a channel it has joined, in addition to the anchor
+	// peers of the channel configuration
+	AddAnchorPeers(chainID common.ChainID, org api.OrgIdentityType, anchorPeers []api.AnchorPeer) error
+
 	// Stop stops the gossip component
 	Stop()
 }
@@ -94,4 +126,7 @@ type Config struct {
 
 	InternalEndpoint string // Endpoint we publish to peers in our organization
 	ExternalEndpoint string // Peer publishes this endpoint instead of SelfEndpoint to foreign organizations
+
+	NetworkEndpoints  map[string]string // Endpoints the peer publishes in addition to ExternalEndpoint, by network name
+	EndpointSelection map[string]string // Network whose endpoints are connected to, by organization of remote peers
 }
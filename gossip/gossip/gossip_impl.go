@@ -21,6 +21,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
 	"sync"
 	"sync/atomic"
@@ -35,6 +36,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/gossip/msgstore"
 	"github.com/hyperledger/fabric/gossip/gossip/pull"
 	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/op/go-logging"
@@ -76,28 +78,73 @@ type gossipServiceImpl struct {
 	disSecAdap        *discoverySecurityAdapter
 	mcs               api.MessageCryptoService
 	stateInfoMsgStore msgstore.MessageStore
+	selfStates        map[string]*selfChannelState
+	selfStatesLock    sync.Mutex
+	metrics           *metrics.GossipMetrics
+	identityLock      sync.Mutex
+}
+
+// selfChannelState is what the peer publishes
+// about itself in the StateInfo of a channel
+type selfChannelState struct {
+	metadata   []byte
+	properties proto.Properties
+	published  bool
 }
 
 // NewGossipService creates a gossip instance attached to a gRPC server
 func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvisor,
 	mcs api.MessageCryptoService, idMapper identity.Mapper, selfIdentity api.PeerIdentityType,
-	secureDialOpts api.PeerSecureDialOpts) Gossip {
+	secureDialOpts api.PeerSecureDialOpts, gossipMetrics *metrics.GossipMetrics) Gossip {
 
 	var c comm.Comm
 	var err error
 
+	if gossipMetrics == nil {
+		gossipMetrics = metrics.NewDisabledGossipMetrics()
+	}
+
 	lgr := util.GetLogger(util.LoggingGossipModule, conf.ID)
 	if s == nil {
-		c, err = createCommWithServer(conf.BindPort, idMapper, selfIdentity, secureDialOpts)
+		c, err = createCommWithServer(conf.BindPort, idMapper, selfIdentity, secureDialOpts, gossipMetrics)
 	} else {
-		c, err = createCommWithoutServer(s, conf.TLSServerCert, idMapper, selfIdentity, secureDialOpts)
+		c, err = createCommWithoutServer(s, conf.TLSServerCert, idMapper, selfIdentity, secureDialOpts, gossipMetrics)
 	}
 
 	if err != nil {
 		lgr.Error("Failed instntiating communication layer:", err)
 		return nil
 	}
+	return newGossipService(conf, c, secAdvisor, mcs, idMapper, selfIdentity, gossipMetrics)
+}
+
+// NewGossipServiceWithTransport creates a gossip instance that communicates
+// with remote peers over the given transport
+func NewGossipServiceWithTransport(conf *Config, transport comm.Transport, secAdvisor api.SecurityAdvisor,
+	mcs api.MessageCryptoService, idMapper identity.Mapper, selfIdentity api.PeerIdentityType,
+	gossipMetrics *metrics.GossipMetrics) Gossip {
+
+	if gossipMetrics == nil {
+		gossipMetrics = metrics.NewDisabledGossipMetrics()
+	}
+	c, err := comm.NewCommInstanceWithTransport(transport, idMapper, selfIdentity, gossipMetrics.CommMetrics)
+	if err != nil {
+		util.GetLogger(util.LoggingGossipModule, conf.ID).Error("Failed instntiating communication layer:", err)
+		return nil
+	}
+	return newGossipService(conf, c, secAdvisor, mcs, idMapper, selfIdentity, gossipMetrics)
+}
+
+func newGossipService(conf *Config, c comm.Comm, secAdvisor api.SecurityAdvisor,
+	mcs api.MessageCryptoService, idMapper identity.Mapper, selfIdentity api.PeerIdentityType,
+	gossipMetrics *metrics.GossipMetrics) Gossip {
 
+	lgr := util.GetLogger(util.LoggingGossipModule, conf.ID)
+	var selectingComm *endpointSelectingComm
+	if len(conf.EndpointSelection) > 0 {
+		selectingComm = &endpointSelectingComm{Comm: c}
+		c = selectingComm
+	}
 	g := &gossipServiceImpl{
 		selfOrg:               secAdvisor.OrgByPeerIdentity(selfIdentity),
 		secAdvisor:            secAdvisor,
@@ -114,17 +161,22 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 		stopFlag:              int32(0),
 		stopSignal:            &sync.WaitGroup{},
 		includeIdentityPeriod: time.Now().Add(conf.PublishCertPeriod),
+		selfStates:            make(map[string]*selfChannelState),
+		metrics:               gossipMetrics,
 	}
 	g.stateInfoMsgStore = g.newStateInfoMsgStore()
 
 	g.chanState = newChannelState(g)
 	g.emitter = newBatchingEmitter(conf.PropagateIterations,
 		conf.MaxPropagationBurstSize, conf.MaxPropagationBurstLatency,
-		g.sendGossipBatch)
+		g.sendGossipBatch, gossipMetrics.EmitterMetrics)
 
 	g.discAdapter = g.newDiscoveryAdapter()
 	g.disSecAdap = g.newDiscoverySecurityAdapter()
 	g.disc = discovery.NewDiscoveryService(g.selfNetworkMember(), g.discAdapter, g.disSecAdap, g.disclosurePolicy)
+	if selectingComm != nil {
+		selectingComm.setSelector(g.selectEndpoint)
+	}
 	g.logger.Info("Creating gossip service with self membership of", g.selfNetworkMember())
 
 	g.certStore = newCertStore(g.createCertStorePuller(), idMapper, selfIdentity, mcs)
@@ -134,6 +186,7 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 	}
 
 	go g.start()
+	go g.reportMembership()
 	go g.periodicalIdentityValidationAndExpiration()
 	go g.connect2BootstrapPeers()
 
@@ -156,13 +209,54 @@ func (g *gossipServiceImpl) selfNetworkMember() discovery.NetworkMember {
 		PKIid:            g.comm.GetPKIid(),
 		Metadata:         []byte{},
 		InternalEndpoint: g.conf.InternalEndpoint,
+		NetworkEndpoints: g.conf.NetworkEndpoints,
 	}
 	if g.disc != nil {
-		self.Metadata = g.disc.Self().Metadata
+		// The external endpoint might have been updated since startup
+		discSelf := g.disc.Self()
+		self.Metadata = discSelf.Metadata
+		self.Endpoint = discSelf.Endpoint
 	}
 	return self
 }
 
+// selectEndpoint selects the endpoint to dial the given remote peer at.
+// A peer that is to be dialed at its external endpoint is dialed at its
+// endpoint of the network configured for its organization instead, if any
+func (g *gossipServiceImpl) selectEndpoint(peer *comm.RemotePeer) string {
+	member := g.disc.Lookup(peer.PKIID)
+	if member == nil || member.Endpoint == "" || member.Endpoint != peer.Endpoint {
+		return peer.Endpoint
+	}
+	org := g.getOrgOfPeer(member.PKIid)
+	if len(org) == 0 {
+		return peer.Endpoint
+	}
+	network, exists := g.conf.EndpointSelection[string(org)]
+	if !exists {
+		return peer.Endpoint
+	}
+	if endpoint, exists := member.NetworkEndpoints[network]; exists {
+		return endpoint
+	}
+	return peer.Endpoint
+}
+
+// isSelfEndpoint returns whether the given endpoint is one
+// of the endpoints the peer publishes to other organizations
+func (g *gossipServiceImpl) isSelfEndpoint(endpoint string) bool {
+	self := g.selfNetworkMember()
+	if self.Endpoint == endpoint {
+		return true
+	}
+	for _, networkEndpoint := range self.NetworkEndpoints {
+		if networkEndpoint == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
 func newChannelState(g *gossipServiceImpl) *channelState {
 	return &channelState{
 		stopping: int32(0),
@@ -172,19 +266,20 @@ func newChannelState(g *gossipServiceImpl) *channelState {
 }
 
 func createCommWithoutServer(s *grpc.Server, cert *tls.Certificate, idStore identity.Mapper,
-	identity api.PeerIdentityType, secureDialOpts api.PeerSecureDialOpts) (comm.Comm, error) {
-	return comm.NewCommInstance(s, cert, idStore, identity, secureDialOpts)
+	identity api.PeerIdentityType, secureDialOpts api.PeerSecureDialOpts, gossipMetrics *metrics.GossipMetrics) (comm.Comm, error) {
+	return comm.NewCommInstance(s, cert, idStore, identity, secureDialOpts, gossipMetrics.CommMetrics)
 }
 
 // NewGossipServiceWithServer creates a new gossip instance with a gRPC server
 func NewGossipServiceWithServer(conf *Config, secAdvisor api.SecurityAdvisor, mcs api.MessageCryptoService,
-	mapper identity.Mapper, identity api.PeerIdentityType, secureDialOpts api.PeerSecureDialOpts) Gossip {
-	return NewGossipService(conf, nil, secAdvisor, mcs, mapper, identity, secureDialOpts)
+	mapper identity.Mapper, identity api.PeerIdentityType, secureDialOpts api.PeerSecureDialOpts,
+	gossipMetrics *metrics.GossipMetrics) Gossip {
+	return NewGossipService(conf, nil, secAdvisor, mcs, mapper, identity, secureDialOpts, gossipMetrics)
 }
 
 func createCommWithServer(port int, idStore identity.Mapper, identity api.PeerIdentityType,
-	secureDialOpts api.PeerSecureDialOpts) (comm.Comm, error) {
-	return comm.NewCommInstanceWithServer(port, idStore, identity, secureDialOpts)
+	secureDialOpts api.PeerSecureDialOpts, gossipMetrics *metrics.GossipMetrics) (comm.Comm, error) {
+	return comm.NewCommInstanceWithServer(port, idStore, identity, secureDialOpts, gossipMetrics.CommMetrics)
 }
 
 func (g *gossipServiceImpl) toDie() bool {
@@ -194,10 +289,26 @@ func (g *gossipServiceImpl) toDie() bool {
 func (g *gossipServiceImpl) JoinChan(joinMsg api.JoinChannelMessage, chainID common.ChainID) {
 	// joinMsg is supposed to have been already verified
 	g.chanState.joinChannel(joinMsg, chainID)
+	g.metrics.CommMetrics.AddChannel(string(chainID))
 
+	isAnchorPeer := false
 	for _, org := range joinMsg.Members() {
-		g.learnAnchorPeers(org, joinMsg.AnchorPeersOf(org))
+		anchorPeers := joinMsg.AnchorPeersOf(org)
+		for _, ap := range anchorPeers {
+			if g.isSelfEndpoint(fmt.Sprintf("%s:%d", ap.Host, ap.Port)) {
+				isAnchorPeer = true
+			}
+		}
+		g.learnAnchorPeers(org, anchorPeers)
 	}
+
+	// The role is published along with the next update of the channel
+	// state, unless the peer already published its state in the channel
+	g.updateChannelState(chainID, func(state *selfChannelState) bool {
+		changed := state.properties.AnchorPeer != isAnchorPeer
+		state.properties.AnchorPeer = isAnchorPeer
+		return changed && state.published
+	})
 }
 
 // SuspectPeers makes the gossip instance validate identities of suspected peers, and close
@@ -208,6 +319,90 @@ func (g *gossipServiceImpl) SuspectPeers(isSuspected api.PeerSuspector) {
 	}
 }
 
+// UpdateIdentity replaces the identity of the peer with the given identity,
+// and announces it to the rest of the network. The signing key of the peer
+// should be replaced with the key of the new identity beforehand
+func (g *gossipServiceImpl) UpdateIdentity(identity api.PeerIdentityType) error {
+	if !bytes.Equal(g.secAdvisor.OrgByPeerIdentity(identity), g.selfOrg) {
+		return errors.New("identity doesn't belong to the organization of the peer")
+	}
+
+	g.identityLock.Lock()
+	defer g.identityLock.Unlock()
+
+	if err := g.idMapper.SetSelfIdentity(identity); err != nil {
+		return fmt.Errorf("failed storing identity: %v", err)
+	}
+	if err := g.certStore.updateSelfIdentity(identity); err != nil {
+		return err
+	}
+	pkiID := g.idMapper.GetPKIidOfCert(identity)
+	g.logger.Info("Updating identity, new PKI-ID is", pkiID)
+
+	// Include the new identity in Alive messages for a while,
+	// so that peers learn it along with the new PKI-ID
+	g.disSecAdap.updateIdentity(identity, time.Now().Add(g.conf.PublishCertPeriod))
+	g.disc.UpdatePKIid(pkiID)
+	g.chanState.RLock()
+	for _, gc := range g.chanState.channels {
+		gc.UpdatePKIid(pkiID)
+	}
+	g.chanState.RUnlock()
+	g.comm.UpdateIdentity(identity)
+	g.selfIdentity = identity
+
+	// Republish the channel states under the new PKI-ID
+	g.selfStatesLock.Lock()
+	var chains []common.ChainID
+	for chainID, state := range g.selfStates {
+		if state.published {
+			chains = append(chains, common.ChainID(chainID))
+		}
+	}
+	g.selfStatesLock.Unlock()
+	for _, chainID := range chains {
+		g.updateChannelState(chainID, func(*selfChannelState) bool {
+			return true
+		})
+	}
+	return nil
+}
+
+// UpdateExternalEndpoint replaces the endpoint the peer publishes to peers
+// of other organizations, and announces it to the rest of the network.
+// An empty endpoint makes the peer inaccessible outside of its organization
+func (g *gossipServiceImpl) UpdateExternalEndpoint(endpoint string) error {
+	if endpoint != "" {
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			return fmt.Errorf("endpoint %s isn't formatted as 'host:port': %v", endpoint, err)
+		}
+	}
+	g.logger.Info("Updating external endpoint to", endpoint)
+	if endpoint == "" {
+		g.logger.Warning("External endpoint is empty, peer will not be accessible outside of its organization")
+	}
+	// Alive messages carry the endpoint, so the rest of
+	// the network learns it with the next Alive message
+	g.disc.UpdateEndpoint(endpoint)
+	return nil
+}
+
+// AddAnchorPeers makes the peer connect to the given anchor peers of an
+// organization of a channel it has joined, in addition to the anchor
+// peers of the channel configuration
+func (g *gossipServiceImpl) AddAnchorPeers(chainID common.ChainID, org api.OrgIdentityType, anchorPeers []api.AnchorPeer) error {
+	gc := g.chanState.getGossipChannelByChainID(chainID)
+	if gc == nil {
+		return fmt.Errorf("channel %s doesn't exist", string(chainID))
+	}
+	if !gc.IsOrgInChannel(org) {
+		return fmt.Errorf("organization %s isn't in channel %s", string(org), string(chainID))
+	}
+	g.logger.Info("Adding anchor peers", anchorPeers, "of", string(org), "in channel", string(chainID))
+	g.learnAnchorPeers(org, anchorPeers)
+	return nil
+}
+
 func (g *gossipServiceImpl) periodicalIdentityValidationAndExpiration() {
 	// We check once every identityExpirationCheckInterval for identities that have been expired
 	go g.periodicalIdentityValidation(func(identity api.PeerIdentityType) bool {
@@ -247,7 +442,7 @@ func (g *gossipServiceImpl) learnAnchorPeers(orgOfAnchorPeers api.OrgIdentityTyp
 		}
 		endpoint := fmt.Sprintf("%s:%d", ap.Host, ap.Port)
 		// Skip connecting to self
-		if g.selfNetworkMember().Endpoint == endpoint || g.selfNetworkMember().InternalEndpoint == endpoint {
+		if g.isSelfEndpoint(endpoint) || g.selfNetworkMember().InternalEndpoint == endpoint {
 			g.logger.Info("Anchor peer with same endpoint, skipping connecting to myself")
 			continue
 		}
@@ -299,6 +494,25 @@ func (g *gossipServiceImpl) handlePresumedDead() {
 	}
 }
 
+// handleExpiredIdentities closes the connections to peers
+// whose identities have expired
+func (g *gossipServiceImpl) handleExpiredIdentities() {
+	defer g.logger.Debug("Exiting")
+	g.stopSignal.Add(1)
+	defer g.stopSignal.Done()
+	for {
+		select {
+		case s := <-g.toDieChan:
+			g.toDieChan <- s
+			return
+		case pkiID := <-g.idMapper.Expired():
+			g.logger.Info("Identity of", pkiID, "has expired, closing its connection")
+			g.certStore.pull.Remove(string(pkiID))
+			g.comm.CloseConn(&comm.RemotePeer{PKIID: pkiID})
+		}
+	}
+}
+
 func (g *gossipServiceImpl) syncDiscovery() {
 	g.logger.Debug("Entering discovery sync with interval", g.conf.PullInterval)
 	defer g.logger.Debug("Exiting discovery sync loop")
@@ -308,9 +522,24 @@ func (g *gossipServiceImpl) syncDiscovery() {
 	}
 }
 
+// reportMembership periodically reports the number of alive peers
+// known to this peer, and the number of alive peers in each channel
+func (g *gossipServiceImpl) reportMembership() {
+	for !g.toDie() {
+		g.metrics.MembershipMetrics.Total.With("").Set(float64(len(g.disc.GetMembership())))
+		g.chanState.RLock()
+		for chainID, gc := range g.chanState.channels {
+			g.metrics.MembershipMetrics.Total.With(chainID).Set(float64(len(gc.GetPeers())))
+		}
+		g.chanState.RUnlock()
+		time.Sleep(g.conf.PullInterval)
+	}
+}
+
 func (g *gossipServiceImpl) start() {
 	go g.syncDiscovery()
 	go g.handlePresumedDead()
+	go g.handleExpiredIdentities()
 
 	msgSelector := func(msg interface{}) bool {
 		gMsg, isGossipMsg := msg.(proto.ReceivedMessage)
@@ -676,6 +905,12 @@ func (g *gossipServiceImpl) PeersOfChannel(channel common.ChainID) []discovery.N
 	return gc.GetPeers()
 }
 
+// IsInMyOrg returns whether the given network member
+// is in the same organization as the peer
+func (g *gossipServiceImpl) IsInMyOrg(member discovery.NetworkMember) bool {
+	return g.isInMyorg(member)
+}
+
 // Stop stops the gossip component
 func (g *gossipServiceImpl) Stop() {
 	if g.toDie() {
@@ -693,6 +928,7 @@ func (g *gossipServiceImpl) Stop() {
 	g.discAdapter.close()
 	g.disc.Stop()
 	g.certStore.stop()
+	g.idMapper.Stop()
 	g.toDieChan <- struct{}{}
 	g.emitter.Stop()
 	g.ChannelDeMultiplexer.Close()
@@ -708,17 +944,73 @@ func (g *gossipServiceImpl) UpdateMetadata(md []byte) {
 // UpdateChannelMetadata updates the self metadata the peer
 // publishes to other peers about its channel-related state
 func (g *gossipServiceImpl) UpdateChannelMetadata(md []byte, chainID common.ChainID) {
+	g.updateChannelState(chainID, func(state *selfChannelState) bool {
+		state.metadata = md
+		return true
+	})
+}
+
+// UpdateLedgerHeight updates the ledger height the peer publishes to
+// other peers in the channel, along with the channel metadata, in a
+// single StateInfo message
+func (g *gossipServiceImpl) UpdateLedgerHeight(height uint64, metadata []byte, chainID common.ChainID) {
+	g.updateChannelState(chainID, func(state *selfChannelState) bool {
+		state.properties.LedgerHeight = height
+		state.metadata = metadata
+		return true
+	})
+}
+
+// UpdateChaincodes updates the chaincodes the peer publishes to other
+// peers as installed on it, and as instantiated in the channel
+func (g *gossipServiceImpl) UpdateChaincodes(installed []*proto.Chaincode, instantiated []*proto.Chaincode, chainID common.ChainID) {
+	g.updateChannelState(chainID, func(state *selfChannelState) bool {
+		state.properties.InstalledChaincodes = installed
+		state.properties.InstantiatedChaincodes = instantiated
+		return true
+	})
+}
+
+// UpdateLeadership updates whether the peer publishes to other
+// peers that it is the leader of its organization in the channel
+func (g *gossipServiceImpl) UpdateLeadership(isLeader bool, chainID common.ChainID) {
+	g.updateChannelState(chainID, func(state *selfChannelState) bool {
+		state.properties.Leader = isLeader
+		return true
+	})
+}
+
+// updateChannelState applies the given update to the state the peer
+// publishes about itself in the channel, and publishes the state
+// if the update returns true
+func (g *gossipServiceImpl) updateChannelState(chainID common.ChainID, update func(*selfChannelState) bool) {
 	gc := g.chanState.getGossipChannelByChainID(chainID)
 	if gc == nil {
 		g.logger.Debug("No such channel", chainID)
 		return
 	}
-	stateInfMsg, err := g.createStateInfoMsg(md, chainID)
+
+	g.selfStatesLock.Lock()
+	defer g.selfStatesLock.Unlock()
+
+	state, exists := g.selfStates[string(chainID)]
+	if !exists {
+		state = &selfChannelState{}
+		g.selfStates[string(chainID)] = state
+	}
+	if !update(state) {
+		return
+	}
+
+	// the message refers to a copy, as the state keeps changing
+	properties := state.properties
+	stateInfMsg, err := g.createStateInfoMsg(state.metadata, &properties, chainID)
 	if err != nil {
 		g.logger.Error("Failed creating StateInfo message")
 		return
 	}
 	gc.UpdateStateInfo(stateInfMsg)
+	state.published = true
 }
 
 // Accept returns a dedicated read-only channel for messages sent by other nodes that match a certain predicate.
@@ -789,6 +1081,47 @@ func (g *gossipServiceImpl) newDiscoveryAdapter() *discoveryAdapter {
 	}
 }
 
+// endpointSelectingComm is a comm.Comm that dials remote peers at
+// the endpoints its selector picks for them, once it has one
+type endpointSelectingComm struct {
+	comm.Comm
+	lock     sync.RWMutex
+	selector func(peer *comm.RemotePeer) string
+}
+
+func (c *endpointSelectingComm) setSelector(selector func(peer *comm.RemotePeer) string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.selector = selector
+}
+
+// selectEndpoint returns the given remote peer with the endpoint to dial it at
+func (c *endpointSelectingComm) selectEndpoint(peer *comm.RemotePeer) *comm.RemotePeer {
+	c.lock.RLock()
+	selector := c.selector
+	c.lock.RUnlock()
+	if selector == nil || len(peer.PKIID) == 0 {
+		return peer
+	}
+	return &comm.RemotePeer{Endpoint: selector(peer), PKIID: peer.PKIID}
+}
+
+func (c *endpointSelectingComm) Send(msg *proto.SignedGossipMessage, peers ...*comm.RemotePeer) {
+	selected := make([]*comm.RemotePeer, len(peers))
+	for i, peer := range peers {
+		selected[i] = c.selectEndpoint(peer)
+	}
+	c.Comm.Send(msg, selected...)
+}
+
+func (c *endpointSelectingComm) Probe(peer *comm.RemotePeer) error {
+	return c.Comm.Probe(c.selectEndpoint(peer))
+}
+
+func (c *endpointSelectingComm) Handshake(peer *comm.RemotePeer) (api.PeerIdentityType, error) {
+	return c.Comm.Handshake(c.selectEndpoint(peer))
+}
+
 // discoveryAdapter is used to supply the discovery module with needed abilities
 // that the comm interface in the discovery module declares
 type discoveryAdapter struct {
@@ -871,6 +1204,7 @@ func (da *discoveryAdapter) CloseConn(peer *discovery.NetworkMember) {
 }
 
 type discoverySecurityAdapter struct {
+	lock                  sync.RWMutex
 	identity              api.PeerIdentityType
 	includeIdentityPeriod time.Time
 	idMapper              identity.Mapper
@@ -926,14 +1260,25 @@ func (sa *discoverySecurityAdapter) ValidateAliveMsg(m *proto.SignedGossipMessag
 	return sa.validateAliveMsgSignature(m, identity)
 }
 
+// updateIdentity replaces the identity included in Alive messages,
+// and includes it in them until the given time
+func (sa *discoverySecurityAdapter) updateIdentity(identity api.PeerIdentityType, includeIdentityPeriod time.Time) {
+	sa.lock.Lock()
+	defer sa.lock.Unlock()
+	sa.identity = identity
+	sa.includeIdentityPeriod = includeIdentityPeriod
+}
+
 // SignMessage signs an AliveMessage and updates its signature field
 func (sa *discoverySecurityAdapter) SignMessage(m *proto.GossipMessage, internalEndpoint string) *proto.Envelope {
 	signer := func(msg []byte) ([]byte, error) {
 		return sa.mcs.Sign(msg)
 	}
+	sa.lock.RLock()
 	if m.IsAliveMsg() && time.Now().Before(sa.includeIdentityPeriod) {
 		m.GetAliveMsg().Identity = sa.identity
 	}
+	sa.lock.RUnlock()
 	sMsg := &proto.SignedGossipMessage{
 		GossipMessage: m,
 	}
@@ -1005,6 +1350,7 @@ func (g *gossipServiceImpl) createCertStorePuller() pull.Mediator {
 		IdExtractor: pkiIDFromMsg,
 		MsgCons:     certConsumer,
 		DigFilter:   g.sameOrgOrOurOrgPullFilter,
+		PullMetrics: g.metrics.PullMetrics,
 	}
 	return pull.NewPullMediator(conf, adapter)
 }
@@ -1068,11 +1414,12 @@ func (g *gossipServiceImpl) connect2BootstrapPeers() {
 
 }
 
-func (g *gossipServiceImpl) createStateInfoMsg(metadata []byte, chainID common.ChainID) (*proto.SignedGossipMessage, error) {
+func (g *gossipServiceImpl) createStateInfoMsg(metadata []byte, properties *proto.Properties, chainID common.ChainID) (*proto.SignedGossipMessage, error) {
 	pkiID := g.comm.GetPKIid()
 	stateInfMsg := &proto.StateInfo{
 		Channel_MAC: channel.GenerateMAC(pkiID, chainID),
 		Metadata:    metadata,
+		Properties:  properties,
 		PkiId:       g.comm.GetPKIid(),
 		Timestamp: &proto.PeerTime{
 			IncNum: uint64(g.incTime.UnixNano()),
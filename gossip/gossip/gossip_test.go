@@ -36,6 +36,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/gossip/algo"
 	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/stretchr/testify/assert"
@@ -111,6 +112,7 @@ type naiveCryptoService struct {
 	sync.RWMutex
 	allowedPkiIDS map[string]struct{}
 	revokedPkiIDS map[string]struct{}
+	expirations   map[string]time.Time
 }
 
 type orgCryptoService struct {
@@ -152,6 +154,16 @@ func (cs *naiveCryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType
 	return nil
 }
 
+// Expiration returns the expiration time of the identity
+func (cs *naiveCryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	cs.RLock()
+	defer cs.RUnlock()
+	if expiration, exists := cs.expirations[string(peerIdentity)]; exists {
+		return expiration, nil
+	}
+	return time.Now().Add(time.Hour), nil
+}
+
 // GetPKIidOfCert returns the PKI-ID of a peer's identity
 func (*naiveCryptoService) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
 	return common.PKIidType(peerIdentity)
@@ -182,6 +194,15 @@ func (*naiveCryptoService) Verify(peerIdentity api.PeerIdentityType, signature,
 	return nil
 }
 
+func (cs *naiveCryptoService) setExpiration(identity api.PeerIdentityType, expiration time.Time) {
+	cs.Lock()
+	defer cs.Unlock()
+	if cs.expirations == nil {
+		cs.expirations = map[string]time.Time{}
+	}
+	cs.expirations[string(identity)] = expiration
+}
+
 func (cs *naiveCryptoService) revoke(pkiID common.PKIidType) {
 	cs.Lock()
 	defer cs.Unlock()
@@ -221,7 +242,7 @@ func newGossipInstanceWithCustomMCS(portPrefix int, id int, maxMsgCount int, mcs
 	selfId := api.PeerIdentityType(conf.InternalEndpoint)
 	idMapper := identity.NewIdentityMapper(mcs, selfId)
 	g := NewGossipServiceWithServer(conf, &orgCryptoService{}, mcs, idMapper,
-		selfId, nil)
+		selfId, nil, metrics.NewDisabledGossipMetrics())
 
 	return g
 }
@@ -254,8 +275,9 @@ func newGossipInstanceWithOnlyPull(portPrefix int, id int, maxMsgCount int, boot
 	selfId := api.PeerIdentityType(conf.InternalEndpoint)
 	idMapper := identity.NewIdentityMapper(cryptoService, selfId)
 
+	// nil metrics are disabled
 	g := NewGossipServiceWithServer(conf, &orgCryptoService{}, cryptoService, idMapper,
-		selfId, nil)
+		selfId, nil, nil)
 	return g
 }
 
@@ -428,6 +450,65 @@ func TestConnectToAnchorPeers(t *testing.T) {
 	testWG.Done()
 }
 
+func TestChannelProperties(t *testing.T) {
+	// Scenario: 2 peers join a channel in which the first is an anchor peer.
+	// The first peer publishes its ledger height, chaincodes and leadership,
+	// and the second peer should see them in the properties of the first
+	portPrefix := 14610
+	channel := common.ChainID("A")
+	jcm := &joinChanMsg{members2AnchorPeers: map[string][]api.AnchorPeer{
+		string(orgInChannelA): {{Host: "1.2.3.4", Port: portPrefix}},
+	}}
+
+	p0 := newGossipInstance(portPrefix, 0, 100)
+	p1 := newGossipInstance(portPrefix, 1, 100, 0)
+	defer stopPeers([]Gossip{p0, p1})
+
+	p0.JoinChan(jcm, channel)
+	p1.JoinChan(jcm, channel)
+	p1.UpdateChannelMetadata([]byte{}, channel)
+
+	cc := &proto.Chaincode{Name: "mycc", Version: "1.0"}
+	p0.UpdateLedgerHeight(5, nil, channel)
+	p0.UpdateChaincodes([]*proto.Chaincode{cc}, []*proto.Chaincode{cc}, channel)
+	p0.UpdateLeadership(true, channel)
+
+	p0AsSeenByP1 := func() *discovery.NetworkMember {
+		for _, member := range p1.PeersOfChannel(channel) {
+			if member.Endpoint == fmt.Sprintf("1.2.3.4:%d", portPrefix) {
+				return &member
+			}
+		}
+		return nil
+	}
+	propertiesOfP0 := func() *proto.Properties {
+		if member := p0AsSeenByP1(); member != nil {
+			return member.Properties
+		}
+		return nil
+	}
+	propertiesUpdated := func() bool {
+		props := propertiesOfP0()
+		return props != nil && props.Leader && props.LedgerHeight == 5
+	}
+	waitUntilOrFail(t, propertiesUpdated)
+
+	props := propertiesOfP0()
+	assert.True(t, props.AnchorPeer)
+	assert.Len(t, props.InstalledChaincodes, 1)
+	assert.Equal(t, "mycc", props.InstantiatedChaincodes[0].Name)
+	assert.Equal(t, "1.0", props.InstantiatedChaincodes[0].Version)
+
+	// Properties keep their value across updates of other properties,
+	// and the ledger height comes along with the metadata encoding it
+	p0.UpdateLedgerHeight(6, []byte("height 6"), channel)
+	waitUntilOrFail(t, func() bool {
+		props := propertiesOfP0()
+		return props != nil && props.LedgerHeight == 6 && props.Leader && len(props.InstantiatedChaincodes) == 1
+	})
+	assert.Equal(t, []byte("height 6"), p0AsSeenByP1().Metadata)
+}
+
 func TestMembership(t *testing.T) {
 	t.Parallel()
 	portPrefix := 4610
@@ -1042,6 +1123,202 @@ func TestIdentityExpiration(t *testing.T) {
 	stopPeers(peers)
 }
 
+func TestIdentityCertificateExpiration(t *testing.T) {
+	t.Parallel()
+	// Scenario: spawn 4 peers, where the certificate of one of them expires
+	// shortly after they start. Eventually, the rest of the peers should
+	// close their connections to the peer with the expired certificate,
+	// and not communicate with it anymore
+
+	portPrefix := 7110
+	expiringPeerIndex := 3
+	expiringIdentity := api.PeerIdentityType(fmt.Sprintf("localhost:%d", portPrefix+expiringPeerIndex))
+	expiration := time.Now().Add(time.Second * 15)
+
+	var peers []Gossip
+	for i := 0; i < 4; i++ {
+		mcs := &naiveCryptoService{}
+		mcs.setExpiration(expiringIdentity, expiration)
+		var boot []int
+		if i > 0 {
+			boot = []int{0}
+		}
+		peers = append(peers, newGossipInstanceWithCustomMCS(portPrefix, i, 100, mcs, boot...))
+	}
+
+	seeAllNeighbors := func() bool {
+		for i := 0; i < 4; i++ {
+			if len(peers[i].Peers()) != 3 {
+				return false
+			}
+		}
+		return true
+	}
+	waitUntilOrFail(t, seeAllNeighbors)
+
+	ensureExpiredPeerIsIgnored := func() bool {
+		for i := 0; i < 4; i++ {
+			expectedNeighborCount := 2
+			if i == expiringPeerIndex {
+				expectedNeighborCount = 0
+			}
+			if len(peers[i].Peers()) != expectedNeighborCount {
+				return false
+			}
+		}
+		return true
+	}
+	waitUntilOrFail(t, ensureExpiredPeerIsIgnored)
+	// The identity of the expired peer was purged
+	for i, p := range peers {
+		if i == expiringPeerIndex {
+			continue
+		}
+		_, err := p.(*gossipServiceImpl).idMapper.Get(common.PKIidType(expiringIdentity))
+		assert.Error(t, err)
+	}
+	stopPeers(peers)
+}
+
+func TestIdentityRotation(t *testing.T) {
+	t.Parallel()
+	// Scenario: spawn 3 peers in a channel, and make one of them rotate its identity.
+	// Eventually, the rest of the peers should know the peer only by its new
+	// PKI-ID, and the peer should keep communicating with them
+
+	portPrefix := 7120
+	g0 := newGossipInstance(portPrefix, 0, 100)
+	g1 := newGossipInstance(portPrefix, 1, 100, 0)
+	g2 := newGossipInstance(portPrefix, 2, 100, 0)
+	peers := []Gossip{g0, g1, g2}
+
+	for i, p := range peers {
+		p.JoinChan(&joinChanMsg{}, common.ChainID("A"))
+		p.UpdateLedgerHeight(uint64(i+1), nil, common.ChainID("A"))
+	}
+
+	knowsPeer := func(members []discovery.NetworkMember, pkiID common.PKIidType) bool {
+		for _, member := range members {
+			if bytes.Equal(member.PKIid, pkiID) {
+				return true
+			}
+		}
+		return false
+	}
+
+	seeAllNeighbors := func() bool {
+		for _, p := range peers {
+			if len(p.Peers()) != 2 || len(p.PeersOfChannel(common.ChainID("A"))) != 2 {
+				return false
+			}
+		}
+		return true
+	}
+	waitUntilOrFail(t, seeAllNeighbors)
+
+	// Rotating to an expired identity fails
+	expiredIdentity := api.PeerIdentityType(fmt.Sprintf("localhost:%d-expired", portPrefix))
+	g0.(*gossipServiceImpl).mcs.(*naiveCryptoService).setExpiration(expiredIdentity, time.Now().Add(-time.Second))
+	assert.Error(t, g0.UpdateIdentity(expiredIdentity))
+
+	oldPKIID := common.PKIidType(fmt.Sprintf("localhost:%d", portPrefix))
+	newIdentity := api.PeerIdentityType(fmt.Sprintf("localhost:%d-rotated", portPrefix))
+	newPKIID := common.PKIidType(newIdentity)
+	assert.NoError(t, g0.UpdateIdentity(newIdentity))
+	assert.Equal(t, newPKIID, g0.(*gossipServiceImpl).comm.GetPKIid())
+
+	knownByNewIdentityOnly := func() bool {
+		for _, p := range peers[1:] {
+			if knowsPeer(p.Peers(), oldPKIID) || !knowsPeer(p.Peers(), newPKIID) {
+				return false
+			}
+			if !knowsPeer(p.PeersOfChannel(common.ChainID("A")), newPKIID) {
+				return false
+			}
+		}
+		return len(g0.Peers()) == 2 && len(g0.PeersOfChannel(common.ChainID("A"))) == 2
+	}
+	waitUntilOrFail(t, knownByNewIdentityOnly)
+
+	// The new identity of the peer is known to the rest of the peers
+	for _, p := range peers[1:] {
+		identity, err := p.(*gossipServiceImpl).idMapper.Get(newPKIID)
+		assert.NoError(t, err)
+		assert.Equal(t, newIdentity, identity)
+		for _, member := range p.PeersOfChannel(common.ChainID("A")) {
+			if bytes.Equal(member.PKIid, newPKIID) {
+				assert.NotNil(t, member.Properties)
+				assert.Equal(t, uint64(1), member.Properties.GetLedgerHeight())
+			}
+		}
+	}
+	stopPeers(peers)
+}
+
+func newGossipInstanceOverMemoryNetwork(network *comm.MemoryNetwork, id int, boot ...int) Gossip {
+	endpoint := fmt.Sprintf("peer%d:7051", id)
+	var bootPeers []string
+	for _, b := range boot {
+		bootPeers = append(bootPeers, fmt.Sprintf("peer%d:7051", b))
+	}
+	conf := &Config{
+		BootstrapPeers:             bootPeers,
+		ID:                         endpoint,
+		MaxBlockCountToStore:       100,
+		MaxPropagationBurstLatency: time.Duration(500) * time.Millisecond,
+		MaxPropagationBurstSize:    20,
+		PropagateIterations:        1,
+		PropagatePeerNum:           3,
+		PullInterval:               time.Duration(2) * time.Second,
+		PullPeerNum:                5,
+		InternalEndpoint:           endpoint,
+		ExternalEndpoint:           endpoint,
+		PublishCertPeriod:          time.Duration(4) * time.Second,
+		PublishStateInfoInterval:   time.Duration(1) * time.Second,
+		RequestStateInfoInterval:   time.Duration(1) * time.Second,
+	}
+	transport, err := network.NewTransport(endpoint)
+	if err != nil {
+		panic(err)
+	}
+	cryptoService := &naiveCryptoService{}
+	selfId := api.PeerIdentityType(endpoint)
+	idMapper := identity.NewIdentityMapper(cryptoService, selfId)
+	return NewGossipServiceWithTransport(conf, transport, &orgCryptoService{}, cryptoService, idMapper,
+		selfId, metrics.NewDisabledGossipMetrics())
+}
+
+func TestMembershipOverMemoryNetwork(t *testing.T) {
+	t.Parallel()
+	// Scenario: 10 peers run over an in-memory network with latency and message loss.
+	// After they all know each other, the network is split into two halves,
+	// and each half should only know its own peers.
+	// Once the network heals, all peers should know each other again
+	network := comm.NewMemoryNetwork(0)
+	network.SetLatency(time.Millisecond * 10)
+	network.SetLossRate(0.01)
+	n := 10
+	var peers []Gossip
+	for i := 0; i < n; i++ {
+		peers = append(peers, newGossipInstanceOverMemoryNetwork(network, i, 0))
+	}
+	defer stopPeers(peers)
+
+	waitUntilOrFail(t, checkPeersMembership(t, peers, n-1))
+
+	var half1, half2 []string
+	for i := 0; i < n/2; i++ {
+		half1 = append(half1, fmt.Sprintf("peer%d:7051", i))
+		half2 = append(half2, fmt.Sprintf("peer%d:7051", i+n/2))
+	}
+	network.Partition(half1, half2)
+	waitUntilOrFail(t, checkPeersMembership(t, peers[:n/2], n/2-1))
+	waitUntilOrFail(t, checkPeersMembership(t, peers[n/2:], n/2-1))
+
+	network.Heal()
+	waitUntilOrFail(t, checkPeersMembership(t, peers, n-1))
+}
+
 func TestEndedGoroutines(t *testing.T) {
 	t.Parallel()
 	testWG.Wait()
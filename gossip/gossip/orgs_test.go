@@ -26,9 +26,11 @@ import (
 
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/stretchr/testify/assert"
@@ -70,6 +72,11 @@ func (*configurableCryptoService) ValidateIdentity(peerIdentity api.PeerIdentity
 	return nil
 }
 
+// Expiration returns the expiration time of the identity
+func (*configurableCryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Now().Add(time.Hour), nil
+}
+
 // GetPKIidOfCert returns the PKI-ID of a peer's identity
 func (*configurableCryptoService) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
 	return common.PKIidType(peerIdentity)
@@ -101,6 +108,11 @@ func (*configurableCryptoService) Verify(peerIdentity api.PeerIdentityType, sign
 }
 
 func newGossipInstanceWithExternalEndpoint(portPrefix int, id int, mcs *configurableCryptoService, externalEndpoint string, boot ...int) Gossip {
+	return newGossipInstanceWithNetworkEndpoints(portPrefix, id, mcs, externalEndpoint, nil, nil, boot...)
+}
+
+func newGossipInstanceWithNetworkEndpoints(portPrefix int, id int, mcs *configurableCryptoService, externalEndpoint string,
+	networkEndpoints map[string]string, endpointSelection map[string]string, boot ...int) Gossip {
 	port := id + portPrefix
 	conf := &Config{
 		BindPort:                   port,
@@ -115,6 +127,8 @@ func newGossipInstanceWithExternalEndpoint(portPrefix int, id int, mcs *configur
 		PullPeerNum:                5,
 		InternalEndpoint:           fmt.Sprintf("localhost:%d", port),
 		ExternalEndpoint:           externalEndpoint,
+		NetworkEndpoints:           networkEndpoints,
+		EndpointSelection:          endpointSelection,
 		PublishCertPeriod:          time.Duration(4) * time.Second,
 		PublishStateInfoInterval:   time.Duration(1) * time.Second,
 		RequestStateInfoInterval:   time.Duration(1) * time.Second,
@@ -122,7 +136,7 @@ func newGossipInstanceWithExternalEndpoint(portPrefix int, id int, mcs *configur
 	selfId := api.PeerIdentityType(conf.InternalEndpoint)
 	idMapper := identity.NewIdentityMapper(mcs, selfId)
 	g := NewGossipServiceWithServer(conf, mcs, mcs, idMapper, selfId,
-		nil)
+		nil, metrics.NewDisabledGossipMetrics())
 
 	return g
 }
@@ -261,6 +275,163 @@ func TestMultipleOrgEndpointLeakage(t *testing.T) {
 	}
 }
 
+func TestExternalAndNetworkEndpoints(t *testing.T) {
+	t.Parallel()
+	// Scenario: orgA has 2 peers, and orgB has a single peer.
+	// The first peer of orgA publishes an endpoint for the "dmz" network, and the
+	// peer of orgB dials peers of orgA at endpoints of that network, while its
+	// membership view keeps their external endpoints.
+	// The second peer of orgA has no external endpoint at first, so the peer of orgB
+	// doesn't know it, until the external endpoint is set at runtime.
+	cs := &configurableCryptoService{m: make(map[string]api.OrgIdentityType)}
+	portPrefix := 16610
+	orgA, orgB := "orgA", "orgB"
+	cs.putInOrg(portPrefix, orgA)
+	cs.putInOrg(portPrefix+1, orgA)
+	cs.putInOrg(portPrefix+2, orgB)
+
+	dmzEndpoint := fmt.Sprintf("127.0.0.1:%d", portPrefix)
+	p0 := newGossipInstanceWithNetworkEndpoints(portPrefix, 0, cs, fmt.Sprintf("localhost:%d", portPrefix),
+		map[string]string{"dmz": dmzEndpoint}, nil)
+	p1 := newGossipInstanceWithExternalEndpoint(portPrefix, 1, cs, "", 0)
+	p2 := newGossipInstanceWithNetworkEndpoints(portPrefix, 2, cs, fmt.Sprintf("localhost:%d", portPrefix+2),
+		nil, map[string]string{orgA: "dmz"})
+	peers := []Gossip{p0, p1, p2}
+	defer stopPeers(peers)
+
+	jcm := &joinChanMsg{
+		members2AnchorPeers: map[string][]api.AnchorPeer{
+			orgA: {{Host: "localhost", Port: portPrefix}},
+			// Only the peer of orgB connects to the anchor peer of orgA,
+			// so the two don't race connecting to each other
+			orgB: {},
+		},
+	}
+	channel := common.ChainID("A")
+	for _, p := range peers {
+		p.JoinChan(jcm, channel)
+	}
+
+	memberOf := func(p Gossip, port int) discovery.NetworkMember {
+		for _, member := range p.Peers() {
+			if string(member.PKIid) == fmt.Sprintf("localhost:%d", port) {
+				return member
+			}
+		}
+		return discovery.NetworkMember{}
+	}
+	endpointOf := func(p Gossip, port int) string {
+		return memberOf(p, port).Endpoint
+	}
+
+	knowsDMZEndpoint := func() bool {
+		return memberOf(p2, portPrefix).NetworkEndpoints["dmz"] == dmzEndpoint && len(p2.Peers()) == 1 &&
+			endpointOf(p0, portPrefix+2) == fmt.Sprintf("localhost:%d", portPrefix+2)
+	}
+	waitUntilOrFail(t, knowsDMZEndpoint)
+	externalEndpoint := fmt.Sprintf("localhost:%d", portPrefix)
+	assert.Equal(t, externalEndpoint, endpointOf(p2, portPrefix))
+	pkiID := common.PKIidType(externalEndpoint)
+	assert.Equal(t, dmzEndpoint, p2.(*gossipServiceImpl).selectEndpoint(&comm.RemotePeer{Endpoint: externalEndpoint, PKIID: pkiID}))
+	// Internal endpoints are dialed as they are
+	assert.Equal(t, "internal:1", p2.(*gossipServiceImpl).selectEndpoint(&comm.RemotePeer{Endpoint: "internal:1", PKIID: pkiID}))
+	// Peers of orgA don't select endpoints of other networks
+	assert.Equal(t, externalEndpoint, endpointOf(p1, portPrefix))
+	_, selectsEndpoints := p1.(*gossipServiceImpl).comm.(*endpointSelectingComm)
+	assert.False(t, selectsEndpoints)
+
+	assert.Error(t, p1.UpdateExternalEndpoint("anEndpointWithoutAPort"))
+	updatedEndpoint := fmt.Sprintf("127.0.0.1:%d", portPrefix+1)
+	assert.NoError(t, p1.UpdateExternalEndpoint(updatedEndpoint))
+	assert.Equal(t, updatedEndpoint, p1.(*gossipServiceImpl).selfNetworkMember().Endpoint)
+
+	knowsUpdatedEndpoint := func() bool {
+		return endpointOf(p2, portPrefix+1) == updatedEndpoint && endpointOf(p0, portPrefix+1) == updatedEndpoint
+	}
+	waitUntilOrFail(t, knowsUpdatedEndpoint)
+}
+
+func TestAddAnchorPeers(t *testing.T) {
+	t.Parallel()
+	// Scenario: orgA and orgB have a peer each, and the channel configuration
+	// has no anchor peers, so the peers don't know each other until the
+	// anchor peer of orgA is added to the peer of orgB at runtime
+	cs := &configurableCryptoService{m: make(map[string]api.OrgIdentityType)}
+	portPrefix := 17610
+	orgA, orgB := "orgA", "orgB"
+	cs.putInOrg(portPrefix, orgA)
+	cs.putInOrg(portPrefix+1, orgB)
+
+	p0 := newGossipInstanceWithExternalEndpoint(portPrefix, 0, cs, fmt.Sprintf("localhost:%d", portPrefix))
+	p1 := newGossipInstanceWithExternalEndpoint(portPrefix, 1, cs, fmt.Sprintf("localhost:%d", portPrefix+1))
+	peers := []Gossip{p0, p1}
+	defer stopPeers(peers)
+
+	jcm := &joinChanMsg{
+		members2AnchorPeers: map[string][]api.AnchorPeer{
+			orgA: {},
+			orgB: {},
+		},
+	}
+	channel := common.ChainID("A")
+	anchorPeers := []api.AnchorPeer{{Host: "localhost", Port: portPrefix}}
+	assert.Error(t, p1.AddAnchorPeers(channel, api.OrgIdentityType(orgA), anchorPeers))
+	for _, p := range peers {
+		p.JoinChan(jcm, channel)
+	}
+	assert.Error(t, p1.AddAnchorPeers(channel, api.OrgIdentityType("orgC"), anchorPeers))
+	assert.Empty(t, p1.Peers())
+
+	assert.NoError(t, p1.AddAnchorPeers(channel, api.OrgIdentityType(orgA), anchorPeers))
+	waitUntilOrFail(t, func() bool {
+		return len(p0.Peers()) == 1 && len(p1.Peers()) == 1
+	})
+}
+
+type dialRecordingComm struct {
+	comm.Comm
+	dialed []string
+}
+
+func (c *dialRecordingComm) Send(msg *proto.SignedGossipMessage, peers ...*comm.RemotePeer) {
+	for _, peer := range peers {
+		c.dialed = append(c.dialed, peer.Endpoint)
+	}
+}
+
+func (c *dialRecordingComm) Probe(peer *comm.RemotePeer) error {
+	c.dialed = append(c.dialed, peer.Endpoint)
+	return nil
+}
+
+func (c *dialRecordingComm) Handshake(peer *comm.RemotePeer) (api.PeerIdentityType, error) {
+	c.dialed = append(c.dialed, peer.Endpoint)
+	return nil, nil
+}
+
+func TestEndpointSelectingComm(t *testing.T) {
+	t.Parallel()
+	recorder := &dialRecordingComm{}
+	c := &endpointSelectingComm{Comm: recorder}
+	p := &comm.RemotePeer{Endpoint: "external:1", PKIID: common.PKIidType("p")}
+
+	// Endpoints are left as they are until there is a selector
+	c.Send(nil, p)
+	assert.Equal(t, []string{"external:1"}, recorder.dialed)
+
+	c.setSelector(func(peer *comm.RemotePeer) string {
+		return "dmz:1"
+	})
+	c.Send(nil, p, p)
+	c.Probe(p)
+	c.Handshake(p)
+	// Peers whose PKI-ID isn't known yet are dialed as they are
+	c.Handshake(&comm.RemotePeer{Endpoint: "anchor:1"})
+	assert.Equal(t, []string{"external:1", "dmz:1", "dmz:1", "dmz:1", "dmz:1", "anchor:1"}, recorder.dialed)
+	// The remote peers passed in are left as they are
+	assert.Equal(t, "external:1", p.Endpoint)
+}
+
 func TestConfidentiality(t *testing.T) {
 	t.Parallel()
 	// Scenario: create 4 organizations: {A, B, C, D}, each with 3 peers.
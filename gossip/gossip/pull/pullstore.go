@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/gossip/algo"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/op/go-logging"
@@ -86,6 +87,7 @@ type PullAdapter struct {
 	IdExtractor proto.IdentifierExtractor
 	MsgCons     proto.MsgConsumer
 	DigFilter   DigestFilter
+	PullMetrics *metrics.PullMetrics
 }
 
 // Mediator is a component wrap a PullEngine and provides the methods
@@ -137,6 +139,10 @@ func NewPullMediator(config Config, adapter *PullAdapter) Mediator {
 		digFilter = acceptAllFilter
 	}
 
+	if adapter.PullMetrics == nil {
+		adapter.PullMetrics = metrics.NewDisabledGossipMetrics().PullMetrics
+	}
+
 	p := &pullMediatorImpl{
 		PullAdapter:  adapter,
 		msgType2Hook: make(map[MsgType][]MessageHook),
@@ -237,6 +243,8 @@ func (p *pullMediatorImpl) Remove(digest string) {
 
 // SelectPeers returns a slice of peers which the engine will initiate the protocol with
 func (p *pullMediatorImpl) SelectPeers() []string {
+	// The engine selects peers once at the beginning of each pull round
+	p.PullMetrics.Rounds.With(string(p.config.Channel), p.config.MsgType.String()).Add(1)
 	remotePeers := SelectEndpoints(p.config.PeerCountToSelect, p.MemSvc.GetMembership())
 	endpoints := make([]string, len(remotePeers))
 	for i, peer := range remotePeers {
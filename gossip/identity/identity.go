@@ -34,6 +34,8 @@ var (
 	usageThreshold = time.Hour
 )
 
+const expiredChanSize = 100
+
 // Mapper holds mappings between pkiID
 // to certificates(identities) of peers
 type Mapper interface {
@@ -59,6 +61,17 @@ type Mapper interface {
 	// peer identities have been revoked, expired or haven't been used
 	// for a long time
 	ListInvalidIdentities(isSuspected api.PeerSuspector) []common.PKIidType
+
+	// SetSelfIdentity replaces the identity of the peer with the given identity,
+	// and returns an error in case the identity is invalid or has expired
+	SetSelfIdentity(identity api.PeerIdentityType) error
+
+	// Expired returns a read-only channel of PKI-IDs of identities
+	// that have been purged from the Mapper because they expired
+	Expired() <-chan common.PKIidType
+
+	// Stop stops all background computations of the Mapper
+	Stop()
 }
 
 // identityMapperImpl is a struct that implements Mapper
@@ -66,16 +79,21 @@ type identityMapperImpl struct {
 	mcs        api.MessageCryptoService
 	pkiID2Cert map[string]*storedIdentity
 	sync.RWMutex
-	selfPKIID string
+	selfPKIID   string
+	expiredChan chan common.PKIidType
+	stopChan    chan struct{}
+	stopOnce    sync.Once
 }
 
 // NewIdentityMapper method, all we need is a reference to a MessageCryptoService
 func NewIdentityMapper(mcs api.MessageCryptoService, selfIdentity api.PeerIdentityType) Mapper {
 	selfPKIID := mcs.GetPKIidOfCert(selfIdentity)
 	idMapper := &identityMapperImpl{
-		mcs:        mcs,
-		pkiID2Cert: make(map[string]*storedIdentity),
-		selfPKIID:  string(selfPKIID),
+		mcs:         mcs,
+		pkiID2Cert:  make(map[string]*storedIdentity),
+		selfPKIID:   string(selfPKIID),
+		expiredChan: make(chan common.PKIidType, expiredChanSize),
+		stopChan:    make(chan struct{}),
 	}
 	if err := idMapper.Put(selfPKIID, selfIdentity); err != nil {
 		panic(fmt.Errorf("Failed putting our own identity into the identity mapper: %v", err))
@@ -84,7 +102,7 @@ func NewIdentityMapper(mcs api.MessageCryptoService, selfIdentity api.PeerIdenti
 }
 
 // put associates an identity to its given pkiID, and returns an error
-// in case the given pkiID doesn't match the identity
+// in case the given pkiID doesn't match the identity, or the identity has expired
 func (is *identityMapperImpl) Put(pkiID common.PKIidType, identity api.PeerIdentityType) error {
 	if pkiID == nil {
 		return errors.New("PKIID is nil")
@@ -102,12 +120,110 @@ func (is *identityMapperImpl) Put(pkiID common.PKIidType, identity api.PeerIdent
 		return errors.New("identity doesn't match the computed pkiID")
 	}
 
+	expirationDate, err := is.mcs.Expiration(identity)
+	if err != nil {
+		return fmt.Errorf("failed determining the expiration date of the identity: %v", err)
+	}
+	is.Lock()
+	defer is.Unlock()
+	// Our own identity is accepted even if it has expired, since the peer
+	// can't operate without it. It is replaced via SetSelfIdentity
+	isSelf := string(id) == is.selfPKIID
+	if !isSelf && hasExpired(expirationDate) {
+		return errors.New("identity expired")
+	}
+
+	if si, exists := is.pkiID2Cert[string(id)]; exists {
+		// The identity is already stored, only mark it as used
+		si.fetchIdentity()
+		return nil
+	}
+
+	si := newStoredIdentity(identity)
+	// Identities expire at the time their certificates expire,
+	// except for our own identity which is replaced via SetSelfIdentity
+	if !expirationDate.IsZero() && !isSelf {
+		si.expirationTimer = time.AfterFunc(expirationDate.Sub(time.Now()), func() {
+			is.expire(id, si)
+		})
+	}
+	is.pkiID2Cert[string(id)] = si
+	return nil
+}
+
+// SetSelfIdentity replaces the identity of the peer with the given identity,
+// and returns an error in case the identity is invalid or has expired
+func (is *identityMapperImpl) SetSelfIdentity(identity api.PeerIdentityType) error {
+	selfPKIID := is.mcs.GetPKIidOfCert(identity)
+	if len(selfPKIID) == 0 {
+		return errors.New("failed computing the PKI-ID of the identity")
+	}
+	expirationDate, err := is.mcs.Expiration(identity)
+	if err != nil {
+		return fmt.Errorf("failed determining the expiration date of the identity: %v", err)
+	}
+	if hasExpired(expirationDate) {
+		return errors.New("identity expired")
+	}
+	// Mark the identity as our own before storing it,
+	// so that it isn't purged when it expires
+	is.Lock()
+	prevSelfPKIID := is.selfPKIID
+	is.selfPKIID = string(selfPKIID)
+	is.Unlock()
+
+	if err := is.Put(selfPKIID, identity); err != nil {
+		is.Lock()
+		is.selfPKIID = prevSelfPKIID
+		is.Unlock()
+		return err
+	}
+
+	// The identity might have been stored before it became our own
 	is.Lock()
 	defer is.Unlock()
-	is.pkiID2Cert[string(id)] = newStoredIdentity(identity)
+	if si, exists := is.pkiID2Cert[string(selfPKIID)]; exists {
+		si.cancelExpiration()
+	}
 	return nil
 }
 
+// expire purges the given stored identity of the given pkiID
+// and publishes its pkiID via the Expired channel
+func (is *identityMapperImpl) expire(pkiID common.PKIidType, si *storedIdentity) {
+	is.Lock()
+	if is.pkiID2Cert[string(pkiID)] != si {
+		// The identity was already purged
+		is.Unlock()
+		return
+	}
+	delete(is.pkiID2Cert, string(pkiID))
+	is.Unlock()
+
+	select {
+	case is.expiredChan <- pkiID:
+	case <-is.stopChan:
+	}
+}
+
+// Expired returns a read-only channel of PKI-IDs of identities
+// that have been purged from the Mapper because they expired
+func (is *identityMapperImpl) Expired() <-chan common.PKIidType {
+	return is.expiredChan
+}
+
+// Stop stops all background computations of the Mapper
+func (is *identityMapperImpl) Stop() {
+	is.stopOnce.Do(func() {
+		close(is.stopChan)
+	})
+	is.Lock()
+	defer is.Unlock()
+	for _, si := range is.pkiID2Cert {
+		si.cancelExpiration()
+	}
+}
+
 // get returns the identity of a given pkiID, or error if such an identity
 // isn't found
 func (is *identityMapperImpl) Get(pkiID common.PKIidType) (api.PeerIdentityType, error) {
@@ -151,6 +267,9 @@ func (is *identityMapperImpl) ListInvalidIdentities(isSuspected api.PeerSuspecto
 	is.Lock()
 	defer is.Unlock()
 	for _, pkiID := range revokedIds {
+		if si, exists := is.pkiID2Cert[string(pkiID)]; exists {
+			si.cancelExpiration()
+		}
 		delete(is.pkiID2Cert, string(pkiID))
 	}
 	return revokedIds
@@ -179,8 +298,9 @@ func (is *identityMapperImpl) validateIdentities(isSuspected api.PeerSuspector)
 }
 
 type storedIdentity struct {
-	lastAccessTime int64
-	peerIdentity   api.PeerIdentityType
+	lastAccessTime  int64
+	peerIdentity    api.PeerIdentityType
+	expirationTimer *time.Timer
 }
 
 func newStoredIdentity(identity api.PeerIdentityType) *storedIdentity {
@@ -199,6 +319,18 @@ func (si *storedIdentity) fetchLastAccessTime() time.Time {
 	return time.Unix(0, atomic.LoadInt64(&si.lastAccessTime))
 }
 
+// hasExpired returns whether the given expiration date has passed.
+// A zero expiration date means the identity never expires
+func hasExpired(expirationDate time.Time) bool {
+	return !expirationDate.IsZero() && !time.Now().Before(expirationDate)
+}
+
+func (si *storedIdentity) cancelExpiration() {
+	if si.expirationTimer != nil {
+		si.expirationTimer.Stop()
+	}
+}
+
 // SetIdentityUsageThreshold sets the usage threshold of identities.
 // Identities that are not used at least once during the given time
 // are purged
@@ -31,12 +31,14 @@ import (
 )
 
 var (
-	msgCryptoService = &naiveCryptoService{revokedIdentities: map[string]struct{}{}}
-	dummyID          = api.PeerIdentityType{}
+	msgCryptoService   = &naiveCryptoService{revokedIdentities: map[string]struct{}{}}
+	dummyID            = api.PeerIdentityType{}
+	unparsableIdentity = api.PeerIdentityType("unparsable")
 )
 
 type naiveCryptoService struct {
 	revokedIdentities map[string]struct{}
+	expirations       map[string]time.Time
 }
 
 func init() {
@@ -50,6 +52,17 @@ func (cs *naiveCryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType
 	return nil
 }
 
+// Expiration returns the expiration time of the identity
+func (cs *naiveCryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	if bytes.Equal(peerIdentity, unparsableIdentity) {
+		return time.Time{}, errors.New("unparsable identity")
+	}
+	if expiration, exists := cs.expirations[string(peerIdentity)]; exists {
+		return expiration, nil
+	}
+	return time.Time{}, nil
+}
+
 // GetPKIidOfCert returns the PKI-ID of a peer's identity
 func (*naiveCryptoService) GetPKIidOfCert(peerIdentity api.PeerIdentityType) common.PKIidType {
 	return common.PKIidType(peerIdentity)
@@ -97,6 +110,12 @@ func TestPut(t *testing.T) {
 	assert.Error(t, idStore.Put(pkiID, identity2))
 }
 
+func TestPutUnparsableIdentity(t *testing.T) {
+	idStore := NewIdentityMapper(msgCryptoService, dummyID)
+	pkiID := msgCryptoService.GetPKIidOfCert(unparsableIdentity)
+	assert.Error(t, idStore.Put(pkiID, unparsableIdentity))
+}
+
 func TestGet(t *testing.T) {
 	idStore := NewIdentityMapper(msgCryptoService, dummyID)
 	identity := []byte("yacovm")
@@ -192,3 +211,87 @@ func TestListInvalidIdentities(t *testing.T) {
 	assert.NotNil(t, cert)
 	stopChan <- struct{}{}
 }
+
+func TestExpiration(t *testing.T) {
+	mcs := &naiveCryptoService{
+		expirations: map[string]time.Time{
+			"expiring": time.Now().Add(time.Second),
+			"expired":  time.Now().Add(-time.Second),
+		},
+	}
+	idStore := NewIdentityMapper(mcs, dummyID)
+	defer idStore.Stop()
+
+	expiring := api.PeerIdentityType("expiring")
+	expired := api.PeerIdentityType("expired")
+	assert.NoError(t, idStore.Put(mcs.GetPKIidOfCert(expiring), expiring))
+	assert.Error(t, idStore.Put(mcs.GetPKIidOfCert(expired), expired))
+
+	cert, err := idStore.Get(mcs.GetPKIidOfCert(expiring))
+	assert.NoError(t, err)
+	assert.Equal(t, expiring, cert)
+
+	// The identity is purged once it expires
+	select {
+	case pkiID := <-idStore.Expired():
+		assert.Equal(t, mcs.GetPKIidOfCert(expiring), pkiID)
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "Didn't purge the expired identity in a timely manner")
+	}
+	cert, err = idStore.Get(mcs.GetPKIidOfCert(expiring))
+	assert.Error(t, err)
+	assert.Nil(t, cert)
+}
+
+func TestSetSelfIdentity(t *testing.T) {
+	mcs := &naiveCryptoService{
+		expirations: map[string]time.Time{
+			"newSelf": time.Now().Add(time.Millisecond * 500),
+			"expired": time.Now().Add(-time.Second),
+		},
+	}
+	idStore := NewIdentityMapper(mcs, dummyID)
+	defer idStore.Stop()
+
+	newSelf := api.PeerIdentityType("newSelf")
+	assert.Error(t, idStore.SetSelfIdentity(api.PeerIdentityType("expired")))
+	assert.NoError(t, idStore.SetSelfIdentity(newSelf))
+
+	// Our own identity isn't purged when it expires
+	time.Sleep(time.Second)
+	cert, err := idStore.Get(mcs.GetPKIidOfCert(newSelf))
+	assert.NoError(t, err)
+	assert.Equal(t, newSelf, cert)
+
+	// Nor when it isn't used for a long time
+	usageThreshold = time.Millisecond
+	defer func() {
+		usageThreshold = time.Hour
+	}()
+	time.Sleep(time.Millisecond * 10)
+	idStore.ListInvalidIdentities(func(_ api.PeerIdentityType) bool {
+		return false
+	})
+	_, err = idStore.Get(mcs.GetPKIidOfCert(newSelf))
+	assert.NoError(t, err)
+	// But the previous identity is
+	_, err = idStore.Get(mcs.GetPKIidOfCert(dummyID))
+	assert.Error(t, err)
+}
+
+func TestStop(t *testing.T) {
+	mcs := &naiveCryptoService{
+		expirations: map[string]time.Time{
+			"expiring": time.Now().Add(time.Millisecond * 500),
+		},
+	}
+	idStore := NewIdentityMapper(mcs, dummyID)
+	expiring := api.PeerIdentityType("expiring")
+	assert.NoError(t, idStore.Put(mcs.GetPKIidOfCert(expiring), expiring))
+	idStore.Stop()
+
+	// Identities are not purged after the mapper is stopped
+	time.Sleep(time.Second)
+	_, err := idStore.Get(mcs.GetPKIidOfCert(expiring))
+	assert.NoError(t, err)
+}
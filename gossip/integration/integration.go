@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
@@ -55,6 +56,11 @@ func newConfig(selfEndpoint string, externalEndpoint string, bootPeers ...string
 		cert = &certTmp
 	}
 
+	networkEndpoints, endpointSelection, err := readNetworkEndpoints()
+	if err != nil {
+		return nil, err
+	}
+
 	return &gossip.Config{
 		BindPort:                   int(port),
 		BootstrapPeers:             bootPeers,
@@ -68,6 +74,8 @@ func newConfig(selfEndpoint string, externalEndpoint string, bootPeers ...string
 		PullPeerNum:                util.GetIntOrDefault("peer.gossip.pullPeerNum", 3),
 		InternalEndpoint:           selfEndpoint,
 		ExternalEndpoint:           externalEndpoint,
+		NetworkEndpoints:           networkEndpoints,
+		EndpointSelection:          endpointSelection,
 		PublishCertPeriod:          util.GetDurationOrDefault("peer.gossip.publishCertPeriod", 10*time.Second),
 		RequestStateInfoInterval:   util.GetDurationOrDefault("peer.gossip.requestStateInfoInterval", 4*time.Second),
 		PublishStateInfoInterval:   util.GetDurationOrDefault("peer.gossip.publishStateInfoInterval", 4*time.Second),
@@ -76,10 +84,44 @@ func newConfig(selfEndpoint string, externalEndpoint string, bootPeers ...string
 	}, nil
 }
 
+// readNetworkEndpoints reads the endpoints the peer publishes per network,
+// and the network whose endpoints are connected to per organization.
+// They are configured as lists rather than maps, since map keys
+// in the configuration aren't case sensitive, unlike MSP IDs
+func readNetworkEndpoints() (map[string]string, map[string]string, error) {
+	var networkEndpoints []struct {
+		Network  string
+		Endpoint string
+	}
+	if err := viper.UnmarshalKey("peer.gossip.networkEndpoints", &networkEndpoints); err != nil {
+		return nil, nil, fmt.Errorf("failed reading network endpoints: %v", err)
+	}
+	var endpointSelection []struct {
+		Org     string
+		Network string
+	}
+	if err := viper.UnmarshalKey("peer.gossip.endpointSelection", &endpointSelection); err != nil {
+		return nil, nil, fmt.Errorf("failed reading endpoint selection: %v", err)
+	}
+
+	endpoints := make(map[string]string)
+	for _, ne := range networkEndpoints {
+		if _, _, err := net.SplitHostPort(ne.Endpoint); err != nil {
+			return nil, nil, fmt.Errorf("misconfigured endpoint %s of network %s, the error is %s", ne.Endpoint, ne.Network, err)
+		}
+		endpoints[ne.Network] = ne.Endpoint
+	}
+	selection := make(map[string]string)
+	for _, es := range endpointSelection {
+		selection[es.Org] = es.Network
+	}
+	return endpoints, selection, nil
+}
+
 // NewGossipComponent creates a gossip component that attaches itself to the given gRPC server
 func NewGossipComponent(peerIdentity []byte, endpoint string, s *grpc.Server,
 	secAdv api.SecurityAdvisor, cryptSvc api.MessageCryptoService, idMapper identity.Mapper,
-	secureDialOpts api.PeerSecureDialOpts, bootPeers ...string) (gossip.Gossip, error) {
+	secureDialOpts api.PeerSecureDialOpts, gossipMetrics *metrics.GossipMetrics, bootPeers ...string) (gossip.Gossip, error) {
 
 	externalEndpoint := viper.GetString("peer.gossip.externalEndpoint")
 
@@ -88,7 +130,7 @@ func NewGossipComponent(peerIdentity []byte, endpoint string, s *grpc.Server,
 		return nil, err
 	}
 	gossipInstance := gossip.NewGossipService(conf, s, secAdv, cryptSvc, idMapper,
-		peerIdentity, secureDialOpts)
+		peerIdentity, secureDialOpts, gossipMetrics)
 
 	return gossipInstance, nil
 }
@@ -21,11 +21,13 @@ import (
 	"net"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
@@ -65,13 +67,13 @@ func TestNewGossipCryptoService(t *testing.T) {
 	idMapper := identity.NewIdentityMapper(cryptSvc, peerIdentity)
 
 	g1, err := NewGossipComponent(peerIdentity, endpoint1, s1, secAdv, cryptSvc, idMapper,
-		defaultSecureDialOpts)
+		defaultSecureDialOpts, metrics.NewDisabledGossipMetrics())
 	assert.NoError(t, err)
 	g2, err := NewGossipComponent(peerIdentity, endpoint2, s2, secAdv, cryptSvc, idMapper,
-		defaultSecureDialOpts, endpoint1)
+		defaultSecureDialOpts, metrics.NewDisabledGossipMetrics(), endpoint1)
 	assert.NoError(t, err)
 	g3, err := NewGossipComponent(peerIdentity, endpoint3, s3, secAdv, cryptSvc, idMapper,
-		defaultSecureDialOpts, endpoint1)
+		defaultSecureDialOpts, metrics.NewDisabledGossipMetrics(), endpoint1)
 	assert.NoError(t, err)
 	defer g1.Stop()
 	defer g2.Stop()
@@ -90,7 +92,31 @@ func TestBadInitialization(t *testing.T) {
 
 	viper.Set("peer.tls.enabled", true)
 	_, err = NewGossipComponent(peerIdentity, "localhost:5000", s1, secAdv, cryptSvc, idMapper,
-		defaultSecureDialOpts)
+		defaultSecureDialOpts, metrics.NewDisabledGossipMetrics())
+	assert.Error(t, err)
+}
+
+func TestNetworkEndpointsConfig(t *testing.T) {
+	defer viper.Set("peer.gossip.networkEndpoints", nil)
+	defer viper.Set("peer.gossip.endpointSelection", nil)
+	viper.Set("peer.tls.enabled", false)
+
+	// Lists in the configuration are read as lists of maps with interface keys
+	viper.Set("peer.gossip.networkEndpoints", []interface{}{
+		map[interface{}]interface{}{"network": "dmz", "endpoint": "peer0.dmz.example.com:7051"},
+	})
+	viper.Set("peer.gossip.endpointSelection", []interface{}{
+		map[interface{}]interface{}{"org": "Org2MSP", "network": "dmz"},
+	})
+	conf, err := newConfig("localhost:5000", "peer0.example.com:7051")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"dmz": "peer0.dmz.example.com:7051"}, conf.NetworkEndpoints)
+	assert.Equal(t, map[string]string{"Org2MSP": "dmz"}, conf.EndpointSelection)
+
+	viper.Set("peer.gossip.networkEndpoints", []interface{}{
+		map[interface{}]interface{}{"network": "dmz", "endpoint": "anEndpointWithoutAPort"},
+	})
+	_, err = newConfig("localhost:5000", "peer0.example.com:7051")
 	assert.Error(t, err)
 }
 
@@ -139,3 +165,8 @@ func (s *cryptoService) VerifyByChannel(chainID common.ChainID, peerIdentity api
 func (s *cryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType) error {
 	return nil
 }
+
+// Expiration returns the expiration time of the identity
+func (*cryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Now().Add(time.Hour), nil
+}
@@ -0,0 +1,295 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+const namespace = "gossip"
+
+// UnknownChannel is the label of the bytes of the messages
+// of channels the peer hasn't joined
+const UnknownChannel = "unknown"
+
+// GossipMetrics encapsulates all the metrics of the gossip components
+type GossipMetrics struct {
+	CommMetrics       *CommMetrics
+	EmitterMetrics    *EmitterMetrics
+	MembershipMetrics *MembershipMetrics
+	PullMetrics       *PullMetrics
+	StateMetrics      *StateMetrics
+}
+
+// NewGossipMetrics creates the metrics of the gossip components
+// out of the given provider, or disabled ones if it is nil
+func NewGossipMetrics(p metrics.Provider) *GossipMetrics {
+	if p == nil {
+		p = &disabled.Provider{}
+	}
+	return &GossipMetrics{
+		CommMetrics:       NewCommMetrics(p),
+		EmitterMetrics:    NewEmitterMetrics(p),
+		MembershipMetrics: NewMembershipMetrics(p),
+		PullMetrics:       NewPullMetrics(p),
+		StateMetrics:      NewStateMetrics(p),
+	}
+}
+
+// NewDisabledGossipMetrics creates gossip metrics that discard
+// everything reported to them
+func NewDisabledGossipMetrics() *GossipMetrics {
+	return NewGossipMetrics(&disabled.Provider{})
+}
+
+// CommMetrics counts the messages and bytes that pass through
+// the communication layer
+type CommMetrics struct {
+	// SentMessages counts sent messages by message type
+	SentMessages metrics.Counter
+	// ReceivedMessages counts received messages by message type
+	ReceivedMessages metrics.Counter
+	// DroppedMessages counts messages dropped because the
+	// send buffer of the connection was full, by message type
+	DroppedMessages metrics.Counter
+	// SentBytes counts sent bytes by channel
+	SentBytes metrics.Counter
+	// ReceivedBytes counts received bytes by channel
+	ReceivedBytes metrics.Counter
+
+	// the channels counted by name, any other channel is counted as
+	// UnknownChannel so that remote peers cannot create labels at will
+	lock     sync.RWMutex
+	channels map[string]struct{}
+}
+
+// AddChannel makes the bytes of the messages of the given
+// channel counted under its name
+func (m *CommMetrics) AddChannel(channel string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.channels[channel] = struct{}{}
+}
+
+// ChannelLabel returns the label the bytes of the
+// messages of the given channel are counted under
+func (m *CommMetrics) ChannelLabel(channel []byte) string {
+	if len(channel) == 0 {
+		return ""
+	}
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if _, ok := m.channels[string(channel)]; ok {
+		return string(channel)
+	}
+	return UnknownChannel
+}
+
+// NewCommMetrics creates the metrics of the communication layer
+func NewCommMetrics(p metrics.Provider) *CommMetrics {
+	return &CommMetrics{
+		SentMessages: p.NewCounter(metrics.CounterOpts{
+			Namespace:  namespace,
+			Subsystem:  "comm",
+			Name:       "messages_sent",
+			Help:       "Number of messages sent, by message type",
+			LabelNames: []string{"type"},
+		}),
+		ReceivedMessages: p.NewCounter(metrics.CounterOpts{
+			Namespace:  namespace,
+			Subsystem:  "comm",
+			Name:       "messages_received",
+			Help:       "Number of messages received, by message type",
+			LabelNames: []string{"type"},
+		}),
+		DroppedMessages: p.NewCounter(metrics.CounterOpts{
+			Namespace:  namespace,
+			Subsystem:  "comm",
+			Name:       "messages_dropped",
+			Help:       "Number of messages dropped due to a full send buffer, by message type",
+			LabelNames: []string{"type"},
+		}),
+		SentBytes: p.NewCounter(metrics.CounterOpts{
+			Namespace:  namespace,
+			Subsystem:  "comm",
+			Name:       "bytes_sent",
+			Help:       "Number of bytes sent, by channel",
+			LabelNames: []string{"channel"},
+		}),
+		ReceivedBytes: p.NewCounter(metrics.CounterOpts{
+			Namespace:  namespace,
+			Subsystem:  "comm",
+			Name:       "bytes_received",
+			Help:       "Number of bytes received, by channel",
+			LabelNames: []string{"channel"},
+		}),
+		channels: make(map[string]struct{}),
+	}
+}
+
+// EmitterMetrics reports the state of the batching emitter
+// that propagates messages to remote peers
+type EmitterMetrics struct {
+	// BufferOverflows counts emissions triggered by a full buffer
+	// rather than by the propagation latency
+	BufferOverflows metrics.Counter
+	// BufferSize is the number of messages waiting to be emitted
+	BufferSize metrics.Gauge
+}
+
+// NewEmitterMetrics creates the metrics of the batching emitter
+func NewEmitterMetrics(p metrics.Provider) *EmitterMetrics {
+	return &EmitterMetrics{
+		BufferOverflows: p.NewCounter(metrics.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "emitter",
+			Name:      "buffer_overflows",
+			Help:      "Number of times the propagation burst size was reached",
+		}),
+		BufferSize: p.NewGauge(metrics.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "emitter",
+			Name:      "buffer_size",
+			Help:      "Number of messages waiting to be propagated",
+		}),
+	}
+}
+
+// MembershipMetrics reports the size of the membership view
+type MembershipMetrics struct {
+	// Total is the number of alive peers known to this peer, by channel.
+	// The channel label is empty for the membership of the whole network.
+	Total metrics.Gauge
+}
+
+// NewMembershipMetrics creates the membership metrics
+func NewMembershipMetrics(p metrics.Provider) *MembershipMetrics {
+	return &MembershipMetrics{
+		Total: p.NewGauge(metrics.GaugeOpts{
+			Namespace:  namespace,
+			Subsystem:  "membership",
+			Name:       "total_peers_known",
+			Help:       "Number of alive peers known, by channel",
+			LabelNames: []string{"channel"},
+		}),
+	}
+}
+
+// PullMetrics counts the rounds of the pull mechanism
+type PullMetrics struct {
+	// Rounds counts pull rounds initiated, by channel and pulled message type
+	Rounds metrics.Counter
+}
+
+// NewPullMetrics creates the pull metrics
+func NewPullMetrics(p metrics.Provider) *PullMetrics {
+	return &PullMetrics{
+		Rounds: p.NewCounter(metrics.CounterOpts{
+			Namespace:  namespace,
+			Subsystem:  "pull",
+			Name:       "rounds",
+			Help:       "Number of pull rounds initiated, by channel and message type",
+			LabelNames: []string{"channel", "type"},
+		}),
+	}
+}
+
+// StateMetrics reports the progress of the state transfer of channels
+type StateMetrics struct {
+	// Height is the ledger height of the channel
+	Height metrics.Gauge
+	// Lag is the number of blocks the ledger lags behind the
+	// highest ledger height advertised by peers of the channel
+	Lag metrics.Gauge
+	// PayloadBufferSize is the number of blocks waiting in the
+	// payloads buffer to be committed
+	PayloadBufferSize metrics.Gauge
+}
+
+// NewStateMetrics creates the state transfer metrics
+func NewStateMetrics(p metrics.Provider) *StateMetrics {
+	return &StateMetrics{
+		Height: p.NewGauge(metrics.GaugeOpts{
+			Namespace:  namespace,
+			Subsystem:  "state",
+			Name:       "height",
+			Help:       "Ledger height of the channel",
+			LabelNames: []string{"channel"},
+		}),
+		Lag: p.NewGauge(metrics.GaugeOpts{
+			Namespace:  namespace,
+			Subsystem:  "state",
+			Name:       "lag",
+			Help:       "Number of blocks the ledger lags behind the peers of the channel",
+			LabelNames: []string{"channel"},
+		}),
+		PayloadBufferSize: p.NewGauge(metrics.GaugeOpts{
+			Namespace:  namespace,
+			Subsystem:  "state",
+			Name:       "payload_buffer_size",
+			Help:       "Number of blocks waiting in the payloads buffer, by channel",
+			LabelNames: []string{"channel"},
+		}),
+	}
+}
+
+// MessageType returns the type of the given gossip message,
+// as used in the labels of the metrics
+func MessageType(msg *proto.GossipMessage) string {
+	switch msg.GetContent().(type) {
+	case *proto.GossipMessage_AliveMsg:
+		return "AliveMsg"
+	case *proto.GossipMessage_MemReq:
+		return "MemReq"
+	case *proto.GossipMessage_MemRes:
+		return "MemRes"
+	case *proto.GossipMessage_DataMsg:
+		return "DataMsg"
+	case *proto.GossipMessage_Hello:
+		return "Hello"
+	case *proto.GossipMessage_DataDig:
+		return "DataDig"
+	case *proto.GossipMessage_DataReq:
+		return "DataReq"
+	case *proto.GossipMessage_DataUpdate:
+		return "DataUpdate"
+	case *proto.GossipMessage_Empty:
+		return "Empty"
+	case *proto.GossipMessage_Conn:
+		return "Conn"
+	case *proto.GossipMessage_StateInfo:
+		return "StateInfo"
+	case *proto.GossipMessage_StateSnapshot:
+		return "StateSnapshot"
+	case *proto.GossipMessage_StateInfoPullReq:
+		return "StateInfoPullReq"
+	case *proto.GossipMessage_StateRequest:
+		return "StateRequest"
+	case *proto.GossipMessage_StateResponse:
+		return "StateResponse"
+	case *proto.GossipMessage_LeadershipMsg:
+		return "LeadershipMsg"
+	case *proto.GossipMessage_PeerIdentity:
+		return "PeerIdentity"
+	default:
+		return "unknown"
+	}
+}
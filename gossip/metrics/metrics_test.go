@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics/prometheus"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageType(t *testing.T) {
+	assert.Equal(t, "unknown", MessageType(nil))
+	assert.Equal(t, "unknown", MessageType(&proto.GossipMessage{}))
+	assert.Equal(t, "AliveMsg", MessageType(&proto.GossipMessage{
+		Content: &proto.GossipMessage_AliveMsg{AliveMsg: &proto.AliveMessage{}},
+	}))
+	assert.Equal(t, "StateInfoPullReq", MessageType(&proto.GossipMessage{
+		Content: &proto.GossipMessage_StateInfoPullReq{StateInfoPullReq: &proto.StateInfoPullRequest{}},
+	}))
+	assert.Equal(t, "PeerIdentity", MessageType(&proto.GossipMessage{
+		Content: &proto.GossipMessage_PeerIdentity{PeerIdentity: &proto.PeerIdentity{}},
+	}))
+}
+
+func TestNewGossipMetrics(t *testing.T) {
+	// Creating the metrics twice out of the same provider
+	// is possible, as several gossip instances may share it
+	p := prometheus.NewProvider()
+	assert.NotPanics(t, func() {
+		NewGossipMetrics(p)
+		NewGossipMetrics(p)
+	})
+	assert.NotNil(t, NewDisabledGossipMetrics().StateMetrics.Lag)
+	assert.NotPanics(t, func() {
+		NewGossipMetrics(nil).CommMetrics.SentMessages.With("DataMsg").Add(1)
+	})
+}
+
+func TestChannelLabel(t *testing.T) {
+	m := NewGossipMetrics(prometheus.NewProvider()).CommMetrics
+	m.AddChannel("A")
+	assert.Equal(t, "A", m.ChannelLabel([]byte("A")))
+	assert.Equal(t, "", m.ChannelLabel(nil))
+	assert.Equal(t, UnknownChannel, m.ChannelLabel([]byte("B")))
+	assert.NotPanics(t, func() {
+		m.ReceivedBytes.With(m.ChannelLabel([]byte{0xff, 0xfe})).Add(1)
+	})
+}
@@ -19,6 +19,7 @@ package service
 import (
 	"sync"
 
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/committer"
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
@@ -28,6 +29,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/identity"
 	"github.com/hyperledger/fabric/gossip/integration"
+	gmetrics "github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/state"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/protos/common"
@@ -55,6 +57,11 @@ type GossipService interface {
 	GetBlock(chainID string, index uint64) *common.Block
 	// AddPayload appends message payload to for given chain
 	AddPayload(chainID string, payload *proto.Payload) error
+	// PeerIdentity returns the identity of the peer with the given PKI-ID,
+	// this peer included
+	PeerIdentity(pkiID gossipCommon.PKIidType) (api.PeerIdentityType, error)
+	// SelfIdentity returns the identity of this peer
+	SelfIdentity() api.PeerIdentityType
 }
 
 // DeliveryServiceFactory factory to create and initialize delivery service instance
@@ -79,15 +86,19 @@ func (*deliveryFactoryImpl) Service(g GossipService, endpoints []string, mcs api
 
 type gossipServiceImpl struct {
 	gossipSvc
-	chains          map[string]state.GossipStateProvider
-	leaderElection  map[string]election.LeaderElectionService
-	deliveryService deliverclient.DeliverService
-	deliveryFactory DeliveryServiceFactory
-	lock            sync.RWMutex
-	idMapper        identity.Mapper
-	mcs             api.MessageCryptoService
-	peerIdentity    []byte
-	secAdv          api.SecurityAdvisor
+	chains         map[string]state.GossipStateProvider
+	leaderElection map[string]election.LeaderElectionService
+	// electionCallbacks holds the callbacks of the leader elections,
+	// in order to restart them when the identity of the peer changes
+	electionCallbacks map[string]func(bool)
+	deliveryService   deliverclient.DeliverService
+	deliveryFactory   DeliveryServiceFactory
+	lock              sync.RWMutex
+	idMapper          identity.Mapper
+	mcs               api.MessageCryptoService
+	peerIdentity      []byte
+	secAdv            api.SecurityAdvisor
+	metrics           *gmetrics.GossipMetrics
 }
 
 // This is an implementation of api.JoinChannelMessage.
@@ -119,19 +130,19 @@ func (jcm *joinChannelMessage) AnchorPeersOf(org api.OrgIdentityType) []api.Anch
 var logger = util.GetLogger(util.LoggingServiceModule, "")
 
 // InitGossipService initialize gossip service
-func InitGossipService(peerIdentity []byte, endpoint string, s *grpc.Server, mcs api.MessageCryptoService,
-	secAdv api.SecurityAdvisor, secureDialOpts api.PeerSecureDialOpts, bootPeers ...string) error {
+func InitGossipService(peerIdentity []byte, metricsProvider metrics.Provider, endpoint string, s *grpc.Server,
+	mcs api.MessageCryptoService, secAdv api.SecurityAdvisor, secureDialOpts api.PeerSecureDialOpts, bootPeers ...string) error {
 	// TODO: Remove this.
 	// TODO: This is a temporary work-around to make the gossip leader election module load its logger at startup
 	// TODO: in order for the flogging package to register this logger in time so it can set the log levels as requested in the config
 	util.GetLogger(util.LoggingElectionModule, "")
-	return InitGossipServiceCustomDeliveryFactory(peerIdentity, endpoint, s, &deliveryFactoryImpl{},
+	return InitGossipServiceCustomDeliveryFactory(peerIdentity, metricsProvider, endpoint, s, &deliveryFactoryImpl{},
 		mcs, secAdv, secureDialOpts, bootPeers...)
 }
 
 // InitGossipServiceCustomDeliveryFactory initialize gossip service with customize delivery factory
 // implementation, might be useful for testing and mocking purposes
-func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, endpoint string, s *grpc.Server,
+func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, metricsProvider metrics.Provider, endpoint string, s *grpc.Server,
 	factory DeliveryServiceFactory, mcs api.MessageCryptoService, secAdv api.SecurityAdvisor,
 	secureDialOpts api.PeerSecureDialOpts, bootPeers ...string) error {
 	var err error
@@ -144,17 +155,20 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, endpoint string
 		logger.Info("Initialize gossip with endpoint", endpoint, "and bootstrap set", bootPeers)
 
 		idMapper := identity.NewIdentityMapper(mcs, peerIdentity)
+		gossipMetrics := gmetrics.NewGossipMetrics(metricsProvider)
 		gossip, err = integration.NewGossipComponent(peerIdentity, endpoint, s, secAdv,
-			mcs, idMapper, secureDialOpts, bootPeers...)
+			mcs, idMapper, secureDialOpts, gossipMetrics, bootPeers...)
 		gossipServiceInstance = &gossipServiceImpl{
-			mcs:             mcs,
-			gossipSvc:       gossip,
-			chains:          make(map[string]state.GossipStateProvider),
-			leaderElection:  make(map[string]election.LeaderElectionService),
-			deliveryFactory: factory,
-			idMapper:        idMapper,
-			peerIdentity:    peerIdentity,
-			secAdv:          secAdv,
+			mcs:               mcs,
+			gossipSvc:         gossip,
+			chains:            make(map[string]state.GossipStateProvider),
+			leaderElection:    make(map[string]election.LeaderElectionService),
+			electionCallbacks: make(map[string]func(bool)),
+			deliveryFactory:   factory,
+			idMapper:          idMapper,
+			peerIdentity:      peerIdentity,
+			secAdv:            secAdv,
+			metrics:           gossipMetrics,
 		}
 	})
 	return err
@@ -162,6 +176,10 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, endpoint string
 
 // GetGossipService returns an instance of gossip service
 func GetGossipService() GossipService {
+	if gossipServiceInstance == nil {
+		// avoid returning a non-nil interface holding a nil pointer
+		return nil
+	}
 	return gossipServiceInstance
 }
 
@@ -176,7 +194,7 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, committer committe
 	defer g.lock.Unlock()
 	// Initialize new state provider for given committer
 	logger.Debug("Creating state provider for chainID", chainID)
-	g.chains[chainID] = state.NewGossipStateProvider(chainID, g, committer, g.mcs)
+	g.chains[chainID] = state.NewGossipStateProvider(chainID, g, committer, g.mcs, g.metrics.StateMetrics)
 	if g.deliveryService == nil {
 		var err error
 		g.deliveryService, err = g.deliveryFactory.Service(gossipServiceInstance, endpoints, g.mcs)
@@ -203,10 +221,16 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, committer committe
 
 		if leaderElection {
 			logger.Debug("Delivery uses dynamic leader election mechanism, channel", chainID)
-			g.leaderElection[chainID] = g.newLeaderElectionComponent(chainID, g.onStatusChangeFactory(chainID, committer))
+			callback := g.onStatusChangeFactory(chainID, committer)
+			g.electionCallbacks[chainID] = callback
+			g.leaderElection[chainID] = g.newLeaderElectionComponent(chainID, callback)
 		} else if isStaticOrgLeader {
 			logger.Debug("This peer is configured to connect to ordering service for blocks delivery, channel", chainID)
-			g.deliveryService.StartDeliverForChannel(chainID, committer)
+			g.deliveryService.StartDeliverForChannel(chainID, committer, func() {
+				logger.Warning("Delivery of blocks from the ordering service has stopped for channel", chainID,
+					", this peer is a static org leader and won't retry")
+			})
+			g.UpdateLeadership(true, gossipCommon.ChainID(chainID))
 		} else {
 			logger.Debug("This peer is not configured to connect to ordering service for blocks delivery, channel", chainID)
 		}
@@ -255,6 +279,53 @@ func (g *gossipServiceImpl) AddPayload(chainID string, payload *proto.Payload) e
 	return g.chains[chainID].AddPayload(payload)
 }
 
+// PeerIdentity returns the identity of the peer with the given PKI-ID
+func (g *gossipServiceImpl) PeerIdentity(pkiID gossipCommon.PKIidType) (api.PeerIdentityType, error) {
+	return g.idMapper.Get(pkiID)
+}
+
+// SelfIdentity returns the identity of this peer
+func (g *gossipServiceImpl) SelfIdentity() api.PeerIdentityType {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.peerIdentity
+}
+
+// UpdateIdentity replaces the identity of the peer with the given identity,
+// and restarts the leader elections of the channels so that the peer takes
+// part in them with its new identity
+func (g *gossipServiceImpl) UpdateIdentity(identity api.PeerIdentityType) error {
+	if err := g.gossipSvc.UpdateIdentity(identity); err != nil {
+		return err
+	}
+
+	g.lock.Lock()
+	g.peerIdentity = identity
+	elections := make(map[string]election.LeaderElectionService, len(g.leaderElection))
+	callbacks := make(map[string]func(bool), len(g.leaderElection))
+	for chainID, electionService := range g.leaderElection {
+		elections[chainID] = electionService
+		callbacks[chainID] = g.electionCallbacks[chainID]
+	}
+	g.lock.Unlock()
+
+	// The elections are restarted without holding the lock,
+	// as renouncing leadership stops the delivery of blocks
+	for chainID, electionService := range elections {
+		logger.Info("Restarting leader election for", chainID, "with the new identity")
+		callback := callbacks[chainID]
+		isLeader := electionService.IsLeader()
+		electionService.Stop()
+		if isLeader {
+			callback(false)
+		}
+		g.lock.Lock()
+		g.leaderElection[chainID] = g.newLeaderElectionComponent(chainID, callback)
+		g.lock.Unlock()
+	}
+	return nil
+}
+
 // Stop stops the gossip component
 func (g *gossipServiceImpl) Stop() {
 	g.lock.Lock()
@@ -291,9 +362,20 @@ func (g *gossipServiceImpl) amIinChannel(myOrg string, config Config) bool {
 
 func (g *gossipServiceImpl) onStatusChangeFactory(chainID string, committer blocksprovider.LedgerInfo) func(bool) {
 	return func(isLeader bool) {
+		g.UpdateLeadership(isLeader, gossipCommon.ChainID(chainID))
 		if isLeader {
 			logger.Info("Elected as a leader, starting delivery service for channel", chainID)
-			if err := g.deliveryService.StartDeliverForChannel(chainID, committer); err != nil {
+			yield := func() {
+				g.lock.RLock()
+				le := g.leaderElection[chainID]
+				g.lock.RUnlock()
+				if le != nil {
+					logger.Warning("Unable to pull blocks from the ordering service for channel", chainID,
+						", yielding leadership")
+					le.Yield()
+				}
+			}
+			if err := g.deliveryService.StartDeliverForChannel(chainID, committer, yield); err != nil {
 				logger.Error("Delivery service is not able to start blocks delivery for chain, due to", err)
 			}
 		} else {
@@ -26,6 +26,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/config"
 	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -33,6 +34,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/election"
 	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/state"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/msp/mgmt"
@@ -69,7 +71,7 @@ func TestInitGossipService(t *testing.T) {
 			defer wg.Done()
 			messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager())
 			secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
-			err := InitGossipService(identity, "localhost:5611", grpcServer, messageCryptoService,
+			err := InitGossipService(identity, &disabled.Provider{}, "localhost:5611", grpcServer, messageCryptoService,
 				secAdv, nil)
 			assert.NoError(t, err)
 		}()
@@ -149,6 +151,80 @@ func TestLeaderElectionWithDeliverClient(t *testing.T) {
 	stopPeers(gossips)
 }
 
+func TestUpdateIdentityWithLeaderElection(t *testing.T) {
+	// Scenario: 3 peers elect a leader, and then the leader rotates its identity.
+	// The leader election of the peer should be restarted with the new identity,
+	// and eventually a single peer should deliver blocks for the channel
+
+	viper.Set("peer.gossip.useLeaderElection", true)
+	viper.Set("peer.gossip.orgLeader", false)
+
+	n := 3
+	gossips := startPeers(t, n, 20000)
+
+	channelName := "chanA"
+	peerIndexes := make([]int, n)
+	for i := 0; i < n; i++ {
+		peerIndexes[i] = i
+	}
+	addPeersToChannel(t, n, 20000, channelName, gossips, peerIndexes)
+
+	waitForFullMembership(t, gossips, n, time.Second*20, time.Second*2)
+
+	electionServices := func() []*electionService {
+		services := make([]*electionService, n)
+		for i := 0; i < n; i++ {
+			g := gossips[i].(*gossipServiceImpl)
+			g.lock.RLock()
+			services[i] = &electionService{g.leaderElection[channelName], false, 0}
+			g.lock.RUnlock()
+		}
+		return services
+	}
+
+	for i := 0; i < n; i++ {
+		deliverServiceFactory := &mockDeliverServiceFactory{
+			service: &mockDeliverService{
+				running: make(map[string]bool),
+			},
+		}
+		gossips[i].(*gossipServiceImpl).deliveryFactory = deliverServiceFactory
+		deliverServiceFactory.service.running[channelName] = false
+		gossips[i].InitializeChannel(channelName, &mockLedgerInfo{1}, []string{"localhost:5005"})
+	}
+
+	assert.True(t, waitForLeaderElection(t, electionServices(), time.Second*30, time.Second*2), "One leader should be selected")
+
+	leader := -1
+	for i, service := range electionServices() {
+		if service.IsLeader() {
+			leader = i
+		}
+	}
+	assert.NotEqual(t, -1, leader)
+
+	g := gossips[leader].(*gossipServiceImpl)
+	prevElection := g.leaderElection[channelName]
+	newIdentity := api.PeerIdentityType(fmt.Sprintf("localhost:%d-rotated", 20000+leader))
+	assert.NoError(t, g.UpdateIdentity(newIdentity))
+	assert.Equal(t, newIdentity, g.SelfIdentity())
+	g.lock.RLock()
+	assert.True(t, prevElection != g.leaderElection[channelName], "Leader election should have been restarted")
+	g.lock.RUnlock()
+
+	assert.True(t, waitForLeaderElection(t, electionServices(), time.Second*30, time.Second*2), "One leader should be selected")
+
+	startsNum := 0
+	for i := 0; i < n; i++ {
+		if gossips[i].(*gossipServiceImpl).deliveryService.(*mockDeliverService).running[channelName] {
+			startsNum++
+		}
+	}
+	assert.Equal(t, 1, startsNum, "Only for one peer delivery client should start")
+
+	stopPeers(gossips)
+}
+
 func TestWithStaticDeliverClientLeader(t *testing.T) {
 
 	//Tests check if static leader flag works ok.
@@ -286,7 +362,7 @@ type mockDeliverService struct {
 	running map[string]bool
 }
 
-func (ds *mockDeliverService) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo) error {
+func (ds *mockDeliverService) StartDeliverForChannel(chainID string, ledgerInfo blocksprovider.LedgerInfo, finalizer func()) error {
 	ds.running[chainID] = true
 	return nil
 }
@@ -613,15 +689,17 @@ func newGossipInstance(portPrefix int, id int, maxMsgCount int, boot ...int) Gos
 	idMapper := identity.NewIdentityMapper(cryptoService, selfId)
 
 	gossip := gossip.NewGossipServiceWithServer(conf, &orgCryptoService{}, cryptoService,
-		idMapper, selfId, nil)
+		idMapper, selfId, nil, metrics.NewDisabledGossipMetrics())
 
 	gossipService := &gossipServiceImpl{
-		gossipSvc:       gossip,
-		chains:          make(map[string]state.GossipStateProvider),
-		leaderElection:  make(map[string]election.LeaderElectionService),
-		deliveryFactory: &deliveryFactoryImpl{},
-		idMapper:        idMapper,
-		peerIdentity:    api.PeerIdentityType(conf.InternalEndpoint),
+		gossipSvc:         gossip,
+		chains:            make(map[string]state.GossipStateProvider),
+		leaderElection:    make(map[string]election.LeaderElectionService),
+		electionCallbacks: make(map[string]func(bool)),
+		deliveryFactory:   &deliveryFactoryImpl{},
+		idMapper:          idMapper,
+		peerIdentity:      api.PeerIdentityType(conf.InternalEndpoint),
+		metrics:           metrics.NewDisabledGossipMetrics(),
 	}
 
 	return gossipService
@@ -663,6 +741,11 @@ func (*naiveCryptoService) ValidateIdentity(peerIdentity api.PeerIdentityType) e
 	return nil
 }
 
+// Expiration returns the expiration time of the identity
+func (*naiveCryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Now().Add(time.Hour), nil
+}
+
 // GetPKIidOfCert returns the PKI-ID of a peer's identity
 func (*naiveCryptoService) GetPKIidOfCert(peerIdentity api.PeerIdentityType) gossipCommon.PKIidType {
 	return gossipCommon.PKIidType(peerIdentity)
@@ -703,7 +786,7 @@ func TestInvalidInitialization(t *testing.T) {
 	defer grpcServer.Stop()
 
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
-	err := InitGossipService(api.PeerIdentityType("IDENTITY"), "localhost:7611", grpcServer,
+	err := InitGossipService(api.PeerIdentityType("IDENTITY"), &disabled.Provider{}, "localhost:7611", grpcServer,
 		&naiveCryptoService{}, secAdv, nil)
 	assert.NoError(t, err)
 	gService := GetGossipService().(*gossipServiceImpl)
@@ -728,7 +811,7 @@ func TestChannelConfig(t *testing.T) {
 	defer grpcServer.Stop()
 
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
-	error = InitGossipService(api.PeerIdentityType("IDENTITY"), "localhost:6611", grpcServer,
+	error = InitGossipService(api.PeerIdentityType("IDENTITY"), &disabled.Provider{}, "localhost:6611", grpcServer,
 		&naiveCryptoService{}, secAdv, nil)
 	assert.NoError(t, error)
 	gService := GetGossipService().(*gossipServiceImpl)
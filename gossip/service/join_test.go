@@ -52,6 +52,18 @@ func (*gossipMock) SuspectPeers(s api.PeerSuspector) {
 	panic("implement me")
 }
 
+func (*gossipMock) UpdateIdentity(identity api.PeerIdentityType) error {
+	return nil
+}
+
+func (*gossipMock) UpdateExternalEndpoint(endpoint string) error {
+	panic("implement me")
+}
+
+func (*gossipMock) AddAnchorPeers(chainID common.ChainID, org api.OrgIdentityType, anchorPeers []api.AnchorPeer) error {
+	panic("implement me")
+}
+
 func (*gossipMock) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
 	panic("implement me")
 }
@@ -64,6 +76,10 @@ func (*gossipMock) PeersOfChannel(common.ChainID) []discovery.NetworkMember {
 	panic("implement me")
 }
 
+func (*gossipMock) IsInMyOrg(member discovery.NetworkMember) bool {
+	panic("implement me")
+}
+
 func (*gossipMock) UpdateMetadata(metadata []byte) {
 	panic("implement me")
 }
@@ -72,6 +88,17 @@ func (*gossipMock) UpdateChannelMetadata(metadata []byte, chainID common.ChainID
 	panic("implement me")
 }
 
+func (*gossipMock) UpdateLedgerHeight(height uint64, metadata []byte, chainID common.ChainID) {
+	panic("implement me")
+}
+
+func (*gossipMock) UpdateChaincodes(installed []*proto.Chaincode, instantiated []*proto.Chaincode, chainID common.ChainID) {
+	panic("implement me")
+}
+
+func (*gossipMock) UpdateLeadership(isLeader bool, chainID common.ChainID) {
+}
+
 func (*gossipMock) Gossip(msg *proto.GossipMessage) {
 	panic("implement me")
 }
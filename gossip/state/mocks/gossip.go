@@ -33,6 +33,18 @@ func (*GossipMock) SuspectPeers(s api.PeerSuspector) {
 	panic("implement me")
 }
 
+func (*GossipMock) UpdateIdentity(identity api.PeerIdentityType) error {
+	return nil
+}
+
+func (*GossipMock) UpdateExternalEndpoint(endpoint string) error {
+	return nil
+}
+
+func (*GossipMock) AddAnchorPeers(chainID common.ChainID, org api.OrgIdentityType, anchorPeers []api.AnchorPeer) error {
+	return nil
+}
+
 func (*GossipMock) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
 	panic("implement me")
 }
@@ -45,6 +57,10 @@ func (*GossipMock) PeersOfChannel(common.ChainID) []discovery.NetworkMember {
 	return nil
 }
 
+func (*GossipMock) IsInMyOrg(member discovery.NetworkMember) bool {
+	return true
+}
+
 func (*GossipMock) UpdateMetadata(metadata []byte) {
 	panic("implement me")
 }
@@ -53,6 +69,18 @@ func (*GossipMock) UpdateChannelMetadata(metadata []byte, chainID common.ChainID
 
 }
 
+func (*GossipMock) UpdateLedgerHeight(height uint64, metadata []byte, chainID common.ChainID) {
+
+}
+
+func (*GossipMock) UpdateChaincodes(installed []*proto.Chaincode, instantiated []*proto.Chaincode, chainID common.ChainID) {
+	panic("implement me")
+}
+
+func (*GossipMock) UpdateLeadership(isLeader bool, chainID common.ChainID) {
+	panic("implement me")
+}
+
 func (*GossipMock) Gossip(msg *proto.GossipMessage) {
 	panic("implement me")
 }
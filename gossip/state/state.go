@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/comm"
 	common2 "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/protos/common"
 	proto "github.com/hyperledger/fabric/protos/gossip"
@@ -53,10 +54,62 @@ const (
 	defAntiEntropyStateResponseTimeout = 3 * time.Second
 	defAntiEntropyBatchSize            = 10
 
-	defChannelBufferSize     = 100
-	defAntiEntropyMaxRetries = 3
+	defChannelBufferSize      = 100
+	defAntiEntropyMaxRetries  = 3
+	defAntiEntropyMaxInFlight = 3
+
+	defCrossOrgPullThreshold = time.Minute
 )
 
+// stateConfig holds the parameters of state transfer, read from
+// the peer.gossip.state section of the configuration
+type stateConfig struct {
+	// AntiEntropyInterval is the time between checks of whether
+	// the peer lags behind the other peers of the channel
+	AntiEntropyInterval time.Duration
+	// ResponseTimeout is the time to wait for the response to a
+	// state request before asking another peer for the blocks
+	ResponseTimeout time.Duration
+	// BatchSize is the number of blocks requested in a single state request
+	BatchSize uint64
+	// MaxRetries is the number of times a range of blocks is requested,
+	// or fails to be, before state transfer is abandoned until the next check
+	MaxRetries int
+	// MaxInFlight is the number of state requests awaiting a response at once
+	MaxInFlight int
+	// PreferOwnOrg determines whether blocks are pulled only from peers of
+	// our organization, as long as the peer makes progress. Otherwise blocks
+	// are pulled from peers of any organization of the channel
+	PreferOwnOrg bool
+	// CrossOrgPullThreshold is the time the ledger of a lagging peer doesn't
+	// grow before blocks are pulled from other organizations, if PreferOwnOrg
+	CrossOrgPullThreshold time.Duration
+}
+
+func readStateConfig() *stateConfig {
+	return &stateConfig{
+		AntiEntropyInterval: util.GetDurationOrDefault("peer.gossip.state.checkInterval", defAntiEntropyInterval),
+		ResponseTimeout:     util.GetDurationOrDefault("peer.gossip.state.responseTimeout", defAntiEntropyStateResponseTimeout),
+		BatchSize:           uint64(positiveIntOrDefault("peer.gossip.state.batchSize", defAntiEntropyBatchSize)),
+		MaxRetries:          positiveIntOrDefault("peer.gossip.state.maxRetries", defAntiEntropyMaxRetries),
+		MaxInFlight:         positiveIntOrDefault("peer.gossip.state.maxInFlightRequests", defAntiEntropyMaxInFlight),
+		PreferOwnOrg:        util.GetBoolOrDefault("peer.gossip.state.crossOrgPull.preferOwnOrg", false),
+		CrossOrgPullThreshold: util.GetDurationOrDefault("peer.gossip.state.crossOrgPull.threshold",
+			defCrossOrgPullThreshold),
+	}
+}
+
+// positiveIntOrDefault returns the integer configured under key, or defVal
+// if it isn't configured or isn't positive
+func positiveIntOrDefault(key string, defVal int) int {
+	val := util.GetIntOrDefault(key, defVal)
+	if val <= 0 {
+		logger.Warningf("Invalid value %d for %s, using the default %d instead", val, key, defVal)
+		return defVal
+	}
+	return val
+}
+
 // GossipAdapter defines gossip/communication required interface for state provider
 type GossipAdapter interface {
 	// Send sends a message to remote peers
@@ -72,9 +125,18 @@ type GossipAdapter interface {
 	// publishes to other peers about its channel-related state
 	UpdateChannelMetadata(metadata []byte, chainID common2.ChainID)
 
+	// UpdateLedgerHeight updates the ledger height the peer publishes to
+	// other peers in the channel, along with the channel metadata, which
+	// encodes it for peers that do not read it from the properties
+	UpdateLedgerHeight(height uint64, metadata []byte, chainID common2.ChainID)
+
 	// PeersOfChannel returns the NetworkMembers considered alive
 	// and also subscribed to the channel given
 	PeersOfChannel(common2.ChainID) []discovery.NetworkMember
+
+	// IsInMyOrg returns whether the given network member
+	// is in the same organization as the peer
+	IsInMyOrg(member discovery.NetworkMember) bool
 }
 
 // GossipStateProviderImpl the implementation of the GossipStateProvider interface
@@ -111,6 +173,14 @@ type GossipStateProviderImpl struct {
 	once sync.Once
 
 	stateTransferActive int32
+
+	config *stateConfig
+
+	// crossOrgPull is whether blocks are currently pulled from
+	// peers of other organizations too, when PreferOwnOrg
+	crossOrgPull bool
+
+	stateMetrics *metrics.StateMetrics
 }
 
 var logger *logging.Logger // package-level logger
@@ -120,9 +190,14 @@ func init() {
 }
 
 // NewGossipStateProvider creates initialized instance of gossip state provider
-func NewGossipStateProvider(chainID string, g GossipAdapter, committer committer.Committer, mcs api.MessageCryptoService) GossipStateProvider {
+func NewGossipStateProvider(chainID string, g GossipAdapter, committer committer.Committer, mcs api.MessageCryptoService,
+	stateMetrics *metrics.StateMetrics) GossipStateProvider {
 	logger := util.GetLogger(util.LoggingStateModule, "")
 
+	if stateMetrics == nil {
+		stateMetrics = metrics.NewDisabledGossipMetrics().StateMetrics
+	}
+
 	gossipChan, _ := g.Accept(func(message interface{}) bool {
 		// Get only data messages
 		return message.(*proto.GossipMessage).IsDataMsg() &&
@@ -195,7 +270,11 @@ func NewGossipStateProvider(chainID string, g GossipAdapter, committer committer
 
 		stateTransferActive: 0,
 
+		config: readStateConfig(),
+
 		once: sync.Once{},
+
+		stateMetrics: stateMetrics,
 	}
 
 	nodeMetastate := NewNodeMetastate(height - 1)
@@ -206,10 +285,11 @@ func NewGossipStateProvider(chainID string, g GossipAdapter, committer committer
 	b, err := nodeMetastate.Bytes()
 	if err == nil {
 		logger.Debug("Updating gossip metadate nodeMetastate", nodeMetastate)
-		g.UpdateChannelMetadata(b, common2.ChainID(s.chainID))
+		g.UpdateLedgerHeight(height, b, common2.ChainID(s.chainID))
 	} else {
 		logger.Errorf("Unable to serialize node meta nodeMetastate, error = %s", err)
 	}
+	s.stateMetrics.Height.With(s.chainID).Set(float64(height))
 
 	s.done.Add(4)
 
@@ -300,9 +380,9 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 	request := msg.GetGossipMessage().GetStateRequest()
 
 	batchSize := request.EndSeqNum - request.StartSeqNum
-	if batchSize > defAntiEntropyBatchSize {
+	if batchSize > s.config.BatchSize {
 		logger.Errorf("Requesting blocks batchSize size (%d) greater than configured allowed"+
-			" (%d) batching for anti-entropy. Ignoring request...", batchSize, defAntiEntropyBatchSize)
+			" (%d) batching for anti-entropy. Ignoring request...", batchSize, s.config.BatchSize)
 		return
 	}
 
@@ -408,6 +488,7 @@ func (s *GossipStateProviderImpl) queueNewMessage(msg *proto.GossipMessage) {
 
 		logger.Debugf("Received new payload with sequence number = [%d]", dataMsg.Payload.SeqNum)
 		s.payloads.Push(dataMsg.GetPayload())
+		s.stateMetrics.PayloadBufferSize.With(s.chainID).Set(float64(s.payloads.Size()))
 	} else {
 		logger.Debug("Gossip message received is not of data message type, usually this should not happen.")
 	}
@@ -436,6 +517,7 @@ func (s *GossipStateProviderImpl) deliverPayloads() {
 				logger.Debug("New block with claimed sequence number ", payload.SeqNum, " transactions num ", len(rawBlock.Data.Data))
 				s.commitBlock(rawBlock)
 			}
+			s.stateMetrics.PayloadBufferSize.With(s.chainID).Set(float64(s.payloads.Size()))
 		case <-s.stopCh:
 			s.stopCh <- struct{}{}
 			logger.Debug("State provider has been stoped, finishing to push new blocks.")
@@ -448,12 +530,16 @@ func (s *GossipStateProviderImpl) antiEntropy() {
 	defer s.done.Done()
 	defer logger.Debug("State Provider stopped, stopping anti entropy procedure.")
 
+	// the last ledger height observed, and when the ledger last grew
+	var lastHeight uint64
+	lastProgress := time.Now()
+
 	for {
 		select {
 		case <-s.stopCh:
 			s.stopCh <- struct{}{}
 			return
-		case <-time.After(defAntiEntropyInterval):
+		case <-time.After(s.config.AntiEntropyInterval):
 			current, err := s.committer.LedgerHeight()
 			if err != nil {
 				// Unable to read from ledger continue to the next round
@@ -464,87 +550,227 @@ func (s *GossipStateProviderImpl) antiEntropy() {
 				logger.Error("Ledger reported block height of 0 but this should be impossible")
 				continue
 			}
-			max := s.maxAvailableLedgerHeight()
+			if current > lastHeight {
+				lastHeight = current
+				lastProgress = time.Now()
+			}
+			max := s.maxAvailableLedgerHeight(func(discovery.NetworkMember) bool { return true })
 
 			if current-1 >= max {
+				s.stateMetrics.Lag.With(s.chainID).Set(0)
+				lastProgress = time.Now()
 				continue
 			}
+			s.stateMetrics.Lag.With(s.chainID).Set(float64(max - (current - 1)))
+
+			if s.config.PreferOwnOrg {
+				ownOrgAhead := current-1 < s.maxAvailableLedgerHeight(s.gossip.IsInMyOrg)
+				s.setCrossOrgPull(time.Since(lastProgress), ownOrgAhead)
+				max = s.maxAvailableLedgerHeight(s.isEligibleForPull)
+				if current-1 >= max {
+					logger.Debugf("None of the peers eligible for state transfer has blocks "+
+						"beyond %d, for chainID %s", current-1, s.chainID)
+					continue
+				}
+			}
 
 			s.requestBlocksInRange(uint64(current), uint64(max))
 		}
 	}
 }
 
-// Iterate over all available peers and check advertised meta state to
-// find maximum available ledger height across peers
-func (s *GossipStateProviderImpl) maxAvailableLedgerHeight() uint64 {
+// setCrossOrgPull determines whether blocks are pulled from peers of other
+// organizations, given the time the ledger of the lagging peer didn't grow
+// and whether a peer of our organization has blocks we don't.
+// This allows an organization whose peers all lost connectivity to the
+// ordering service to catch up from the rest of the channel, and keep doing
+// so until the organization gets ahead on its own. The blocks are verified
+// via the MessageCryptoService regardless of their source
+func (s *GossipStateProviderImpl) setCrossOrgPull(stalledFor time.Duration, ownOrgAhead bool) {
+	crossOrgPull := stalledFor > s.config.CrossOrgPullThreshold || (s.crossOrgPull && !ownOrgAhead)
+	if crossOrgPull && !s.crossOrgPull {
+		logger.Warningf("Ledger didn't grow for %v, pulling blocks from peers of "+
+			"other organizations too, for chainID %s", stalledFor, s.chainID)
+	}
+	if !crossOrgPull && s.crossOrgPull {
+		logger.Infof("Pulling blocks only from peers of our organization, for chainID %s", s.chainID)
+	}
+	s.crossOrgPull = crossOrgPull
+}
+
+// isEligibleForPull returns whether blocks can be pulled from the given peer.
+// All peers are eligible unless our organization is preferred, in which case
+// peers of other organizations are eligible only while cross-org pull is active
+func (s *GossipStateProviderImpl) isEligibleForPull(peer discovery.NetworkMember) bool {
+	return !s.config.PreferOwnOrg || s.crossOrgPull || s.gossip.IsInMyOrg(peer)
+}
+
+// Iterate over the available peers which match the given predicate and check
+// advertised meta state to find maximum available ledger height across them
+func (s *GossipStateProviderImpl) maxAvailableLedgerHeight(predicate func(peer discovery.NetworkMember) bool) uint64 {
 	max := uint64(0)
 	for _, p := range s.gossip.PeersOfChannel(common2.ChainID(s.chainID)) {
-		if nodeMetastate, err := FromBytes(p.Metadata); err == nil {
-			if max < nodeMetastate.LedgerHeight {
-				max = nodeMetastate.LedgerHeight
+		if !predicate(p) {
+			continue
+		}
+		if seq, err := lastBlockSeq(p); err == nil {
+			if max < seq {
+				max = seq
 			}
 		}
 	}
 	return max
 }
 
-// GetBlocksInRange capable to acquire blocks with sequence
-// numbers in the range [start...end].
+// lastBlockSeq returns the sequence number of the last block the peer
+// advertised it committed, taken from its properties if it published them
+func lastBlockSeq(peer discovery.NetworkMember) (uint64, error) {
+	if peer.Properties != nil && peer.Properties.LedgerHeight > 0 {
+		return peer.Properties.LedgerHeight - 1, nil
+	}
+	nodeMetastate, err := FromBytes(peer.Metadata)
+	if err != nil {
+		return 0, err
+	}
+	return nodeMetastate.LedgerHeight, nil
+}
+
+// rangeRequest is a request for the blocks in the range [start...end]
+type rangeRequest struct {
+	start    uint64
+	end      uint64
+	nonce    uint64
+	attempts int
+	deadline time.Time
+	// the peers already asked for the range, by PKI-ID
+	tried map[string]struct{}
+}
+
+// requestBlocksInRange acquires the blocks with sequence numbers in the
+// range [start...end]. The range is split into batches which are requested
+// from peers advertising they have them, with up to MaxInFlight requests
+// awaiting a response at once. A batch whose response does not arrive in
+// time is requested again from another peer.
 func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
 	atomic.StoreInt32(&s.stateTransferActive, 1)
 	defer atomic.StoreInt32(&s.stateTransferActive, 0)
 
-	for prev := start; prev <= end; {
-		next := min(end, prev+defAntiEntropyBatchSize)
+	var pending []*rangeRequest
+	for prev := start; prev <= end; prev += s.config.BatchSize {
+		pending = append(pending, &rangeRequest{
+			start: prev,
+			end:   min(end, prev+s.config.BatchSize-1),
+			tried: make(map[string]struct{}),
+		})
+	}
+	inFlight := make(map[uint64]*rangeRequest)
 
-		gossipMsg := s.stateRequestMessage(prev, next)
+	// retry schedules the request to be sent again, unless it ran out of attempts
+	retry := func(req *rangeRequest) bool {
+		if req.attempts >= s.config.MaxRetries {
+			logger.Warningf("Wasn't able to get blocks in range [%d...%d], after %d retries",
+				req.start, req.end, req.attempts)
+			return false
+		}
+		pending = append([]*rangeRequest{req}, pending...)
+		return true
+	}
 
-		responseReceived := false
-		tryCounts := 0
+	for len(pending) > 0 || len(inFlight) > 0 {
+		for len(inFlight) < s.config.MaxInFlight && len(pending) > 0 {
+			req := pending[0]
+			pending = pending[1:]
+			if err := s.sendRangeRequest(req); err != nil {
+				logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to %s",
+					req.start, req.end, err)
+				if !retry(req) {
+					return
+				}
+				// give peers time to catch up before sending it again
+				break
+			}
+			inFlight[req.nonce] = req
+		}
 
-		for !responseReceived {
-			if tryCounts > defAntiEntropyMaxRetries {
-				logger.Warningf("Wasn't  able to get blocks in range [%d...%d], after %d retries",
-					prev, next, tryCounts)
-				return
+		select {
+		case msg := <-s.stateResponseCh:
+			req, exists := inFlight[msg.GetGossipMessage().Nonce]
+			if !exists {
+				continue
 			}
-			// Select peers to ask for blocks
-			peer, err := s.selectPeerToRequestFrom(next)
+			delete(inFlight, req.nonce)
+			index, err := s.handleStateResponse(msg)
 			if err != nil {
-				logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to",
-					prev, next, err)
-				return
+				logger.Warningf("Wasn't able to process state response for "+
+					"blocks [%d...%d], due to %s", req.start, req.end, err)
+				if !retry(req) {
+					return
+				}
+				continue
 			}
-
-			logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
-				"for chainID %s", peer.Endpoint, prev, next, s.chainID)
-
-			s.gossip.Send(gossipMsg, peer)
-			tryCounts++
-
-			// Wait until timeout or response arrival
-			select {
-			case msg := <-s.stateResponseCh:
-				if msg.GetGossipMessage().Nonce != gossipMsg.Nonce {
-					continue
+			// the peer might have sent only part of the range, the rest
+			// is requested anew, which counts as another attempt
+			if index < req.end {
+				if index >= req.start {
+					req.start = index + 1
 				}
-				// Got corresponding response for state request, can continue
-				index, err := s.handleStateResponse(msg)
-				if err != nil {
-					logger.Warningf("Wasn't able to process state response for "+
-						"blocks [%d...%d], due to %s", prev, next, err)
+				if !retry(req) {
+					return
+				}
+			}
+		case <-time.After(s.nextDeadline(inFlight)):
+			now := time.Now()
+			for nonce, req := range inFlight {
+				if now.Before(req.deadline) {
 					continue
 				}
-				prev = index + 1
-				responseReceived = true
-			case <-time.After(defAntiEntropyStateResponseTimeout):
-			case <-s.stopCh:
-				s.stopCh <- struct{}{}
-				return
+				logger.Debugf("State request for blocks in range [%d...%d] timed out", req.start, req.end)
+				delete(inFlight, nonce)
+				if !retry(req) {
+					return
+				}
 			}
+		case <-s.stopCh:
+			s.stopCh <- struct{}{}
+			return
+		}
+	}
+}
+
+// sendRangeRequest sends the request to a peer which has the blocks
+// of the range, preferring peers which were not asked for it yet
+func (s *GossipStateProviderImpl) sendRangeRequest(req *rangeRequest) error {
+	req.attempts++
+	peer, err := s.selectPeerToRequestFrom(req.end, req.tried)
+	if err != nil {
+		return err
+	}
+
+	gossipMsg := s.stateRequestMessage(req.start, req.end)
+	req.nonce = gossipMsg.Nonce
+	req.deadline = time.Now().Add(s.config.ResponseTimeout)
+	req.tried[string(peer.PKIID)] = struct{}{}
+
+	logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
+		"for chainID %s", peer.Endpoint, req.start, req.end, s.chainID)
+
+	s.gossip.Send(gossipMsg, peer)
+	return nil
+}
+
+// nextDeadline returns the time until the earliest
+// deadline of the requests awaiting a response
+func (s *GossipStateProviderImpl) nextDeadline(inFlight map[uint64]*rangeRequest) time.Duration {
+	next := s.config.ResponseTimeout
+	for _, req := range inFlight {
+		if d := req.deadline.Sub(time.Now()); d < next {
+			next = d
 		}
 	}
+	if next < 0 {
+		return 0
+	}
+	return next
 }
 
 // Generate state request message for given blocks in range [beginSeq...endSeq]
@@ -562,16 +788,31 @@ func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq ui
 	}
 }
 
-// Select peer which has required blocks to ask missing blocks from
-func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64) (*comm.RemotePeer, error) {
+// Select peer which has required blocks to ask missing blocks from,
+// among the peers not excluded if there are such peers
+func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64, excluded map[string]struct{}) (*comm.RemotePeer, error) {
 	// Filter peers which posses required range of missing blocks
-	peers := s.filterPeers(s.hasRequiredHeight(height))
+	peers := s.filterPeers(func(peer discovery.NetworkMember) bool {
+		return s.isEligibleForPull(peer) && s.hasRequiredHeight(height)(peer)
+	})
 
 	n := len(peers)
 	if n == 0 {
 		return nil, errors.New("there are no peers to ask for missing blocks from")
 	}
 
+	var candidates []*comm.RemotePeer
+	for _, p := range peers {
+		if _, isExcluded := excluded[string(p.PKIID)]; !isExcluded {
+			candidates = append(candidates, p)
+		}
+	}
+	// once all peers were excluded, any of them can be asked again
+	if len(candidates) > 0 {
+		peers = candidates
+		n = len(candidates)
+	}
+
 	// Select peers to ask for blocks
 	return peers[util.RandomInt(n)], nil
 }
@@ -593,9 +834,9 @@ func (s *GossipStateProviderImpl) filterPeers(predicate func(peer discovery.Netw
 // by provided input parameter
 func (s *GossipStateProviderImpl) hasRequiredHeight(height uint64) func(peer discovery.NetworkMember) bool {
 	return func(peer discovery.NetworkMember) bool {
-		if nodeMetadata, err := FromBytes(peer.Metadata); err != nil {
+		if seq, err := lastBlockSeq(peer); err != nil {
 			logger.Errorf("Unable to de-serialize node meta state, error = %s", err)
-		} else if nodeMetadata.LedgerHeight >= height {
+		} else if seq >= height {
 			return true
 		}
 
@@ -618,7 +859,9 @@ func (s *GossipStateProviderImpl) GetBlock(index uint64) *common.Block {
 func (s *GossipStateProviderImpl) AddPayload(payload *proto.Payload) error {
 
 	logger.Debug("Adding new payload into the buffer, seqNum = ", payload.SeqNum)
-	return s.payloads.Push(payload)
+	err := s.payloads.Push(payload)
+	s.stateMetrics.PayloadBufferSize.With(s.chainID).Set(float64(s.payloads.Size()))
+	return err
 }
 
 func (s *GossipStateProviderImpl) commitBlock(block *common.Block) error {
@@ -627,16 +870,18 @@ func (s *GossipStateProviderImpl) commitBlock(block *common.Block) error {
 		return err
 	}
 
-	// Update ledger level within node metadata
+	// Update ledger level within node metadata too, for peers
+	// which do not read the ledger height from the properties
 	nodeMetastate := NewNodeMetastate(block.Header.Number)
 	// Decode nodeMetastate to byte array
 	b, err := nodeMetastate.Bytes()
 	if err == nil {
-		s.gossip.UpdateChannelMetadata(b, common2.ChainID(s.chainID))
+		s.gossip.UpdateLedgerHeight(block.Header.Number+1, b, common2.ChainID(s.chainID))
 	} else {
 
 		logger.Errorf("Unable to serialize node meta nodeMetastate, error = %s", err)
 	}
+	s.stateMetrics.Height.With(s.chainID).Set(float64(block.Header.Number + 1))
 
 	logger.Debugf("Channel [%s]: Created block [%d] with %d transaction(s)",
 		s.chainID, block.Header.Number, len(block.Data.Data))
@@ -27,6 +27,7 @@ import (
 
 	pb "github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/metrics/prometheus"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/committer"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
@@ -34,8 +35,10 @@ import (
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/state/mocks"
 	gutil "github.com/hyperledger/fabric/gossip/util"
 	pcomm "github.com/hyperledger/fabric/protos/common"
@@ -141,6 +144,11 @@ func (*cryptoServiceMock) ValidateIdentity(peerIdentity api.PeerIdentityType) er
 	return nil
 }
 
+// Expiration returns the expiration time of the identity
+func (*cryptoServiceMock) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	return time.Now().Add(time.Hour), nil
+}
+
 func bootPeers(ids ...int) []string {
 	peers := []string{}
 	for _, id := range ids {
@@ -217,7 +225,7 @@ func newGossipInstance(config *gossip.Config, mcs api.MessageCryptoService) goss
 	id := api.PeerIdentityType(config.InternalEndpoint)
 	idMapper := identity.NewIdentityMapper(mcs, id)
 	return gossip.NewGossipServiceWithServer(config, &orgCryptoService{}, mcs,
-		idMapper, id, nil)
+		idMapper, id, nil, metrics.NewDisabledGossipMetrics())
 }
 
 // Create new instance of KVLedger to be used for testing
@@ -241,7 +249,8 @@ func newPeerNodeWithGossip(config *gossip.Config, committer committer.Committer,
 	// Initialize pseudo peer simulator, which has only three
 	// basic parts
 
-	sp := NewGossipStateProvider(util.GetTestChainID(), g, committer, cs)
+	// nil metrics are disabled
+	sp := NewGossipStateProvider(util.GetTestChainID(), g, committer, cs, nil)
 	if sp == nil {
 		return nil
 	}
@@ -260,6 +269,39 @@ func newPeerNode(config *gossip.Config, committer committer.Committer, acceptor
 	return newPeerNodeWithGossip(config, committer, acceptor, nil)
 }
 
+func TestStateMetrics(t *testing.T) {
+	// Scenario: create a state provider of a ledger with only the genesis block,
+	// and add a payload of a block that isn't the next one.
+	// The ledger height and the payloads buffer size are expected to be reported
+	mc := &mockCommitter{}
+	mc.On("LedgerHeight", mock.Anything).Return(uint64(1), nil)
+	g := &mocks.GossipMock{}
+	g.On("Accept", mock.Anything, false).Return(make(<-chan *proto.GossipMessage), nil)
+	g.On("Accept", mock.Anything, true).Return(nil, make(<-chan proto.ReceivedMessage))
+	provider := prometheus.NewProvider()
+	s := NewGossipStateProvider(util.GetTestChainID(), g, mc, &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor},
+		metrics.NewStateMetrics(provider))
+	defer s.Stop()
+
+	s.AddPayload(&proto.Payload{SeqNum: 5, Data: []byte{}})
+	out := &bytes.Buffer{}
+	provider.WriteTo(out)
+	assert.Contains(t, out.String(), fmt.Sprintf(`gossip_state_height{channel="%s"} 1`, util.GetTestChainID()))
+	assert.Contains(t, out.String(), fmt.Sprintf(`gossip_state_payload_buffer_size{channel="%s"} 1`, util.GetTestChainID()))
+}
+
+func TestReadStateConfig(t *testing.T) {
+	viper.Set("peer.gossip.state.batchSize", -1)
+	viper.Set("peer.gossip.state.maxRetries", 5)
+	viper.Set("peer.gossip.state.maxInFlightRequests", -2)
+	defer viper.Set("peer.gossip.state", nil)
+
+	config := readStateConfig()
+	assert.Equal(t, uint64(defAntiEntropyBatchSize), config.BatchSize)
+	assert.Equal(t, 5, config.MaxRetries)
+	assert.Equal(t, defAntiEntropyMaxInFlight, config.MaxInFlight)
+}
+
 func TestNilDirectMsg(t *testing.T) {
 	mc := &mockCommitter{}
 	mc.On("LedgerHeight", mock.Anything).Return(uint64(1), nil)
@@ -789,3 +831,247 @@ func waitUntilTrueOrTimeout(t *testing.T, predicate func() bool, timeout time.Du
 	}
 	logger.Debug("Stop waiting until timeout or true")
 }
+
+type stateTransferGossipMock struct {
+	peers    []discovery.NetworkMember
+	requests chan *sentStateRequest
+	// PKI-IDs of the peers of other organizations
+	otherOrgPeers map[string]struct{}
+}
+
+type sentStateRequest struct {
+	msg  *proto.GossipMessage
+	peer *comm.RemotePeer
+}
+
+func (g *stateTransferGossipMock) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
+	g.requests <- &sentStateRequest{msg: msg, peer: peers[0]}
+}
+
+func (g *stateTransferGossipMock) Accept(acceptor common.MessageAcceptor, passThrough bool) (<-chan *proto.GossipMessage, <-chan proto.ReceivedMessage) {
+	return nil, nil
+}
+
+func (g *stateTransferGossipMock) UpdateChannelMetadata(metadata []byte, chainID common.ChainID) {
+}
+
+func (g *stateTransferGossipMock) UpdateLedgerHeight(height uint64, metadata []byte, chainID common.ChainID) {
+}
+
+func (g *stateTransferGossipMock) PeersOfChannel(common.ChainID) []discovery.NetworkMember {
+	return g.peers
+}
+
+func (g *stateTransferGossipMock) IsInMyOrg(member discovery.NetworkMember) bool {
+	_, isInOtherOrg := g.otherOrgPeers[string(member.PKIid)]
+	return !isInOtherOrg
+}
+
+type stateResponseMsg struct {
+	msg *proto.SignedGossipMessage
+}
+
+func (m *stateResponseMsg) Respond(msg *proto.GossipMessage) {
+}
+
+func (m *stateResponseMsg) GetGossipMessage() *proto.SignedGossipMessage {
+	return m.msg
+}
+
+func (m *stateResponseMsg) GetSourceEnvelope() *proto.Envelope {
+	return nil
+}
+
+func (m *stateResponseMsg) GetConnectionInfo() *proto.ConnectionInfo {
+	return nil
+}
+
+func TestRequestBlocksInRange(t *testing.T) {
+	// Scenario: blocks [1...20] are requested in batches of 5 with 2 requests
+	// in flight at once. Of the 3 peers of the channel, one lags behind and is
+	// never asked, one never answers, and one answers. All blocks should
+	// eventually be fetched from the peer which answers.
+	peerWithHeight := func(id string, height uint64) discovery.NetworkMember {
+		return discovery.NetworkMember{
+			Endpoint:   id,
+			PKIid:      common.PKIidType(id),
+			Properties: &proto.Properties{LedgerHeight: height},
+		}
+	}
+	g := &stateTransferGossipMock{
+		peers: []discovery.NetworkMember{
+			peerWithHeight("lagging", 3),
+			peerWithHeight("unresponsive", 21),
+			peerWithHeight("responsive", 21),
+		},
+		requests: make(chan *sentStateRequest, 100),
+	}
+	s := &GossipStateProviderImpl{
+		chainID:         util.GetTestChainID(),
+		gossip:          g,
+		mcs:             &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor},
+		payloads:        NewPayloadsBuffer(1),
+		stateResponseCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
+		stopCh:          make(chan struct{}, 1),
+		config: &stateConfig{
+			ResponseTimeout: 100 * time.Millisecond,
+			BatchSize:       5,
+			MaxRetries:      3,
+			MaxInFlight:     2,
+		},
+	}
+
+	var lock sync.Mutex
+	requestsByPeer := make(map[string]int)
+	go func() {
+		for req := range g.requests {
+			assert.NotEqual(t, "lagging", req.peer.Endpoint)
+			stateReq := req.msg.GetStateRequest()
+			assert.True(t, stateReq.EndSeqNum-stateReq.StartSeqNum < 5)
+			lock.Lock()
+			requestsByPeer[req.peer.Endpoint]++
+			lock.Unlock()
+			if req.peer.Endpoint == "unresponsive" {
+				continue
+			}
+			response := &proto.RemoteStateResponse{}
+			for seq := stateReq.StartSeqNum; seq <= stateReq.EndSeqNum; seq++ {
+				response.Payloads = append(response.Payloads, &proto.Payload{SeqNum: seq, Data: []byte{}})
+			}
+			msg, _ := (&proto.GossipMessage{
+				Nonce:   req.msg.Nonce,
+				Content: &proto.GossipMessage_StateResponse{StateResponse: response},
+			}).NoopSign()
+			s.stateResponseCh <- &stateResponseMsg{msg: msg}
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		s.requestBlocksInRange(1, 20)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Didn't fetch the blocks in time")
+	}
+	close(g.requests)
+
+	assert.Equal(t, 20, s.payloads.Size())
+	lock.Lock()
+	defer lock.Unlock()
+	// each of the 4 batches was fetched from the responsive peer,
+	// including those first requested from the unresponsive one
+	assert.Equal(t, 4, requestsByPeer["responsive"])
+}
+
+func TestStateTransferRetries(t *testing.T) {
+	// Scenario: the range of blocks is requested MaxRetries times at most,
+	// whether requests go unanswered, get partial responses or cannot be
+	// sent at all because no peer has the blocks.
+	newProvider := func(g *stateTransferGossipMock) *GossipStateProviderImpl {
+		return &GossipStateProviderImpl{
+			chainID:         util.GetTestChainID(),
+			gossip:          g,
+			mcs:             &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor},
+			payloads:        NewPayloadsBuffer(1),
+			stateResponseCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
+			stopCh:          make(chan struct{}, 1),
+			config: &stateConfig{
+				ResponseTimeout: 50 * time.Millisecond,
+				BatchSize:       10,
+				MaxRetries:      3,
+				MaxInFlight:     1,
+			},
+		}
+	}
+	peer := discovery.NetworkMember{
+		Endpoint:   "peer",
+		PKIid:      common.PKIidType("peer"),
+		Properties: &proto.Properties{LedgerHeight: 21},
+	}
+
+	// unanswered requests
+	g := &stateTransferGossipMock{peers: []discovery.NetworkMember{peer}, requests: make(chan *sentStateRequest, 100)}
+	newProvider(g).requestBlocksInRange(1, 10)
+	assert.Len(t, g.requests, 3)
+
+	// each response only has the first block of the range asked for
+	g = &stateTransferGossipMock{peers: []discovery.NetworkMember{peer}, requests: make(chan *sentStateRequest, 100)}
+	s := newProvider(g)
+	go func() {
+		for req := range g.requests {
+			start := req.msg.GetStateRequest().StartSeqNum
+			msg, _ := (&proto.GossipMessage{
+				Nonce: req.msg.Nonce,
+				Content: &proto.GossipMessage_StateResponse{StateResponse: &proto.RemoteStateResponse{
+					Payloads: []*proto.Payload{{SeqNum: start, Data: []byte{}}},
+				}},
+			}).NoopSign()
+			s.stateResponseCh <- &stateResponseMsg{msg: msg}
+		}
+	}()
+	s.requestBlocksInRange(1, 10)
+	close(g.requests)
+	assert.Equal(t, 3, s.payloads.Size())
+
+	// no peer has the blocks, requests are retried after a while
+	g = &stateTransferGossipMock{requests: make(chan *sentStateRequest, 100)}
+	before := time.Now()
+	newProvider(g).requestBlocksInRange(1, 10)
+	assert.True(t, time.Since(before) >= 100*time.Millisecond, "send failures should be retried after the response timeout")
+	assert.Len(t, g.requests, 0)
+}
+
+func TestCrossOrgPull(t *testing.T) {
+	// Scenario: the peer of our organization lags behind as well, and only
+	// the peer of the other organization has the missing blocks. When our
+	// organization is preferred, blocks are pulled from the other one only
+	// once the ledger didn't grow for longer than the threshold, and until
+	// the peer of our organization has blocks we don't.
+	g := &stateTransferGossipMock{
+		peers: []discovery.NetworkMember{
+			{Endpoint: "local", PKIid: common.PKIidType("local"), Properties: &proto.Properties{LedgerHeight: 5}},
+			{Endpoint: "foreign", PKIid: common.PKIidType("foreign"), Properties: &proto.Properties{LedgerHeight: 21}},
+		},
+		otherOrgPeers: map[string]struct{}{"foreign": {}},
+	}
+	s := &GossipStateProviderImpl{
+		chainID: util.GetTestChainID(),
+		gossip:  g,
+		config: &stateConfig{
+			PreferOwnOrg:          true,
+			CrossOrgPullThreshold: time.Minute,
+		},
+	}
+
+	s.setCrossOrgPull(time.Second, false)
+	assert.Equal(t, uint64(4), s.maxAvailableLedgerHeight(s.isEligibleForPull))
+	_, err := s.selectPeerToRequestFrom(10, nil)
+	assert.Error(t, err)
+
+	s.setCrossOrgPull(2*time.Minute, false)
+	assert.Equal(t, uint64(20), s.maxAvailableLedgerHeight(s.isEligibleForPull))
+	peer, err := s.selectPeerToRequestFrom(10, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "foreign", peer.Endpoint)
+
+	// The ledger grows from the blocks of the other organization,
+	// which keep being pulled as our organization has none we don't
+	s.setCrossOrgPull(0, false)
+	assert.Equal(t, uint64(20), s.maxAvailableLedgerHeight(s.isEligibleForPull))
+
+	// Once a peer of our organization is ahead, blocks are pulled only from it again
+	s.setCrossOrgPull(0, true)
+	assert.Equal(t, uint64(4), s.maxAvailableLedgerHeight(s.isEligibleForPull))
+
+	// Without preferring our organization, blocks are pulled from any peer
+	s.config.PreferOwnOrg = false
+	s.setCrossOrgPull(0, true)
+	assert.Equal(t, uint64(20), s.maxAvailableLedgerHeight(s.isEligibleForPull))
+	peer, err = s.selectPeerToRequestFrom(10, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "foreign", peer.Endpoint)
+}
@@ -169,6 +169,18 @@ func GetDurationOrDefault(key string, defVal time.Duration) time.Duration {
 	return defVal
 }
 
+// GetBoolOrDefault returns the bool value from config if present otherwise default value
+func GetBoolOrDefault(key string, defVal bool) bool {
+	viperLock.RLock()
+	defer viperLock.RUnlock()
+
+	if viper.IsSet(key) {
+		return viper.GetBool(key)
+	}
+
+	return defVal
+}
+
 // SetDuration stores duration key value to viper
 func SetDuration(key string, val time.Duration) {
 	viperLock.Lock()
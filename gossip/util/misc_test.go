@@ -104,6 +104,12 @@ func TestGetDurationOrDefault(t *testing.T) {
 	assert.Equal(t, time.Second*2, bar)
 }
 
+func TestGetBoolOrDefault(t *testing.T) {
+	viper.Set("enabled", false)
+	assert.False(t, GetBoolOrDefault("enabled", true))
+	assert.True(t, GetBoolOrDefault("disabled", true))
+}
+
 func TestPrintStackTrace(t *testing.T) {
 	PrintStackTrace()
 }
@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/manudrijvers/amcl/go"
+)
+
+// Identity Mixer Credential is a list of attributes certified (signed) by the issuer
+// A credential also contains a user secret key blindly signed by the issuer
+// Without the secret key the credential cannot be used
+
+// The issuance protocol is described in credrequest.go
+
+// NewCredential issues a new credential, which is the last step of the interactive issuance protocol
+// All attribute values are added by the issuer at this step and then signed together with a commitment to
+// the user's secret key from a credential request
+func NewCredential(key *IssuerKey, m *CredRequest, attrs []*amcl.BIG, rng *amcl.RAND) (*Credential, error) {
+	// check the credential request
+	err := m.Check(key.Ipk)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attrs) != len(key.Ipk.AttributeNames) {
+		return nil, fmt.Errorf("incorrect number of attribute values passed, expected %d, got %d", len(key.Ipk.AttributeNames), len(attrs))
+	}
+
+	// Place a BBS+ signature on the user key and the attribute values
+	// (For BBS+, see "Constant-Size Dynamic k-TAA" by Man Ho Au, Willy Susilo, Yi Mu)
+	E := RandModOrder(rng)
+	S := RandModOrder(rng)
+
+	// B = g_1 \cdot h_r^s \cdot Nym \cdot \prod_{i=1}^L h_{a_i}^{a_i}
+	B := amcl.NewECP()
+	B.Copy(GenG1)
+	B.Add(EcpFromProto(m.Nym))
+	B.Add(EcpFromProto(key.Ipk.HRand).Mul(S))
+	for i := 0; i < len(attrs)/2; i++ {
+		B.Add(EcpFromProto(key.Ipk.HAttrs[2*i]).Mul2(attrs[2*i], EcpFromProto(key.Ipk.HAttrs[2*i+1]), attrs[2*i+1]))
+	}
+	if len(attrs)%2 != 0 {
+		B.Add(EcpFromProto(key.Ipk.HAttrs[len(attrs)-1]).Mul(attrs[len(attrs)-1]))
+	}
+
+	// A = B^{1/(ISk + e)}
+	Exp := amcl.Modadd(BigFromBytes(key.GetIsk()), E, GroupOrder)
+	Exp.Invmodp(GroupOrder)
+	A := B.Mul(Exp)
+
+	CredAttrs := make([][]byte, len(attrs))
+	for index, attribute := range attrs {
+		CredAttrs[index] = BigToBytes(attribute)
+	}
+
+	return &Credential{
+		A:     EcpToProto(A),
+		B:     EcpToProto(B),
+		E:     BigToBytes(E),
+		S:     BigToBytes(S),
+		Attrs: CredAttrs}, nil
+}
+
+// Ver cryptographically verifies the credential by verifying the signature
+// on the attribute values and user's secret key
+func (cred *Credential) Ver(sk *amcl.BIG, ipk *IssuerPublicKey) error {
+	// Validate Input
+
+	// - parse the credential
+	A := EcpFromProto(cred.GetA())
+	B := EcpFromProto(cred.GetB())
+	E := BigFromBytes(cred.GetE())
+	S := BigFromBytes(cred.GetS())
+
+	// - verify that all attribute values are present
+	if len(cred.GetAttrs()) != len(ipk.GetAttributeNames()) || len(ipk.GetHAttrs()) < len(cred.GetAttrs()) {
+		return errors.New("credential does not contain the expected attributes")
+	}
+	for i := 0; i < len(cred.GetAttrs()); i++ {
+		if cred.Attrs[i] == nil {
+			return fmt.Errorf("credential has no value for attribute %s", ipk.AttributeNames[i])
+		}
+	}
+
+	// - verify cryptographic signature on the attributes and the user secret key
+	BPrime := amcl.NewECP()
+	BPrime.Copy(GenG1)
+	BPrime.Add(EcpFromProto(ipk.HSk).Mul2(sk, EcpFromProto(ipk.HRand), S))
+	for i := 0; i < len(cred.Attrs)/2; i++ {
+		BPrime.Add(
+			EcpFromProto(ipk.HAttrs[2*i]).Mul2(
+				BigFromBytes(cred.Attrs[2*i]),
+				EcpFromProto(ipk.HAttrs[2*i+1]),
+				BigFromBytes(cred.Attrs[2*i+1]),
+			),
+		)
+	}
+	if len(cred.Attrs)%2 != 0 {
+		BPrime.Add(EcpFromProto(ipk.HAttrs[len(cred.Attrs)-1]).Mul(BigFromBytes(cred.Attrs[len(cred.Attrs)-1])))
+	}
+	if !B.Equals(BPrime) {
+		return errors.New("b-value from credential does not match the attribute values")
+	}
+
+	// Verify BBS+ signature. Namely: e(w \cdot g_2^e, A) =? e(g_2, B)
+	a := GenG2.Mul(E)
+	a.Add(Ecp2FromProto(ipk.W))
+
+	left := amcl.Fexp(amcl.Ate(a, A))
+	right := amcl.Fexp(amcl.Ate(GenG2, B))
+
+	if !left.Equals(right) {
+		return errors.New("credential is not cryptographically valid")
+	}
+
+	return nil
+}
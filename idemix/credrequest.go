@@ -0,0 +1,115 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"errors"
+
+	"github.com/manudrijvers/amcl/go"
+)
+
+// credRequestLabel is the label used in zero-knowledge proof (ZKP) to identify that this ZKP is a credential request
+const credRequestLabel = "credRequest"
+
+// Credential issuance is an interactive protocol between a user and an issuer
+// The issuer takes its secret and public keys and user attribute values as input
+// The user takes the issuer public key and user secret as input
+// The issuance protocol consists of the following steps:
+// 1) The issuer sends a random nonce to the user
+// 2) The user creates a Credential Request using the public key of the issuer, user secret, and the nonce as input
+//    The request consists of a commitment to the user secret (can be seen as a public key) and a zero-knowledge proof
+//     of knowledge of the user secret key
+//    The user sends the credential request to the issuer
+// 3) The issuer verifies the credential request by verifying the zero-knowledge proof
+//    If the request is valid, the issuer issues a credential to the user by signing the commitment to the secret key
+//    together with the attribute values and sends the credential back to the user
+// 4) The user verifies the issuer's signature and stores the credential that consists of
+//    the signature value, a randomness used to create the signature, the user secret, and the attribute values
+
+// NewCredRequest creates a new Credential Request, the first message of the interactive credential issuance protocol
+// (from user to issuer)
+func NewCredRequest(sk *amcl.BIG, IssuerNonce []byte, ipk *IssuerPublicKey, rng *amcl.RAND) *CredRequest {
+	// Set Nym as h_{sk}^{sk}
+	HSk := EcpFromProto(ipk.HSk)
+	Nym := HSk.Mul(sk)
+
+	// generate a zero-knowledge proof of knowledge (ZK PoK) of the secret key
+
+	// Sample the randomness needed for the proof
+	rSk := RandModOrder(rng)
+
+	// Step 1: First message (t-values)
+	t := HSk.Mul(rSk) // t = h_{sk}^{r_{sk}}, cover Nym
+
+	// Step 2: Compute the Fiat-Shamir hash, forming the challenge of the ZKP.
+	proofC := HashModOrder(credRequestProofData(t, HSk, Nym, IssuerNonce, ipk.Hash))
+
+	// Step 3: reply to the challenge message (s-values)
+	proofS := amcl.Modadd(amcl.Modmul(proofC, sk, GroupOrder), rSk, GroupOrder) // s = r_{sk} + C \cdot sk
+
+	// Done
+	return &CredRequest{
+		Nym:         EcpToProto(Nym),
+		IssuerNonce: IssuerNonce,
+		ProofC:      BigToBytes(proofC),
+		ProofS:      BigToBytes(proofS)}
+}
+
+// Check cryptographically verifies the credential request
+func (m *CredRequest) Check(ipk *IssuerPublicKey) error {
+	Nym := EcpFromProto(m.GetNym())
+	IssuerNonce := m.GetIssuerNonce()
+	ProofC := BigFromBytes(m.GetProofC())
+	ProofS := BigFromBytes(m.GetProofS())
+
+	HSk := EcpFromProto(ipk.GetHSk())
+
+	if Nym.Is_infinity() || len(IssuerNonce) != FieldBytes || m.GetProofC() == nil || m.GetProofS() == nil {
+		return errors.New("one of the proof values is undefined")
+	}
+
+	// Verify Proof
+
+	// Recompute t-values using s-values
+	t := HSk.Mul(ProofS)
+	t.Sub(Nym.Mul(ProofC)) // t = h_{sk}^s / Nym^C
+
+	// Recompute challenge
+	if !ProofC.Equals(HashModOrder(credRequestProofData(t, HSk, Nym, IssuerNonce, ipk.GetHash()))) {
+		return errors.New("zero knowledge proof is invalid")
+	}
+
+	return nil
+}
+
+// credRequestProofData serializes the data hashed by the proof of knowledge
+// of the user secret key
+func credRequestProofData(t, HSk, Nym *amcl.ECP, IssuerNonce, ipkHash []byte) []byte {
+	// proofData is the data being hashed, it consists of:
+	// the credential request label
+	// 3 elements of G1 each taking 2*FieldBytes+1 bytes
+	// the issuer nonce and the hash of the issuer public key
+	proofData := make([]byte, len([]byte(credRequestLabel))+3*(2*FieldBytes+1)+len(IssuerNonce)+FieldBytes)
+	index := 0
+	index = appendBytesString(proofData, index, credRequestLabel)
+	index = appendBytesG1(proofData, index, t)
+	index = appendBytesG1(proofData, index, HSk)
+	index = appendBytesG1(proofData, index, Nym)
+	index = appendBytes(proofData, index, IssuerNonce)
+	copy(proofData[index:], ipkHash)
+	return proofData
+}
@@ -0,0 +1,166 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/manudrijvers/amcl/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdemix(t *testing.T) {
+	rng, err := GetRand()
+	assert.NoError(t, err)
+
+	// Test issuer key generation
+	AttributeNames := []string{"Attr1", "Attr2", "Attr3", "Attr4", "Attr5"}
+	attrs := make([]*amcl.BIG, len(AttributeNames))
+	for i := range AttributeNames {
+		attrs[i] = amcl.NewBIGint(i)
+	}
+
+	_, err = NewIssuerKey([]string{"Attr1", "Attr1"}, rng)
+	assert.Error(t, err, "issuer key generation should fail with duplicate attribute names")
+
+	key, err := NewIssuerKey(AttributeNames, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, key.Ipk.Check(), "issuer public key should be valid")
+
+	// Test a public key with a broken proof
+	brokenIpk := proto.Clone(key.Ipk).(*IssuerPublicKey)
+	brokenIpk.ProofC = BigToBytes(RandModOrder(rng))
+	assert.Error(t, brokenIpk.Check(), "issuer public key with a wrong proof should be rejected")
+
+	// Test a public key with a wrong hash
+	brokenIpk = proto.Clone(key.Ipk).(*IssuerPublicKey)
+	brokenIpk.Hash = BigToBytes(RandModOrder(rng))
+	assert.Error(t, brokenIpk.Check(), "issuer public key with a wrong hash should be rejected")
+
+	// Test a public key with missing attribute bases
+	brokenIpk = proto.Clone(key.Ipk).(*IssuerPublicKey)
+	brokenIpk.HAttrs = brokenIpk.HAttrs[:2]
+	assert.Error(t, brokenIpk.Check(), "issuer public key without enough attribute bases should be rejected")
+
+	// Test issuance
+	sk := RandModOrder(rng)
+	ni := BigToBytes(RandModOrder(rng))
+	m := NewCredRequest(sk, ni, key.Ipk, rng)
+	assert.NoError(t, m.Check(key.Ipk), "credential request should be valid")
+
+	brokenRequest := proto.Clone(m).(*CredRequest)
+	brokenRequest.ProofS = BigToBytes(RandModOrder(rng))
+	assert.Error(t, brokenRequest.Check(key.Ipk), "credential request with a wrong proof should be rejected")
+	_, err = NewCredential(key, brokenRequest, attrs, rng)
+	assert.Error(t, err, "no credential should be issued for an invalid request")
+
+	brokenRequest = proto.Clone(m).(*CredRequest)
+	brokenRequest.IssuerNonce = []byte{1, 2, 3}
+	assert.Error(t, brokenRequest.Check(key.Ipk), "credential request with a short nonce should be rejected")
+
+	_, err = NewCredential(key, m, attrs[:2], rng)
+	assert.Error(t, err, "no credential should be issued with the wrong number of attributes")
+
+	cred, err := NewCredential(key, m, attrs, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, cred.Ver(sk, key.Ipk), "credential should be valid")
+	assert.Error(t, cred.Ver(RandModOrder(rng), key.Ipk), "credential should be bound to the user secret key")
+
+	brokenCred := proto.Clone(cred).(*Credential)
+	brokenCred.Attrs[0] = BigToBytes(amcl.NewBIGint(42))
+	assert.Error(t, brokenCred.Ver(sk, key.Ipk), "credential with modified attributes should be rejected")
+
+	// Test revocation information
+	revocationKey, err := GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	epoch := 0
+	cri, err := CreateCRI(revocationKey, []*amcl.BIG{}, epoch, ALG_NO_REVOCATION, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyEpochPK(&revocationKey.PublicKey, cri.EpochPk, cri.EpochPkSig, int(cri.Epoch), ALG_NO_REVOCATION))
+	assert.Error(t, VerifyEpochPK(&revocationKey.PublicKey, cri.EpochPk, cri.EpochPkSig, epoch+1, ALG_NO_REVOCATION), "epoch key signature should be bound to the epoch")
+	_, err = CreateCRI(revocationKey, []*amcl.BIG{}, epoch, RevocationAlgorithm(-1), rng)
+	assert.Error(t, err, "unknown revocation algorithms should be rejected")
+
+	// Test signing with no disclosure
+	rhIndex := 4
+	Nym, RNym := MakeNym(sk, key.Ipk, rng)
+	disclosure := []byte{0, 0, 0, 0, 0}
+	msg := []byte{1, 2, 3, 4, 5}
+	sig, err := NewSignature(cred, sk, Nym, RNym, key.Ipk, disclosure, msg, rhIndex, cri, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, sig.Ver(disclosure, key.Ipk, msg, nil, rhIndex, &revocationKey.PublicKey, epoch), "signature should be valid")
+	assert.Error(t, sig.Ver(disclosure, key.Ipk, []byte{1}, nil, rhIndex, &revocationKey.PublicKey, epoch), "signature should be bound to the message")
+	assert.Error(t, sig.Ver(disclosure, key.Ipk, msg, nil, rhIndex, &revocationKey.PublicKey, epoch+1), "signature should be bound to the epoch")
+
+	otherRevocationKey, err := GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	assert.Error(t, sig.Ver(disclosure, key.Ipk, msg, nil, rhIndex, &otherRevocationKey.PublicKey, epoch), "signature should only be accepted with the revocation key of the CRI")
+
+	// Test signing with selective disclosure
+	disclosure = []byte{0, 1, 1, 1, 0}
+	sig, err = NewSignature(cred, sk, Nym, RNym, key.Ipk, disclosure, msg, rhIndex, cri, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, sig.Ver(disclosure, key.Ipk, msg, attrs, rhIndex, &revocationKey.PublicKey, epoch), "signature with selective disclosure should be valid")
+
+	wrongAttrs := make([]*amcl.BIG, len(attrs))
+	copy(wrongAttrs, attrs)
+	wrongAttrs[1] = amcl.NewBIGint(42)
+	assert.Error(t, sig.Ver(disclosure, key.Ipk, msg, wrongAttrs, rhIndex, &revocationKey.PublicKey, epoch), "signature should not verify against wrong disclosed attribute values")
+
+	brokenSig := proto.Clone(sig).(*Signature)
+	brokenSig.ProofSAttrs = brokenSig.ProofSAttrs[:1]
+	assert.Error(t, brokenSig.Ver(disclosure, key.Ipk, msg, attrs, rhIndex, &revocationKey.PublicKey, epoch), "signature with missing s-values should be rejected")
+
+	brokenSig = proto.Clone(sig).(*Signature)
+	brokenSig.APrime = &ECP{X: []byte{1}, Y: []byte{2}}
+	assert.Error(t, brokenSig.Ver(disclosure, key.Ipk, msg, attrs, rhIndex, &revocationKey.PublicKey, epoch), "signature with a malformed point should be rejected")
+
+	_, err = NewSignature(cred, sk, Nym, RNym, key.Ipk, []byte{0, 1}, msg, rhIndex, cri, rng)
+	assert.Error(t, err, "signing should fail with a disclosure of the wrong length")
+
+	// Test NymSignatures
+	nymsig, err := NewNymSignature(sk, Nym, RNym, key.Ipk, msg, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, nymsig.Ver(Nym, key.Ipk, msg), "nym signature should be valid")
+	assert.Error(t, nymsig.Ver(Nym, key.Ipk, []byte{1}), "nym signature should be bound to the message")
+
+	otherNym, _ := MakeNym(sk, key.Ipk, rng)
+	assert.Error(t, nymsig.Ver(otherNym, key.Ipk, msg), "nym signature should be bound to the pseudonym")
+
+	_, err = NewNymSignature(nil, Nym, RNym, key.Ipk, msg, rng)
+	assert.Error(t, err, "nym signing should fail without a secret key")
+}
+
+func TestBigFromBytes(t *testing.T) {
+	rng, err := GetRand()
+	assert.NoError(t, err)
+
+	r := RandModOrder(rng)
+	assert.True(t, r.Equals(BigFromBytes(BigToBytes(r))))
+
+	// short and long inputs are padded and truncated rather than causing a panic
+	assert.True(t, amcl.NewBIGint(1).Equals(BigFromBytes([]byte{1})))
+	assert.True(t, amcl.NewBIGint(0).Equals(BigFromBytes(nil)))
+	assert.True(t, r.Equals(BigFromBytes(append([]byte{9, 9}, BigToBytes(r)...))))
+
+	// points that are not on the curve decode to the point at infinity
+	assert.True(t, EcpFromProto(&ECP{X: []byte{1}, Y: []byte{1}}).Is_infinity())
+	assert.True(t, Ecp2FromProto(&ECP2{}).Is_infinity())
+	assert.True(t, GenG1.Equals(EcpFromProto(EcpToProto(GenG1))))
+	assert.True(t, GenG2.Equals(Ecp2FromProto(Ecp2ToProto(GenG2))))
+}
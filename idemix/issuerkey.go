@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/manudrijvers/amcl/go"
+)
+
+// The Issuer secret ISk and public IPk keys are used to issue credentials and
+// to verify signatures created using the credentials
+
+// NewIssuerKey creates a new issuer key pair taking an array of attribute names
+// that will be contained in credentials certified by this issuer (a credential specification)
+func NewIssuerKey(AttributeNames []string, rng *amcl.RAND) (*IssuerKey, error) {
+	// validate inputs
+
+	// check for duplicated attributes
+	attributeNamesMap := map[string]bool{}
+	for _, name := range AttributeNames {
+		if attributeNamesMap[name] {
+			return nil, fmt.Errorf("attribute %s appears multiple times in AttributeNames", name)
+		}
+		attributeNamesMap[name] = true
+	}
+
+	key := new(IssuerKey)
+
+	// generate issuer secret key
+	ISk := RandModOrder(rng)
+	key.Isk = BigToBytes(ISk)
+
+	// generate the corresponding public key
+	key.Ipk = new(IssuerPublicKey)
+	key.Ipk.AttributeNames = AttributeNames
+
+	W := GenG2.Mul(ISk)
+	key.Ipk.W = Ecp2ToProto(W)
+
+	// generate bases that correspond to the attributes
+	key.Ipk.HAttrs = make([]*ECP, len(AttributeNames))
+	for i := 0; i < len(AttributeNames); i++ {
+		key.Ipk.HAttrs[i] = EcpToProto(GenG1.Mul(RandModOrder(rng)))
+	}
+
+	// generate base for the secret key
+	HSk := GenG1.Mul(RandModOrder(rng))
+	key.Ipk.HSk = EcpToProto(HSk)
+
+	// generate base for the randomness
+	HRand := GenG1.Mul(RandModOrder(rng))
+	key.Ipk.HRand = EcpToProto(HRand)
+
+	BarG1 := GenG1.Mul(RandModOrder(rng))
+	key.Ipk.BarG1 = EcpToProto(BarG1)
+
+	BarG2 := BarG1.Mul(ISk)
+	key.Ipk.BarG2 = EcpToProto(BarG2)
+
+	// generate a zero-knowledge proof of knowledge (ZK PoK) of the secret key which
+	// is in W and BarG2.
+
+	// Sample the randomness needed for the proof
+	r := RandModOrder(rng)
+
+	// Step 1: First message (t-values)
+	t1 := GenG2.Mul(r) // t1 = g_2^r, cover W
+	t2 := BarG1.Mul(r) // t2 = (\bar g_1)^r, cover BarG2
+
+	// Step 2: Compute the Fiat-Shamir hash, forming the challenge of the ZKP.
+	proofC := HashModOrder(issuerKeyProofData(t1, t2, BarG1, W, BarG2))
+	key.Ipk.ProofC = BigToBytes(proofC)
+
+	// Step 3: reply to the challenge message (s-values)
+	proofS := amcl.Modadd(amcl.Modmul(proofC, ISk, GroupOrder), r, GroupOrder) // s = r + C \cdot ISk
+	key.Ipk.ProofS = BigToBytes(proofS)
+
+	// Hash the public key
+	if err := key.Ipk.SetHash(); err != nil {
+		return nil, err
+	}
+
+	// We are done
+	return key, nil
+}
+
+// Check checks that this issuer public key is valid, i.e.
+// that all components are present and a ZK proofs verifies
+func (IPk *IssuerPublicKey) Check() error {
+	// Unmarshall the public key
+	NumAttrs := len(IPk.GetAttributeNames())
+	HSk := EcpFromProto(IPk.GetHSk())
+	HRand := EcpFromProto(IPk.GetHRand())
+	HAttrs := make([]*amcl.ECP, len(IPk.GetHAttrs()))
+	for i := 0; i < len(IPk.GetHAttrs()); i++ {
+		HAttrs[i] = EcpFromProto(IPk.GetHAttrs()[i])
+	}
+	BarG1 := EcpFromProto(IPk.GetBarG1())
+	BarG2 := EcpFromProto(IPk.GetBarG2())
+	W := Ecp2FromProto(IPk.GetW())
+	ProofC := BigFromBytes(IPk.GetProofC())
+	ProofS := BigFromBytes(IPk.GetProofS())
+
+	// Check that the public key is well-formed
+	if HSk.Is_infinity() ||
+		HRand.Is_infinity() ||
+		BarG1.Is_infinity() ||
+		BarG2.Is_infinity() ||
+		W.Is_infinity() ||
+		len(HAttrs) < NumAttrs {
+		return errors.New("some part of the public key is undefined")
+	}
+	for _, h := range HAttrs {
+		if h.Is_infinity() {
+			return errors.New("some part of the public key is undefined")
+		}
+	}
+
+	// Verify Proof
+
+	// Recompute t-values using s-values
+	t1 := GenG2.Mul(ProofS)
+	t1.Add(W.Mul(amcl.Modneg(ProofC, GroupOrder))) // t1 = g_2^s \cdot W^{-C}
+
+	t2 := BarG1.Mul(ProofS)
+	t2.Add(BarG2.Mul(amcl.Modneg(ProofC, GroupOrder))) // t2 = {\bar g_1}^s \cdot {\bar g_2}^{-C}
+
+	// Recompute challenge
+	if !ProofC.Equals(HashModOrder(issuerKeyProofData(t1, t2, BarG1, W, BarG2))) {
+		return errors.New("zero knowledge proof in public key invalid")
+	}
+
+	// Check that the hash is the one of the public key
+	hash, err := IPk.computeHash()
+	if err != nil {
+		return err
+	}
+	if !BigFromBytes(hash).Equals(BigFromBytes(IPk.GetHash())) {
+		return errors.New("hash of the public key does not match")
+	}
+
+	return nil
+}
+
+// SetHash appends a hash of a serialized public key
+func (IPk *IssuerPublicKey) SetHash() error {
+	hash, err := IPk.computeHash()
+	if err != nil {
+		return err
+	}
+	IPk.Hash = hash
+	return nil
+}
+
+// computeHash hashes the public key serialized without its hash field
+func (IPk *IssuerPublicKey) computeHash() ([]byte, error) {
+	unhashed := *IPk
+	unhashed.Hash = nil
+	serializedIPk, err := proto.Marshal(&unhashed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal issuer public key: %s", err)
+	}
+	return BigToBytes(HashModOrder(serializedIPk)), nil
+}
+
+// issuerKeyProofData serializes the data hashed by the proof of knowledge
+// of the issuer secret key
+func issuerKeyProofData(t1 *amcl.ECP2, t2, BarG1 *amcl.ECP, W *amcl.ECP2, BarG2 *amcl.ECP) []byte {
+	// proofData is the data being hashed, it consists of:
+	// t1, g_2, W (3 elements of G2 each taking 4*FieldBytes bytes)
+	// t2, \bar g_1, \bar g_2 (3 elements of G1 each taking 2*FieldBytes+1 bytes)
+	proofData := make([]byte, 18*FieldBytes+3)
+	index := 0
+	index = appendBytesG2(proofData, index, t1)
+	index = appendBytesG1(proofData, index, t2)
+	index = appendBytesG2(proofData, index, GenG2)
+	index = appendBytesG1(proofData, index, BarG1)
+	index = appendBytesG2(proofData, index, W)
+	index = appendBytesG1(proofData, index, BarG2)
+	return proofData
+}
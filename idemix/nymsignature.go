@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"errors"
+
+	"github.com/manudrijvers/amcl/go"
+)
+
+// nymSigLabel is the label used in zero-knowledge proof (ZKP) to identify that this ZKP is a Nym signature
+const nymSigLabel = "nymSig"
+
+// NewNymSignature creates a new idemix pseudonym signature
+func NewNymSignature(sk *amcl.BIG, Nym *amcl.ECP, RNym *amcl.BIG, ipk *IssuerPublicKey, msg []byte, rng *amcl.RAND) (*NymSignature, error) {
+	// Validate inputs
+	if sk == nil || Nym == nil || RNym == nil || ipk == nil || rng == nil {
+		return nil, errors.New("cannot create NymSignature: received nil input")
+	}
+
+	Nonce := RandModOrder(rng)
+
+	HRand := EcpFromProto(ipk.HRand)
+	HSk := EcpFromProto(ipk.HSk)
+
+	// The rest of this function constructs the non-interactive zero knowledge proof proving that
+	// the signer 'owns' this pseudonym, i.e., it knows the secret key and randomness on which it is based.
+	// Recall that (Nym,RNym) is the output of MakeNym. Therefore, Nym = h_{sk}^sk \cdot h_r^r
+
+	// Sample the randomness needed for the proof
+	rSk := RandModOrder(rng)
+	rRNym := RandModOrder(rng)
+
+	// Step 1: First message (t-values)
+	t := HSk.Mul2(rSk, HRand, rRNym) // t = h_{sk}^{r_sk} \cdot h_r^{r_{RNym}}
+
+	// Step 2: Compute the Fiat-Shamir hash, forming the challenge of the ZKP.
+	c := HashModOrder(nymSignatureProofData(t, Nym, ipk.Hash, msg))
+	ProofC := HashModOrder(challengeWithNonce(c, Nonce))
+
+	// Step 3: reply to the challenge message (s-values)
+	ProofSSk := amcl.Modadd(rSk, amcl.Modmul(ProofC, sk, GroupOrder), GroupOrder)       // s_{sk} = r_{sk} + C \cdot sk
+	ProofSRNym := amcl.Modadd(rRNym, amcl.Modmul(ProofC, RNym, GroupOrder), GroupOrder) // s_{RNym} = r_{RNym} + C \cdot RNym
+
+	// The signature consists of the Fiat-Shamir hash (ProofC), the s-values (ProofSSk, ProofSRNym), and the nonce.
+	return &NymSignature{
+		ProofC:     BigToBytes(ProofC),
+		ProofSSk:   BigToBytes(ProofSSk),
+		ProofSRNym: BigToBytes(ProofSRNym),
+		Nonce:      BigToBytes(Nonce)}, nil
+}
+
+// Ver verifies an idemix NymSignature
+func (sig *NymSignature) Ver(nym *amcl.ECP, ipk *IssuerPublicKey, msg []byte) error {
+	// Validate inputs
+	if nym == nil || ipk == nil {
+		return errors.New("cannot verify NymSignature: received nil input")
+	}
+	if nym.Is_infinity() {
+		return errors.New("cannot verify NymSignature: pseudonym is undefined")
+	}
+
+	ProofC := BigFromBytes(sig.GetProofC())
+	ProofSSk := BigFromBytes(sig.GetProofSSk())
+	ProofSRNym := BigFromBytes(sig.GetProofSRNym())
+	Nonce := BigFromBytes(sig.GetNonce())
+
+	HRand := EcpFromProto(ipk.HRand)
+	HSk := EcpFromProto(ipk.HSk)
+
+	// Recompute t-values using s-values
+	t := HSk.Mul2(ProofSSk, HRand, ProofSRNym)
+	t.Sub(nym.Mul(ProofC)) // t = h_{sk}^{s_{sk}} \cdot h_r^{s_{RNym}} / Nym^C
+
+	// Recompute challenge
+	c := HashModOrder(nymSignatureProofData(t, nym, ipk.Hash, msg))
+	if !ProofC.Equals(HashModOrder(challengeWithNonce(c, Nonce))) {
+		return errors.New("NymSig is invalid")
+	}
+
+	return nil
+}
+
+// nymSignatureProofData serializes the data hashed by the pseudonym signature
+func nymSignatureProofData(t, Nym *amcl.ECP, ipkHash []byte, msg []byte) []byte {
+	// proofData is the data being hashed, it consists of:
+	// the nym signature label
+	// 2 elements of G1 each taking 2*FieldBytes+1 bytes
+	// one bigint (hash of the issuer public key) of length FieldBytes
+	// message to sign
+	proofData := make([]byte, len([]byte(nymSigLabel))+2*(2*FieldBytes+1)+FieldBytes+len(msg))
+	index := 0
+	index = appendBytesString(proofData, index, nymSigLabel)
+	index = appendBytesG1(proofData, index, t)
+	index = appendBytesG1(proofData, index, Nym)
+	copy(proofData[index:], ipkHash)
+	index = index + FieldBytes
+	copy(proofData[index:], msg)
+	return proofData
+}
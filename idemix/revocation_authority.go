@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/manudrijvers/amcl/go"
+)
+
+// RevocationAlgorithm identifies the revocation algorithm
+// used by a revocation authority
+type RevocationAlgorithm int32
+
+const (
+	// ALG_NO_REVOCATION means that revocation is not supported;
+	// signers only prove that they hold a valid credential
+	ALG_NO_REVOCATION RevocationAlgorithm = iota
+)
+
+// GenerateLongTermRevocationKey generates a long term signing key that will be used for revocation
+func GenerateLongTermRevocationKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+}
+
+// CreateCRI creates the Credential Revocation Information for a certain time period (epoch).
+// Users can use the CRI to prove that they are not revoked.
+// Note that when not using revocation (i.e., alg = ALG_NO_REVOCATION), the entered unrevokedHandles are not used,
+// and the resulting CRI can be used by any signer.
+func CreateCRI(key *ecdsa.PrivateKey, unrevokedHandles []*amcl.BIG, epoch int, alg RevocationAlgorithm, rng *amcl.RAND) (*CredentialRevocationInformation, error) {
+	if key == nil || rng == nil {
+		return nil, errors.New("CreateCRI received nil input")
+	}
+	if alg != ALG_NO_REVOCATION {
+		return nil, fmt.Errorf("the specified revocation algorithm %d is not supported", alg)
+	}
+
+	cri := &CredentialRevocationInformation{
+		RevocationAlg: int32(alg),
+		Epoch:         int64(epoch),
+		// without revocation no epoch key is needed, so put a dummy key in the proto
+		EpochPk: Ecp2ToProto(GenG2),
+	}
+
+	// sign epoch + epoch key with long term key
+	digest, err := epochPKDigest(cri)
+	if err != nil {
+		return nil, err
+	}
+	cri.EpochPkSig, err = key.Sign(rand.Reader, digest, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return cri, nil
+}
+
+// VerifyEpochPK verifies that the revocation PK for a certain epoch is valid,
+// by checking that it was signed with the long term revocation key.
+// Note that even if we use no revocation (i.e., alg = ALG_NO_REVOCATION), we need
+// to verify the signature to make sure the issuer indeed signed that no revocation
+// is used in this epoch.
+func VerifyEpochPK(pk *ecdsa.PublicKey, epochPK *ECP2, epochPkSig []byte, epoch int, alg RevocationAlgorithm) error {
+	if pk == nil || epochPK == nil {
+		return errors.New("EpochPK invalid: received nil input")
+	}
+
+	digest, err := epochPKDigest(&CredentialRevocationInformation{
+		RevocationAlg: int32(alg),
+		EpochPk:       epochPK,
+		Epoch:         int64(epoch),
+	})
+	if err != nil {
+		return err
+	}
+
+	sig := &struct{ R, S *big.Int }{}
+	rest, err := asn1.Unmarshal(epochPkSig, sig)
+	if err != nil || len(rest) != 0 || sig.R == nil || sig.S == nil {
+		return errors.New("EpochPKSig invalid: malformed signature")
+	}
+	if !ecdsa.Verify(pk, digest, sig.R, sig.S) {
+		return errors.New("EpochPKSig invalid")
+	}
+
+	return nil
+}
+
+// epochPKDigest hashes the epoch, the epoch key and the revocation
+// algorithm of a CRI, i.e. the data signed by the long term key
+func epochPKDigest(cri *CredentialRevocationInformation) ([]byte, error) {
+	bytesToSign, err := proto.Marshal(&CredentialRevocationInformation{
+		RevocationAlg: cri.RevocationAlg,
+		EpochPk:       cri.EpochPk,
+		Epoch:         cri.Epoch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal CRI: %s", err)
+	}
+	digest := sha256.Sum256(bytesToSign)
+	return digest[:], nil
+}
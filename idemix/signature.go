@@ -0,0 +1,326 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/manudrijvers/amcl/go"
+)
+
+// signLabel is the label used in zero-knowledge proof (ZKP) to identify that this ZKP is a signature of knowledge
+const signLabel = "sign"
+
+// A signature that is produced using an Identity Mixer credential is a so-called signature of knowledge
+// (for details see C.P.Schnorr "Efficient Identification and Signatures for Smart Cards")
+// An Identity Mixer signature is a signature of knowledge that signs a message and proves (in zero-knowledge)
+// the knowledge of the user secret (and possibly attributes) signed inside a credential
+// that was issued by a certain issuer (referred to with the issuer public key)
+// The signature is verified using the message being signed and the public key of the issuer
+// Some of the attributes from the credential can be selectively disclosed or different statements can be proven about
+// credential attributes without disclosing them in the clear
+// The difference between a standard signature using X.509 certificates and an Identity Mixer signature is
+// the advanced privacy features provided by Identity Mixer (due to zero-knowledge proofs):
+//  - Unlinkability of the signatures produced with the same credential
+//  - Selective attribute disclosure and predicates over attributes
+
+// hiddenIndices makes a slice of indices of the attributes that are not disclosed
+func hiddenIndices(Disclosure []byte) []int {
+	HiddenIndices := make([]int, 0)
+	for index, disclose := range Disclosure {
+		if disclose == 0 {
+			HiddenIndices = append(HiddenIndices, index)
+		}
+	}
+	return HiddenIndices
+}
+
+// NewSignature creates a new idemix signature (Schnorr-type signature)
+// The []byte Disclosure steers which attributes are disclosed:
+// if Disclosure[i] == 0 then attribute i remains hidden and otherwise it is disclosed.
+// We require the revocation handle to remain undisclosed (i.e., Disclosure[rhIndex] == 0).
+// We use the zero-knowledge proof by http://eprint.iacr.org/2016/663.pdf, Sec. 4.5 to prove knowledge of a BBS+ signature
+func NewSignature(cred *Credential, sk *amcl.BIG, Nym *amcl.ECP, RNym *amcl.BIG, ipk *IssuerPublicKey, Disclosure []byte, msg []byte, rhIndex int, cri *CredentialRevocationInformation, rng *amcl.RAND) (*Signature, error) {
+	// Validate inputs
+	if cred == nil || sk == nil || Nym == nil || RNym == nil || ipk == nil || rng == nil || cri == nil {
+		return nil, errors.New("cannot create idemix signature: received nil input")
+	}
+
+	if rhIndex < 0 || rhIndex >= len(ipk.AttributeNames) || len(Disclosure) != len(ipk.AttributeNames) || len(cred.Attrs) != len(ipk.AttributeNames) {
+		return nil, errors.New("cannot create idemix signature: received invalid input")
+	}
+
+	if cri.RevocationAlg != int32(ALG_NO_REVOCATION) {
+		return nil, fmt.Errorf("cannot create idemix signature: the revocation algorithm %d is not supported", cri.RevocationAlg)
+	}
+
+	HiddenIndices := hiddenIndices(Disclosure)
+
+	// Step 1: First message (t-values)
+
+	// Randomize credential
+
+	// sample the randomness needed for the proof
+	r1 := RandModOrder(rng)
+	r2 := RandModOrder(rng)
+	r3 := amcl.NewBIGcopy(r1)
+	r3.Invmodp(GroupOrder)
+
+	Nonce := RandModOrder(rng)
+
+	A := EcpFromProto(cred.A)
+	B := EcpFromProto(cred.B)
+	E := BigFromBytes(cred.E)
+	S := BigFromBytes(cred.S)
+
+	APrime := amcl.G1mul(A, r1) // A' = A^{r1}
+	ABar := amcl.G1mul(B, r1)
+	ABar.Sub(amcl.G1mul(APrime, E)) // barA = A'^{-e} b^{r1}
+
+	BPrime := amcl.G1mul(B, r1)
+	HRand := EcpFromProto(ipk.HRand)
+	HSk := EcpFromProto(ipk.HSk)
+
+	BPrime.Sub(amcl.G1mul(HRand, r2)) // b' = b^{r1} h_r^{-r2}
+
+	sPrime := amcl.Modsub(S, amcl.Modmul(r2, r3, GroupOrder), GroupOrder) // s' = s - r2 r3
+
+	// Construct ZK proof
+	rSk := RandModOrder(rng)
+	re := RandModOrder(rng)
+	rR2 := RandModOrder(rng)
+	rR3 := RandModOrder(rng)
+	rSPrime := RandModOrder(rng)
+	rRNym := RandModOrder(rng)
+
+	rAttrs := make([]*amcl.BIG, len(HiddenIndices))
+	for i := range HiddenIndices {
+		rAttrs[i] = RandModOrder(rng)
+	}
+
+	t1 := APrime.Mul2(re, HRand, rR2) // A'^{r_E} \cdot h_r^{r_{r2}}
+	t2 := amcl.G1mul(HRand, rSPrime)  // h_r^{r_{s'}} \cdot b'^{r_{r3}} \cdot h_{sk}^{r_{sk}} \cdot \prod_{i \in hidden} h_i^{r_{a_i}}
+	t2.Add(BPrime.Mul2(rR3, HSk, rSk))
+	for i := 0; i < len(HiddenIndices)/2; i++ {
+		t2.Add(EcpFromProto(ipk.HAttrs[HiddenIndices[2*i]]).Mul2(rAttrs[2*i], EcpFromProto(ipk.HAttrs[HiddenIndices[2*i+1]]), rAttrs[2*i+1]))
+	}
+	if len(HiddenIndices)%2 != 0 {
+		t2.Add(amcl.G1mul(EcpFromProto(ipk.HAttrs[HiddenIndices[len(HiddenIndices)-1]]), rAttrs[len(HiddenIndices)-1]))
+	}
+
+	t3 := HSk.Mul2(rSk, HRand, rRNym) // h_{sk}^{r_{sk}} \cdot h_r^{r_{rnym}}
+
+	// Step 2: Compute the Fiat-Shamir hash, forming the challenge of the ZKP.
+	c := HashModOrder(signatureProofData(t1, t2, t3, APrime, ABar, BPrime, Nym, ipk.Hash, Disclosure, msg))
+	ProofC := HashModOrder(challengeWithNonce(c, Nonce))
+
+	// Step 3: reply to the challenge message (s-values)
+	ProofSSk := amcl.Modadd(rSk, amcl.Modmul(ProofC, sk, GroupOrder), GroupOrder)             // s_sk = rSK + C \cdot sk
+	ProofSE := amcl.Modsub(re, amcl.Modmul(ProofC, E, GroupOrder), GroupOrder)                // s_e = re - C \cdot E
+	ProofSR2 := amcl.Modadd(rR2, amcl.Modmul(ProofC, r2, GroupOrder), GroupOrder)             // s_r2 = rR2 + C \cdot r2
+	ProofSR3 := amcl.Modsub(rR3, amcl.Modmul(ProofC, r3, GroupOrder), GroupOrder)             // s_r3 = rR3 - C \cdot r3
+	ProofSSPrime := amcl.Modadd(rSPrime, amcl.Modmul(ProofC, sPrime, GroupOrder), GroupOrder) // s_S' = rSPrime + C \cdot sPrime
+	ProofSRNym := amcl.Modadd(rRNym, amcl.Modmul(ProofC, RNym, GroupOrder), GroupOrder)       // s_RNym = rRNym + C \cdot RNym
+	ProofSAttrs := make([][]byte, len(HiddenIndices))
+	for i, j := range HiddenIndices {
+		ProofSAttrs[i] = BigToBytes(
+			// s_attrsi = rAttrsi + C \cdot cred.Attrs[j]
+			amcl.Modadd(rAttrs[i], amcl.Modmul(ProofC, BigFromBytes(cred.Attrs[j]), GroupOrder), GroupOrder),
+		)
+	}
+
+	// We are done. Return signature
+	return &Signature{
+		APrime:            EcpToProto(APrime),
+		ABar:              EcpToProto(ABar),
+		BPrime:            EcpToProto(BPrime),
+		ProofC:            BigToBytes(ProofC),
+		ProofSSk:          BigToBytes(ProofSSk),
+		ProofSE:           BigToBytes(ProofSE),
+		ProofSR2:          BigToBytes(ProofSR2),
+		ProofSR3:          BigToBytes(ProofSR3),
+		ProofSSPrime:      BigToBytes(ProofSSPrime),
+		ProofSAttrs:       ProofSAttrs,
+		Nonce:             BigToBytes(Nonce),
+		Nym:               EcpToProto(Nym),
+		ProofSRNym:        BigToBytes(ProofSRNym),
+		RevocationEpochPk: cri.EpochPk,
+		RevocationPkSig:   cri.EpochPkSig,
+		Epoch:             cri.Epoch,
+		NonRevocationProof: &NonRevocationProof{
+			RevocationAlg: cri.RevocationAlg,
+		},
+	}, nil
+}
+
+// Ver verifies an idemix signature
+// Disclosure steers which attributes it expects to be disclosed
+// attributeValues contains the desired attribute values.
+// This function will check that if attribute i is disclosed, the i-th attribute equals attributeValues[i].
+func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, attributeValues []*amcl.BIG, rhIndex int, revPk *ecdsa.PublicKey, epoch int) error {
+	// Validate inputs
+	if ipk == nil || revPk == nil {
+		return errors.New("cannot verify idemix signature: received nil input")
+	}
+
+	if rhIndex < 0 || rhIndex >= len(ipk.AttributeNames) || len(Disclosure) != len(ipk.AttributeNames) || len(ipk.HAttrs) < len(Disclosure) {
+		return errors.New("cannot verify idemix signature: received invalid input")
+	}
+
+	for index, disclose := range Disclosure {
+		if disclose != 0 && (index >= len(attributeValues) || attributeValues[index] == nil) {
+			return fmt.Errorf("cannot verify idemix signature: no value given for disclosed attribute %d", index)
+		}
+	}
+
+	if sig.GetNonRevocationProof().GetRevocationAlg() != int32(ALG_NO_REVOCATION) {
+		return fmt.Errorf("cannot verify idemix signature: the revocation algorithm %d is not supported", sig.GetNonRevocationProof().GetRevocationAlg())
+	}
+
+	HiddenIndices := hiddenIndices(Disclosure)
+
+	// Parse signature
+	APrime := EcpFromProto(sig.GetAPrime())
+	ABar := EcpFromProto(sig.GetABar())
+	BPrime := EcpFromProto(sig.GetBPrime())
+	Nym := EcpFromProto(sig.GetNym())
+	ProofC := BigFromBytes(sig.GetProofC())
+	ProofSSk := BigFromBytes(sig.GetProofSSk())
+	ProofSE := BigFromBytes(sig.GetProofSE())
+	ProofSR2 := BigFromBytes(sig.GetProofSR2())
+	ProofSR3 := BigFromBytes(sig.GetProofSR3())
+	ProofSSPrime := BigFromBytes(sig.GetProofSSPrime())
+	ProofSRNym := BigFromBytes(sig.GetProofSRNym())
+	if len(sig.GetProofSAttrs()) != len(HiddenIndices) {
+		return errors.New("signature invalid: incorrect amount of s-values for AttributeProofSpec")
+	}
+	ProofSAttrs := make([]*amcl.BIG, len(HiddenIndices))
+	for i, b := range sig.GetProofSAttrs() {
+		ProofSAttrs[i] = BigFromBytes(b)
+	}
+	Nonce := BigFromBytes(sig.GetNonce())
+
+	// Parse issuer public key
+	W := Ecp2FromProto(ipk.W)
+	HRand := EcpFromProto(ipk.HRand)
+	HSk := EcpFromProto(ipk.HSk)
+
+	// Verify signature
+	if APrime.Is_infinity() {
+		return errors.New("signature invalid: APrime = 1")
+	}
+	if Nym.Is_infinity() {
+		return errors.New("signature invalid: Nym = 1")
+	}
+	temp1 := amcl.Ate(W, APrime)
+	temp2 := amcl.Ate(GenG2, ABar)
+	temp2.Inverse()
+	temp1.Mul(temp2)
+	if !amcl.Fexp(temp1).Isunity() {
+		return errors.New("signature invalid: APrime and ABar don't have the expected structure")
+	}
+
+	// Verify ZK proof
+
+	// Recover t-values
+
+	// Recompute t1
+	t1 := APrime.Mul2(ProofSE, HRand, ProofSR2)
+	temp := amcl.NewECP()
+	temp.Copy(ABar)
+	temp.Sub(BPrime)
+	t1.Sub(amcl.G1mul(temp, ProofC))
+
+	// Recompute t2
+	t2 := amcl.G1mul(HRand, ProofSSPrime)
+	t2.Add(BPrime.Mul2(ProofSR3, HSk, ProofSSk))
+	for i := 0; i < len(HiddenIndices)/2; i++ {
+		t2.Add(EcpFromProto(ipk.HAttrs[HiddenIndices[2*i]]).Mul2(ProofSAttrs[2*i], EcpFromProto(ipk.HAttrs[HiddenIndices[2*i+1]]), ProofSAttrs[2*i+1]))
+	}
+	if len(HiddenIndices)%2 != 0 {
+		t2.Add(amcl.G1mul(EcpFromProto(ipk.HAttrs[HiddenIndices[len(HiddenIndices)-1]]), ProofSAttrs[len(HiddenIndices)-1]))
+	}
+	temp = amcl.NewECP()
+	temp.Copy(GenG1)
+	for index, disclose := range Disclosure {
+		if disclose != 0 {
+			temp.Add(amcl.G1mul(EcpFromProto(ipk.HAttrs[index]), attributeValues[index]))
+		}
+	}
+	t2.Add(amcl.G1mul(temp, ProofC))
+
+	// Recompute t3
+	t3 := HSk.Mul2(ProofSSk, HRand, ProofSRNym)
+	t3.Sub(Nym.Mul(ProofC))
+
+	// Recompute challenge
+	c := HashModOrder(signatureProofData(t1, t2, t3, APrime, ABar, BPrime, Nym, ipk.Hash, Disclosure, msg))
+	if !ProofC.Equals(HashModOrder(challengeWithNonce(c, Nonce))) {
+		return errors.New("signature invalid: zero-knowledge proof is invalid")
+	}
+
+	// Verify that the signature was made in the expected epoch
+	if int64(epoch) != sig.GetEpoch() {
+		return fmt.Errorf("signature invalid: it was made in epoch %d, expected epoch %d", sig.GetEpoch(), epoch)
+	}
+
+	// Verify that the revocation authority signed the epoch public key
+	err := VerifyEpochPK(revPk, sig.GetRevocationEpochPk(), sig.GetRevocationPkSig(), int(sig.GetEpoch()), RevocationAlgorithm(sig.GetNonRevocationProof().GetRevocationAlg()))
+	if err != nil {
+		return fmt.Errorf("signature invalid: %s", err)
+	}
+
+	// Signature is valid
+	return nil
+}
+
+// signatureProofData serializes the data hashed by the signature of knowledge
+func signatureProofData(t1, t2, t3, APrime, ABar, BPrime, Nym *amcl.ECP, ipkHash []byte, Disclosure []byte, msg []byte) []byte {
+	// proofData is the data being hashed, it consists of:
+	// the signature label
+	// 7 elements of G1 each taking 2*FieldBytes+1 bytes
+	// one bigint (hash of the issuer public key) of length FieldBytes
+	// disclosed attributes
+	// message being signed
+	proofData := make([]byte, len([]byte(signLabel))+7*(2*FieldBytes+1)+FieldBytes+len(Disclosure)+len(msg))
+	index := 0
+	index = appendBytesString(proofData, index, signLabel)
+	index = appendBytesG1(proofData, index, t1)
+	index = appendBytesG1(proofData, index, t2)
+	index = appendBytesG1(proofData, index, t3)
+	index = appendBytesG1(proofData, index, APrime)
+	index = appendBytesG1(proofData, index, ABar)
+	index = appendBytesG1(proofData, index, BPrime)
+	index = appendBytesG1(proofData, index, Nym)
+	copy(proofData[index:], ipkHash)
+	index = index + FieldBytes
+	index = appendBytes(proofData, index, Disclosure)
+	copy(proofData[index:], msg)
+	return proofData
+}
+
+// challengeWithNonce combines a Fiat-Shamir hash with a fresh nonce,
+// the result is hashed again to obtain the final challenge
+func challengeWithNonce(c, Nonce *amcl.BIG) []byte {
+	data := make([]byte, 2*FieldBytes)
+	index := 0
+	index = appendBytesBig(data, index, c)
+	appendBytesBig(data, index, Nonce)
+	return data
+}
@@ -0,0 +1,162 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idemix
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+
+	"github.com/manudrijvers/amcl/go"
+)
+
+// GenG1 is a generator of Group G1
+var GenG1 = amcl.NewECPbigs(
+	amcl.NewBIGints(amcl.CURVE_Gx),
+	amcl.NewBIGints(amcl.CURVE_Gy))
+
+// GenG2 is a generator of Group G2
+var GenG2 = amcl.NewECP2fp2s(
+	amcl.NewFP2bigs(amcl.NewBIGints(amcl.CURVE_Pxa), amcl.NewBIGints(amcl.CURVE_Pxb)),
+	amcl.NewFP2bigs(amcl.NewBIGints(amcl.CURVE_Pya), amcl.NewBIGints(amcl.CURVE_Pyb)))
+
+// GenGT is a generator of Group GT
+var GenGT = amcl.Fexp(amcl.Ate(GenG2, GenG1))
+
+// GroupOrder is the order of the groups
+var GroupOrder = amcl.NewBIGints(amcl.CURVE_Order)
+
+// FieldBytes is the bytelength of the group order
+var FieldBytes = int(amcl.MODBYTES)
+
+// RandModOrder returns a random element in 0, ..., GroupOrder-1
+func RandModOrder(rng *amcl.RAND) *amcl.BIG {
+	return amcl.Randomnum(amcl.NewBIGcopy(GroupOrder), rng)
+}
+
+// HashModOrder hashes data into 0, ..., GroupOrder-1
+func HashModOrder(data []byte) *amcl.BIG {
+	digest := sha256.Sum256(data)
+	digestBig := BigFromBytes(digest[:])
+	digestBig.Mod(GroupOrder)
+	return digestBig
+}
+
+// BigFromBytes converts a big-endian byte slice into an *amcl.BIG.
+// Slices shorter than FieldBytes are left-padded with zeros and longer
+// slices are truncated to their last FieldBytes bytes, so that
+// malformed input never makes the conversion panic
+func BigFromBytes(b []byte) *amcl.BIG {
+	if len(b) > FieldBytes {
+		b = b[len(b)-FieldBytes:]
+	}
+	padded := make([]byte, FieldBytes)
+	copy(padded[FieldBytes-len(b):], b)
+	return amcl.FromBytes(padded)
+}
+
+// BigToBytes takes an *amcl.BIG and returns a []byte representation
+func BigToBytes(big *amcl.BIG) []byte {
+	ret := make([]byte, FieldBytes)
+	big.ToBytes(ret)
+	return ret
+}
+
+// EcpToProto converts a *amcl.ECP into the proto struct *ECP
+func EcpToProto(p *amcl.ECP) *ECP {
+	return &ECP{
+		X: BigToBytes(p.GetX()),
+		Y: BigToBytes(p.GetY())}
+}
+
+// EcpFromProto converts a proto struct *ECP into an *amcl.ECP;
+// coordinates that do not lie on the curve yield the point at infinity
+func EcpFromProto(p *ECP) *amcl.ECP {
+	return amcl.NewECPbigs(BigFromBytes(p.GetX()), BigFromBytes(p.GetY()))
+}
+
+// Ecp2ToProto converts a *amcl.ECP2 into the proto struct *ECP2
+func Ecp2ToProto(p *amcl.ECP2) *ECP2 {
+	return &ECP2{
+		Xa: BigToBytes(p.GetX().GetA()),
+		Xb: BigToBytes(p.GetX().GetB()),
+		Ya: BigToBytes(p.GetY().GetA()),
+		Yb: BigToBytes(p.GetY().GetB())}
+}
+
+// Ecp2FromProto converts a proto struct *ECP2 into an *amcl.ECP2;
+// coordinates that do not lie on the curve yield the point at infinity
+func Ecp2FromProto(p *ECP2) *amcl.ECP2 {
+	return amcl.NewECP2fp2s(
+		amcl.NewFP2bigs(BigFromBytes(p.GetXa()), BigFromBytes(p.GetXb())),
+		amcl.NewFP2bigs(BigFromBytes(p.GetYa()), BigFromBytes(p.GetYb())))
+}
+
+// MakeNym creates a new unlinkable pseudonym
+func MakeNym(sk *amcl.BIG, IPk *IssuerPublicKey, rng *amcl.RAND) (*amcl.ECP, *amcl.BIG) {
+	// Construct a commitment to the sk
+	// Nym = h_{sk}^sk \cdot h_r^r
+	RandNym := RandModOrder(rng)
+	Nym := EcpFromProto(IPk.HSk).Mul2(sk, EcpFromProto(IPk.HRand), RandNym)
+	return Nym, RandNym
+}
+
+// GetRand returns a new *amcl.RAND with a fresh seed
+func GetRand() (*amcl.RAND, error) {
+	seedLength := 32
+	b := make([]byte, seedLength)
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, err
+	}
+	rng := amcl.NewRAND()
+	rng.Clean()
+	rng.Seed(seedLength, b)
+	return rng, nil
+}
+
+// appendBytes appends data to dest at the given index and returns
+// the index right after the appended data
+func appendBytes(dest []byte, index int, data []byte) int {
+	copy(dest[index:], data)
+	return index + len(data)
+}
+
+// appendBytesG1 appends the serialization of a G1 element to dest
+func appendBytesG1(dest []byte, index int, E *amcl.ECP) int {
+	length := 2*FieldBytes + 1
+	E.ToBytes(dest[index : index+length])
+	return index + length
+}
+
+// appendBytesG2 appends the serialization of a G2 element to dest
+func appendBytesG2(dest []byte, index int, E *amcl.ECP2) int {
+	length := 4 * FieldBytes
+	E.ToBytes(dest[index : index+length])
+	return index + length
+}
+
+// appendBytesBig appends the serialization of a big integer to dest
+func appendBytesBig(dest []byte, index int, B *amcl.BIG) int {
+	length := FieldBytes
+	B.ToBytes(dest[index : index+length])
+	return index + length
+}
+
+// appendBytesString appends a string to dest
+func appendBytesString(dest []byte, index int, data string) int {
+	return appendBytes(dest, index, []byte(data))
+}
@@ -38,8 +38,19 @@ type OrganizationalUnitIdentifiersConfiguration struct {
 	OrganizationalUnitIdentifier string `yaml:"OrganizationalUnitIdentifier,omitempty"`
 }
 
+// NodeOUs contains the information on how to tell apart clients, peers,
+// orderers and admins based on the OUs of their certificates
+type NodeOUs struct {
+	Enable              bool                                        `yaml:"Enable,omitempty"`
+	ClientOUIdentifier  *OrganizationalUnitIdentifiersConfiguration `yaml:"ClientOUIdentifier,omitempty"`
+	PeerOUIdentifier    *OrganizationalUnitIdentifiersConfiguration `yaml:"PeerOUIdentifier,omitempty"`
+	AdminOUIdentifier   *OrganizationalUnitIdentifiersConfiguration `yaml:"AdminOUIdentifier,omitempty"`
+	OrdererOUIdentifier *OrganizationalUnitIdentifiersConfiguration `yaml:"OrdererOUIdentifier,omitempty"`
+}
+
 type Configuration struct {
 	OrganizationalUnitIdentifiers []*OrganizationalUnitIdentifiersConfiguration `yaml:"OrganizationalUnitIdentifiers,omitempty"`
+	NodeOUs                       *NodeOUs                                      `yaml:"NodeOUs,omitempty"`
 }
 
 func readFile(file string) ([]byte, error) {
@@ -111,6 +122,14 @@ const (
 	tlsintermediatecerts = "tlsintermediatecerts"
 )
 
+const (
+	IdemixConfigDirMsp                  = "msp"
+	IdemixConfigDirUser                 = "user"
+	IdemixConfigFileIssuerPublicKey     = "IssuerPublicKey"
+	IdemixConfigFileRevocationPublicKey = "RevocationPublicKey"
+	IdemixConfigFileSigner              = "SignerConfig"
+)
+
 func SetupBCCSPKeystoreConfig(bccspConfig *factory.FactoryOpts, keystoreDir string) *factory.FactoryOpts {
 	if bccspConfig == nil {
 		bccspConfig = factory.GetDefaultOpts()
@@ -121,11 +140,14 @@ func SetupBCCSPKeystoreConfig(bccspConfig *factory.FactoryOpts, keystoreDir stri
 			bccspConfig.SwOpts = factory.GetDefaultOpts().SwOpts
 		}
 
-		// Only override the KeyStorePath if it was left empty
-		if bccspConfig.SwOpts.FileKeystore == nil ||
-			bccspConfig.SwOpts.FileKeystore.KeyStorePath == "" {
+		// Only override the KeyStorePath if it was left empty,
+		// keeping the keystore passphrase if any
+		if bccspConfig.SwOpts.FileKeystore == nil {
 			bccspConfig.SwOpts.Ephemeral = false
 			bccspConfig.SwOpts.FileKeystore = &factory.FileKeystoreOpts{KeyStorePath: keystoreDir}
+		} else if bccspConfig.SwOpts.FileKeystore.KeyStorePath == "" {
+			bccspConfig.SwOpts.Ephemeral = false
+			bccspConfig.SwOpts.FileKeystore.KeyStorePath = keystoreDir
 		}
 	}
 
@@ -177,7 +199,7 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 	}
 
 	admincert, err := getPemMaterialFromDir(admincertDir)
-	if err != nil || len(admincert) == 0 {
+	if err != nil && !os.IsNotExist(err) {
 		return nil, fmt.Errorf("Could not load a valid admin certificate from directory %s, err %s", admincertDir, err)
 	}
 
@@ -216,6 +238,7 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 	// if the configuration file is there then load it
 	// otherwise skip it
 	var ouis []*msp.FabricOUIdentifier
+	var nodeOUs *msp.FabricNodeOUs
 	_, err = os.Stat(configFile)
 	if err == nil {
 		// load the file, if there is a failure in loading it then
@@ -246,10 +269,33 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 				ouis = append(ouis, oui)
 			}
 		}
+
+		// Prepare NodeOUs
+		if configuration.NodeOUs != nil && configuration.NodeOUs.Enable {
+			mspLogger.Debug("Loading NodeOUs")
+			nodeOUs = &msp.FabricNodeOUs{Enable: true}
+			if nodeOUs.ClientOuIdentifier, err = loadNodeOUIdentifier(dir, configuration.NodeOUs.ClientOUIdentifier); err != nil {
+				return nil, err
+			}
+			if nodeOUs.PeerOuIdentifier, err = loadNodeOUIdentifier(dir, configuration.NodeOUs.PeerOUIdentifier); err != nil {
+				return nil, err
+			}
+			if nodeOUs.AdminOuIdentifier, err = loadNodeOUIdentifier(dir, configuration.NodeOUs.AdminOUIdentifier); err != nil {
+				return nil, err
+			}
+			if nodeOUs.OrdererOuIdentifier, err = loadNodeOUIdentifier(dir, configuration.NodeOUs.OrdererOUIdentifier); err != nil {
+				return nil, err
+			}
+		}
 	} else {
 		mspLogger.Debugf("MSP configuration file not found at [%s]: [%s]", configFile, err)
 	}
 
+	// Admins are optional only if they can be told apart by the admin OU
+	if len(admincert) == 0 && (nodeOUs == nil || nodeOUs.AdminOuIdentifier == nil) {
+		return nil, fmt.Errorf("Could not load a valid admin certificate from directory %s", admincertDir)
+	}
+
 	// Set FabricCryptoConfig
 	cryptoConfig := &msp.FabricCryptoConfig{
 		SignatureHashFamily:            bccsp.SHA2,
@@ -268,6 +314,7 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 		CryptoConfig:                  cryptoConfig,
 		TlsRootCerts:                  tlsCACerts,
 		TlsIntermediateCerts:          tlsIntermediateCerts,
+		FabricNodeOus:                 nodeOUs,
 	}
 
 	fmpsjs, _ := proto.Marshal(fmspconf)
@@ -276,3 +323,61 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 
 	return mspconf, nil
 }
+
+// loadNodeOUIdentifier converts the configuration of a NodeOU into its
+// protobuf representation. The certificate of a NodeOU is optional
+func loadNodeOUIdentifier(dir string, ouID *OrganizationalUnitIdentifiersConfiguration) (*msp.FabricOUIdentifier, error) {
+	if ouID == nil {
+		return nil, nil
+	}
+	oui := &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: ouID.OrganizationalUnitIdentifier}
+	if len(ouID.Certificate) != 0 {
+		f := filepath.Join(dir, ouID.Certificate)
+		raw, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading NodeOU certificate at [%s]: [%s]", f, err)
+		}
+		oui.Certificate = raw
+	}
+	return oui, nil
+}
+
+// GetIdemixMspConfig returns the configuration for the Idemix MSP
+// whose material is stored in dir; the signer configuration is
+// optional, without it the MSP can only verify identities
+func GetIdemixMspConfig(dir string, ID string) (*msp.MSPConfig, error) {
+	ipkBytes, err := readFile(filepath.Join(dir, IdemixConfigDirMsp, IdemixConfigFileIssuerPublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issuer public key file: %s", err)
+	}
+
+	revocationPkBytes, err := readPemFile(filepath.Join(dir, IdemixConfigDirMsp, IdemixConfigFileRevocationPublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read revocation public key file: %s", err)
+	}
+
+	idemixConfig := &msp.IdemixMSPConfig{
+		Name:         ID,
+		Ipk:          ipkBytes,
+		RevocationPk: revocationPkBytes,
+	}
+
+	signerBytes, err := ioutil.ReadFile(filepath.Join(dir, IdemixConfigDirUser, IdemixConfigFileSigner))
+	if err == nil {
+		signerConfig := &msp.IdemixMSPSignerConfig{}
+		err = proto.Unmarshal(signerBytes, signerConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the idemix signer config: %s", err)
+		}
+		idemixConfig.Signer = signerConfig
+	} else {
+		mspLogger.Debugf("No idemix signer config found in %s, setting up a verification only MSP", dir)
+	}
+
+	confBytes, err := proto.Marshal(idemixConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &msp.MSPConfig{Config: confBytes, Type: int32(IDEMIX)}, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package msp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CRLUpdater is implemented by MSPs whose certificate revocation
+// lists can be replaced at runtime
+type CRLUpdater interface {
+	// UpdateCRLs replaces the CRLs of the MSP with the supplied
+	// PEM or DER encoded ones
+	UpdateCRLs(revocationList [][]byte) error
+}
+
+// WatchCRLs checks the crls folder of the MSP configuration directory dir
+// every interval, and updates the CRLs of m whenever its content changes.
+// It returns when stop is closed.
+func WatchCRLs(dir string, m CRLUpdater, interval time.Duration, stop <-chan struct{}) {
+	crlsDir := filepath.Join(dir, crlsfolder)
+	// the MSP was set up with the CRLs currently in the folder
+	crls, _ := readCRLs(crlsDir)
+	lastDigest := pemDigest(crls)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		crls, err := readCRLs(crlsDir)
+		if err != nil {
+			mspLogger.Warningf("Failed loading crls at [%s]: [%s]", crlsDir, err)
+			continue
+		}
+		digest := pemDigest(crls)
+		if bytes.Equal(digest, lastDigest) {
+			continue
+		}
+
+		// the same content is not retried until the folder changes again
+		lastDigest = digest
+		mspLogger.Infof("crls folder at [%s] changed, updating CRLs", crlsDir)
+		if err := m.UpdateCRLs(crls); err != nil {
+			mspLogger.Errorf("Failed updating CRLs from [%s], keeping the current ones: [%s]", crlsDir, err)
+		}
+	}
+}
+
+// readCRLs returns the CRLs in crlsDir; a missing
+// folder is the same as an empty one
+func readCRLs(crlsDir string) ([][]byte, error) {
+	crls, err := getPemMaterialFromDir(crlsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return crls, err
+}
+
+// pemDigest returns a digest of the given PEM materials
+func pemDigest(materials [][]byte) []byte {
+	h := sha256.New()
+	for _, material := range materials {
+		d := sha256.Sum256(material)
+		h.Write(d[:])
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package msp
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchCRLs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "crlwatcher")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCA(t)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, cacerts), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, cacerts, "ca.pem"), ca.pem(), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, admincerts), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, admincerts, "admin.pem"), ca.issue(t, 2), 0644))
+
+	conf, err := GetVerifyingMspConfig(dir, "TestMSP")
+	assert.NoError(t, err)
+	thisMSP, err := NewBccspMsp()
+	assert.NoError(t, err)
+	assert.NoError(t, thisMSP.Setup(conf))
+
+	id := deserialize(t, thisMSP, ca.issue(t, 10))
+	assert.NoError(t, id.Validate())
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		WatchCRLs(dir, thisMSP.(CRLUpdater), 10*time.Millisecond, stop)
+		close(done)
+	}()
+	// let the watcher take its initial snapshot of the (missing) crls folder
+	time.Sleep(100 * time.Millisecond)
+
+	// the crls folder is created and a CRL revoking the identity is added
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, crlsfolder), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, crlsfolder, "crl.pem"), ca.crl(t, 10), 0644))
+	assert.True(t, waitFor(func() bool { return id.Validate() != nil }), "identity should have been revoked")
+
+	// an invalid CRL leaves the current ones in place
+	invalid := pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: []byte("garbage")})
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, crlsfolder, "invalid.pem"), invalid, 0644))
+	time.Sleep(100 * time.Millisecond)
+	assert.Error(t, id.Validate())
+
+	// removing the CRLs reinstates the identity
+	assert.NoError(t, os.RemoveAll(filepath.Join(dir, crlsfolder)))
+	assert.True(t, waitFor(func() bool { return id.Validate() == nil }), "identity should have been reinstated")
+
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("WatchCRLs did not return after stop was closed")
+	}
+}
+
+// waitFor polls cond until it holds or a timeout expires
+func waitFor(cond func() bool) bool {
+	timeout := time.After(5 * time.Second)
+	for !cond() {
+		select {
+		case <-timeout:
+			return false
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return true
+}
@@ -0,0 +1,138 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package msp
+
+import (
+	"crypto/ed25519"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
+	cryptogenmsp "github.com/hyperledger/fabric/common/tools/cryptogen/msp"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateEd25519MSP uses cryptogen to generate a local MSP whose
+// CA and signing identity use Ed25519 keys, and returns its directory
+func generateEd25519MSP(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "ed25519msp")
+	assert.NoError(t, err)
+
+	signCA, err := ca.NewCA(filepath.Join(dir, "ca"), "org1.example.com", "ca.org1.example.com", bccsp.ED25519)
+	assert.NoError(t, err)
+	tlsCA, err := ca.NewCA(filepath.Join(dir, "tlsca"), "org1.example.com", "tlsca.org1.example.com", bccsp.ECDSA)
+	assert.NoError(t, err)
+	err = cryptogenmsp.GenerateLocalMSP(dir, "peer0.org1.example.com", nil, signCA, tlsCA, "")
+	assert.NoError(t, err)
+
+	return filepath.Join(dir, "msp"), func() { os.RemoveAll(dir) }
+}
+
+func TestEd25519MSP(t *testing.T) {
+	dir, cleanup := generateEd25519MSP(t)
+	defer cleanup()
+	thisMSP := getLocalMSP(t, dir)
+
+	id, err := thisMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.IsType(t, ed25519.PublicKey{}, id.(*signingidentity).cert.PublicKey)
+	assert.NoError(t, thisMSP.Validate(id.GetPublicVersion()))
+
+	serializedID, err := id.Serialize()
+	assert.NoError(t, err)
+	deserializedID, err := thisMSP.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+
+	for _, hashFamily := range []string{bccsp.SHA2, bccsp.SHA3} {
+		thisMSP.(*bccspmsp).cryptoConfig.SignatureHashFamily = hashFamily
+
+		msg := []byte("foo")
+		sig, err := id.Sign(msg)
+		assert.NoError(t, err)
+
+		assert.NoError(t, id.Verify(msg, sig))
+		assert.NoError(t, deserializedID.Verify(msg, sig))
+		assert.Error(t, deserializedID.Verify([]byte("bar"), sig))
+
+		// The signature is a standard Ed25519 signature of the message
+		pubKey := id.(*signingidentity).cert.PublicKey.(ed25519.PublicKey)
+		assert.True(t, ed25519.Verify(pubKey, msg, sig))
+	}
+}
+
+func TestEd25519MSPv1_0(t *testing.T) {
+	dir, cleanup := generateEd25519MSP(t)
+	defer cleanup()
+	conf, err := GetLocalMspConfig(dir, nil, "DEFAULT")
+	assert.NoError(t, err)
+
+	thisMSP, err := newBccspMsp(MSPv1_0)
+	assert.NoError(t, err)
+	ks, err := sw.NewFileBasedKeyStore(nil, filepath.Join(dir, "keystore"), true)
+	assert.NoError(t, err)
+	csp, err := sw.New(256, "SHA2", ks)
+	assert.NoError(t, err)
+	thisMSP.(*bccspmsp).bccsp = csp
+
+	// Ed25519 identities, such as the admins, are only valid in v1.1 MSPs
+	err = thisMSP.Setup(conf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Ed25519 identities require MSP version v1.1")
+}
+
+func TestEd25519MSPKeyMaterial(t *testing.T) {
+	dir, cleanup := generateEd25519MSP(t)
+	defer cleanup()
+	conf, err := GetLocalMspConfig(dir, nil, "DEFAULT")
+	assert.NoError(t, err)
+
+	// pass the private key as key material rather than via the keystore
+	keyFiles, err := ioutil.ReadDir(filepath.Join(dir, "keystore"))
+	assert.NoError(t, err)
+	keyPEM, err := ioutil.ReadFile(filepath.Join(dir, "keystore", keyFiles[0].Name()))
+	assert.NoError(t, err)
+
+	fabricMSPConfig := &msp.FabricMSPConfig{}
+	err = proto.Unmarshal(conf.Config, fabricMSPConfig)
+	assert.NoError(t, err)
+	fabricMSPConfig.SigningIdentity.PrivateSigner = &msp.KeyInfo{KeyMaterial: keyPEM}
+	conf.Config, err = proto.Marshal(fabricMSPConfig)
+	assert.NoError(t, err)
+
+	thisMSP, err := NewBccspMsp()
+	assert.NoError(t, err)
+	csp, err := sw.New(256, "SHA2", sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	thisMSP.(*bccspmsp).bccsp = csp
+
+	err = thisMSP.Setup(conf)
+	assert.NoError(t, err)
+
+	id, err := thisMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+
+	msg := []byte("foo")
+	sig, err := id.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify(msg, sig))
+}
@@ -0,0 +1,658 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package msp
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/idemix"
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/manudrijvers/amcl/go"
+	"github.com/op/go-logging"
+)
+
+const (
+	// AttributeIndexOU contains the index of the OU attribute in the idemix credential attributes
+	AttributeIndexOU = iota
+
+	// AttributeIndexRole contains the index of the Role attribute in the idemix credential attributes
+	AttributeIndexRole
+
+	// AttributeIndexEnrollmentId contains the index of the Enrollment ID attribute in the idemix credential attributes
+	AttributeIndexEnrollmentId
+
+	// AttributeIndexRevocationHandle contains the index of the Revocation Handle attribute in the idemix credential attributes
+	AttributeIndexRevocationHandle
+)
+
+const (
+	// AttributeNameOU is the attribute name of the Organization Unit attribute
+	AttributeNameOU = "OU"
+
+	// AttributeNameRole is the attribute name of the Role attribute
+	AttributeNameRole = "Role"
+
+	// AttributeNameEnrollmentId is the attribute name of the Enrollment ID attribute
+	AttributeNameEnrollmentId = "EnrollmentID"
+
+	// AttributeNameRevocationHandle is the attribute name of the revocation handle attribute
+	AttributeNameRevocationHandle = "RevocationHandle"
+)
+
+// discloseFlags will be passed to the idemix signing and verification routines.
+// It informs idemix to disclose both attributes (OU and Role) when signing.
+var discloseFlags = []byte{1, 1, 0, 0}
+
+// This is an instantiation of an MSP that uses the Identity Mixer
+// anonymous credential scheme: identities are unlinkable pseudonyms
+// that only disclose their organizational unit and their role
+type idemixmsp struct {
+	// the provider identifier for this MSP
+	name string
+
+	// the public key of the issuer of the credentials
+	ipk *idemix.IssuerPublicKey
+
+	// the credential of the default signer (if any), from
+	// which fresh default signing identities are derived
+	signer *idemixSignerCredential
+
+	// the long term public key of the revocation authority, which
+	// signs the epoch of the credential revocation information.
+	// Only ALG_NO_REVOCATION is supported, so credentials cannot be revoked yet
+	revocationPK *ecdsa.PublicKey
+
+	// the current revocation epoch
+	epoch int
+}
+
+// idemixSignerCredential holds the credential and secret key of the default
+// signer of an idemix MSP, along with the attributes it discloses
+type idemixSignerCredential struct {
+	cred *idemix.Credential
+	sk   *amcl.BIG
+	cri  *idemix.CredentialRevocationInformation
+	role *m.MSPRole
+	ou   *m.OrganizationUnit
+}
+
+// NewIdemixMsp creates a new instance of idemixmsp
+func NewIdemixMsp() (MSP, error) {
+	mspLogger.Debugf("Creating Idemix-based MSP instance")
+
+	return &idemixmsp{}, nil
+}
+
+// Setup sets up the internal data structures
+// for this MSP, given an MSPConfig ref; it
+// returns nil in case of success or an error otherwise
+func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
+	mspLogger.Debugf("Setting up Idemix-based MSP instance")
+
+	if conf1 == nil {
+		return fmt.Errorf("Setup error: nil conf reference")
+	}
+
+	if conf1.Type != int32(IDEMIX) {
+		return fmt.Errorf("Setup error: config is not of type IDEMIX")
+	}
+
+	// given that it's an msp of type idemix, extract the IdemixMSPConfig instance
+	conf := &m.IdemixMSPConfig{}
+	err := proto.Unmarshal(conf1.Config, conf)
+	if err != nil {
+		return fmt.Errorf("Failed unmarshalling idemix msp config, err %s", err)
+	}
+
+	// set the name for this msp
+	msp.name = conf.Name
+	mspLogger.Debugf("Setting up Idemix MSP instance %s", msp.name)
+
+	// setup the issuer public key
+	ipk := &idemix.IssuerPublicKey{}
+	err = proto.Unmarshal(conf.Ipk, ipk)
+	if err != nil {
+		return fmt.Errorf("Failed to unmarshal ipk from idemix msp config, err %s", err)
+	}
+	if len(ipk.AttributeNames) != 4 ||
+		ipk.AttributeNames[AttributeIndexOU] != AttributeNameOU ||
+		ipk.AttributeNames[AttributeIndexRole] != AttributeNameRole ||
+		ipk.AttributeNames[AttributeIndexEnrollmentId] != AttributeNameEnrollmentId ||
+		ipk.AttributeNames[AttributeIndexRevocationHandle] != AttributeNameRevocationHandle {
+		return fmt.Errorf("Issuer public key must have attributes OU, Role, EnrollmentId, and RevocationHandle")
+	}
+	err = ipk.Check()
+	if err != nil {
+		return fmt.Errorf("Cannot setup idemix msp with invalid public key, err %s", err)
+	}
+	msp.ipk = ipk
+
+	// setup the revocation public key
+	blockPub, _ := pem.Decode(conf.RevocationPk)
+	if blockPub == nil {
+		return fmt.Errorf("Failed to decode the revocation public key")
+	}
+	revocationPk, err := x509.ParsePKIXPublicKey(blockPub.Bytes)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the revocation public key, err %s", err)
+	}
+	ecdsaPk, ok := revocationPk.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("The revocation public key is not an ECDSA public key")
+	}
+	msp.revocationPK = ecdsaPk
+	msp.epoch = int(conf.Epoch)
+
+	if conf.Signer == nil {
+		// No credential in config, so we don't setup a default signer
+		mspLogger.Debug("idemix msp setup as verification only msp (no key material found)")
+		return nil
+	}
+
+	// A credential is present in the config, so we setup a default signer
+	return msp.setupSigner(conf.Signer)
+}
+
+// setupSigner checks the credential of the default signer against the
+// issuer public key, and that the signing identities derived from it are valid
+func (msp *idemixmsp) setupSigner(conf *m.IdemixMSPSignerConfig) error {
+	cred := &idemix.Credential{}
+	err := proto.Unmarshal(conf.Cred, cred)
+	if err != nil {
+		return fmt.Errorf("Failed unmarshalling credential from config, err %s", err)
+	}
+
+	sk := idemix.BigFromBytes(conf.Sk)
+
+	if len(cred.Attrs) != 4 {
+		return fmt.Errorf("Credential contains %d attribute values, but expected 4", len(cred.Attrs))
+	}
+
+	// Check that the OU, role and enrollment ID in the config match the credential attributes
+	if !idemix.BigFromBytes(cred.Attrs[AttributeIndexOU]).Equals(idemix.HashModOrder([]byte(conf.OrganizationalUnitIdentifier))) {
+		return fmt.Errorf("Credential does not contain the correct OU attribute value")
+	}
+	if !idemix.BigFromBytes(cred.Attrs[AttributeIndexRole]).Equals(amcl.NewBIGint(int(conf.Role))) {
+		return fmt.Errorf("Credential does not contain the correct Role attribute value")
+	}
+	if !idemix.BigFromBytes(cred.Attrs[AttributeIndexEnrollmentId]).Equals(idemix.HashModOrder([]byte(conf.EnrollmentId))) {
+		return fmt.Errorf("Credential does not contain the correct Enrollment ID attribute value")
+	}
+
+	// Verify that the credential is cryptographically valid
+	err = cred.Ver(sk, msp.ipk)
+	if err != nil {
+		return fmt.Errorf("Credential is not cryptographically valid, err %s", err)
+	}
+
+	cri := &idemix.CredentialRevocationInformation{}
+	err = proto.Unmarshal(conf.CredentialRevocationInformation, cri)
+	if err != nil {
+		return fmt.Errorf("Failed unmarshalling credential revocation information, err %s", err)
+	}
+
+	// Set up default signer
+	msp.signer = &idemixSignerCredential{
+		cred: cred,
+		sk:   sk,
+		cri:  cri,
+		role: &m.MSPRole{
+			MspIdentifier: msp.name,
+			Role:          m.MSPRole_MSPRoleType(conf.Role),
+		},
+		ou: &m.OrganizationUnit{
+			MspIdentifier:                msp.name,
+			OrganizationalUnitIdentifier: conf.OrganizationalUnitIdentifier,
+			CertifiersIdentifier:         msp.ipk.Hash,
+		},
+	}
+
+	// Make sure that the default signer is a valid member of this MSP
+	signer, err := msp.newSigningIdentity()
+	if err == nil {
+		err = signer.Validate()
+	}
+	if err != nil {
+		msp.signer = nil
+		return fmt.Errorf("The default signer is not valid, err %s", err)
+	}
+
+	return nil
+}
+
+// newSigningIdentity derives a signing identity from the credential of the
+// default signer, with a fresh pseudonym and proof of membership, so that
+// the signatures of different signing identities cannot be linked together
+func (msp *idemixmsp) newSigningIdentity() (*idemixSigningIdentity, error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get a random number generator, err %s", err)
+	}
+
+	// Create the cryptographic evidence that this identity is valid
+	Nym, RandNym := idemix.MakeNym(msp.signer.sk, msp.ipk, rng)
+	proof, err := idemix.NewSignature(msp.signer.cred, msp.signer.sk, Nym, RandNym, msp.ipk,
+		discloseFlags, nil, AttributeIndexRevocationHandle, msp.signer.cri, rng)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to setup cryptographic proof of identity, err %s", err)
+	}
+
+	return &idemixSigningIdentity{
+		idemixidentity: newIdemixIdentity(msp, Nym, msp.signer.role, msp.signer.ou, proof),
+		Cred:           msp.signer.cred,
+		Sk:             msp.signer.sk,
+		RandNym:        RandNym,
+	}, nil
+}
+
+// GetType returns the type for this MSP
+func (msp *idemixmsp) GetType() ProviderType {
+	return IDEMIX
+}
+
+// GetIdentifier returns the MSP identifier for this instance
+func (msp *idemixmsp) GetIdentifier() (string, error) {
+	return msp.name, nil
+}
+
+// GetSigningIdentity returns a specific signing
+// identity identified by the supplied identifier
+func (msp *idemixmsp) GetSigningIdentity(identifier *IdentityIdentifier) (SigningIdentity, error) {
+	return nil, fmt.Errorf("GetSigningIdentity not implemented")
+}
+
+// GetDefaultSigningIdentity returns the default signing identity for this
+// MSP (if any). Each call returns an identity with a fresh pseudonym, so the
+// same identity should be used to create and to sign a message
+func (msp *idemixmsp) GetDefaultSigningIdentity() (SigningIdentity, error) {
+	mspLogger.Debugf("Obtaining default idemix signing identity")
+
+	if msp.signer == nil {
+		return nil, fmt.Errorf("This MSP does not possess a valid default signing identity")
+	}
+	signer, err := msp.newSigningIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// GetTLSRootCerts returns the root certificates for this MSP;
+// idemix MSPs do not carry any TLS material
+func (msp *idemixmsp) GetTLSRootCerts() [][]byte {
+	return nil
+}
+
+// GetTLSIntermediateCerts returns the intermediate root certificates for this MSP;
+// idemix MSPs do not carry any TLS material
+func (msp *idemixmsp) GetTLSIntermediateCerts() [][]byte {
+	return nil
+}
+
+// DeserializeIdentity returns an Identity given the byte-level
+// representation of a SerializedIdentity struct
+func (msp *idemixmsp) DeserializeIdentity(serializedID []byte) (Identity, error) {
+	sID := &m.SerializedIdentity{}
+	err := proto.Unmarshal(serializedID, sID)
+	if err != nil {
+		return nil, fmt.Errorf("Could not deserialize a SerializedIdentity, err %s", err)
+	}
+
+	if sID.Mspid != msp.name {
+		return nil, fmt.Errorf("Expected MSP ID %s, received %s", msp.name, sID.Mspid)
+	}
+
+	return msp.deserializeIdentityInternal(sID.GetIdBytes())
+}
+
+// deserializeIdentityInternal returns an identity given its byte-level representation
+func (msp *idemixmsp) deserializeIdentityInternal(serializedID []byte) (Identity, error) {
+	mspLogger.Debug("idemixmsp: deserializing identity")
+
+	serialized := new(m.SerializedIdemixIdentity)
+	err := proto.Unmarshal(serializedID, serialized)
+	if err != nil {
+		return nil, fmt.Errorf("Could not deserialize a SerializedIdemixIdentity, err %s", err)
+	}
+	if serialized.NymX == nil || serialized.NymY == nil {
+		return nil, fmt.Errorf("Unable to deserialize idemix identity: pseudonym is invalid")
+	}
+	Nym := amcl.NewECPbigs(idemix.BigFromBytes(serialized.NymX), idemix.BigFromBytes(serialized.NymY))
+	if Nym.Is_infinity() {
+		return nil, fmt.Errorf("Unable to deserialize idemix identity: pseudonym is not a valid curve point")
+	}
+
+	ou := &m.OrganizationUnit{}
+	err = proto.Unmarshal(serialized.Ou, ou)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot deserialize the OU of the identity, err %s", err)
+	}
+	role := &m.MSPRole{}
+	err = proto.Unmarshal(serialized.Role, role)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot deserialize the role of the identity, err %s", err)
+	}
+	proof := &idemix.Signature{}
+	err = proto.Unmarshal(serialized.Proof, proof)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot deserialize the proof of the identity, err %s", err)
+	}
+
+	return newIdemixIdentity(msp, Nym, role, ou, proof), nil
+}
+
+// Validate attempts to determine whether
+// the supplied identity is valid according
+// to this MSP's issuer public key; it returns
+// nil in case the identity is valid or an
+// error otherwise
+func (msp *idemixmsp) Validate(id Identity) error {
+	var identity *idemixidentity
+	switch t := id.(type) {
+	case *idemixidentity:
+		identity = t
+	case *idemixSigningIdentity:
+		identity = t.idemixidentity
+	default:
+		return fmt.Errorf("Identity type not recognized")
+	}
+
+	mspLogger.Debugf("Validating identity %+v", identity)
+	if identity.GetMSPIdentifier() != msp.name {
+		return fmt.Errorf("The supplied identity does not belong to this msp")
+	}
+	return msp.verifyProof(identity)
+}
+
+// SatisfiesPrincipal returns null if the identity matches the principal or an error otherwise
+func (msp *idemixmsp) SatisfiesPrincipal(id Identity, principal *m.MSPPrincipal) error {
+	err := msp.Validate(id)
+	if err != nil {
+		return fmt.Errorf("Identity is not valid with respect to this MSP, err %s", err)
+	}
+
+	identity, ok := id.(*idemixidentity)
+	if !ok {
+		identity = id.(*idemixSigningIdentity).idemixidentity
+	}
+
+	switch principal.PrincipalClassification {
+	case m.MSPPrincipal_ROLE:
+		// in this case, we have to check whether the
+		// identity has a role in the msp
+		mspRole := &m.MSPRole{}
+		err := proto.Unmarshal(principal.Principal, mspRole)
+		if err != nil {
+			return fmt.Errorf("Could not unmarshal MSPRole from principal, err %s", err)
+		}
+
+		// at first, we check whether the MSP
+		// identifier is the same as that of the identity
+		if mspRole.MspIdentifier != msp.name {
+			return fmt.Errorf("The identity is a member of a different MSP (expected %s, got %s)", mspRole.MspIdentifier, id.GetMSPIdentifier())
+		}
+
+		// now we validate the different msp roles
+		switch mspRole.Role {
+		case m.MSPRole_MEMBER:
+			// in the case of member, we simply check
+			// whether this identity is valid for the MSP,
+			// which we already did above
+			mspLogger.Debugf("Checking if identity satisfies MEMBER role for %s", msp.name)
+			return nil
+		case m.MSPRole_ADMIN, m.MSPRole_CLIENT, m.MSPRole_PEER, m.MSPRole_ORDERER:
+			// the role is certified by the issuer as an attribute of the credential
+			mspLogger.Debugf("Checking if identity satisfies %s role for %s", mspRole.Role, msp.name)
+			if identity.Role.Role != mspRole.Role {
+				return fmt.Errorf("The identity is not a %s under this MSP [%s]", mspRole.Role, msp.name)
+			}
+			return nil
+		default:
+			return fmt.Errorf("Invalid MSP role type %d", int32(mspRole.Role))
+		}
+	case m.MSPPrincipal_IDENTITY:
+		// in this case we have to deserialize the principal's identity
+		// and compare its pseudonym with ours
+		principalId, err := msp.DeserializeIdentity(principal.Principal)
+		if err != nil {
+			return fmt.Errorf("Invalid identity principal, err %s", err)
+		}
+
+		if !principalId.(*idemixidentity).Nym.Equals(identity.Nym) {
+			return errors.New("The identities do not match")
+		}
+		return nil
+	case m.MSPPrincipal_ORGANIZATION_UNIT:
+		// Principal contains the OrganizationUnit
+		ou := &m.OrganizationUnit{}
+		err := proto.Unmarshal(principal.Principal, ou)
+		if err != nil {
+			return fmt.Errorf("Could not unmarshal OrganizationUnit from principal, err %s", err)
+		}
+
+		// at first, we check whether the MSP
+		// identifier is the same as that of the identity
+		if ou.MspIdentifier != msp.name {
+			return fmt.Errorf("The identity is a member of a different MSP (expected %s, got %s)", ou.MspIdentifier, id.GetMSPIdentifier())
+		}
+
+		if ou.OrganizationalUnitIdentifier != identity.OU.OrganizationalUnitIdentifier ||
+			!bytes.Equal(ou.CertifiersIdentifier, msp.ipk.Hash) {
+			return errors.New("The identities do not match")
+		}
+		return nil
+	default:
+		return fmt.Errorf("Invalid principal type %d", int32(principal.PrincipalClassification))
+	}
+}
+
+// verifyProof checks that the association proof of the identity was made
+// with its pseudonym and certifies its OU and role under the issuer public
+// key of this MSP. The revocation handle of the credential is not checked,
+// as the revocation information of ALG_NO_REVOCATION only certifies the epoch
+func (msp *idemixmsp) verifyProof(id *idemixidentity) error {
+	if id.OU.MspIdentifier != msp.name || !bytes.Equal(id.OU.CertifiersIdentifier, msp.ipk.Hash) {
+		return errors.New("The OU of the identity was not certified by this MSP")
+	}
+	if id.Role.MspIdentifier != msp.name {
+		return errors.New("The role of the identity was not certified by this MSP")
+	}
+
+	// the proof must have been generated for this very pseudonym
+	if !idemix.EcpFromProto(id.associationProof.GetNym()).Equals(id.Nym) {
+		return errors.New("The proof of the identity was not made with its pseudonym")
+	}
+
+	attributeValues := make([]*amcl.BIG, len(discloseFlags))
+	attributeValues[AttributeIndexOU] = idemix.HashModOrder([]byte(id.OU.OrganizationalUnitIdentifier))
+	attributeValues[AttributeIndexRole] = amcl.NewBIGint(int(id.Role.Role))
+
+	return id.associationProof.Ver(
+		discloseFlags,
+		msp.ipk,
+		nil,
+		attributeValues,
+		AttributeIndexRevocationHandle,
+		msp.revocationPK,
+		msp.epoch,
+	)
+}
+
+type idemixidentity struct {
+	// Nym is the pseudonym of this identity, it is used to verify its signatures
+	Nym *amcl.ECP
+
+	// msp is the MSP that "owns" this identity
+	msp *idemixmsp
+
+	// id contains the identifier (MSPID and identity identifier) for this instance
+	id *IdentityIdentifier
+
+	// Role is the role of this identity, certified by the issuer
+	Role *m.MSPRole
+
+	// OU is the organizational unit of this identity, certified by the issuer
+	OU *m.OrganizationUnit
+
+	// associationProof contains cryptographic proof that this identity
+	// belongs to the MSP id.msp, i.e., it proves that the pseudonym
+	// is constructed from a secret key on which the CA issued a credential.
+	associationProof *idemix.Signature
+}
+
+func newIdemixIdentity(msp *idemixmsp, Nym *amcl.ECP, role *m.MSPRole, ou *m.OrganizationUnit, proof *idemix.Signature) *idemixidentity {
+	id := &idemixidentity{
+		Nym:              Nym,
+		msp:              msp,
+		Role:             role,
+		OU:               ou,
+		associationProof: proof,
+	}
+
+	// The identity identifier is the serialized pseudonym
+	id.id = &IdentityIdentifier{
+		Mspid: msp.name,
+		Id:    hex.EncodeToString(append(idemix.BigToBytes(Nym.GetX()), idemix.BigToBytes(Nym.GetY())...))}
+
+	return id
+}
+
+// SatisfiesPrincipal returns null if this instance matches the supplied principal or an error otherwise
+func (id *idemixidentity) SatisfiesPrincipal(principal *m.MSPPrincipal) error {
+	return id.msp.SatisfiesPrincipal(id, principal)
+}
+
+// GetIdentifier returns the identifier (MSPID/IDID) for this instance
+func (id *idemixidentity) GetIdentifier() *IdentityIdentifier {
+	return id.id
+}
+
+// GetMSPIdentifier returns the MSP identifier for this instance
+func (id *idemixidentity) GetMSPIdentifier() string {
+	return id.msp.name
+}
+
+// Validate returns nil if this instance is a valid identity or an error otherwise
+func (id *idemixidentity) Validate() error {
+	return id.msp.Validate(id)
+}
+
+// GetOrganizationalUnits returns the OU for this instance
+func (id *idemixidentity) GetOrganizationalUnits() []*OUIdentifier {
+	// we use the (serialized) public key of this MSP as the CertifiersIdentifier
+	return []*OUIdentifier{
+		{
+			CertifiersIdentifier:         id.msp.ipk.Hash,
+			OrganizationalUnitIdentifier: id.OU.OrganizationalUnitIdentifier,
+		},
+	}
+}
+
+// Verify checks against a signature and a message
+// to determine whether this identity produced the
+// signature; it returns nil if so or an error otherwise
+func (id *idemixidentity) Verify(msg []byte, sig []byte) error {
+	if mspIdentityLogger.IsEnabledFor(logging.DEBUG) {
+		mspIdentityLogger.Debugf("Verify Idemix sig: msg = %s", hex.Dump(msg))
+		mspIdentityLogger.Debugf("Verify Idemix sig: sig = %s", hex.Dump(sig))
+	}
+
+	signature := new(idemix.NymSignature)
+	err := proto.Unmarshal(sig, signature)
+	if err != nil {
+		return fmt.Errorf("Could not unmarshal the nym signature, err %s", err)
+	}
+	return signature.Ver(id.Nym, id.msp.ipk, msg)
+}
+
+// Serialize returns a byte array representation of this identity
+func (id *idemixidentity) Serialize() ([]byte, error) {
+	serialized := &m.SerializedIdemixIdentity{}
+	serialized.NymX = idemix.BigToBytes(id.Nym.GetX())
+	serialized.NymY = idemix.BigToBytes(id.Nym.GetY())
+
+	ouBytes, err := proto.Marshal(id.OU)
+	if err != nil {
+		return nil, fmt.Errorf("Could not marshal OU of identity %s, err %s", id.id, err)
+	}
+	roleBytes, err := proto.Marshal(id.Role)
+	if err != nil {
+		return nil, fmt.Errorf("Could not marshal role of identity %s, err %s", id.id, err)
+	}
+	proofBytes, err := proto.Marshal(id.associationProof)
+	if err != nil {
+		return nil, fmt.Errorf("Could not marshal proof of identity %s, err %s", id.id, err)
+	}
+
+	serialized.Ou = ouBytes
+	serialized.Role = roleBytes
+	serialized.Proof = proofBytes
+
+	idemixIDBytes, err := proto.Marshal(serialized)
+	if err != nil {
+		return nil, fmt.Errorf("Could not marshal identity %s, err %s", id.id, err)
+	}
+
+	sID := &m.SerializedIdentity{Mspid: id.GetMSPIdentifier(), IdBytes: idemixIDBytes}
+	idBytes, err := proto.Marshal(sID)
+	if err != nil {
+		return nil, fmt.Errorf("Could not marshal a SerializedIdentity structure for identity %s, err %s", id.id, err)
+	}
+
+	return idBytes, nil
+}
+
+type idemixSigningIdentity struct {
+	*idemixidentity
+
+	// Cred is the credential issued to this identity
+	Cred *idemix.Credential
+
+	// Sk is the secret key of this identity
+	Sk *amcl.BIG
+
+	// RandNym is the randomness used to create the pseudonym of this identity
+	RandNym *amcl.BIG
+}
+
+// Sign produces a pseudonym signature on the supplied message
+func (id *idemixSigningIdentity) Sign(msg []byte) ([]byte, error) {
+	mspLogger.Debugf("Idemix identity %s is signing", id.GetIdentifier())
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get a random number generator, err %s", err)
+	}
+
+	sig, err := idemix.NewNymSignature(id.Sk, id.Nym, id.RandNym, id.msp.ipk, msg, rng)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(sig)
+}
+
+// GetPublicVersion returns the public part of this signing identity
+func (id *idemixSigningIdentity) GetPublicVersion() Identity {
+	return id.idemixidentity
+}
@@ -0,0 +1,401 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package msp
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupIdemixMSP(t *testing.T, dir string, ID string) (MSP, error) {
+	conf, err := GetIdemixMspConfig(dir, ID)
+	assert.NoError(t, err)
+
+	thisMSP, err := New(IDEMIX, MSPv1_1)
+	assert.NoError(t, err)
+	return thisMSP, thisMSP.Setup(conf)
+}
+
+func getIdemixMSP(t *testing.T, dir string, ID string) MSP {
+	thisMSP, err := setupIdemixMSP(t, dir, ID)
+	assert.NoError(t, err)
+	return thisMSP
+}
+
+func getIdemixSigner(t *testing.T, thisMSP MSP) SigningIdentity {
+	signer, err := thisMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	return signer
+}
+
+func TestIdemixSetup(t *testing.T) {
+	thisMSP := getIdemixMSP(t, "testdata/idemix/MSP1OU1", "MSP1OU1")
+	assert.Equal(t, IDEMIX, thisMSP.GetType())
+	id, err := thisMSP.GetIdentifier()
+	assert.NoError(t, err)
+	assert.Equal(t, "MSP1OU1", id)
+	assert.Nil(t, thisMSP.GetTLSRootCerts())
+	assert.Nil(t, thisMSP.GetTLSIntermediateCerts())
+
+	_, err = thisMSP.GetSigningIdentity(nil)
+	assert.Error(t, err)
+
+	// a verifier MSP has no default signing identity
+	verifier := getIdemixMSP(t, "testdata/idemix/MSP1Verifier", "MSP1OU1")
+	_, err = verifier.GetDefaultSigningIdentity()
+	assert.Error(t, err)
+
+	_, err = GetIdemixMspConfig("testdata/idemix/nonexistent", "MSP1OU1")
+	assert.Error(t, err)
+
+	err = thisMSP.Setup(nil)
+	assert.Error(t, err)
+
+	conf, err := GetIdemixMspConfig("testdata/idemix/MSP1OU1", "MSP1OU1")
+	assert.NoError(t, err)
+
+	// wrong MSP type
+	conf.Type = int32(FABRIC)
+	err = thisMSP.Setup(conf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not of type IDEMIX")
+}
+
+func TestIdemixSetupBadConfig(t *testing.T) {
+	getConf := func() (*msp.MSPConfig, *msp.IdemixMSPConfig) {
+		conf, err := GetIdemixMspConfig("testdata/idemix/MSP1OU1", "MSP1OU1")
+		assert.NoError(t, err)
+		idemixConf := &msp.IdemixMSPConfig{}
+		assert.NoError(t, proto.Unmarshal(conf.Config, idemixConf))
+		return conf, idemixConf
+	}
+	setup := func(conf *msp.MSPConfig, idemixConf *msp.IdemixMSPConfig) error {
+		var err error
+		conf.Config, err = proto.Marshal(idemixConf)
+		assert.NoError(t, err)
+		thisMSP, err := NewIdemixMsp()
+		assert.NoError(t, err)
+		return thisMSP.Setup(conf)
+	}
+
+	// issuer public key with a broken proof
+	conf, idemixConf := getConf()
+	ipk := &idemix.IssuerPublicKey{}
+	assert.NoError(t, proto.Unmarshal(idemixConf.Ipk, ipk))
+	ipk.ProofC = ipk.ProofS
+	idemixConf.Ipk, _ = proto.Marshal(ipk)
+	err := setup(conf, idemixConf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid public key")
+
+	// issuer public key with the wrong attributes
+	conf, idemixConf = getConf()
+	ipk = &idemix.IssuerPublicKey{}
+	assert.NoError(t, proto.Unmarshal(idemixConf.Ipk, ipk))
+	ipk.AttributeNames = ipk.AttributeNames[:3]
+	idemixConf.Ipk, _ = proto.Marshal(ipk)
+	err = setup(conf, idemixConf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must have attributes")
+
+	// missing revocation public key
+	conf, idemixConf = getConf()
+	idemixConf.RevocationPk = nil
+	assert.Error(t, setup(conf, idemixConf))
+
+	// signer config not matching the credential
+	conf, idemixConf = getConf()
+	idemixConf.Signer.OrganizationalUnitIdentifier = "OU2"
+	err = setup(conf, idemixConf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "OU attribute")
+
+	conf, idemixConf = getConf()
+	idemixConf.Signer.Role = int32(msp.MSPRole_ADMIN)
+	err = setup(conf, idemixConf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Role attribute")
+
+	conf, idemixConf = getConf()
+	idemixConf.Signer.EnrollmentId = "someoneelse"
+	err = setup(conf, idemixConf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Enrollment ID attribute")
+
+	// signer secret key not matching the credential
+	conf, idemixConf = getConf()
+	idemixConf.Signer.Sk = idemixConf.Signer.Sk[1:]
+	err = setup(conf, idemixConf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not cryptographically valid")
+
+	// credential from another issuer
+	conf, idemixConf = getConf()
+	otherConf, err := GetIdemixMspConfig("testdata/idemix/MSP2OU1", "MSP1OU1")
+	assert.NoError(t, err)
+	otherIdemixConf := &msp.IdemixMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(otherConf.Config, otherIdemixConf))
+	idemixConf.Signer = otherIdemixConf.Signer
+	assert.Error(t, setup(conf, idemixConf))
+
+	// epoch not matching the CRI of the signer
+	conf, idemixConf = getConf()
+	idemixConf.Epoch = 1
+	err = setup(conf, idemixConf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "default signer is not valid")
+}
+
+func TestIdemixSignVerify(t *testing.T) {
+	thisMSP := getIdemixMSP(t, "testdata/idemix/MSP1OU1", "MSP1OU1")
+	signer := getIdemixSigner(t, thisMSP)
+
+	msg := []byte("TestMessage")
+	sig, err := signer.Sign(msg)
+	assert.NoError(t, err)
+
+	assert.NoError(t, signer.Verify(msg, sig))
+	assert.NoError(t, signer.GetPublicVersion().Verify(msg, sig))
+	assert.Error(t, signer.Verify([]byte("OtherMessage"), sig))
+	assert.Error(t, signer.Verify(msg, []byte("not a signature")))
+
+	// a signature does not verify under another identity
+	otherSigner := getIdemixSigner(t, getIdemixMSP(t, "testdata/idemix/MSP1OU2", "MSP1OU1"))
+	assert.Error(t, otherSigner.Verify(msg, sig))
+}
+
+func TestIdemixUnlinkableSigningIdentities(t *testing.T) {
+	thisMSP := getIdemixMSP(t, "testdata/idemix/MSP1OU1", "MSP1OU1")
+	signer1 := getIdemixSigner(t, thisMSP)
+	signer2 := getIdemixSigner(t, thisMSP)
+
+	// each default signing identity has its own pseudonym and proof
+	serializedID1, err := signer1.Serialize()
+	assert.NoError(t, err)
+	serializedID2, err := signer2.Serialize()
+	assert.NoError(t, err)
+	assert.NotEqual(t, serializedID1, serializedID2)
+	assert.NotEqual(t, signer1.GetIdentifier(), signer2.GetIdentifier())
+	assert.NoError(t, signer1.Validate())
+	assert.NoError(t, signer2.Validate())
+
+	// and signatures verify only under the identity that made them
+	msg := []byte("TestMessage")
+	sig, err := signer1.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, signer1.Verify(msg, sig))
+	assert.Error(t, signer2.Verify(msg, sig))
+}
+
+func TestIdemixSerializeDeserialize(t *testing.T) {
+	thisMSP := getIdemixMSP(t, "testdata/idemix/MSP1OU1", "MSP1OU1")
+	signer := getIdemixSigner(t, thisMSP)
+	assert.NoError(t, signer.Validate())
+	assert.NoError(t, thisMSP.Validate(signer))
+
+	serializedID, err := signer.Serialize()
+	assert.NoError(t, err)
+
+	// a verifier MSP with the same issuer public key accepts the identity
+	verifier := getIdemixMSP(t, "testdata/idemix/MSP1Verifier", "MSP1OU1")
+	id, err := verifier.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+	assert.Equal(t, signer.GetIdentifier(), id.GetIdentifier())
+	assert.Equal(t, "MSP1OU1", id.GetMSPIdentifier())
+
+	ous := id.GetOrganizationalUnits()
+	assert.Len(t, ous, 1)
+	assert.Equal(t, "OU1", ous[0].OrganizationalUnitIdentifier)
+
+	msg := []byte("TestMessage")
+	sig, err := signer.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify(msg, sig))
+
+	reserializedID, err := id.Serialize()
+	assert.NoError(t, err)
+	assert.Equal(t, serializedID, reserializedID)
+
+	// the manager routes the identity to the idemix MSP
+	mgr := NewMSPManager()
+	assert.NoError(t, mgr.Setup([]MSP{verifier}))
+	id, err = mgr.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+
+	// an MSP with a different name rejects the identity
+	otherMSP := getIdemixMSP(t, "testdata/idemix/MSP2OU1", "MSP2OU1")
+	_, err = otherMSP.DeserializeIdentity(serializedID)
+	assert.Error(t, err)
+
+	// an MSP with the same name but a different issuer does not validate the identity
+	otherMSP = getIdemixMSP(t, "testdata/idemix/MSP2OU1", "MSP1OU1")
+	id, err = otherMSP.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	assert.Error(t, id.Validate())
+
+	// identities of other MSP types are not recognized
+	assert.Error(t, thisMSP.Validate(&identity{}))
+
+	_, err = verifier.DeserializeIdentity([]byte("garbage"))
+	assert.Error(t, err)
+}
+
+func TestIdemixTamperedIdentity(t *testing.T) {
+	thisMSP := getIdemixMSP(t, "testdata/idemix/MSP1OU1", "MSP1OU1")
+	signer := getIdemixSigner(t, thisMSP)
+
+	tamper := func(f func(*msp.SerializedIdemixIdentity)) (Identity, error) {
+		serializedID, err := signer.Serialize()
+		assert.NoError(t, err)
+		sID := &msp.SerializedIdentity{}
+		assert.NoError(t, proto.Unmarshal(serializedID, sID))
+		serialized := &msp.SerializedIdemixIdentity{}
+		assert.NoError(t, proto.Unmarshal(sID.IdBytes, serialized))
+		f(serialized)
+		sID.IdBytes, err = proto.Marshal(serialized)
+		assert.NoError(t, err)
+		serializedID, err = proto.Marshal(sID)
+		assert.NoError(t, err)
+		return thisMSP.DeserializeIdentity(serializedID)
+	}
+
+	// claim another OU
+	id, err := tamper(func(serialized *msp.SerializedIdemixIdentity) {
+		serialized.Ou, _ = proto.Marshal(&msp.OrganizationUnit{
+			MspIdentifier:                "MSP1OU1",
+			OrganizationalUnitIdentifier: "OU2",
+			CertifiersIdentifier:         signer.GetOrganizationalUnits()[0].CertifiersIdentifier,
+		})
+	})
+	assert.NoError(t, err)
+	assert.Error(t, id.Validate())
+
+	// claim the admin role
+	id, err = tamper(func(serialized *msp.SerializedIdemixIdentity) {
+		serialized.Role, _ = proto.Marshal(&msp.MSPRole{MspIdentifier: "MSP1OU1", Role: msp.MSPRole_ADMIN})
+	})
+	assert.NoError(t, err)
+	assert.Error(t, id.Validate())
+
+	// reuse the proof with another pseudonym
+	otherSigner := getIdemixSigner(t, getIdemixMSP(t, "testdata/idemix/MSP1OU2", "MSP1OU1"))
+	otherSerializedID, err := otherSigner.Serialize()
+	assert.NoError(t, err)
+	otherID, err := thisMSP.DeserializeIdentity(otherSerializedID)
+	assert.NoError(t, err)
+	id, err = tamper(func(serialized *msp.SerializedIdemixIdentity) {
+		serialized.NymX = idemix.BigToBytes(otherID.(*idemixidentity).Nym.GetX())
+		serialized.NymY = idemix.BigToBytes(otherID.(*idemixidentity).Nym.GetY())
+	})
+	assert.NoError(t, err)
+	assert.Error(t, id.Validate())
+
+	// a pseudonym that is not a curve point
+	_, err = tamper(func(serialized *msp.SerializedIdemixIdentity) {
+		serialized.NymX = []byte{1}
+	})
+	assert.Error(t, err)
+
+	// a broken proof
+	_, err = tamper(func(serialized *msp.SerializedIdemixIdentity) {
+		serialized.Proof = []byte("garbage")
+	})
+	assert.Error(t, err)
+}
+
+func TestIdemixSatisfiesPrincipal(t *testing.T) {
+	thisMSP := getIdemixMSP(t, "testdata/idemix/MSP1OU1", "MSP1OU1")
+	member := getIdemixSigner(t, thisMSP)
+	admin := getIdemixSigner(t, getIdemixMSP(t, "testdata/idemix/MSP1OU1Admin", "MSP1OU1"))
+	otherOU := getIdemixSigner(t, getIdemixMSP(t, "testdata/idemix/MSP1OU2", "MSP1OU1"))
+
+	marshal := func(pb proto.Message) []byte {
+		bytes, err := proto.Marshal(pb)
+		assert.NoError(t, err)
+		return bytes
+	}
+	rolePrincipal := func(mspID string, role msp.MSPRole_MSPRoleType) *msp.MSPPrincipal {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ROLE,
+			Principal:               marshal(&msp.MSPRole{MspIdentifier: mspID, Role: role})}
+	}
+	ouPrincipal := func(mspID string, ou string, certifiersID []byte) *msp.MSPPrincipal {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT,
+			Principal: marshal(&msp.OrganizationUnit{
+				MspIdentifier:                mspID,
+				OrganizationalUnitIdentifier: ou,
+				CertifiersIdentifier:         certifiersID})}
+	}
+
+	// roles
+	assert.NoError(t, member.SatisfiesPrincipal(rolePrincipal("MSP1OU1", msp.MSPRole_MEMBER)))
+	assert.NoError(t, admin.SatisfiesPrincipal(rolePrincipal("MSP1OU1", msp.MSPRole_MEMBER)))
+	assert.NoError(t, admin.SatisfiesPrincipal(rolePrincipal("MSP1OU1", msp.MSPRole_ADMIN)))
+	assert.Error(t, member.SatisfiesPrincipal(rolePrincipal("MSP1OU1", msp.MSPRole_ADMIN)))
+	assert.Error(t, member.SatisfiesPrincipal(rolePrincipal("MSP1OU1", msp.MSPRole_PEER)))
+	assert.Error(t, member.SatisfiesPrincipal(rolePrincipal("MSP2OU1", msp.MSPRole_MEMBER)))
+	assert.Error(t, member.SatisfiesPrincipal(rolePrincipal("MSP1OU1", msp.MSPRole_MSPRoleType(42))))
+
+	// organizational units
+	certifiersID := member.GetOrganizationalUnits()[0].CertifiersIdentifier
+	assert.NoError(t, member.SatisfiesPrincipal(ouPrincipal("MSP1OU1", "OU1", certifiersID)))
+	assert.Error(t, otherOU.SatisfiesPrincipal(ouPrincipal("MSP1OU1", "OU1", certifiersID)))
+	assert.NoError(t, otherOU.SatisfiesPrincipal(ouPrincipal("MSP1OU1", "OU2", certifiersID)))
+	assert.Error(t, member.SatisfiesPrincipal(ouPrincipal("MSP1OU1", "OU1", []byte("otherCA"))))
+	assert.Error(t, member.SatisfiesPrincipal(ouPrincipal("MSP2OU1", "OU1", certifiersID)))
+
+	// identities
+	serializedMember, err := member.Serialize()
+	assert.NoError(t, err)
+	identityPrincipal := &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_IDENTITY,
+		Principal:               serializedMember}
+	assert.NoError(t, member.SatisfiesPrincipal(identityPrincipal))
+	assert.NoError(t, member.GetPublicVersion().SatisfiesPrincipal(identityPrincipal))
+	assert.Error(t, admin.SatisfiesPrincipal(identityPrincipal))
+
+	// unknown principal types
+	assert.Error(t, member.SatisfiesPrincipal(&msp.MSPPrincipal{PrincipalClassification: msp.MSPPrincipal_Classification(42)}))
+
+	// identities that are not valid never satisfy a principal
+	otherMSP := getIdemixMSP(t, "testdata/idemix/MSP2OU1", "MSP1OU1")
+	otherIssuerMember := getIdemixSigner(t, otherMSP)
+	assert.Error(t, thisMSP.SatisfiesPrincipal(otherIssuerMember, rolePrincipal("MSP1OU1", msp.MSPRole_MEMBER)))
+}
+
+func TestNewMSPOfType(t *testing.T) {
+	fabricMSP, err := New(FABRIC, MSPv1_1)
+	assert.NoError(t, err)
+	assert.Equal(t, FABRIC, fabricMSP.GetType())
+
+	idemixMSP, err := New(IDEMIX, MSPv1_1)
+	assert.NoError(t, err)
+	assert.Equal(t, IDEMIX, idemixMSP.GetType())
+
+	_, err = New(IDEMIX, MSPv1_0)
+	assert.Error(t, err)
+
+	_, err = New(OTHER, MSPv1_1)
+	assert.Error(t, err)
+}
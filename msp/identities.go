@@ -18,6 +18,7 @@ package msp
 
 import (
 	"crypto"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/hex"
@@ -124,15 +125,9 @@ func NewSerializedIdentity(mspID string, certPEM []byte) ([]byte, error) {
 func (id *identity) Verify(msg []byte, sig []byte) error {
 	// mspIdentityLogger.Infof("Verifying signature")
 
-	// Compute Hash
-	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+	digest, err := id.digest(msg)
 	if err != nil {
-		return fmt.Errorf("Failed getting hash function options [%s]", err)
-	}
-
-	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
-	if err != nil {
-		return fmt.Errorf("Failed computing digest [%s]", err)
+		return err
 	}
 
 	if mspIdentityLogger.IsEnabledFor(logging.DEBUG) {
@@ -170,6 +165,25 @@ func (id *identity) Serialize() ([]byte, error) {
 	return idBytes, nil
 }
 
+// digest returns what the key of the identity signs: the hash of the message,
+// or the message itself for Ed25519 keys, which hash it as they sign it
+func (id *identity) digest(msg []byte) ([]byte, error) {
+	if _, isEd25519 := id.cert.PublicKey.(ed25519.PublicKey); isEd25519 {
+		return msg, nil
+	}
+
+	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting hash function options [%s]", err)
+	}
+
+	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
+	if err != nil {
+		return nil, fmt.Errorf("Failed computing digest [%s]", err)
+	}
+	return digest, nil
+}
+
 func (id *identity) getHashOpt(hashFamily string) (bccsp.HashOpts, error) {
 	switch hashFamily {
 	case bccsp.SHA2:
@@ -201,15 +215,9 @@ func newSigningIdentity(id *IdentityIdentifier, cert *x509.Certificate, pk bccsp
 func (id *signingidentity) Sign(msg []byte) ([]byte, error) {
 	//mspIdentityLogger.Infof("Signing message")
 
-	// Compute Hash
-	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+	digest, err := id.digest(msg)
 	if err != nil {
-		return nil, fmt.Errorf("Failed getting hash function options [%s]", err)
-	}
-
-	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
-	if err != nil {
-		return nil, fmt.Errorf("Failed computing digest [%s]", err)
+		return nil, err
 	}
 
 	if len(msg) < 32 {
@@ -17,8 +17,10 @@ limitations under the License.
 package mgmt
 
 import (
+	"fmt"
 	"reflect"
 	"sync"
+	"time"
 
 	"errors"
 
@@ -40,7 +42,160 @@ func LoadLocalMsp(dir string, bccspConfig *factory.FactoryOpts, mspID string) er
 		return err
 	}
 
-	return GetLocalMSP().Setup(conf)
+	localMSP := GetLocalMSP()
+	err = localMSP.Setup(conf)
+	if err != nil {
+		return err
+	}
+
+	// pick up changes to the CRLs of the local MSP without a restart
+	if updater, ok := localMSP.(msp.CRLUpdater); ok {
+		watchLocalCRLs(dir, updater)
+	}
+	return nil
+}
+
+// CRLPollInterval is how often the crls folder of the local MSP
+// is checked for changes
+var CRLPollInterval = 30 * time.Second
+
+var crlWatcherStop chan struct{}
+
+// watchLocalCRLs starts watching the crls folder of the local MSP
+// in dir, stopping any previous watcher
+func watchLocalCRLs(dir string, updater msp.CRLUpdater) {
+	m.Lock()
+	defer m.Unlock()
+
+	if crlWatcherStop != nil {
+		close(crlWatcherStop)
+	}
+	crlWatcherStop = make(chan struct{})
+	go msp.WatchCRLs(dir, updater, CRLPollInterval, crlWatcherStop)
+}
+
+// ReloadLocalMsp sets up a new local MSP from the specified directory
+// and replaces the current one with it. The private key of the signing
+// certificate is looked up in the BCCSP initialized by LoadLocalMsp.
+func ReloadLocalMsp(dir string, mspID string) error {
+	newMsp, err := setupLocalMsp(dir, mspID)
+	if err != nil {
+		return err
+	}
+
+	swapLocalMsp(newMsp)
+	if updater, ok := newMsp.(msp.CRLUpdater); ok {
+		watchLocalCRLs(dir, updater)
+	}
+	return nil
+}
+
+// setupLocalMsp sets up, without installing it, a local MSP
+// from the specified directory
+func setupLocalMsp(dir string, mspID string) (msp.MSP, error) {
+	if mspID == "" {
+		return nil, errors.New("The local MSP must have an ID")
+	}
+
+	conf, err := msp.GetLocalMspConfig(dir, nil, mspID)
+	if err != nil {
+		return nil, err
+	}
+
+	newMsp, err := msp.NewBccspMsp()
+	if err != nil {
+		return nil, err
+	}
+	if err = newMsp.Setup(conf); err != nil {
+		return nil, err
+	}
+	return newMsp, nil
+}
+
+// swapLocalMsp replaces the local MSP with newMsp and returns the previous one
+func swapLocalMsp(newMsp msp.MSP) msp.MSP {
+	m.Lock()
+	defer m.Unlock()
+	previous := localMsp
+	localMsp = newMsp
+	return previous
+}
+
+// SigningCertPollInterval is how often the signcerts folder
+// of the local MSP is checked for a new certificate
+var SigningCertPollInterval = 30 * time.Second
+
+// WatchLocalSigningIdentity reloads the local MSP from dir whenever its
+// signing certificate is replaced, and passes the new serialized signing
+// identity to update. The new MSP is kept only if update succeeds.
+// It returns when stop is closed.
+func WatchLocalSigningIdentity(dir string, mspID string, update func(serializedIdentity []byte) error, stop <-chan struct{}) {
+	msp.WatchSigningCert(dir, SigningCertPollInterval, func() error {
+		newMsp, err := setupLocalMsp(dir, mspID)
+		if err != nil {
+			return err
+		}
+		signer, err := newMsp.GetDefaultSigningIdentity()
+		if err != nil {
+			return err
+		}
+		serializedIdentity, err := signer.Serialize()
+		if err != nil {
+			return err
+		}
+
+		// the identity is updated with the new MSP installed,
+		// as the peer signs with its key from then on
+		previous := swapLocalMsp(newMsp)
+		if err = update(serializedIdentity); err != nil {
+			swapLocalMsp(previous)
+			return err
+		}
+		if updater, ok := newMsp.(msp.CRLUpdater); ok {
+			watchLocalCRLs(dir, updater)
+		}
+		return nil
+	}, stop)
+}
+
+// LoadLocalMspWithType loads the local MSP of the given type
+// ("bccsp" or "idemix") from the specified directory
+func LoadLocalMspWithType(dir string, bccspConfig *factory.FactoryOpts, mspID, mspType string) error {
+	if mspID == "" {
+		return errors.New("The local MSP must have an ID")
+	}
+
+	switch mspType {
+	case msp.ProviderTypeToString(msp.FABRIC):
+		return LoadLocalMsp(dir, bccspConfig, mspID)
+	case msp.ProviderTypeToString(msp.IDEMIX):
+		// the idemix MSP does not need BCCSP, but the rest of the node does
+		err := factory.InitFactories(bccspConfig)
+		if err != nil {
+			return fmt.Errorf("Could not initialize BCCSP Factories [%s]", err)
+		}
+
+		conf, err := msp.GetIdemixMspConfig(dir, mspID)
+		if err != nil {
+			return err
+		}
+
+		idemixMsp, err := msp.New(msp.IDEMIX, msp.MSPv1_1)
+		if err != nil {
+			return err
+		}
+		err = idemixMsp.Setup(conf)
+		if err != nil {
+			return err
+		}
+
+		m.Lock()
+		defer m.Unlock()
+		localMsp = idemixMsp
+		return nil
+	default:
+		return fmt.Errorf("Unknown MSP type %s", mspType)
+	}
 }
 
 // Loads the development local MSP for use in testing.  Not valid for production/runtime context
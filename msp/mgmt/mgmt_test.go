@@ -17,9 +17,23 @@ limitations under the License.
 package mgmt
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
 	configvaluesmsp "github.com/hyperledger/fabric/common/config/msp"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
+	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/stretchr/testify/assert"
 )
@@ -68,3 +82,138 @@ func TestGetLocalSigningIdentityOrPanic(t *testing.T) {
 	sid := GetLocalSigningIdentityOrPanic()
 	assert.NotNil(t, sid)
 }
+
+func TestLoadLocalMspWithType(t *testing.T) {
+	// restore the local MSP used by the other tests
+	defer func(lclMsp msp.MSP) {
+		m.Lock()
+		localMsp = lclMsp
+		m.Unlock()
+	}(GetLocalMSP())
+
+	err := LoadLocalMspWithType("../testdata/idemix/MSP1OU1", nil, "", msp.ProviderTypeToString(msp.IDEMIX))
+	assert.Error(t, err)
+
+	err = LoadLocalMspWithType("../testdata/idemix/MSP1OU1", nil, "MSP1OU1", "unknown")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Unknown MSP type")
+
+	err = LoadLocalMspWithType("../testdata/idemix/nonexistent", nil, "MSP1OU1", msp.ProviderTypeToString(msp.IDEMIX))
+	assert.Error(t, err)
+
+	err = LoadLocalMspWithType("../testdata/idemix/MSP1OU1", nil, "MSP1OU1", msp.ProviderTypeToString(msp.IDEMIX))
+	assert.NoError(t, err)
+	assert.Equal(t, msp.IDEMIX, GetLocalMSP().GetType())
+	assert.NotNil(t, GetLocalSigningIdentityOrPanic())
+}
+
+func TestWatchLocalSigningIdentity(t *testing.T) {
+	// restore the local MSP used by the other tests
+	defer func(lclMsp msp.MSP, interval time.Duration) {
+		m.Lock()
+		localMsp = lclMsp
+		m.Unlock()
+		SigningCertPollInterval = interval
+	}(GetLocalMSP(), SigningCertPollInterval)
+	SigningCertPollInterval = 10 * time.Millisecond
+
+	dir, err := ioutil.TempDir("", "identitywatcher")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the key of the development MSP is reused, as it is
+	// in the keystore the BCCSP was initialized with
+	devMspDir, err := config.GetDevMspDir()
+	assert.NoError(t, err)
+	keyPEM, err := ioutil.ReadFile(filepath.Join(devMspDir, "keystore", "key.pem"))
+	assert.NoError(t, err)
+	key, err := utils.PEMtoPrivateKey(keyPEM, nil)
+	assert.NoError(t, err)
+	pub := &key.(*ecdsa.PrivateKey).PublicKey
+
+	signCA, err := ca.NewCA(filepath.Join(dir, "ca"), "org1.example.com", "ca.org1.example.com", bccsp.ECDSA)
+	assert.NoError(t, err)
+	issue := func(name string) []byte {
+		cert, err := signCA.SignCertificate(filepath.Join(dir, "ca"), name, nil, pub,
+			x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+		assert.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	mspDir := filepath.Join(dir, "msp")
+	write := func(folder string, content []byte) {
+		assert.NoError(t, os.MkdirAll(filepath.Join(mspDir, folder), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(mspDir, folder, "cert.pem"), content, 0644))
+	}
+	oldCert := issue("peer0.org1.example.com")
+	write("cacerts", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: signCA.SignCert.Raw}))
+	write("admincerts", oldCert)
+	write("signcerts", oldCert)
+	write("keystore", keyPEM)
+
+	assert.NoError(t, LoadLocalMsp(mspDir, nil, "Org1MSP"))
+	oldIdentity, err := GetLocalSigningIdentityOrPanic().Serialize()
+	assert.NoError(t, err)
+
+	updates := make(chan []byte, 10)
+	var attempts, reject int32
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		WatchLocalSigningIdentity(mspDir, "Org1MSP", func(serializedIdentity []byte) error {
+			atomic.AddInt32(&attempts, 1)
+			if atomic.LoadInt32(&reject) == 1 {
+				return errors.New("identity rejected")
+			}
+			updates <- serializedIdentity
+			return nil
+		}, stop)
+		close(done)
+	}()
+	// let the watcher take its initial snapshot of the signcerts folder
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, updates, 0)
+
+	// the signing certificate is renewed
+	write("signcerts", issue("peer0-renewed.org1.example.com"))
+	select {
+	case newIdentity := <-updates:
+		assert.False(t, bytes.Equal(oldIdentity, newIdentity))
+		localIdentity, err := GetLocalSigningIdentityOrPanic().Serialize()
+		assert.NoError(t, err)
+		assert.Equal(t, newIdentity, localIdentity)
+		// and the new identity signs with the key in the keystore
+		sig, err := GetLocalSigningIdentityOrPanic().Sign([]byte("foo"))
+		assert.NoError(t, err)
+		assert.NoError(t, GetLocalSigningIdentityOrPanic().Verify([]byte("foo"), sig))
+	case <-time.After(5 * time.Second):
+		t.Fatal("The new signing identity was not passed on")
+	}
+
+	// an identity the update rejects is not installed, and is
+	// not attempted again until the MSP folder changes
+	renewedIdentity, err := GetLocalSigningIdentityOrPanic().Serialize()
+	assert.NoError(t, err)
+	atomic.StoreInt32(&attempts, 0)
+	atomic.StoreInt32(&reject, 1)
+	write("signcerts", issue("peer0-rejected.org1.example.com"))
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+	localIdentity, err := GetLocalSigningIdentityOrPanic().Serialize()
+	assert.NoError(t, err)
+	assert.Equal(t, renewedIdentity, localIdentity)
+	atomic.StoreInt32(&reject, 0)
+
+	// a certificate without a private key in the keystore is not picked up
+	otherCA, err := ca.NewCA(filepath.Join(dir, "otherca"), "org1.example.com", "ca.org1.example.com", bccsp.ECDSA)
+	assert.NoError(t, err)
+	write("signcerts", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: otherCA.SignCert.Raw}))
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, updates, 0)
+
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("WatchLocalSigningIdentity did not return after stop was closed")
+	}
+}
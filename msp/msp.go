@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package msp
 
 import (
+	"fmt"
+
 	"github.com/hyperledger/fabric/protos/msp"
 )
 
@@ -186,5 +188,50 @@ type ProviderType int
 // The ProviderType of a member relative to the member API
 const (
 	FABRIC ProviderType = iota // MSP is of FABRIC type
+	IDEMIX                     // MSP is of IDEMIX type
 	OTHER                      // MSP is of OTHER TYPE
 )
+
+var mspTypeStrings = map[ProviderType]string{
+	FABRIC: "bccsp",
+	IDEMIX: "idemix",
+}
+
+// ProviderTypeToString returns a string that represents the ProviderType integer
+func ProviderTypeToString(id ProviderType) string {
+	if res, found := mspTypeStrings[id]; found {
+		return res
+	}
+
+	return ""
+}
+
+// MSPVersion selects the behavior of an MSP, so that all the nodes
+// of a channel evaluate identities alike, whatever their release
+type MSPVersion int
+
+const (
+	// MSPv1_0 is the behavior of the v1.0 MSPs, whose identities
+	// are either members or admins
+	MSPv1_0 MSPVersion = iota
+
+	// MSPv1_1 adds NodeOUs, which tell apart the clients, peers,
+	// orderers and admins of an MSP, idemix MSPs and Ed25519 identities
+	MSPv1_1
+)
+
+// New creates a new, not yet set up, MSP instance of the given type,
+// which behaves as the given version
+func New(mspType ProviderType, version MSPVersion) (MSP, error) {
+	switch mspType {
+	case FABRIC:
+		return newBccspMsp(version)
+	case IDEMIX:
+		if version < MSPv1_1 {
+			return nil, fmt.Errorf("Idemix MSPs require MSP version v1.1")
+		}
+		return NewIdemixMsp()
+	default:
+		return nil, fmt.Errorf("Invalid msp type %d", mspType)
+	}
+}
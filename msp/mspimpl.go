@@ -8,6 +8,7 @@ package msp
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
@@ -17,6 +18,7 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -62,26 +64,46 @@ type bccspmsp struct {
 	// verification options for MSP members
 	opts *x509.VerifyOptions
 
-	// list of certificate revocation lists
-	CRL []*pkix.CertificateList
+	// list of certificate revocation lists, guarded by crlLock
+	// as it can be replaced at runtime (see UpdateCRLs)
+	CRL     []*pkix.CertificateList
+	crlLock sync.RWMutex
 
 	// list of OUs
 	ouIdentifiers map[string][][]byte
 
 	// cryptoConfig contains
 	cryptoConfig *m.FabricCryptoConfig
+
+	// version selects the behavior of the MSP
+	version MSPVersion
+
+	// NodeOUs configuration: when ouEnforcement is true, every identity
+	// must carry exactly one of the OUs identifying clients, peers,
+	// orderers and admins. It requires MSPv1_1
+	ouEnforcement bool
+	clientOU      *OUIdentifier
+	peerOU        *OUIdentifier
+	ordererOU     *OUIdentifier
+	adminOU       *OUIdentifier
 }
 
 // NewBccspMsp returns an MSP instance backed up by a BCCSP
 // crypto provider. It handles x.509 certificates and can
 // generate identities and signing identities backed by
-// certificates and keypairs
+// certificates and keypairs. The MSP behaves as the latest
+// version, as local MSPs do
 func NewBccspMsp() (MSP, error) {
+	return newBccspMsp(MSPv1_1)
+}
+
+func newBccspMsp(version MSPVersion) (MSP, error) {
 	mspLogger.Debugf("Creating BCCSP-based MSP instance")
 
 	bccsp := factory.GetDefault()
 	theMsp := &bccspmsp{}
 	theMsp.bccsp = bccsp
+	theMsp.version = version
 
 	return theMsp, nil
 }
@@ -161,9 +183,13 @@ func (msp *bccspmsp) getSigningIdentityFromConf(sidInfo *m.SigningIdentityInfo)
 		}
 
 		pemKey, _ := pem.Decode(sidInfo.PrivateSigner.KeyMaterial)
-		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+		var importOpts bccsp.KeyImportOpts = &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true}
+		if _, isEd25519 := idPub.(*identity).cert.PublicKey.(ed25519.PublicKey); isEd25519 {
+			importOpts = &bccsp.ED25519PrivateKeyImportOpts{Temporary: true}
+		}
+		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, importOpts)
 		if err != nil {
-			return nil, fmt.Errorf("getIdentityFromBytes error: Failed to import EC private key, err %s", err)
+			return nil, fmt.Errorf("getIdentityFromBytes error: Failed to import %s private key, err %s", importOpts.Algorithm(), err)
 		}
 	}
 
@@ -325,6 +351,11 @@ func (msp *bccspmsp) Setup(conf1 *m.MSPConfig) error {
 		return err
 	}
 
+	// setup the NodeOUs
+	if err := msp.setupNodeOUs(conf); err != nil {
+		return err
+	}
+
 	// setup TLS CAs
 	if err := msp.setupTLSCAs(conf); err != nil {
 		return err
@@ -501,7 +532,40 @@ func (msp *bccspmsp) SatisfiesPrincipal(id Identity, principal *m.MSPPrincipal)
 				}
 			}
 
+			// otherwise, if NodeOUs are enabled, a valid
+			// identity carrying the admin OU is an admin too
+			if msp.version >= MSPv1_1 && msp.ouEnforcement && msp.adminOU != nil {
+				if err := msp.Validate(id); err != nil {
+					return fmt.Errorf("The identity is not valid under this MSP [%s]: %s", msp.name, err)
+				}
+				if msp.hasNodeOU(id.(*identity), msp.adminOU) {
+					return nil
+				}
+			}
+
 			return errors.New("This identity is not an admin")
+		case m.MSPRole_CLIENT, m.MSPRole_PEER, m.MSPRole_ORDERER:
+			if msp.version < MSPv1_1 {
+				// v1.0 MSPs don't know these roles
+				return fmt.Errorf("Invalid MSP role type %d", int32(mspRole.Role))
+			}
+			mspLogger.Debugf("Checking if identity satisfies %s role for %s", mspRole.Role, msp.name)
+			if !msp.ouEnforcement {
+				return fmt.Errorf("NodeOUs are not enabled in MSP %s, cannot tell apart %s identities", msp.name, mspRole.Role)
+			}
+			if err := msp.Validate(id); err != nil {
+				return fmt.Errorf("The identity is not valid under this MSP [%s]: %s", msp.name, err)
+			}
+
+			nodeOU := map[m.MSPRole_MSPRoleType]*OUIdentifier{
+				m.MSPRole_CLIENT:  msp.clientOU,
+				m.MSPRole_PEER:    msp.peerOU,
+				m.MSPRole_ORDERER: msp.ordererOU,
+			}[mspRole.Role]
+			if !msp.hasNodeOU(id.(*identity), nodeOU) {
+				return fmt.Errorf("The identity is not a %s under this MSP [%s]", mspRole.Role, msp.name)
+			}
+			return nil
 		default:
 			return fmt.Errorf("Invalid MSP role type %d", int32(mspRole.Role))
 		}
@@ -774,11 +838,24 @@ func (msp *bccspmsp) setupAdmins(conf *m.FabricMSPConfig) error {
 
 func (msp *bccspmsp) setupCRLs(conf *m.FabricMSPConfig) error {
 	// setup the CRL (if present)
-	msp.CRL = make([]*pkix.CertificateList, len(conf.RevocationList))
-	for i, crlbytes := range conf.RevocationList {
+	crls, err := parseCRLs(conf.RevocationList)
+	if err != nil {
+		return err
+	}
+
+	msp.crlLock.Lock()
+	msp.CRL = crls
+	msp.crlLock.Unlock()
+
+	return nil
+}
+
+func parseCRLs(revocationList [][]byte) ([]*pkix.CertificateList, error) {
+	crls := make([]*pkix.CertificateList, len(revocationList))
+	for i, crlbytes := range revocationList {
 		crl, err := x509.ParseCRL(crlbytes)
 		if err != nil {
-			return fmt.Errorf("Could not parse RevocationList, err %s", err)
+			return nil, fmt.Errorf("Could not parse RevocationList, err %s", err)
 		}
 
 		// TODO: pre-verify the signature on the CRL and create a map
@@ -786,12 +863,35 @@ func (msp *bccspmsp) setupCRLs(conf *m.FabricMSPConfig) error {
 		//       validation we can already look up the CRL given the
 		//       chain of the certificate to be validated
 
-		msp.CRL[i] = crl
+		crls[i] = crl
 	}
 
+	return crls, nil
+}
+
+// UpdateCRLs replaces the certificate revocation lists of this MSP
+// with the supplied ones, e.g. after the crls folder of a local MSP
+// changed. The MSP is left unchanged if any of the CRLs is invalid.
+func (msp *bccspmsp) UpdateCRLs(revocationList [][]byte) error {
+	crls, err := parseCRLs(revocationList)
+	if err != nil {
+		return err
+	}
+
+	msp.crlLock.Lock()
+	msp.CRL = crls
+	msp.crlLock.Unlock()
+
+	mspLogger.Infof("MSP %s now has %d CRL(s)", msp.name, len(crls))
 	return nil
 }
 
+func (msp *bccspmsp) getCRLs() []*pkix.CertificateList {
+	msp.crlLock.RLock()
+	defer msp.crlLock.RUnlock()
+	return msp.CRL
+}
+
 func (msp *bccspmsp) finalizeSetupCAs(config *m.FabricMSPConfig) error {
 	// ensure that our CAs are properly formed and that they are valid
 	for _, id := range append(append([]Identity{}, msp.rootCerts...), msp.intermediateCerts...) {
@@ -835,66 +935,77 @@ func (msp *bccspmsp) setupSigningIdentity(conf *m.FabricMSPConfig) error {
 	return nil
 }
 
-func (msp *bccspmsp) setupOUs(conf *m.FabricMSPConfig) error {
-	msp.ouIdentifiers = make(map[string][][]byte)
-	for _, ou := range conf.OrganizationalUnitIdentifiers {
+// getCertifiersIdentifier returns the identifier of the certification chain
+// of the given root or intermediate CA certificate of this MSP
+func (msp *bccspmsp) getCertifiersIdentifier(certRaw []byte) ([]byte, error) {
+	// 1. check that certificate is registered in msp.rootCerts or msp.intermediateCerts
+	cert, err := msp.getCertFromPem(certRaw)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting certificate for [%v]: [%s]", certRaw, err)
+	}
 
-		// 1. check that certificate is registered in msp.rootCerts or msp.intermediateCerts
-		cert, err := msp.getCertFromPem(ou.Certificate)
-		if err != nil {
-			return fmt.Errorf("Failed getting certificate for [%v]: [%s]", ou, err)
-		}
+	// 2. Sanitize it to ensure like for like comparison
+	cert, err = msp.sanitizeCert(cert)
+	if err != nil {
+		return nil, fmt.Errorf("sanitizeCert failed %s", err)
+	}
 
-		// 2. Sanitize it to ensure like for like comparison
-		cert, err = msp.sanitizeCert(cert)
-		if err != nil {
-			return fmt.Errorf("sanitizeCert failed %s", err)
+	found := false
+	root := false
+	// Search among root certificates
+	for _, v := range msp.rootCerts {
+		if v.(*identity).cert.Equal(cert) {
+			found = true
+			root = true
+			break
 		}
-
-		found := false
-		root := false
-		// Search among root certificates
-		for _, v := range msp.rootCerts {
+	}
+	if !found {
+		// Search among root intermediate certificates
+		for _, v := range msp.intermediateCerts {
 			if v.(*identity).cert.Equal(cert) {
 				found = true
-				root = true
 				break
 			}
 		}
-		if !found {
-			// Search among root intermediate certificates
-			for _, v := range msp.intermediateCerts {
-				if v.(*identity).cert.Equal(cert) {
-					found = true
-					break
-				}
-			}
-		}
-		if !found {
-			// Certificate not valid, reject configuration
-			return fmt.Errorf("Failed adding OU. Certificate [%v] not in root or intermediate certs.", ou.Certificate)
-		}
+	}
+	if !found {
+		// Certificate not valid, reject configuration
+		return nil, fmt.Errorf("Certificate [%v] not in root or intermediate certs.", certRaw)
+	}
 
-		// 3. get the certification path for it
-		var certifiersIdentitifer []byte
-		var chain []*x509.Certificate
-		if root {
-			chain = []*x509.Certificate{cert}
-		} else {
-			chain, err = msp.getValidationChain(cert, true)
-			if err != nil {
-				return fmt.Errorf("Failed computing validation chain for [%v]. [%s]", cert, err)
-			}
+	// 3. get the certification path for it
+	var certifiersIdentifier []byte
+	var chain []*x509.Certificate
+	if root {
+		chain = []*x509.Certificate{cert}
+	} else {
+		chain, err = msp.getValidationChain(cert, true)
+		if err != nil {
+			return nil, fmt.Errorf("Failed computing validation chain for [%v]. [%s]", cert, err)
 		}
+	}
+
+	// 4. compute the hash of the certification path
+	certifiersIdentifier, err = msp.getCertificationChainIdentifierFromChain(chain)
+	if err != nil {
+		return nil, fmt.Errorf("Failed computing Certifiers Identifier for [%v]. [%s]", certRaw, err)
+	}
+
+	return certifiersIdentifier, nil
+}
 
-		// 4. compute the hash of the certification path
-		certifiersIdentitifer, err = msp.getCertificationChainIdentifierFromChain(chain)
+func (msp *bccspmsp) setupOUs(conf *m.FabricMSPConfig) error {
+	msp.ouIdentifiers = make(map[string][][]byte)
+	for _, ou := range conf.OrganizationalUnitIdentifiers {
+
+		certifiersIdentitifer, err := msp.getCertifiersIdentifier(ou.Certificate)
 		if err != nil {
-			return fmt.Errorf("Failed computing Certifiers Identifier for [%v]. [%s]", ou.Certificate, err)
+			return fmt.Errorf("Failed adding OU [%s]. %s", ou.OrganizationalUnitIdentifier, err)
 		}
 
 		// Check for duplicates
-		found = false
+		found := false
 		for _, id := range msp.ouIdentifiers[ou.OrganizationalUnitIdentifier] {
 			if bytes.Equal(id, certifiersIdentitifer) {
 				mspLogger.Warningf("Duplicate found in ou identifiers [%s, %v]", ou.OrganizationalUnitIdentifier, id)
@@ -915,6 +1026,59 @@ func (msp *bccspmsp) setupOUs(conf *m.FabricMSPConfig) error {
 	return nil
 }
 
+func (msp *bccspmsp) setupNodeOUs(conf *m.FabricMSPConfig) error {
+	msp.ouEnforcement = false
+	msp.clientOU, msp.peerOU, msp.ordererOU, msp.adminOU = nil, nil, nil, nil
+	if conf.FabricNodeOus == nil {
+		return nil
+	}
+	if msp.version < MSPv1_1 {
+		return fmt.Errorf("Failed setting up NodeOUs. NodeOUs require MSP version v1.1, MSP %s is v1.0", conf.Name)
+	}
+	if !conf.FabricNodeOus.Enable {
+		return nil
+	}
+
+	nodeOU := func(role string, ou *m.FabricOUIdentifier) (*OUIdentifier, error) {
+		if ou == nil {
+			return nil, nil
+		}
+		if len(ou.OrganizationalUnitIdentifier) == 0 {
+			return nil, fmt.Errorf("Failed setting up NodeOUs. The %s OU identifier is empty", role)
+		}
+		res := &OUIdentifier{OrganizationalUnitIdentifier: ou.OrganizationalUnitIdentifier}
+		// Without a certificate, the OU is recognized regardless of the chain of trust
+		if len(ou.Certificate) != 0 {
+			certifiersIdentifier, err := msp.getCertifiersIdentifier(ou.Certificate)
+			if err != nil {
+				return nil, fmt.Errorf("Failed setting up the %s NodeOU. %s", role, err)
+			}
+			res.CertifiersIdentifier = certifiersIdentifier
+		}
+		return res, nil
+	}
+
+	var err error
+	if msp.clientOU, err = nodeOU("client", conf.FabricNodeOus.ClientOuIdentifier); err != nil {
+		return err
+	}
+	if msp.peerOU, err = nodeOU("peer", conf.FabricNodeOus.PeerOuIdentifier); err != nil {
+		return err
+	}
+	if msp.ordererOU, err = nodeOU("orderer", conf.FabricNodeOus.OrdererOuIdentifier); err != nil {
+		return err
+	}
+	if msp.adminOU, err = nodeOU("admin", conf.FabricNodeOus.AdminOuIdentifier); err != nil {
+		return err
+	}
+	if msp.clientOU == nil && msp.peerOU == nil && msp.ordererOU == nil && msp.adminOU == nil {
+		return errors.New("Failed setting up NodeOUs. NodeOUs are enabled but no OU identifier is set")
+	}
+
+	msp.ouEnforcement = true
+	return nil
+}
+
 func (msp *bccspmsp) setupTLSCAs(conf *m.FabricMSPConfig) error {
 
 	opts := &x509.VerifyOptions{Roots: x509.NewCertPool(), Intermediates: x509.NewCertPool()}
@@ -1005,6 +1169,10 @@ func (msp *bccspmsp) sanitizeCert(cert *x509.Certificate) (*x509.Certificate, er
 }
 
 func (msp *bccspmsp) validateIdentity(id *identity) error {
+	if _, isEd25519 := id.cert.PublicKey.(ed25519.PublicKey); isEd25519 && msp.version < MSPv1_1 {
+		return errors.New("Ed25519 identities require MSP version v1.1")
+	}
+
 	validationChain, err := msp.getCertificationChainForBCCSPIdentity(id)
 	if err != nil {
 		return fmt.Errorf("Could not obtain certification chain, err %s", err)
@@ -1072,7 +1240,7 @@ func (msp *bccspmsp) validateCertAgainstChain(cert *x509.Certificate, validation
 
 	// check whether one of the CRLs we have has this cert's
 	// SKI as its AuthorityKeyIdentifier
-	for _, crl := range msp.CRL {
+	for _, crl := range msp.getCRLs() {
 		aki, err := getAuthorityKeyIdentifierFromCrl(crl)
 		if err != nil {
 			return fmt.Errorf("Could not obtain Authority Key Identifier for crl, err %s", err)
@@ -1139,9 +1307,41 @@ func (msp *bccspmsp) validateIdentityOUs(id *identity) error {
 		}
 	}
 
+	if msp.version < MSPv1_1 || !msp.ouEnforcement {
+		return nil
+	}
+
+	// Make sure that the identity is exactly one of
+	// a client, a peer, an orderer or an admin
+	counter := 0
+	for _, nodeOU := range []*OUIdentifier{msp.clientOU, msp.peerOU, msp.ordererOU, msp.adminOU} {
+		if msp.hasNodeOU(id, nodeOU) {
+			counter++
+		}
+	}
+	if counter != 1 {
+		return fmt.Errorf("The identity must carry exactly one of the NodeOUs of MSP %s, it carries %d. OUs: [%v]", msp.name, counter, id.GetOrganizationalUnits())
+	}
+
 	return nil
 }
 
+// hasNodeOU returns whether the identity carries the given NodeOU
+func (msp *bccspmsp) hasNodeOU(id *identity, nodeOU *OUIdentifier) bool {
+	if nodeOU == nil {
+		return false
+	}
+	for _, OU := range id.GetOrganizationalUnits() {
+		if OU.OrganizationalUnitIdentifier != nodeOU.OrganizationalUnitIdentifier {
+			continue
+		}
+		if len(nodeOU.CertifiersIdentifier) == 0 || bytes.Equal(nodeOU.CertifiersIdentifier, OU.CertifiersIdentifier) {
+			return true
+		}
+	}
+	return false
+}
+
 func (msp *bccspmsp) getValidityOptsForCert(cert *x509.Certificate) x509.VerifyOptions {
 	// First copy the opts to override the CurrentTime field
 	// in order to make the certificate passing the expiration test
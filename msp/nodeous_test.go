@@ -0,0 +1,226 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package msp
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+// getNodeOUsMSP sets up an MSP out of the development MSP configuration,
+// with the given NodeOUs configuration and, optionally, without admins
+func getNodeOUsMSP(t *testing.T, nodeOUs *msp.FabricNodeOUs, withAdmins bool) (MSP, error) {
+	dir, err := config.GetDevMspDir()
+	assert.NoError(t, err)
+	conf, err := GetVerifyingMspConfig(dir, "DEFAULT")
+	assert.NoError(t, err)
+
+	fabricConf := &msp.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, fabricConf))
+	fabricConf.FabricNodeOus = nodeOUs
+	if !withAdmins {
+		fabricConf.Admins = nil
+	}
+	conf.Config, err = proto.Marshal(fabricConf)
+	assert.NoError(t, err)
+
+	thisMSP, err := NewBccspMsp()
+	assert.NoError(t, err)
+	return thisMSP, thisMSP.Setup(conf)
+}
+
+// getDevIdentity returns the development identity, which carries the OU COP,
+// as deserialized by the given MSP
+func getDevIdentity(t *testing.T, thisMSP MSP) Identity {
+	sid, err := localMsp.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	serializedID, err := sid.Serialize()
+	assert.NoError(t, err)
+	id, err := thisMSP.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	return id
+}
+
+func rolePrincipal(t *testing.T, role msp.MSPRole_MSPRoleType) *msp.MSPPrincipal {
+	principalBytes, err := proto.Marshal(&msp.MSPRole{Role: role, MspIdentifier: "DEFAULT"})
+	assert.NoError(t, err)
+	return &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               principalBytes,
+	}
+}
+
+func TestNodeOUsRoles(t *testing.T) {
+	dir, err := config.GetDevMspDir()
+	assert.NoError(t, err)
+	caCert, err := readFile(dir + "/cacerts/cacert.pem")
+	assert.NoError(t, err)
+
+	thisMSP, err := getNodeOUsMSP(t, &msp.FabricNodeOUs{
+		Enable:              true,
+		ClientOuIdentifier:  &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "COP", Certificate: caCert},
+		PeerOuIdentifier:    &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "peer"},
+		OrdererOuIdentifier: &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "orderer"},
+	}, true)
+	assert.NoError(t, err)
+
+	id := getDevIdentity(t, thisMSP)
+	assert.NoError(t, id.Validate())
+	assert.NoError(t, thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_MEMBER)))
+	assert.NoError(t, thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_CLIENT)))
+	assert.NoError(t, thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_ADMIN)))
+	err = thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_PEER))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "The identity is not a PEER")
+	assert.Error(t, thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_ORDERER)))
+}
+
+func TestNodeOUsDisabled(t *testing.T) {
+	thisMSP, err := getNodeOUsMSP(t, &msp.FabricNodeOUs{
+		Enable:             false,
+		ClientOuIdentifier: &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "COP"},
+	}, true)
+	assert.NoError(t, err)
+
+	id := getDevIdentity(t, thisMSP)
+	assert.NoError(t, thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_MEMBER)))
+	err = thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_CLIENT))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "NodeOUs are not enabled")
+}
+
+func TestNodeOUsMSPv1_0(t *testing.T) {
+	// v1.0 MSPs refuse NodeOUs, even disabled ones,
+	// and don't know the client, peer and orderer roles
+	dir, err := config.GetDevMspDir()
+	assert.NoError(t, err)
+	conf, err := GetVerifyingMspConfig(dir, "DEFAULT")
+	assert.NoError(t, err)
+	thisMSP, err := New(FABRIC, MSPv1_0)
+	assert.NoError(t, err)
+	assert.NoError(t, thisMSP.Setup(conf))
+
+	id := getDevIdentity(t, thisMSP)
+	assert.NoError(t, thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_MEMBER)))
+	err = thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_CLIENT))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid MSP role type")
+
+	fabricConf := &msp.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, fabricConf))
+	fabricConf.FabricNodeOus = &msp.FabricNodeOUs{Enable: false}
+	conf.Config, err = proto.Marshal(fabricConf)
+	assert.NoError(t, err)
+	thisMSP, err = New(FABRIC, MSPv1_0)
+	assert.NoError(t, err)
+	err = thisMSP.Setup(conf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "NodeOUs require MSP version v1.1")
+}
+
+func TestNodeOUsAdmin(t *testing.T) {
+	// Scenario: the MSP has no explicit admins,
+	// so admins are told apart by the admin OU only
+	thisMSP, err := getNodeOUsMSP(t, &msp.FabricNodeOUs{
+		Enable:             true,
+		ClientOuIdentifier: &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "client"},
+		AdminOuIdentifier:  &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "COP"},
+	}, false)
+	assert.NoError(t, err)
+
+	id := getDevIdentity(t, thisMSP)
+	assert.NoError(t, thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_ADMIN)))
+	assert.Error(t, thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_CLIENT)))
+
+	// Without the admin OU, the identity isn't an admin, and isn't even valid
+	thisMSP, err = getNodeOUsMSP(t, &msp.FabricNodeOUs{
+		Enable:             true,
+		ClientOuIdentifier: &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "client"},
+	}, false)
+	assert.NoError(t, err)
+
+	id = getDevIdentity(t, thisMSP)
+	assert.Error(t, id.Validate())
+	assert.Error(t, thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_ADMIN)))
+}
+
+func TestNodeOUsExactlyOne(t *testing.T) {
+	// Scenario: the identity carries both the client and the peer OU
+	thisMSP, err := getNodeOUsMSP(t, &msp.FabricNodeOUs{
+		Enable:             true,
+		ClientOuIdentifier: &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "COP"},
+		PeerOuIdentifier:   &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "COP"},
+	}, false)
+	assert.NoError(t, err)
+
+	id := getDevIdentity(t, thisMSP)
+	err = id.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly one of the NodeOUs")
+	assert.Error(t, thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_PEER)))
+
+	// Admins must be valid identities, so the setup fails with them
+	_, err = getNodeOUsMSP(t, &msp.FabricNodeOUs{
+		Enable:             true,
+		ClientOuIdentifier: &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "COP"},
+		PeerOuIdentifier:   &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "COP"},
+	}, true)
+	assert.Error(t, err)
+}
+
+func TestNodeOUsBadConfig(t *testing.T) {
+	_, err := getNodeOUsMSP(t, &msp.FabricNodeOUs{Enable: true}, true)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no OU identifier is set")
+
+	_, err = getNodeOUsMSP(t, &msp.FabricNodeOUs{
+		Enable:             true,
+		ClientOuIdentifier: &msp.FabricOUIdentifier{},
+	}, true)
+	assert.Error(t, err)
+
+	_, err = getNodeOUsMSP(t, &msp.FabricNodeOUs{
+		Enable:             true,
+		ClientOuIdentifier: &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: "COP", Certificate: []byte("not a certificate")},
+	}, true)
+	assert.Error(t, err)
+}
+
+func TestNodeOUsFromConfigFile(t *testing.T) {
+	// testdata/nodeous:
+	// the configuration has no admin certificates, and
+	// identities with OU=COP are admins
+	conf, err := GetVerifyingMspConfig("testdata/nodeous", "DEFAULT")
+	assert.NoError(t, err)
+
+	thisMSP, err := NewBccspMsp()
+	assert.NoError(t, err)
+	assert.NoError(t, thisMSP.Setup(conf))
+
+	id := getDevIdentity(t, thisMSP)
+	assert.NoError(t, id.Validate())
+	assert.NoError(t, thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_ADMIN)))
+	assert.Error(t, thisMSP.SatisfiesPrincipal(id, rolePrincipal(t, msp.MSPRole_PEER)))
+
+	// Without NodeOUs, admin certificates are mandatory
+	_, err = GetVerifyingMspConfig("testdata/nodeous/cacerts", "DEFAULT")
+	assert.Error(t, err)
+}
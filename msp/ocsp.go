@@ -0,0 +1,271 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package msp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// defaultOCSPCacheTTL bounds how long an OCSP response is cached
+	// when OCSPOptions.CacheTTL is not set
+	defaultOCSPCacheTTL = 5 * time.Minute
+	// ocspFailureCacheTTL bounds how long a failure to establish the
+	// revocation status of a certificate is cached
+	ocspFailureCacheTTL = 30 * time.Second
+	// defaultOCSPTimeout is used for requests to OCSP responders
+	// when OCSPOptions.Timeout is not set
+	defaultOCSPTimeout = 5 * time.Second
+	// maxOCSPResponseSize bounds the size of a response read from a responder
+	maxOCSPResponseSize = 1024 * 1024
+	// maxOCSPCacheEntries is the number of cached responses above which
+	// expired entries are purged
+	maxOCSPCacheEntries = 10000
+)
+
+// OCSPOptions configures an OCSPChecker
+type OCSPOptions struct {
+	// CacheTTL is the maximum time a response is cached for; responses
+	// are never cached past their NextUpdate time
+	CacheTTL time.Duration
+	// Timeout is the timeout of each request to an OCSP responder
+	Timeout time.Duration
+	// HardFail rejects identities whose revocation status cannot be
+	// established, e.g. because the responder is unreachable. By default
+	// such identities are accepted and a warning is logged.
+	HardFail bool
+}
+
+// OCSPChecker checks the revocation status of identities whose certificate
+// carries an OCSP responder URL, caching the responses, as well as
+// failures to get one for a short time. Concurrent checks of the same
+// certificate share a single query.
+// The outcome of a check depends on the responder and on time, so it must
+// only be used to reject requests when they are received (e.g. proposals
+// or broadcasts) and never while validating blocks.
+type OCSPChecker struct {
+	opts   OCSPOptions
+	client *http.Client
+	now    func() time.Time
+
+	lock     sync.Mutex
+	cache    map[string]*ocspCacheEntry
+	inflight singleflight.Group
+}
+
+type ocspCacheEntry struct {
+	revoked bool
+	// err is the reason the revocation status could not be established
+	err    error
+	expiry time.Time
+}
+
+// NewOCSPChecker returns a new OCSPChecker for the given options
+func NewOCSPChecker(opts OCSPOptions) *OCSPChecker {
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = defaultOCSPCacheTTL
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultOCSPTimeout
+	}
+
+	return &OCSPChecker{
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+		now:    time.Now,
+		cache:  make(map[string]*ocspCacheEntry),
+	}
+}
+
+// CheckIdentity returns an error if the certificate of the supplied
+// identity has been revoked according to its OCSP responder. Identities
+// that are not x.509 based, or whose certificate does not carry an OCSP
+// responder URL, are not checked.
+func (c *OCSPChecker) CheckIdentity(id Identity) error {
+	var x509id *identity
+	switch id := id.(type) {
+	case *identity:
+		x509id = id
+	case *signingidentity:
+		x509id = &id.identity
+	default:
+		return nil
+	}
+	if len(x509id.cert.OCSPServer) == 0 {
+		return nil
+	}
+
+	chain, err := x509id.msp.getCertificationChainForBCCSPIdentity(x509id)
+	if err != nil {
+		return fmt.Errorf("Could not obtain certification chain, err %s", err)
+	}
+
+	return c.checkCert(x509id.cert, chain[1])
+}
+
+func (c *OCSPChecker) checkCert(cert, issuer *x509.Certificate) error {
+	digest := sha256.Sum256(append(append([]byte{}, issuer.Raw...), cert.SerialNumber.Bytes()...))
+	key := string(digest[:])
+
+	c.lock.Lock()
+	entry, ok := c.cache[key]
+	c.lock.Unlock()
+	if !ok || !c.now().Before(entry.expiry) {
+		v, _, _ := c.inflight.Do(key, func() (interface{}, error) {
+			return c.refresh(key, cert, issuer), nil
+		})
+		entry = v.(*ocspCacheEntry)
+	}
+
+	if entry.err != nil {
+		if c.opts.HardFail {
+			return fmt.Errorf("Could not establish the revocation status of the certificate, err %s", entry.err)
+		}
+		mspLogger.Warningf("Could not establish the revocation status of certificate [%s] through OCSP, accepting it: %s", cert.Subject, entry.err)
+		return nil
+	}
+	if entry.revoked {
+		return errors.New("The certificate has been revoked")
+	}
+	return nil
+}
+
+// refresh queries the OCSP responders of cert and caches the outcome
+// under key. Failures are cached too, for a shorter time, so that an
+// unreachable responder does not delay every request.
+func (c *OCSPChecker) refresh(key string, cert, issuer *x509.Certificate) *ocspCacheEntry {
+	now := c.now()
+	resp, err := c.query(cert, issuer)
+
+	var entry *ocspCacheEntry
+	if err != nil {
+		entry = &ocspCacheEntry{err: err, expiry: now.Add(ocspFailureCacheTTL)}
+		if c.opts.CacheTTL < ocspFailureCacheTTL {
+			entry.expiry = now.Add(c.opts.CacheTTL)
+		}
+	} else {
+		entry = &ocspCacheEntry{
+			revoked: resp.Status == ocsp.Revoked,
+			expiry:  now.Add(c.opts.CacheTTL),
+		}
+		if !resp.NextUpdate.IsZero() && resp.NextUpdate.Before(entry.expiry) {
+			entry.expiry = resp.NextUpdate
+		}
+	}
+	c.store(key, entry, now)
+
+	return entry
+}
+
+func (c *OCSPChecker) store(key string, entry *ocspCacheEntry, now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.cache) >= maxOCSPCacheEntries {
+		for k, e := range c.cache {
+			if !now.Before(e.expiry) {
+				delete(c.cache, k)
+			}
+		}
+	}
+	c.cache[key] = entry
+}
+
+// query asks the OCSP responders listed in cert for its status, and returns
+// the first current Good or Revoked response
+func (c *OCSPChecker) query(cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating OCSP request: %s", err)
+	}
+
+	var lastErr error
+	for _, url := range cert.OCSPServer {
+		resp, err := c.queryResponder(url, req, cert, issuer)
+		if err != nil {
+			mspLogger.Debugf("OCSP responder %s failed: %s", url, err)
+			lastErr = fmt.Errorf("OCSP responder %s failed: %s", url, err)
+			continue
+		}
+		return resp, nil
+	}
+	return nil, lastErr
+}
+
+func (c *OCSPChecker) queryResponder(url string, req []byte, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	httpResp, err := c.client.Post(url, "application/ocsp-request", bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %s", httpResp.Status)
+	}
+	raw, err := ioutil.ReadAll(&io.LimitedReader{R: httpResp.Body, N: maxOCSPResponseSize})
+	if err != nil {
+		return nil, err
+	}
+
+	// the response must be signed by the issuer, or by
+	// a responder the issuer delegated to
+	resp, err := ocsp.ParseResponse(raw, issuer)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status == ocsp.ServerFailed {
+		return nil, errors.New("responder failed")
+	}
+	if resp.Certificate != nil && !resp.Certificate.Equal(issuer) && !hasExtKeyUsage(resp.Certificate, x509.ExtKeyUsageOCSPSigning) {
+		return nil, errors.New("responder certificate is not authorized to sign OCSP responses")
+	}
+	if resp.SerialNumber == nil || resp.SerialNumber.Cmp(cert.SerialNumber) != 0 {
+		return nil, errors.New("response is for another certificate")
+	}
+
+	now := c.now()
+	if resp.ThisUpdate.After(now.Add(time.Minute)) {
+		return nil, errors.New("response is not yet valid")
+	}
+	if !resp.NextUpdate.IsZero() && resp.NextUpdate.Before(now) {
+		return nil, errors.New("response has expired")
+	}
+	if resp.Status != ocsp.Good && resp.Status != ocsp.Revoked {
+		return nil, errors.New("certificate status is unknown")
+	}
+	return resp, nil
+}
+
+func hasExtKeyUsage(cert *x509.Certificate, usage x509.ExtKeyUsage) bool {
+	for _, u := range cert.ExtKeyUsage {
+		if u == usage {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,419 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package msp
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	m "github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ocsp"
+)
+
+// testCA issues certificates and CRLs for the OCSP and CRL tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca.example.com"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	assert.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// issue returns a PEM encoded certificate with the given serial number
+// and OCSP responders
+func (ca *testCA) issue(t *testing.T, serial int64, ocspServers ...string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "user.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		OCSPServer:   ocspServers,
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw})
+}
+
+// crl returns a PEM encoded CRL revoking the given serial numbers
+func (ca *testCA) crl(t *testing.T, serials ...int64) []byte {
+	var revoked []pkix.RevokedCertificate
+	for _, serial := range serials {
+		revoked = append(revoked, pkix.RevokedCertificate{SerialNumber: big.NewInt(serial), RevocationTime: time.Now()})
+	}
+	raw, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:              big.NewInt(time.Now().UnixNano()),
+		ThisUpdate:          time.Now().Add(-time.Minute),
+		NextUpdate:          time.Now().Add(time.Hour),
+		RevokedCertificates: revoked,
+	}, ca.cert, ca.key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: raw})
+}
+
+func (ca *testCA) pem() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+// newTestMSP returns a verifying MSP trusting the given CA
+func newTestMSP(t *testing.T, ca *testCA) MSP {
+	conf, err := proto.Marshal(&m.FabricMSPConfig{
+		Name:      "TestMSP",
+		RootCerts: [][]byte{ca.pem()},
+	})
+	assert.NoError(t, err)
+	thisMSP, err := NewBccspMsp()
+	assert.NoError(t, err)
+	err = thisMSP.Setup(&m.MSPConfig{Type: int32(FABRIC), Config: conf})
+	assert.NoError(t, err)
+	return thisMSP
+}
+
+func deserialize(t *testing.T, thisMSP MSP, cert []byte) Identity {
+	sid, err := proto.Marshal(&m.SerializedIdentity{Mspid: "TestMSP", IdBytes: cert})
+	assert.NoError(t, err)
+	id, err := thisMSP.DeserializeIdentity(sid)
+	assert.NoError(t, err)
+	return id
+}
+
+// ocspResponder answers OCSP requests with the status set for
+// each serial number, signing with the key of the CA
+type ocspResponder struct {
+	ca       *testCA
+	signer   crypto.Signer
+	lock     sync.Mutex
+	statuses map[int64]int
+	requests int
+	next     time.Duration
+
+	// serial, if set, is the serial number of every response
+	serial *big.Int
+	// delegate, if set, is the responder certificate the issuer
+	// delegated to, which is embedded in the responses
+	delegate *x509.Certificate
+}
+
+func newOCSPResponder(ca *testCA) *ocspResponder {
+	return &ocspResponder{ca: ca, signer: ca.key, statuses: map[int64]int{}, next: time.Hour}
+}
+
+func (r *ocspResponder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.requests++
+
+	raw, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	ocspReq, err := ocsp.ParseRequest(raw)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	status, ok := r.statuses[ocspReq.SerialNumber.Int64()]
+	if !ok {
+		status = ocsp.Unknown
+	}
+	serial := ocspReq.SerialNumber
+	if r.serial != nil {
+		serial = r.serial
+	}
+	responderCert := r.ca.cert
+	if r.delegate != nil {
+		responderCert = r.delegate
+	}
+	resp, err := ocsp.CreateResponse(r.ca.cert, responderCert, ocsp.Response{
+		Status:       status,
+		SerialNumber: serial,
+		Certificate:  r.delegate,
+		ThisUpdate:   time.Now().Add(-time.Minute),
+		NextUpdate:   time.Now().Add(r.next),
+		RevokedAt:    time.Now().Add(-time.Minute),
+	}, r.signer)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/ocsp-response")
+	w.Write(resp)
+}
+
+func (r *ocspResponder) set(serial int64, status int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.statuses[serial] = status
+}
+
+func (r *ocspResponder) count() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.requests
+}
+
+func TestOCSPCheckIdentity(t *testing.T) {
+	ca := newTestCA(t)
+	responder := newOCSPResponder(ca)
+	server := httptest.NewServer(responder)
+	defer server.Close()
+	thisMSP := newTestMSP(t, ca)
+
+	good := deserialize(t, thisMSP, ca.issue(t, 10, server.URL))
+	revoked := deserialize(t, thisMSP, ca.issue(t, 11, server.URL))
+	noOCSP := deserialize(t, thisMSP, ca.issue(t, 12))
+	responder.set(10, ocsp.Good)
+	responder.set(11, ocsp.Revoked)
+
+	checker := NewOCSPChecker(OCSPOptions{})
+	assert.NoError(t, checker.CheckIdentity(good))
+	err := checker.CheckIdentity(revoked)
+	assert.EqualError(t, err, "The certificate has been revoked")
+	assert.Equal(t, 2, responder.count())
+
+	// identities without OCSP responder are not checked
+	assert.NoError(t, checker.CheckIdentity(noOCSP))
+	assert.Equal(t, 2, responder.count())
+
+	// the revocation is independent from the validation against the MSP
+	assert.NoError(t, revoked.Validate())
+}
+
+func TestOCSPCache(t *testing.T) {
+	ca := newTestCA(t)
+	responder := newOCSPResponder(ca)
+	server := httptest.NewServer(responder)
+	defer server.Close()
+	thisMSP := newTestMSP(t, ca)
+
+	id := deserialize(t, thisMSP, ca.issue(t, 10, server.URL))
+	responder.set(10, ocsp.Good)
+
+	now := time.Now()
+	checker := NewOCSPChecker(OCSPOptions{CacheTTL: time.Minute})
+	checker.now = func() time.Time { return now }
+	assert.NoError(t, checker.CheckIdentity(id))
+	assert.NoError(t, checker.CheckIdentity(id))
+	assert.Equal(t, 1, responder.count())
+
+	// once the cached response expires, the revocation is picked up
+	responder.set(10, ocsp.Revoked)
+	now = now.Add(30 * time.Second)
+	assert.NoError(t, checker.CheckIdentity(id))
+	now = now.Add(time.Minute)
+	assert.Error(t, checker.CheckIdentity(id))
+	assert.Equal(t, 2, responder.count())
+
+	// responses are not cached past their NextUpdate
+	responder.set(10, ocsp.Good)
+	responder.next = 10 * time.Second
+	checker = NewOCSPChecker(OCSPOptions{CacheTTL: time.Hour})
+	now = time.Now()
+	checker.now = func() time.Time { return now }
+	assert.NoError(t, checker.CheckIdentity(id))
+	now = now.Add(20 * time.Second)
+	assert.NoError(t, checker.CheckIdentity(id))
+	assert.Equal(t, 4, responder.count())
+}
+
+func TestOCSPFailures(t *testing.T) {
+	ca := newTestCA(t)
+	responder := newOCSPResponder(ca)
+	server := httptest.NewServer(responder)
+	thisMSP := newTestMSP(t, ca)
+
+	unknown := deserialize(t, thisMSP, ca.issue(t, 10, server.URL))
+
+	// responses signed by an unrelated key are not trusted
+	badlySigned := deserialize(t, thisMSP, ca.issue(t, 11, server.URL))
+	responder.set(11, ocsp.Revoked)
+
+	softFail := NewOCSPChecker(OCSPOptions{})
+	hardFail := NewOCSPChecker(OCSPOptions{HardFail: true})
+
+	assert.NoError(t, softFail.CheckIdentity(unknown))
+	err := hardFail.CheckIdentity(unknown)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate status is unknown")
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	responder.signer = otherKey
+	assert.NoError(t, softFail.CheckIdentity(badlySigned))
+	assert.Error(t, hardFail.CheckIdentity(badlySigned))
+
+	// unreachable responders
+	server.Close()
+	unreachable := deserialize(t, thisMSP, ca.issue(t, 12, server.URL))
+	assert.NoError(t, softFail.CheckIdentity(unreachable))
+	assert.Error(t, hardFail.CheckIdentity(unreachable))
+}
+
+func TestOCSPFailureCache(t *testing.T) {
+	ca := newTestCA(t)
+	responder := newOCSPResponder(ca)
+	server := httptest.NewServer(responder)
+	defer server.Close()
+	thisMSP := newTestMSP(t, ca)
+
+	id := deserialize(t, thisMSP, ca.issue(t, 10, server.URL))
+
+	now := time.Now()
+	checker := NewOCSPChecker(OCSPOptions{HardFail: true})
+	checker.now = func() time.Time { return now }
+	assert.Error(t, checker.CheckIdentity(id))
+	assert.Error(t, checker.CheckIdentity(id))
+	assert.Equal(t, 1, responder.count())
+
+	// failures are cached for a short time only
+	responder.set(10, ocsp.Good)
+	now = now.Add(ocspFailureCacheTTL)
+	assert.NoError(t, checker.CheckIdentity(id))
+	assert.Equal(t, 2, responder.count())
+}
+
+func TestOCSPConcurrentChecks(t *testing.T) {
+	ca := newTestCA(t)
+	responder := newOCSPResponder(ca)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		responder.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	thisMSP := newTestMSP(t, ca)
+
+	id := deserialize(t, thisMSP, ca.issue(t, 10, server.URL))
+	responder.set(10, ocsp.Revoked)
+
+	// concurrent checks of the same certificate share a single query
+	checker := NewOCSPChecker(OCSPOptions{})
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- checker.CheckIdentity(id)
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.EqualError(t, err, "The certificate has been revoked")
+	}
+	assert.Equal(t, 1, responder.count())
+}
+
+func TestOCSPMultipleResponders(t *testing.T) {
+	ca := newTestCA(t)
+	responder := newOCSPResponder(ca)
+	server := httptest.NewServer(responder)
+	defer server.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	thisMSP := newTestMSP(t, ca)
+
+	// the first responder that answers is used
+	id := deserialize(t, thisMSP, ca.issue(t, 10, down.URL, server.URL))
+	responder.set(10, ocsp.Revoked)
+	checker := NewOCSPChecker(OCSPOptions{HardFail: true})
+	assert.EqualError(t, checker.CheckIdentity(id), "The certificate has been revoked")
+}
+
+func TestOCSPResponseChecks(t *testing.T) {
+	ca := newTestCA(t)
+	responder := newOCSPResponder(ca)
+	server := httptest.NewServer(responder)
+	defer server.Close()
+	thisMSP := newTestMSP(t, ca)
+
+	id := deserialize(t, thisMSP, ca.issue(t, 10, server.URL))
+	responder.set(10, ocsp.Revoked)
+
+	// responses for other certificates are not trusted
+	responder.serial = big.NewInt(11)
+	err := NewOCSPChecker(OCSPOptions{HardFail: true}).CheckIdentity(id)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "response is for another certificate")
+	responder.serial = nil
+
+	// delegated responders must be authorized to sign OCSP responses
+	for _, usage := range []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageOCSPSigning} {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(100 + int64(usage)),
+			Subject:      pkix.Name{CommonName: "ocsp.example.com"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		raw, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+		assert.NoError(t, err)
+		responder.delegate, err = x509.ParseCertificate(raw)
+		assert.NoError(t, err)
+		responder.signer = key
+
+		err = NewOCSPChecker(OCSPOptions{HardFail: true}).CheckIdentity(id)
+		if usage == x509.ExtKeyUsageOCSPSigning {
+			assert.EqualError(t, err, "The certificate has been revoked")
+		} else {
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "not authorized to sign OCSP responses")
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package msp
+
+import (
+	"bytes"
+	"path/filepath"
+	"time"
+)
+
+// WatchSigningCert checks the signcerts folder of the MSP configuration
+// directory dir every interval, and calls onChange whenever its content
+// changes. A failed onChange is only retried once the content of the
+// signcerts or keystore folders changes again.
+// It returns when stop is closed.
+func WatchSigningCert(dir string, interval time.Duration, onChange func() error, stop <-chan struct{}) {
+	signcertDir := filepath.Join(dir, signcerts)
+	// the MSP was set up with the certificate currently in the folder
+	certs, _ := getPemMaterialFromDir(signcertDir)
+	lastDigest := pemDigest(certs)
+	var failedDigest []byte
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		certs, err := getPemMaterialFromDir(signcertDir)
+		if err != nil || len(certs) == 0 {
+			// the certificate may be in the middle of being replaced
+			continue
+		}
+		digest := pemDigest(certs)
+		if bytes.Equal(digest, lastDigest) {
+			continue
+		}
+
+		// e.g. the key of the new certificate may be
+		// added to the keystore after the certificate
+		keys, _ := getPemMaterialFromDir(filepath.Join(dir, keystore))
+		attemptDigest := pemDigest(append(append([][]byte{}, certs...), keys...))
+		if bytes.Equal(attemptDigest, failedDigest) {
+			continue
+		}
+
+		mspLogger.Infof("signcerts folder at [%s] changed, updating the signing identity", signcertDir)
+		if err := onChange(); err != nil {
+			mspLogger.Errorf("Failed updating the signing identity from [%s], retrying once it changes: [%s]", signcertDir, err)
+			failedDigest = attemptDigest
+			continue
+		}
+		lastDigest = digest
+		failedDigest = nil
+	}
+}
@@ -17,12 +17,14 @@ limitations under the License.
 package broadcast
 
 import (
+	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/orderer/common/filter"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/op/go-logging"
 
 	"io"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -61,14 +63,33 @@ type Support interface {
 	Filters() *filter.RuleSet
 }
 
+// CreatorChecker performs checks on the creator of a message when it is
+// received, in addition to the filters of the chain. Unlike filters, these
+// checks are not applied again once the message is ordered, so they need
+// not be deterministic (e.g. online revocation checks).
+type CreatorChecker interface {
+	// CheckCreator returns an error if the creator of a message
+	// for the given chain must be rejected
+	CheckCreator(chainID string, creator []byte) error
+}
+
 type handlerImpl struct {
 	sm SupportManager
+	cc CreatorChecker
 }
 
 // NewHandlerImpl constructs a new implementation of the Handler interface
 func NewHandlerImpl(sm SupportManager) Handler {
+	return NewHandlerImplWithCreatorChecker(sm, nil)
+}
+
+// NewHandlerImplWithCreatorChecker constructs a new implementation of the
+// Handler interface which, when cc is not nil, rejects messages whose
+// creator does not pass the checks of cc
+func NewHandlerImplWithCreatorChecker(sm SupportManager, cc CreatorChecker) Handler {
 	return &handlerImpl{
 		sm: sm,
+		cc: cc,
 	}
 }
 
@@ -103,6 +124,15 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
 		}
 
+		// the creator of the message as received, i.e. before any
+		// CONFIG_UPDATE processing
+		shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+		if err != nil {
+			logger.Warningf("Received malformed message (bad signature header), dropping connection: %s", err)
+			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
+		}
+		creator := shdr.Creator
+
 		if chdr.Type == int32(cb.HeaderType_CONFIG_UPDATE) {
 			logger.Debugf("Preprocessing CONFIG_UPDATE")
 			msg, err = bh.sm.Process(msg)
@@ -145,6 +175,18 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST})
 		}
 
+		if crypto.IsExpired(creator, time.Now()) {
+			logger.Warningf("[channel: %s] Rejecting broadcast message because the creator identity has expired", chdr.ChannelId)
+			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_FORBIDDEN})
+		}
+
+		if bh.cc != nil {
+			if err = bh.cc.CheckCreator(chdr.ChannelId, creator); err != nil {
+				logger.Warningf("[channel: %s] Rejecting broadcast message because of creator check error: %s", chdr.ChannelId, err)
+				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_FORBIDDEN})
+			}
+		}
+
 		if !support.Enqueue(msg) {
 			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE})
 		}
@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
 	"github.com/hyperledger/fabric/orderer/common/filter"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -408,3 +409,87 @@ func TestEmptyChannelIDAfterProcessing(t *testing.T) {
 	reply := <-m.sendChan
 	assert.Equal(t, cb.Status_INTERNAL_SERVER_ERROR, reply.Status, "Should respond with internal server error")
 }
+
+type mockCreatorChecker struct {
+	chainID string
+	creator []byte
+	err     error
+}
+
+func (mcc *mockCreatorChecker) CheckCreator(chainID string, creator []byte) error {
+	mcc.chainID = chainID
+	mcc.creator = creator
+	return mcc.err
+}
+
+func makeSignedMessage(chainID string, creator []byte) *cb.Envelope {
+	payload := &cb.Payload{
+		Data: []byte("Some bytes"),
+		Header: &cb.Header{
+			ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+				ChannelId: chainID,
+			}),
+			SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+				Creator: creator,
+			}),
+		},
+	}
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(payload),
+	}
+}
+
+func TestCreatorChecker(t *testing.T) {
+	mm, _ := getMockSupportManager()
+	cc := &mockCreatorChecker{}
+	bh := NewHandlerImplWithCreatorChecker(mm, cc)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- makeSignedMessage(systemChain, []byte("creator"))
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have accepted the message")
+	assert.Equal(t, systemChain, cc.chainID)
+	assert.Equal(t, []byte("creator"), cc.creator)
+
+	cc.err = fmt.Errorf("revoked")
+	m.recvChan <- makeSignedMessage(systemChain, []byte("creator"))
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_FORBIDDEN, reply.Status, "Should have rejected the message")
+}
+
+func TestBadSignatureHeader(t *testing.T) {
+	mm, _ := getMockSupportManager()
+	bh := NewHandlerImpl(mm)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{Header: &cb.Header{
+		ChannelHeader:   utils.MarshalOrPanic(&cb.ChannelHeader{ChannelId: systemChain}),
+		SignatureHeader: []byte("garbage"),
+	}})}
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_BAD_REQUEST, reply.Status, "Should have rejected bad signature header")
+}
+
+func TestExpiredCreator(t *testing.T) {
+	mm, _ := getMockSupportManager()
+	bh := NewHandlerImpl(mm)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	creator, err := mockcrypto.MakeIdentity("SampleOrg", time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	m.recvChan <- makeSignedMessage(systemChain, creator)
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have accepted the message")
+
+	expired, err := mockcrypto.MakeIdentity("SampleOrg", time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	m.recvChan <- makeSignedMessage(systemChain, expired)
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_FORBIDDEN, reply.Status, "Should have rejected the expired creator")
+}
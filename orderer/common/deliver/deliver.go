@@ -18,7 +18,9 @@ package deliver
 
 import (
 	"io"
+	"time"
 
+	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/orderer/common/filter"
 	"github.com/hyperledger/fabric/orderer/common/sigfilter"
@@ -127,6 +129,17 @@ func (ds *deliverServer) Handle(srv ab.AtomicBroadcast_DeliverServer) error {
 			return sendStatusReply(srv, cb.Status_FORBIDDEN)
 		}
 
+		shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+		if err != nil {
+			logger.Warningf("[channel: %s] Received a deliver request with a malformed signature header: %s", chdr.ChannelId, err)
+			return sendStatusReply(srv, cb.Status_BAD_REQUEST)
+		}
+
+		if crypto.IsExpired(shdr.Creator, time.Now()) {
+			logger.Warningf("[channel: %s] Rejecting deliver request because the client identity has expired", chdr.ChannelId)
+			return sendStatusReply(srv, cb.Status_FORBIDDEN)
+		}
+
 		seekInfo := &ab.SeekInfo{}
 		if err = proto.Unmarshal(payload.Data, seekInfo); err != nil {
 			logger.Warningf("[channel: %s] Received a signed deliver request with malformed seekInfo payload: %s", chdr.ChannelId, err)
@@ -182,6 +195,11 @@ func (ds *deliverServer) Handle(srv ab.AtomicBroadcast_DeliverServer) error {
 				}
 			}
 
+			if crypto.IsExpired(shdr.Creator, time.Now()) {
+				logger.Warningf("[channel: %s] Client identity expired during deliver request", chdr.ChannelId)
+				return sendStatusReply(srv, cb.Status_FORBIDDEN)
+			}
+
 			block, status := cursor.Next()
 			if status != cb.Status_SUCCESS {
 				logger.Errorf("[channel: %s] Error reading from channel, cause was: %v", chdr.ChannelId, status)
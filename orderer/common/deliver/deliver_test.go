@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
+	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/orderer/ledger"
@@ -672,3 +673,69 @@ func TestMissingSeekPosition(t *testing.T) {
 		t.Fatalf("Timed out waiting to get all blocks")
 	}
 }
+
+func makeSignedSeek(chainID string, creator []byte, seekInfo *ab.SeekInfo) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					ChannelId: chainID,
+				}),
+				SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{Creator: creator}),
+			},
+			Data: utils.MarshalOrPanic(seekInfo),
+		}),
+	}
+}
+
+func TestExpiredCreatorSeek(t *testing.T) {
+	m := newMockD()
+	defer close(m.recvChan)
+
+	ds := initializeDeliverHandler()
+	go ds.Handle(m)
+
+	creator, err := mockcrypto.MakeIdentity("SampleOrg", time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	m.recvChan <- makeSignedSeek(systemChainID, creator, &ab.SeekInfo{Start: seekOldest, Stop: seekNewest, Behavior: ab.SeekInfo_BLOCK_UNTIL_READY})
+
+	select {
+	case deliverReply := <-m.sendChan:
+		assert.Equal(t, cb.Status_FORBIDDEN, deliverReply.GetStatus(), "Expired creator should have been forbidden")
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the reply")
+	}
+}
+
+func TestCreatorExpiresDuringSeek(t *testing.T) {
+	mm := newMockMultichainManager()
+	l := mm.chains[systemChainID].ledger
+	m := newMockD()
+	defer close(m.recvChan)
+	ds := NewHandlerImpl(mm)
+	go ds.Handle(m)
+
+	// certificates carry whole seconds, so the creator expires in 1 to 2 seconds
+	expiresAt := time.Now().Truncate(time.Second).Add(2 * time.Second)
+	creator, err := mockcrypto.MakeIdentity("SampleOrg", expiresAt)
+	assert.NoError(t, err)
+	m.recvChan <- makeSignedSeek(systemChainID, creator, &ab.SeekInfo{Start: seekOldest, Stop: seekSpecified(uint64(ledgerSize)), Behavior: ab.SeekInfo_BLOCK_UNTIL_READY})
+
+	select {
+	case deliverReply := <-m.sendChan:
+		assert.NotNil(t, deliverReply.GetBlock(), "Should have received the genesis block")
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the genesis block")
+	}
+
+	// the next block is appended once the creator has expired
+	time.Sleep(time.Until(expiresAt) + 100*time.Millisecond)
+	l.Append(ledger.CreateNextBlock(l, []*cb.Envelope{&cb.Envelope{Payload: []byte("1")}}))
+
+	select {
+	case deliverReply := <-m.sendChan:
+		assert.Equal(t, cb.Status_FORBIDDEN, deliverReply.GetStatus(), "Should have stopped delivering to the expired creator")
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the reply")
+	}
+}
@@ -64,6 +64,7 @@ type TopLevel struct {
 	FileLedger FileLedger
 	RAMLedger  RAMLedger
 	Kafka      Kafka
+	Metrics    Metrics
 }
 
 // General contains config which should be common among all orderer types.
@@ -80,6 +81,7 @@ type General struct {
 	LocalMSPDir    string
 	LocalMSPID     string
 	BCCSP          *bccsp.FactoryOpts
+	OCSP           OCSP
 }
 
 // TLS contains config for TLS connections.
@@ -92,12 +94,42 @@ type TLS struct {
 	ClientRootCAs     []string
 }
 
+// OCSP contains configuration for the OCSP checks of the creators of
+// broadcast messages.
+type OCSP struct {
+	Enabled  bool
+	CacheTTL time.Duration
+	Timeout  time.Duration
+	HardFail bool
+}
+
 // Profile contains configuration for Go pprof profiling.
 type Profile struct {
 	Enabled bool
 	Address string
 }
 
+// Metrics contains configuration for the metrics of the orderer.
+type Metrics struct {
+	Provider   string
+	Prometheus Prometheus
+	Statsd     Statsd
+}
+
+// Prometheus contains configuration for exposing metrics to Prometheus.
+type Prometheus struct {
+	ListenAddress string
+	Path          string
+}
+
+// Statsd contains configuration for pushing metrics to a statsd server.
+type Statsd struct {
+	Network       string
+	Address       string
+	WriteInterval time.Duration
+	Prefix        string
+}
+
 // FileLedger contains configuration for the file-based ledger.
 type FileLedger struct {
 	Location string
@@ -176,6 +208,11 @@ var defaults = TopLevel{
 		LocalMSPDir: "msp",
 		LocalMSPID:  "DEFAULT",
 		BCCSP:       bccsp.GetDefaultOpts(),
+		OCSP: OCSP{
+			Enabled:  false,
+			CacheTTL: 5 * time.Minute,
+			Timeout:  5 * time.Second,
+		},
 	},
 	RAMLedger: RAMLedger{
 		HistorySize: 10000,
@@ -213,6 +250,18 @@ var defaults = TopLevel{
 			Enabled: false,
 		},
 	},
+	Metrics: Metrics{
+		Provider: "disabled",
+		Prometheus: Prometheus{
+			ListenAddress: "0.0.0.0:8443",
+			Path:          "/metrics",
+		},
+		Statsd: Statsd{
+			Network:       "udp",
+			Address:       "127.0.0.1:8125",
+			WriteInterval: 10 * time.Second,
+		},
+	},
 }
 
 // Load parses the orderer.yaml file and environment, producing a struct suitable for config use
@@ -340,6 +389,25 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("Kafka.Retry.Consumer.RetryBackoff unset, setting to %v", defaults.Kafka.Retry.Consumer.RetryBackoff)
 			c.Kafka.Retry.Consumer.RetryBackoff = defaults.Kafka.Retry.Consumer.RetryBackoff
 
+		case c.Metrics.Provider == "":
+			logger.Infof("Metrics.Provider unset, setting to %s", defaults.Metrics.Provider)
+			c.Metrics.Provider = defaults.Metrics.Provider
+		case c.Metrics.Provider == "prometheus" && c.Metrics.Prometheus.ListenAddress == "":
+			logger.Infof("Metrics.Prometheus.ListenAddress unset, setting to %s", defaults.Metrics.Prometheus.ListenAddress)
+			c.Metrics.Prometheus.ListenAddress = defaults.Metrics.Prometheus.ListenAddress
+		case c.Metrics.Provider == "prometheus" && c.Metrics.Prometheus.Path == "":
+			logger.Infof("Metrics.Prometheus.Path unset, setting to %s", defaults.Metrics.Prometheus.Path)
+			c.Metrics.Prometheus.Path = defaults.Metrics.Prometheus.Path
+		case c.Metrics.Provider == "statsd" && c.Metrics.Statsd.Network == "":
+			logger.Infof("Metrics.Statsd.Network unset, setting to %s", defaults.Metrics.Statsd.Network)
+			c.Metrics.Statsd.Network = defaults.Metrics.Statsd.Network
+		case c.Metrics.Provider == "statsd" && c.Metrics.Statsd.Address == "":
+			logger.Infof("Metrics.Statsd.Address unset, setting to %s", defaults.Metrics.Statsd.Address)
+			c.Metrics.Statsd.Address = defaults.Metrics.Statsd.Address
+		case c.Metrics.Provider == "statsd" && c.Metrics.Statsd.WriteInterval == 0*time.Second:
+			logger.Infof("Metrics.Statsd.WriteInterval unset, setting to %v", defaults.Metrics.Statsd.WriteInterval)
+			c.Metrics.Statsd.WriteInterval = defaults.Metrics.Statsd.WriteInterval
+
 		case c.Kafka.Version == sarama.KafkaVersion{}:
 			logger.Infof("Kafka.Version unset, setting to %v", defaults.Kafka.Version)
 			c.Kafka.Version = defaults.Kafka.Version
@@ -29,6 +29,10 @@ import (
 	"github.com/hyperledger/fabric/common/configtx/tool/provisional"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/prometheus"
+	"github.com/hyperledger/fabric/common/metrics/statsd"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
 	"github.com/hyperledger/fabric/orderer/kafka"
@@ -43,6 +47,7 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	logging "github.com/op/go-logging"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -71,9 +76,10 @@ func main() {
 		initializeProfilingService(conf)
 		grpcServer := initializeGrpcServer(conf)
 		initializeLocalMsp(conf)
+		initializeCertExpirationTracking(conf, initializeMetricsProvider(conf))
 		signer := localmsp.NewSigner()
 		manager := initializeMultiChainManager(conf, signer)
-		server := NewServer(manager, signer)
+		server := NewServer(manager, signer, initializeOCSPChecker(conf))
 		ab.RegisterAtomicBroadcastServer(grpcServer.Server(), server)
 		logger.Info("Beginning to serve requests")
 		grpcServer.Start()
@@ -203,6 +209,76 @@ func initializeLocalMsp(conf *config.TopLevel) {
 	}
 }
 
+// initializeMetricsProvider creates the metrics provider configured in the
+// Metrics section, and starts exposing or pushing its metrics
+func initializeMetricsProvider(conf *config.TopLevel) metrics.Provider {
+	switch conf.Metrics.Provider {
+	case "prometheus":
+		provider := prometheus.NewProvider()
+		lis, err := net.Listen("tcp", conf.Metrics.Prometheus.ListenAddress)
+		if err != nil {
+			logger.Fatalf("Failed listening for metrics requests on %s: %s", conf.Metrics.Prometheus.ListenAddress, err)
+		}
+		mux := http.NewServeMux()
+		mux.Handle(conf.Metrics.Prometheus.Path, provider)
+		logger.Infof("Exposing Prometheus metrics on %s", conf.Metrics.Prometheus.ListenAddress)
+		go func() {
+			logger.Error("Metrics server exited:", http.Serve(lis, mux))
+		}()
+		return provider
+	case "statsd":
+		conn, err := net.Dial(conf.Metrics.Statsd.Network, conf.Metrics.Statsd.Address)
+		if err != nil {
+			logger.Fatalf("Failed connecting to statsd server at %s: %s", conf.Metrics.Statsd.Address, err)
+		}
+		provider := statsd.NewProvider(conf.Metrics.Statsd.Prefix)
+		logger.Infof("Pushing metrics to statsd server at %s every %s", conf.Metrics.Statsd.Address, conf.Metrics.Statsd.WriteInterval)
+		provider.Start(conn, conf.Metrics.Statsd.WriteInterval)
+		return provider
+	case "disabled":
+		return &disabled.Provider{}
+	default:
+		logger.Panic("Unknown metrics provider:", conf.Metrics.Provider)
+		return nil
+	}
+}
+
+// initializeCertExpirationTracking warns about, and reports the time left
+// before, the expiration of the signing and TLS certificates of the orderer
+func initializeCertExpirationTracking(conf *config.TopLevel, provider metrics.Provider) {
+	serializedIdentity, err := mspmgmt.GetLocalSigningIdentityOrPanic().Serialize()
+	if err != nil {
+		logger.Fatal("Failed serializing the signing identity:", err)
+	}
+	certs := []crypto.CertificateExpiration{
+		{Name: "signing", ExpiresAt: crypto.ExpiresAt(serializedIdentity)},
+	}
+	if conf.General.TLS.Enabled {
+		serverCertificate, err := ioutil.ReadFile(conf.General.TLS.Certificate)
+		if err != nil {
+			logger.Fatalf("Failed to load ServerCertificate file '%s' (%s)", conf.General.TLS.Certificate, err)
+		}
+		certs = append(certs, crypto.CertificateExpiration{
+			Name:      "tls",
+			ExpiresAt: crypto.CertExpiresAt(serverCertificate),
+		})
+	}
+	// tracks the certificates for the lifetime of the process
+	go crypto.TrackExpiration(certs, crypto.NewExpirationGauge(provider, "orderer"), nil)
+}
+
+func initializeOCSPChecker(conf *config.TopLevel) *msp.OCSPChecker {
+	if !conf.General.OCSP.Enabled {
+		return nil
+	}
+	logger.Infof("Checking the revocation status of broadcast creators through OCSP")
+	return msp.NewOCSPChecker(msp.OCSPOptions{
+		CacheTTL: conf.General.OCSP.CacheTTL,
+		Timeout:  conf.General.OCSP.Timeout,
+		HardFail: conf.General.OCSP.HardFail,
+	})
+}
+
 func initializeMultiChainManager(conf *config.TopLevel, signer crypto.LocalSigner) multichain.Manager {
 	lf, _ := createLedgerFactory(conf)
 	// Are we bootstrapping?
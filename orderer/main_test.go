@@ -288,3 +288,54 @@ func (b *panicOnCriticalBackend) Log(level logging.Level, calldepth int, record
 	}
 	return err
 }
+
+func TestInitializeMetricsProvider(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		assert.NotNil(t, initializeMetricsProvider(&config.TopLevel{Metrics: config.Metrics{Provider: "disabled"}}))
+	})
+	t.Run("Prometheus", func(t *testing.T) {
+		// get a free random port
+		listenAddr := func() string {
+			l, _ := net.Listen("tcp", "localhost:0")
+			l.Close()
+			return l.Addr().String()
+		}()
+		provider := initializeMetricsProvider(&config.TopLevel{Metrics: config.Metrics{
+			Provider:   "prometheus",
+			Prometheus: config.Prometheus{ListenAddress: listenAddr, Path: "/metrics"},
+		}})
+		localMSPDir, _ := coreconfig.GetDevMspDir()
+		conf := &config.TopLevel{
+			General: config.General{
+				LocalMSPDir: localMSPDir,
+				LocalMSPID:  "DEFAULT",
+				BCCSP: &factory.FactoryOpts{
+					ProviderName: "SW",
+					SwOpts: &factory.SwOpts{
+						HashFamily: "SHA2",
+						SecLevel:   256,
+						Ephemeral:  true,
+					},
+				},
+			},
+		}
+		initializeLocalMsp(conf)
+		initializeCertExpirationTracking(conf, provider)
+		var body []byte
+		for i := 0; i < 10 && !strings.Contains(string(body), "orderer_certificate_seconds_until_expiration"); i++ {
+			time.Sleep(100 * time.Millisecond)
+			resp, err := http.Get("http://" + listenAddr + "/metrics")
+			if err != nil {
+				continue
+			}
+			body, _ = ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+		assert.Contains(t, string(body), `orderer_certificate_seconds_until_expiration{certificate="signing"}`)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		assert.Panics(t, func() {
+			initializeMetricsProvider(&config.TopLevel{Metrics: config.Metrics{Provider: "unknown"}})
+		})
+	})
+}
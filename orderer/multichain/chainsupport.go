@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/configtxfilter"
@@ -86,6 +87,9 @@ type ChainSupport interface {
 	// PolicyManager returns the current policy manager as specified by the chain config
 	PolicyManager() policies.Manager
 
+	// MSPManager returns the current MSP manager as specified by the chain config
+	MSPManager() msp.MSPManager
+
 	// Reader returns the chain Reader for the chain
 	Reader() ledger.Reader
 
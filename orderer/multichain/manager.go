@@ -64,6 +64,54 @@ func (cr *configResources) SharedConfig() config.Orderer {
 	return oc
 }
 
+// ProposeConfigUpdate applies a CONFIG_UPDATE to the current config and, in
+// addition to the checks of the config manager, rejects the update if the
+// resulting config requires capabilities which this orderer does not support
+func (cr *configResources) ProposeConfigUpdate(env *cb.Envelope) (*cb.ConfigEnvelope, error) {
+	configEnv, err := cr.Manager.ProposeConfigUpdate(env)
+	if err != nil {
+		return nil, err
+	}
+
+	proposedEnv, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, cr.ChainID(), nil, configEnv, msgVersion, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	proposedManager, err := configtx.NewManagerImpl(proposedEnv, configtx.NewInitializer(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkResources(proposedManager); err != nil {
+		return nil, fmt.Errorf("Config update is not compatible: %s", err)
+	}
+
+	return configEnv, nil
+}
+
+// checkResources returns an error if the channel or orderer capabilities
+// required by the config are not supported by this orderer
+func checkResources(res configtxapi.Resources) error {
+	if err := res.ChannelConfig().Capabilities().Supported(); err != nil {
+		return err
+	}
+
+	oc, ok := res.OrdererConfig()
+	if !ok {
+		return fmt.Errorf("Config does not contain orderer config")
+	}
+	return oc.Capabilities().Supported()
+}
+
+// checkResourcesOrPanic halts the orderer when a channel requires capabilities
+// which it does not support, as it cannot safely order for the channel
+func checkResourcesOrPanic(cm configtxapi.Manager) {
+	if err := checkResources(cm); err != nil {
+		logger.Panicf("[channel %s] incompatible config, this orderer must be upgraded: %s", cm.ChainID(), err)
+	}
+}
+
 type ledgerResources struct {
 	*configResources
 	ledger ledger.ReadWriter
@@ -159,7 +207,7 @@ func (ml *multiLedger) GetChain(chainID string) (ChainSupport, bool) {
 
 func (ml *multiLedger) newLedgerResources(configTx *cb.Envelope) *ledgerResources {
 	initializer := configtx.NewInitializer()
-	configManager, err := configtx.NewManagerImpl(configTx, initializer, nil)
+	configManager, err := configtx.NewManagerImpl(configTx, initializer, []func(configtxapi.Manager){checkResourcesOrPanic})
 	if err != nil {
 		logger.Panicf("Error creating configtx manager and handlers: %s", err)
 	}
@@ -202,6 +250,24 @@ func (ml *multiLedger) channelsCount() int {
 	return len(ml.chains)
 }
 
+// zeroVersions recursively sets the version of the group and of all of its
+// values, policies and sub-groups to 0
+func zeroVersions(cg *cb.ConfigGroup) {
+	cg.Version = 0
+
+	for _, value := range cg.Values {
+		value.Version = 0
+	}
+
+	for _, policy := range cg.Policies {
+		policy.Version = 0
+	}
+
+	for _, group := range cg.Groups {
+		zeroVersions(group)
+	}
+}
+
 func (ml *multiLedger) NewChannelConfig(envConfigUpdate *cb.Envelope) (configtxapi.Manager, error) {
 	configUpdatePayload, err := utils.UnmarshalPayload(envConfigUpdate.Payload)
 	if err != nil {
@@ -308,6 +374,16 @@ func (ml *multiLedger) NewChannelConfig(envConfigUpdate *cb.Envelope) (configtxa
 	channelGroup.Groups[config.ApplicationGroupKey] = applicationGroup
 	channelGroup.Values[config.ConsortiumKey] = config.TemplateConsortium(consortium.Name).Values[config.ConsortiumKey]
 
+	// Non-backwards compatible bugfix, gated so that orderers which do not
+	// support it produce the same template, the capability check should be
+	// removed once v1.0 is deprecated
+	if oc, ok := ml.systemChannel.OrdererConfig(); ok && oc.Capabilities().PredictableChannelTemplate() {
+		// The groups, values and policies are shared with the system channel config
+		channelGroup = proto.Clone(channelGroup).(*cb.ConfigGroup)
+		channelGroup.ModPolicy = systemChannelGroup.ModPolicy
+		zeroVersions(channelGroup)
+	}
+
 	templateConfig, _ := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, configUpdate.ChannelId, ml.signer, &cb.ConfigEnvelope{
 		Config: &cb.Config{
 			ChannelGroup: channelGroup,
@@ -535,6 +535,56 @@ func TestMismatchedChannelIDs(t *testing.T) {
 	assert.Regexp(t, "mismatched channel IDs", err.Error())
 }
 
+func TestUnsupportedCapabilities(t *testing.T) {
+	ordererConf := *conf.Orderer
+	ordererConf.Capabilities = map[string]bool{"V9_9": true}
+	unsupportedConf := *conf
+	unsupportedConf.Orderer = &ordererConf
+
+	rlf := ramledger.New(10)
+	rl, err := rlf.GetOrCreate(provisional.TestChainID)
+	assert.NoError(t, err)
+	assert.NoError(t, rl.Append(provisional.New(&unsupportedConf).GenesisBlock()))
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	assert.Panics(t, func() { NewManagerImpl(rlf, consenters, mockCrypto()) }, "Should have halted on unsupported capabilities")
+}
+
+func TestPredictableChannelTemplate(t *testing.T) {
+	ordererConf := *conf.Orderer
+	ordererConf.Capabilities = map[string]bool{"V1_1": true}
+	v11Conf := *conf
+	v11Conf.Orderer = &ordererConf
+	v11GenesisBlock := provisional.New(&v11Conf).GenesisBlock()
+
+	rlf := ramledger.New(10)
+	rl, err := rlf.GetOrCreate(provisional.TestChainID)
+	assert.NoError(t, err)
+	assert.NoError(t, rl.Append(v11GenesisBlock))
+
+	consenters := make(map[string]Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewManagerImpl(rlf, consenters, mockCrypto())
+
+	envConfigUpdate, err := configtx.MakeChainCreationTransaction("predictable-chain", genesisconfig.SampleConsortiumName, mockSigningIdentity)
+	assert.NoError(t, err, "Constructing chain creation tx")
+
+	cm, err := manager.NewChannelConfig(envConfigUpdate)
+	assert.NoError(t, err, "Constructing initial channel config")
+
+	systemChannelGroup := configtx.UnmarshalConfigEnvelopeOrPanic(utils.UnmarshalPayloadOrPanic(utils.ExtractEnvelopeOrPanic(v11GenesisBlock, 0).Payload).Data).Config.ChannelGroup
+	channelGroup := cm.ConfigEnvelope().Config.ChannelGroup
+	assert.NotEmpty(t, systemChannelGroup.ModPolicy)
+	assert.Equal(t, systemChannelGroup.ModPolicy, channelGroup.ModPolicy, "Channel template should use the mod policy of the system channel")
+	assert.Equal(t, uint64(0), channelGroup.Groups[config.OrdererGroupKey].Version, "Channel template should not carry the versions of the system channel")
+
+	_, err = cm.ProposeConfigUpdate(envConfigUpdate)
+	assert.NoError(t, err, "Proposing initial update")
+}
+
 // This test brings up the entire system, with the mock consenter, including the broadcasters etc. and creates a new chain
 func TestNewChain(t *testing.T) {
 	expectedLastConfigBlockNumber := uint64(0)
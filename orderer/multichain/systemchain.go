@@ -185,5 +185,14 @@ func (scf *systemChainFilter) authorizeAndInspect(configTx *cb.Envelope) error {
 	}
 
 	// Make sure that the config does not modify any of the orderer
-	return scf.inspect(proposedManager, configManager)
+	if err = scf.inspect(proposedManager, configManager); err != nil {
+		return err
+	}
+
+	// Make sure that the channel does not require unsupported capabilities
+	if err = checkResources(proposedManager); err != nil {
+		return fmt.Errorf("Rejecting chain proposal: %s", err)
+	}
+
+	return nil
 }
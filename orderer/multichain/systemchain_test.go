@@ -52,6 +52,7 @@ type mockChainCreator struct {
 	ms                  *mockSupport
 	newChains           []*cb.Envelope
 	NewChannelConfigErr error
+	CapabilitiesErr     error
 }
 
 func newMockChainCreator() *mockChainCreator {
@@ -75,6 +76,16 @@ func (mcc *mockChainCreator) NewChannelConfig(envConfigUpdate *cb.Envelope) (con
 	}
 	confUpdate := configtx.UnmarshalConfigUpdateOrPanic(configtx.UnmarshalConfigUpdateEnvelopeOrPanic(utils.UnmarshalPayloadOrPanic(envConfigUpdate.Payload).Data).ConfigUpdate)
 	return &mockconfigtx.Manager{
+		Initializer: mockconfigtx.Initializer{
+			Resources: mockconfigtx.Resources{
+				ChannelConfigVal: &mockconfig.Channel{
+					CapabilitiesVal: &mockconfig.ChannelCapabilities{SupportedErr: mcc.CapabilitiesErr},
+				},
+				OrdererConfigVal: &mockconfig.Orderer{
+					CapabilitiesVal: &mockconfig.OrdererCapabilities{},
+				},
+			},
+		},
 		ConfigEnvelopeVal: &cb.ConfigEnvelope{
 			Config:     &cb.Config{Sequence: 1, ChannelGroup: confUpdate.WriteSet},
 			LastUpdate: envConfigUpdate,
@@ -139,6 +150,32 @@ func TestProposalRejectedByConfig(t *testing.T) {
 	assert.Len(t, mcc.newChains, 0, "Proposal should not have created a new chain")
 }
 
+func TestProposalRejectedByCapabilities(t *testing.T) {
+	newChainID := "NewChainID"
+
+	mcc := newMockChainCreator()
+	mcc.CapabilitiesErr = fmt.Errorf("Channel capability V9_9 is required but not supported")
+
+	configEnv, err := configtx.NewCompositeTemplate(
+		configtx.NewSimpleTemplate(
+			config.DefaultHashingAlgorithm(),
+			config.DefaultBlockDataHashingStructure(),
+			config.TemplateOrdererAddresses([]string{"foo"}),
+		),
+		configtx.NewChainCreationTemplate("SampleConsortium", []string{}),
+	).Envelope(newChainID)
+	assert.Nil(t, err, "Error constructing configtx")
+
+	ingressTx := makeConfigTxFromConfigUpdateEnvelope(newChainID, configEnv)
+	wrapped := wrapConfigTx(ingressTx)
+
+	sysFilter := newSystemChainFilter(mcc.ms, mcc)
+	action, _ := sysFilter.Apply(wrapped)
+
+	assert.EqualValues(t, filter.Reject, action, "Should have rejected the channel requiring unsupported capabilities")
+	assert.Len(t, mcc.newChains, 0, "Should not have created a new chain")
+}
+
 func TestNumChainsExceeded(t *testing.T) {
 	newChainID := "NewChainID"
 
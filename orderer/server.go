@@ -17,7 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"fmt"
+
 	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/deliver"
 	"github.com/hyperledger/fabric/orderer/configupdate"
@@ -44,6 +47,25 @@ func (bs broadcastSupport) GetChain(chainID string) (broadcast.Support, bool) {
 	return bs.Manager.GetChain(chainID)
 }
 
+// ocspCreatorChecker rejects broadcast messages whose creator's
+// certificate has been revoked according to its OCSP responder
+type ocspCreatorChecker struct {
+	multichain.Manager
+	checker *msp.OCSPChecker
+}
+
+func (occ ocspCreatorChecker) CheckCreator(chainID string, creator []byte) error {
+	chain, ok := occ.Manager.GetChain(chainID)
+	if !ok {
+		return fmt.Errorf("channel %s not found", chainID)
+	}
+	id, err := chain.MSPManager().DeserializeIdentity(creator)
+	if err != nil {
+		return fmt.Errorf("failed to deserialize creator identity: %s", err)
+	}
+	return occ.checker.CheckIdentity(id)
+}
+
 type deliverSupport struct {
 	multichain.Manager
 }
@@ -57,14 +79,20 @@ type server struct {
 	dh deliver.Handler
 }
 
-// NewServer creates an ab.AtomicBroadcastServer based on the broadcast target and ledger Reader
-func NewServer(ml multichain.Manager, signer crypto.LocalSigner) ab.AtomicBroadcastServer {
+// NewServer creates an ab.AtomicBroadcastServer based on the broadcast target and ledger Reader.
+// When ocspChecker is not nil, broadcast messages whose creator has been revoked according to
+// OCSP are rejected.
+func NewServer(ml multichain.Manager, signer crypto.LocalSigner, ocspChecker *msp.OCSPChecker) ab.AtomicBroadcastServer {
+	var cc broadcast.CreatorChecker
+	if ocspChecker != nil {
+		cc = ocspCreatorChecker{Manager: ml, checker: ocspChecker}
+	}
 	s := &server{
 		dh: deliver.NewHandlerImpl(deliverSupport{Manager: ml}),
-		bh: broadcast.NewHandlerImpl(broadcastSupport{
+		bh: broadcast.NewHandlerImplWithCreatorChecker(broadcastSupport{
 			Manager:               ml,
 			ConfigUpdateProcessor: configupdate.New(ml.SystemChannelID(), configUpdateSupport{Manager: ml}, signer),
-		}),
+		}, cc),
 	}
 	return s
 }
@@ -76,6 +76,7 @@ var (
 	orderingEndpoint  string
 	tls               bool
 	caFile            string
+	isInit            bool
 )
 
 var chaincodeCmd = &cobra.Command{
@@ -116,6 +117,8 @@ func resetFlags() {
 		fmt.Sprint("The name of the endorsement system chaincode to be used for this chaincode"))
 	flags.StringVarP(&vscc, "vscc", "V", common.UndefinedParamValue,
 		fmt.Sprint("The name of the verification system chaincode to be used for this chaincode"))
+	flags.BoolVarP(&isInit, "isInit", "I", false,
+		fmt.Sprint("Invoke the Init function of a chaincode whose definition requires explicit initialization"))
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
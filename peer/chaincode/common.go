@@ -83,6 +83,7 @@ func getChaincodeSpec(cmd *cobra.Command) (*pb.ChaincodeSpec, error) {
 	if err := json.Unmarshal([]byte(chaincodeCtorJSON), &input); err != nil {
 		return spec, fmt.Errorf("Chaincode argument error: %s", err)
 	}
+	input.IsInit = isInit
 
 	chaincodeLang = strings.ToUpper(chaincodeLang)
 	if pb.ChaincodeSpec_Type_value[chaincodeLang] == int32(pb.ChaincodeSpec_JAVA) {
@@ -101,6 +102,8 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, args []string, invoke bool, cf *
 	if err != nil {
 		return err
 	}
+	//queries are not submitted for ordering
+	spec.Input.IsQuery = !invoke
 
 	proposalResp, err := ChaincodeInvokeOrQuery(
 		spec,
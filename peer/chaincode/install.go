@@ -66,14 +66,20 @@ func installCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	return chaincodeInstallCmd
 }
 
-//install the depspec to "peer.address"
-func install(msg proto.Message, cf *ChaincodeCmdFactory) error {
+//install the depspec to "peer.address". Packages which are not a proto.Message,
+//such as tar packages, are given as bytes
+func install(msg proto.Message, ccpackbytes []byte, cf *ChaincodeCmdFactory) error {
 	creator, err := cf.Signer.Serialize()
 	if err != nil {
 		return fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
 	}
 
-	prop, _, err := utils.CreateInstallProposalFromCDS(msg, creator)
+	var prop *pb.Proposal
+	if ccpackbytes != nil {
+		prop, _, err = utils.CreateInstallProposalFromPackage(ccpackbytes, creator)
+	} else {
+		prop, _, err = utils.CreateInstallProposalFromCDS(msg, creator)
+	}
 	if err != nil {
 		return fmt.Errorf("Error creating proposal  %s: %s", chainFuncName, err)
 	}
@@ -115,23 +121,28 @@ func genChaincodeDeploymentSpec(cmd *cobra.Command, chaincodeName, chaincodeVers
 	return cds, nil
 }
 
-//getPackageFromFile get the chaincode package from file and the extracted ChaincodeDeploymentSpec
-func getPackageFromFile(ccpackfile string) (proto.Message, *pb.ChaincodeDeploymentSpec, error) {
+//getPackageFromFile get the chaincode package from file and the extracted ChaincodeDeploymentSpec.
+//A tar package is not a proto.Message, its bytes are returned instead
+func getPackageFromFile(ccpackfile string) (proto.Message, []byte, *pb.ChaincodeDeploymentSpec, error) {
 	b, err := ioutil.ReadFile(ccpackfile)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	//the bytes should be a valid package (CDS or SigedCDS)
+	//the bytes should be a valid package (CDS, SigedCDS or tar)
 	ccpack, err := ccprovider.GetCCPackage(b)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+
+	if _, ok := ccpack.(*ccprovider.TarPackage); ok {
+		return nil, b, ccpack.GetDepSpec(), nil
 	}
 
 	//either CDS or Envelope
 	o, err := ccpack.GetPackageObject(), nil
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	//try CDS first
@@ -140,23 +151,23 @@ func getPackageFromFile(ccpackfile string) (proto.Message, *pb.ChaincodeDeployme
 		//try Envelope next
 		env, ok := o.(*pcommon.Envelope)
 		if !ok || env == nil {
-			return nil, nil, fmt.Errorf("error extracting valid chaincode package")
+			return nil, nil, nil, fmt.Errorf("error extracting valid chaincode package")
 		}
 
 		//this will check for a valid package Envelope
 		_, sCDS, err := ccpackage.ExtractSignedCCDepSpec(env)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error extracting valid signed chaincode package(%s)", err)
+			return nil, nil, nil, fmt.Errorf("error extracting valid signed chaincode package(%s)", err)
 		}
 
 		//...and get the CDS at last
 		cds, err = utils.GetChaincodeDeploymentSpec(sCDS.ChaincodeDeploymentSpec)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error extracting chaincode deployment spec(%s)", err)
+			return nil, nil, nil, fmt.Errorf("error extracting chaincode deployment spec(%s)", err)
 		}
 	}
 
-	return o, cds, nil
+	return o, nil, cds, nil
 }
 
 // chaincodeInstall installs the chaincode. If remoteinstall, does it via a lscc call
@@ -170,6 +181,7 @@ func chaincodeInstall(cmd *cobra.Command, ccpackfile string, cf *ChaincodeCmdFac
 	}
 
 	var ccpackmsg proto.Message
+	var ccpackbytes []byte
 	if ccpackfile == "" {
 		if chaincodePath == common.UndefinedParamValue || chaincodeVersion == common.UndefinedParamValue || chaincodeName == common.UndefinedParamValue {
 			return fmt.Errorf("Must supply value for %s name, path and version parameters.", chainFuncName)
@@ -183,7 +195,7 @@ func chaincodeInstall(cmd *cobra.Command, ccpackfile string, cf *ChaincodeCmdFac
 		//read in a package generated by the "package" sub-command (and perhaps signed
 		//by multiple owners with the "signpackage" sub-command)
 		var cds *pb.ChaincodeDeploymentSpec
-		ccpackmsg, cds, err = getPackageFromFile(ccpackfile)
+		ccpackmsg, ccpackbytes, cds, err = getPackageFromFile(ccpackfile)
 
 		if err != nil {
 			return err
@@ -204,7 +216,7 @@ func chaincodeInstall(cmd *cobra.Command, ccpackfile string, cf *ChaincodeCmdFac
 		}
 	}
 
-	err = install(ccpackmsg, cf)
+	err = install(ccpackmsg, ccpackbytes, cf)
 
 	return err
 }
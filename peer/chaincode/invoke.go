@@ -39,6 +39,7 @@ func invokeCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		"name",
 		"ctor",
 		"channelID",
+		"isInit",
 	}
 	attachFlags(chaincodeInvokeCmd, flagList)
 
@@ -18,6 +18,9 @@ package chaincode
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"io/ioutil"
 
@@ -26,6 +29,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/core/common/ccpackage"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	pcommon "github.com/hyperledger/fabric/protos/common"
@@ -37,10 +41,20 @@ var chaincodePackageCmd *cobra.Command
 var createSignedCCDepSpec bool
 var signCCDepSpec bool
 var instantiationPolicy string
+var packageFormat string
+var packageLabel string
+var packageMetaInf string
+var collectionsConfig string
 
 const packageCmdName = "package"
 const packageDesc = "Package the specified chaincode into a deployment spec."
 
+// formats of the chaincode package
+const (
+	cdsPackageFormat = "cds"
+	tarPackageFormat = "tar"
+)
+
 type ccDepSpecFactory func(spec *pb.ChaincodeSpec) (*pb.ChaincodeDeploymentSpec, error)
 
 func defaultCDSFactory(spec *pb.ChaincodeSpec) (*pb.ChaincodeDeploymentSpec, error) {
@@ -77,6 +91,10 @@ func packageCmd(cf *ChaincodeCmdFactory, cdsFact ccDepSpecFactory) *cobra.Comman
 	chaincodePackageCmd.Flags().BoolVarP(&createSignedCCDepSpec, "cc-package", "s", false, "create CC deployment spec for owner endorsements instead of raw CC deployment spec")
 	chaincodePackageCmd.Flags().BoolVarP(&signCCDepSpec, "sign", "S", false, "if creating CC deployment spec package for owner endorsements, also sign it with local MSP")
 	chaincodePackageCmd.Flags().StringVarP(&instantiationPolicy, "instantiate-policy", "i", "", "instantiation policy for the chaincode")
+	chaincodePackageCmd.Flags().StringVarP(&packageFormat, "format", "f", cdsPackageFormat, "format of the package, either \"cds\" (deployment spec) or \"tar\" (tar.gz archive with metadata and artifacts)")
+	chaincodePackageCmd.Flags().StringVarP(&packageLabel, "label", "", "", "label of a tar package, defaults to <name>_<version>")
+	chaincodePackageCmd.Flags().StringVarP(&packageMetaInf, "metainf", "", "", "directory whose content is added to a tar package under META-INF, e.g. index definitions")
+	chaincodePackageCmd.Flags().StringVarP(&collectionsConfig, "collections-config", "", "", "file holding the collection configuration added to a tar package")
 
 	return chaincodePackageCmd
 }
@@ -172,7 +190,17 @@ func chaincodePackage(cmd *cobra.Command, args []string, cdsFact ccDepSpecFactor
 	}
 
 	var bytesToWrite []byte
-	if createSignedCCDepSpec {
+	if packageFormat == tarPackageFormat {
+		if createSignedCCDepSpec {
+			return fmt.Errorf("tar packages cannot be signed by owners")
+		}
+		bytesToWrite, err = getChaincodeTarPackage(cds)
+		if err != nil {
+			return err
+		}
+	} else if packageFormat != cdsPackageFormat {
+		return fmt.Errorf("unknown package format %s", packageFormat)
+	} else if createSignedCCDepSpec {
 		bytesToWrite, err = getChaincodeInstallPackage(cds, cf)
 		if err != nil {
 			return err
@@ -189,5 +217,61 @@ func chaincodePackage(cmd *cobra.Command, args []string, cdsFact ccDepSpecFactor
 		return err
 	}
 
+	if packageFormat == tarPackageFormat {
+		ccpack := &ccprovider.TarPackage{}
+		if _, err = ccpack.InitFromBuffer(bytesToWrite); err != nil {
+			return err
+		}
+		fmt.Printf("Package ID: %s\n", ccpack.GetPackageID())
+	}
+
 	return err
 }
+
+//getChaincodeTarPackage returns a tar package holding the code of the
+//deployment spec along with the artifacts given on the command line
+func getChaincodeTarPackage(cds *pb.ChaincodeDeploymentSpec) ([]byte, error) {
+	id := cds.ChaincodeSpec.ChaincodeId
+	md := &ccpackage.PackageMetadata{
+		Label:   packageLabel,
+		Type:    strings.ToLower(cds.ChaincodeSpec.Type.String()),
+		Path:    id.Path,
+		Name:    id.Name,
+		Version: id.Version,
+	}
+	if md.Label == "" {
+		md.Label = id.Name + "_" + id.Version
+	}
+
+	artifacts := make(map[string][]byte)
+	if collectionsConfig != "" {
+		b, err := ioutil.ReadFile(collectionsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading collection configuration: %s", err)
+		}
+		artifacts[ccpackage.CollectionsFile] = b
+	}
+
+	if packageMetaInf != "" {
+		err := filepath.Walk(packageMetaInf, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(packageMetaInf, path)
+			if err != nil {
+				return err
+			}
+			b, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			artifacts[ccpackage.MetaInfDir+filepath.ToSlash(rel)] = b
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error reading META-INF directory %s: %s", packageMetaInf, err)
+		}
+	}
+
+	return ccpackage.CreateTarPackage(md, cds.CodePackage, artifacts)
+}
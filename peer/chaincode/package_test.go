@@ -17,13 +17,18 @@
 package chaincode
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/peer/common"
 	pcommon "github.com/hyperledger/fabric/protos/common"
@@ -86,6 +91,72 @@ func TestCDSPackage(t *testing.T) {
 	}
 }
 
+// TestTarPackage tests generation of a tar package with artifacts
+func TestTarPackage(t *testing.T) {
+	pdir := newTempDir()
+	defer os.RemoveAll(pdir)
+
+	metainf := pdir + "/META-INF/statedb/couchdb/indexes"
+	if err := os.MkdirAll(metainf, 0755); err != nil {
+		t.Fatalf("could not create META-INF directory: %s", err)
+	}
+	if err := ioutil.WriteFile(metainf+"/index.json", []byte("{}"), 0644); err != nil {
+		t.Fatalf("could not write index: %s", err)
+	}
+
+	ccpackfile := pdir + "/ccpack.tar.gz"
+	err := createSignedCDSPackage([]string{"-n", "somecc", "-p", "some/go/package", "-v", "0", "-f", "tar", "--metainf", pdir + "/META-INF", ccpackfile}, false)
+	if err != nil {
+		t.Fatalf("Run chaincode package cmd error:%v", err)
+	}
+
+	_, b, cds, err := getPackageFromFile(ccpackfile)
+	if err != nil {
+		t.Fatalf("could not read tar package: %s", err)
+	}
+	if b == nil || cds.ChaincodeSpec.ChaincodeId.Name != "somecc" || string(cds.CodePackage) != "somecode" {
+		t.Fatalf("unexpected content of tar package")
+	}
+
+	ccpack := &ccprovider.TarPackage{}
+	if _, err = ccpack.InitFromBuffer(b); err != nil {
+		t.Fatalf("could not read tar package: %s", err)
+	}
+	if ccpack.GetMetadata().Label != "somecc_0" {
+		t.Fatalf("expected default label somecc_0, got %s", ccpack.GetMetadata().Label)
+	}
+	if !tarContains(t, b, "META-INF/statedb/couchdb/indexes/index.json") {
+		t.Fatalf("expected index in tar package")
+	}
+
+	//tar packages are not signed by owners
+	err = createSignedCDSPackage([]string{"-n", "somecc", "-p", "some/go/package", "-v", "0", "-f", "tar", "-s", pdir + "/other"}, false)
+	if err == nil {
+		t.Fatalf("expected error creating signed tar package")
+	}
+}
+
+//tarContains returns whether the gzipped tar archive has the given file
+func tarContains(t *testing.T, b []byte, name string) bool {
+	gr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("could not open tar package: %s", err)
+	}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return false
+		}
+		if err != nil {
+			t.Fatalf("could not read tar package: %s", err)
+		}
+		if hdr.Name == name {
+			return true
+		}
+	}
+}
+
 //helper to create a SignedChaincodeDeploymentSpec
 func createSignedCDSPackage(args []string, sign bool) error {
 	InitMSP()
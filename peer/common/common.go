@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/configtx"
 	configtxapi "github.com/hyperledger/fabric/common/configtx/api"
@@ -85,7 +86,7 @@ func InitConfig(cmdRoot string) error {
 }
 
 //InitCrypto initializes crypto for this peer
-func InitCrypto(mspMgrConfigDir string, localMSPID string) error {
+func InitCrypto(mspMgrConfigDir, localMSPID, localMSPType string) error {
 	var err error
 	// Check whenever msp folder exists
 	_, err = os.Stat(mspMgrConfigDir)
@@ -101,7 +102,7 @@ func InitCrypto(mspMgrConfigDir string, localMSPID string) error {
 		return fmt.Errorf("could not parse YAML config [%s]", err)
 	}
 
-	err = mspmgmt.LoadLocalMsp(mspMgrConfigDir, bccspConfig, localMSPID)
+	err = mspmgmt.LoadLocalMspWithType(mspMgrConfigDir, bccspConfig, localMSPID, localMSPType)
 	if err != nil {
 		return fmt.Errorf("error when setting up MSP from directory %s: err %s", mspMgrConfigDir, err)
 	}
@@ -141,6 +142,33 @@ func GetDefaultSigner() (msp.SigningIdentity, error) {
 	return signer, err
 }
 
+// CreateAdminRequest wraps a request to the admin service of the peer
+// into a proposal signed by the given signer
+func CreateAdminRequest(request proto.Message, signer msp.SigningIdentity) (*pb.SignedProposal, error) {
+	creator, err := signer.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("Error serializing identity: %s", err)
+	}
+	nonce, err := putils.CreateNonce()
+	if err != nil {
+		return nil, err
+	}
+	payload, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	chdr := putils.MakeChannelHeader(pcommon.HeaderType_MESSAGE, 0, "", 0)
+	shdr := putils.MakeSignatureHeader(creator, nonce)
+	if err := putils.SetTxID(chdr, shdr); err != nil {
+		return nil, err
+	}
+	prop := &pb.Proposal{
+		Header:  putils.MarshalOrPanic(putils.MakePayloadHeader(chdr, shdr)),
+		Payload: payload,
+	}
+	return putils.GetSignedProposal(prop, signer)
+}
+
 // GetOrdererEndpointOfChain returns orderer endpoints of given chain
 func GetOrdererEndpointOfChain(chainID string, signer msp.SigningIdentity, endorserClient pb.EndorserClient) ([]string, error) {
 
@@ -155,7 +183,7 @@ func GetOrdererEndpointOfChain(chainID string, signer msp.SigningIdentity, endor
 
 	creator, err := signer.Serialize()
 	if err != nil {
-		return nil, fmt.Errorf("Error serializing identity for %s: %s", signer.GetIdentifier(), err)
+		return nil, fmt.Errorf("Error serializing identity: %s", err)
 	}
 
 	prop, _, err := putils.CreateProposalFromCIS(pcommon.HeaderType_CONFIG, "", invocation, creator)
@@ -18,8 +18,11 @@ package gossip
 
 import (
 	"bytes"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
@@ -74,6 +77,32 @@ func (s *mspMessageCryptoService) ValidateIdentity(peerIdentity api.PeerIdentity
 	return err
 }
 
+// Expiration returns the time when the identity expires.
+// Identities that are not X.509 certificates don't expire,
+// and a zero time.Time is returned for them.
+func (s *mspMessageCryptoService) Expiration(peerIdentity api.PeerIdentityType) (time.Time, error) {
+	if len(peerIdentity) == 0 {
+		return time.Time{}, errors.New("Invalid Peer Identity. It must be different from nil.")
+	}
+
+	sid, err := s.deserializer.Deserialize(peerIdentity)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Failed deserializing peer identity [% x]: [%s]", peerIdentity, err)
+	}
+
+	bl, _ := pem.Decode(sid.IdBytes)
+	if bl == nil {
+		// Not a certificate, hence there is no expiration time
+		return time.Time{}, nil
+	}
+
+	cert, err := x509.ParseCertificate(bl.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Failed parsing certificate of peer identity [% x]: [%s]", peerIdentity, err)
+	}
+	return cert.NotAfter, nil
+}
+
 // GetPKIidOfCert returns the PKI-ID of a peer's identity
 // If any error occurs, the method return nil
 // The PKid of a peer is computed as the SHA2-256 of peerIdentity which
@@ -17,8 +17,13 @@ limitations under the License.
 package gossip
 
 import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
@@ -100,6 +105,39 @@ func TestValidateIdentity(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestExpiration(t *testing.T) {
+	msgCryptoService := NewMCS(
+		&mocks.ChannelPolicyManagerGetterWithManager{},
+		&mockscrypto.LocalSigner{Identity: []byte("Alice")},
+		&mocks.DeserializersManager{
+			LocalDeserializer: &mocks.IdentityDeserializer{[]byte("Alice"), []byte("msg1")},
+		},
+	)
+
+	// A certificate expires at its NotAfter
+	certPEM, err := ioutil.ReadFile(filepath.Join("..", "..", "sampleconfig", "msp", "signcerts", "peer.pem"))
+	assert.NoError(t, err)
+	bl, _ := pem.Decode(certPEM)
+	cert, err := x509.ParseCertificate(bl.Bytes)
+	assert.NoError(t, err)
+	expiration, err := msgCryptoService.Expiration(certPEM)
+	assert.NoError(t, err)
+	assert.Equal(t, cert.NotAfter, expiration)
+
+	// An identity that isn't a certificate doesn't expire
+	expiration, err = msgCryptoService.Expiration([]byte("Alice"))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Time{}, expiration)
+
+	// A malformed certificate
+	malformed := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("Alice")})
+	_, err = msgCryptoService.Expiration(malformed)
+	assert.Error(t, err)
+
+	_, err = msgCryptoService.Expiration(nil)
+	assert.Error(t, err)
+}
+
 func TestSign(t *testing.T) {
 	msgCryptoService := NewMCS(
 		&mocks.ChannelPolicyManagerGetter{},
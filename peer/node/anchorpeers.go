@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+var anchorPeersChannelID string
+
+func anchorPeersCmd() *cobra.Command {
+	nodeAnchorPeersCmd.Flags().StringVarP(&anchorPeersChannelID, "channelID", "c", "", "Channel of the anchor peers")
+	return nodeAnchorPeersCmd
+}
+
+var nodeAnchorPeersCmd = &cobra.Command{
+	Use:   "anchorpeers <mspID> <host:port>...",
+	Short: "Adds anchor peers of an organization to the node.",
+	Long: `Makes the running node connect to the given anchor peers of an organization of a channel, ` +
+		`in addition to the anchor peers of the channel configuration, until the node restarts. ` +
+		`The request is signed with the local MSP, which must be an admin of the organization of the node.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if anchorPeersChannelID == "" {
+			return fmt.Errorf("a channel is required")
+		}
+		if len(args) < 2 {
+			return fmt.Errorf("expected an MSP ID and at least one anchor peer, got %d arguments", len(args))
+		}
+		return addAnchorPeers(anchorPeersChannelID, args[0], args[1:])
+	},
+}
+
+func addAnchorPeers(channelID, mspID string, endpoints []string) error {
+	request := &pb.AnchorPeersRequest{ChannelId: channelID, MspId: mspID}
+	for _, endpoint := range endpoints {
+		host, portString, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return fmt.Errorf("anchor peer %s isn't formatted as 'host:port': %s", endpoint, err)
+		}
+		port, err := strconv.ParseUint(portString, 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid port of anchor peer %s: %s", endpoint, err)
+		}
+		request.AnchorPeers = append(request.AnchorPeers, &pb.AnchorPeer{Host: host, Port: int32(port)})
+	}
+
+	adminClient, err := common.GetAdminClient()
+	if err != nil {
+		logger.Warningf("%s", err)
+		return err
+	}
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		return err
+	}
+	signedRequest, err := common.CreateAdminRequest(request, signer)
+	if err != nil {
+		return err
+	}
+
+	_, err = adminClient.AddAnchorPeers(context.Background(), signedRequest)
+	if err != nil {
+		logger.Infof("Error trying to add anchor peers to the local peer: %s", err)
+		return fmt.Errorf("Error trying to add anchor peers to the local peer: %s", err)
+	}
+	fmt.Println("Added anchor peers", endpoints, "of", mspID, "in channel", channelID)
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func endpointCmd() *cobra.Command {
+	return nodeEndpointCmd
+}
+
+var nodeEndpointCmd = &cobra.Command{
+	Use:   "endpoint <host:port>",
+	Short: "Updates the external gossip endpoint of the node.",
+	Long: `Updates the endpoint the running node publishes to peers of other organizations, ` +
+		`without restarting it. An empty endpoint makes the node inaccessible outside of its organization. ` +
+		`The request is signed with the local MSP, which must be an admin of the organization of the node.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("expected a single endpoint, got %d arguments", len(args))
+		}
+		return updateEndpoint(args[0])
+	},
+}
+
+func updateEndpoint(endpoint string) error {
+	adminClient, err := common.GetAdminClient()
+	if err != nil {
+		logger.Warningf("%s", err)
+		return err
+	}
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		return err
+	}
+	request, err := common.CreateAdminRequest(&pb.GossipEndpointRequest{Endpoint: endpoint}, signer)
+	if err != nil {
+		return err
+	}
+
+	_, err = adminClient.UpdateGossipEndpoint(context.Background(), request)
+	if err != nil {
+		logger.Infof("Error trying to update the endpoint of the local peer: %s", err)
+		return fmt.Errorf("Error trying to update the endpoint of the local peer: %s", err)
+	}
+	fmt.Println("Updated the external endpoint to", endpoint)
+	return nil
+}
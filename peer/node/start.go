@@ -18,8 +18,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/prometheus"
+	"github.com/hyperledger/fabric/common/metrics/statsd"
 	"github.com/hyperledger/fabric/core"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/comm"
@@ -29,12 +34,17 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/scc"
+	"github.com/hyperledger/fabric/discovery"
+	"github.com/hyperledger/fabric/discovery/support"
 	"github.com/hyperledger/fabric/events/producer"
+	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/peer/common"
 	peergossip "github.com/hyperledger/fabric/peer/gossip"
 	"github.com/hyperledger/fabric/peer/version"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -177,13 +187,45 @@ func serve(args []string) error {
 		}
 		return dialOpts
 	}
-	err = service.InitGossipService(serializedIdentity, peerEndpoint.Address, peerServer.Server(),
+	metricsProvider, err := initMetricsProvider()
+	if err != nil {
+		return err
+	}
+
+	// warn about, and report the time left before, the expiration of
+	// the certificates of the peer
+	certExpirations := []crypto.CertificateExpiration{
+		{Name: "signing", ExpiresAt: crypto.ExpiresAt(serializedIdentity)},
+	}
+	if secureConfig.UseTLS {
+		certExpirations = append(certExpirations, crypto.CertificateExpiration{
+			Name:      "tls",
+			ExpiresAt: crypto.CertExpiresAt(secureConfig.ServerCertificate),
+		})
+	}
+	stopExpirationTracking := make(chan struct{})
+	defer close(stopExpirationTracking)
+	go crypto.TrackExpiration(certExpirations, crypto.NewExpirationGauge(metricsProvider, "peer"), stopExpirationTracking)
+
+	err = service.InitGossipService(serializedIdentity, metricsProvider, peerEndpoint.Address, peerServer.Server(),
 		messageCryptoService, secAdv, secureDialOpts, bootstrap...)
 	if err != nil {
 		return err
 	}
 	defer service.GetGossipService().Stop()
 
+	// rotate the identity of the peer when the signing certificate
+	// in its local MSP folder is replaced
+	if mspType := viper.GetString("peer.localMspType"); mspType == "" || mspType == msp.ProviderTypeToString(msp.FABRIC) {
+		stopIdentityWatcher := make(chan struct{})
+		defer close(stopIdentityWatcher)
+		go mgmt.WatchLocalSigningIdentity(config.GetPath("peer.mspConfigPath"), viper.GetString("peer.localMspId"),
+			func(serializedIdentity []byte) error {
+				logger.Info("Local signing identity changed, updating the identity of the peer in gossip")
+				return service.GetGossipService().UpdateIdentity(api.PeerIdentityType(serializedIdentity))
+			}, stopIdentityWatcher)
+	}
+
 	//initialize system chaincodes
 	initSysCCs()
 
@@ -193,6 +235,11 @@ func serve(args []string) error {
 		scc.DeploySysCCs(cid)
 	})
 
+	if viper.GetBool("peer.discovery.enabled") {
+		logger.Info("Discovery service activated")
+		discprotos.RegisterDiscoveryServer(peerServer.Server(), discovery.NewService(support.NewPeerSupport()))
+	}
+
 	logger.Infof("Starting peer with ID=[%s], network ID=[%s], address=[%s]",
 		peerEndpoint.Id, viper.GetString("peer.networkId"), peerEndpoint.Address)
 
@@ -288,6 +335,8 @@ func createChaincodeServer(peerServer comm.GRPCServer, peerListenAddress string)
 		if err != nil {
 			panic(err)
 		}
+		config.MaxRecvMsgSize = viper.GetInt("chaincode.stream.maxRecvMsgSize")
+		config.MaxSendMsgSize = viper.GetInt("chaincode.stream.maxSendMsgSize")
 
 		srv, err = comm.NewGRPCServer(cclistenAddress, config)
 		if err != nil {
@@ -366,6 +415,48 @@ func createEventHubServer(secureConfig comm.SecureServerConfig) (comm.GRPCServer
 	return grpcServer, nil
 }
 
+// initMetricsProvider creates the metrics provider configured in the metrics
+// section of the configuration, and starts exposing or pushing its metrics
+func initMetricsProvider() (metrics.Provider, error) {
+	switch providerType := viper.GetString("metrics.provider"); providerType {
+	case "prometheus":
+		provider := prometheus.NewProvider()
+		listenAddress := viper.GetString("metrics.prometheus.listenAddress")
+		lis, err := net.Listen("tcp", listenAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed listening for metrics requests on %s: %s", listenAddress, err)
+		}
+		mux := http.NewServeMux()
+		mux.Handle(viper.GetString("metrics.prometheus.path"), provider)
+		logger.Infof("Exposing Prometheus metrics on %s", listenAddress)
+		go func() {
+			if err := http.Serve(lis, mux); err != nil {
+				logger.Errorf("Metrics server exited with error: %s", err)
+			}
+		}()
+		return provider, nil
+	case "statsd":
+		network := viper.GetString("metrics.statsd.network")
+		address := viper.GetString("metrics.statsd.address")
+		conn, err := net.Dial(network, address)
+		if err != nil {
+			return nil, fmt.Errorf("failed connecting to statsd server at %s: %s", address, err)
+		}
+		writeInterval := viper.GetDuration("metrics.statsd.writeInterval")
+		if writeInterval <= 0 {
+			writeInterval = 10 * time.Second
+		}
+		provider := statsd.NewProvider(viper.GetString("metrics.statsd.prefix"))
+		logger.Infof("Pushing metrics to statsd server at %s every %s", address, writeInterval)
+		provider.Start(conn, writeInterval)
+		return provider, nil
+	case "", "disabled":
+		return &disabled.Provider{}, nil
+	default:
+		return nil, fmt.Errorf("unknown metrics provider: %s", providerType)
+	}
+}
+
 func writePid(fileName string, pid int) error {
 	err := os.MkdirAll(filepath.Dir(fileName), 0755)
 	if err != nil {
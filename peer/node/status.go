@@ -18,8 +18,10 @@ package node
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/peer/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/cobra"
@@ -55,6 +57,20 @@ func status() (err error) {
 		fmt.Println(&pb.ServerStatus{Status: pb.ServerStatus_UNKNOWN})
 		return err
 	}
-	fmt.Println(status)
+	fmt.Println(&pb.ServerStatus{Status: status.Status})
+	printExpiration("Signing certificate", status.SigningCertExpiration)
+	printExpiration("TLS certificate", status.TlsCertExpiration)
 	return nil
 }
+
+func printExpiration(name string, expiresAt *timestamp.Timestamp) {
+	if expiresAt == nil {
+		return
+	}
+	t := time.Unix(expiresAt.Seconds, int64(expiresAt.Nanos)).UTC()
+	if time.Now().After(t) {
+		fmt.Printf("%s expired on %s\n", name, t.Format(time.RFC3339))
+		return
+	}
+	fmt.Printf("%s expires on %s\n", name, t.Format(time.RFC3339))
+}
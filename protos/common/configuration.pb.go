@@ -86,30 +86,89 @@ func (m *Consortium) GetName() string {
 	return ""
 }
 
+// Capabilities message defines the capabilities a particular binary must implement
+// for that binary to be able to safely participate in the channel.  The capabilities
+// message is defined at the /Channel level, the /Channel/Application level, and the
+// /Channel/Orderer level.
+//
+// The /Channel level capabilities define capabilities which both the orderer and peer
+// binaries must satisfy.  These capabilities might be things like a new MSP type,
+// or a new policy type.
+//
+// The /Channel/Orderer level capabilities define capabilities which must be supported
+// by the orderer, but which have no bearing on the behavior of the peer.  For instance
+// if the orderer changes the logic for how it constructs new channels, only all orderers
+// must agree on the new logic.  The peers do not need to be aware of this change as
+// they only interact with the channel after it has been constructed.
+//
+// Finally, the /Channel/Application level capabilities define capabilities which the peer
+// binary must satisfy, but which have no bearing on the orderer.  For instance, if the
+// peer adds a new UTXO transaction type, or changes the chaincode lifecycle requirements,
+// all peers must agree on the new logic.  However, orderers never inspect transactions
+// this deeply, and therefore have no need to be aware of the change.
+//
+// The capabilities strings defined in these messages typically correspond to release
+// binary versions (e.g. "V1_1"), and are used primarily as a mechanism for a fully
+// upgraded network to switch from one set of logic to a new one.
+type Capabilities struct {
+	Capabilities map[string]*Capability `protobuf:"bytes,1,rep,name=capabilities" json:"capabilities,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *Capabilities) Reset()                    { *m = Capabilities{} }
+func (m *Capabilities) String() string            { return proto.CompactTextString(m) }
+func (*Capabilities) ProtoMessage()               {}
+func (*Capabilities) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{4} }
+
+func (m *Capabilities) GetCapabilities() map[string]*Capability {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+// Capability is an empty message for the time being.  It is defined as a protobuf
+// message rather than a constant, so that we may extend capabilities with other fields
+// if the need arises in the future.  For the time being, a capability being in the
+// capabilities map requires that that capability be supported.
+type Capability struct {
+}
+
+func (m *Capability) Reset()                    { *m = Capability{} }
+func (m *Capability) String() string            { return proto.CompactTextString(m) }
+func (*Capability) ProtoMessage()               {}
+func (*Capability) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{5} }
+
 func init() {
 	proto.RegisterType((*HashingAlgorithm)(nil), "common.HashingAlgorithm")
 	proto.RegisterType((*BlockDataHashingStructure)(nil), "common.BlockDataHashingStructure")
 	proto.RegisterType((*OrdererAddresses)(nil), "common.OrdererAddresses")
 	proto.RegisterType((*Consortium)(nil), "common.Consortium")
+	proto.RegisterType((*Capabilities)(nil), "common.Capabilities")
+	proto.RegisterType((*Capability)(nil), "common.Capability")
 }
 
 func init() { proto.RegisterFile("common/configuration.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 230 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x8f, 0xc1, 0x4a, 0x03, 0x31,
-	0x10, 0x86, 0x59, 0xd4, 0xc2, 0x0e, 0x08, 0x25, 0x78, 0xa8, 0xe2, 0x61, 0x59, 0x44, 0x0a, 0xc2,
-	0x46, 0xf1, 0x09, 0x5a, 0x3d, 0x78, 0x13, 0xb6, 0x37, 0x6f, 0xd9, 0x24, 0x4d, 0x82, 0xbb, 0x99,
-	0x32, 0x99, 0x20, 0xbe, 0xbd, 0xb8, 0x51, 0xf4, 0xd0, 0xdb, 0x7c, 0x33, 0xdf, 0x0f, 0xf3, 0xc3,
-	0x95, 0xc6, 0x69, 0xc2, 0x28, 0x35, 0xc6, 0x7d, 0x70, 0x99, 0x14, 0x07, 0x8c, 0xdd, 0x81, 0x90,
-	0x51, 0x2c, 0xca, 0xad, 0xbd, 0x85, 0xe5, 0x8b, 0x4a, 0x3e, 0x44, 0xb7, 0x19, 0x1d, 0x52, 0x60,
-	0x3f, 0x09, 0x01, 0xa7, 0x51, 0x4d, 0x76, 0x55, 0x35, 0xd5, 0xba, 0xee, 0xe7, 0xb9, 0x7d, 0x80,
-	0xcb, 0xed, 0x88, 0xfa, 0xfd, 0x59, 0xb1, 0xfa, 0x09, 0xec, 0x98, 0xb2, 0xe6, 0x4c, 0x56, 0x5c,
-	0xc0, 0xd9, 0x47, 0x30, 0xec, 0xe7, 0xc4, 0x79, 0x5f, 0xa0, 0xbd, 0x87, 0xe5, 0x2b, 0x19, 0x4b,
-	0x96, 0x36, 0xc6, 0x90, 0x4d, 0xc9, 0x26, 0x71, 0x0d, 0xb5, 0xfa, 0x85, 0x55, 0xd5, 0x9c, 0xac,
-	0xeb, 0xfe, 0x6f, 0xd1, 0x36, 0x00, 0x4f, 0x18, 0x13, 0x12, 0x87, 0x7c, 0xf4, 0x8d, 0xed, 0x0e,
-	0x6e, 0x90, 0x5c, 0xe7, 0x3f, 0x0f, 0x96, 0x46, 0x6b, 0x9c, 0xa5, 0x6e, 0xaf, 0x06, 0x0a, 0xba,
-	0xd4, 0x4a, 0x5d, 0xa9, 0xf5, 0x76, 0xe7, 0x02, 0xfb, 0x3c, 0x7c, 0xa3, 0xfc, 0x27, 0xcb, 0x22,
-	0xcb, 0x22, 0xcb, 0x22, 0x0f, 0x8b, 0x19, 0x1f, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0xb6, 0x15,
-	0xb9, 0xb4, 0x30, 0x01, 0x00, 0x00,
+	// 311 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x6c, 0x91, 0x41, 0x6b, 0xf2, 0x40,
+	0x10, 0x86, 0x89, 0x7e, 0x0a, 0x8e, 0x7e, 0x60, 0x97, 0x1e, 0xac, 0xf4, 0x10, 0x42, 0x91, 0x40,
+	0x21, 0x69, 0xed, 0xa5, 0xf4, 0xa6, 0xb6, 0x50, 0x7a, 0x29, 0xc4, 0x5b, 0x6f, 0x9b, 0x64, 0x4c,
+	0x16, 0x93, 0x5d, 0x99, 0xdd, 0xb4, 0xe4, 0x57, 0xf5, 0x2f, 0x16, 0xb3, 0x16, 0x23, 0xf6, 0x36,
+	0xcf, 0xce, 0xf3, 0xce, 0xce, 0xb2, 0x30, 0x4d, 0x54, 0x59, 0x2a, 0x19, 0x26, 0x4a, 0x6e, 0x44,
+	0x56, 0x11, 0x37, 0x42, 0xc9, 0x60, 0x47, 0xca, 0x28, 0xd6, 0xb7, 0x3d, 0x6f, 0x06, 0xe3, 0x57,
+	0xae, 0x73, 0x21, 0xb3, 0x45, 0x91, 0x29, 0x12, 0x26, 0x2f, 0x19, 0x83, 0x7f, 0x92, 0x97, 0x38,
+	0x71, 0x5c, 0xc7, 0x1f, 0x44, 0x4d, 0xed, 0xdd, 0xc3, 0xd5, 0xb2, 0x50, 0xc9, 0xf6, 0x99, 0x1b,
+	0x7e, 0x08, 0xac, 0x0d, 0x55, 0x89, 0xa9, 0x08, 0xd9, 0x25, 0xf4, 0xbe, 0x44, 0x6a, 0xf2, 0x26,
+	0xf1, 0x3f, 0xb2, 0xe0, 0xdd, 0xc1, 0xf8, 0x9d, 0x52, 0x24, 0xa4, 0x45, 0x9a, 0x12, 0x6a, 0x8d,
+	0x9a, 0x5d, 0xc3, 0x80, 0xff, 0xc2, 0xc4, 0x71, 0xbb, 0xfe, 0x20, 0x3a, 0x1e, 0x78, 0x2e, 0xc0,
+	0x4a, 0x49, 0xad, 0xc8, 0x88, 0xea, 0xef, 0x35, 0xbe, 0x1d, 0x18, 0xad, 0xf8, 0x8e, 0xc7, 0xa2,
+	0x10, 0x46, 0xa0, 0x66, 0x6f, 0x30, 0x4a, 0x5a, 0xdc, 0xcc, 0x1c, 0xce, 0x67, 0x81, 0x7d, 0x5e,
+	0xd0, 0x76, 0x4f, 0xe0, 0x45, 0x1a, 0xaa, 0xa3, 0x93, 0xec, 0x74, 0x0d, 0x17, 0x67, 0x0a, 0x1b,
+	0x43, 0x77, 0x8b, 0xf5, 0x61, 0x89, 0x7d, 0xc9, 0x7c, 0xe8, 0x7d, 0xf2, 0xa2, 0xc2, 0x49, 0xc7,
+	0x75, 0xfc, 0xe1, 0x9c, 0x9d, 0xdd, 0x55, 0x47, 0x56, 0x78, 0xea, 0x3c, 0x3a, 0xde, 0x08, 0xe0,
+	0xd8, 0x58, 0xae, 0xe1, 0x46, 0x51, 0x16, 0xe4, 0xf5, 0x0e, 0xa9, 0xc0, 0x34, 0x43, 0x0a, 0x36,
+	0x3c, 0x26, 0x91, 0xd8, 0x6f, 0xd1, 0x87, 0x59, 0x1f, 0xb7, 0x99, 0x30, 0x79, 0x15, 0xef, 0x31,
+	0x6c, 0xc9, 0xa1, 0x95, 0x43, 0x2b, 0x87, 0x56, 0x8e, 0xfb, 0x0d, 0x3e, 0xfc, 0x0c, 0x00, 0xd6,
+	0x7e, 0xb4, 0x89, 0xf0, 0x01, 0x00, 0x00,
 }
@@ -13,6 +13,8 @@ It has these top-level messages:
 	Secret
 	GossipMessage
 	StateInfo
+	Properties
+	Chaincode
 	StateInfoSnapshot
 	StateInfoPullRequest
 	ConnEstablish
@@ -29,6 +31,7 @@ It has these top-level messages:
 	MembershipRequest
 	MembershipResponse
 	Member
+	NetworkEndpoint
 	Empty
 	RemoteStateRequest
 	RemoteStateResponse
@@ -893,7 +896,8 @@ type StateInfo struct {
 	// channel_MAC is an authentication code that proves
 	// that the peer that sent this message knows
 	// the name of the channel.
-	Channel_MAC []byte `protobuf:"bytes,4,opt,name=channel_MAC,json=channelMAC,proto3" json:"channel_MAC,omitempty"`
+	Channel_MAC []byte      `protobuf:"bytes,4,opt,name=channel_MAC,json=channelMAC,proto3" json:"channel_MAC,omitempty"`
+	Properties  *Properties `protobuf:"bytes,5,opt,name=properties" json:"properties,omitempty"`
 }
 
 func (m *StateInfo) Reset()                    { *m = StateInfo{} }
@@ -929,6 +933,92 @@ func (m *StateInfo) GetChannel_MAC() []byte {
 	return nil
 }
 
+func (m *StateInfo) GetProperties() *Properties {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+// Properties are the channel related properties
+// a peer publishes about itself
+type Properties struct {
+	LedgerHeight           uint64       `protobuf:"varint,1,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
+	InstalledChaincodes    []*Chaincode `protobuf:"bytes,2,rep,name=installed_chaincodes,json=installedChaincodes" json:"installed_chaincodes,omitempty"`
+	InstantiatedChaincodes []*Chaincode `protobuf:"bytes,3,rep,name=instantiated_chaincodes,json=instantiatedChaincodes" json:"instantiated_chaincodes,omitempty"`
+	// leader is set if the peer is the leader of its
+	// organization, i.e. pulls blocks from the ordering service
+	Leader bool `protobuf:"varint,4,opt,name=leader" json:"leader,omitempty"`
+	// anchor_peer is set if the peer is an anchor peer of the channel
+	AnchorPeer bool `protobuf:"varint,5,opt,name=anchor_peer,json=anchorPeer" json:"anchor_peer,omitempty"`
+}
+
+func (m *Properties) Reset()                    { *m = Properties{} }
+func (m *Properties) String() string            { return proto.CompactTextString(m) }
+func (*Properties) ProtoMessage()               {}
+func (*Properties) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *Properties) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
+func (m *Properties) GetInstalledChaincodes() []*Chaincode {
+	if m != nil {
+		return m.InstalledChaincodes
+	}
+	return nil
+}
+
+func (m *Properties) GetInstantiatedChaincodes() []*Chaincode {
+	if m != nil {
+		return m.InstantiatedChaincodes
+	}
+	return nil
+}
+
+func (m *Properties) GetLeader() bool {
+	if m != nil {
+		return m.Leader
+	}
+	return false
+}
+
+func (m *Properties) GetAnchorPeer() bool {
+	if m != nil {
+		return m.AnchorPeer
+	}
+	return false
+}
+
+// Chaincode identifies a chaincode a peer
+// has installed or instantiated
+type Chaincode struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *Chaincode) Reset()                    { *m = Chaincode{} }
+func (m *Chaincode) String() string            { return proto.CompactTextString(m) }
+func (*Chaincode) ProtoMessage()               {}
+func (*Chaincode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *Chaincode) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Chaincode) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
 // StateInfoSnapshot is an aggregation of StateInfo messages
 type StateInfoSnapshot struct {
 	Elements []*Envelope `protobuf:"bytes,1,rep,name=elements" json:"elements,omitempty"`
@@ -937,7 +1027,7 @@ type StateInfoSnapshot struct {
 func (m *StateInfoSnapshot) Reset()                    { *m = StateInfoSnapshot{} }
 func (m *StateInfoSnapshot) String() string            { return proto.CompactTextString(m) }
 func (*StateInfoSnapshot) ProtoMessage()               {}
-func (*StateInfoSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*StateInfoSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *StateInfoSnapshot) GetElements() []*Envelope {
 	if m != nil {
@@ -958,7 +1048,7 @@ type StateInfoPullRequest struct {
 func (m *StateInfoPullRequest) Reset()                    { *m = StateInfoPullRequest{} }
 func (m *StateInfoPullRequest) String() string            { return proto.CompactTextString(m) }
 func (*StateInfoPullRequest) ProtoMessage()               {}
-func (*StateInfoPullRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*StateInfoPullRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *StateInfoPullRequest) GetChannel_MAC() []byte {
 	if m != nil {
@@ -979,7 +1069,7 @@ type ConnEstablish struct {
 func (m *ConnEstablish) Reset()                    { *m = ConnEstablish{} }
 func (m *ConnEstablish) String() string            { return proto.CompactTextString(m) }
 func (*ConnEstablish) ProtoMessage()               {}
-func (*ConnEstablish) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*ConnEstablish) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *ConnEstablish) GetPkiId() []byte {
 	if m != nil {
@@ -1014,7 +1104,7 @@ type PeerIdentity struct {
 func (m *PeerIdentity) Reset()                    { *m = PeerIdentity{} }
 func (m *PeerIdentity) String() string            { return proto.CompactTextString(m) }
 func (*PeerIdentity) ProtoMessage()               {}
-func (*PeerIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*PeerIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *PeerIdentity) GetPkiId() []byte {
 	if m != nil {
@@ -1048,7 +1138,7 @@ type DataRequest struct {
 func (m *DataRequest) Reset()                    { *m = DataRequest{} }
 func (m *DataRequest) String() string            { return proto.CompactTextString(m) }
 func (*DataRequest) ProtoMessage()               {}
-func (*DataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*DataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *DataRequest) GetNonce() uint64 {
 	if m != nil {
@@ -1082,7 +1172,7 @@ type GossipHello struct {
 func (m *GossipHello) Reset()                    { *m = GossipHello{} }
 func (m *GossipHello) String() string            { return proto.CompactTextString(m) }
 func (*GossipHello) ProtoMessage()               {}
-func (*GossipHello) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*GossipHello) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *GossipHello) GetNonce() uint64 {
 	if m != nil {
@@ -1116,7 +1206,7 @@ type DataUpdate struct {
 func (m *DataUpdate) Reset()                    { *m = DataUpdate{} }
 func (m *DataUpdate) String() string            { return proto.CompactTextString(m) }
 func (*DataUpdate) ProtoMessage()               {}
-func (*DataUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*DataUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *DataUpdate) GetNonce() uint64 {
 	if m != nil {
@@ -1150,7 +1240,7 @@ type DataDigest struct {
 func (m *DataDigest) Reset()                    { *m = DataDigest{} }
 func (m *DataDigest) String() string            { return proto.CompactTextString(m) }
 func (*DataDigest) ProtoMessage()               {}
-func (*DataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*DataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *DataDigest) GetNonce() uint64 {
 	if m != nil {
@@ -1181,7 +1271,7 @@ type DataMessage struct {
 func (m *DataMessage) Reset()                    { *m = DataMessage{} }
 func (m *DataMessage) String() string            { return proto.CompactTextString(m) }
 func (*DataMessage) ProtoMessage()               {}
-func (*DataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*DataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *DataMessage) GetPayload() *Payload {
 	if m != nil {
@@ -1199,7 +1289,7 @@ type Payload struct {
 func (m *Payload) Reset()                    { *m = Payload{} }
 func (m *Payload) String() string            { return proto.CompactTextString(m) }
 func (*Payload) ProtoMessage()               {}
-func (*Payload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*Payload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *Payload) GetSeqNum() uint64 {
 	if m != nil {
@@ -1226,7 +1316,7 @@ type AliveMessage struct {
 func (m *AliveMessage) Reset()                    { *m = AliveMessage{} }
 func (m *AliveMessage) String() string            { return proto.CompactTextString(m) }
 func (*AliveMessage) ProtoMessage()               {}
-func (*AliveMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*AliveMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *AliveMessage) GetMembership() *Member {
 	if m != nil {
@@ -1255,12 +1345,15 @@ type LeadershipMessage struct {
 	PkiId         []byte    `protobuf:"bytes,1,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
 	Timestamp     *PeerTime `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
 	IsDeclaration bool      `protobuf:"varint,3,opt,name=is_declaration,json=isDeclaration" json:"is_declaration,omitempty"`
+	// is_pinned is set if the sender is configured to
+	// be preferred over other peers as the leader
+	IsPinned bool `protobuf:"varint,4,opt,name=is_pinned,json=isPinned" json:"is_pinned,omitempty"`
 }
 
 func (m *LeadershipMessage) Reset()                    { *m = LeadershipMessage{} }
 func (m *LeadershipMessage) String() string            { return proto.CompactTextString(m) }
 func (*LeadershipMessage) ProtoMessage()               {}
-func (*LeadershipMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*LeadershipMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *LeadershipMessage) GetPkiId() []byte {
 	if m != nil {
@@ -1283,6 +1376,13 @@ func (m *LeadershipMessage) GetIsDeclaration() bool {
 	return false
 }
 
+func (m *LeadershipMessage) GetIsPinned() bool {
+	if m != nil {
+		return m.IsPinned
+	}
+	return false
+}
+
 // PeerTime defines the logical time of a peer's life
 type PeerTime struct {
 	IncNum uint64 `protobuf:"varint,1,opt,name=inc_num,json=incNum" json:"inc_num,omitempty"`
@@ -1292,7 +1392,7 @@ type PeerTime struct {
 func (m *PeerTime) Reset()                    { *m = PeerTime{} }
 func (m *PeerTime) String() string            { return proto.CompactTextString(m) }
 func (*PeerTime) ProtoMessage()               {}
-func (*PeerTime) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*PeerTime) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *PeerTime) GetIncNum() uint64 {
 	if m != nil {
@@ -1318,7 +1418,7 @@ type MembershipRequest struct {
 func (m *MembershipRequest) Reset()                    { *m = MembershipRequest{} }
 func (m *MembershipRequest) String() string            { return proto.CompactTextString(m) }
 func (*MembershipRequest) ProtoMessage()               {}
-func (*MembershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*MembershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *MembershipRequest) GetSelfInformation() *Envelope {
 	if m != nil {
@@ -1343,7 +1443,7 @@ type MembershipResponse struct {
 func (m *MembershipResponse) Reset()                    { *m = MembershipResponse{} }
 func (m *MembershipResponse) String() string            { return proto.CompactTextString(m) }
 func (*MembershipResponse) ProtoMessage()               {}
-func (*MembershipResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*MembershipResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *MembershipResponse) GetAlive() []*Envelope {
 	if m != nil {
@@ -1362,15 +1462,16 @@ func (m *MembershipResponse) GetDead() []*Envelope {
 // Member holds membership-related information
 // about a peer
 type Member struct {
-	Endpoint string `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
-	Metadata []byte `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	PkiId    []byte `protobuf:"bytes,3,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	Endpoint         string             `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
+	Metadata         []byte             `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	PkiId            []byte             `protobuf:"bytes,3,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	NetworkEndpoints []*NetworkEndpoint `protobuf:"bytes,4,rep,name=network_endpoints,json=networkEndpoints" json:"network_endpoints,omitempty"`
 }
 
 func (m *Member) Reset()                    { *m = Member{} }
 func (m *Member) String() string            { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()               {}
-func (*Member) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*Member) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *Member) GetEndpoint() string {
 	if m != nil {
@@ -1393,6 +1494,39 @@ func (m *Member) GetPkiId() []byte {
 	return nil
 }
 
+func (m *Member) GetNetworkEndpoints() []*NetworkEndpoint {
+	if m != nil {
+		return m.NetworkEndpoints
+	}
+	return nil
+}
+
+// NetworkEndpoint is an endpoint a peer can be
+// reached through from a certain network
+type NetworkEndpoint struct {
+	Network  string `protobuf:"bytes,1,opt,name=network" json:"network,omitempty"`
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint" json:"endpoint,omitempty"`
+}
+
+func (m *NetworkEndpoint) Reset()                    { *m = NetworkEndpoint{} }
+func (m *NetworkEndpoint) String() string            { return proto.CompactTextString(m) }
+func (*NetworkEndpoint) ProtoMessage()               {}
+func (*NetworkEndpoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *NetworkEndpoint) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *NetworkEndpoint) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
 // Empty is used for pinging and in tests
 type Empty struct {
 }
@@ -1400,7 +1534,7 @@ type Empty struct {
 func (m *Empty) Reset()                    { *m = Empty{} }
 func (m *Empty) String() string            { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 // RemoteStateRequest is used to ask a set of blocks
 // from a remote peer
@@ -1412,7 +1546,7 @@ type RemoteStateRequest struct {
 func (m *RemoteStateRequest) Reset()                    { *m = RemoteStateRequest{} }
 func (m *RemoteStateRequest) String() string            { return proto.CompactTextString(m) }
 func (*RemoteStateRequest) ProtoMessage()               {}
-func (*RemoteStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*RemoteStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *RemoteStateRequest) GetStartSeqNum() uint64 {
 	if m != nil {
@@ -1437,7 +1571,7 @@ type RemoteStateResponse struct {
 func (m *RemoteStateResponse) Reset()                    { *m = RemoteStateResponse{} }
 func (m *RemoteStateResponse) String() string            { return proto.CompactTextString(m) }
 func (*RemoteStateResponse) ProtoMessage()               {}
-func (*RemoteStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*RemoteStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *RemoteStateResponse) GetPayloads() []*Payload {
 	if m != nil {
@@ -1452,6 +1586,8 @@ func init() {
 	proto.RegisterType((*Secret)(nil), "gossip.Secret")
 	proto.RegisterType((*GossipMessage)(nil), "gossip.GossipMessage")
 	proto.RegisterType((*StateInfo)(nil), "gossip.StateInfo")
+	proto.RegisterType((*Properties)(nil), "gossip.Properties")
+	proto.RegisterType((*Chaincode)(nil), "gossip.Chaincode")
 	proto.RegisterType((*StateInfoSnapshot)(nil), "gossip.StateInfoSnapshot")
 	proto.RegisterType((*StateInfoPullRequest)(nil), "gossip.StateInfoPullRequest")
 	proto.RegisterType((*ConnEstablish)(nil), "gossip.ConnEstablish")
@@ -1468,6 +1604,7 @@ func init() {
 	proto.RegisterType((*MembershipRequest)(nil), "gossip.MembershipRequest")
 	proto.RegisterType((*MembershipResponse)(nil), "gossip.MembershipResponse")
 	proto.RegisterType((*Member)(nil), "gossip.Member")
+	proto.RegisterType((*NetworkEndpoint)(nil), "gossip.NetworkEndpoint")
 	proto.RegisterType((*Empty)(nil), "gossip.Empty")
 	proto.RegisterType((*RemoteStateRequest)(nil), "gossip.RemoteStateRequest")
 	proto.RegisterType((*RemoteStateResponse)(nil), "gossip.RemoteStateResponse")
@@ -1620,91 +1757,104 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1366 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x6f, 0xdc, 0x44,
-	0x10, 0x3f, 0x27, 0xf7, 0x39, 0xf7, 0x91, 0xcb, 0x26, 0x05, 0x13, 0x2a, 0x88, 0x2c, 0x5a, 0x05,
-	0x52, 0x2e, 0x55, 0xca, 0x47, 0xa5, 0x82, 0xd0, 0x25, 0x77, 0xe4, 0x22, 0x7a, 0x49, 0xe4, 0xa4,
-	0x82, 0xf2, 0x62, 0x6d, 0xce, 0x13, 0x9f, 0xa9, 0xbd, 0x76, 0xbc, 0x7b, 0x85, 0x3c, 0x22, 0xde,
-	0x78, 0xe1, 0x95, 0x3f, 0x17, 0x79, 0xd7, 0xf6, 0xd9, 0x75, 0x52, 0xa9, 0x95, 0x78, 0xf3, 0x7c,
-	0xfc, 0x66, 0x66, 0x67, 0xe7, 0x63, 0x0d, 0x9b, 0x4e, 0xc0, 0xb9, 0x1b, 0xee, 0xf9, 0xc8, 0x39,
-	0x75, 0x70, 0x10, 0x46, 0x81, 0x08, 0x48, 0x5d, 0x71, 0x8d, 0xbf, 0x34, 0x68, 0x8e, 0xd9, 0x6b,
-	0xf4, 0x82, 0x10, 0x89, 0x0e, 0x8d, 0x90, 0xde, 0x78, 0x01, 0xb5, 0x75, 0x6d, 0x5b, 0xdb, 0xe9,
-	0x98, 0x29, 0x49, 0xee, 0x43, 0x8b, 0xbb, 0x0e, 0xa3, 0x62, 0x11, 0xa1, 0xbe, 0x22, 0x65, 0x4b,
-	0x06, 0xf9, 0x01, 0xd6, 0x38, 0xce, 0x22, 0x14, 0x16, 0x26, 0xa6, 0xf4, 0xd5, 0x6d, 0x6d, 0xa7,
-	0xbd, 0xff, 0xc1, 0x40, 0xb9, 0x19, 0x9c, 0x4b, 0x71, 0xea, 0xc8, 0xec, 0xf1, 0x02, 0x6d, 0x4c,
-	0xa0, 0x57, 0xd4, 0x78, 0xdf, 0x50, 0x8c, 0x21, 0xd4, 0x95, 0x25, 0xf2, 0x08, 0xfa, 0x2e, 0x13,
-	0x18, 0x31, 0xea, 0x8d, 0x99, 0x1d, 0x06, 0x2e, 0x13, 0xd2, 0x54, 0x6b, 0x52, 0x31, 0x4b, 0x92,
-	0x83, 0x16, 0x34, 0x66, 0x01, 0x13, 0xc8, 0x84, 0xf1, 0x6f, 0x0b, 0xba, 0x47, 0x32, 0xec, 0xa9,
-	0x4a, 0x19, 0xd9, 0x84, 0x1a, 0x0b, 0xd8, 0x0c, 0x25, 0xbe, 0x6a, 0x2a, 0x22, 0x0e, 0x71, 0x36,
-	0xa7, 0x8c, 0xa1, 0x97, 0x84, 0x91, 0x92, 0x64, 0x17, 0x56, 0x05, 0x75, 0x64, 0x0e, 0x7a, 0xfb,
-	0x1f, 0xa5, 0x39, 0x28, 0xd8, 0x1c, 0x5c, 0x50, 0xc7, 0x8c, 0xb5, 0xc8, 0x13, 0x68, 0x51, 0xcf,
-	0x7d, 0x8d, 0x96, 0xcf, 0x1d, 0xbd, 0x26, 0xd3, 0xb6, 0x99, 0x42, 0x86, 0xb1, 0x20, 0x41, 0x4c,
-	0x2a, 0x66, 0x53, 0x2a, 0x4e, 0xb9, 0x43, 0xbe, 0x82, 0x86, 0x8f, 0xbe, 0x15, 0xe1, 0xb5, 0x5e,
-	0x97, 0x90, 0xcc, 0xcb, 0x14, 0xfd, 0x4b, 0x8c, 0xf8, 0xdc, 0x0d, 0x4d, 0xbc, 0x5e, 0x20, 0x17,
-	0x93, 0x8a, 0x59, 0xf7, 0xd1, 0x37, 0xf1, 0x9a, 0x7c, 0x9d, 0xa2, 0xb8, 0xde, 0x90, 0xa8, 0xad,
-	0xdb, 0x50, 0x3c, 0x0c, 0x18, 0xc7, 0x0c, 0xc6, 0xc9, 0x63, 0x68, 0xda, 0x54, 0x50, 0x19, 0x60,
-	0x53, 0xe2, 0x36, 0x52, 0xdc, 0x88, 0x0a, 0xba, 0x8c, 0xaf, 0x11, 0xab, 0xc5, 0xe1, 0xed, 0x42,
-	0x6d, 0x8e, 0x9e, 0x17, 0xe8, 0xad, 0xa2, 0xba, 0x4a, 0xc1, 0x24, 0x16, 0x4d, 0x2a, 0xa6, 0xd2,
-	0x21, 0x7b, 0x89, 0x79, 0xdb, 0x75, 0x74, 0x90, 0xfa, 0x24, 0x6f, 0x7e, 0xe4, 0x3a, 0xea, 0x14,
-	0xd2, 0xfa, 0xc8, 0x75, 0xb2, 0x78, 0xe2, 0xd3, 0xb7, 0xcb, 0xf1, 0x2c, 0xcf, 0x2d, 0x11, 0xea,
-	0xe0, 0x6d, 0x89, 0x58, 0x84, 0x36, 0x15, 0xa8, 0x77, 0xca, 0x5e, 0x5e, 0x48, 0xc9, 0xa4, 0x62,
-	0x82, 0x9d, 0x51, 0xe4, 0x01, 0xd4, 0xd0, 0x0f, 0xc5, 0x8d, 0xde, 0x95, 0x80, 0x6e, 0x0a, 0x18,
-	0xc7, 0xcc, 0xf8, 0x00, 0x52, 0x4a, 0x76, 0xa1, 0x3a, 0x0b, 0x18, 0xd3, 0x7b, 0x52, 0xeb, 0x5e,
-	0xaa, 0x75, 0x18, 0x30, 0x36, 0xe6, 0x82, 0x5e, 0x7a, 0x2e, 0x9f, 0x4f, 0x2a, 0xa6, 0x54, 0x22,
-	0xfb, 0x00, 0x5c, 0x50, 0x81, 0x96, 0xcb, 0xae, 0x02, 0x7d, 0x4d, 0x42, 0xd6, 0xb3, 0x36, 0x89,
-	0x25, 0xc7, 0xec, 0x2a, 0xce, 0x4e, 0x8b, 0xa7, 0x04, 0x39, 0x80, 0x9e, 0xc2, 0x70, 0x46, 0x43,
-	0x3e, 0x0f, 0x84, 0xde, 0x2f, 0x5e, 0x7a, 0x86, 0x3b, 0x4f, 0x14, 0x26, 0x15, 0xb3, 0x2b, 0x21,
-	0x29, 0x83, 0x4c, 0x61, 0x63, 0xe9, 0xd7, 0x0a, 0x17, 0x9e, 0x27, 0xf3, 0xb7, 0x2e, 0x0d, 0xdd,
-	0x2f, 0x19, 0x3a, 0x5b, 0x78, 0xde, 0x32, 0x91, 0x7d, 0xfe, 0x06, 0x9f, 0x0c, 0x41, 0xd9, 0x8f,
-	0x8d, 0xc4, 0x4a, 0x3a, 0x29, 0x16, 0x94, 0x89, 0x7e, 0x20, 0x50, 0x9a, 0x5b, 0x9a, 0xe9, 0xf0,
-	0x1c, 0x4d, 0x46, 0xe9, 0xa9, 0xa2, 0xa4, 0xe4, 0xf4, 0x0d, 0x69, 0xe3, 0xe3, 0x5b, 0x6d, 0x64,
-	0x55, 0xd9, 0xe5, 0x79, 0x46, 0x9c, 0x1b, 0x0f, 0xa9, 0xad, 0x8a, 0x57, 0x96, 0xe8, 0x66, 0x31,
-	0x37, 0xcf, 0x33, 0xe9, 0xb2, 0x50, 0xbb, 0x4b, 0x48, 0x5c, 0xae, 0xcf, 0xa0, 0x1b, 0x22, 0x46,
-	0x96, 0x6b, 0x23, 0x13, 0xae, 0xb8, 0xd1, 0xef, 0x15, 0xdb, 0xf0, 0x0c, 0x31, 0x3a, 0x4e, 0x64,
-	0xf1, 0x31, 0xc2, 0x1c, 0x6d, 0x58, 0xb0, 0x7a, 0x41, 0x1d, 0xd2, 0x85, 0xd6, 0x8b, 0x93, 0xd1,
-	0xf8, 0xc7, 0xe3, 0x93, 0xf1, 0xa8, 0x5f, 0x21, 0x2d, 0xa8, 0x8d, 0xa7, 0x67, 0x17, 0x2f, 0xfb,
-	0x1a, 0xe9, 0x40, 0xf3, 0xd4, 0x3c, 0xb2, 0x4e, 0x4f, 0x9e, 0xbf, 0xec, 0xaf, 0xc4, 0x7a, 0x87,
-	0x93, 0xe1, 0x89, 0x22, 0x57, 0x49, 0x1f, 0x3a, 0x92, 0x1c, 0x9e, 0x8c, 0xac, 0x53, 0xf3, 0xa8,
-	0x5f, 0x25, 0x6b, 0xd0, 0x56, 0x0a, 0xa6, 0x64, 0xd4, 0xf2, 0xa3, 0xe9, 0x1f, 0x0d, 0x5a, 0xd9,
-	0x15, 0x91, 0x2d, 0x68, 0xfa, 0x28, 0x68, 0x5c, 0xb0, 0xc9, 0x90, 0xcc, 0x68, 0x32, 0x80, 0x96,
-	0x70, 0x7d, 0xe4, 0x82, 0xfa, 0xa1, 0x1c, 0x4f, 0xed, 0xfd, 0x7e, 0xfe, 0x38, 0x17, 0xae, 0x8f,
-	0xe6, 0x52, 0x85, 0xdc, 0x83, 0x7a, 0xf8, 0xca, 0xb5, 0x5c, 0x5b, 0x4e, 0xad, 0x8e, 0x59, 0x0b,
-	0x5f, 0xb9, 0xc7, 0x36, 0xf9, 0x14, 0xda, 0xc9, 0x50, 0xb3, 0xa6, 0xc3, 0x43, 0xbd, 0x2a, 0x65,
-	0x90, 0xb0, 0xa6, 0xc3, 0x43, 0x63, 0x08, 0xeb, 0xa5, 0xe2, 0x23, 0x8f, 0xa0, 0x89, 0x1e, 0xfa,
-	0xc8, 0x04, 0xd7, 0xb5, 0xed, 0xd5, 0xbc, 0xef, 0x6c, 0x05, 0x64, 0x1a, 0xc6, 0xb7, 0xb0, 0x79,
-	0x5b, 0xd9, 0xbd, 0xe9, 0x5b, 0x2b, 0xf9, 0xbe, 0x82, 0x6e, 0xa1, 0xc7, 0x72, 0x87, 0xd0, 0xf2,
-	0x87, 0xd8, 0x82, 0x66, 0x76, 0xb3, 0x6a, 0x52, 0x67, 0x34, 0x31, 0xa0, 0x2b, 0x3c, 0x6e, 0xcd,
-	0x30, 0x12, 0xd6, 0x9c, 0xf2, 0x79, 0x72, 0xfc, 0xb6, 0xf0, 0xf8, 0x21, 0x46, 0x62, 0x42, 0xf9,
-	0xdc, 0x78, 0x01, 0x9d, 0x7c, 0x05, 0xdc, 0xe5, 0x86, 0x40, 0x35, 0x36, 0x93, 0xb8, 0x90, 0xdf,
-	0x85, 0x2b, 0x5a, 0x2d, 0x5e, 0x91, 0xe1, 0x43, 0x3b, 0x37, 0xae, 0xee, 0x5e, 0x32, 0xb6, 0x1c,
-	0x80, 0x5c, 0x5f, 0xd9, 0x5e, 0xdd, 0x69, 0x99, 0x29, 0x49, 0x06, 0xd0, 0xf4, 0xb9, 0x63, 0x89,
-	0x9b, 0x64, 0xdb, 0xf6, 0x96, 0x53, 0x30, 0xce, 0xe2, 0x94, 0x3b, 0x17, 0x37, 0x21, 0x9a, 0x0d,
-	0x5f, 0x7d, 0x18, 0x01, 0xb4, 0x73, 0xe3, 0xf7, 0x0e, 0x77, 0xf9, 0x78, 0x57, 0x4a, 0x25, 0xf5,
-	0x6e, 0x0e, 0xff, 0x00, 0x58, 0x4e, 0xd6, 0x3b, 0xfc, 0x7d, 0x06, 0xd5, 0xc4, 0xd7, 0xed, 0x55,
-	0x52, 0x7d, 0x2f, 0xcf, 0x9e, 0xf2, 0xac, 0x36, 0xc7, 0xff, 0x9e, 0xd8, 0xa7, 0xea, 0x1e, 0xd3,
-	0xc7, 0xc2, 0xe7, 0xc5, 0x97, 0x4b, 0x7b, 0x7f, 0x2d, 0x43, 0x2b, 0x76, 0xf6, 0x94, 0x31, 0xbe,
-	0x81, 0x46, 0xc2, 0x23, 0x1f, 0x42, 0x83, 0xe3, 0xb5, 0xc5, 0x16, 0x7e, 0x12, 0x66, 0x9d, 0xe3,
-	0xf5, 0xc9, 0xc2, 0x8f, 0xab, 0x2a, 0x77, 0x1b, 0xf2, 0xdb, 0xf8, 0x5b, 0x83, 0x4e, 0xfe, 0x69,
-	0x40, 0x06, 0x00, 0x7e, 0xb6, 0xc1, 0x13, 0xb7, 0xbd, 0xe2, 0x6e, 0x37, 0x73, 0x1a, 0xef, 0x3c,
-	0x1d, 0xf2, 0x1d, 0x54, 0x2d, 0x76, 0x90, 0xf1, 0xa7, 0x06, 0xeb, 0xa5, 0x19, 0x7b, 0x57, 0x8f,
-	0xbc, 0xab, 0xe3, 0x07, 0xd0, 0x73, 0xb9, 0x65, 0xe3, 0xcc, 0xa3, 0x11, 0x15, 0x6e, 0xc0, 0xe4,
-	0x8d, 0x34, 0xcd, 0xae, 0xcb, 0x47, 0x4b, 0xa6, 0xf1, 0x1d, 0x34, 0x53, 0x74, 0x9c, 0x49, 0x97,
-	0xcd, 0xf2, 0x99, 0x74, 0xd9, 0x2c, 0xce, 0x64, 0x2e, 0xc5, 0x2b, 0xf9, 0x14, 0x1b, 0x57, 0xb0,
-	0x5e, 0x7a, 0x35, 0x91, 0x67, 0xd0, 0xe7, 0xe8, 0x5d, 0xc9, 0x75, 0x19, 0xf9, 0xca, 0xb7, 0x56,
-	0x0c, 0x38, 0xab, 0xd2, 0xb5, 0x58, 0xf3, 0x78, 0xa9, 0x18, 0x97, 0xdc, 0x2b, 0x16, 0xfc, 0xce,
-	0x64, 0x69, 0x75, 0x4c, 0x45, 0x18, 0x97, 0x40, 0xca, 0xef, 0x2c, 0xf2, 0x10, 0x6a, 0xf2, 0x59,
-	0x77, 0xe7, 0xa4, 0x54, 0x62, 0xd9, 0x2a, 0x48, 0xed, 0xb7, 0xb4, 0x0a, 0x52, 0xdb, 0xf8, 0x19,
-	0xea, 0xca, 0x47, 0x7c, 0x67, 0x58, 0x78, 0xf7, 0x9a, 0x19, 0xfd, 0xd6, 0x36, 0xbf, 0x7d, 0x13,
-	0x18, 0x0d, 0xa8, 0xc9, 0x67, 0x8f, 0xf1, 0x0b, 0x90, 0xf2, 0x72, 0x8f, 0xe7, 0x28, 0x17, 0x34,
-	0x12, 0x56, 0xb1, 0x8a, 0xdb, 0x92, 0x79, 0xae, 0x4a, 0xf9, 0x13, 0x68, 0x23, 0xb3, 0xad, 0xe2,
-	0x25, 0xb4, 0x90, 0xd9, 0x4a, 0x6e, 0x1c, 0xc0, 0xc6, 0x2d, 0x2b, 0x9f, 0xec, 0x42, 0x33, 0x69,
-	0x98, 0x74, 0x9b, 0x94, 0x3a, 0x2a, 0x53, 0xf8, 0xe2, 0x7b, 0x68, 0xe7, 0x9a, 0xf4, 0xcd, 0xad,
-	0xdc, 0x85, 0xd6, 0xc1, 0xf3, 0xd3, 0xc3, 0x9f, 0xac, 0xe9, 0xf9, 0x51, 0x5f, 0x8b, 0x97, 0xef,
-	0xf1, 0x68, 0x7c, 0x72, 0x71, 0x7c, 0xf1, 0x52, 0x72, 0x56, 0xf6, 0x7f, 0x83, 0xba, 0x1a, 0x92,
-	0xe4, 0x29, 0x74, 0xd4, 0xd7, 0xb9, 0x88, 0x90, 0xfa, 0xa4, 0x94, 0xf0, 0xad, 0x12, 0xc7, 0xa8,
-	0xec, 0x68, 0x8f, 0x35, 0xf2, 0x10, 0xaa, 0x67, 0x2e, 0x73, 0x48, 0xf1, 0xb9, 0xb8, 0x55, 0x24,
-	0x8d, 0xca, 0xc1, 0x97, 0xbf, 0xee, 0x3a, 0xae, 0x98, 0x2f, 0x2e, 0x07, 0xb3, 0xc0, 0xdf, 0x9b,
-	0xdf, 0x84, 0x18, 0x79, 0x68, 0x3b, 0x18, 0xed, 0x5d, 0xd1, 0xcb, 0xc8, 0x9d, 0xed, 0xc9, 0x3f,
-	0x35, 0xbe, 0xa7, 0x60, 0x97, 0x75, 0x49, 0x3e, 0xf9, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x74, 0x25,
-	0x05, 0xf1, 0xd0, 0x0d, 0x00, 0x00,
+	// 1582 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x57, 0x5f, 0x4f, 0xe4, 0xc8,
+	0x11, 0x1f, 0x33, 0x7f, 0x5d, 0x33, 0x03, 0x43, 0xc3, 0xee, 0x3a, 0xdc, 0x29, 0x41, 0x4e, 0xee,
+	0xb4, 0x09, 0x17, 0x38, 0x71, 0xf9, 0x73, 0xd1, 0x25, 0x8a, 0x80, 0x21, 0x0c, 0xc9, 0x0e, 0x8b,
+	0x0c, 0x2b, 0x65, 0xf3, 0x62, 0x35, 0xe3, 0xc2, 0xd3, 0xc1, 0x6e, 0x1b, 0x77, 0xb3, 0x17, 0x9e,
+	0xf3, 0x96, 0x4f, 0x90, 0x97, 0xe4, 0xcb, 0xe4, 0x6b, 0xe5, 0x21, 0xea, 0x6e, 0xdb, 0x63, 0x33,
+	0xcc, 0x49, 0x7b, 0x52, 0xde, 0x5c, 0x55, 0xbf, 0xfa, 0xd3, 0xd5, 0x55, 0xd5, 0x65, 0xd8, 0x0e,
+	0x13, 0x21, 0x58, 0x7a, 0x10, 0xa3, 0x10, 0x34, 0xc4, 0xfd, 0x34, 0x4b, 0x64, 0x42, 0x3a, 0x86,
+	0xeb, 0xfe, 0xdd, 0x82, 0xde, 0x29, 0xff, 0x80, 0x51, 0x92, 0x22, 0x71, 0xa0, 0x9b, 0xd2, 0xc7,
+	0x28, 0xa1, 0x81, 0x63, 0xed, 0x5a, 0xaf, 0x07, 0x5e, 0x41, 0x92, 0x4f, 0xc1, 0x16, 0x2c, 0xe4,
+	0x54, 0x3e, 0x64, 0xe8, 0xac, 0x69, 0xd9, 0x82, 0x41, 0x7e, 0x0f, 0x1b, 0x02, 0x67, 0x19, 0x4a,
+	0x1f, 0x73, 0x53, 0x4e, 0x73, 0xd7, 0x7a, 0xdd, 0x3f, 0x7c, 0xb9, 0x6f, 0xdc, 0xec, 0x5f, 0x69,
+	0x71, 0xe1, 0xc8, 0x5b, 0x17, 0x35, 0xda, 0x9d, 0xc0, 0x7a, 0x1d, 0xf1, 0x7d, 0x43, 0x71, 0x8f,
+	0xa0, 0x63, 0x2c, 0x91, 0x2f, 0x60, 0xc4, 0xb8, 0xc4, 0x8c, 0xd3, 0xe8, 0x94, 0x07, 0x69, 0xc2,
+	0xb8, 0xd4, 0xa6, 0xec, 0x49, 0xc3, 0x5b, 0x92, 0x1c, 0xdb, 0xd0, 0x9d, 0x25, 0x5c, 0x22, 0x97,
+	0xee, 0x3f, 0x6d, 0x18, 0x9e, 0xe9, 0xb0, 0xa7, 0x26, 0x65, 0x64, 0x1b, 0xda, 0x3c, 0xe1, 0x33,
+	0xd4, 0xfa, 0x2d, 0xcf, 0x10, 0x2a, 0xc4, 0xd9, 0x9c, 0x72, 0x8e, 0x51, 0x1e, 0x46, 0x41, 0x92,
+	0x3d, 0x68, 0x4a, 0x1a, 0xea, 0x1c, 0xac, 0x1f, 0xfe, 0xa0, 0xc8, 0x41, 0xcd, 0xe6, 0xfe, 0x35,
+	0x0d, 0x3d, 0x85, 0x22, 0x5f, 0x81, 0x4d, 0x23, 0xf6, 0x01, 0xfd, 0x58, 0x84, 0x4e, 0x5b, 0xa7,
+	0x6d, 0xbb, 0x50, 0x39, 0x52, 0x82, 0x5c, 0x63, 0xd2, 0xf0, 0x7a, 0x1a, 0x38, 0x15, 0x21, 0xf9,
+	0x05, 0x74, 0x63, 0x8c, 0xfd, 0x0c, 0xef, 0x9d, 0x8e, 0x56, 0x29, 0xbd, 0x4c, 0x31, 0xbe, 0xc1,
+	0x4c, 0xcc, 0x59, 0xea, 0xe1, 0xfd, 0x03, 0x0a, 0x39, 0x69, 0x78, 0x9d, 0x18, 0x63, 0x0f, 0xef,
+	0xc9, 0x2f, 0x0b, 0x2d, 0xe1, 0x74, 0xb5, 0xd6, 0xce, 0x73, 0x5a, 0x22, 0x4d, 0xb8, 0xc0, 0x52,
+	0x4d, 0x90, 0x2f, 0xa1, 0x17, 0x50, 0x49, 0x75, 0x80, 0x3d, 0xad, 0xb7, 0x55, 0xe8, 0x8d, 0xa9,
+	0xa4, 0x8b, 0xf8, 0xba, 0x0a, 0xa6, 0xc2, 0xdb, 0x83, 0xf6, 0x1c, 0xa3, 0x28, 0x71, 0xec, 0x3a,
+	0xdc, 0xa4, 0x60, 0xa2, 0x44, 0x93, 0x86, 0x67, 0x30, 0xe4, 0x20, 0x37, 0x1f, 0xb0, 0xd0, 0x01,
+	0x8d, 0x27, 0x55, 0xf3, 0x63, 0x16, 0x9a, 0x53, 0x68, 0xeb, 0x63, 0x16, 0x96, 0xf1, 0xa8, 0xd3,
+	0xf7, 0x97, 0xe3, 0x59, 0x9c, 0x5b, 0x6b, 0x98, 0x83, 0xf7, 0xb5, 0xc6, 0x43, 0x1a, 0x50, 0x89,
+	0xce, 0x60, 0xd9, 0xcb, 0x3b, 0x2d, 0x99, 0x34, 0x3c, 0x08, 0x4a, 0x8a, 0x7c, 0x06, 0x6d, 0x8c,
+	0x53, 0xf9, 0xe8, 0x0c, 0xb5, 0xc2, 0xb0, 0x50, 0x38, 0x55, 0x4c, 0x75, 0x00, 0x2d, 0x25, 0x7b,
+	0xd0, 0x9a, 0x25, 0x9c, 0x3b, 0xeb, 0x1a, 0xf5, 0xa2, 0x40, 0x9d, 0x24, 0x9c, 0x9f, 0x0a, 0x49,
+	0x6f, 0x22, 0x26, 0xe6, 0x93, 0x86, 0xa7, 0x41, 0xe4, 0x10, 0x40, 0x48, 0x2a, 0xd1, 0x67, 0xfc,
+	0x36, 0x71, 0x36, 0xb4, 0xca, 0x66, 0xd9, 0x26, 0x4a, 0x72, 0xce, 0x6f, 0x55, 0x76, 0x6c, 0x51,
+	0x10, 0xe4, 0x18, 0xd6, 0x8d, 0x8e, 0xe0, 0x34, 0x15, 0xf3, 0x44, 0x3a, 0xa3, 0xfa, 0xa5, 0x97,
+	0x7a, 0x57, 0x39, 0x60, 0xd2, 0xf0, 0x86, 0x5a, 0xa5, 0x60, 0x90, 0x29, 0x6c, 0x2d, 0xfc, 0xfa,
+	0xe9, 0x43, 0x14, 0xe9, 0xfc, 0x6d, 0x6a, 0x43, 0x9f, 0x2e, 0x19, 0xba, 0x7c, 0x88, 0xa2, 0x45,
+	0x22, 0x47, 0xe2, 0x09, 0x9f, 0x1c, 0x81, 0xb1, 0xef, 0x67, 0x06, 0xe4, 0x90, 0x7a, 0x41, 0x79,
+	0x18, 0x27, 0x12, 0xb5, 0xb9, 0x85, 0x99, 0x81, 0xa8, 0xd0, 0x64, 0x5c, 0x9c, 0x2a, 0xcb, 0x4b,
+	0xce, 0xd9, 0xd2, 0x36, 0x3e, 0x79, 0xd6, 0x46, 0x59, 0x95, 0x43, 0x51, 0x65, 0xa8, 0xdc, 0x44,
+	0x48, 0x03, 0x53, 0xbc, 0xba, 0x44, 0xb7, 0xeb, 0xb9, 0x79, 0x53, 0x4a, 0x17, 0x85, 0x3a, 0x5c,
+	0xa8, 0xa8, 0x72, 0xfd, 0x06, 0x86, 0x29, 0x62, 0xe6, 0xb3, 0x00, 0xb9, 0x64, 0xf2, 0xd1, 0x79,
+	0x51, 0x6f, 0xc3, 0x4b, 0xc4, 0xec, 0x3c, 0x97, 0xa9, 0x63, 0xa4, 0x15, 0xda, 0xf5, 0xa1, 0x79,
+	0x4d, 0x43, 0x32, 0x04, 0xfb, 0xdd, 0xc5, 0xf8, 0xf4, 0x0f, 0xe7, 0x17, 0xa7, 0xe3, 0x51, 0x83,
+	0xd8, 0xd0, 0x3e, 0x9d, 0x5e, 0x5e, 0xbf, 0x1f, 0x59, 0x64, 0x00, 0xbd, 0xb7, 0xde, 0x99, 0xff,
+	0xf6, 0xe2, 0xcd, 0xfb, 0xd1, 0x9a, 0xc2, 0x9d, 0x4c, 0x8e, 0x2e, 0x0c, 0xd9, 0x24, 0x23, 0x18,
+	0x68, 0xf2, 0xe8, 0x62, 0xec, 0xbf, 0xf5, 0xce, 0x46, 0x2d, 0xb2, 0x01, 0x7d, 0x03, 0xf0, 0x34,
+	0xa3, 0x5d, 0x1d, 0x4d, 0xff, 0xb1, 0xc0, 0x2e, 0xaf, 0x88, 0xec, 0x40, 0x2f, 0x46, 0x49, 0x55,
+	0xc1, 0xe6, 0x43, 0xb2, 0xa4, 0xc9, 0x3e, 0xd8, 0x92, 0xc5, 0x28, 0x24, 0x8d, 0x53, 0x3d, 0x9e,
+	0xfa, 0x87, 0xa3, 0xea, 0x71, 0xae, 0x59, 0x8c, 0xde, 0x02, 0x42, 0x5e, 0x40, 0x27, 0xbd, 0x63,
+	0x3e, 0x0b, 0xf4, 0xd4, 0x1a, 0x78, 0xed, 0xf4, 0x8e, 0x9d, 0x07, 0xe4, 0x47, 0xd0, 0xcf, 0x87,
+	0x9a, 0x3f, 0x3d, 0x3a, 0x71, 0x5a, 0x5a, 0x06, 0x39, 0x6b, 0x7a, 0x74, 0xa2, 0xca, 0x39, 0xcd,
+	0x92, 0x14, 0x33, 0xc9, 0x50, 0x38, 0xed, 0x7a, 0x63, 0x5d, 0x96, 0x12, 0xaf, 0x82, 0x72, 0xff,
+	0x6b, 0x01, 0x2c, 0x44, 0xe4, 0xc7, 0x30, 0x8c, 0x30, 0x08, 0x31, 0xf3, 0xe7, 0xc8, 0xc2, 0xb9,
+	0xcc, 0xa7, 0xec, 0xc0, 0x30, 0x27, 0x9a, 0x47, 0xc6, 0xb0, 0xcd, 0xb8, 0x90, 0x34, 0x8a, 0x30,
+	0xf0, 0x67, 0x73, 0xca, 0xf8, 0x2c, 0x09, 0x50, 0x38, 0x6b, 0xbb, 0xcd, 0x6a, 0x03, 0x9d, 0x14,
+	0x12, 0x6f, 0xab, 0x84, 0x97, 0x3c, 0x41, 0xfe, 0x08, 0xaf, 0x34, 0x9b, 0x4b, 0x46, 0x65, 0xdd,
+	0x50, 0x73, 0x95, 0xa1, 0x97, 0x55, 0x8d, 0x8a, 0xad, 0x97, 0xd0, 0x31, 0x55, 0xa4, 0xb3, 0xd2,
+	0xf3, 0x72, 0x4a, 0xa5, 0x8c, 0xf2, 0xd9, 0x3c, 0xc9, 0x7c, 0x55, 0x26, 0x3a, 0x25, 0x3d, 0x0f,
+	0x0c, 0x4b, 0x65, 0xde, 0xfd, 0x0d, 0xd8, 0xa5, 0x19, 0x42, 0xa0, 0xc5, 0x69, 0x6c, 0x5e, 0x16,
+	0xdb, 0xd3, 0xdf, 0xea, 0x61, 0xf9, 0x80, 0x99, 0x60, 0x09, 0xd7, 0x37, 0x67, 0x7b, 0x05, 0xe9,
+	0x1e, 0xc1, 0xe6, 0x52, 0xab, 0x93, 0x2f, 0xa0, 0x87, 0x11, 0xc6, 0xc8, 0xa5, 0x70, 0xac, 0xdd,
+	0x66, 0xf5, 0xa6, 0xcb, 0x07, 0xb7, 0x44, 0xb8, 0xbf, 0x86, 0xed, 0xe7, 0x9a, 0xfc, 0xe9, 0x4d,
+	0x5b, 0x4f, 0x6f, 0xda, 0xbd, 0x85, 0x61, 0x6d, 0xa2, 0x55, 0x4a, 0xc6, 0xaa, 0x96, 0xcc, 0x0e,
+	0xf4, 0xca, 0x3e, 0x32, 0xef, 0x62, 0x49, 0x13, 0x17, 0x86, 0x32, 0x12, 0xfe, 0x0c, 0x33, 0xe9,
+	0xcf, 0xa9, 0x98, 0xe7, 0xc5, 0xd6, 0x97, 0x91, 0x38, 0xc1, 0x4c, 0x4e, 0xa8, 0x98, 0xbb, 0xef,
+	0x60, 0x50, 0xed, 0xb7, 0x55, 0x6e, 0x08, 0xb4, 0x94, 0x99, 0xdc, 0x85, 0xfe, 0xae, 0x35, 0x44,
+	0xb3, 0xde, 0x10, 0x6e, 0x0c, 0xfd, 0xca, 0xe3, 0xb0, 0xfa, 0x49, 0x0f, 0xf4, 0x73, 0x63, 0x0a,
+	0xcb, 0xf6, 0x0a, 0x92, 0xec, 0x43, 0x2f, 0x16, 0xa1, 0x2f, 0x1f, 0xf3, 0xdd, 0x66, 0x7d, 0xf1,
+	0xe6, 0xa8, 0x2c, 0x4e, 0x45, 0x78, 0xfd, 0x98, 0xa2, 0xd7, 0x8d, 0xcd, 0x87, 0x9b, 0x40, 0xbf,
+	0xf2, 0xd8, 0xad, 0x70, 0x57, 0x8d, 0x77, 0x6d, 0xa9, 0x81, 0x3f, 0xce, 0xe1, 0xdf, 0x00, 0x16,
+	0xef, 0xd8, 0x0a, 0x7f, 0x3f, 0x81, 0x56, 0xee, 0xeb, 0xf9, 0x2a, 0x69, 0x7d, 0x2f, 0xcf, 0x11,
+	0xc0, 0xe2, 0x9d, 0xfe, 0xbf, 0x27, 0xf6, 0x6b, 0x73, 0x8f, 0xc5, 0x6a, 0xf6, 0xd3, 0xfa, 0x9e,
+	0xd8, 0x3f, 0xdc, 0x28, 0xb5, 0x0d, 0xbb, 0x5c, 0x1c, 0xdd, 0x5f, 0x41, 0x37, 0xe7, 0x91, 0x57,
+	0xd0, 0x15, 0x78, 0xef, 0xf3, 0x87, 0x38, 0x0f, 0xb3, 0x23, 0xf0, 0xfe, 0xe2, 0x21, 0x56, 0x55,
+	0x55, 0xb9, 0x0d, 0xfd, 0xed, 0xfe, 0xc3, 0x82, 0x41, 0x75, 0x11, 0x23, 0xfb, 0x00, 0x71, 0xb9,
+	0x2f, 0xe5, 0x6e, 0xd7, 0xeb, 0x9b, 0x94, 0x57, 0x41, 0x7c, 0xf4, 0x2c, 0xae, 0x76, 0x50, 0xab,
+	0xde, 0x41, 0xee, 0xbf, 0x2d, 0xd8, 0x5c, 0x7a, 0xd1, 0x56, 0xf5, 0xc8, 0xc7, 0x3a, 0xfe, 0x0c,
+	0xd6, 0x99, 0xf0, 0x03, 0x9c, 0x45, 0x34, 0xa3, 0x52, 0xcd, 0x9f, 0xa6, 0x9e, 0x5e, 0x43, 0x26,
+	0xc6, 0x0b, 0x26, 0xf9, 0x04, 0x6c, 0x26, 0xfc, 0x94, 0x71, 0x8e, 0x41, 0x3e, 0xfc, 0x7a, 0x4c,
+	0x5c, 0x6a, 0xda, 0xfd, 0x2d, 0xf4, 0x0a, 0xd3, 0x2a, 0xcd, 0x8c, 0xcf, 0xaa, 0x69, 0x66, 0x7c,
+	0xa6, 0xd2, 0x5c, 0xc9, 0xff, 0x5a, 0x35, 0xff, 0xee, 0x2d, 0x6c, 0x2e, 0x2d, 0xb0, 0xe4, 0x1b,
+	0x18, 0x09, 0x8c, 0x6e, 0xf5, 0xe6, 0x92, 0xc5, 0x26, 0x30, 0x6b, 0xd7, 0x7a, 0xb6, 0x84, 0x37,
+	0x14, 0xf2, 0x7c, 0x01, 0x54, 0xf5, 0x78, 0xc7, 0x93, 0x6f, 0xb9, 0xae, 0xbb, 0x81, 0x67, 0x08,
+	0xf7, 0x06, 0xc8, 0xf2, 0xca, 0x4b, 0x3e, 0x87, 0xb6, 0xde, 0xb0, 0x57, 0x8e, 0x51, 0x23, 0xd6,
+	0x7d, 0x84, 0x34, 0xf8, 0x8e, 0x3e, 0x42, 0x1a, 0xb8, 0xff, 0xb2, 0xa0, 0x63, 0x9c, 0xa8, 0x1b,
+	0xc5, 0xda, 0x3f, 0x88, 0x57, 0xd2, 0xdf, 0x39, 0x04, 0x56, 0xbc, 0xca, 0x63, 0xd8, 0xe4, 0x28,
+	0xbf, 0x4d, 0xb2, 0x3b, 0xbf, 0x30, 0x23, 0x9c, 0x96, 0x0e, 0xe6, 0x55, 0x11, 0xcc, 0x85, 0x01,
+	0x14, 0x3f, 0x38, 0xde, 0x88, 0xd7, 0x19, 0xc2, 0x3d, 0x83, 0x8d, 0x27, 0x20, 0xd5, 0xa6, 0x39,
+	0x2c, 0x0f, 0xb3, 0x20, 0x6b, 0x27, 0x58, 0xab, 0x9f, 0xc0, 0xed, 0x42, 0x5b, 0x6f, 0xc4, 0xee,
+	0x9f, 0x81, 0x2c, 0xef, 0x7d, 0x6a, 0xe8, 0x0b, 0x49, 0x33, 0xe9, 0xd7, 0x5b, 0xae, 0xaf, 0x99,
+	0x57, 0xa6, 0xef, 0x7e, 0x08, 0x7d, 0xe4, 0x81, 0x5f, 0x2f, 0x0a, 0x1b, 0x79, 0x60, 0xe4, 0xee,
+	0x31, 0x6c, 0x3d, 0xb3, 0x0d, 0x92, 0x3d, 0xe8, 0xe5, 0xdd, 0x5d, 0x3c, 0x7d, 0x4b, 0xed, 0x5f,
+	0x02, 0x7e, 0xf6, 0x3b, 0xe8, 0x57, 0x26, 0xca, 0xd3, 0x85, 0x6d, 0x08, 0xf6, 0xf1, 0x9b, 0xb7,
+	0x27, 0x7f, 0xf2, 0xa7, 0x57, 0x67, 0x23, 0x4b, 0xed, 0x65, 0xe7, 0xe3, 0xd3, 0x8b, 0xeb, 0xf3,
+	0xeb, 0xf7, 0x9a, 0xb3, 0x76, 0xf8, 0x57, 0xe8, 0x98, 0x89, 0x4e, 0xbe, 0x86, 0x81, 0xf9, 0xba,
+	0x92, 0x19, 0xd2, 0x98, 0x2c, 0x15, 0xc0, 0xce, 0x12, 0xc7, 0x6d, 0xbc, 0xb6, 0xbe, 0xb4, 0xc8,
+	0xe7, 0xd0, 0xba, 0x64, 0x3c, 0x24, 0xf5, 0x3f, 0x89, 0x9d, 0x3a, 0xe9, 0x36, 0x8e, 0x7f, 0xfe,
+	0x97, 0xbd, 0x90, 0xc9, 0xf9, 0xc3, 0xcd, 0xfe, 0x2c, 0x89, 0x0f, 0xe6, 0x8f, 0x29, 0x66, 0x66,
+	0x1b, 0x3a, 0xb8, 0xa5, 0x37, 0x19, 0x9b, 0x1d, 0xe8, 0x9f, 0x78, 0x71, 0x60, 0xd4, 0x6e, 0x3a,
+	0x9a, 0xfc, 0xea, 0x7f, 0x03, 0x00, 0x81, 0x4f, 0x96, 0x5d, 0xeb, 0x0f, 0x00, 0x00,
 }
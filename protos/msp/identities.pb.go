@@ -11,12 +11,16 @@ It is generated from these files:
 
 It has these top-level messages:
 	SerializedIdentity
+	SerializedIdemixIdentity
 	MSPConfig
 	FabricMSPConfig
 	FabricCryptoConfig
 	SigningIdentityInfo
 	KeyInfo
 	FabricOUIdentifier
+	FabricNodeOUs
+	IdemixMSPConfig
+	IdemixMSPSignerConfig
 	MSPPrincipal
 	OrganizationUnit
 	MSPRole
@@ -67,23 +71,89 @@ func (m *SerializedIdentity) GetIdBytes() []byte {
 	return nil
 }
 
+// This struct represents an Idemix Identity
+// to be used to serialize it and deserialize it.
+// The IdemixMSP will first serialize an idemix identity to bytes using
+// this proto, and then uses these bytes as id_bytes in SerializedIdentity
+type SerializedIdemixIdentity struct {
+	// nym_x is the X-component of the pseudonym elliptic curve point.
+	// It is a []byte representation of an amcl.BIG
+	// The pseudonym can be seen as a public key of the identity, it is used to verify signatures.
+	NymX []byte `protobuf:"bytes,1,opt,name=nym_x,json=nymX,proto3" json:"nym_x,omitempty"`
+	// nym_y is the Y-component of the pseudonym elliptic curve point.
+	// It is a []byte representation of an amcl.BIG
+	// The pseudonym can be seen as a public key of the identity, it is used to verify signatures.
+	NymY []byte `protobuf:"bytes,2,opt,name=nym_y,json=nymY,proto3" json:"nym_y,omitempty"`
+	// ou contains the organizational unit of the idemix identity
+	Ou []byte `protobuf:"bytes,3,opt,name=ou,proto3" json:"ou,omitempty"`
+	// role contains the role of this identity (e.g., ADMIN or MEMBER)
+	Role []byte `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	// proof contains the cryptographic evidence that this identity is valid
+	Proof []byte `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *SerializedIdemixIdentity) Reset()                    { *m = SerializedIdemixIdentity{} }
+func (m *SerializedIdemixIdentity) String() string            { return proto.CompactTextString(m) }
+func (*SerializedIdemixIdentity) ProtoMessage()               {}
+func (*SerializedIdemixIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *SerializedIdemixIdentity) GetNymX() []byte {
+	if m != nil {
+		return m.NymX
+	}
+	return nil
+}
+
+func (m *SerializedIdemixIdentity) GetNymY() []byte {
+	if m != nil {
+		return m.NymY
+	}
+	return nil
+}
+
+func (m *SerializedIdemixIdentity) GetOu() []byte {
+	if m != nil {
+		return m.Ou
+	}
+	return nil
+}
+
+func (m *SerializedIdemixIdentity) GetRole() []byte {
+	if m != nil {
+		return m.Role
+	}
+	return nil
+}
+
+func (m *SerializedIdemixIdentity) GetProof() []byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SerializedIdentity)(nil), "msp.SerializedIdentity")
+	proto.RegisterType((*SerializedIdemixIdentity)(nil), "msp.SerializedIdemixIdentity")
 }
 
 func init() { proto.RegisterFile("msp/identities.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 171 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x4c, 0xcd, 0x3d, 0x0b, 0xc2, 0x30,
-	0x10, 0xc6, 0x71, 0xaa, 0xf8, 0x16, 0x9c, 0x42, 0x87, 0xba, 0x55, 0xa7, 0x4e, 0xc9, 0xe0, 0x37,
-	0x28, 0x38, 0x38, 0xb8, 0xd4, 0xcd, 0x45, 0x9a, 0xe6, 0x6c, 0x0f, 0x1a, 0x73, 0xe4, 0xe2, 0x50,
-	0x3f, 0xbd, 0x68, 0x16, 0xb7, 0xe7, 0x0f, 0x3f, 0x78, 0x44, 0xee, 0x98, 0x34, 0x5a, 0x78, 0x46,
-	0x8c, 0x08, 0xac, 0x28, 0xf8, 0xe8, 0xe5, 0xdc, 0x31, 0x1d, 0x4e, 0x42, 0x5e, 0x21, 0x60, 0x3b,
-	0xe2, 0x1b, 0xec, 0x39, 0x91, 0x49, 0xe6, 0x62, 0xe1, 0x98, 0xd0, 0x16, 0x59, 0x99, 0x55, 0x9b,
-	0x26, 0x85, 0xdc, 0x89, 0x35, 0xda, 0xbb, 0x99, 0x22, 0x70, 0x31, 0x2b, 0xb3, 0x6a, 0xdb, 0xac,
-	0xd0, 0xd6, 0xdf, 0xac, 0x2f, 0x62, 0xef, 0x43, 0xaf, 0x86, 0x89, 0x20, 0x8c, 0x60, 0x7b, 0x08,
-	0xea, 0xd1, 0x9a, 0x80, 0x5d, 0xfa, 0x62, 0xe5, 0x98, 0x6e, 0x55, 0x8f, 0x71, 0x78, 0x19, 0xd5,
-	0x79, 0xa7, 0xff, 0xa4, 0x4e, 0x52, 0x27, 0xa9, 0x1d, 0x93, 0x59, 0xfe, 0xf6, 0xf1, 0x13, 0x00,
-	0x00, 0xff, 0xff, 0xaf, 0xfd, 0x80, 0x9c, 0xb9, 0x00, 0x00, 0x00,
+	// 236 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x54, 0x8f, 0x3f, 0x4f, 0xc3, 0x30,
+	0x10, 0xc5, 0x95, 0x34, 0xe1, 0x8f, 0x55, 0x31, 0x98, 0x0e, 0x66, 0x2b, 0x9d, 0x32, 0xc5, 0x03,
+	0xdf, 0xa0, 0x12, 0x03, 0x03, 0x4b, 0x58, 0x80, 0xa5, 0x6a, 0xea, 0x6b, 0x7a, 0x52, 0x2e, 0x67,
+	0xd9, 0x8e, 0x54, 0x33, 0xf0, 0xd9, 0x51, 0x62, 0x51, 0xc1, 0xf6, 0xde, 0x4f, 0x3f, 0x3d, 0xdd,
+	0x89, 0x15, 0x79, 0xab, 0xd1, 0xc0, 0x10, 0x30, 0x20, 0xf8, 0xda, 0x3a, 0x0e, 0x2c, 0x17, 0xe4,
+	0xed, 0xe6, 0x59, 0xc8, 0x37, 0x70, 0xb8, 0xef, 0xf1, 0x0b, 0xcc, 0x4b, 0x52, 0xa2, 0x5c, 0x89,
+	0x92, 0xbc, 0x45, 0xa3, 0xb2, 0x75, 0x56, 0xdd, 0x36, 0xa9, 0xc8, 0x07, 0x71, 0x83, 0x66, 0xd7,
+	0xc6, 0x00, 0x5e, 0xe5, 0xeb, 0xac, 0x5a, 0x36, 0xd7, 0x68, 0xb6, 0x53, 0xdd, 0x7c, 0x0b, 0xf5,
+	0x6f, 0x86, 0xf0, 0x7c, 0x19, 0xbb, 0x17, 0xe5, 0x10, 0x69, 0x77, 0x9e, 0xc7, 0x96, 0x4d, 0x31,
+	0x44, 0x7a, 0xff, 0x85, 0x51, 0xe5, 0x17, 0xf8, 0x21, 0xef, 0x44, 0xce, 0xa3, 0x5a, 0xcc, 0x24,
+	0xe7, 0x51, 0x4a, 0x51, 0x38, 0xee, 0x41, 0x15, 0xc9, 0x99, 0xf2, 0x74, 0x9a, 0x75, 0xcc, 0x47,
+	0x55, 0xce, 0x30, 0x95, 0xed, 0xab, 0x78, 0x64, 0xd7, 0xd5, 0xa7, 0x68, 0xc1, 0xf5, 0x60, 0x3a,
+	0x70, 0xf5, 0x71, 0xdf, 0x3a, 0x3c, 0xa4, 0x5f, 0x7d, 0x4d, 0xde, 0x7e, 0x56, 0x1d, 0x86, 0xd3,
+	0xd8, 0xd6, 0x07, 0x26, 0xfd, 0xc7, 0xd4, 0xc9, 0xd4, 0xc9, 0xd4, 0xe4, 0x6d, 0x7b, 0x35, 0xe7,
+	0xa7, 0x9f, 0x01, 0x00, 0x13, 0xdc, 0xc8, 0x62, 0x39, 0x01, 0x00, 0x00,
 }
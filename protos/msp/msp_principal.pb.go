@@ -46,17 +46,26 @@ func (MSPPrincipal_Classification) EnumDescriptor() ([]byte, []int) {
 type MSPRole_MSPRoleType int32
 
 const (
-	MSPRole_MEMBER MSPRole_MSPRoleType = 0
-	MSPRole_ADMIN  MSPRole_MSPRoleType = 1
+	MSPRole_MEMBER  MSPRole_MSPRoleType = 0
+	MSPRole_ADMIN   MSPRole_MSPRoleType = 1
+	MSPRole_CLIENT  MSPRole_MSPRoleType = 2
+	MSPRole_PEER    MSPRole_MSPRoleType = 3
+	MSPRole_ORDERER MSPRole_MSPRoleType = 4
 )
 
 var MSPRole_MSPRoleType_name = map[int32]string{
 	0: "MEMBER",
 	1: "ADMIN",
+	2: "CLIENT",
+	3: "PEER",
+	4: "ORDERER",
 }
 var MSPRole_MSPRoleType_value = map[string]int32{
-	"MEMBER": 0,
-	"ADMIN":  1,
+	"MEMBER":  0,
+	"ADMIN":   1,
+	"CLIENT":  2,
+	"PEER":    3,
+	"ORDERER": 4,
 }
 
 func (x MSPRole_MSPRoleType) String() string {
@@ -66,22 +75,23 @@ func (MSPRole_MSPRoleType) EnumDescriptor() ([]byte, []int) { return fileDescrip
 
 // MSPPrincipal aims to represent an MSP-centric set of identities.
 // In particular, this structure allows for definition of
-//  - a group of identities that are member of the same MSP
-//  - a group of identities that are member of the same organization unit
-//    in the same MSP
-//  - a group of identities that are administering a specific MSP
-//  - a specific identity
+//   - a group of identities that are member of the same MSP
+//   - a group of identities that are member of the same organization unit
+//     in the same MSP
+//   - a group of identities that are administering a specific MSP
+//   - a specific identity
+//
 // Expressing these groups is done given two fields of the fields below
-//  - Classification, that defines the type of classification of identities
-//    in an MSP this principal would be defined on; Classification can take
-//    three values:
+//   - Classification, that defines the type of classification of identities
+//     in an MSP this principal would be defined on; Classification can take
+//     three values:
 //     (i)  ByMSPRole: that represents a classification of identities within
-//          MSP based on one of the two pre-defined MSP rules, "member" and "admin"
+//     MSP based on one of the two pre-defined MSP rules, "member" and "admin"
 //     (ii) ByOrganizationUnit: that represents a classification of identities
-//          within MSP based on the organization unit an identity belongs to
+//     within MSP based on the organization unit an identity belongs to
 //     (iii)ByIdentity that denotes that MSPPrincipal is mapped to a single
-//          identity/certificate; this would mean that the Principal bytes
-//          message
+//     identity/certificate; this would mean that the Principal bytes
+//     message
 type MSPPrincipal struct {
 	// Classification describes the way that one should process
 	// Principal. An Classification value of "ByOrganizationUnit" reflects
@@ -161,7 +171,9 @@ func (m *OrganizationUnit) GetCertifiersIdentifier() []byte {
 
 // MSPRole governs the organization of the Principal
 // field of an MSPPrincipal when it aims to define one of the
-// two dedicated roles within an MSP: Admin and Members.
+// dedicated roles within an MSP: Admin and Members, and, when
+// the MSP classifies its identities by node OUs, Client, Peer
+// and Orderer.
 type MSPRole struct {
 	// MSPIdentifier represents the identifier of the MSP this principal
 	// refers to
@@ -201,30 +213,31 @@ func init() {
 func init() { proto.RegisterFile("msp/msp_principal.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 386 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x92, 0xdf, 0x8e, 0x93, 0x40,
-	0x14, 0x87, 0x77, 0xea, 0x5a, 0xb7, 0xc7, 0x4a, 0x70, 0xe2, 0x66, 0x9b, 0xb8, 0x31, 0x1b, 0xac,
-	0x49, 0xaf, 0x20, 0x69, 0x1f, 0xc0, 0xb4, 0x96, 0x18, 0x12, 0xf9, 0x93, 0x29, 0xbd, 0xb0, 0x17,
-	0x12, 0x4a, 0xa7, 0x74, 0x12, 0x60, 0x26, 0x03, 0xbd, 0xa8, 0x2f, 0xe0, 0x0b, 0xf9, 0x1a, 0xbe,
-	0x93, 0x01, 0x2c, 0x9d, 0x7a, 0xb5, 0x57, 0x30, 0xe7, 0xf7, 0x7d, 0x67, 0x66, 0xe0, 0xc0, 0x43,
-	0x5e, 0x0a, 0x2b, 0x2f, 0x45, 0x24, 0x24, 0x2b, 0x12, 0x26, 0xe2, 0xcc, 0x14, 0x92, 0x57, 0x1c,
-	0xf7, 0x13, 0x9e, 0xe7, 0xbc, 0x30, 0xfe, 0x20, 0x18, 0xba, 0xab, 0x20, 0x38, 0xc7, 0xf8, 0x07,
-	0x8c, 0x3a, 0x36, 0x4a, 0xb2, 0xb8, 0x2c, 0xd9, 0x9e, 0x25, 0x71, 0xc5, 0x78, 0x31, 0x42, 0x4f,
-	0x68, 0xa2, 0x4d, 0x3f, 0x9a, 0xad, 0x6b, 0xaa, 0x9e, 0xf9, 0xe5, 0x0a, 0x25, 0x0f, 0x5d, 0x93,
-	0xeb, 0x00, 0x3f, 0xc2, 0xa0, 0x8b, 0x46, 0xbd, 0x27, 0x34, 0x19, 0x92, 0x4b, 0xc1, 0xf8, 0x0c,
-	0xda, 0x7f, 0xfc, 0x1d, 0xdc, 0x12, 0xff, 0x9b, 0xad, 0xdf, 0xe0, 0x7b, 0x78, 0xeb, 0x93, 0xaf,
-	0x73, 0xcf, 0xd9, 0xcc, 0x43, 0xc7, 0xf7, 0xa2, 0xb5, 0xe7, 0x84, 0x3a, 0xc2, 0x43, 0xb8, 0x73,
-	0x96, 0xb6, 0x17, 0x3a, 0xe1, 0x77, 0xbd, 0x67, 0xfc, 0x46, 0xa0, 0xfb, 0x32, 0x8d, 0x0b, 0xf6,
-	0xb3, 0xf1, 0xd7, 0x05, 0xab, 0xf0, 0x27, 0xd0, 0xea, 0x6f, 0xc0, 0x76, 0xb4, 0xa8, 0xd8, 0x9e,
-	0x51, 0xd9, 0xdc, 0x64, 0x40, 0xde, 0xe4, 0xa5, 0x70, 0xba, 0x22, 0x5e, 0xc2, 0x07, 0xae, 0xa8,
-	0x71, 0x16, 0x1d, 0x0b, 0x56, 0xa9, 0x5a, 0xaf, 0xd1, 0x1e, 0xaf, 0xa9, 0x7a, 0x0b, 0xa5, 0xcb,
-	0x0c, 0xee, 0x13, 0x2a, 0xdb, 0x45, 0xa9, 0xca, 0x2f, 0x9a, 0xcb, 0xbe, 0xbb, 0x84, 0x17, 0xc9,
-	0xf8, 0x85, 0xe0, 0x95, 0xbb, 0x0a, 0x08, 0xcf, 0xe8, 0x73, 0x4f, 0x6b, 0xc1, 0xad, 0xe4, 0x19,
-	0x6d, 0xce, 0xa4, 0x4d, 0xdf, 0x2b, 0x3f, 0xa5, 0xee, 0x72, 0x7e, 0x86, 0x27, 0x41, 0x49, 0x03,
-	0x1a, 0x63, 0x78, 0xad, 0x14, 0x31, 0x40, 0xdf, 0xb5, 0xdd, 0x85, 0x4d, 0xf4, 0x1b, 0x3c, 0x80,
-	0x97, 0xf3, 0xa5, 0xeb, 0x78, 0x3a, 0x5a, 0x04, 0x30, 0xe6, 0x32, 0x35, 0x0f, 0x27, 0x41, 0x65,
-	0x46, 0x77, 0x29, 0x95, 0xe6, 0x3e, 0xde, 0x4a, 0x96, 0xb4, 0x83, 0x53, 0xfe, 0xdb, 0x67, 0x33,
-	0x49, 0x59, 0x75, 0x38, 0x6e, 0xeb, 0xa5, 0xa5, 0xc0, 0x56, 0x0b, 0x5b, 0x2d, 0x5c, 0x8f, 0xde,
-	0xb6, 0xdf, 0xbc, 0xcf, 0xfe, 0x06, 0x00, 0x00, 0xff, 0xff, 0xf1, 0xd3, 0xfe, 0xdb, 0x8c, 0x02,
-	0x00, 0x00,
+	// 408 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x92, 0xdf, 0x6a, 0xdb, 0x30,
+	0x14, 0x87, 0xab, 0x34, 0x4b, 0x9b, 0xd3, 0x2c, 0x68, 0x62, 0xa5, 0x81, 0x95, 0x51, 0xbc, 0x0d,
+	0x72, 0x65, 0x43, 0xfb, 0x00, 0x23, 0x6d, 0x44, 0x11, 0xd4, 0x7f, 0x50, 0xdd, 0x8b, 0xf5, 0x62,
+	0xc1, 0x71, 0x15, 0x57, 0x60, 0x5b, 0x42, 0x76, 0x2f, 0xba, 0x47, 0xda, 0xf5, 0x5e, 0x63, 0xef,
+	0x34, 0x6c, 0x2f, 0x8e, 0xb2, 0xab, 0x5d, 0xd9, 0x3a, 0xbf, 0xef, 0x3b, 0x3e, 0x92, 0x05, 0x67,
+	0x45, 0xa5, 0xbd, 0xa2, 0xd2, 0x2b, 0x6d, 0x64, 0x99, 0x4a, 0x9d, 0xe4, 0xae, 0x36, 0xaa, 0x56,
+	0x64, 0x94, 0xaa, 0xa2, 0x50, 0xa5, 0xf3, 0x1b, 0xc1, 0xc4, 0xbf, 0x8f, 0xa2, 0x6d, 0x4c, 0xbe,
+	0xc3, 0xac, 0x67, 0x57, 0x69, 0x9e, 0x54, 0x95, 0xdc, 0xc8, 0x34, 0xa9, 0xa5, 0x2a, 0x67, 0xe8,
+	0x02, 0xcd, 0xa7, 0x97, 0x9f, 0xdc, 0xce, 0x75, 0x6d, 0xcf, 0xbd, 0xd9, 0x43, 0xf9, 0x59, 0xdf,
+	0x64, 0x3f, 0x20, 0xe7, 0x30, 0xee, 0xa3, 0xd9, 0xe0, 0x02, 0xcd, 0x27, 0x7c, 0x57, 0x70, 0xbe,
+	0xc2, 0xf4, 0x1f, 0xfe, 0x18, 0x86, 0x3c, 0xbc, 0xa3, 0xf8, 0x80, 0x9c, 0xc2, 0xbb, 0x90, 0xdf,
+	0x2e, 0x02, 0xf6, 0xb8, 0x88, 0x59, 0x18, 0xac, 0x1e, 0x02, 0x16, 0x63, 0x44, 0x26, 0x70, 0xcc,
+	0x96, 0x34, 0x88, 0x59, 0xfc, 0x0d, 0x0f, 0x9c, 0x5f, 0x08, 0x70, 0x68, 0xb2, 0xa4, 0x94, 0x3f,
+	0x5a, 0xff, 0xa1, 0x94, 0x35, 0xf9, 0x02, 0xd3, 0xe6, 0x0c, 0xe4, 0x93, 0x28, 0x6b, 0xb9, 0x91,
+	0xc2, 0xb4, 0x3b, 0x19, 0xf3, 0xb7, 0x45, 0xa5, 0x59, 0x5f, 0x24, 0x4b, 0xf8, 0xa8, 0x2c, 0x35,
+	0xc9, 0x57, 0x2f, 0xa5, 0xac, 0x6d, 0x6d, 0xd0, 0x6a, 0xe7, 0xfb, 0x54, 0xf3, 0x09, 0xab, 0xcb,
+	0x15, 0x9c, 0xa6, 0xc2, 0x74, 0x8b, 0xca, 0x96, 0x0f, 0xdb, 0xcd, 0xbe, 0xdf, 0x85, 0x3b, 0xc9,
+	0xf9, 0x89, 0xe0, 0xc8, 0xbf, 0x8f, 0xb8, 0xca, 0xc5, 0xff, 0x4e, 0xeb, 0xc1, 0xd0, 0xa8, 0x5c,
+	0xb4, 0x33, 0x4d, 0x2f, 0x3f, 0x58, 0x3f, 0xa5, 0xe9, 0xb2, 0x7d, 0xc6, 0xaf, 0x5a, 0xf0, 0x16,
+	0x74, 0x6e, 0xe1, 0xc4, 0x2a, 0x12, 0x80, 0x91, 0x4f, 0xfd, 0x6b, 0xca, 0xf1, 0x01, 0x19, 0xc3,
+	0x9b, 0xc5, 0xd2, 0x67, 0x01, 0x46, 0x4d, 0xf9, 0xe6, 0x8e, 0xd1, 0x20, 0xc6, 0x83, 0xe6, 0xec,
+	0x23, 0x4a, 0x39, 0x3e, 0x24, 0x27, 0x70, 0x14, 0xf2, 0x25, 0xe5, 0x94, 0xe3, 0xe1, 0x75, 0x04,
+	0x9f, 0x95, 0xc9, 0xdc, 0xe7, 0x57, 0x2d, 0x4c, 0x2e, 0x9e, 0x32, 0x61, 0xdc, 0x4d, 0xb2, 0x36,
+	0x32, 0xed, 0xee, 0x56, 0xf5, 0x77, 0x94, 0xc7, 0x79, 0x26, 0xeb, 0xe7, 0x97, 0x75, 0xb3, 0xf4,
+	0x2c, 0xd8, 0xeb, 0x60, 0xaf, 0x83, 0x9b, 0xdb, 0xb9, 0x1e, 0xb5, 0xef, 0x57, 0x7f, 0x06, 0x00,
+	0x95, 0x4b, 0xcf, 0xee, 0xaf, 0x02, 0x00, 0x00,
 }
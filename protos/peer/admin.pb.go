@@ -22,6 +22,8 @@ It has these top-level messages:
 	ServerStatus
 	LogLevelRequest
 	LogLevelResponse
+	GossipEndpointRequest
+	AnchorPeersRequest
 	ChaincodeID
 	ChaincodeInput
 	ChaincodeSpec
@@ -30,6 +32,7 @@ It has these top-level messages:
 	ChaincodeEvent
 	ChaincodeMessage
 	PutStateInfo
+	StateChunk
 	GetStateByRange
 	GetQueryResult
 	GetHistoryForKey
@@ -39,6 +42,8 @@ It has these top-level messages:
 	QueryResponse
 	AnchorPeers
 	AnchorPeer
+	ACLs
+	APIResource
 	ChaincodeReg
 	Interest
 	Register
@@ -75,6 +80,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/empty"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/timestamp"
 
 import (
 	context "golang.org/x/net/context"
@@ -127,6 +133,11 @@ func (ServerStatus_StatusCode) EnumDescriptor() ([]byte, []int) { return fileDes
 
 type ServerStatus struct {
 	Status ServerStatus_StatusCode `protobuf:"varint,1,opt,name=status,enum=protos.ServerStatus_StatusCode" json:"status,omitempty"`
+	// Expiration time of the certificate of the identity the node
+	// signs with, unset if it isn't an X.509 certificate
+	SigningCertExpiration *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=signing_cert_expiration,json=signingCertExpiration" json:"signing_cert_expiration,omitempty"`
+	// Expiration time of the TLS server certificate, unset if TLS is disabled
+	TlsCertExpiration *google_protobuf1.Timestamp `protobuf:"bytes,3,opt,name=tls_cert_expiration,json=tlsCertExpiration" json:"tls_cert_expiration,omitempty"`
 }
 
 func (m *ServerStatus) Reset()                    { *m = ServerStatus{} }
@@ -141,6 +152,20 @@ func (m *ServerStatus) GetStatus() ServerStatus_StatusCode {
 	return ServerStatus_UNDEFINED
 }
 
+func (m *ServerStatus) GetSigningCertExpiration() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.SigningCertExpiration
+	}
+	return nil
+}
+
+func (m *ServerStatus) GetTlsCertExpiration() *google_protobuf1.Timestamp {
+	if m != nil {
+		return m.TlsCertExpiration
+	}
+	return nil
+}
+
 type LogLevelRequest struct {
 	LogModule string `protobuf:"bytes,1,opt,name=log_module,json=logModule" json:"log_module,omitempty"`
 	LogLevel  string `protobuf:"bytes,2,opt,name=log_level,json=logLevel" json:"log_level,omitempty"`
@@ -189,10 +214,65 @@ func (m *LogLevelResponse) GetLogLevel() string {
 	return ""
 }
 
+// GossipEndpointRequest holds the endpoint the peer
+// publishes to peers of other organizations
+type GossipEndpointRequest struct {
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
+}
+
+func (m *GossipEndpointRequest) Reset()                    { *m = GossipEndpointRequest{} }
+func (m *GossipEndpointRequest) String() string            { return proto.CompactTextString(m) }
+func (*GossipEndpointRequest) ProtoMessage()               {}
+func (*GossipEndpointRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *GossipEndpointRequest) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+// AnchorPeersRequest holds anchor peers of an organization of a
+// channel, which the peer connects to in addition to those of the
+// channel configuration, until it restarts
+type AnchorPeersRequest struct {
+	ChannelId   string        `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	MspId       string        `protobuf:"bytes,2,opt,name=msp_id,json=mspId" json:"msp_id,omitempty"`
+	AnchorPeers []*AnchorPeer `protobuf:"bytes,3,rep,name=anchor_peers,json=anchorPeers" json:"anchor_peers,omitempty"`
+}
+
+func (m *AnchorPeersRequest) Reset()                    { *m = AnchorPeersRequest{} }
+func (m *AnchorPeersRequest) String() string            { return proto.CompactTextString(m) }
+func (*AnchorPeersRequest) ProtoMessage()               {}
+func (*AnchorPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *AnchorPeersRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *AnchorPeersRequest) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func (m *AnchorPeersRequest) GetAnchorPeers() []*AnchorPeer {
+	if m != nil {
+		return m.AnchorPeers
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ServerStatus)(nil), "protos.ServerStatus")
 	proto.RegisterType((*LogLevelRequest)(nil), "protos.LogLevelRequest")
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
+	proto.RegisterType((*GossipEndpointRequest)(nil), "protos.GossipEndpointRequest")
+	proto.RegisterType((*AnchorPeersRequest)(nil), "protos.AnchorPeersRequest")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
 }
 
@@ -213,6 +293,10 @@ type AdminClient interface {
 	GetModuleLogLevel(ctx context.Context, in *LogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	SetModuleLogLevel(ctx context.Context, in *LogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	RevertLogLevels(ctx context.Context, in *google_protobuf.Empty, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	// The following take a proposal whose payload is the request,
+	// signed by an admin of the organization of the peer
+	UpdateGossipEndpoint(ctx context.Context, in *SignedProposal, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	AddAnchorPeers(ctx context.Context, in *SignedProposal, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 }
 
 type adminClient struct {
@@ -268,6 +352,24 @@ func (c *adminClient) RevertLogLevels(ctx context.Context, in *google_protobuf.E
 	return out, nil
 }
 
+func (c *adminClient) UpdateGossipEndpoint(ctx context.Context, in *SignedProposal, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/protos.Admin/UpdateGossipEndpoint", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) AddAnchorPeers(ctx context.Context, in *SignedProposal, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/protos.Admin/AddAnchorPeers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Admin service
 
 type AdminServer interface {
@@ -277,6 +379,10 @@ type AdminServer interface {
 	GetModuleLogLevel(context.Context, *LogLevelRequest) (*LogLevelResponse, error)
 	SetModuleLogLevel(context.Context, *LogLevelRequest) (*LogLevelResponse, error)
 	RevertLogLevels(context.Context, *google_protobuf.Empty) (*google_protobuf.Empty, error)
+	// The following take a proposal whose payload is the request,
+	// signed by an admin of the organization of the peer
+	UpdateGossipEndpoint(context.Context, *SignedProposal) (*google_protobuf.Empty, error)
+	AddAnchorPeers(context.Context, *SignedProposal) (*google_protobuf.Empty, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -373,6 +479,42 @@ func _Admin_RevertLogLevels_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_UpdateGossipEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedProposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).UpdateGossipEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/UpdateGossipEndpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).UpdateGossipEndpoint(ctx, req.(*SignedProposal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_AddAnchorPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedProposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).AddAnchorPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/AddAnchorPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).AddAnchorPeers(ctx, req.(*SignedProposal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -397,6 +539,14 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevertLogLevels",
 			Handler:    _Admin_RevertLogLevels_Handler,
 		},
+		{
+			MethodName: "UpdateGossipEndpoint",
+			Handler:    _Admin_UpdateGossipEndpoint_Handler,
+		},
+		{
+			MethodName: "AddAnchorPeers",
+			Handler:    _Admin_AddAnchorPeers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
@@ -405,32 +555,45 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 418 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x93, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0x9b, 0x42, 0x0b, 0x79, 0x1b, 0xcc, 0x58, 0x08, 0xaa, 0x4e, 0x08, 0x94, 0x13, 0x5c,
-	0x1c, 0x69, 0x1c, 0x38, 0x20, 0x0e, 0xdd, 0x12, 0x06, 0x62, 0x4b, 0x23, 0x67, 0x15, 0x02, 0x09,
-	0x4d, 0x49, 0xf3, 0xe6, 0x55, 0x38, 0x73, 0xb0, 0x9d, 0x4a, 0xfb, 0x3a, 0x7c, 0x2e, 0x3e, 0x0c,
-	0x4a, 0xdc, 0x68, 0x13, 0xb0, 0x03, 0x82, 0x93, 0xf3, 0xde, 0xfb, 0xff, 0xff, 0x71, 0x7e, 0xb1,
-	0x81, 0xd4, 0x88, 0x3a, 0xcc, 0xcb, 0x6a, 0x75, 0xc1, 0x6a, 0xad, 0xac, 0xa2, 0xe3, 0x6e, 0x31,
-	0xd3, 0x5d, 0xa1, 0x94, 0x90, 0x18, 0x76, 0x65, 0xd1, 0x9c, 0x85, 0x58, 0xd5, 0xf6, 0xd2, 0x89,
-	0x82, 0xef, 0x1e, 0x6c, 0x67, 0xa8, 0xd7, 0xa8, 0x33, 0x9b, 0xdb, 0xc6, 0xd0, 0x57, 0x30, 0x36,
-	0xdd, 0xd3, 0xc4, 0x7b, 0xe6, 0x3d, 0xbf, 0xbf, 0xf7, 0xd4, 0x09, 0x0d, 0xbb, 0xae, 0x62, 0x6e,
-	0x39, 0x50, 0x25, 0xf2, 0x8d, 0x3c, 0xf8, 0x04, 0x70, 0xd5, 0xa5, 0xf7, 0xc0, 0x5f, 0x24, 0x51,
-	0xfc, 0xf6, 0x7d, 0x12, 0x47, 0x64, 0x40, 0xb7, 0xe0, 0x4e, 0x76, 0x32, 0xe3, 0x27, 0x71, 0x44,
-	0x3c, 0x57, 0xcc, 0xd3, 0x34, 0x8e, 0xc8, 0x90, 0x02, 0x8c, 0xd3, 0xd9, 0x22, 0x8b, 0x23, 0x72,
-	0x8b, 0xfa, 0x30, 0x8a, 0x39, 0x9f, 0x73, 0x72, 0xbb, 0xd5, 0x2c, 0x92, 0x0f, 0xc9, 0xfc, 0x63,
-	0x42, 0x46, 0xc1, 0x31, 0xec, 0x1c, 0x29, 0x71, 0x84, 0x6b, 0x94, 0x1c, 0xbf, 0x35, 0x68, 0x2c,
-	0x7d, 0x02, 0x20, 0x95, 0x38, 0xad, 0x54, 0xd9, 0x48, 0xec, 0xb6, 0xea, 0x73, 0x5f, 0x2a, 0x71,
-	0xdc, 0x35, 0xe8, 0x2e, 0xb4, 0xc5, 0xa9, 0x6c, 0x2d, 0x93, 0x61, 0x37, 0xbd, 0x2b, 0x37, 0x11,
-	0x41, 0x02, 0xe4, 0x2a, 0xce, 0xd4, 0xea, 0xc2, 0xe0, 0xbf, 0xe4, 0xed, 0xfd, 0x18, 0xc2, 0x68,
-	0xd6, 0x82, 0xa7, 0xaf, 0xc1, 0x3f, 0x44, 0xbb, 0x21, 0xf9, 0x88, 0x39, 0xf0, 0xac, 0x07, 0xcf,
-	0xe2, 0x16, 0xfc, 0xf4, 0xe1, 0x9f, 0x88, 0x06, 0x03, 0xfa, 0x06, 0xb6, 0x32, 0x9b, 0x6b, 0xeb,
-	0xda, 0x7f, 0x6d, 0x7f, 0x07, 0x0f, 0x0e, 0xd1, 0xba, 0xfd, 0xf6, 0x9f, 0x47, 0x1f, 0xf7, 0xe2,
-	0x5f, 0xf8, 0x4d, 0x27, 0xbf, 0x0f, 0x1c, 0x09, 0x97, 0x94, 0xfd, 0x9f, 0xa4, 0x03, 0xd8, 0xe1,
-	0xb8, 0x46, 0x6d, 0xfb, 0xd9, 0xcd, 0x54, 0x6e, 0xe8, 0x07, 0x83, 0xfd, 0x2f, 0x10, 0x28, 0x2d,
-	0xd8, 0xf9, 0x65, 0x8d, 0x5a, 0x62, 0x29, 0x50, 0xb3, 0xb3, 0xbc, 0xd0, 0xab, 0x65, 0xff, 0xe2,
-	0xf6, 0xe4, 0xef, 0x6f, 0x77, 0x7f, 0x20, 0xcd, 0x97, 0x5f, 0x73, 0x81, 0x9f, 0x5f, 0x88, 0x95,
-	0x3d, 0x6f, 0x0a, 0xb6, 0x54, 0x55, 0x78, 0xcd, 0x18, 0x3a, 0xa3, 0xbb, 0x0a, 0x26, 0x6c, 0x8d,
-	0x85, 0xbb, 0x26, 0x2f, 0x7f, 0x06, 0x00, 0x00, 0xff, 0xff, 0x6e, 0xe6, 0xef, 0xb7, 0x41, 0x03,
-	0x00, 0x00,
+	// 632 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x94, 0x5d, 0x4f, 0xdb, 0x3c,
+	0x14, 0xc7, 0x29, 0x7d, 0xda, 0x87, 0x9e, 0x32, 0x08, 0xe6, 0xad, 0x2a, 0x9a, 0x40, 0xb9, 0x62,
+	0x37, 0xa9, 0x54, 0x34, 0xed, 0x62, 0xda, 0x45, 0xa1, 0x19, 0x63, 0x83, 0x52, 0xa5, 0x54, 0xd3,
+	0x26, 0x4d, 0x55, 0x9a, 0x1c, 0x5c, 0x6b, 0x49, 0xec, 0xd9, 0x2e, 0x1a, 0x97, 0xfb, 0x6a, 0xfb,
+	0x38, 0xfb, 0x14, 0x53, 0xe2, 0x84, 0x16, 0x18, 0x9b, 0xd0, 0x76, 0x95, 0xfa, 0xbc, 0xfc, 0xfc,
+	0xef, 0xf1, 0xdf, 0x06, 0x4b, 0x20, 0xca, 0x96, 0x1f, 0xc6, 0x2c, 0x71, 0x84, 0xe4, 0x9a, 0x93,
+	0x6a, 0xf6, 0x51, 0xcd, 0x1d, 0xca, 0x39, 0x8d, 0xb0, 0x95, 0x2d, 0xc7, 0xd3, 0xcb, 0x16, 0xc6,
+	0x42, 0x5f, 0x9b, 0xa2, 0xe6, 0xee, 0xdd, 0xa4, 0x66, 0x31, 0x2a, 0xed, 0xc7, 0x22, 0x2f, 0x68,
+	0x64, 0xdc, 0x80, 0x27, 0x97, 0x8c, 0x4e, 0xa5, 0xaf, 0x19, 0xcf, 0xf9, 0xcd, 0xf5, 0x2c, 0x23,
+	0x24, 0x17, 0x5c, 0xf9, 0x91, 0x09, 0xda, 0xdf, 0x17, 0x61, 0x79, 0x80, 0xf2, 0x0a, 0xe5, 0x40,
+	0xfb, 0x7a, 0xaa, 0xc8, 0x0b, 0xa8, 0xaa, 0xec, 0x57, 0xa3, 0xb4, 0x57, 0xda, 0x5f, 0x69, 0xef,
+	0x9a, 0x42, 0xe5, 0xcc, 0x57, 0x39, 0xe6, 0x73, 0xc4, 0x43, 0xf4, 0xf2, 0x72, 0xe2, 0xc1, 0xb6,
+	0x62, 0x34, 0x61, 0x09, 0x1d, 0x05, 0x28, 0xf5, 0x08, 0xbf, 0x0a, 0x66, 0xf6, 0x6f, 0x2c, 0xee,
+	0x95, 0xf6, 0xeb, 0xed, 0xa6, 0x63, 0xb4, 0x3b, 0x85, 0x76, 0xe7, 0xa2, 0xd0, 0xee, 0x6d, 0xe6,
+	0xad, 0x47, 0x28, 0xb5, 0x7b, 0xd3, 0x48, 0xde, 0xc2, 0xba, 0x8e, 0xd4, 0x3d, 0x5e, 0xf9, 0x8f,
+	0xbc, 0x35, 0x1d, 0xa9, 0xdb, 0x2c, 0xfb, 0x03, 0xc0, 0x4c, 0x35, 0x79, 0x02, 0xb5, 0x61, 0xaf,
+	0xeb, 0xbe, 0x3e, 0xe9, 0xb9, 0x5d, 0x6b, 0x81, 0xd4, 0xe1, 0xff, 0xc1, 0x45, 0xc7, 0xbb, 0x70,
+	0xbb, 0x56, 0xc9, 0x2c, 0xce, 0xfb, 0x7d, 0xb7, 0x6b, 0x2d, 0x12, 0x80, 0x6a, 0xbf, 0x33, 0x1c,
+	0xb8, 0x5d, 0xab, 0x4c, 0x6a, 0x50, 0x71, 0x3d, 0xef, 0xdc, 0xb3, 0xfe, 0x4b, 0x6b, 0x86, 0xbd,
+	0x77, 0xbd, 0xf3, 0xf7, 0x3d, 0xab, 0x62, 0x9f, 0xc1, 0xea, 0x29, 0xa7, 0xa7, 0x78, 0x85, 0x91,
+	0x87, 0x5f, 0xa6, 0xa8, 0x34, 0x79, 0x0a, 0x10, 0x71, 0x3a, 0x8a, 0x79, 0x38, 0x8d, 0x30, 0x1b,
+	0x65, 0xcd, 0xab, 0x45, 0x9c, 0x9e, 0x65, 0x01, 0xb2, 0x03, 0xe9, 0x62, 0x14, 0xa5, 0x2d, 0xd9,
+	0x78, 0x6a, 0xde, 0x52, 0x94, 0x23, 0xec, 0x1e, 0x58, 0x33, 0x9c, 0x12, 0x3c, 0x51, 0xf8, 0x57,
+	0xbc, 0x03, 0xd8, 0x3c, 0xe6, 0x4a, 0x31, 0xe1, 0x26, 0xa1, 0xe0, 0x2c, 0xd1, 0x85, 0xc8, 0x26,
+	0x2c, 0x61, 0x1e, 0xca, 0x91, 0x37, 0x6b, 0xfb, 0x5b, 0x09, 0x48, 0x27, 0x09, 0x26, 0x5c, 0xf6,
+	0x11, 0xa5, 0x9a, 0xfb, 0x5f, 0xc1, 0xc4, 0x4f, 0x12, 0x8c, 0x46, 0x2c, 0x2c, 0x74, 0xe4, 0x91,
+	0x93, 0x90, 0x6c, 0x42, 0x35, 0x56, 0x22, 0x4d, 0x19, 0x11, 0x95, 0x58, 0x89, 0x93, 0x90, 0x3c,
+	0x87, 0x65, 0x3f, 0x63, 0x8d, 0x52, 0x0f, 0xaa, 0x46, 0x79, 0xaf, 0xbc, 0x5f, 0x6f, 0x93, 0xc2,
+	0x5a, 0xb3, 0x7d, 0xbc, 0xba, 0x3f, 0xdb, 0xb3, 0xfd, 0xa3, 0x0c, 0x95, 0x4e, 0x7a, 0x43, 0xc8,
+	0x4b, 0xa8, 0x1d, 0xa3, 0xce, 0x2d, 0xba, 0x75, 0xef, 0xe0, 0xdd, 0xf4, 0x86, 0x34, 0x37, 0x7e,
+	0x65, 0x55, 0x7b, 0x81, 0xbc, 0x82, 0xfa, 0x40, 0xfb, 0x52, 0x9b, 0xf0, 0xa3, 0xdb, 0xdf, 0xc0,
+	0xda, 0x31, 0x6a, 0x33, 0xe8, 0xe2, 0x5c, 0xc8, 0x76, 0x51, 0x7c, 0xe7, 0xe0, 0x9b, 0x8d, 0xfb,
+	0x09, 0x73, 0x84, 0x86, 0x34, 0xf8, 0x37, 0xa4, 0x23, 0x58, 0xf5, 0xf0, 0x0a, 0xa5, 0x2e, 0x72,
+	0x0f, 0x4f, 0xe5, 0x81, 0x78, 0x26, 0x67, 0x63, 0x28, 0x42, 0x5f, 0xe3, 0x6d, 0x77, 0x90, 0xad,
+	0x9b, 0x41, 0x30, 0x9a, 0x60, 0xd8, 0xcf, 0x5f, 0x8c, 0xdf, 0x90, 0x0e, 0x61, 0xa5, 0x13, 0x86,
+	0x73, 0x76, 0x79, 0x3c, 0xe3, 0xf0, 0x13, 0xd8, 0x5c, 0x52, 0x67, 0x72, 0x2d, 0x50, 0x46, 0x18,
+	0x52, 0x94, 0xce, 0xa5, 0x3f, 0x96, 0x2c, 0x28, 0x48, 0xa9, 0x75, 0x0e, 0x97, 0x33, 0x3f, 0xf4,
+	0xfd, 0xe0, 0xb3, 0x4f, 0xf1, 0xe3, 0x33, 0xca, 0xf4, 0x64, 0x3a, 0x76, 0x02, 0x1e, 0xb7, 0xe6,
+	0x1a, 0x5b, 0xa6, 0xd1, 0x3c, 0x92, 0xaa, 0x95, 0x36, 0x8e, 0xcd, 0xeb, 0x7a, 0xf0, 0x73, 0x00,
+	0x5b, 0x50, 0x8b, 0x45, 0x78, 0x05, 0x00, 0x00,
 }
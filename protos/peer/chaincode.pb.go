@@ -136,6 +136,14 @@ func (m *ChaincodeID) GetVersion() string {
 // the []byte-based current ChaincodeInput structure.
 type ChaincodeInput struct {
 	Args [][]byte `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
+	// is_init is set by clients to invoke the Init function of a chaincode
+	// whose definition requires an explicit initialization
+	IsInit bool `protobuf:"varint,2,opt,name=is_init,json=isInit" json:"is_init,omitempty"`
+	// is_query is set by clients whose proposal is not meant to be
+	// submitted for ordering; the query timeout of the chaincode then
+	// applies, and peers do not endorse the proposal, on channels
+	// requiring the V1_2 application capability
+	IsQuery bool `protobuf:"varint,3,opt,name=is_query,json=isQuery" json:"is_query,omitempty"`
 }
 
 func (m *ChaincodeInput) Reset()                    { *m = ChaincodeInput{} }
@@ -150,6 +158,20 @@ func (m *ChaincodeInput) GetArgs() [][]byte {
 	return nil
 }
 
+func (m *ChaincodeInput) GetIsInit() bool {
+	if m != nil {
+		return m.IsInit
+	}
+	return false
+}
+
+func (m *ChaincodeInput) GetIsQuery() bool {
+	if m != nil {
+		return m.IsQuery
+	}
+	return false
+}
+
 // Carries the chaincode specification. This is the actual metadata required for
 // defining a chaincode.
 type ChaincodeSpec struct {
@@ -281,42 +303,45 @@ func init() {
 func init() { proto.RegisterFile("peer/chaincode.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 591 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x93, 0x5d, 0x6f, 0xd3, 0x3e,
-	0x14, 0xc6, 0x97, 0xb5, 0x7b, 0x3b, 0x7d, 0xf9, 0xe7, 0x6f, 0x06, 0x54, 0xbb, 0x61, 0x44, 0x5c,
-	0x8c, 0x09, 0xa5, 0x52, 0x99, 0xb8, 0x42, 0x48, 0x59, 0x93, 0x4d, 0x81, 0xd2, 0x4c, 0x59, 0x87,
-	0x04, 0x37, 0x95, 0x9b, 0x9c, 0xa6, 0x16, 0xa9, 0x1d, 0x25, 0x6e, 0xb4, 0x5e, 0xf3, 0xbd, 0xf8,
-	0x6a, 0x20, 0x3b, 0x6b, 0xb7, 0xa9, 0xbb, 0xe4, 0x2a, 0xf6, 0x93, 0xe7, 0xd8, 0xcf, 0xf9, 0xe9,
-	0x18, 0x0e, 0x33, 0xc4, 0xbc, 0x1b, 0xcd, 0x28, 0xe3, 0x91, 0x88, 0xd1, 0xce, 0x72, 0x21, 0x05,
-	0xd9, 0xd5, 0x9f, 0xe2, 0xe8, 0x55, 0x22, 0x44, 0x92, 0x62, 0x57, 0x6f, 0x27, 0x8b, 0x69, 0x57,
-	0xb2, 0x39, 0x16, 0x92, 0xce, 0xb3, 0xca, 0x68, 0x05, 0xd0, 0xe8, 0xaf, 0x6a, 0x7d, 0x97, 0x10,
-	0xa8, 0x67, 0x54, 0xce, 0x3a, 0xc6, 0xb1, 0x71, 0x72, 0x10, 0xea, 0xb5, 0xd2, 0x38, 0x9d, 0x63,
-	0x67, 0xbb, 0xd2, 0xd4, 0x9a, 0x74, 0x60, 0xaf, 0xc4, 0xbc, 0x60, 0x82, 0x77, 0x6a, 0x5a, 0x5e,
-	0x6d, 0xad, 0x37, 0xd0, 0xbe, 0x3f, 0x90, 0x67, 0x0b, 0xa9, 0xea, 0x69, 0x9e, 0x14, 0x1d, 0xe3,
-	0xb8, 0x76, 0xd2, 0x0c, 0xf5, 0xda, 0xfa, 0x63, 0x40, 0x6b, 0x6d, 0xbb, 0xce, 0x30, 0x22, 0x36,
-	0xd4, 0xe5, 0x32, 0x43, 0x7d, 0x73, 0xbb, 0x77, 0x54, 0xc5, 0x2b, 0xec, 0x47, 0x26, 0x7b, 0xb4,
-	0xcc, 0x30, 0xd4, 0x3e, 0xf2, 0x01, 0x9a, 0xeb, 0xa6, 0xc7, 0x2c, 0xd6, 0xe9, 0x1a, 0xbd, 0x67,
-	0x1b, 0x75, 0xbe, 0x1b, 0x36, 0xd6, 0x46, 0x3f, 0x26, 0xef, 0x60, 0x87, 0xa9, 0x58, 0x3a, 0x77,
-	0xa3, 0xf7, 0x62, 0xb3, 0x40, 0xfd, 0x0d, 0x2b, 0x93, 0xea, 0x53, 0x11, 0x13, 0x0b, 0xd9, 0xa9,
-	0x1f, 0x1b, 0x27, 0x3b, 0xe1, 0x6a, 0x6b, 0x7d, 0x82, 0xba, 0x4a, 0x43, 0x5a, 0x70, 0x70, 0x33,
-	0x74, 0xbd, 0x0b, 0x7f, 0xe8, 0xb9, 0xe6, 0x16, 0x01, 0xd8, 0xbd, 0x0c, 0x06, 0xce, 0xf0, 0xd2,
-	0x34, 0xc8, 0x3e, 0xd4, 0x87, 0x81, 0xeb, 0x99, 0xdb, 0x64, 0x0f, 0x6a, 0x7d, 0x27, 0x34, 0x6b,
-	0x4a, 0xfa, 0xec, 0x7c, 0x73, 0xcc, 0xba, 0xf5, 0x7b, 0x1b, 0x5e, 0xae, 0xef, 0x74, 0x31, 0x4b,
-	0xc5, 0x72, 0x8e, 0x5c, 0x6a, 0x16, 0x1f, 0xa1, 0x7d, 0xdf, 0x5b, 0x91, 0x61, 0xa4, 0xa9, 0x34,
-	0x7a, 0xcf, 0x9f, 0xa4, 0x12, 0xb6, 0xa2, 0x47, 0x24, 0x1d, 0x68, 0xe3, 0x74, 0x8a, 0x91, 0x64,
-	0x25, 0x8e, 0x63, 0x2a, 0xf1, 0x8e, 0xcd, 0x91, 0x5d, 0x0d, 0x83, 0xbd, 0x1a, 0x06, 0x7b, 0xb4,
-	0x1a, 0x86, 0xb0, 0xb5, 0xae, 0x70, 0xa9, 0x44, 0xf2, 0x1a, 0x9a, 0xfa, 0xee, 0x8c, 0x46, 0x3f,
-	0x69, 0x82, 0x9a, 0x55, 0x33, 0x6c, 0x28, 0xed, 0xaa, 0x92, 0x48, 0x00, 0xfb, 0x78, 0x8b, 0xd1,
-	0x18, 0x79, 0xa9, 0xd1, 0xb4, 0x7b, 0x67, 0x1b, 0xe9, 0x1e, 0xb7, 0x65, 0x7b, 0xb7, 0x18, 0x2d,
-	0x24, 0x13, 0xdc, 0xe3, 0x25, 0xcb, 0x05, 0x57, 0x3f, 0xc2, 0x3d, 0x75, 0x8a, 0xc7, 0x4b, 0xcb,
-	0x86, 0xc3, 0xa7, 0x0c, 0x8a, 0xa8, 0x1b, 0xf4, 0xbf, 0x78, 0x61, 0x45, 0xf7, 0xfa, 0xfb, 0xf5,
-	0xc8, 0xfb, 0x6a, 0x1a, 0xd6, 0x2f, 0xe3, 0x01, 0x40, 0x9f, 0x97, 0x22, 0xa2, 0xaa, 0xf4, 0x1f,
-	0x00, 0x3c, 0x85, 0xff, 0x59, 0x3c, 0x4e, 0x90, 0x63, 0xae, 0x8f, 0x1c, 0xd3, 0x34, 0xb9, 0x9b,
-	0xfe, 0xff, 0x58, 0x7c, 0xb9, 0xd6, 0x9d, 0x34, 0x39, 0x3d, 0x83, 0xc3, 0xbe, 0xe0, 0x53, 0x16,
-	0x23, 0x97, 0x8c, 0xa6, 0x4c, 0x2e, 0x07, 0x58, 0x62, 0xaa, 0x92, 0x5e, 0xdd, 0x9c, 0x0f, 0xfc,
-	0xbe, 0xb9, 0x45, 0x4c, 0x68, 0xf6, 0x83, 0xe1, 0x85, 0xef, 0x7a, 0xc3, 0x91, 0xef, 0x0c, 0x4c,
-	0xe3, 0x3c, 0x00, 0x4b, 0xe4, 0x89, 0x3d, 0x5b, 0x66, 0x98, 0xa7, 0x18, 0x27, 0x98, 0xdb, 0x53,
-	0x3a, 0xc9, 0x59, 0xb4, 0xca, 0xa7, 0x1e, 0xf5, 0x8f, 0xb7, 0x09, 0x93, 0xb3, 0xc5, 0xc4, 0x8e,
-	0xc4, 0xbc, 0xfb, 0xc0, 0xda, 0xad, 0xac, 0xd5, 0x9b, 0x2e, 0xba, 0xca, 0x3a, 0xa9, 0xde, 0xfb,
-	0xfb, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x05, 0x18, 0x06, 0x44, 0x0e, 0x04, 0x00, 0x00,
+	// 625 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x94, 0xcf, 0x4f, 0xdb, 0x4a,
+	0x10, 0xc7, 0x31, 0x09, 0x24, 0x4c, 0x7e, 0x3c, 0xbf, 0x7d, 0xbc, 0x47, 0x1e, 0x97, 0x52, 0x9f,
+	0x28, 0xaa, 0x1c, 0x29, 0x45, 0x3d, 0x55, 0x95, 0x42, 0x6c, 0x90, 0xdb, 0x34, 0xa1, 0x4b, 0xa8,
+	0xda, 0x5e, 0x2c, 0xc7, 0x9e, 0x38, 0xab, 0x3a, 0xbb, 0xae, 0xbd, 0xb1, 0xc8, 0xb9, 0xff, 0x57,
+	0xff, 0xb5, 0x56, 0xbb, 0x26, 0x01, 0x04, 0xc7, 0x9e, 0xbc, 0xf3, 0xdd, 0xef, 0xcc, 0xce, 0x7c,
+	0xb4, 0x5e, 0xd8, 0x4f, 0x11, 0xb3, 0x6e, 0x38, 0x0f, 0x18, 0x0f, 0x45, 0x84, 0x76, 0x9a, 0x09,
+	0x29, 0xc8, 0xae, 0xfe, 0xe4, 0x87, 0xcf, 0x62, 0x21, 0xe2, 0x04, 0xbb, 0x3a, 0x9c, 0x2e, 0x67,
+	0x5d, 0xc9, 0x16, 0x98, 0xcb, 0x60, 0x91, 0x96, 0x46, 0x6b, 0x0c, 0x8d, 0xc1, 0x3a, 0xd7, 0x73,
+	0x08, 0x81, 0x6a, 0x1a, 0xc8, 0x79, 0xc7, 0x38, 0x32, 0x8e, 0xf7, 0xa8, 0x5e, 0x2b, 0x8d, 0x07,
+	0x0b, 0xec, 0x6c, 0x97, 0x9a, 0x5a, 0x93, 0x0e, 0xd4, 0x0a, 0xcc, 0x72, 0x26, 0x78, 0xa7, 0xa2,
+	0xe5, 0x75, 0x68, 0x7d, 0x86, 0xf6, 0x5d, 0x41, 0x9e, 0x2e, 0xa5, 0xca, 0x0f, 0xb2, 0x38, 0xef,
+	0x18, 0x47, 0x95, 0xe3, 0x26, 0xd5, 0x6b, 0x72, 0x00, 0x35, 0x96, 0xfb, 0x8c, 0x33, 0xa9, 0xcb,
+	0xd6, 0xe9, 0x2e, 0xcb, 0x3d, 0xce, 0x24, 0xf9, 0x1f, 0xea, 0x2c, 0xf7, 0xbf, 0x2f, 0x31, 0x5b,
+	0xe9, 0xca, 0x75, 0x5a, 0x63, 0xf9, 0x47, 0x15, 0x5a, 0xbf, 0x0c, 0x68, 0x6d, 0x4a, 0x5f, 0xa5,
+	0x18, 0x12, 0x1b, 0xaa, 0x72, 0x95, 0xa2, 0xee, 0xb6, 0xdd, 0x3b, 0x2c, 0x47, 0xca, 0xed, 0x07,
+	0x26, 0x7b, 0xb2, 0x4a, 0x91, 0x6a, 0x1f, 0x79, 0x0d, 0xcd, 0x0d, 0x28, 0x9f, 0x45, 0xfa, 0xe8,
+	0x46, 0xef, 0x9f, 0x47, 0x79, 0x9e, 0x43, 0x1b, 0x1b, 0xa3, 0x17, 0x91, 0x97, 0xb0, 0xc3, 0xd4,
+	0x28, 0xba, 0xa3, 0x46, 0xef, 0xbf, 0xc7, 0x09, 0x6a, 0x97, 0x96, 0x26, 0xc5, 0x46, 0x51, 0x16,
+	0x4b, 0xd9, 0xa9, 0x1e, 0x19, 0xc7, 0x3b, 0x74, 0x1d, 0x5a, 0x6f, 0xa1, 0xaa, 0xba, 0x21, 0x2d,
+	0xd8, 0xbb, 0x1e, 0x39, 0xee, 0xb9, 0x37, 0x72, 0x1d, 0x73, 0x8b, 0x00, 0xec, 0x5e, 0x8c, 0x87,
+	0xfd, 0xd1, 0x85, 0x69, 0x90, 0x3a, 0x54, 0x47, 0x63, 0xc7, 0x35, 0xb7, 0x49, 0x0d, 0x2a, 0x83,
+	0x3e, 0x35, 0x2b, 0x4a, 0x7a, 0xd7, 0xff, 0xd4, 0x37, 0xab, 0xd6, 0xcf, 0x6d, 0x38, 0xd8, 0x9c,
+	0xe9, 0x60, 0x9a, 0x88, 0xd5, 0x02, 0xb9, 0xd4, 0x2c, 0xde, 0x40, 0xfb, 0x6e, 0xb6, 0x3c, 0xc5,
+	0x50, 0x53, 0x69, 0xf4, 0xfe, 0x7d, 0x92, 0x0a, 0x6d, 0x85, 0xf7, 0x43, 0xd2, 0x87, 0x36, 0xce,
+	0x66, 0x18, 0x4a, 0x56, 0xa0, 0x1f, 0x05, 0x12, 0x6f, 0xd9, 0x1c, 0xda, 0xe5, 0x05, 0xb2, 0xd7,
+	0x17, 0xc8, 0x9e, 0xac, 0x2f, 0x10, 0x6d, 0x6d, 0x32, 0x9c, 0x40, 0x22, 0x79, 0x0e, 0x4d, 0x7d,
+	0x76, 0x1a, 0x84, 0xdf, 0x82, 0x18, 0x35, 0xab, 0x26, 0x6d, 0x28, 0xed, 0xb2, 0x94, 0xc8, 0x18,
+	0xea, 0x78, 0x83, 0xa1, 0x8f, 0xbc, 0xd0, 0x68, 0xda, 0xbd, 0xd3, 0x47, 0xdd, 0x3d, 0x1c, 0xcb,
+	0x76, 0x6f, 0x30, 0x5c, 0x4a, 0x26, 0xb8, 0xcb, 0x0b, 0x96, 0x09, 0xae, 0x36, 0x68, 0x4d, 0x55,
+	0x71, 0x79, 0x61, 0xd9, 0xb0, 0xff, 0x94, 0x41, 0x11, 0x75, 0xc6, 0x83, 0xf7, 0x2e, 0x2d, 0xe9,
+	0x5e, 0x7d, 0xb9, 0x9a, 0xb8, 0x1f, 0x4c, 0xc3, 0xfa, 0x61, 0xdc, 0x03, 0xe8, 0xf1, 0x42, 0x84,
+	0x81, 0x4a, 0xfd, 0x03, 0x00, 0x4f, 0xe0, 0x6f, 0x16, 0xf9, 0x31, 0x72, 0xcc, 0x74, 0x49, 0x3f,
+	0x48, 0xe2, 0xdb, 0x3f, 0xe6, 0x2f, 0x16, 0x5d, 0x6c, 0xf4, 0x7e, 0x12, 0x9f, 0x9c, 0xc2, 0xfe,
+	0x40, 0xf0, 0x19, 0x8b, 0x90, 0x4b, 0x16, 0x24, 0x4c, 0xae, 0x86, 0x58, 0x60, 0xa2, 0x3a, 0xbd,
+	0xbc, 0x3e, 0x1b, 0x7a, 0x03, 0x73, 0x8b, 0x98, 0xd0, 0x1c, 0x8c, 0x47, 0xe7, 0x9e, 0xe3, 0x8e,
+	0x26, 0x5e, 0x7f, 0x68, 0x1a, 0x67, 0x63, 0xb0, 0x44, 0x16, 0xdb, 0xf3, 0x55, 0x8a, 0x59, 0x82,
+	0x51, 0x8c, 0x99, 0x3d, 0x0b, 0xa6, 0x19, 0x0b, 0xd7, 0xfd, 0xa9, 0x87, 0xe0, 0xeb, 0x8b, 0x98,
+	0xc9, 0xf9, 0x72, 0x6a, 0x87, 0x62, 0xd1, 0xbd, 0x67, 0xed, 0x96, 0xd6, 0xf2, 0x1d, 0xc8, 0xbb,
+	0xca, 0x3a, 0x2d, 0xdf, 0x88, 0x57, 0xbf, 0x07, 0x00, 0x04, 0x57, 0x09, 0xea, 0x42, 0x04, 0x00,
+	0x00,
 }
@@ -40,6 +40,8 @@ const (
 	ChaincodeMessage_QUERY_STATE_CLOSE   ChaincodeMessage_Type = 17
 	ChaincodeMessage_KEEPALIVE           ChaincodeMessage_Type = 18
 	ChaincodeMessage_GET_HISTORY_FOR_KEY ChaincodeMessage_Type = 19
+	ChaincodeMessage_PUT_STATE_CHUNK     ChaincodeMessage_Type = 20
+	ChaincodeMessage_GET_STATE_CHUNK     ChaincodeMessage_Type = 21
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -62,6 +64,8 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	17: "QUERY_STATE_CLOSE",
 	18: "KEEPALIVE",
 	19: "GET_HISTORY_FOR_KEY",
+	20: "PUT_STATE_CHUNK",
+	21: "GET_STATE_CHUNK",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
 	"UNDEFINED":           0,
@@ -83,6 +87,8 @@ var ChaincodeMessage_Type_value = map[string]int32{
 	"QUERY_STATE_CLOSE":   17,
 	"KEEPALIVE":           18,
 	"GET_HISTORY_FOR_KEY": 19,
+	"PUT_STATE_CHUNK":     20,
+	"GET_STATE_CHUNK":     21,
 }
 
 func (x ChaincodeMessage_Type) String() string {
@@ -173,6 +179,54 @@ func (m *PutStateInfo) GetValue() []byte {
 	return nil
 }
 
+// StateChunk carries a part of a state value that is too large to be
+// transferred in a single ChaincodeMessage. The chaincode sends a large
+// value as a sequence of PUT_STATE_CHUNK messages; the peer answers a
+// GET_STATE for a large value with a GET_STATE_CHUNK holding the first
+// part, and the chaincode asks for the following parts with GET_STATE_CHUNK
+// messages giving their offset
+type StateChunk struct {
+	Key  string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// offset of data within the value
+	Offset uint64 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+	// size of the whole value
+	Size uint64 `protobuf:"varint,4,opt,name=size" json:"size,omitempty"`
+}
+
+func (m *StateChunk) Reset()                    { *m = StateChunk{} }
+func (m *StateChunk) String() string            { return proto.CompactTextString(m) }
+func (*StateChunk) ProtoMessage()               {}
+func (*StateChunk) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+
+func (m *StateChunk) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StateChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *StateChunk) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *StateChunk) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
 type GetStateByRange struct {
 	StartKey string `protobuf:"bytes,1,opt,name=startKey" json:"startKey,omitempty"`
 	EndKey   string `protobuf:"bytes,2,opt,name=endKey" json:"endKey,omitempty"`
@@ -181,7 +235,7 @@ type GetStateByRange struct {
 func (m *GetStateByRange) Reset()                    { *m = GetStateByRange{} }
 func (m *GetStateByRange) String() string            { return proto.CompactTextString(m) }
 func (*GetStateByRange) ProtoMessage()               {}
-func (*GetStateByRange) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{2} }
+func (*GetStateByRange) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
 
 func (m *GetStateByRange) GetStartKey() string {
 	if m != nil {
@@ -204,7 +258,7 @@ type GetQueryResult struct {
 func (m *GetQueryResult) Reset()                    { *m = GetQueryResult{} }
 func (m *GetQueryResult) String() string            { return proto.CompactTextString(m) }
 func (*GetQueryResult) ProtoMessage()               {}
-func (*GetQueryResult) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{3} }
+func (*GetQueryResult) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
 
 func (m *GetQueryResult) GetQuery() string {
 	if m != nil {
@@ -220,7 +274,7 @@ type GetHistoryForKey struct {
 func (m *GetHistoryForKey) Reset()                    { *m = GetHistoryForKey{} }
 func (m *GetHistoryForKey) String() string            { return proto.CompactTextString(m) }
 func (*GetHistoryForKey) ProtoMessage()               {}
-func (*GetHistoryForKey) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{4} }
+func (*GetHistoryForKey) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{5} }
 
 func (m *GetHistoryForKey) GetKey() string {
 	if m != nil {
@@ -236,7 +290,7 @@ type QueryStateNext struct {
 func (m *QueryStateNext) Reset()                    { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string            { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()               {}
-func (*QueryStateNext) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{5} }
+func (*QueryStateNext) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
 
 func (m *QueryStateNext) GetId() string {
 	if m != nil {
@@ -252,7 +306,7 @@ type QueryStateClose struct {
 func (m *QueryStateClose) Reset()                    { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string            { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()               {}
-func (*QueryStateClose) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{6} }
+func (*QueryStateClose) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
 
 func (m *QueryStateClose) GetId() string {
 	if m != nil {
@@ -268,7 +322,7 @@ type QueryResultBytes struct {
 func (m *QueryResultBytes) Reset()                    { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string            { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()               {}
-func (*QueryResultBytes) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{7} }
+func (*QueryResultBytes) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
 
 func (m *QueryResultBytes) GetResultBytes() []byte {
 	if m != nil {
@@ -286,7 +340,7 @@ type QueryResponse struct {
 func (m *QueryResponse) Reset()                    { *m = QueryResponse{} }
 func (m *QueryResponse) String() string            { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()               {}
-func (*QueryResponse) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{8} }
+func (*QueryResponse) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{9} }
 
 func (m *QueryResponse) GetResults() []*QueryResultBytes {
 	if m != nil {
@@ -312,6 +366,7 @@ func (m *QueryResponse) GetId() string {
 func init() {
 	proto.RegisterType((*ChaincodeMessage)(nil), "protos.ChaincodeMessage")
 	proto.RegisterType((*PutStateInfo)(nil), "protos.PutStateInfo")
+	proto.RegisterType((*StateChunk)(nil), "protos.StateChunk")
 	proto.RegisterType((*GetStateByRange)(nil), "protos.GetStateByRange")
 	proto.RegisterType((*GetQueryResult)(nil), "protos.GetQueryResult")
 	proto.RegisterType((*GetHistoryForKey)(nil), "protos.GetHistoryForKey")
@@ -429,54 +484,57 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 771 bytes of a gzipped FileDescriptorProto
+	// 828 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x74, 0x94, 0x6f, 0x6f, 0xe2, 0x46,
-	0x10, 0xc6, 0x8f, 0x3f, 0x49, 0x60, 0x20, 0xb0, 0xb7, 0xb9, 0xa6, 0x3e, 0xa4, 0xaa, 0xd4, 0xaa,
-	0x2a, 0xfa, 0xc6, 0xb4, 0xb4, 0xaa, 0xfa, 0xae, 0x22, 0xb0, 0x21, 0x56, 0x88, 0xcd, 0xad, 0x9d,
-	0xd3, 0xd1, 0x37, 0x96, 0x03, 0x1b, 0x63, 0x15, 0x58, 0x77, 0x77, 0x39, 0x9d, 0x3f, 0x61, 0xbf,
-	0x4c, 0x3f, 0xc4, 0x69, 0x6d, 0x4c, 0xb8, 0x9c, 0xf2, 0xca, 0x7e, 0x66, 0x7e, 0xf3, 0xcc, 0xec,
-	0x6a, 0x35, 0xf0, 0x36, 0x61, 0x4c, 0xf4, 0x17, 0xab, 0x30, 0xde, 0x2e, 0xf8, 0x92, 0x05, 0x72,
-	0x15, 0x6f, 0xac, 0x44, 0x70, 0xc5, 0xf1, 0x69, 0xf6, 0x91, 0x9d, 0xce, 0x33, 0x84, 0x7d, 0x64,
-	0x5b, 0x95, 0x33, 0x9d, 0x8b, 0x2c, 0x97, 0x08, 0x9e, 0x70, 0x19, 0xae, 0xf7, 0xc1, 0xef, 0x23,
-	0xce, 0xa3, 0x35, 0xeb, 0x67, 0xea, 0x61, 0xf7, 0xd8, 0x57, 0xf1, 0x86, 0x49, 0x15, 0x6e, 0x92,
-	0x1c, 0x30, 0xff, 0xaf, 0x02, 0x1a, 0x15, 0x7e, 0x77, 0x4c, 0xca, 0x30, 0x62, 0xf8, 0x57, 0xa8,
-	0xaa, 0x34, 0x61, 0x46, 0xa9, 0x5b, 0xea, 0xb5, 0x06, 0xdf, 0xe5, 0xa8, 0xb4, 0x9e, 0x73, 0x96,
-	0x9f, 0x26, 0x8c, 0x66, 0x28, 0xfe, 0x13, 0xea, 0x07, 0x6b, 0xa3, 0xdc, 0x2d, 0xf5, 0x1a, 0x83,
-	0x8e, 0x95, 0x37, 0xb7, 0x8a, 0xe6, 0x96, 0x5f, 0x10, 0xf4, 0x09, 0xc6, 0x06, 0x9c, 0x25, 0x61,
-	0xba, 0xe6, 0xe1, 0xd2, 0xa8, 0x74, 0x4b, 0xbd, 0x26, 0x2d, 0x24, 0xc6, 0x50, 0x55, 0x9f, 0xe2,
-	0xa5, 0x51, 0xed, 0x96, 0x7a, 0x75, 0x9a, 0xfd, 0xe3, 0x01, 0xd4, 0x8a, 0x23, 0x1a, 0x27, 0x59,
-	0x9b, 0xcb, 0x62, 0x3c, 0x2f, 0x8e, 0xb6, 0x6c, 0x39, 0xdb, 0x67, 0xe9, 0x81, 0xc3, 0x7f, 0x41,
-	0xfb, 0xd9, 0x95, 0x19, 0xa7, 0x5f, 0x96, 0x1e, 0x4e, 0x46, 0x74, 0x96, 0xb6, 0x16, 0x5f, 0x68,
-	0xf3, 0xbf, 0x32, 0x54, 0xf5, 0x59, 0xf1, 0x39, 0xd4, 0xef, 0x9d, 0x31, 0xb9, 0xb6, 0x1d, 0x32,
-	0x46, 0xaf, 0x70, 0x13, 0x6a, 0x94, 0x4c, 0x6c, 0xcf, 0x27, 0x14, 0x95, 0x70, 0x0b, 0xa0, 0x50,
-	0x64, 0x8c, 0xca, 0xb8, 0x06, 0x55, 0xdb, 0xb1, 0x7d, 0x54, 0xc1, 0x75, 0x38, 0xa1, 0x64, 0x38,
-	0x9e, 0xa3, 0x2a, 0x6e, 0x43, 0xc3, 0xa7, 0x43, 0xc7, 0x1b, 0x8e, 0x7c, 0xdb, 0x75, 0xd0, 0x89,
-	0xb6, 0x1c, 0xb9, 0x77, 0xb3, 0x29, 0xf1, 0xc9, 0x18, 0x9d, 0x6a, 0x94, 0x50, 0xea, 0x52, 0x74,
-	0xa6, 0x33, 0x13, 0xe2, 0x07, 0x9e, 0x3f, 0xf4, 0x09, 0xaa, 0x69, 0x39, 0xbb, 0x2f, 0x64, 0x5d,
-	0xcb, 0x31, 0x99, 0xee, 0x25, 0xe0, 0x37, 0x80, 0x6c, 0xe7, 0xbd, 0x7b, 0x4b, 0x82, 0xd1, 0xcd,
-	0xd0, 0x76, 0x46, 0xee, 0x98, 0xa0, 0x46, 0x3e, 0xa0, 0x37, 0x73, 0x1d, 0x8f, 0xa0, 0x73, 0x7c,
-	0x09, 0xf8, 0x60, 0x18, 0x5c, 0xcd, 0x03, 0x3a, 0x74, 0x26, 0x04, 0xb5, 0x74, 0xad, 0x8e, 0xbf,
-	0xbb, 0x27, 0x74, 0x1e, 0x50, 0xe2, 0xdd, 0x4f, 0x7d, 0xd4, 0xd6, 0xd1, 0x3c, 0x92, 0xf3, 0x0e,
-	0xf9, 0xe0, 0x23, 0x84, 0xbf, 0x81, 0xd7, 0xc7, 0xd1, 0xd1, 0xd4, 0xf5, 0x08, 0x7a, 0xad, 0xa7,
-	0xb9, 0x25, 0x64, 0x36, 0x9c, 0xda, 0xef, 0x09, 0xc2, 0xf8, 0x5b, 0xb8, 0xd0, 0x8e, 0x37, 0xb6,
-	0xe7, 0xbb, 0x74, 0x1e, 0x5c, 0xbb, 0x34, 0xb8, 0x25, 0x73, 0x74, 0x61, 0xfe, 0x01, 0xcd, 0xd9,
-	0x4e, 0x79, 0x2a, 0x54, 0xcc, 0xde, 0x3e, 0x72, 0x8c, 0xa0, 0xf2, 0x0f, 0x4b, 0xb3, 0x87, 0x56,
-	0xa7, 0xfa, 0x17, 0xbf, 0x81, 0x93, 0x8f, 0xe1, 0x7a, 0xc7, 0xb2, 0x47, 0xd4, 0xa4, 0xb9, 0x30,
-	0x09, 0xb4, 0x27, 0x2c, 0xaf, 0xbb, 0x4a, 0x69, 0xb8, 0x8d, 0x18, 0xee, 0x40, 0x4d, 0xaa, 0x50,
-	0xa8, 0xdb, 0x43, 0xfd, 0x41, 0xe3, 0x4b, 0x38, 0x65, 0xdb, 0xa5, 0xce, 0x94, 0xb3, 0xcc, 0x5e,
-	0x99, 0x3f, 0x41, 0x6b, 0xc2, 0xd4, 0xbb, 0x1d, 0x13, 0x29, 0x65, 0x72, 0xb7, 0x56, 0xba, 0xdd,
-	0xbf, 0x5a, 0xee, 0x2d, 0x72, 0x61, 0xfe, 0x08, 0x68, 0xc2, 0xd4, 0x4d, 0x2c, 0x15, 0x17, 0xe9,
-	0x35, 0x17, 0xda, 0xf3, 0xab, 0x51, 0xcd, 0x2e, 0xb4, 0x32, 0xab, 0x6c, 0x2c, 0x87, 0x7d, 0x52,
-	0xb8, 0x05, 0xe5, 0x78, 0xb9, 0x47, 0xca, 0xf1, 0xd2, 0xfc, 0x01, 0xda, 0x4f, 0xc4, 0x68, 0xcd,
-	0x25, 0xfb, 0x0a, 0xf9, 0x1d, 0xd0, 0xd1, 0x3c, 0x57, 0xa9, 0x62, 0x12, 0x77, 0xa1, 0x21, 0x9e,
-	0x64, 0x06, 0x37, 0xe9, 0x71, 0xc8, 0xdc, 0xc2, 0x79, 0x51, 0x95, 0xf0, 0xad, 0x64, 0x78, 0x00,
-	0x67, 0x79, 0x5e, 0xe3, 0x95, 0x5e, 0x63, 0x60, 0x14, 0x6f, 0xfb, 0xb9, 0x3b, 0x2d, 0x40, 0xfc,
-	0x16, 0x6a, 0xab, 0x50, 0x06, 0x1b, 0x2e, 0xf2, 0xdb, 0xae, 0xd1, 0xb3, 0x55, 0x28, 0xef, 0xb8,
-	0x28, 0xa6, 0xac, 0x14, 0x53, 0x0e, 0x3e, 0x1c, 0x6d, 0x09, 0x6f, 0x97, 0x24, 0x5c, 0x28, 0x3c,
-	0x86, 0x1a, 0x65, 0x51, 0x2c, 0x15, 0x13, 0xd8, 0x78, 0x69, 0x47, 0x74, 0x5e, 0xcc, 0x98, 0xaf,
-	0x7a, 0xa5, 0x5f, 0x4a, 0x57, 0x2e, 0x98, 0x5c, 0x44, 0xd6, 0x2a, 0x4d, 0x98, 0x58, 0xb3, 0x65,
-	0xc4, 0x84, 0xf5, 0x18, 0x3e, 0x88, 0x78, 0x51, 0xd4, 0xe9, 0xb5, 0xf6, 0xf7, 0xcf, 0x51, 0xac,
-	0x56, 0xbb, 0x07, 0x6b, 0xc1, 0x37, 0xfd, 0x23, 0xb4, 0x9f, 0xa3, 0xf9, 0x7a, 0x93, 0x7d, 0x8d,
-	0x3e, 0xe4, 0xbb, 0xf2, 0xb7, 0xcf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xad, 0x9f, 0x80, 0x68, 0x4f,
-	0x05, 0x00, 0x00,
+	0x10, 0xc6, 0x8f, 0x7f, 0x09, 0x0c, 0x04, 0xf6, 0x36, 0xb9, 0x94, 0x43, 0xaa, 0x4a, 0xad, 0xaa,
+	0xa2, 0x6f, 0xa0, 0xa5, 0x55, 0xd5, 0x77, 0x15, 0x81, 0x0d, 0xb1, 0x20, 0x36, 0xb7, 0x36, 0xa7,
+	0xa3, 0x2f, 0x6a, 0x39, 0xb0, 0x18, 0xeb, 0xc0, 0xeb, 0x7a, 0x97, 0xd3, 0xb9, 0x1f, 0xa4, 0xdf,
+	0xb3, 0xdf, 0xa0, 0x5a, 0x1b, 0x13, 0x92, 0x6b, 0x5e, 0xb1, 0xcf, 0xcc, 0x6f, 0x9e, 0x9d, 0x81,
+	0x65, 0xe0, 0x6d, 0xc8, 0x58, 0xd4, 0x5b, 0x6e, 0x5c, 0x3f, 0x58, 0xf2, 0x15, 0x73, 0xc4, 0xc6,
+	0xdf, 0x75, 0xc3, 0x88, 0x4b, 0x8e, 0xcf, 0x92, 0x0f, 0xd1, 0x6a, 0x3d, 0x43, 0xd8, 0x27, 0x16,
+	0xc8, 0x94, 0x69, 0x5d, 0x26, 0xb9, 0x30, 0xe2, 0x21, 0x17, 0xee, 0xf6, 0x10, 0xfc, 0xc6, 0xe3,
+	0xdc, 0xdb, 0xb2, 0x5e, 0xa2, 0x1e, 0xf6, 0xeb, 0x9e, 0xf4, 0x77, 0x4c, 0x48, 0x77, 0x17, 0xa6,
+	0x80, 0xf6, 0x4f, 0x09, 0xd0, 0x30, 0xf3, 0xbb, 0x67, 0x42, 0xb8, 0x1e, 0xc3, 0x3f, 0x41, 0x51,
+	0xc6, 0x21, 0x6b, 0xe6, 0xda, 0xb9, 0x4e, 0xbd, 0xff, 0x75, 0x8a, 0x8a, 0xee, 0x73, 0xae, 0x6b,
+	0xc7, 0x21, 0xa3, 0x09, 0x8a, 0x7f, 0x83, 0xca, 0xd1, 0xba, 0x99, 0x6f, 0xe7, 0x3a, 0xd5, 0x7e,
+	0xab, 0x9b, 0x5e, 0xde, 0xcd, 0x2e, 0xef, 0xda, 0x19, 0x41, 0x1f, 0x61, 0xdc, 0x84, 0xf3, 0xd0,
+	0x8d, 0xb7, 0xdc, 0x5d, 0x35, 0x0b, 0xed, 0x5c, 0xa7, 0x46, 0x33, 0x89, 0x31, 0x14, 0xe5, 0x67,
+	0x7f, 0xd5, 0x2c, 0xb6, 0x73, 0x9d, 0x0a, 0x4d, 0xce, 0xb8, 0x0f, 0xe5, 0x6c, 0xc4, 0x66, 0x29,
+	0xb9, 0xe6, 0x3a, 0x6b, 0xcf, 0xf2, 0xbd, 0x80, 0xad, 0x66, 0x87, 0x2c, 0x3d, 0x72, 0xf8, 0x77,
+	0x68, 0x3c, 0xfb, 0xca, 0x9a, 0x67, 0x4f, 0x4b, 0x8f, 0x93, 0x11, 0x95, 0xa5, 0xf5, 0xe5, 0x13,
+	0xad, 0xfd, 0x9b, 0x87, 0xa2, 0x9a, 0x15, 0x5f, 0x40, 0x65, 0x6e, 0x8c, 0xc8, 0xad, 0x6e, 0x90,
+	0x11, 0x7a, 0x85, 0x6b, 0x50, 0xa6, 0x64, 0xac, 0x5b, 0x36, 0xa1, 0x28, 0x87, 0xeb, 0x00, 0x99,
+	0x22, 0x23, 0x94, 0xc7, 0x65, 0x28, 0xea, 0x86, 0x6e, 0xa3, 0x02, 0xae, 0x40, 0x89, 0x92, 0xc1,
+	0x68, 0x81, 0x8a, 0xb8, 0x01, 0x55, 0x9b, 0x0e, 0x0c, 0x6b, 0x30, 0xb4, 0x75, 0xd3, 0x40, 0x25,
+	0x65, 0x39, 0x34, 0xef, 0x67, 0x53, 0x62, 0x93, 0x11, 0x3a, 0x53, 0x28, 0xa1, 0xd4, 0xa4, 0xe8,
+	0x5c, 0x65, 0xc6, 0xc4, 0x76, 0x2c, 0x7b, 0x60, 0x13, 0x54, 0x56, 0x72, 0x36, 0xcf, 0x64, 0x45,
+	0xc9, 0x11, 0x99, 0x1e, 0x24, 0xe0, 0x2b, 0x40, 0xba, 0xf1, 0xde, 0x9c, 0x10, 0x67, 0x78, 0x37,
+	0xd0, 0x8d, 0xa1, 0x39, 0x22, 0xa8, 0x9a, 0x36, 0x68, 0xcd, 0x4c, 0xc3, 0x22, 0xe8, 0x02, 0x5f,
+	0x03, 0x3e, 0x1a, 0x3a, 0x37, 0x0b, 0x87, 0x0e, 0x8c, 0x31, 0x41, 0x75, 0x55, 0xab, 0xe2, 0xef,
+	0xe6, 0x84, 0x2e, 0x1c, 0x4a, 0xac, 0xf9, 0xd4, 0x46, 0x0d, 0x15, 0x4d, 0x23, 0x29, 0x6f, 0x90,
+	0x0f, 0x36, 0x42, 0xf8, 0x0d, 0xbc, 0x3e, 0x8d, 0x0e, 0xa7, 0xa6, 0x45, 0xd0, 0x6b, 0xd5, 0xcd,
+	0x84, 0x90, 0xd9, 0x60, 0xaa, 0xbf, 0x27, 0x08, 0xe3, 0xaf, 0xe0, 0x52, 0x39, 0xde, 0xe9, 0x96,
+	0x6d, 0xd2, 0x85, 0x73, 0x6b, 0x52, 0x67, 0x42, 0x16, 0xe8, 0x12, 0x5f, 0x42, 0xe3, 0x38, 0x84,
+	0x33, 0xbc, 0x9b, 0x1b, 0x13, 0x74, 0xa5, 0x82, 0x63, 0xf2, 0x34, 0xf8, 0x46, 0xfb, 0x15, 0x6a,
+	0xb3, 0xbd, 0xb4, 0xa4, 0x2b, 0x99, 0x1e, 0xac, 0x39, 0x46, 0x50, 0xf8, 0xc8, 0xe2, 0xe4, 0x49,
+	0x56, 0xa8, 0x3a, 0xe2, 0x2b, 0x28, 0x7d, 0x72, 0xb7, 0x7b, 0x96, 0x3c, 0xb7, 0x1a, 0x4d, 0x85,
+	0xf6, 0x27, 0x40, 0x52, 0x34, 0xdc, 0xec, 0x83, 0x8f, 0xff, 0x53, 0x85, 0xa1, 0xb8, 0x72, 0xa5,
+	0x7b, 0x28, 0x4a, 0xce, 0xf8, 0x1a, 0xce, 0xf8, 0x7a, 0x2d, 0x98, 0x4c, 0x5e, 0x60, 0x91, 0x1e,
+	0x94, 0x62, 0x85, 0xff, 0x37, 0x4b, 0x1e, 0x60, 0x91, 0x26, 0x67, 0x8d, 0x40, 0x63, 0xcc, 0xd2,
+	0xbe, 0x6e, 0x62, 0xea, 0x06, 0x1e, 0xc3, 0x2d, 0x28, 0x0b, 0xe9, 0x46, 0x72, 0x72, 0xbc, 0xe9,
+	0xa8, 0x95, 0x35, 0x0b, 0x56, 0x2a, 0x93, 0x4f, 0x32, 0x07, 0xa5, 0x7d, 0x0f, 0xf5, 0x31, 0x93,
+	0xef, 0xf6, 0x2c, 0x8a, 0x29, 0x13, 0xfb, 0xad, 0x54, 0xe3, 0xfc, 0xa5, 0xe4, 0xc1, 0x22, 0x15,
+	0xda, 0x77, 0x80, 0xc6, 0x4c, 0xde, 0xf9, 0x42, 0xf2, 0x28, 0xbe, 0xe5, 0x91, 0xf2, 0xfc, 0x62,
+	0x28, 0xad, 0x0d, 0xf5, 0xc4, 0x2a, 0x69, 0xcb, 0x60, 0x9f, 0x25, 0xae, 0x43, 0xde, 0x5f, 0x1d,
+	0x90, 0xbc, 0xbf, 0xd2, 0xbe, 0x85, 0xc6, 0x23, 0x31, 0xdc, 0x72, 0xc1, 0xbe, 0x40, 0x7e, 0x01,
+	0x74, 0xd2, 0xcf, 0x4d, 0x2c, 0x99, 0xc0, 0x6d, 0xa8, 0x46, 0x8f, 0x32, 0x81, 0x6b, 0xf4, 0x34,
+	0xa4, 0x05, 0x70, 0x91, 0x55, 0x85, 0x3c, 0x10, 0x0c, 0xf7, 0xe1, 0x3c, 0xcd, 0x2b, 0xbc, 0xd0,
+	0xa9, 0xf6, 0x9b, 0xd9, 0xbf, 0xec, 0xb9, 0x3b, 0xcd, 0x40, 0xfc, 0x16, 0xca, 0x1b, 0x57, 0x38,
+	0x3b, 0x1e, 0xa5, 0xbf, 0x66, 0x99, 0x9e, 0x6f, 0x5c, 0x71, 0xcf, 0xa3, 0xac, 0xcb, 0x42, 0xd6,
+	0x65, 0xff, 0xc3, 0xc9, 0xbe, 0xb2, 0xf6, 0x61, 0xc8, 0x23, 0x89, 0x47, 0x50, 0xa6, 0xcc, 0xf3,
+	0x85, 0x64, 0x11, 0x6e, 0xbe, 0xb4, 0xad, 0x5a, 0x2f, 0x66, 0xb4, 0x57, 0x9d, 0xdc, 0x8f, 0xb9,
+	0x1b, 0x13, 0x34, 0x1e, 0x79, 0xdd, 0x4d, 0x1c, 0xb2, 0x68, 0xcb, 0x56, 0x1e, 0x8b, 0xba, 0x6b,
+	0xf7, 0x21, 0xf2, 0x97, 0x59, 0x9d, 0x5a, 0xb0, 0x7f, 0xfc, 0xe0, 0xf9, 0x72, 0xb3, 0x7f, 0xe8,
+	0x2e, 0xf9, 0xae, 0x77, 0x82, 0xf6, 0x52, 0x34, 0x5d, 0xb4, 0xa2, 0xa7, 0xd0, 0x87, 0x74, 0x6b,
+	0xff, 0xfc, 0xdf, 0x00, 0x54, 0x57, 0xec, 0x2e, 0xd9, 0x05, 0x00, 0x00,
 }
@@ -25,6 +25,7 @@ import (
 type strArgs struct {
 	Function string
 	Args     []string
+	IsInit   bool
 }
 
 // UnmarshalJSON converts the string-based REST/JSON input to
@@ -40,5 +41,6 @@ func (c *ChaincodeInput) UnmarshalJSON(b []byte) error {
 		allArgs = append([]string{sa.Function}, sa.Args...)
 	}
 	c.Args = util.ToChaincodeArgs(allArgs...)
+	c.IsInit = sa.IsInit
 	return nil
 }
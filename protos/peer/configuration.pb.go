@@ -57,25 +57,70 @@ func (m *AnchorPeer) GetPort() int32 {
 	return 0
 }
 
+// ACLs provides mappings for resources in a channel. APIResource encapsulates
+// reference to a policy used to determine ACL for a resource
+type ACLs struct {
+	Acls map[string]*APIResource `protobuf:"bytes,1,rep,name=acls" json:"acls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ACLs) Reset()                    { *m = ACLs{} }
+func (m *ACLs) String() string            { return proto.CompactTextString(m) }
+func (*ACLs) ProtoMessage()               {}
+func (*ACLs) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{2} }
+
+func (m *ACLs) GetAcls() map[string]*APIResource {
+	if m != nil {
+		return m.Acls
+	}
+	return nil
+}
+
+// APIResource represents an API resource in the peer whose ACL
+// is determined by the policy_ref field
+type APIResource struct {
+	PolicyRef string `protobuf:"bytes,1,opt,name=policy_ref,json=policyRef" json:"policy_ref,omitempty"`
+}
+
+func (m *APIResource) Reset()                    { *m = APIResource{} }
+func (m *APIResource) String() string            { return proto.CompactTextString(m) }
+func (*APIResource) ProtoMessage()               {}
+func (*APIResource) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{3} }
+
+func (m *APIResource) GetPolicyRef() string {
+	if m != nil {
+		return m.PolicyRef
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*AnchorPeers)(nil), "protos.AnchorPeers")
 	proto.RegisterType((*AnchorPeer)(nil), "protos.AnchorPeer")
+	proto.RegisterType((*ACLs)(nil), "protos.ACLs")
+	proto.RegisterType((*APIResource)(nil), "protos.APIResource")
 }
 
 func init() { proto.RegisterFile("peer/configuration.proto", fileDescriptor4) }
 
 var fileDescriptor4 = []byte{
-	// 192 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x4c, 0x8f, 0x3d, 0x8f, 0xc3, 0x20,
-	0x0c, 0x86, 0xc5, 0x7d, 0x49, 0x47, 0x6e, 0x62, 0x62, 0x8c, 0x32, 0xe5, 0x16, 0x90, 0xee, 0xe3,
-	0x07, 0xb4, 0xea, 0xde, 0x2a, 0x63, 0x97, 0x8a, 0x50, 0x02, 0x48, 0x6d, 0x8c, 0x0c, 0x19, 0xfa,
-	0xef, 0x2b, 0x40, 0x55, 0x3a, 0xf1, 0x62, 0x3f, 0x8f, 0x6c, 0x53, 0x1e, 0x8c, 0x41, 0xa9, 0x61,
-	0x9e, 0xbc, 0x5d, 0x50, 0x25, 0x0f, 0xb3, 0x08, 0x08, 0x09, 0xd8, 0x47, 0x79, 0x62, 0xb7, 0xa3,
-	0xcd, 0x66, 0xd6, 0x0e, 0xf0, 0x60, 0x0c, 0x46, 0xf6, 0x4f, 0xbf, 0x54, 0xf9, 0x9e, 0xb2, 0x19,
-	0x39, 0x69, 0x5f, 0xfb, 0xe6, 0x87, 0x55, 0x29, 0x8a, 0x15, 0x1d, 0x1a, 0xb5, 0x6a, 0xdd, 0x1f,
-	0xa5, 0x6b, 0x8b, 0x31, 0xfa, 0xe6, 0x20, 0x26, 0x4e, 0x5a, 0xd2, 0x7f, 0x0e, 0x25, 0xe7, 0x5a,
-	0x00, 0x4c, 0xfc, 0xa5, 0x25, 0xfd, 0xfb, 0x50, 0xf2, 0x76, 0x4f, 0x3b, 0x40, 0x2b, 0xdc, 0x2d,
-	0x18, 0xbc, 0x98, 0xb3, 0x35, 0x28, 0x26, 0x35, 0xa2, 0xd7, 0x8f, 0x71, 0x79, 0x87, 0xe3, 0xb7,
-	0xf5, 0xc9, 0x2d, 0xa3, 0xd0, 0x70, 0x95, 0x4f, 0xa8, 0xac, 0xa8, 0xac, 0xa8, 0xcc, 0xe8, 0x58,
-	0x8f, 0xfa, 0xbd, 0x07, 0x00, 0x00, 0xff, 0xff, 0x6d, 0xf2, 0x7b, 0x6f, 0xf7, 0x00, 0x00, 0x00,
+	// 290 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x4c, 0x91, 0xdd, 0x4b, 0xc3, 0x30,
+	0x14, 0xc5, 0xe9, 0x3e, 0x84, 0xdd, 0xfa, 0x20, 0x11, 0xa4, 0x08, 0xc2, 0xe8, 0xd3, 0x26, 0x92,
+	0xc2, 0x54, 0x10, 0xdf, 0xe6, 0xf4, 0x41, 0x18, 0x38, 0xf2, 0xe8, 0xcb, 0xc8, 0xe2, 0xed, 0x07,
+	0xd6, 0xa6, 0xdc, 0xa4, 0x42, 0xdf, 0xfc, 0xd3, 0xa5, 0xc9, 0xb6, 0xee, 0x29, 0x27, 0xe7, 0xfe,
+	0xce, 0x3d, 0x90, 0x40, 0x54, 0x23, 0x52, 0xa2, 0x74, 0x95, 0x16, 0x59, 0x43, 0xd2, 0x16, 0xba,
+	0xe2, 0x35, 0x69, 0xab, 0xd9, 0x99, 0x3b, 0x4c, 0xfc, 0x0a, 0xe1, 0xb2, 0x52, 0xb9, 0xa6, 0x0d,
+	0x22, 0x19, 0xf6, 0x08, 0xe7, 0xd2, 0x5d, 0xb7, 0x5d, 0xd2, 0x44, 0xc1, 0x74, 0x38, 0x0b, 0x17,
+	0xcc, 0x87, 0x0c, 0xef, 0x51, 0x11, 0xca, 0x3e, 0x16, 0x3f, 0x00, 0xf4, 0x23, 0xc6, 0x60, 0x94,
+	0x6b, 0x63, 0xa3, 0x60, 0x1a, 0xcc, 0x26, 0xc2, 0xe9, 0xce, 0xab, 0x35, 0xd9, 0x68, 0x30, 0x0d,
+	0x66, 0x63, 0xe1, 0x74, 0xfc, 0x17, 0xc0, 0x68, 0xb9, 0x5a, 0x1b, 0x76, 0x0b, 0x23, 0xa9, 0xca,
+	0x43, 0xdb, 0xd5, 0xb1, 0x6d, 0xb5, 0x36, 0x7c, 0xa9, 0x4a, 0xf3, 0x56, 0x59, 0x6a, 0x85, 0x63,
+	0xae, 0xd7, 0x30, 0x39, 0x5a, 0xec, 0x02, 0x86, 0xdf, 0xd8, 0xee, 0x8b, 0x3a, 0xc9, 0xe6, 0x30,
+	0xfe, 0x95, 0x65, 0x83, 0xae, 0x28, 0x5c, 0x5c, 0x1e, 0x77, 0x6d, 0xde, 0x05, 0x1a, 0xdd, 0x90,
+	0x42, 0xe1, 0x89, 0xe7, 0xc1, 0x53, 0x10, 0xdf, 0x41, 0x78, 0x32, 0x61, 0x37, 0x00, 0xb5, 0x2e,
+	0x0b, 0xd5, 0x6e, 0x09, 0xd3, 0xfd, 0xda, 0x89, 0x77, 0x04, 0xa6, 0x2f, 0x1f, 0x10, 0x6b, 0xca,
+	0x78, 0xde, 0xd6, 0x48, 0x25, 0x7e, 0x65, 0x48, 0x3c, 0x95, 0x3b, 0x2a, 0xd4, 0xa1, 0xa5, 0x7b,
+	0xb4, 0xcf, 0x79, 0x56, 0xd8, 0xbc, 0xd9, 0x71, 0xa5, 0x7f, 0x92, 0x13, 0x34, 0xf1, 0x68, 0xe2,
+	0xd1, 0xa4, 0x43, 0x77, 0xfe, 0x17, 0xee, 0xff, 0x07, 0x00, 0x7e, 0xdb, 0xc0, 0xcf, 0xa8, 0x01,
+	0x00, 0x00,
 }
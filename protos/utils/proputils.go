@@ -425,6 +425,18 @@ func CreateInstallProposalFromCDS(ccpack proto.Message, creator []byte) (*peer.P
 	return createProposalFromCDS("", ccpack, creator, nil, nil, nil, "install")
 }
 
+// CreateInstallProposalFromPackage returns a install proposal given a serialized identity
+// and the bytes of a chaincode package which is not a proto.Message, such as a tar package
+func CreateInstallProposalFromPackage(ccpack []byte, creator []byte) (*peer.Proposal, string, error) {
+	lsccSpec := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			Type:        peer.ChaincodeSpec_GOLANG,
+			ChaincodeId: &peer.ChaincodeID{Name: "lscc"},
+			Input:       &peer.ChaincodeInput{Args: [][]byte{[]byte("install"), ccpack}}}}
+
+	return CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, "", lsccSpec, creator)
+}
+
 // CreateDeployProposalFromCDS returns a deploy proposal given a serialized identity and a ChaincodeDeploymentSpec
 func CreateDeployProposalFromCDS(chainID string, cds *peer.ChaincodeDeploymentSpec, creator []byte, policy []byte, escc []byte, vscc []byte) (*peer.Proposal, string, error) {
 	return createProposalFromCDS(chainID, cds, creator, policy, escc, vscc, "deploy")